@@ -16,14 +16,17 @@ package command
 import (
 	"context"
 	"fmt"
-	"io/ioutil"
+	"os"
 	"path/filepath"
 	"strings"
 
 	"github.com/spf13/cobra"
 
 	ackgenerate "github.com/aws-controllers-k8s/code-generator/pkg/generate/ack"
+	ackgenconfig "github.com/aws-controllers-k8s/code-generator/pkg/generate/config"
 	ackmetadata "github.com/aws-controllers-k8s/code-generator/pkg/metadata"
+	ackmodel "github.com/aws-controllers-k8s/code-generator/pkg/model"
+	"github.com/aws-controllers-k8s/code-generator/pkg/model/multiversion"
 	"github.com/aws-controllers-k8s/code-generator/pkg/util"
 )
 
@@ -39,6 +42,7 @@ var (
 	optGenVersion    string
 	optAPIsInputPath string
 	apisVersionPath  string
+	optBumpVersion   string
 )
 
 // apiCmd is the command that generates service API types
@@ -53,12 +57,24 @@ func init() {
 	apisCmd.PersistentFlags().StringVar(
 		&optGenVersion, "version", "v1alpha1", "the resource API Version to use when generating API infrastructure and type definitions",
 	)
+	apisCmd.PersistentFlags().StringVar(
+		&optBumpVersion, "bump-version", "", "the new resource API Version, e.g. v1beta1, to add to the service metadata file and generate, along with the conversion functions from the previous API Versions",
+	)
 	rootCmd.AddCommand(apisCmd)
 }
 
 // saveGeneratedMetadata saves the parameters used to generate APIs and checksum
 // of the generated code.
 func saveGeneratedMetadata(cmd *cobra.Command, args []string) error {
+	// Conversion functions are written into the API version directories and
+	// must therefore be generated before the directory checksum is computed.
+	if err := generateConversionFunctions(strings.ToLower(args[0])); err != nil {
+		return fmt.Errorf("cannot generate conversion functions: %v", err)
+	}
+	if !writesOutput() {
+		return nil
+	}
+
 	err := ackmetadata.CreateGenerationMetadata(
 		optGenVersion,
 		filepath.Join(optOutputPath, "apis"),
@@ -91,6 +107,11 @@ func generateAPIs(cmd *cobra.Command, args []string) error {
 	if optOutputPath == "" {
 		optOutputPath = filepath.Join(optServicesDir, svcAlias)
 	}
+	if optBumpVersion != "" {
+		if err := bumpAPIVersion(); err != nil {
+			return err
+		}
+	}
 	ctx, cancel := contextWithSigterm(context.Background())
 	defer cancel()
 	if err := ensureSDKRepo(ctx, optCacheDir, optRefreshCache); err != nil {
@@ -100,28 +121,223 @@ func generateAPIs(cmd *cobra.Command, args []string) error {
 	if err != nil {
 		return err
 	}
-	ts, err := ackgenerate.APIs(m, optTemplateDirs)
+	ts, err := ackgenerate.APIs(m, templateDirs())
 	if err != nil {
 		return err
 	}
 
-	if err = ts.Execute(); err != nil {
+	if err = executeTemplates(ts); err != nil {
 		return err
 	}
 
 	apisVersionPath = filepath.Join(optOutputPath, "apis", optGenVersion)
-	for path, contents := range ts.Executed() {
-		if optDryRun {
-			fmt.Printf("============================= %s ======================================\n", path)
-			fmt.Println(strings.TrimSpace(contents.String()))
+	if err = writeGeneratedFiles(apisVersionPath, ts.Executed()); err != nil {
+		return err
+	}
+	if err = generateCRDVersionPatches(m); err != nil {
+		return fmt.Errorf("cannot generate CRD version patches: %v", err)
+	}
+	return runPlugins(ctx, m, "apis")
+}
+
+// generateCRDVersionPatches generates the patches of the custom resource
+// definitions setting which of the API versions listed in the service
+// metadata file are served and deprecated. Nothing is generated until an API
+// version is deprecated or not served anymore.
+func generateCRDVersionPatches(m *ackmodel.Model) error {
+	if optMetadataConfigPath == "" {
+		return nil
+	}
+	metadata, err := ackmetadata.NewServiceMetadata(optMetadataConfigPath)
+	if err != nil {
+		return err
+	}
+	if !metadata.HasVersionsSunset() {
+		return nil
+	}
+	ts, err := ackgenerate.CRDVersionPatches(
+		m, metadata.GetCRDVersions(), templateDirs(),
+	)
+	if err != nil {
+		return err
+	}
+	if err = executeTemplates(ts); err != nil {
+		return err
+	}
+	return writeGeneratedFiles(optOutputPath, ts.Executed())
+}
+
+// bumpAPIVersion adds the API version supplied with --bump-version to the
+// service metadata file and makes it the API version being generated. The
+// previous API version keeps the generator config it was last generated with,
+// so the conversion functions generated afterwards account for the fields
+// renamed since.
+func bumpAPIVersion() error {
+	if optMetadataConfigPath == "" {
+		return fmt.Errorf("--bump-version requires --metadata-config-path")
+	}
+	if !writesOutput() {
+		return fmt.Errorf("--bump-version cannot be combined with --dry-run or --check")
+	}
+	metadata, err := ackmetadata.NewServiceMetadata(optMetadataConfigPath)
+	if err != nil {
+		return err
+	}
+	previousVersion, err := metadata.BumpAPIVersion(optBumpVersion)
+	if err != nil {
+		return err
+	}
+	previousConfigPath := filepath.Join(
+		optOutputPath, "apis", previousVersion, "generator.yaml",
+	)
+	if _, err = os.Stat(previousConfigPath); err != nil {
+		return fmt.Errorf(
+			"API version %s must be generated before it is bumped: %v",
+			previousVersion, err,
+		)
+	}
+	if err = metadata.Save(optMetadataConfigPath); err != nil {
+		return fmt.Errorf("cannot update %s: %v", optMetadataConfigPath, err)
+	}
+	fmt.Printf("bumped API version %s to %s\n", previousVersion, optBumpVersion)
+	optGenVersion = optBumpVersion
+	return nil
+}
+
+// generateConversionFunctions generates the conversion functions between
+// every available API version listed in the service metadata file and the
+// conversion hub, which is the hub_version of the generator config or else
+// the API version being generated. Nothing is generated when the service only
+// has a single available API version.
+func generateConversionFunctions(svcAlias string) error {
+	// The models of the other API versions can only be read from the SDK
+	if optMetadataConfigPath == "" || optModelFile != "" {
+		return nil
+	}
+	metadata, err := ackmetadata.NewServiceMetadata(optMetadataConfigPath)
+	if err != nil {
+		return err
+	}
+	availableVersions := metadata.GetAvailableAPIVersions()
+	if len(availableVersions) < 2 {
+		return nil
+	}
+	if !util.InStrings(optGenVersion, availableVersions) {
+		return fmt.Errorf(
+			"API version %s is not listed as available in %s",
+			optGenVersion, optMetadataConfigPath,
+		)
+	}
+	cfg, err := ackgenconfig.New(optGeneratorConfigPath, ackgenerate.DefaultConfig)
+	if err != nil {
+		return err
+	}
+	hubVersion := cfg.GetHubVersion(optGenVersion)
+	if !util.InStrings(hubVersion, availableVersions) {
+		return fmt.Errorf(
+			"hub API version %s is not listed as available in %s",
+			hubVersion, optMetadataConfigPath,
+		)
+	}
+
+	hubSDKVersion, err := getSDKVersion("")
+	if err != nil {
+		return err
+	}
+	apisPath := filepath.Join(optOutputPath, "apis")
+	apiInfos := map[string]ackmetadata.APIInfo{}
+	for _, version := range metadata.APIVersions {
+		apiInfo := ackmetadata.APIInfo{
+			Status:     version.Status,
+			APIVersion: version.APIVersion,
+		}
+		if version.APIVersion == optGenVersion {
+			apiInfo.AWSSDKVersion = hubSDKVersion
+			apiInfo.GeneratorConfigPath = optGeneratorConfigPath
+		} else if version.Status == ackmetadata.APIStatusAvailable {
+			generationMetadata, err := ackmetadata.LoadGenerationMetadata(
+				apisPath, version.APIVersion,
+			)
+			if err != nil {
+				return fmt.Errorf(
+					"cannot load generation metadata for API version %s: %v",
+					version.APIVersion, err,
+				)
+			}
+			apiInfo.AWSSDKVersion = generationMetadata.AWSSDKGoVersion
+			if apiInfo.AWSSDKVersion == "" {
+				apiInfo.AWSSDKVersion = hubSDKVersion
+			}
+			apiInfo.GeneratorConfigPath = filepath.Join(
+				apisPath, version.APIVersion, "generator.yaml",
+			)
+		}
+		apiInfo.AWSSDKVersion = ensureSemverPrefix(apiInfo.AWSSDKVersion)
+		apiInfos[version.APIVersion] = apiInfo
+	}
+
+	// Each API version may have been generated from a different aws-sdk-go
+	// version. Make sure all of them are present in the local clone.
+	ctx, cancel := contextWithSigterm(context.Background())
+	defer cancel()
+	repo, err := util.LoadRepository(sdkDir)
+	if err != nil {
+		return fmt.Errorf(
+			"generating conversion functions requires a git repository of the SDK: %v", err,
+		)
+	}
+	for _, apiInfo := range apiInfos {
+		if apiInfo.Status != ackmetadata.APIStatusAvailable ||
+			util.HasRepositoryTag(repo, apiInfo.AWSSDKVersion) {
 			continue
 		}
-		outPath := filepath.Join(apisVersionPath, path)
-		outDir := filepath.Dir(outPath)
-		if _, err := ensureDir(outDir); err != nil {
-			return err
+		if err := fetchSDKRepoTag(ctx, apiInfo.AWSSDKVersion); err != nil {
+			return fmt.Errorf("cannot fetch tag %s: %v", apiInfo.AWSSDKVersion, err)
+		}
+	}
+
+	mgr, err := multiversion.NewAPIVersionManager(
+		sdkDir,
+		optMetadataConfigPath,
+		svcAlias,
+		hubVersion,
+		apiInfos,
+		ackgenerate.DefaultConfig,
+	)
+	if err != nil {
+		return err
+	}
+	ts, err := ackgenerate.ConversionFunctions(mgr, templateDirs())
+	if err != nil {
+		return err
+	}
+	if err = executeTemplates(ts); err != nil {
+		return err
+	}
+
+	if err = writeGeneratedFiles(apisPath, ts.Executed()); err != nil {
+		return err
+	}
+	if !writesOutput() {
+		return nil
+	}
+
+	// The directories of the other API versions changed, so refresh their
+	// checksums. The checksum of the API version being generated is computed
+	// afterwards.
+	for _, version := range append([]string{hubVersion}, mgr.GetSpokeVersions()...) {
+		if version == optGenVersion {
+			continue
 		}
-		if err = ioutil.WriteFile(outPath, contents.Bytes(), 0666); err != nil {
+		apiInfo := apiInfos[version]
+		err = ackmetadata.CreateGenerationMetadata(
+			version,
+			apisPath,
+			ackmetadata.UpdateReasonConversionFunctionsGeneration,
+			apiInfo.AWSSDKVersion,
+			apiInfo.GeneratorConfigPath,
+		)
+		if err != nil {
 			return err
 		}
 	}
@@ -0,0 +1,291 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package command
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/ghodss/yaml"
+	"github.com/spf13/cobra"
+
+	"github.com/aws-controllers-k8s/code-generator/pkg/util"
+)
+
+var (
+	optBatchManifestPath string
+	optBatchConcurrency  int
+	optBatchAPIVersion   string
+)
+
+// batchCommands are the ack-generate commands that can be run for multiple
+// services at once
+var batchCommands = []string{"apis", "controller"}
+
+var batchCmd = &cobra.Command{
+	Use:   "batch <command> [<service> ...]",
+	Short: "Runs the apis or controller command for multiple services concurrently",
+	Long: `Runs the apis or controller command for multiple services concurrently,
+sharing a single copy of the aws-sdk-go repository. Services are specified
+either as arguments or in a manifest file (--manifest) of the form:
+
+  services:
+  - name: ecr
+    output: ../ecr-controller
+    generator_config_path: ../ecr-controller/generator.yaml
+    metadata_config_path: ../ecr-controller/metadata.yaml
+    api_version: v1alpha1
+
+Unless specified, a service's output directory defaults to the service's
+directory under --services-dir, and its generator and metadata config paths
+default to the generator.yaml and metadata.yaml files of the output directory,
+if they exist.
+
+All services are generated from the same SDK version, which must be supplied
+with --aws-sdk-go-version unless --aws-sdk-go-path is used.`,
+	RunE: generateBatch,
+}
+
+func init() {
+	batchCmd.PersistentFlags().StringVar(
+		&optBatchManifestPath, "manifest", "", "Path to a manifest file listing the services to generate",
+	)
+	batchCmd.PersistentFlags().IntVar(
+		&optBatchConcurrency, "concurrency", runtime.NumCPU(), "Maximum number of services to generate concurrently",
+	)
+	batchCmd.PersistentFlags().StringVar(
+		&optBatchAPIVersion, "version", "v1alpha1", "the resource API Version to use when running the apis command for services that do not specify one",
+	)
+	rootCmd.AddCommand(batchCmd)
+}
+
+// batchManifest lists the services to generate with the batch command
+type batchManifest struct {
+	Services []batchService `json:"services"`
+}
+
+// batchService contains the parameters used to generate a single service
+// with the batch command
+type batchService struct {
+	Name                string `json:"name"`
+	Output              string `json:"output,omitempty"`
+	GeneratorConfigPath string `json:"generator_config_path,omitempty"`
+	MetadataConfigPath  string `json:"metadata_config_path,omitempty"`
+	APIVersion          string `json:"api_version,omitempty"`
+}
+
+// generateBatch runs an ack-generate command for every requested service
+// concurrently. Each service is generated by a separate ack-generate process,
+// pointed at the SDK copy resolved once by this process.
+func generateBatch(cmd *cobra.Command, args []string) error {
+	if len(args) < 1 || !util.InStrings(args[0], batchCommands) {
+		return fmt.Errorf(
+			"please specify the command to run for each service, one of: %s",
+			strings.Join(batchCommands, ", "),
+		)
+	}
+	command := args[0]
+	services, err := batchServices(args[1:])
+	if err != nil {
+		return err
+	}
+	if len(services) == 0 {
+		return fmt.Errorf("please specify the services to generate as arguments or with --manifest")
+	}
+	if optAWSSDKGoPath == "" && optAWSSDKGoVersion == "" {
+		return fmt.Errorf("please specify the SDK version shared by all services with --aws-sdk-go-version")
+	}
+
+	ctx, cancel := contextWithSigterm(context.Background())
+	defer cancel()
+	// The output path is service specific and must not be used to look for
+	// vendored models
+	optOutputPath = ""
+	if err := ensureSDKRepo(ctx, optCacheDir, optRefreshCache); err != nil {
+		return err
+	}
+
+	executable, err := os.Executable()
+	if err != nil {
+		return err
+	}
+	concurrency := optBatchConcurrency
+	if concurrency < 1 {
+		concurrency = 1
+	}
+
+	var wg sync.WaitGroup
+	var mu sync.Mutex
+	sem := make(chan struct{}, concurrency)
+	failed := []string{}
+	for _, svc := range services {
+		wg.Add(1)
+		go func(svc batchService) {
+			defer wg.Done()
+			sem <- struct{}{}
+			defer func() { <-sem }()
+
+			var out bytes.Buffer
+			child := exec.CommandContext(ctx, executable, batchChildArgs(command, svc)...)
+			child.Stdout = &out
+			child.Stderr = &out
+			err := child.Run()
+
+			mu.Lock()
+			defer mu.Unlock()
+			if output := strings.TrimSpace(out.String()); output != "" {
+				for _, line := range strings.Split(output, "\n") {
+					fmt.Printf("[%s] %s\n", svc.Name, line)
+				}
+			}
+			if err != nil {
+				fmt.Printf("[%s] %s failed: %v\n", svc.Name, command, err)
+				failed = append(failed, svc.Name)
+			}
+		}(svc)
+	}
+	wg.Wait()
+
+	if len(failed) > 0 {
+		sort.Strings(failed)
+		return fmt.Errorf(
+			"%s failed for %d of %d services: %s",
+			command, len(failed), len(services), strings.Join(failed, ", "),
+		)
+	}
+	return nil
+}
+
+// batchServices returns the services to generate, from the command-line
+// arguments and the manifest file, with their defaults applied
+func batchServices(svcAliases []string) ([]batchService, error) {
+	services := []batchService{}
+	if optBatchManifestPath != "" {
+		b, err := ioutil.ReadFile(optBatchManifestPath)
+		if err != nil {
+			return nil, err
+		}
+		manifest := batchManifest{}
+		if err = yaml.Unmarshal(b, &manifest); err != nil {
+			return nil, fmt.Errorf("cannot parse manifest %s: %v", optBatchManifestPath, err)
+		}
+		// Relative paths in the manifest are relative to the manifest itself
+		manifestDir := filepath.Dir(optBatchManifestPath)
+		for _, svc := range manifest.Services {
+			for _, path := range []*string{
+				&svc.Output, &svc.GeneratorConfigPath, &svc.MetadataConfigPath,
+			} {
+				if *path != "" && !filepath.IsAbs(*path) {
+					*path = filepath.Join(manifestDir, *path)
+				}
+			}
+			services = append(services, svc)
+		}
+	}
+	for _, svcAlias := range svcAliases {
+		services = append(services, batchService{Name: svcAlias})
+	}
+
+	seen := map[string]bool{}
+	for x := range services {
+		svc := &services[x]
+		svc.Name = strings.ToLower(svc.Name)
+		if svc.Name == "" {
+			return nil, fmt.Errorf("service name missing in manifest %s", optBatchManifestPath)
+		}
+		if seen[svc.Name] {
+			return nil, fmt.Errorf("service %s specified more than once", svc.Name)
+		}
+		seen[svc.Name] = true
+		if svc.Output == "" {
+			svc.Output = filepath.Join(optServicesDir, svc.Name)
+		}
+		if svc.GeneratorConfigPath == "" {
+			if path := filepath.Join(svc.Output, "generator.yaml"); util.FileExists(path) {
+				svc.GeneratorConfigPath = path
+			}
+		}
+		if svc.MetadataConfigPath == "" {
+			if path := filepath.Join(svc.Output, "metadata.yaml"); util.FileExists(path) {
+				svc.MetadataConfigPath = path
+			}
+		}
+		if svc.APIVersion == "" {
+			svc.APIVersion = optBatchAPIVersion
+		}
+	}
+	return services, nil
+}
+
+// batchChildArgs returns the command-line arguments of the ack-generate
+// process generating a single service
+func batchChildArgs(command string, svc batchService) []string {
+	args := []string{
+		command, svc.Name,
+		"--aws-sdk-go-path", sdkDir,
+		"--model-format", optModelFormat,
+		"--output", svc.Output,
+		"--template-dirs", strings.Join(optTemplateDirs, ","),
+		"--log-format", optLogFormat,
+	}
+	for x := 0; x < optVerbosity; x++ {
+		args = append(args, "-v")
+	}
+	if optAWSSDKGoVersion != "" {
+		args = append(args, "--aws-sdk-go-version", optAWSSDKGoVersion)
+	}
+	if optAWSSDKGoRepoURL != "" {
+		args = append(args, "--aws-sdk-go-repo-url", optAWSSDKGoRepoURL)
+	}
+	if optAWSSDKGoArchive {
+		args = append(args, "--aws-sdk-go-archive")
+	}
+	if optTemplatesDir != "" {
+		args = append(args, "--templates-dir", optTemplatesDir)
+	}
+	if optExtraModelsDir != "" {
+		args = append(args, "--extra-models-dir", optExtraModelsDir)
+	}
+	if svc.GeneratorConfigPath != "" {
+		args = append(args, "--generator-config-path", svc.GeneratorConfigPath)
+	}
+	if svc.MetadataConfigPath != "" {
+		args = append(args, "--metadata-config-path", svc.MetadataConfigPath)
+	}
+	if optDryRun {
+		args = append(args, "--dry-run")
+	}
+	if optDiff {
+		args = append(args, "--diff")
+	}
+	if optCheck {
+		args = append(args, "--check")
+	}
+	if !optProgress {
+		args = append(args, "--progress=false")
+	}
+	if command == "apis" {
+		args = append(args, "--version", svc.APIVersion)
+	}
+	return args
+}
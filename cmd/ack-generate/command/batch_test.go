@@ -0,0 +1,71 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package command
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBatchChildArgs(t *testing.T) {
+	testCases := []struct {
+		name       string
+		repoURL    string
+		archive    bool
+		wantArgs   []string
+		noWantArgs []string
+	}{
+		{
+			name:       "SDK repository",
+			noWantArgs: []string{"--aws-sdk-go-repo-url", "--aws-sdk-go-archive"},
+		},
+		{
+			name:       "mirror",
+			repoURL:    "https://mirror.example.com/aws/aws-sdk-go.git",
+			wantArgs:   []string{"--aws-sdk-go-repo-url https://mirror.example.com/aws/aws-sdk-go.git"},
+			noWantArgs: []string{"--aws-sdk-go-archive"},
+		},
+		{
+			name:     "mirror archive",
+			repoURL:  "https://mirror.example.com/aws/aws-sdk-go",
+			archive:  true,
+			wantArgs: []string{"--aws-sdk-go-repo-url https://mirror.example.com/aws/aws-sdk-go", "--aws-sdk-go-archive"},
+		},
+	}
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			assert := assert.New(t)
+
+			defer func(repoURL string, archive bool) {
+				optAWSSDKGoRepoURL = repoURL
+				optAWSSDKGoArchive = archive
+			}(optAWSSDKGoRepoURL, optAWSSDKGoArchive)
+			optAWSSDKGoRepoURL = tt.repoURL
+			optAWSSDKGoArchive = tt.archive
+
+			args := strings.Join(
+				batchChildArgs("controller", batchService{Name: "ecr", Output: "ecr-controller"}), " ",
+			)
+			assert.True(strings.HasPrefix(args, "controller ecr "))
+			for _, arg := range tt.wantArgs {
+				assert.Contains(args, arg)
+			}
+			for _, arg := range tt.noWantArgs {
+				assert.NotContains(args, arg)
+			}
+		})
+	}
+}
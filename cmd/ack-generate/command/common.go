@@ -15,8 +15,11 @@ package command
 
 import (
 	"context"
+	"encoding/base64"
 	"fmt"
 	"io/ioutil"
+	"net/http"
+	"net/url"
 	"os"
 	"os/signal"
 	"path/filepath"
@@ -26,19 +29,41 @@ import (
 	"time"
 
 	"golang.org/x/mod/modfile"
+	"gopkg.in/src-d/go-git.v4/plumbing/transport"
+	githttp "gopkg.in/src-d/go-git.v4/plumbing/transport/http"
+	gitssh "gopkg.in/src-d/go-git.v4/plumbing/transport/ssh"
 
 	ackgenerate "github.com/aws-controllers-k8s/code-generator/pkg/generate/ack"
 	ackgenconfig "github.com/aws-controllers-k8s/code-generator/pkg/generate/config"
+	ackgenlog "github.com/aws-controllers-k8s/code-generator/pkg/log"
 	ackmodel "github.com/aws-controllers-k8s/code-generator/pkg/model"
 	acksdk "github.com/aws-controllers-k8s/code-generator/pkg/sdk"
 	"github.com/aws-controllers-k8s/code-generator/pkg/util"
 	k8sversion "k8s.io/apimachinery/pkg/version"
 )
 
+// sdkLog records where the service API models are loaded from
+var sdkLog = ackgenlog.Log().WithName("sdk")
+
 const (
 	sdkRepoURL             = "https://github.com/aws/aws-sdk-go"
+	sdkV2RepoURL           = "https://github.com/aws/aws-sdk-go-v2"
 	defaultGitCloneTimeout = 180 * time.Second
 	defaultGitFetchTimeout = 30 * time.Second
+	// sdkRepoURLEnvVar is the environment variable containing the URL of the
+	// SDK repository mirror used when the --aws-sdk-go-repo-url flag isn't set
+	sdkRepoURLEnvVar = "ACK_GENERATE_AWS_SDK_GO_REPO_URL"
+	// sdkRepoUsernameEnvVar and sdkRepoPasswordEnvVar are the environment
+	// variables containing the credentials used to access the SDK repository
+	// over HTTP(S). The password may be an access token.
+	sdkRepoUsernameEnvVar = "ACK_GENERATE_AWS_SDK_GO_REPO_USERNAME"
+	sdkRepoPasswordEnvVar = "ACK_GENERATE_AWS_SDK_GO_REPO_PASSWORD"
+	// sdkRepoSSHKeyEnvVar is the environment variable containing the path to
+	// the private key used to access the SDK repository over SSH, and
+	// sdkRepoSSHKeyPasswordEnvVar the key's passphrase, if any. Without a
+	// key, the keys of the SSH agent are used.
+	sdkRepoSSHKeyEnvVar         = "ACK_GENERATE_AWS_SDK_GO_REPO_SSH_KEY"
+	sdkRepoSSHKeyPasswordEnvVar = "ACK_GENERATE_AWS_SDK_GO_REPO_SSH_KEY_PASSWORD"
 )
 
 func contextWithSigterm(ctx context.Context) (context.Context, context.CancelFunc) {
@@ -64,6 +89,16 @@ func contextWithSigterm(ctx context.Context) (context.Context, context.CancelFun
 	return ctx, cancelFunc
 }
 
+// templateDirs returns the directories to search for templates in, in order
+// of precedence. The --templates-dir override directory, when set, shadows
+// the built-in templates.
+func templateDirs() []string {
+	if optTemplatesDir == "" {
+		return optTemplateDirs
+	}
+	return append([]string{optTemplatesDir}, optTemplateDirs...)
+}
+
 // ensureDir makes sure that a supplied directory exists and
 // returns whether the directory already existed.
 func ensureDir(fp string) (bool, error) {
@@ -97,11 +132,100 @@ func isDirWriteable(fp string) bool {
 	return true
 }
 
+// getSDKRepoURL returns the URL of the SDK repository containing the service
+// API models for the model format selected with the --model-format flag.
+func getSDKRepoURL() string {
+	if optModelFormat == acksdk.ModelFormatSmithy {
+		return sdkV2RepoURL
+	}
+	return sdkRepoURL
+}
+
+// getSDKRemote returns the URL the SDK repository is cloned and fetched from,
+// either the mirror set with the --aws-sdk-go-repo-url flag, or else in the
+// ACK_GENERATE_AWS_SDK_GO_REPO_URL environment variable, or else the SDK
+// repository itself, along with the credentials to access it, if any. The
+// credentials are read from the URL's user info, or else from the
+// ACK_GENERATE_AWS_SDK_GO_REPO_USERNAME and
+// ACK_GENERATE_AWS_SDK_GO_REPO_PASSWORD environment variables, and are
+// stripped from the returned URL so that they aren't stored in the clone's
+// git config. SSH URLs authenticate with the private key set in the
+// ACK_GENERATE_AWS_SDK_GO_REPO_SSH_KEY environment variable, or else with
+// the SSH agent. Local repositories need no credentials.
+func getSDKRemote() (string, transport.AuthMethod, error) {
+	repoURL := optAWSSDKGoRepoURL
+	if repoURL == "" {
+		repoURL = os.Getenv(sdkRepoURLEnvVar)
+	}
+	if repoURL == "" {
+		repoURL = getSDKRepoURL()
+	}
+	if !strings.HasPrefix(repoURL, "https://") && !strings.HasPrefix(repoURL, "http://") {
+		auth, err := getSDKRemoteSSHAuth(repoURL)
+		return repoURL, auth, err
+	}
+	u, err := url.Parse(repoURL)
+	if err != nil {
+		return "", nil, fmt.Errorf("invalid SDK repository URL: %v", err)
+	}
+	username := os.Getenv(sdkRepoUsernameEnvVar)
+	password := os.Getenv(sdkRepoPasswordEnvVar)
+	if u.User != nil {
+		username = u.User.Username()
+		password, _ = u.User.Password()
+		u.User = nil
+	}
+	if username == "" && password == "" {
+		return u.String(), nil, nil
+	}
+	if username == "" {
+		// Git servers accept access tokens with any non-empty username
+		username = "git"
+	}
+	return u.String(), &githttp.BasicAuth{Username: username, Password: password}, nil
+}
+
+// getSDKRemoteSSHAuth returns the credentials to access the SDK repository at
+// the supplied URL with, if it is an SSH URL whose private key is set in the
+// ACK_GENERATE_AWS_SDK_GO_REPO_SSH_KEY environment variable. Otherwise nil
+// is returned, with which go-git uses the SSH agent for SSH URLs.
+func getSDKRemoteSSHAuth(repoURL string) (transport.AuthMethod, error) {
+	keyPath := os.Getenv(sdkRepoSSHKeyEnvVar)
+	if keyPath == "" {
+		return nil, nil
+	}
+	ep, err := transport.NewEndpoint(repoURL)
+	if err != nil {
+		return nil, fmt.Errorf("invalid SDK repository URL: %v", err)
+	}
+	if ep.Protocol != "ssh" {
+		return nil, nil
+	}
+	username := ep.User
+	if username == "" {
+		username = "git"
+	}
+	auth, err := gitssh.NewPublicKeysFromFile(
+		username, keyPath, os.Getenv(sdkRepoSSHKeyPasswordEnvVar),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("cannot read SSH key %s: %v", keyPath, err)
+	}
+	return auth, nil
+}
+
 // ensureSDKRepo ensures that we have a git clone'd copy of the aws-sdk-go
-// repository, which we use model JSON files from. Upon successful return of
+// repository (or aws-sdk-go-v2 repository when generating from Smithy
+// models), which we use model JSON files from. Upon successful return of
 // this function, the sdkDir global variable will be set to the directory where
 // the aws-sdk-go is found. It will also optionally fetch all the remote tags
 // and checkout the given tag.
+//
+// If the --aws-sdk-go-path flag is set, the supplied local copy of the SDK is
+// used as-is and no git operations are performed, even if the output
+// directory has vendored service API models. Otherwise the vendored models
+// are used, if any. If the --model-file flag is set, the SDK is not needed
+// and nothing is done. The source of the models is logged at info level.
 func ensureSDKRepo(
 	ctx context.Context,
 	cacheDir string,
@@ -109,32 +233,63 @@ func ensureSDKRepo(
 	// the upstream repository
 	fetchTags bool,
 ) error {
-	var err error
-	srcPath := filepath.Join(cacheDir, "src")
-	if err = os.MkdirAll(srcPath, os.ModePerm); err != nil {
-		return err
+	// Models loaded from a model file don't need the SDK at all
+	if optModelFile != "" {
+		return nil
 	}
-
-	// Clone repository if it doen't exist
-	sdkDir = filepath.Join(srcPath, "aws-sdk-go")
-	if _, err := os.Stat(sdkDir); os.IsNotExist(err) {
-
-		ctx, cancel := context.WithTimeout(ctx, defaultGitCloneTimeout)
-		defer cancel()
-		err = util.CloneRepository(ctx, sdkDir, sdkRepoURL)
-		if err != nil {
-			return fmt.Errorf("canot clone repository: %v", err)
+	vendored := optOutputPath != "" && hasVendoredModels(optOutputPath)
+	// An explicit SDK path takes precedence over the vendored models
+	if optAWSSDKGoPath != "" {
+		if vendored {
+			sdkLog.Info(
+				"ignoring service API models vendored in the output directory",
+				"path", sdkModelsDir(optOutputPath),
+			)
 		}
+		sdkLog.Info("using service API models of local SDK", "path", optAWSSDKGoPath)
+		return useLocalSDKPath(optAWSSDKGoPath)
+	}
+	// Prefer any service API models vendored into the output directory by the
+	// `vendor-model` command.
+	if vendored {
+		sdkLog.Info(
+			"using service API models vendored in the output directory",
+			"path", sdkModelsDir(optOutputPath),
+		)
+		return useLocalSDKPath(optOutputPath)
 	}
+	// CloudFormation resource schemas aren't published in a git repository
+	if optModelFormat == acksdk.ModelFormatCloudControl {
+		return fmt.Errorf(
+			"%s models require --aws-sdk-go-path to point to a directory "+
+				"containing a schemas/<service> directory of resource schemas",
+			acksdk.ModelFormatCloudControl,
+		)
+	}
+	sdkLog.Info(
+		"using service API models of SDK repository",
+		"repository", getSDKRepoURL(), "archive", optAWSSDKGoArchive,
+	)
+	return cloneSDKRepo(ctx, cacheDir, fetchTags)
+}
 
-	// Fetch all tags
-	if fetchTags {
-		ctx, cancel := context.WithTimeout(ctx, defaultGitFetchTimeout)
-		defer cancel()
-		err = util.FetchRepositoryTags(ctx, sdkDir)
-		if err != nil {
-			return fmt.Errorf("cannot fetch tags: %v", err)
-		}
+// cloneSDKRepo clones (if necessary) the SDK repository into the cache
+// directory, optionally fetches its tags and checks out the SDK version to
+// generate from. Upon successful return the sdkDir global variable is set to
+// the clone'd repository. If the --aws-sdk-go-archive flag is set, the source
+// code archive of the SDK version is downloaded instead.
+func cloneSDKRepo(
+	ctx context.Context,
+	cacheDir string,
+	fetchTags bool,
+) error {
+	if optAWSSDKGoArchive {
+		return downloadSDKArchive(ctx, cacheDir)
+	}
+	var err error
+	srcPath := filepath.Join(cacheDir, "src")
+	if err = os.MkdirAll(srcPath, os.ModePerm); err != nil {
+		return err
 	}
 
 	// get sdkVersion and ensure it prefix
@@ -146,10 +301,39 @@ func ensureSDKRepo(
 	}
 	sdkVersion = ensureSemverPrefix(sdkVersion)
 
+	// Clone repository if it doen't exist. We first try a shallow clone of
+	// only the tag we need, which is an order of magnitude faster than
+	// cloning the entire repository history, and fall back to a full clone.
+	// The clone is named after the SDK repository, even when cloned from a
+	// mirror.
+	remoteURL, auth, err := getSDKRemote()
+	if err != nil {
+		return err
+	}
+	sdkDir = filepath.Join(srcPath, filepath.Base(getSDKRepoURL()))
+	if _, err := os.Stat(sdkDir); os.IsNotExist(err) {
+		if err = cloneSDKRepoTag(ctx, remoteURL, auth, sdkVersion); err != nil {
+			return fmt.Errorf("canot clone repository: %v", err)
+		}
+	}
+
 	repo, err := util.LoadRepository(sdkDir)
 	if err != nil {
 		return fmt.Errorf("cannot read local repository: %v", err)
 	}
+	// The cached clone may have been cloned from another remote, e.g. before
+	// a mirror was configured
+	if err = util.SetRepositoryRemoteURL(repo, remoteURL); err != nil {
+		return fmt.Errorf("cannot set repository remote: %v", err)
+	}
+
+	// Fetch the tag if we don't already have it. Tags are immutable so
+	// there's no need to go to the network when it's already present.
+	if fetchTags && !util.HasRepositoryTag(repo, sdkVersion) {
+		if err = fetchSDKRepoTag(ctx, sdkVersion); err != nil {
+			return fmt.Errorf("cannot fetch tags: %v", err)
+		}
+	}
 
 	// Now checkout the local repository.
 	err = util.CheckoutRepositoryTag(repo, sdkVersion)
@@ -160,6 +344,171 @@ func ensureSDKRepo(
 	return err
 }
 
+// downloadSDKArchive downloads (if necessary) the source code archive of the
+// SDK version to generate from and extracts its service API models into the
+// cache directory. The archive is downloaded from the SDK repository, or
+// its mirror, at `<repository>/archive/refs/tags/<version>.tar.gz`, the
+// layout of GitHub's source code archives. Upon successful return the sdkDir
+// global variable is set to the extracted directory, which, unlike a clone,
+// isn't a git repository.
+func downloadSDKArchive(ctx context.Context, cacheDir string) error {
+	sdkVersion, err := getSDKVersion("")
+	if err != nil {
+		return err
+	}
+	sdkVersion = ensureSemverPrefix(sdkVersion)
+
+	archivesPath := filepath.Join(cacheDir, "archives")
+	archiveDir := filepath.Join(
+		archivesPath, filepath.Base(getSDKRepoURL())+"@"+sdkVersion,
+	)
+	// Tags are immutable so a previously extracted archive is up to date
+	if _, err := os.Stat(archiveDir); err == nil {
+		return useLocalSDKPath(archiveDir)
+	}
+
+	remoteURL, auth, err := getSDKRemote()
+	if err != nil {
+		return err
+	}
+	if !strings.HasPrefix(remoteURL, "https://") && !strings.HasPrefix(remoteURL, "http://") {
+		return fmt.Errorf(
+			"cannot download the SDK archive from %s: only HTTP(S) repository URLs are supported",
+			remoteURL,
+		)
+	}
+	archiveURL := strings.TrimSuffix(remoteURL, ".git") +
+		"/archive/refs/tags/" + sdkVersion + ".tar.gz"
+	header := http.Header{}
+	if basicAuth, ok := auth.(*githttp.BasicAuth); ok {
+		credentials := basicAuth.Username + ":" + basicAuth.Password
+		header.Set(
+			"Authorization",
+			"Basic "+base64.StdEncoding.EncodeToString([]byte(credentials)),
+		)
+	}
+
+	// Extract into a temporary directory first so that an interrupted
+	// download doesn't leave a partial archive in the cache
+	if err = os.MkdirAll(archivesPath, os.ModePerm); err != nil {
+		return err
+	}
+	tmpDir, err := ioutil.TempDir(archivesPath, ".download-")
+	if err != nil {
+		return err
+	}
+	defer os.RemoveAll(tmpDir)
+	p := newProgress()
+	defer p.Done()
+	downloadCtx, cancel := context.WithTimeout(ctx, defaultGitCloneTimeout)
+	defer cancel()
+	err = util.DownloadTarball(
+		downloadCtx, archiveURL, header, tmpDir, []string{sdkModelsDir("")},
+		p.gitProgress("downloading "+sdkVersion),
+	)
+	if err != nil {
+		return fmt.Errorf("cannot download SDK archive: %v", err)
+	}
+	if err = os.Rename(tmpDir, archiveDir); err != nil {
+		return err
+	}
+	return useLocalSDKPath(archiveDir)
+}
+
+// cloneSDKRepoTag clones only the supplied tag of the SDK repository into
+// sdkDir, falling back to a full clone of the repository if the shallow clone
+// fails (e.g. when the git server doesn't support shallow clones).
+func cloneSDKRepoTag(
+	ctx context.Context,
+	repoURL string,
+	auth transport.AuthMethod,
+	tag string,
+) error {
+	p := newProgress()
+	defer p.Done()
+	shallowCtx, cancel := context.WithTimeout(ctx, defaultGitCloneTimeout)
+	defer cancel()
+	err := util.CloneRepositoryTag(
+		shallowCtx, sdkDir, repoURL, tag, auth, p.gitProgress("cloning "+tag),
+	)
+	if err == nil {
+		return nil
+	}
+	// The partial clone was removed by CloneRepositoryTag
+	fullCtx, cancel := context.WithTimeout(ctx, defaultGitCloneTimeout)
+	defer cancel()
+	return util.CloneRepository(fullCtx, sdkDir, repoURL, auth, p.gitProgress("cloning"))
+}
+
+// fetchSDKRepoTag fetches only the supplied tag into the sdkDir repository,
+// falling back to fetching all the remote tags.
+func fetchSDKRepoTag(ctx context.Context, tag string) error {
+	_, auth, err := getSDKRemote()
+	if err != nil {
+		return err
+	}
+	p := newProgress()
+	defer p.Done()
+	shallowCtx, cancel := context.WithTimeout(ctx, defaultGitFetchTimeout)
+	defer cancel()
+	err = util.FetchRepositoryTag(
+		shallowCtx, sdkDir, tag, auth, p.gitProgress("fetching "+tag),
+	)
+	if err == nil {
+		return nil
+	}
+	fullCtx, cancel := context.WithTimeout(ctx, defaultGitFetchTimeout)
+	defer cancel()
+	return util.FetchRepositoryTags(fullCtx, sdkDir, auth, p.gitProgress("fetching tags"))
+}
+
+// useLocalSDKPath sets the sdkDir global variable to a local copy of the SDK
+// after checking that the directory contains service API models. The local
+// copy can either be a git checkout or a read-only Go module cache directory
+// (e.g. $GOPATH/pkg/mod/github.com/aws/aws-sdk-go@v1.37.10).
+func useLocalSDKPath(localPath string) error {
+	absPath, err := filepath.Abs(localPath)
+	if err != nil {
+		return err
+	}
+	modelsDir := sdkModelsDir(absPath)
+	fi, err := os.Stat(modelsDir)
+	if err != nil {
+		return fmt.Errorf(
+			"cannot use SDK path %s: %v", localPath, err,
+		)
+	}
+	if !fi.IsDir() {
+		return fmt.Errorf(
+			"cannot use SDK path %s: expected %s to be a directory",
+			localPath, modelsDir,
+		)
+	}
+	sdkDir = absPath
+	return nil
+}
+
+// sdkModelsDir returns the directory, relative to the supplied SDK base
+// path, that contains the service API model files for the model format
+// selected with the --model-format flag.
+func sdkModelsDir(basePath string) string {
+	switch optModelFormat {
+	case acksdk.ModelFormatSmithy:
+		return filepath.Join(basePath, "codegen", "sdk-codegen", "aws-models")
+	case acksdk.ModelFormatCloudControl:
+		return filepath.Join(basePath, "schemas")
+	}
+	return filepath.Join(basePath, "models", "apis")
+}
+
+// hasVendoredModels returns true if the supplied service controller
+// directory contains service API models vendored by `ack-generate
+// vendor-model`
+func hasVendoredModels(outputPath string) bool {
+	fi, err := os.Stat(sdkModelsDir(outputPath))
+	return err == nil && fi.IsDir()
+}
+
 // ensureSemverPrefix takes a semver string and tries to append the 'v'
 // prefix if it's missing.
 func ensureSemverPrefix(s string) string {
@@ -195,7 +544,7 @@ func getSDKVersion(
 }
 
 // getSDKVersionFromGoMod parses a given go.mod file and returns
-// the aws-sdk-go version in the required modules.
+// the aws-sdk-go (or aws-sdk-go-v2) version in the required modules.
 func getSDKVersionFromGoMod(goModPath string) (string, error) {
 	b, err := ioutil.ReadFile(goModPath)
 	if err != nil {
@@ -205,7 +554,7 @@ func getSDKVersionFromGoMod(goModPath string) (string, error) {
 	if err != nil {
 		return "", err
 	}
-	sdkModule := strings.TrimPrefix(sdkRepoURL, "https://")
+	sdkModule := strings.TrimPrefix(getSDKRepoURL(), "https://")
 	for _, require := range goMod.Require {
 		if require.Mod.Path == sdkModule {
 			return require.Mod.Version, nil
@@ -227,6 +576,9 @@ func loadModelWithLatestAPIVersion(svcAlias string) (*ackmodel.Model, error) {
 // loadModel finds the AWS SDK for a given service alias and creates a new model
 // with the given API version.
 func loadModel(svcAlias string, apiVersion string, apiGroup string, defaultCfg ackgenconfig.Config) (*ackmodel.Model, error) {
+	if optModelFile != "" {
+		return loadModelFromFile(svcAlias, apiVersion, apiGroup)
+	}
 
 	cfg, err := ackgenconfig.New(optGeneratorConfigPath, defaultCfg)
 	if err != nil {
@@ -239,6 +591,10 @@ func loadModel(svcAlias string, apiVersion string, apiGroup string, defaultCfg a
 	}
 
 	sdkHelper := acksdk.NewHelper(sdkDir, cfg)
+	if err := sdkHelper.WithModelFormat(optModelFormat); err != nil {
+		return nil, err
+	}
+	sdkHelper.WithExtraModelsPath(optExtraModelsDir)
 	sdkAPI, err := sdkHelper.API(modelName)
 	if err != nil {
 		retryModelName, err := FallBackFindServiceID(sdkDir, svcAlias)
@@ -17,7 +17,6 @@ import (
 	"bufio"
 	"context"
 	"fmt"
-	"io/ioutil"
 	"os"
 	"path/filepath"
 	"regexp"
@@ -63,31 +62,19 @@ func generateController(cmd *cobra.Command, args []string) error {
 	if err != nil {
 		return err
 	}
-	ts, err := ackgenerate.Controller(m, optTemplateDirs)
+	ts, err := ackgenerate.Controller(m, templateDirs())
 	if err != nil {
 		return err
 	}
 
-	if err = ts.Execute(); err != nil {
+	if err = executeTemplates(ts); err != nil {
 		return err
 	}
 
-	for path, contents := range ts.Executed() {
-		if optDryRun {
-			fmt.Printf("============================= %s ======================================\n", path)
-			fmt.Println(strings.TrimSpace(contents.String()))
-			continue
-		}
-		outPath := filepath.Join(optOutputPath, path)
-		outDir := filepath.Dir(outPath)
-		if _, err := ensureDir(outDir); err != nil {
-			return err
-		}
-		if err = ioutil.WriteFile(outPath, contents.Bytes(), 0666); err != nil {
-			return err
-		}
+	if err = writeGeneratedFiles(optOutputPath, ts.Executed()); err != nil {
+		return err
 	}
-	return nil
+	return runPlugins(ctx, m, "controller")
 }
 
 // FallBackFindServiceID reads through aws-sdk-go/models/apis/*/*/api-2.json
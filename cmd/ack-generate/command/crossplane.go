@@ -16,7 +16,6 @@ package command
 import (
 	"context"
 	"fmt"
-	"io/ioutil"
 	"os/exec"
 	"path/filepath"
 	"strings"
@@ -49,35 +48,28 @@ func generateCrossplane(_ *cobra.Command, args []string) error {
 		return err
 	}
 	svcAlias := strings.ToLower(args[0])
-	optGeneratorConfigPath = filepath.Join(optOutputPath, "apis", svcAlias, optGenVersion, "generator-config.yaml")
+	if optModelFile == "" {
+		optGeneratorConfigPath = filepath.Join(optOutputPath, "apis", svcAlias, optGenVersion, "generator-config.yaml")
+	}
 	m, err := loadModel(svcAlias, optGenVersion, "aws.crossplane.io", cpgenerate.DefaultConfig)
 	if err != nil {
 		return err
 	}
 
-	ts, err := cpgenerate.Crossplane(m, optTemplateDirs)
+	ts, err := cpgenerate.Crossplane(m, templateDirs())
 	if err != nil {
 		return err
 	}
 
-	if err = ts.Execute(); err != nil {
+	if err = executeTemplates(ts); err != nil {
 		return err
 	}
 
-	for path, contents := range ts.Executed() {
-		if optDryRun {
-			fmt.Printf("============================= %s ======================================\n", path)
-			fmt.Println(strings.TrimSpace(contents.String()))
-			continue
-		}
-		outPath := filepath.Join(optOutputPath, path)
-		outDir := filepath.Dir(outPath)
-		if _, err := ensureDir(outDir); err != nil {
-			return err
-		}
-		if err = ioutil.WriteFile(outPath, contents.Bytes(), 0666); err != nil {
-			return err
-		}
+	if err = writeGeneratedFiles(optOutputPath, ts.Executed()); err != nil {
+		return err
+	}
+	if !writesOutput() {
+		return nil
 	}
 	apiPath := filepath.Join(optOutputPath, "apis", svcAlias, optGenVersion)
 	controllerPath := filepath.Join(optOutputPath, "pkg", "controller", svcAlias)
@@ -0,0 +1,70 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package command
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	ackgenerate "github.com/aws-controllers-k8s/code-generator/pkg/generate/ack"
+)
+
+var docsCmd = &cobra.Command{
+	Use:   "docs <service>",
+	Short: "Generates API reference markdown for the custom resources of a given service",
+	RunE:  generateDocs,
+}
+
+func init() {
+	rootCmd.AddCommand(docsCmd)
+}
+
+// generateDocs generates the API reference markdown files for a service's
+// custom resources
+func generateDocs(cmd *cobra.Command, args []string) error {
+	if len(args) != 1 {
+		return fmt.Errorf("please specify the service alias for the AWS service API to generate")
+	}
+	svcAlias := strings.ToLower(args[0])
+	if optOutputPath == "" {
+		optOutputPath = filepath.Join(optServicesDir, svcAlias)
+	}
+
+	ctx, cancel := contextWithSigterm(context.Background())
+	defer cancel()
+	if err := ensureSDKRepo(ctx, optCacheDir, optRefreshCache); err != nil {
+		return err
+	}
+	m, err := loadModelWithLatestAPIVersion(svcAlias)
+	if err != nil {
+		return err
+	}
+	ts, err := ackgenerate.Docs(m, templateDirs())
+	if err != nil {
+		return err
+	}
+
+	if err = executeTemplates(ts); err != nil {
+		return err
+	}
+
+	if err = writeGeneratedFiles(optOutputPath, ts.Executed()); err != nil {
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,79 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package command
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+var modelCmd = &cobra.Command{
+	Use:   "model",
+	Short: "Inspects the code generation model inferred for an AWS service API",
+}
+
+var modelDumpCmd = &cobra.Command{
+	Use:   "dump <service>",
+	Short: "Writes the code generation model for a service to stdout as JSON",
+	Long: `Writes the code generation model inferred for a service (resources, their
+Spec and Status fields, the API operations controlling them, type and enum
+definitions) to stdout as a JSON document, so that external tooling can
+consume the model without re-implementing its inference.`,
+	RunE: dumpModel,
+}
+
+var optDumpIncludeSource bool
+
+func init() {
+	modelDumpCmd.PersistentFlags().BoolVar(
+		&optDumpIncludeSource, "include-source", false, "If true, embeds the service API model files and generator config in the dump, so that it can be used with the --model-file flag",
+	)
+	modelCmd.AddCommand(modelDumpCmd)
+	rootCmd.AddCommand(modelCmd)
+}
+
+// dumpModel writes the JSON representation of a service's model to stdout
+func dumpModel(cmd *cobra.Command, args []string) error {
+	if len(args) != 1 {
+		return fmt.Errorf("please specify the service alias for the AWS service API to dump")
+	}
+	svcAlias := strings.ToLower(args[0])
+
+	ctx, cancel := contextWithSigterm(context.Background())
+	defer cancel()
+	if err := ensureSDKRepo(ctx, optCacheDir, optRefreshCache); err != nil {
+		return err
+	}
+	m, err := loadModelWithLatestAPIVersion(svcAlias)
+	if err != nil {
+		return err
+	}
+	doc, err := m.Document()
+	if err != nil {
+		return err
+	}
+	if optDumpIncludeSource {
+		if doc.Source, err = modelSource(svcAlias, m); err != nil {
+			return err
+		}
+	}
+	enc := json.NewEncoder(os.Stdout)
+	enc.SetIndent("", "  ")
+	return enc.Encode(doc)
+}
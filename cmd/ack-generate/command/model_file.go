@@ -0,0 +1,138 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package command
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"strings"
+
+	ackgenconfig "github.com/aws-controllers-k8s/code-generator/pkg/generate/config"
+	ackmodel "github.com/aws-controllers-k8s/code-generator/pkg/model"
+	acksdk "github.com/aws-controllers-k8s/code-generator/pkg/sdk"
+)
+
+// modelSource returns the inputs the supplied model was inferred from, read
+// from the SDK directory, so that they can be embedded into a model dump
+func modelSource(svcAlias string, m *ackmodel.Model) (*ackmodel.DocumentSource, error) {
+	cfg := m.GetConfig()
+	modelName := strings.ToLower(cfg.ModelName)
+	if modelName == "" {
+		modelName = svcAlias
+	}
+	sdkHelper := acksdk.NewHelper(sdkDir, *cfg)
+	if err := sdkHelper.WithModelFormat(optModelFormat); err != nil {
+		return nil, err
+	}
+	sdkHelper.WithExtraModelsPath(optExtraModelsDir)
+	files, err := sdkHelper.ModelFiles(modelName)
+	if err != nil {
+		retryModelName, err := FallBackFindServiceID(sdkDir, svcAlias)
+		if err != nil {
+			return nil, err
+		}
+		modelName = retryModelName
+		// Retry using path found by querying service ID
+		files, err = sdkHelper.ModelFiles(modelName)
+		if err != nil {
+			return nil, fmt.Errorf("service %s not found", svcAlias)
+		}
+	}
+	modelFiles := make(map[string]json.RawMessage, len(files))
+	for fileName, b := range files {
+		modelFiles[fileName] = json.RawMessage(b)
+	}
+	// The SDK version is informational only and may not be known, e.g. when
+	// using a local SDK copy
+	sdkVersion, _ := getSDKVersion("")
+	return &ackmodel.DocumentSource{
+		ModelName:       modelName,
+		ModelFormat:     optModelFormat,
+		SDKVersion:      sdkVersion,
+		ModelFiles:      modelFiles,
+		GeneratorConfig: cfg,
+	}, nil
+}
+
+// loadModelFromFile creates a new model with the given API version from the
+// model file supplied with the --model-file flag, without reading the SDK.
+// The model file must have been written by `ack-generate model dump` with
+// the --include-source flag. The generator config embedded in the model file
+// is used, so the --generator-config-path flag must not be set.
+func loadModelFromFile(svcAlias string, apiVersion string, apiGroup string) (*ackmodel.Model, error) {
+	if optGeneratorConfigPath != "" {
+		return nil, fmt.Errorf(
+			"--model-file and --generator-config-path cannot be used together; " +
+				"the generator config embedded in the model file is used",
+		)
+	}
+	b, err := ioutil.ReadFile(optModelFile)
+	if err != nil {
+		return nil, err
+	}
+	doc := ackmodel.Document{}
+	if err = json.Unmarshal(b, &doc); err != nil {
+		return nil, fmt.Errorf("cannot parse model file %s: %v", optModelFile, err)
+	}
+	if doc.Source == nil {
+		return nil, fmt.Errorf(
+			"model file %s does not contain the model source; "+
+				"dump the model with the --include-source flag",
+			optModelFile,
+		)
+	}
+	if doc.ServicePackageName != svcAlias {
+		return nil, fmt.Errorf(
+			"model file %s is for service %s, not %s",
+			optModelFile, doc.ServicePackageName, svcAlias,
+		)
+	}
+
+	cfg := ackgenconfig.Config{}
+	if doc.Source.GeneratorConfig != nil {
+		cfg = *doc.Source.GeneratorConfig
+	}
+	tmpDir, err := ioutil.TempDir("", "ack-model-")
+	if err != nil {
+		return nil, err
+	}
+	defer os.RemoveAll(tmpDir)
+
+	sdkHelper := acksdk.NewHelper(tmpDir, cfg)
+	if err := sdkHelper.WithModelFormat(doc.Source.ModelFormat); err != nil {
+		return nil, err
+	}
+	files := make(map[string][]byte, len(doc.Source.ModelFiles))
+	for fileName, raw := range doc.Source.ModelFiles {
+		files[fileName] = []byte(raw)
+	}
+	if err = sdkHelper.WriteModelFiles(doc.Source.ModelName, files); err != nil {
+		return nil, err
+	}
+	sdkAPI, err := sdkHelper.API(doc.Source.ModelName)
+	if err != nil {
+		return nil, fmt.Errorf("cannot load model file %s: %v", optModelFile, err)
+	}
+	if apiGroup != "" {
+		sdkAPI.APIGroupSuffix = apiGroup
+	}
+	// Record the SDK version the model was dumped from in the generation
+	// metadata
+	if optAWSSDKGoVersion == "" {
+		optAWSSDKGoVersion = doc.Source.SDKVersion
+	}
+	return ackmodel.New(sdkAPI, svcAlias, apiVersion, cfg)
+}
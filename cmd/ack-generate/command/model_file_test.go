@@ -0,0 +1,36 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package command
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	ackgenerate "github.com/aws-controllers-k8s/code-generator/pkg/generate/ack"
+)
+
+func TestLoadModelFromFile_GeneratorConfigPath(t *testing.T) {
+	defer func(modelFile, generatorConfigPath string) {
+		optModelFile = modelFile
+		optGeneratorConfigPath = generatorConfigPath
+	}(optModelFile, optGeneratorConfigPath)
+	optModelFile = "ecr-model.json"
+	optGeneratorConfigPath = "generator.yaml"
+
+	_, err := loadModel("ecr", "v1alpha1", "", ackgenerate.DefaultConfig)
+	require.NotNil(t, err)
+	assert.Contains(t, err.Error(), "--generator-config-path")
+}
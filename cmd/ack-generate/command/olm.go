@@ -96,16 +96,14 @@ func generateOLMAssets(cmd *cobra.Command, args []string) error {
 	// read the configuration from file
 	svcConfigYAML, err := ioutil.ReadFile(optOLMConfigPath)
 	if err != nil {
-		fmt.Println("unable to read configuration file at path:", optOLMConfigPath)
-		return err
+		return fmt.Errorf("unable to read configuration file at path %s: %v", optOLMConfigPath, err)
 	}
 
 	// set the base metadata and then override values as
 	// defined by the service config.
 	svcConf := olmgenerate.DefaultServiceConfig()
 	if err = yaml.Unmarshal(svcConfigYAML, &svcConf); err != nil {
-		fmt.Println("unable to convert olm configuration file to data instance")
-		return err
+		return fmt.Errorf("unable to convert olm configuration file to data instance: %v", err)
 	}
 
 	// prepare the common metadata
@@ -119,29 +117,17 @@ func generateOLMAssets(cmd *cobra.Command, args []string) error {
 	}
 
 	// generate templates
-	ts, err := olmgenerate.BundleAssets(m, commonMeta, svcConf, version, optTemplateDirs)
+	ts, err := olmgenerate.BundleAssets(m, commonMeta, svcConf, version, templateDirs())
 	if err != nil {
 		return err
 	}
 
-	if err = ts.Execute(); err != nil {
+	if err = executeTemplates(ts); err != nil {
 		return err
 	}
 
-	for path, contents := range ts.Executed() {
-		if optDryRun {
-			fmt.Printf("============================= %s ======================================\n", path)
-			fmt.Println(strings.TrimSpace(contents.String()))
-			continue
-		}
-		outPath := filepath.Join(optOutputPath, path)
-		outDir := filepath.Dir(outPath)
-		if _, err := ensureDir(outDir); err != nil {
-			return err
-		}
-		if err = ioutil.WriteFile(outPath, contents.Bytes(), 0666); err != nil {
-			return err
-		}
+	if err = writeGeneratedFiles(optOutputPath, ts.Executed()); err != nil {
+		return err
 	}
 
 	return nil
@@ -0,0 +1,276 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package command
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"github.com/pmezard/go-difflib/difflib"
+	"github.com/spf13/cobra"
+)
+
+const (
+	fileStatusNew       = "new"
+	fileStatusChanged   = "changed"
+	fileStatusUnchanged = "unchanged"
+	fileStatusObsolete  = "obsolete"
+)
+
+// generatedMarker is the comment identifying the files generated by
+// ack-generate
+var generatedMarker = []byte("// Code generated by ack-generate. DO NOT EDIT.")
+
+var (
+	// staleFiles contains the paths of the generated files found to be
+	// missing, out of date or obsolete in the output directory when the
+	// --check flag is set
+	staleFiles []string
+	// checkedFiles contains the paths, relative to the output directory
+	// they're keyed by, of the generated files compared with the output
+	// directories when the --check flag is set
+	checkedFiles = map[string][]string{}
+)
+
+// writesOutput returns true if generated files are written into the output
+// directory, as opposed to only being compared with it
+func writesOutput() bool {
+	return !optDryRun && !optCheck
+}
+
+// writeGeneratedFiles writes the supplied generated files, keyed by path
+// relative to outputDir, into outputDir.
+//
+// When the --dry-run flag is set nothing is written. Instead, the path of
+// every file that would be written is printed along with whether the file
+// would be created, changed or left unchanged and, when the --diff flag is
+// also set, a unified diff against the existing file.
+//
+// When the --check flag is set nothing is written either, and the files that
+// would be created or changed are recorded as stale.
+func writeGeneratedFiles(outputDir string, files map[string]*bytes.Buffer) error {
+	if !writesOutput() {
+		return printGeneratedFiles(outputDir, files)
+	}
+	for path, contents := range files {
+		outPath := filepath.Join(outputDir, path)
+		// Leave files whose content is unchanged untouched, so that their
+		// modification time is preserved and build caches stay warm
+		unchanged, err := hasContent(outPath, contents.Bytes())
+		if err != nil {
+			return err
+		}
+		if unchanged {
+			continue
+		}
+		outDir := filepath.Dir(outPath)
+		if _, err := ensureDir(outDir); err != nil {
+			return err
+		}
+		if err := ioutil.WriteFile(outPath, contents.Bytes(), 0666); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// hasContent returns true if the file at the supplied path exists and has
+// exactly the supplied content
+func hasContent(path string, content []byte) (bool, error) {
+	fi, err := os.Stat(path)
+	if os.IsNotExist(err) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	// Avoid reading files that cannot possibly match
+	if fi.IsDir() || fi.Size() != int64(len(content)) {
+		return false, nil
+	}
+	existing, err := ioutil.ReadFile(path)
+	if err != nil {
+		return false, err
+	}
+	return bytes.Equal(existing, content), nil
+}
+
+// printGeneratedFiles prints, in path order, the files that would be written
+// into outputDir and how they compare to the existing files
+func printGeneratedFiles(outputDir string, files map[string]*bytes.Buffer) error {
+	paths := make([]string, 0, len(files))
+	for path := range files {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+	if optCheck {
+		checkedFiles[outputDir] = append(checkedFiles[outputDir], paths...)
+	}
+	for _, path := range paths {
+		outPath := filepath.Join(outputDir, path)
+		existing, err := ioutil.ReadFile(outPath)
+		if err != nil && !os.IsNotExist(err) {
+			return err
+		}
+		status := fileStatusChanged
+		if os.IsNotExist(err) {
+			status = fileStatusNew
+		} else if bytes.Equal(existing, files[path].Bytes()) {
+			status = fileStatusUnchanged
+		}
+		if status != fileStatusUnchanged {
+			staleFiles = append(staleFiles, outPath)
+		}
+		// In check mode, only report the files that are out of date
+		if optDryRun || status != fileStatusUnchanged {
+			fmt.Printf("%-9s %s\n", status, outPath)
+		}
+		if !optDiff || status == fileStatusUnchanged {
+			continue
+		}
+		diff, err := difflib.GetUnifiedDiffString(difflib.UnifiedDiff{
+			A:        difflib.SplitLines(string(existing)),
+			B:        difflib.SplitLines(files[path].String()),
+			FromFile: outPath,
+			ToFile:   outPath,
+			Context:  3,
+		})
+		if err != nil {
+			return err
+		}
+		fmt.Print(diff)
+	}
+	return nil
+}
+
+// findObsoleteFiles returns, in path order, the files carrying the generated
+// marker that were found in the checked output directories but aren't
+// generated anymore, e.g. those of a resource removed from the generator
+// config. Only the directories containing generated files are searched,
+// along with their subdirectories not containing any, so that the files
+// generated by other commands in the same output directory are not
+// reported.
+func findObsoleteFiles() ([]string, error) {
+	generated := map[string]bool{}
+	generatedDirs := map[string]bool{}
+	for outputDir, paths := range checkedFiles {
+		outputDir = filepath.Clean(outputDir)
+		for _, path := range paths {
+			outPath := filepath.Join(outputDir, path)
+			generated[outPath] = true
+			// Every directory between the file and the output directory
+			// contains generated files
+			for dir := filepath.Dir(outPath); ; dir = filepath.Dir(dir) {
+				generatedDirs[dir] = true
+				if dir == outputDir || dir == filepath.Dir(dir) {
+					break
+				}
+			}
+		}
+	}
+	obsolete := map[string]bool{}
+	searched := map[string]bool{}
+	check := func(path string) error {
+		if generated[path] || obsolete[path] {
+			return nil
+		}
+		content, err := ioutil.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		if bytes.Contains(content, generatedMarker) {
+			obsolete[path] = true
+		}
+		return nil
+	}
+	for outputDir, paths := range checkedFiles {
+		outputDir = filepath.Clean(outputDir)
+		for _, path := range paths {
+			dir := filepath.Dir(filepath.Join(outputDir, path))
+			if searched[dir] {
+				continue
+			}
+			searched[dir] = true
+			entries, err := ioutil.ReadDir(dir)
+			if os.IsNotExist(err) {
+				continue
+			}
+			if err != nil {
+				return nil, err
+			}
+			for _, entry := range entries {
+				entryPath := filepath.Join(dir, entry.Name())
+				if entry.Mode().IsRegular() {
+					if err = check(entryPath); err != nil {
+						return nil, err
+					}
+					continue
+				}
+				// The output directory's subdirectories may contain the
+				// files generated by other commands
+				if !entry.IsDir() || dir == outputDir || generatedDirs[entryPath] {
+					continue
+				}
+				err = filepath.Walk(entryPath, func(path string, info os.FileInfo, err error) error {
+					if err != nil || !info.Mode().IsRegular() {
+						return err
+					}
+					return check(path)
+				})
+				if err != nil {
+					return nil, err
+				}
+			}
+		}
+	}
+	paths := make([]string, 0, len(obsolete))
+	for path := range obsolete {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+	return paths, nil
+}
+
+// reportStaleFiles fails the command if the --check flag is set and any
+// generated file was found to be missing, out of date or obsolete
+func reportStaleFiles(cmd *cobra.Command, args []string) error {
+	if !optCheck {
+		return nil
+	}
+	obsolete, err := findObsoleteFiles()
+	if err != nil {
+		return err
+	}
+	for _, path := range obsolete {
+		fmt.Printf("%-9s %s\n", fileStatusObsolete, path)
+	}
+	staleFiles = append(staleFiles, obsolete...)
+	if len(staleFiles) == 0 {
+		return nil
+	}
+	if len(obsolete) > 0 {
+		return fmt.Errorf(
+			"%d generated file(s) out of date, including %d obsolete; re-run %s without --check to update them and delete the obsolete ones",
+			len(staleFiles), len(obsolete), cmd.CommandPath(),
+		)
+	}
+	return fmt.Errorf(
+		"%d generated file(s) out of date; re-run %s without --check to update them",
+		len(staleFiles), cmd.CommandPath(),
+	)
+}
@@ -0,0 +1,75 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package command
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReportStaleFiles_Obsolete(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	dir, err := ioutil.TempDir("", "output")
+	require.Nil(err)
+	defer os.RemoveAll(dir)
+
+	generatedFile := string(generatedMarker) + "\n\npackage repository\n"
+	existingFiles := map[string]string{
+		// Up to date
+		"pkg/resource/repository/manager.go": generatedFile,
+		// Hand-written
+		"pkg/resource/repository/hooks.go": "package repository\n",
+		// Obsolete, e.g. the files of a resource removed from the generator
+		// config
+		"pkg/resource/repository/references.go":           generatedFile,
+		"pkg/resource/pull_through_cache_rule/manager.go": generatedFile,
+		// Generated by another command
+		"apis/v1alpha1/repository.go": string(generatedMarker) + "\n\npackage v1alpha1\n",
+	}
+	for path, content := range existingFiles {
+		path = filepath.Join(dir, path)
+		require.Nil(os.MkdirAll(filepath.Dir(path), os.ModePerm))
+		require.Nil(ioutil.WriteFile(path, []byte(content), 0666))
+	}
+
+	defer func(check bool) {
+		optCheck = check
+		staleFiles = nil
+		checkedFiles = map[string][]string{}
+	}(optCheck)
+	optCheck = true
+
+	err = writeGeneratedFiles(dir, map[string]*bytes.Buffer{
+		"pkg/resource/repository/manager.go": bytes.NewBufferString(generatedFile),
+		"pkg/resource/registry.go":           bytes.NewBufferString(generatedFile),
+	})
+	require.Nil(err)
+	err = reportStaleFiles(&cobra.Command{Use: "controller"}, nil)
+	require.NotNil(err)
+	assert.Contains(err.Error(), "including 2 obsolete")
+	assert.Equal([]string{
+		filepath.Join(dir, "pkg/resource/registry.go"),
+		filepath.Join(dir, "pkg/resource/pull_through_cache_rule/manager.go"),
+		filepath.Join(dir, "pkg/resource/repository/references.go"),
+	}, staleFiles)
+}
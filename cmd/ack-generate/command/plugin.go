@@ -0,0 +1,63 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package command
+
+import (
+	"bytes"
+	"context"
+	"path/filepath"
+
+	ackplugin "github.com/aws-controllers-k8s/code-generator/pkg/generate/plugin"
+	ackmodel "github.com/aws-controllers-k8s/code-generator/pkg/model"
+)
+
+// runPlugins executes the plugins configured in the generator config that
+// run after the supplied ack-generate command and writes the files they emit
+// into the output directory
+func runPlugins(ctx context.Context, m *ackmodel.Model, target string) error {
+	cfg := m.GetConfig()
+	if cfg == nil || len(cfg.Plugins) == 0 {
+		return nil
+	}
+	var req *ackplugin.Request
+	for _, pluginCfg := range cfg.Plugins {
+		if !pluginCfg.RunsFor(target) {
+			continue
+		}
+		if req == nil {
+			doc, err := m.Document()
+			if err != nil {
+				return err
+			}
+			req = &ackplugin.Request{
+				Target: target,
+				Model:  doc,
+			}
+		}
+		files, err := ackplugin.Run(
+			ctx, pluginCfg, filepath.Dir(optGeneratorConfigPath), req,
+		)
+		if err != nil {
+			return err
+		}
+		buffers := make(map[string]*bytes.Buffer, len(files))
+		for path, contents := range files {
+			buffers[path] = bytes.NewBuffer(contents)
+		}
+		if err = writeGeneratedFiles(optOutputPath, buffers); err != nil {
+			return err
+		}
+	}
+	return nil
+}
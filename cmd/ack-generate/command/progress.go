@@ -0,0 +1,89 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package command
+
+import (
+	"fmt"
+	"os"
+	"sync"
+
+	"github.com/aws-controllers-k8s/code-generator/pkg/generate/templateset"
+	ackgenlog "github.com/aws-controllers-k8s/code-generator/pkg/log"
+)
+
+var progressLog = ackgenlog.Log().WithName("progress")
+
+// progress reports the progress of long-running operations. When stderr is a
+// terminal, each report overwrites the previous one on the same line.
+// Otherwise reports are logged at verbosity level 1, so they don't flood CI
+// logs.
+type progress struct {
+	mu       sync.Mutex
+	terminal bool
+	active   bool
+}
+
+// newProgress returns a progress reporter for stderr
+func newProgress() *progress {
+	terminal := false
+	if fi, err := os.Stderr.Stat(); err == nil {
+		terminal = fi.Mode()&os.ModeCharDevice != 0
+	}
+	return &progress{terminal: terminal}
+}
+
+// Report reports a progress message for the supplied operation
+func (p *progress) Report(operation string, message string) {
+	if !optProgress {
+		return
+	}
+	if !p.terminal {
+		progressLog.V(1).Info(message, "operation", operation)
+		return
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	// Return to the start of the line and clear it before writing
+	fmt.Fprintf(os.Stderr, "\r\033[K%s: %s", operation, message)
+	p.active = true
+}
+
+// Done clears the last progress message, if any
+func (p *progress) Done() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.active {
+		fmt.Fprint(os.Stderr, "\r\033[K")
+		p.active = false
+	}
+}
+
+// gitProgress returns a function reporting the progress messages of the
+// supplied git operation
+func (p *progress) gitProgress(operation string) func(string) {
+	return func(message string) {
+		p.Report(operation, message)
+	}
+}
+
+// executeTemplates executes the templates of the supplied TemplateSet,
+// reporting which file is being rendered
+func executeTemplates(ts *templateset.TemplateSet) error {
+	p := newProgress()
+	defer p.Done()
+	ts.SetProgress(func(done int, total int, path string) {
+		p.Report("rendering", fmt.Sprintf("[%d/%d] %s", done, total, path))
+	})
+	return ts.Execute()
+}
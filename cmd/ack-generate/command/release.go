@@ -16,7 +16,6 @@ package command
 import (
 	"context"
 	"fmt"
-	"io/ioutil"
 	"path/filepath"
 	"strings"
 
@@ -84,31 +83,19 @@ func generateRelease(cmd *cobra.Command, args []string) error {
 	}
 
 	ts, err := ackgenerate.Release(
-		m, metadata, optTemplateDirs,
+		m, metadata, templateDirs(),
 		releaseVersion, optImageRepository, optServiceAccountName,
 	)
 	if err != nil {
 		return err
 	}
 
-	if err = ts.Execute(); err != nil {
+	if err = executeTemplates(ts); err != nil {
 		return err
 	}
 
-	for path, contents := range ts.Executed() {
-		if optDryRun {
-			fmt.Printf("============================= %s ======================================\n", path)
-			fmt.Println(strings.TrimSpace(contents.String()))
-			continue
-		}
-		outPath := filepath.Join(optReleaseOutputPath, path)
-		outDir := filepath.Dir(outPath)
-		if _, err := ensureDir(outDir); err != nil {
-			return err
-		}
-		if err = ioutil.WriteFile(outPath, contents.Bytes(), 0666); err != nil {
-			return err
-		}
+	if err = writeGeneratedFiles(optReleaseOutputPath, ts.Executed()); err != nil {
+		return err
 	}
 	return nil
 }
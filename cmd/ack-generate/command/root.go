@@ -19,6 +19,9 @@ import (
 	"path/filepath"
 
 	"github.com/spf13/cobra"
+
+	ackgenlog "github.com/aws-controllers-k8s/code-generator/pkg/log"
+	acksdk "github.com/aws-controllers-k8s/code-generator/pkg/sdk"
 )
 
 const (
@@ -34,22 +37,36 @@ var (
 	optCacheDir            string
 	optRefreshCache        bool
 	optAWSSDKGoVersion     string
+	optAWSSDKGoPath        string
+	optAWSSDKGoRepoURL     string
+	optAWSSDKGoArchive     bool
+	optModelFormat         string
+	optExtraModelsDir      string
+	optModelFile           string
 	defaultTemplateDirs    []string
 	optTemplateDirs        []string
+	optTemplatesDir        string
 	defaultServicesDir     string
 	optServicesDir         string
 	optDryRun              bool
+	optDiff                bool
+	optCheck               bool
 	sdkDir                 string
 	optGeneratorConfigPath string
 	optMetadataConfigPath  string
 	optOutputPath          string
+	optVerbosity           int
+	optLogFormat           string
+	optProgress            bool
 )
 
 var rootCmd = &cobra.Command{
-	Use:          appName,
-	Short:        appShortDesc,
-	Long:         appLongDesc,
-	SilenceUsage: true,
+	Use:                appName,
+	Short:              appShortDesc,
+	Long:               appLongDesc,
+	SilenceUsage:       true,
+	PersistentPreRunE:  configureLogging,
+	PersistentPostRunE: reportStaleFiles,
 }
 
 func init() {
@@ -94,12 +111,30 @@ func init() {
 			}
 		}
 	}
+	rootCmd.PersistentFlags().CountVarP(
+		&optVerbosity, "verbose", "v", "Increase log verbosity. Pass once to log which generator config rules were applied and which API operations were matched to which resources, twice to also log per-field decisions",
+	)
+	rootCmd.PersistentFlags().StringVar(
+		&optLogFormat, "log-format", ackgenlog.FormatText, "Format of the log entries written to stderr. Either 'text' or 'json'",
+	)
+	rootCmd.PersistentFlags().BoolVar(
+		&optProgress, "progress", true, "If true, reports the progress of cloning the aws-sdk-go repository and rendering templates. When stderr is not a terminal, progress is only logged with --verbose",
+	)
+	rootCmd.PersistentFlags().BoolVar(
+		&optDryRun, "dry-run", false, "If true, renders all files in memory and prints the list of files that would be written, without touching the output directory",
+	)
+	rootCmd.PersistentFlags().BoolVar(
+		&optDiff, "diff", false, "If true, and --dry-run or --check is set, also prints a unified diff of every file that would be changed",
+	)
 	rootCmd.PersistentFlags().BoolVar(
-		&optDryRun, "dry-run", false, "If true, outputs all files to stdout",
+		&optCheck, "check", false, "If true, renders all files in memory, compares them with the output directory without touching it, and fails if any generated file is out of date, or if the output directory has obsolete generated files",
 	)
 	rootCmd.PersistentFlags().StringSliceVar(
 		&optTemplateDirs, "template-dirs", defaultTemplateDirs, "Paths to directories with templates to use in code generation. Note that the order in which directories is specified will be used to provide override functionality.",
 	)
+	rootCmd.PersistentFlags().StringVar(
+		&optTemplatesDir, "templates-dir", "", "Path to a directory with templates that shadow the built-in templates of the same relative path. Templates not found in this directory fall back to those in --template-dirs",
+	)
 	rootCmd.PersistentFlags().StringVar(
 		&optServicesDir, "services-dir", defaultServicesDir, "Path to directory to output service-specific code",
 	)
@@ -107,7 +142,7 @@ func init() {
 		&optCacheDir, "cache-dir", defaultCacheDir, "Path to directory to store cached files (including clone'd aws-sdk-go repo)",
 	)
 	rootCmd.PersistentFlags().BoolVar(
-		&optRefreshCache, "refresh-cache", true, "If true, and aws-sdk-go repo is already cloned, will git pull the latest aws-sdk-go commit",
+		&optRefreshCache, "refresh-cache", true, "If true, and aws-sdk-go repo is already cloned, will fetch the requested aws-sdk-go tag if it is missing from the local clone",
 	)
 	rootCmd.PersistentFlags().StringVar(
 		&optGeneratorConfigPath, "generator-config-path", "", "Path to file containing instructions for code generation to use",
@@ -121,6 +156,37 @@ func init() {
 	rootCmd.PersistentFlags().StringVar(
 		&optAWSSDKGoVersion, "aws-sdk-go-version", "", "Version of github.com/aws/aws-sdk-go used to generate apis and controllers files",
 	)
+	rootCmd.PersistentFlags().StringVar(
+		&optAWSSDKGoPath, "aws-sdk-go-path", "", "Path to an existing local copy of the github.com/aws/aws-sdk-go repository (or Go module cache directory) to read API models from. When set, no git operations are performed and --aws-sdk-go-version is ignored",
+	)
+	rootCmd.PersistentFlags().StringVar(
+		&optExtraModelsDir, "extra-models-dir", "", "Path to a directory of additional api-2 service API models laid out like the aws-sdk-go models/apis directory (<service>/<api-version>/api-2.json). Its files are overlaid on top of the SDK's, e.g. to generate from models not yet released in the SDK",
+	)
+	rootCmd.PersistentFlags().StringVar(
+		&optAWSSDKGoRepoURL, "aws-sdk-go-repo-url", "", "URL of a git mirror of the SDK repository to clone it from, e.g. behind a proxy. Defaults to $"+sdkRepoURLEnvVar+", or else the SDK repository. HTTP(S) credentials are read from the URL or from $"+sdkRepoUsernameEnvVar+" and $"+sdkRepoPasswordEnvVar+". SSH URLs use the private key in $"+sdkRepoSSHKeyEnvVar+", or the SSH agent",
+	)
+	rootCmd.PersistentFlags().BoolVar(
+		&optAWSSDKGoArchive, "aws-sdk-go-archive", false, "If true, downloads the source code archive of the aws-sdk-go version over HTTP(S) from --aws-sdk-go-repo-url instead of cloning the git repository, which is faster and works where the git protocol is blocked. Generating API conversion functions still requires a clone",
+	)
+	rootCmd.PersistentFlags().StringVar(
+		&optModelFile, "model-file", "", "Path to a model file written by `ack-generate model dump --include-source`; dumps written without --include-source cannot be loaded. When set, the model is loaded from this file, the AWS SDK is not read and the generator config embedded in the file is used, so --generator-config-path must not be set",
+	)
+	rootCmd.PersistentFlags().StringVar(
+		&optModelFormat, "model-format", acksdk.ModelFormatAPI2, "Format of the service API models to generate from. One of 'api-2' (aws-sdk-go), 'smithy' (aws-sdk-go-v2) or 'cloudcontrol' (CloudFormation resource schemas, requires --aws-sdk-go-path or vendored schemas)",
+	)
+}
+
+// configureLogging sets up the logger used by the code generator packages
+// from the --verbose and --log-format flags
+func configureLogging(_ *cobra.Command, _ []string) error {
+	if !ackgenlog.ValidFormat(optLogFormat) {
+		return fmt.Errorf(
+			"invalid --log-format %q. Must be either %q or %q",
+			optLogFormat, ackgenlog.FormatText, ackgenlog.FormatJSON,
+		)
+	}
+	ackgenlog.Configure(os.Stderr, optVerbosity, optLogFormat)
+	return nil
 }
 
 // Execute adds all child commands to the root command and sets flags
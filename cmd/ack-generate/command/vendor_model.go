@@ -0,0 +1,151 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package command
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	ackgenerate "github.com/aws-controllers-k8s/code-generator/pkg/generate/ack"
+	ackgenconfig "github.com/aws-controllers-k8s/code-generator/pkg/generate/config"
+	acksdk "github.com/aws-controllers-k8s/code-generator/pkg/sdk"
+	"github.com/aws-controllers-k8s/code-generator/pkg/util"
+)
+
+var vendorModelCmd = &cobra.Command{
+	Use:   "vendor-model <service>",
+	Short: "Copies the AWS service API model files into the service controller repository",
+	Long: `Copies the AWS service API model files used for code generation into the
+service controller repository. Subsequent invocations of ack-generate against
+the same output directory will use the vendored model files instead of
+cloning the aws-sdk-go repository.`,
+	RunE: vendorModel,
+}
+
+func init() {
+	rootCmd.AddCommand(vendorModelCmd)
+}
+
+// vendorModel copies the service API model files for the SDK version being
+// generated from into the output directory, preserving the SDK's directory
+// layout.
+func vendorModel(cmd *cobra.Command, args []string) error {
+	if len(args) != 1 {
+		return fmt.Errorf("please specify the service alias for the AWS service API to vendor")
+	}
+	svcAlias := strings.ToLower(args[0])
+	if optOutputPath == "" {
+		optOutputPath = filepath.Join(optServicesDir, svcAlias)
+	}
+
+	// Always read from the SDK repository itself, never from a previously
+	// vendored copy.
+	if optAWSSDKGoPath != "" {
+		if err := useLocalSDKPath(optAWSSDKGoPath); err != nil {
+			return err
+		}
+	} else {
+		ctx, cancel := contextWithSigterm(context.Background())
+		defer cancel()
+		if err := cloneSDKRepo(ctx, optCacheDir, optRefreshCache); err != nil {
+			return err
+		}
+	}
+
+	cfg, err := ackgenconfig.New(optGeneratorConfigPath, ackgenerate.DefaultConfig)
+	if err != nil {
+		return err
+	}
+	modelName := strings.ToLower(cfg.ModelName)
+	if modelName == "" {
+		modelName = svcAlias
+	}
+	sdkHelper := acksdk.NewHelper(sdkDir, cfg)
+	if err := sdkHelper.WithModelFormat(optModelFormat); err != nil {
+		return err
+	}
+	sdkHelper.WithExtraModelsPath(optExtraModelsDir)
+	modelPath, _, err := sdkHelper.ModelAndDocsPath(modelName)
+	if err != nil || !util.FileExists(modelPath) {
+		retryModelName, err := FallBackFindServiceID(sdkDir, svcAlias)
+		if err != nil {
+			return err
+		}
+		// Retry using path found by querying service ID
+		modelPath, _, err = sdkHelper.ModelAndDocsPath(retryModelName)
+		if err != nil || !util.FileExists(modelPath) {
+			return fmt.Errorf("service %s not found", svcAlias)
+		}
+		modelName = retryModelName
+	}
+
+	// api-2.json models are accompanied by docs, paginators and waiters
+	// files in the same directory, all of which are read by the model loader.
+	// Smithy models are self-contained and Cloud Control models are the
+	// resource schemas in the model directory.
+	srcPaths, err := sdkHelper.ModelFilePaths(modelName)
+	if err != nil {
+		return err
+	}
+	fileNames := []string{}
+	for fileName := range srcPaths {
+		fileNames = append(fileNames, fileName)
+	}
+	sort.Strings(fileNames)
+
+	for _, fileName := range fileNames {
+		srcPath := srcPaths[fileName]
+		relPath, err := vendoredModelPath(srcPath)
+		if err != nil {
+			return err
+		}
+		destPath := filepath.Join(optOutputPath, relPath)
+		if optDryRun {
+			fmt.Printf("%s -> %s\n", srcPath, destPath)
+			continue
+		}
+		if _, err := ensureDir(filepath.Dir(destPath)); err != nil {
+			return err
+		}
+		if err := util.CopyFile(srcPath, destPath); err != nil {
+			return fmt.Errorf("cannot vendor model file %s: %v", srcPath, err)
+		}
+	}
+	return nil
+}
+
+// vendoredModelPath returns the path, relative to the output directory, the
+// supplied model file is vendored to. Files of the extra models are vendored
+// into the SDK's `models/apis` directory, whose layout they share.
+func vendoredModelPath(srcPath string) (string, error) {
+	if optExtraModelsDir != "" {
+		extraPath, err := filepath.Abs(optExtraModelsDir)
+		if err != nil {
+			return "", err
+		}
+		absPath, err := filepath.Abs(srcPath)
+		if err != nil {
+			return "", err
+		}
+		if relPath, err := filepath.Rel(extraPath, absPath); err == nil && !strings.HasPrefix(relPath, "..") {
+			return filepath.Join("models", "apis", relPath), nil
+		}
+	}
+	return filepath.Rel(sdkDir, srcPath)
+}
@@ -0,0 +1,96 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package command
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	ackgenerate "github.com/aws-controllers-k8s/code-generator/pkg/generate/ack"
+	ackmetadata "github.com/aws-controllers-k8s/code-generator/pkg/metadata"
+)
+
+var webhookCmd = &cobra.Command{
+	Use:   "webhook <service>",
+	Short: "Generates Go files and manifests for the admission and conversion webhooks of a given service",
+	Long: `Generates the defaulting and validating webhooks of every resource of the
+latest API version of a service, along with the manifests needed to deploy
+them. Immutable fields configured in generator.yaml are rejected by the
+validating webhook on update. When the service metadata file lists more than
+one available API version, the CRD patches enabling the conversion webhook
+are generated as well.`,
+	RunE: generateWebhook,
+}
+
+func init() {
+	rootCmd.AddCommand(webhookCmd)
+}
+
+// generateWebhook generates the Go files and manifests for a service's
+// webhooks
+func generateWebhook(cmd *cobra.Command, args []string) error {
+	if len(args) != 1 {
+		return fmt.Errorf("please specify the service alias for the AWS service API to generate")
+	}
+	svcAlias := strings.ToLower(args[0])
+	if optOutputPath == "" {
+		optOutputPath = filepath.Join(optServicesDir, svcAlias)
+	}
+
+	withConversion, err := hasMultipleAPIVersions()
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := contextWithSigterm(context.Background())
+	defer cancel()
+	if err := ensureSDKRepo(ctx, optCacheDir, optRefreshCache); err != nil {
+		return err
+	}
+	m, err := loadModelWithLatestAPIVersion(svcAlias)
+	if err != nil {
+		return err
+	}
+	ts, err := ackgenerate.Webhooks(m, templateDirs(), withConversion)
+	if err != nil {
+		return err
+	}
+
+	if err = executeTemplates(ts); err != nil {
+		return err
+	}
+
+	if err = writeGeneratedFiles(optOutputPath, ts.Executed()); err != nil {
+		return err
+	}
+	return nil
+}
+
+// hasMultipleAPIVersions returns true if the service metadata file lists more
+// than one available API version, in which case the API versions must be
+// converted between each other by a conversion webhook.
+func hasMultipleAPIVersions() (bool, error) {
+	if optMetadataConfigPath == "" {
+		return false, nil
+	}
+	metadata, err := ackmetadata.NewServiceMetadata(optMetadataConfigPath)
+	if err != nil {
+		return false, err
+	}
+	return len(metadata.GetAvailableAPIVersions()) > 1, nil
+}
@@ -14,12 +14,16 @@
 package ack
 
 import (
+	"fmt"
 	"path/filepath"
 	"strings"
 	ttpl "text/template"
 
+	"github.com/aws-controllers-k8s/code-generator/pkg/generate/code"
 	"github.com/aws-controllers-k8s/code-generator/pkg/generate/templateset"
+	ackmetadata "github.com/aws-controllers-k8s/code-generator/pkg/metadata"
 	ackmodel "github.com/aws-controllers-k8s/code-generator/pkg/model"
+	"github.com/aws-controllers-k8s/code-generator/pkg/model/multiversion"
 	"github.com/iancoleman/strcase"
 )
 
@@ -37,7 +41,11 @@ var (
 	}
 	apisCopyPaths = []string{}
 	apisFuncMap   = ttpl.FuncMap{
-		"Join": strings.Join,
+		"Join":    strings.Join,
+		"ToLower": strings.ToLower,
+		"GoCodeConvert": func(delta *multiversion.CRDDelta, toHub bool, hubImportAlias string, sourceVarName string, targetVarName string, indentLevel int) string {
+			return code.ConvertResource(delta, toHub, hubImportAlias, sourceVarName, targetVarName, indentLevel)
+		},
 	}
 )
 
@@ -67,27 +75,188 @@ func APIs(
 		apisFuncMap,
 	)
 
+	// The Go package of each API group gets its own copy of the enums and
+	// type definitions, so that the packages don't depend on each other
 	metaVars := m.MetaVars()
-	apiVars := &templateAPIVars{
-		metaVars,
-		enumDefs,
-		typeDefs,
+	groups := append([]string{metaVars.APIGroup}, m.AdditionalAPIGroups()...)
+	for _, group := range groups {
+		groupDir := m.APIGroupDir(group)
+		apiVars := &templateAPIVars{
+			m.APIGroupMetaVars(group),
+			enumDefs,
+			typeDefs,
+		}
+		for _, path := range apisTemplatePaths {
+			outPath := filepath.Join(groupDir, strings.TrimSuffix(filepath.Base(path), ".tpl"))
+			if err = ts.Add(outPath, path, apiVars); err != nil {
+				return nil, err
+			}
+		}
+
+		if m.GetConfig().ResourceContainsReferences() {
+			outPath := filepath.Join(groupDir, "references.go")
+			if err = ts.Add(outPath, "apis/references.go.tpl", apiVars); err != nil {
+				return nil, err
+			}
+		}
 	}
-	for _, path := range apisTemplatePaths {
-		outPath := strings.TrimSuffix(filepath.Base(path), ".tpl")
-		if err = ts.Add(outPath, path, apiVars); err != nil {
+
+	for _, crd := range crds {
+		crdFileName := filepath.Join(
+			m.APIGroupDir(crd.APIGroup()), strcase.ToSnake(crd.Kind)+".go",
+		)
+		crdVars := &templateCRDTypeVars{
+			templateCRDVars{
+				m.CRDMetaVars(crd),
+				m.SDKAPI,
+				crd,
+			},
+			m.HubVersion() == metaVars.APIVersion,
+			ackmetadata.ServiceVersion{
+				APIVersion: metaVars.APIVersion,
+				Status:     ackmetadata.APIStatusAvailable,
+			},
+		}
+		if err = ts.Add(crdFileName, "apis/crd.go.tpl", crdVars); err != nil {
 			return nil, err
 		}
 	}
+	return ts, nil
+}
 
-	for _, crd := range crds {
-		crdFileName := strcase.ToSnake(crd.Kind) + ".go"
+// ConversionFunctions returns a pointer to a TemplateSet containing the
+// templates for generating the conversion functions between each spoke API
+// version and the hub API version of a service. The type definitions of the
+// resources are generated again for every API version, so that only those of
+// the hub API version are marked as the storage version. Output paths are
+// relative to the apis/ directory of the service controller.
+func ConversionFunctions(
+	mgr *multiversion.APIVersionManager,
+	templateBasePaths []string,
+) (*templateset.TemplateSet, error) {
+	ts := templateset.New(
+		templateBasePaths,
+		apisIncludePaths,
+		apisCopyPaths,
+		apisFuncMap,
+	)
+
+	hubVersion := mgr.GetHubVersion()
+	hubModel, err := mgr.GetModel(hubVersion)
+	if err != nil {
+		return nil, err
+	}
+	hubCRDs, err := hubModel.GetCRDs()
+	if err != nil {
+		return nil, err
+	}
+	for _, crd := range hubCRDs {
+		hubDir := filepath.Join(hubVersion, hubModel.APIGroupDir(crd.APIGroup()))
+		outPath := filepath.Join(
+			hubDir, strcase.ToSnake(crd.Kind)+"_conversion.go",
+		)
 		crdVars := &templateCRDVars{
-			metaVars,
-			m.SDKAPI,
+			hubModel.CRDMetaVars(crd),
+			hubModel.SDKAPI,
 			crd,
 		}
-		if err = ts.Add(crdFileName, "apis/crd.go.tpl", crdVars); err != nil {
+		if err = ts.Add(outPath, "apis/webhooks/conversion/hub.go.tpl", crdVars); err != nil {
+			return nil, err
+		}
+		crdTypeVars := &templateCRDTypeVars{
+			*crdVars,
+			true,
+			mgr.GetServiceVersion(hubVersion),
+		}
+		outPath = filepath.Join(hubDir, strcase.ToSnake(crd.Kind)+".go")
+		if err = ts.Add(outPath, "apis/crd.go.tpl", crdTypeVars); err != nil {
+			return nil, err
+		}
+	}
+
+	for _, spokeVersion := range mgr.GetSpokeVersions() {
+		spokeModel, err := mgr.GetModel(spokeVersion)
+		if err != nil {
+			return nil, err
+		}
+		spokeCRDs, err := spokeModel.GetCRDs()
+		if err != nil {
+			return nil, err
+		}
+		deltas, err := mgr.CompareHubWith(spokeVersion)
+		if err != nil {
+			return nil, err
+		}
+		for _, crd := range spokeCRDs {
+			delta, ok := deltas[crd.Names.Camel]
+			if !ok {
+				return nil, fmt.Errorf(
+					"cannot find %s in hub version %s", crd.Names.Camel, hubVersion,
+				)
+			}
+			spokeMetaVars := spokeModel.CRDMetaVars(crd)
+			spokeDir := filepath.Join(spokeVersion, spokeModel.APIGroupDir(crd.APIGroup()))
+			outPath := filepath.Join(
+				spokeDir, strcase.ToSnake(crd.Kind)+"_conversion.go",
+			)
+			conversionVars := &templateConversionVars{
+				spokeMetaVars,
+				crd,
+				hubVersion,
+				hubModel.APIPackagePath(crd.APIGroup()),
+				delta,
+			}
+			if err = ts.Add(outPath, "apis/webhooks/conversion/spoke.go.tpl", conversionVars); err != nil {
+				return nil, err
+			}
+			crdTypeVars := &templateCRDTypeVars{
+				templateCRDVars{
+					spokeMetaVars,
+					spokeModel.SDKAPI,
+					crd,
+				},
+				false,
+				mgr.GetServiceVersion(spokeVersion),
+			}
+			outPath = filepath.Join(spokeDir, strcase.ToSnake(crd.Kind)+".go")
+			if err = ts.Add(outPath, "apis/crd.go.tpl", crdTypeVars); err != nil {
+				return nil, err
+			}
+		}
+	}
+	return ts, nil
+}
+
+// CRDVersionPatches returns a pointer to a TemplateSet containing the
+// templates for generating the patches setting which of the supplied API
+// versions of each custom resource definition are served and deprecated.
+// Output paths are relative to the service controller's root directory.
+func CRDVersionPatches(
+	m *ackmodel.Model,
+	versions []ackmetadata.ServiceVersion,
+	templateBasePaths []string,
+) (*templateset.TemplateSet, error) {
+	crds, err := m.GetCRDs()
+	if err != nil {
+		return nil, err
+	}
+	ts := templateset.New(
+		templateBasePaths,
+		apisIncludePaths,
+		apisCopyPaths,
+		apisFuncMap,
+	)
+	for _, crd := range crds {
+		outPath := filepath.Join(
+			"config/crd/patches",
+			"versions_in_"+strings.ToLower(crd.Plural)+".yaml",
+		)
+		versionsVars := &templateCRDVersionsVars{
+			m.CRDMetaVars(crd),
+			crd,
+			versions,
+		}
+		if err = ts.Add(outPath, "config/crd/patches/versions_in_crd.yaml.tpl", versionsVars); err != nil {
 			return nil, err
 		}
 	}
@@ -109,3 +278,37 @@ type templateCRDVars struct {
 	SDKAPI *ackmodel.SDKAPI
 	CRD    *ackmodel.CRD
 }
+
+// templateCRDTypeVars contains template variables for the template that
+// outputs the Go type definitions of a single top-level resource
+type templateCRDTypeVars struct {
+	templateCRDVars
+	// IsStorageVersion is true if the resources are stored in this API
+	// version, which is the conversion hub
+	IsStorageVersion bool
+	// Version tells whether this API version is deprecated or not served
+	// anymore
+	Version ackmetadata.ServiceVersion
+}
+
+// templateConversionVars contains template variables for the template that
+// outputs the conversion functions of a single top-level resource in a spoke
+// API version
+type templateConversionVars struct {
+	templateset.MetaVars
+	CRD        *ackmodel.CRD
+	HubVersion string
+	// HubPackagePath is the path, relative to the root of the service
+	// controller, of the Go package of the resource's hub API version
+	HubPackagePath string
+	Delta          *multiversion.CRDDelta
+}
+
+// templateCRDVersionsVars contains template variables for the template that
+// outputs the patch of the API versions of a single custom resource
+// definition
+type templateCRDVersionsVars struct {
+	templateset.MetaVars
+	CRD      *ackmodel.CRD
+	Versions []ackmetadata.ServiceVersion
+}
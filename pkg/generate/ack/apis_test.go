@@ -0,0 +1,65 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package ack_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/aws-controllers-k8s/code-generator/pkg/generate/ack"
+	"github.com/aws-controllers-k8s/code-generator/pkg/testutil"
+)
+
+// generateCRDFile returns the contents of the file the apis generator renders
+// for the supplied kind of the supplied model
+func generateCRDFile(
+	t *testing.T,
+	serviceAlias string,
+	kind string,
+	options *testutil.TestingModelOptions,
+) string {
+	m := testutil.NewModelForServiceWithOptions(t, serviceAlias, options)
+	ts, err := ack.APIs(m, templateBasePaths)
+	require.Nil(t, err)
+	require.Nil(t, ts.Execute())
+	for _, contents := range ts.Executed() {
+		if strings.Contains(contents.String(), "\ntype "+kind+" struct {") {
+			return contents.String()
+		}
+	}
+	t.Fatalf("no file rendered for kind %s", kind)
+	return ""
+}
+
+func TestAPIs_ECR_Repository_MetadataMarker(t *testing.T) {
+	assert := assert.New(t)
+
+	crdFile := generateCRDFile(t, "ecr", "Repository", &testutil.TestingModelOptions{
+		GeneratorConfigFile: "generator-with-crd-metadata.yaml",
+	})
+	assert.Contains(
+		crdFile,
+		"\n// +kubebuilder:metadata:"+
+			`annotations={"operators.operatorframework.io/internal-objects=false"},`+
+			`labels={"app.kubernetes.io/part-of=ack","team=registry"}`+"\n",
+	)
+
+	// Without labels nor annotations, controller-gen would reject a marker
+	// without arguments
+	crdFile = generateCRDFile(t, "ecr", "Repository", &testutil.TestingModelOptions{})
+	assert.NotContains(crdFile, "+kubebuilder:metadata")
+}
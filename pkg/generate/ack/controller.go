@@ -14,6 +14,7 @@
 package ack
 
 import (
+	"encoding/json"
 	"path/filepath"
 	"strings"
 	ttpl "text/template"
@@ -50,6 +51,13 @@ var (
 		"pkg/resource/sdk_update_custom.go.tpl",
 		"pkg/resource/sdk_update_set_attributes.go.tpl",
 		"pkg/resource/sdk_update_not_implemented.go.tpl",
+		"pkg/resource/sdk_update_operations.go.tpl",
+		"pkg/resource/sdk_update_upsert.go.tpl",
+		"pkg/resource/sdk_observe_only.go.tpl",
+		"pkg/resource/sdk_tags.go.tpl",
+		"pkg/resource/sdk_secondary_operations.go.tpl",
+		"pkg/resource/sdk_split.go.tpl",
+		"pkg/resource/sdk_cloud_control.go.tpl",
 	}
 	controllerCopyPaths = []string{}
 	controllerFuncMap   = ttpl.FuncMap{
@@ -99,6 +107,15 @@ var (
 		"GoCodeSetDeleteInput": func(r *ackmodel.CRD, sourceVarName string, targetVarName string, indentLevel int) string {
 			return code.SetSDK(r.Config(), r, ackmodel.OpTypeDelete, sourceVarName, targetVarName, indentLevel)
 		},
+		"GoCodeMergeSplitUpdateInput": func(r *ackmodel.CRD, sourceVarName string, targetVarName string, indentLevel int) string {
+			return code.MergeSplitInput(r.Config(), r, r.Ops.Update, sourceVarName, targetVarName, indentLevel)
+		},
+		"GoCodeSetUpdateOperationInput": func(r *ackmodel.CRD, op *ackmodel.UpdateOp, sourceVarName string, targetVarName string, indentLevel int) string {
+			return code.SetSDKForOperation(r.Config(), r, op.Operation, sourceVarName, targetVarName, indentLevel)
+		},
+		"GoCodeSetSecondaryOperationInput": func(r *ackmodel.CRD, op *ackmodel.SecondaryOp, sourceVarName string, targetVarName string, indentLevel int) string {
+			return code.SetSDKForOperation(r.Config(), r, op.Operation, sourceVarName, targetVarName, indentLevel)
+		},
 		"GoCodeSetSDKForStruct": func(r *ackmodel.CRD, targetFieldName string, targetVarName string, targetShapeRef *awssdkmodel.ShapeRef, sourceFieldPath string, sourceVarName string, indentLevel int) string {
 			return code.SetSDKForStruct(r.Config(), r, targetFieldName, targetVarName, targetShapeRef, sourceFieldPath, sourceVarName, indentLevel)
 		},
@@ -149,6 +166,24 @@ var (
 		"GoCodeIncompleteLateInitialization": func(r *ackmodel.CRD, resVarName string, indentLevel int) string {
 			return code.IncompleteLateInitialization(r.Config(), r, resVarName, indentLevel)
 		},
+		"GoCodeReadBackSpecDefaults": func(r *ackmodel.CRD, sourceVarName string, targetVarName string, indentLevel int) string {
+			return code.ReadBackSpecDefaults(r.Config(), r, sourceVarName, targetVarName, indentLevel)
+		},
+		"GoCodeCRTagsToMap": func(r *ackmodel.CRD, sourceVarName string, targetVarName string, indentLevel int) string {
+			return code.CRTagsToMap(r, sourceVarName, targetVarName, indentLevel)
+		},
+		"GoCodeListTagsOutputToMap": func(r *ackmodel.CRD, sourceVarName string, targetVarName string, indentLevel int) string {
+			return code.ListTagsOutputToMap(r, sourceVarName, targetVarName, indentLevel)
+		},
+		"GoCodeSetTagResourceInputTags": func(r *ackmodel.CRD, sourceVarName string, targetVarName string, indentLevel int) string {
+			return code.SetTagResourceInputTags(r.Config(), r, sourceVarName, targetVarName, indentLevel)
+		},
+		"GoCodeExportData": func(r *ackmodel.CRD, sourceVarName string, targetVarName string, indentLevel int) string {
+			return code.ExportData(r, sourceVarName, targetVarName, indentLevel)
+		},
+		"GoCodeResyncPeriod": func(r *ackmodel.CRD, sourceVarName string, indentLevel int) string {
+			return code.ResyncPeriod(r, sourceVarName, indentLevel)
+		},
 	}
 )
 
@@ -169,7 +204,7 @@ func Controller(
 	// in any of our base paths...
 	controllerFuncMap["Hook"] = func(r *ackmodel.CRD, hookID string) string {
 		crdVars := &templateCRDVars{
-			metaVars,
+			m.CRDMetaVars(r),
 			m.SDKAPI,
 			r,
 		}
@@ -195,6 +230,7 @@ func Controller(
 		"identifiers.go.tpl",
 		"manager.go.tpl",
 		"manager_factory.go.tpl",
+		"references.go.tpl",
 		"resource.go.tpl",
 		"sdk.go.tpl",
 	}
@@ -203,7 +239,7 @@ func Controller(
 			outPath := filepath.Join("pkg/resource", crd.Names.Snake, strings.TrimSuffix(target, ".tpl"))
 			tplPath := filepath.Join("pkg/resource", target)
 			crdVars := &templateCRDVars{
-				metaVars,
+				m.CRDMetaVars(crd),
 				m.SDKAPI,
 				crd,
 			}
@@ -211,6 +247,17 @@ func Controller(
 				return nil, err
 			}
 		}
+		if crd.Export() != nil {
+			outPath := filepath.Join("pkg/resource", crd.Names.Snake, "export.go")
+			crdVars := &templateCRDVars{
+				m.CRDMetaVars(crd),
+				m.SDKAPI,
+				crd,
+			}
+			if err = ts.Add(outPath, "pkg/resource/export.go.tpl", crdVars); err != nil {
+				return nil, err
+			}
+		}
 	}
 
 	configVars := &templateConfigVars{
@@ -234,11 +281,48 @@ func Controller(
 	cmdVars := &templateCmdVars{
 		metaVars,
 		snakeCasedCRDNames,
+		m.GetConfig(),
 	}
 	if err = ts.Add("cmd/controller/main.go", "cmd/controller/main.go.tpl", cmdVars); err != nil {
 		return nil, err
 	}
 
+	// Next add the recommended IAM policy granting the actions of the API
+	// operations the controller calls
+	policyJSON, err := m.IAMPolicyJSON()
+	if err != nil {
+		return nil, err
+	}
+	iamVars := &templateIAMVars{
+		metaVars,
+		policyJSON,
+	}
+	if err = ts.Add("config/iam/policy.json", "config/iam/recommended-inline-policy.tpl", iamVars); err != nil {
+		return nil, err
+	}
+
+	// And the report of the IAM actions performed for each kind of resource
+	for _, crd := range crds {
+		actions := crd.IAMActions()
+		actionsJSON, err := json.MarshalIndent(actions, "", "  ")
+		if err != nil {
+			return nil, err
+		}
+		actionsVars := &templateIAMActionsVars{
+			metaVars,
+			crd,
+			actions,
+			string(actionsJSON),
+		}
+		for _, ext := range []string{"json", "md"} {
+			outPath := filepath.Join("config/iam/actions", crd.Names.Snake+"."+ext)
+			tplPath := "config/iam/actions." + ext + ".tpl"
+			if err = ts.Add(outPath, tplPath, actionsVars); err != nil {
+				return nil, err
+			}
+		}
+	}
+
 	// Finally, add the configuration YAML file templates
 	for _, path := range controllerConfigTemplatePaths {
 		outPath := strings.TrimSuffix(path, ".tpl")
@@ -254,6 +338,7 @@ func Controller(
 type templateCmdVars struct {
 	templateset.MetaVars
 	SnakeCasedCRDNames []string
+	GeneratorConfig    *ackgenconfig.Config
 }
 
 // templateConfigVars contains template variables for the templates that require
@@ -262,3 +347,21 @@ type templateConfigVars struct {
 	templateset.MetaVars
 	GeneratorConfig *ackgenconfig.Config
 }
+
+// templateIAMVars contains template variables for the templates that output
+// the recommended IAM policy of the controller
+type templateIAMVars struct {
+	templateset.MetaVars
+	// PolicyJSON is the JSON document of the IAM policy
+	PolicyJSON string
+}
+
+// templateIAMActionsVars contains template variables for the templates that
+// output the report of the IAM actions performed for a kind of resource
+type templateIAMActionsVars struct {
+	templateset.MetaVars
+	CRD     *ackmodel.CRD
+	Actions *ackmodel.IAMActions
+	// ActionsJSON is the JSON document of the IAM actions
+	ActionsJSON string
+}
@@ -0,0 +1,120 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package ack_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/aws-controllers-k8s/code-generator/pkg/generate/ack"
+	"github.com/aws-controllers-k8s/code-generator/pkg/testutil"
+)
+
+// generateControllerFiles returns the contents of every file the controller
+// generator renders for the supplied model
+func generateControllerFiles(
+	t *testing.T,
+	serviceAlias string,
+	options *testutil.TestingModelOptions,
+) map[string]string {
+	m := testutil.NewModelForServiceWithOptions(t, serviceAlias, options)
+	ts, err := ack.Controller(m, templateBasePaths)
+	require.Nil(t, err)
+	require.Nil(t, ts.Execute())
+	files := map[string]string{}
+	for path, contents := range ts.Executed() {
+		files[path] = contents.String()
+	}
+	return files
+}
+
+func TestController_ECR_Repository_Recreate(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	files := generateControllerFiles(t, "ecr", &testutil.TestingModelOptions{
+		GeneratorConfigFile: "generator-with-recreate-fields.yaml",
+	})
+	manager, found := files["pkg/resource/repository/manager.go"]
+	require.True(found)
+	sdk, found := files["pkg/resource/repository/sdk.go"]
+	require.True(found)
+
+	// Update deletes the latest resource and requeues it, rather than
+	// creating the desired resource right away, until it is deleted
+	assert.Contains(manager, `
+	if isRecreating(latest) || rm.recreateRequested(desired, delta) {
+		// The desired resource is created once the latest resource is
+		// reported as not found
+		recreating, err := rm.recreate(ctx, latest)
+		if err != nil {
+			return rm.onError(latest, err)
+		}
+		return recreating, ackrequeue.NeededAfter(nil, recreateRequeueDelay)
+	}
+`)
+	// Create removes the mark once the desired resource is created
+	assert.Contains(manager, `
+	setRecreatingCondition(created.ko, corev1.ConditionFalse)
+	return rm.onSuccess(created)
+`)
+	// The latest resource is deleted only once
+	assert.Contains(sdk, `
+	if !isRecreating(latest) {
+		if _, err = rm.sdkDelete(ctx, latest); err != nil {
+			return nil, err
+		}
+	}
+`)
+}
+
+func TestController_ECR_Repository_NoRecreate(t *testing.T) {
+	assert := assert.New(t)
+
+	files := generateControllerFiles(t, "ecr", &testutil.TestingModelOptions{})
+	assert.NotContains(files["pkg/resource/repository/manager.go"], "recreate")
+	assert.NotContains(files["pkg/resource/repository/sdk.go"], "recreatingConditionType")
+}
+
+func TestController_RDS_DBInstance_SensitiveFields(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	files := generateControllerFiles(t, "rds", &testutil.TestingModelOptions{
+		GeneratorConfigFile: "generator-with-sensitive-fields.yaml",
+	})
+	delta, found := files["pkg/resource/db_instance/delta.go"]
+	require.True(found)
+	manager, found := files["pkg/resource/db_instance/manager.go"]
+	require.True(found)
+
+	// The delta the resource is updated with keeps the actual values, only
+	// its logged copy is redacted
+	assert.NotContains(delta, "diff.A = redactedValue")
+	assert.Contains(delta, "redactedDiff.A = redactedValue")
+	assert.Contains(delta, `
+func redactedDelta(delta *ackcompare.Delta) *ackcompare.Delta {
+	redacted := ackcompare.NewDelta()
+`)
+	assert.Contains(manager, `
+	rlog.Debug("updating resource", "diff", redactedDelta(delta).Differences)
+	updated, err := rm.sdkUpdate(ctx, desired, latest, delta)
+`)
+
+	// Resources without sensitive fields have nothing to redact
+	assert.NotContains(files["pkg/resource/db_subnet_group/delta.go"], "redactedDelta")
+	assert.NotContains(files["pkg/resource/db_subnet_group/manager.go"], "redactedDelta")
+}
@@ -0,0 +1,61 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package ack_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/aws-controllers-k8s/code-generator/pkg/generate/ack"
+	"github.com/aws-controllers-k8s/code-generator/pkg/generate/templateset"
+	ackmodel "github.com/aws-controllers-k8s/code-generator/pkg/model"
+	"github.com/aws-controllers-k8s/code-generator/pkg/testutil"
+)
+
+var templateBasePaths = []string{"../../../templates"}
+
+// generateFiles builds a fresh model for the supplied service and returns the
+// contents of every file the apis and controller generators render for it
+func generateFiles(t *testing.T, serviceAlias string) map[string]string {
+	m := testutil.NewModelForService(t, serviceAlias)
+	files := map[string]string{}
+	for prefix, generator := range map[string]func(*ackmodel.Model, []string) (*templateset.TemplateSet, error){
+		"apis/":       ack.APIs,
+		"controller/": ack.Controller,
+	} {
+		ts, err := generator(m, templateBasePaths)
+		require.Nil(t, err)
+		require.Nil(t, ts.Execute())
+		for path, contents := range ts.Executed() {
+			files[prefix+path] = contents.String()
+		}
+	}
+	return files
+}
+
+func TestGenerateDeterministic(t *testing.T) {
+	// Each run builds its own model from the API files, so any dependency on
+	// Go's randomized map iteration order shows up as differing output
+	for _, serviceAlias := range []string{"ecr", "elasticache", "sns"} {
+		t.Run(serviceAlias, func(t *testing.T) {
+			expected := generateFiles(t, serviceAlias)
+			require.NotEmpty(t, expected)
+			for x := 0; x < 3; x++ {
+				assert.Equal(t, expected, generateFiles(t, serviceAlias))
+			}
+		})
+	}
+}
@@ -0,0 +1,117 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package ack
+
+import (
+	"path/filepath"
+	"strings"
+	ttpl "text/template"
+
+	"github.com/iancoleman/strcase"
+
+	"github.com/aws-controllers-k8s/code-generator/pkg/generate/templateset"
+	ackmodel "github.com/aws-controllers-k8s/code-generator/pkg/model"
+)
+
+var (
+	docsIncludePaths = []string{}
+	docsCopyPaths    = []string{}
+	docsFuncMap      = ttpl.FuncMap{
+		"ToLower":      strings.ToLower,
+		"ToSnake":      strcase.ToSnake,
+		"DocString":    DocString,
+		"DocTableCell": DocTableCell,
+	}
+)
+
+// Docs returns a pointer to a TemplateSet containing all the templates for
+// generating the API reference documentation of an ACK service controller's
+// custom resources
+func Docs(
+	m *ackmodel.Model,
+	templateBasePaths []string,
+) (*templateset.TemplateSet, error) {
+	typeDefs, err := m.GetTypeDefs()
+	if err != nil {
+		return nil, err
+	}
+	crds, err := m.GetCRDs()
+	if err != nil {
+		return nil, err
+	}
+
+	ts := templateset.New(
+		templateBasePaths,
+		docsIncludePaths,
+		docsCopyPaths,
+		docsFuncMap,
+	)
+
+	metaVars := m.MetaVars()
+	docsPath := filepath.Join("docs", "api", metaVars.APIVersion)
+	for _, crd := range crds {
+		crdVars := &templateCRDVars{
+			m.CRDMetaVars(crd),
+			m.SDKAPI,
+			crd,
+		}
+		outPath := filepath.Join(docsPath, strcase.ToSnake(crd.Kind)+".md")
+		if err = ts.Add(outPath, "docs/api/crd.md.tpl", crdVars); err != nil {
+			return nil, err
+		}
+	}
+
+	docsVars := &templateDocsVars{
+		metaVars,
+		crds,
+		typeDefs,
+	}
+	outPath := filepath.Join(docsPath, "types.md")
+	if err = ts.Add(outPath, "docs/api/types.md.tpl", docsVars); err != nil {
+		return nil, err
+	}
+	outPath = filepath.Join(docsPath, "README.md")
+	if err = ts.Add(outPath, "docs/api/README.md.tpl", docsVars); err != nil {
+		return nil, err
+	}
+	return ts, nil
+}
+
+// DocString returns the plain text of a documentation string formatted as a
+// Go code comment block, suitable for including in a markdown document
+func DocString(goComment string) string {
+	lines := strings.Split(goComment, "\n")
+	for x, line := range lines {
+		line = strings.TrimSpace(line)
+		line = strings.TrimPrefix(line, "//")
+		lines[x] = strings.TrimPrefix(line, " ")
+	}
+	return strings.TrimSpace(strings.Join(lines, "\n"))
+}
+
+// DocTableCell returns the plain text of a documentation string formatted as
+// a Go code comment block, collapsed onto a single line and escaped so that it
+// can be placed in a markdown table cell
+func DocTableCell(goComment string) string {
+	doc := strings.Join(strings.Fields(DocString(goComment)), " ")
+	return strings.Replace(doc, "|", `\|`, -1)
+}
+
+// templateDocsVars contains template variables for the templates that
+// document all the resources and types of an API version
+type templateDocsVars struct {
+	templateset.MetaVars
+	CRDs     []*ackmodel.CRD
+	TypeDefs []*ackmodel.TypeDef
+}
@@ -0,0 +1,40 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package ack_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/aws-controllers-k8s/code-generator/pkg/generate/ack"
+)
+
+func TestDocString(t *testing.T) {
+	assert := assert.New(t)
+
+	goComment := `// The name of the repository.
+//
+// The repository name must be unique | case-sensitive.`
+
+	assert.Equal(
+		"The name of the repository.\n\nThe repository name must be unique | case-sensitive.",
+		ack.DocString(goComment),
+	)
+	assert.Equal(
+		`The name of the repository. The repository name must be unique \| case-sensitive.`,
+		ack.DocTableCell(goComment),
+	)
+	assert.Equal("", ack.DocTableCell(""))
+}
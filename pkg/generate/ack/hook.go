@@ -20,6 +20,7 @@ import (
 	"path/filepath"
 	ttpl "text/template"
 
+	ackgenlog "github.com/aws-controllers-k8s/code-generator/pkg/log"
 	ackmodel "github.com/aws-controllers-k8s/code-generator/pkg/model"
 	ackutil "github.com/aws-controllers-k8s/code-generator/pkg/util"
 )
@@ -61,6 +62,10 @@ code paths:
 * delta_post_compare
 * late_initialize_pre_read_one
 * late_initialize_post_read_one
+* webhook_default
+* webhook_validate_create
+* webhook_validate_update
+* webhook_validate_delete
 
 The "pre_build_request" hooks are called BEFORE the call to construct
 the Input shape that is used in the API operation and therefore BEFORE
@@ -110,8 +115,20 @@ readOne call inside AWSResourceManager.LateInitialize() method
 The "late_initialize_post_read_one" hooks are called AFTER making the
 readOne call inside AWSResourceManager.LateInitialize() method
 
+The "webhook_default" hook is called inside the resource's Default() method
+of the defaulting webhook and has access to the resource as `r`.
+
+The "webhook_validate_*" hooks are called inside the corresponding
+ValidateCreate(), ValidateUpdate() and ValidateDelete() methods of the
+validating webhook. These hooks have access to the resource as `r` and can
+append `*field.Error` values to a Go variable named `allErrs`. The
+"webhook_validate_update" hook also has access to the previous version of the
+resource as `old`.
+
 */
 
+var hookLog = ackgenlog.Log().WithName("hook")
+
 // ResourceHookCode returns a string with custom callback code for a resource
 // and hook identifier
 func ResourceHookCode(
@@ -138,6 +155,7 @@ func ResourceHookCode(
 		return "", nil
 	}
 	if hook.Code != nil {
+		hookLog.V(1).Info("hook fired", "resource", resourceName, "hook", hookID, "source", "inline")
 		return *hook.Code, nil
 	}
 	if hook.TemplatePath == nil {
@@ -179,6 +197,7 @@ func ResourceHookCode(
 			)
 			return "", err
 		}
+		hookLog.V(1).Info("hook fired", "resource", resourceName, "hook", hookID, "source", tplPath)
 		return b.String(), nil
 	}
 	err := fmt.Errorf(
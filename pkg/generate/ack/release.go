@@ -84,6 +84,18 @@ func Release(
 		}
 	}
 
+	policyJSON, err := m.IAMPolicyJSON()
+	if err != nil {
+		return nil, err
+	}
+	iamVars := &templateIAMVars{
+		metaVars,
+		policyJSON,
+	}
+	if err = ts.Add("config/iam/recommended-inline-policy", "config/iam/recommended-inline-policy.tpl", iamVars); err != nil {
+		return nil, err
+	}
+
 	return ts, nil
 }
 
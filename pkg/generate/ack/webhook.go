@@ -0,0 +1,146 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package ack
+
+import (
+	"path/filepath"
+	"strings"
+	ttpl "text/template"
+
+	"github.com/iancoleman/strcase"
+
+	"github.com/aws-controllers-k8s/code-generator/pkg/generate/code"
+	"github.com/aws-controllers-k8s/code-generator/pkg/generate/templateset"
+	ackmodel "github.com/aws-controllers-k8s/code-generator/pkg/model"
+)
+
+var (
+	webhookConfigTemplatePaths = []string{
+		"config/webhook/kustomization.yaml.tpl",
+		"config/webhook/service.yaml.tpl",
+	}
+	webhookIncludePaths = []string{
+		"boilerplate.go.tpl",
+	}
+	webhookCopyPaths = []string{
+		"config/webhook/kustomizeconfig.yaml",
+	}
+	webhookFuncMap = ttpl.FuncMap{
+		"ToLower": strings.ToLower,
+		"Dashed": func(s string) string {
+			return strings.Replace(s, ".", "-", -1)
+		},
+		"GoCodeValidateImmutableFields": func(r *ackmodel.CRD, oldVarName string, newVarName string, errsVarName string, indentLevel int) string {
+			return code.ValidateImmutableFields(r, oldVarName, newVarName, errsVarName, indentLevel)
+		},
+		"GoCodeDeprecationWarnings": func(r *ackmodel.CRD, varName string, warningsVarName string, indentLevel int) string {
+			return code.DeprecationWarnings(r, varName, warningsVarName, indentLevel)
+		},
+	}
+)
+
+// Webhooks returns a pointer to a TemplateSet containing all the templates
+// for generating the defaulting and validating webhooks of an ACK service
+// controller's resources, along with the webhook manifests. When
+// withConversion is true, the CRD patches enabling the conversion webhook
+// are generated as well.
+func Webhooks(
+	m *ackmodel.Model,
+	templateBasePaths []string,
+	withConversion bool,
+) (*templateset.TemplateSet, error) {
+	crds, err := m.GetCRDs()
+	if err != nil {
+		return nil, err
+	}
+
+	metaVars := m.MetaVars()
+
+	webhookFuncMap["Hook"] = func(r *ackmodel.CRD, hookID string) string {
+		crdVars := &templateCRDVars{
+			m.CRDMetaVars(r),
+			m.SDKAPI,
+			r,
+		}
+		code, err := ResourceHookCode(templateBasePaths, r, hookID, crdVars, webhookFuncMap)
+		if err != nil {
+			// It's a compile-time error, so just panic...
+			panic(err)
+		}
+		return code
+	}
+
+	ts := templateset.New(
+		templateBasePaths,
+		webhookIncludePaths,
+		webhookCopyPaths,
+		webhookFuncMap,
+	)
+
+	for _, crd := range crds {
+		crdMetaVars := m.CRDMetaVars(crd)
+		crdVars := &templateCRDVars{
+			crdMetaVars,
+			m.SDKAPI,
+			crd,
+		}
+		outPath := filepath.Join(crdMetaVars.APIPackagePath, strcase.ToSnake(crd.Kind)+"_webhook.go")
+		if err = ts.Add(outPath, "apis/webhooks/webhook.go.tpl", crdVars); err != nil {
+			return nil, err
+		}
+		if !withConversion {
+			continue
+		}
+		outPath = filepath.Join(
+			"config/crd/patches",
+			"webhook_in_"+strings.ToLower(crd.Plural)+".yaml",
+		)
+		if err = ts.Add(outPath, "config/crd/patches/webhook_in_crd.yaml.tpl", crdVars); err != nil {
+			return nil, err
+		}
+	}
+
+	// Each API group's package sets up the webhooks of its own resources
+	groups := append([]string{metaVars.APIGroup}, m.AdditionalAPIGroups()...)
+	for _, group := range groups {
+		groupCRDs, err := m.GetCRDsInAPIGroup(group)
+		if err != nil {
+			return nil, err
+		}
+		groupMetaVars := m.APIGroupMetaVars(group)
+		webhookVars := &templateWebhookVars{
+			groupMetaVars,
+			groupCRDs,
+		}
+		outPath := filepath.Join(groupMetaVars.APIPackagePath, "webhooks.go")
+		if err = ts.Add(outPath, "apis/webhooks/setup.go.tpl", webhookVars); err != nil {
+			return nil, err
+		}
+	}
+
+	for _, path := range webhookConfigTemplatePaths {
+		outPath := strings.TrimSuffix(path, ".tpl")
+		if err = ts.Add(outPath, path, metaVars); err != nil {
+			return nil, err
+		}
+	}
+	return ts, nil
+}
+
+// templateWebhookVars contains template variables for the templates that
+// register the webhooks of all resources in an API version
+type templateWebhookVars struct {
+	templateset.MetaVars
+	CRDs []*ackmodel.CRD
+}
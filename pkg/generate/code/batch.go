@@ -0,0 +1,195 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package code
+
+import (
+	"fmt"
+	"strings"
+
+	awssdkmodel "github.com/aws/aws-sdk-go/private/model/api"
+
+	ackgenconfig "github.com/aws-controllers-k8s/code-generator/pkg/generate/config"
+	"github.com/aws-controllers-k8s/code-generator/pkg/model"
+)
+
+// setSDKForBatchInput returns the Go code that sets the list member of a
+// batch operation's Input shape holding the resources to a list with a
+// single element built from the resource.
+//
+// For a list of scalars, the output looks like this:
+//
+//	if r.ko.Spec.ResourceID != nil {
+//		res.SetResourceIds([]*string{r.ko.Spec.ResourceID})
+//	}
+//
+// For a list of structures, the element's members are set from the Spec
+// fields of the same name:
+//
+//	f0elem := &svcsdk.Entry{}
+//	if r.ko.Spec.Name != nil {
+//		f0elem.SetName(*r.ko.Spec.Name)
+//	}
+//	res.SetEntries([]*svcsdk.Entry{f0elem})
+func setSDKForBatchInput(
+	cfg *ackgenconfig.Config,
+	r *model.CRD,
+	op *awssdkmodel.Operation,
+	memberIndex int,
+	memberName string,
+	memberShapeRef *awssdkmodel.ShapeRef,
+	sourceVarName string,
+	targetVarName string,
+	indentLevel int,
+) string {
+	elemRef, err := model.BatchElementShapeRef(op.Name, memberName, memberShapeRef)
+	if err != nil {
+		// This is a compile-time failure, just bomb out...
+		panic(err.Error())
+	}
+	out := ""
+	indent := strings.Repeat("\t", indentLevel)
+
+	if elemRef.Shape.Type == "structure" {
+		elemVarName := fmt.Sprintf("f%delem", memberIndex)
+		elemGoType := "svcsdk." + elemRef.Shape.ShapeName
+		out += fmt.Sprintf("%s%s := &%s{}\n", indent, elemVarName, elemGoType)
+		out += SetSDKForStruct(
+			cfg, r,
+			memberName,
+			elemVarName,
+			elemRef,
+			"",
+			sourceVarName+cfg.PrefixConfig.SpecField,
+			indentLevel,
+		)
+		out += fmt.Sprintf(
+			"%s%s.Set%s([]*%s{%s})\n",
+			indent, targetVarName, memberName, elemGoType, elemVarName,
+		)
+		return out
+	}
+
+	if !model.IsBatchScalar(elemRef) {
+		// This is a compile-time failure, just bomb out...
+		panic(fmt.Sprintf(
+			"unsupported element type %s of member %s of batch operation %s",
+			elemRef.Shape.Type, memberName, op.Name,
+		))
+	}
+	fieldName, _ := cfg.ResourceFieldRename(
+		r.Names.Original, op.Name, model.BatchElementName(memberName),
+	)
+	sourceAdaptedVarName := sourceVarName
+	var f *model.Field
+	inSpec, inStatus := r.HasMember(fieldName, op.Name)
+	if inSpec {
+		sourceAdaptedVarName += cfg.PrefixConfig.SpecField
+		f = r.SpecFields[fieldName]
+	} else if inStatus {
+		sourceAdaptedVarName += cfg.PrefixConfig.StatusField
+		f = r.StatusFields[fieldName]
+	} else {
+		return ""
+	}
+	sourceAdaptedVarName += "." + f.Names.Camel
+	out += fmt.Sprintf("%sif %s != nil {\n", indent, sourceAdaptedVarName)
+	out += fmt.Sprintf(
+		"%s\t%s.Set%s([]%s{%s})\n",
+		indent, targetVarName, memberName, elemRef.GoType(), sourceAdaptedVarName,
+	)
+	out += fmt.Sprintf("%s}\n", indent)
+	return out
+}
+
+// setResourceForBatchOutput returns the Go code that sets the resource's
+// fields from the first element of the list member of a batch operation's
+// Output shape holding the results for the resources.
+//
+// For a list of scalars, the output looks like this:
+//
+//	if len(resp.FlowLogIds) > 0 {
+//		ko.Status.FlowLogID = resp.FlowLogIds[0]
+//	}
+//
+// For a list of structures, the fields named like the element's scalar
+// members are set:
+//
+//	if len(resp.Successful) > 0 {
+//		ko.Status.ID = resp.Successful[0].Id
+//	}
+func setResourceForBatchOutput(
+	cfg *ackgenconfig.Config,
+	r *model.CRD,
+	op *awssdkmodel.Operation,
+	memberName string,
+	memberShapeRef *awssdkmodel.ShapeRef,
+	sourceVarName string,
+	targetVarName string,
+	indentLevel int,
+) string {
+	elemRef, err := model.BatchElementShapeRef(op.Name, memberName, memberShapeRef)
+	if err != nil {
+		// This is a compile-time failure, just bomb out...
+		panic(err.Error())
+	}
+	indent := strings.Repeat("\t", indentLevel)
+	sourceElemVarName := fmt.Sprintf("%s.%s[0]", sourceVarName, memberName)
+
+	// Pairs of the element's member names, or the empty string for a scalar
+	// element, and the fields they are set into
+	elemMemberNames := []string{}
+	targetVarNames := []string{}
+	addTarget := func(elemMemberName string, fieldName string) {
+		fieldName, _ = cfg.ResourceFieldRename(r.Names.Original, op.Name, fieldName)
+		inSpec, inStatus := r.HasMember(fieldName, op.Name)
+		if inSpec {
+			targetVarNames = append(targetVarNames, targetVarName+
+				cfg.PrefixConfig.SpecField+"."+r.SpecFields[fieldName].Names.Camel)
+		} else if inStatus {
+			targetVarNames = append(targetVarNames, targetVarName+
+				cfg.PrefixConfig.StatusField+"."+r.StatusFields[fieldName].Names.Camel)
+		} else {
+			return
+		}
+		elemMemberNames = append(elemMemberNames, elemMemberName)
+	}
+	if elemRef.Shape.Type == "structure" {
+		for _, elemMemberName := range elemRef.Shape.MemberNames() {
+			if !model.IsBatchScalar(elemRef.Shape.MemberRefs[elemMemberName]) {
+				continue
+			}
+			addTarget(elemMemberName, elemMemberName)
+		}
+	} else if model.IsBatchScalar(elemRef) {
+		addTarget("", model.BatchElementName(memberName))
+	}
+	if len(targetVarNames) == 0 {
+		return ""
+	}
+
+	out := fmt.Sprintf(
+		"%sif len(%s.%s) > 0 {\n", indent, sourceVarName, memberName,
+	)
+	for x, targetAdaptedVarName := range targetVarNames {
+		sourceAdaptedVarName := sourceElemVarName
+		if elemMemberNames[x] != "" {
+			sourceAdaptedVarName += "." + elemMemberNames[x]
+		}
+		out += fmt.Sprintf(
+			"%s\t%s = %s\n", indent, targetAdaptedVarName, sourceAdaptedVarName,
+		)
+	}
+	out += fmt.Sprintf("%s}\n", indent)
+	return out
+}
@@ -0,0 +1,66 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//	 http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package code_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/aws-controllers-k8s/code-generator/pkg/generate/code"
+	"github.com/aws-controllers-k8s/code-generator/pkg/model"
+	"github.com/aws-controllers-k8s/code-generator/pkg/testutil"
+)
+
+func TestBatch_EC2_FlowLog(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	g := testutil.NewModelForServiceWithOptions(t, "ec2", &testutil.TestingModelOptions{
+		GeneratorConfigFile: "generator-with-batch-operations.yaml",
+	})
+
+	crd := testutil.GetCRDByName(t, g, "FlowLog")
+	require.NotNil(crd)
+
+	// The single resource is wrapped into the batch of the Input shapes...
+	assert.Contains(
+		code.SetSDK(crd.Config(), crd, model.OpTypeCreate, "r.ko", "res", 1),
+		`
+	if r.ko.Spec.ResourceID != nil {
+		res.SetResourceIds([]*string{r.ko.Spec.ResourceID})
+	}
+`,
+	)
+	expected := `
+	if r.ko.Status.FlowLogID != nil {
+		res.SetFlowLogIds([]*string{r.ko.Status.FlowLogID})
+	}
+`
+	assert.Equal(
+		expected,
+		code.SetSDK(crd.Config(), crd, model.OpTypeDelete, "r.ko", "res", 1),
+	)
+
+	// ...and unwrapped from the batch of the Output shape
+	got := code.SetResource(crd.Config(), crd, model.OpTypeCreate, "resp", "ko", 1)
+	assert.Contains(got, `
+	if len(resp.FlowLogIds) > 0 {
+		ko.Status.FlowLogID = resp.FlowLogIds[0]
+	}
+`,
+	)
+	assert.NotContains(got, "Unsuccessful")
+}
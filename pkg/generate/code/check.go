@@ -122,7 +122,7 @@ func checkRequiredFieldsMissingFromShape(
 					continue
 				}
 			} else {
-				if memberName == "Attributes" {
+				if memberName == r.AttributesMapMemberName() {
 					continue
 				}
 			}
@@ -14,6 +14,7 @@
 package code
 
 import (
+	"fmt"
 	"strings"
 
 	awssdkmodel "github.com/aws/aws-sdk-go/private/model/api"
@@ -52,7 +53,7 @@ func FindIdentifiersInShape(
 	}
 
 	// Handles field renames
-	opType, _ := model.GetOpTypeAndResourceNameFromOpID(op.Name, r.Config())
+	opType := r.Ops.OpTypeOf(op, r.Config())
 	renames, _ := r.GetAllRenames(opType)
 	for _, memberName := range shape.MemberNames() {
 		lookupName := memberName
@@ -188,3 +189,46 @@ func FindPrimaryIdentifierFieldNames(
 	}
 	return crField, shapeField
 }
+
+// copyViaJSON returns Go code that copies the value of a source variable into
+// a target variable of a different Go type with the same shape, by marshaling
+// the value to JSON and back. encoding/json matches field names case
+// insensitively, so this works between the aws-sdk-go types and the CR types.
+// It is used for recursive shapes, whose values can't be copied by a finite
+// amount of generated code.
+//
+// The Go code output from this function looks like this:
+//
+//	f0f1raw, err := json.Marshal(ko.Spec.Filter.Not)
+//	if err != nil {
+//		return nil, err
+//	}
+//	if err := json.Unmarshal(f0f1raw, &f0f1); err != nil {
+//		return nil, err
+//	}
+func copyViaJSON(
+	// The variable name that we want to set a value to
+	targetVarName string,
+	// The variable we access our source value from
+	sourceVarName string,
+	// The values returned by the generated code on error, e.g. "nil, err"
+	errReturn string,
+	indentLevel int,
+) string {
+	out := ""
+	indent := strings.Repeat("\t", indentLevel)
+	rawVarName := targetVarName + "raw"
+	out += fmt.Sprintf(
+		"%s%s, err := json.Marshal(%s)\n", indent, rawVarName, sourceVarName,
+	)
+	out += fmt.Sprintf("%sif err != nil {\n", indent)
+	out += fmt.Sprintf("%s\treturn %s\n", indent, errReturn)
+	out += fmt.Sprintf("%s}\n", indent)
+	out += fmt.Sprintf(
+		"%sif err := json.Unmarshal(%s, &%s); err != nil {\n",
+		indent, rawVarName, targetVarName,
+	)
+	out += fmt.Sprintf("%s\treturn %s\n", indent, errReturn)
+	out += fmt.Sprintf("%s}\n", indent)
+	return out
+}
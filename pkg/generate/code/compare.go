@@ -16,6 +16,7 @@ package code
 import (
 	"fmt"
 	"sort"
+	"strconv"
 	"strings"
 
 	awssdkmodel "github.com/aws/aws-sdk-go/private/model/api"
@@ -113,6 +114,10 @@ func CompareResource(
 
 		var compareConfig *ackgenconfig.CompareFieldConfig
 		fieldConfig := fieldConfigs[fieldName]
+		if fieldConfig == nil {
+			// Fields added by the code generator have their own config
+			fieldConfig = specField.FieldConfig
+		}
 		if fieldConfig != nil {
 			compareConfig = fieldConfig.Compare
 		}
@@ -120,6 +125,13 @@ func CompareResource(
 		if compareConfig != nil && compareConfig.IsIgnored {
 			continue
 		}
+		if fieldConfig != nil && fieldConfig.IsCreateOnly {
+			// The field can't be updated so its changes are ignored
+			continue
+		}
+		if r.IgnoresDrift(specField.Names.Camel) {
+			continue
+		}
 
 		// this is the "path" to the field within the structs being compared.
 		// This is passed down into the compareXXX functions recursively and
@@ -128,13 +140,30 @@ func CompareResource(
 			cfg.PrefixConfig.SpecField+"."+specField.Names.Camel, ".",
 		)
 
-		memberShapeRef := specField.ShapeRef
-		memberShape := memberShapeRef.Shape
+		if specField.HasCustomGoType() {
+			// There is no shape to guide the comparison of Go types supplied
+			// by hook code
+			out += compareDeepEqual(
+				deltaVarName,
+				firstResAdaptedVarName,
+				secondResAdaptedVarName,
+				fieldPath,
+				indentLevel,
+			)
+			continue
+		}
+
+		// Attribute fields have no shape: their values are strings
+		memberShape := &awssdkmodel.Shape{ShapeName: "String", Type: "string"}
+		if specField.ShapeRef != nil {
+			memberShape = specField.ShapeRef.Shape
+		}
 
 		// if ackcompare.HasNilDifference(a.ko.Spec.Name, b.ko.Spec.Name == nil) {
 		//   delta.Add("Spec.Name", a.ko.Spec.Name, b.ko.Spec.Name)
 		// }
 		nilCode := compareNil(
+			cfg,
 			compareConfig,
 			memberShape,
 			deltaVarName,
@@ -153,7 +182,40 @@ func CompareResource(
 			indentLevel++
 		}
 
-		switch memberShape.Type {
+		memberShapeType := memberShape.Type
+		if r.IsDocument(memberShape) {
+			memberShapeType = "document"
+		} else if specField.NumberFormat() == ackgenconfig.NumberFormatQuantity {
+			memberShapeType = "quantity"
+		} else if specField.TagFormat() != "" {
+			memberShapeType = "tags"
+		}
+		switch memberShapeType {
+		case "document":
+			out += compareDocument(
+				deltaVarName,
+				firstResAdaptedVarName,
+				secondResAdaptedVarName,
+				fieldPath,
+				indentLevel,
+			)
+		case "quantity":
+			out += compareQuantity(
+				deltaVarName,
+				firstResAdaptedVarName,
+				secondResAdaptedVarName,
+				fieldPath,
+				indentLevel,
+			)
+		case "tags":
+			out += compareTags(
+				specField.TagFormat(),
+				deltaVarName,
+				firstResAdaptedVarName,
+				secondResAdaptedVarName,
+				fieldPath,
+				indentLevel,
+			)
 		case "structure":
 			// Recurse through all the struct's fields and subfields, building
 			// nested conditionals and calls to `delta.Add()`...
@@ -198,6 +260,7 @@ func CompareResource(
 			out += compareScalar(
 				compareConfig,
 				memberShape,
+				specField.TimestampFormat(),
 				deltaVarName,
 				firstResAdaptedVarName,
 				secondResAdaptedVarName,
@@ -226,6 +289,7 @@ func CompareResource(
 //   delta.Add("Spec.Name", a.ko.Spec.Name, b.ko.Spec.Name)
 // }
 func compareNil(
+	cfg *ackgenconfig.Config,
 	// struct informing code generator how to compare the field values
 	compareConfig *ackgenconfig.CompareFieldConfig,
 	// struct describing the SDK type of the field being compared
@@ -257,8 +321,17 @@ func compareNil(
 		// for slice types, there is no nilability test. Instead, the normal
 		// value test checks length of slices.
 		return ""
+	case "map":
+		if cfg.CompareNilEqualsEmpty(compareConfig) {
+			// the normal value test checks the length of the maps
+			return ""
+		}
+		out += fmt.Sprintf(
+			"%sif ackcompare.HasNilDifference(%s, %s) {\n",
+			indent, firstResVarName, secondResVarName,
+		)
 	case "boolean", "string", "character", "byte", "short", "integer", "long",
-		"float", "double", "timestamp", "structure", "map", "jsonvalue":
+		"float", "double", "timestamp", "structure", "jsonvalue":
 		// if ackcompare.HasNilDifference(a.ko.Spec.Name, b.ko.Spec.Name) {
 		out += fmt.Sprintf(
 			"%sif ackcompare.HasNilDifference(%s, %s) {\n",
@@ -280,6 +353,232 @@ func compareNil(
 	return out
 }
 
+// compareDocument outputs Go code that compares two JSON document values from
+// two resource fields and, if there is a difference, adds the difference to a
+// variable representing an `ackcompare.Delta`. The API server stores the
+// documents with their keys sorted, as does encoding/json, so the raw bytes
+// of equal documents are equal.
+//
+// Output code will look something like this:
+//
+//	if !reflect.DeepEqual(a.ko.Spec.Definition.Raw, b.ko.Spec.Definition.Raw) {
+//	  delta.Add("Spec.Definition", a.ko.Spec.Definition, b.ko.Spec.Definition)
+//	}
+func compareDocument(
+	// String representing the name of the variable that is of type
+	// `*ackcompare.Delta`. We will generate Go code that calls the `Add()`
+	// method of this variable when differences between fields are detected.
+	deltaVarName string,
+	// String representing the name of the variable that represents the first
+	// CR under comparison. This will typically be something like
+	// "a.ko.Spec.Name". See `templates/pkg/resource/delta.go.tpl`.
+	firstResVarName string,
+	// String representing the name of the variable that represents the second
+	// CR under comparison. This will typically be something like
+	// "b.ko.Spec.Name". See `templates/pkg/resource/delta.go.tpl`.
+	secondResVarName string,
+	// String indicating the current field path being evaluated, e.g.
+	// "Author.Name". This does not include the top-level Spec or Status
+	// struct.
+	fieldPath string,
+	// Number of levels of indentation to use
+	indentLevel int,
+) string {
+	out := ""
+	indent := strings.Repeat("\t", indentLevel)
+	// if !reflect.DeepEqual(a.ko.Spec.Definition.Raw, b.ko.Spec.Definition.Raw) {
+	out += fmt.Sprintf(
+		"%sif !reflect.DeepEqual(%s.Raw, %s.Raw) {\n",
+		indent, firstResVarName, secondResVarName,
+	)
+	//   delta.Add("Spec.Definition", a.ko.Spec.Definition, b.ko.Spec.Definition)
+	out += fmt.Sprintf(
+		"%s\t%s.Add(\"%s\", %s, %s)\n",
+		indent, deltaVarName, fieldPath, firstResVarName, secondResVarName,
+	)
+	// }
+	out += fmt.Sprintf(
+		"%s}\n", indent,
+	)
+	return out
+}
+
+// compareDeepEqual outputs Go code that compares two values of any type from
+// two resource fields using reflect.DeepEqual and, if there is a difference,
+// adds the difference to a variable representing an `ackcompare.Delta`.
+//
+// Output code will look something like this:
+//
+//	if !reflect.DeepEqual(a.ko.Spec.Mirror, b.ko.Spec.Mirror) {
+//		delta.Add("Spec.Mirror", a.ko.Spec.Mirror, b.ko.Spec.Mirror)
+//	}
+func compareDeepEqual(
+	// String representing the name of the variable that is of type
+	// `*ackcompare.Delta`. We will generate Go code that calls the `Add()`
+	// method of this variable when differences between fields are detected.
+	deltaVarName string,
+	// String representing the name of the variable that represents the first
+	// CR under comparison. This will typically be something like
+	// "a.ko.Spec.Mirror". See `templates/pkg/resource/delta.go.tpl`.
+	firstResVarName string,
+	// String representing the name of the variable that represents the second
+	// CR under comparison. This will typically be something like
+	// "b.ko.Spec.Mirror". See `templates/pkg/resource/delta.go.tpl`.
+	secondResVarName string,
+	// String indicating the current field path being evaluated, e.g.
+	// "Author.Name". This does not include the top-level Spec or Status
+	// struct.
+	fieldPath string,
+	// Number of levels of indentation to use
+	indentLevel int,
+) string {
+	out := ""
+	indent := strings.Repeat("\t", indentLevel)
+	out += fmt.Sprintf(
+		"%sif !reflect.DeepEqual(%s, %s) {\n",
+		indent, firstResVarName, secondResVarName,
+	)
+	out += fmt.Sprintf(
+		"%s\t%s.Add(\"%s\", %s, %s)\n",
+		indent, deltaVarName, fieldPath, firstResVarName, secondResVarName,
+	)
+	out += fmt.Sprintf(
+		"%s}\n", indent,
+	)
+	return out
+}
+
+// compareQuantity outputs Go code that compares two resource.Quantity values
+// from two resource fields and, if there is a difference, adds the
+// difference to a variable representing an `ackcompare.Delta`. Quantities
+// with different representations of the same value, like "1" and "1000m",
+// are equal.
+//
+// Output code will look something like this:
+//
+//	if a.ko.Spec.Weight.Cmp(*b.ko.Spec.Weight) != 0 {
+//	  delta.Add("Spec.Weight", a.ko.Spec.Weight, b.ko.Spec.Weight)
+//	}
+func compareQuantity(
+	// String representing the name of the variable that is of type
+	// `*ackcompare.Delta`. We will generate Go code that calls the `Add()`
+	// method of this variable when differences between fields are detected.
+	deltaVarName string,
+	// String representing the name of the variable that represents the first
+	// CR under comparison. This will typically be something like
+	// "a.ko.Spec.Name". See `templates/pkg/resource/delta.go.tpl`.
+	firstResVarName string,
+	// String representing the name of the variable that represents the second
+	// CR under comparison. This will typically be something like
+	// "b.ko.Spec.Name". See `templates/pkg/resource/delta.go.tpl`.
+	secondResVarName string,
+	// String indicating the current field path being evaluated, e.g.
+	// "Author.Name". This does not include the top-level Spec or Status
+	// struct.
+	fieldPath string,
+	// Number of levels of indentation to use
+	indentLevel int,
+) string {
+	out := ""
+	indent := strings.Repeat("\t", indentLevel)
+	// if a.ko.Spec.Weight.Cmp(*b.ko.Spec.Weight) != 0 {
+	out += fmt.Sprintf(
+		"%sif %s.Cmp(*%s) != 0 {\n",
+		indent, firstResVarName, secondResVarName,
+	)
+	//   delta.Add("Spec.Weight", a.ko.Spec.Weight, b.ko.Spec.Weight)
+	out += fmt.Sprintf(
+		"%s\t%s.Add(\"%s\", %s, %s)\n",
+		indent, deltaVarName, fieldPath, firstResVarName, secondResVarName,
+	)
+	// }
+	out += fmt.Sprintf(
+		"%s}\n", indent,
+	)
+	return out
+}
+
+// compareTags outputs Go code that compares two sets of tags from two
+// resource fields and, if there is a difference, adds the difference to a
+// variable representing an `ackcompare.Delta`. The order of tags represented
+// as a list doesn't matter, so they are compared as maps.
+//
+// When tags are represented as a list, the output code will look something
+// like this:
+//
+//	tagsA := map[string]*string{}
+//	for _, iter := range a.ko.Spec.Tags {
+//	  if iter.Key != nil {
+//	    tagsA[*iter.Key] = iter.Value
+//	  }
+//	}
+//	tagsB := map[string]*string{}
+//	for _, iter := range b.ko.Spec.Tags {
+//	  if iter.Key != nil {
+//	    tagsB[*iter.Key] = iter.Value
+//	  }
+//	}
+//	if !ackcompare.MapStringStringPEqual(tagsA, tagsB) {
+//	  delta.Add("Spec.Tags", a.ko.Spec.Tags, b.ko.Spec.Tags)
+//	}
+func compareTags(
+	// The format of the tags in the CR
+	tagFormat string,
+	// String representing the name of the variable that is of type
+	// `*ackcompare.Delta`. We will generate Go code that calls the `Add()`
+	// method of this variable when differences between fields are detected.
+	deltaVarName string,
+	// String representing the name of the variable that represents the first
+	// CR under comparison. This will typically be something like
+	// "a.ko.Spec.Name". See `templates/pkg/resource/delta.go.tpl`.
+	firstResVarName string,
+	// String representing the name of the variable that represents the second
+	// CR under comparison. This will typically be something like
+	// "b.ko.Spec.Name". See `templates/pkg/resource/delta.go.tpl`.
+	secondResVarName string,
+	// String indicating the current field path being evaluated, e.g.
+	// "Author.Name". This does not include the top-level Spec or Status
+	// struct.
+	fieldPath string,
+	// Number of levels of indentation to use
+	indentLevel int,
+) string {
+	out := ""
+	indent := strings.Repeat("\t", indentLevel)
+	firstTags := firstResVarName
+	secondTags := secondResVarName
+	if tagFormat == ackgenconfig.TagFormatList {
+		firstTags = "tagsA"
+		secondTags = "tagsB"
+		for _, tags := range [][2]string{
+			{firstTags, firstResVarName},
+			{secondTags, secondResVarName},
+		} {
+			out += fmt.Sprintf("%s%s := map[string]*string{}\n", indent, tags[0])
+			out += fmt.Sprintf("%sfor _, iter := range %s {\n", indent, tags[1])
+			out += fmt.Sprintf("%s\tif iter.Key != nil {\n", indent)
+			out += fmt.Sprintf("%s\t\t%s[*iter.Key] = iter.Value\n", indent, tags[0])
+			out += fmt.Sprintf("%s\t}\n", indent)
+			out += fmt.Sprintf("%s}\n", indent)
+		}
+	}
+	// if !ackcompare.MapStringStringPEqual(a.ko.Spec.Tags, b.ko.Spec.Tags) {
+	out += fmt.Sprintf(
+		"%sif !ackcompare.MapStringStringPEqual(%s, %s) {\n",
+		indent, firstTags, secondTags,
+	)
+	//   delta.Add("Spec.Tags", a.ko.Spec.Tags, b.ko.Spec.Tags)
+	out += fmt.Sprintf(
+		"%s\t%s.Add(\"%s\", %s, %s)\n",
+		indent, deltaVarName, fieldPath, firstResVarName, secondResVarName,
+	)
+	// }
+	out += fmt.Sprintf(
+		"%s}\n", indent,
+	)
+	return out
+}
+
 // compareScalar outputs Go code that compares two scalar values from two
 // resource fields and, if there is a difference, adds the difference to a
 // variable representing an `ackcompare.Delta`.
@@ -294,6 +593,8 @@ func compareScalar(
 	compareConfig *ackgenconfig.CompareFieldConfig,
 	// struct describing the SDK type of the field being compared
 	shape *awssdkmodel.Shape,
+	// The format of the values being compared if they are timestamps
+	timestampFormat string,
 	// String representing the name of the variable that is of type
 	// `*ackcompare.Delta`. We will generate Go code that calls the `Add()`
 	// method of this variable when differences between fields are detected.
@@ -316,6 +617,19 @@ func compareScalar(
 	out := ""
 	indent := strings.Repeat("\t", indentLevel)
 
+	if cond := compareScalarTolerant(
+		compareConfig, shape, firstResVarName, secondResVarName, fieldPath,
+	); cond != "" {
+		out += fmt.Sprintf("%sif %s {\n", indent, cond)
+		//   delta.Add("Spec.Name", a.ko.Spec.Name, b.ko.Spec.Name)
+		out += fmt.Sprintf(
+			"%s\t%s.Add(\"%s\", %s, %s)\n",
+			indent, deltaVarName, fieldPath, firstResVarName, secondResVarName,
+		)
+		out += fmt.Sprintf("%s}\n", indent)
+		return out
+	}
+
 	switch shape.Type {
 	case "boolean", "string", "character", "byte", "short", "integer", "long", "float", "double":
 		// if *a.ko.Spec.Name != *b.ko.Spec.Name {
@@ -329,6 +643,14 @@ func compareScalar(
 			indent, firstResVarName, secondResVarName,
 		)
 	case "timestamp":
+		if timestampFormat != ackgenconfig.TimestampFormatTime {
+			// if *a.ko.Status.CreatedAt != *b.ko.Status.CreatedAt {
+			out += fmt.Sprintf(
+				"%sif *%s != *%s {\n",
+				indent, firstResVarName, secondResVarName,
+			)
+			break
+		}
 		// if !a.ko.Spec.CreatedAt.Equal(b.ko.Spec.CreatedAt) {
 		out += fmt.Sprintf(
 			"%sif !%s.Equal(%s) {\n",
@@ -350,6 +672,74 @@ func compareScalar(
 	return out
 }
 
+// compareScalarTolerant returns the condition of the Go code comparing two
+// scalar values according to the tolerant comparison mode of the supplied
+// compare config, or an empty string if it has none.
+//
+// The condition will look something like this:
+//
+//	!strings.EqualFold(*a.ko.Spec.Name, *b.ko.Spec.Name)
+//	!equalJSONStrings(*a.ko.Spec.Policy, *b.ko.Spec.Policy)
+//	math.Abs(float64(*a.ko.Spec.Size)-float64(*b.ko.Spec.Size)) > 0.5
+func compareScalarTolerant(
+	// struct informing code generator how to compare the field values
+	compareConfig *ackgenconfig.CompareFieldConfig,
+	// struct describing the SDK type of the field being compared
+	shape *awssdkmodel.Shape,
+	// String representing the name of the variable that represents the first
+	// CR under comparison
+	firstResVarName string,
+	// String representing the name of the variable that represents the second
+	// CR under comparison
+	secondResVarName string,
+	// String indicating the current field path being evaluated
+	fieldPath string,
+) string {
+	if compareConfig == nil {
+		return ""
+	}
+	isString := shape.Type == "string"
+	isNumber := false
+	switch shape.Type {
+	case "byte", "short", "integer", "long", "float", "double":
+		isNumber = true
+	}
+	switch {
+	case compareConfig.IsCaseInsensitive:
+		if !isString {
+			panic("is_case_insensitive compare is only supported on string fields, not " + fieldPath)
+		}
+		return fmt.Sprintf(
+			"!strings.EqualFold(*%s, *%s)", firstResVarName, secondResVarName,
+		)
+	case compareConfig.Normalize == ackgenconfig.CompareNormalizeWhitespace:
+		if !isString {
+			panic("whitespace compare normalization is only supported on string fields, not " + fieldPath)
+		}
+		return fmt.Sprintf(
+			"strings.Join(strings.Fields(*%s), \" \") != strings.Join(strings.Fields(*%s), \" \")",
+			firstResVarName, secondResVarName,
+		)
+	case compareConfig.Normalize == ackgenconfig.CompareNormalizeJSON:
+		if !isString {
+			panic("json compare normalization is only supported on string fields, not " + fieldPath)
+		}
+		return fmt.Sprintf(
+			"!equalJSONStrings(*%s, *%s)", firstResVarName, secondResVarName,
+		)
+	case compareConfig.Tolerance != nil:
+		if !isNumber {
+			panic("compare tolerance is only supported on number fields, not " + fieldPath)
+		}
+		return fmt.Sprintf(
+			"math.Abs(float64(*%s)-float64(*%s)) > %s",
+			firstResVarName, secondResVarName,
+			strconv.FormatFloat(*compareConfig.Tolerance, 'g', -1, 64),
+		)
+	}
+	return ""
+}
+
 // compareMap outputs Go code that compares two map values from two resource
 // fields and, if there is a difference, adds the difference to a variable
 // representing an `ackcompare.Delta`.
@@ -396,12 +786,21 @@ func compareMap(
 
 	valType := shape.ValueRef.Shape.Type
 
+	if r.EnumTypeName(shape.ValueRef.Shape) != "" {
+		// The ackcompare helpers only accept *string values, so maps of
+		// enum values are compared using reflect
+		valType = "enum"
+	}
+
+	emptyCond := compareNilEqualsEmptyCond(
+		cfg, compareConfig, firstResVarName, secondResVarName,
+	)
 	switch valType {
 	case "string":
 		// if !ackcompare.MapStringStringPEqual(a.ko.Spec.Tags, b.ko.Spec.Tags) {
 		out += fmt.Sprintf(
-			"%sif !ackcompare.MapStringStringPEqual(%s, %s) {\n",
-			indent, firstResVarName, secondResVarName,
+			"%sif %s!ackcompare.MapStringStringPEqual(%s, %s) {\n",
+			indent, emptyCond, firstResVarName, secondResVarName,
 		)
 	default:
 		// NOTE(jaypipes): Using reflect here is really punting. We should
@@ -410,8 +809,8 @@ func compareMap(
 		// building up the fieldPath appropriately and calling into a
 		// struct-specific comparator function...
 		out += fmt.Sprintf(
-			"%sif !reflect.DeepEqual(%s, %s) {\n",
-			indent, firstResVarName, secondResVarName,
+			"%sif %s!reflect.DeepEqual(%s, %s) {\n",
+			indent, emptyCond, firstResVarName, secondResVarName,
 		)
 	}
 	//   delta.Add("Spec.Name", a.ko.Spec.Name, b.ko.Spec.Name)
@@ -427,6 +826,33 @@ func compareMap(
 	return out
 }
 
+// compareNilEqualsEmptyCond returns the beginning of the condition of the Go
+// code comparing two list or map values, skipping the comparison when both are
+// nil or empty if the field is configured to consider them equal, or an empty
+// string otherwise.
+//
+// The condition will look something like this:
+//
+//	(len(a.ko.Spec.Tags) > 0 || len(b.ko.Spec.Tags) > 0) &&
+func compareNilEqualsEmptyCond(
+	cfg *ackgenconfig.Config,
+	// struct informing code generator how to compare the field values
+	compareConfig *ackgenconfig.CompareFieldConfig,
+	// String representing the name of the variable that represents the first
+	// CR under comparison
+	firstResVarName string,
+	// String representing the name of the variable that represents the second
+	// CR under comparison
+	secondResVarName string,
+) string {
+	if !cfg.CompareNilEqualsEmpty(compareConfig) {
+		return ""
+	}
+	return fmt.Sprintf(
+		"(len(%s) > 0 || len(%s) > 0) && ", firstResVarName, secondResVarName,
+	)
+}
+
 // compareSlice outputs Go code that compares two slice values from two
 // resource fields and, if there is a difference, adds the difference to a
 // variable representing an `ackcompare.Delta`.
@@ -462,19 +888,40 @@ func compareSlice(
 	// Number of levels of indentation to use
 	indentLevel int,
 ) string {
+	if compareConfig != nil && compareConfig.IsUnordered {
+		return compareUnorderedSlice(
+			compareConfig.Key,
+			shape,
+			deltaVarName,
+			firstResVarName,
+			secondResVarName,
+			fieldPath,
+			indentLevel,
+		)
+	}
+
 	out := ""
 	indent := strings.Repeat("\t", indentLevel)
 
 	elemType := shape.MemberRef.Shape.Type
 
+	if r.EnumTypeName(shape.MemberRef.Shape) != "" {
+		// The ackcompare helpers only accept *string elements, so slices of
+		// enum values are compared using reflect
+		elemType = "enum"
+	}
+
+	emptyCond := compareNilEqualsEmptyCond(
+		cfg, compareConfig, firstResVarName, secondResVarName,
+	)
 	switch elemType {
 	case "string":
 		// if !ackcompare.SliceStringPEqual(a.ko.Spec.SecurityGroupIDs, b.ko.Spec.SecurityGroupIDs) {
 		out += fmt.Sprintf(
-			"%sif !ackcompare.SliceStringPEqual(%s, %s) {\n",
-			indent, firstResVarName, secondResVarName,
+			"%sif %s!ackcompare.SliceStringPEqual(%s, %s) {\n",
+			indent, emptyCond, firstResVarName, secondResVarName,
 		)
-	case "structure":
+	case "structure", "enum":
 		// NOTE(jaypipes): Using reflect here is really punting. We should
 		// implement this in a cleaner, more efficient fashion by walking the
 		// struct values and comparing each struct individually, building up
@@ -482,8 +929,8 @@ func compareSlice(
 		// comparator function...the tricky part of this is figuring out how to
 		// sort the slice of structs...
 		out += fmt.Sprintf(
-			"%sif !reflect.DeepEqual(%s, %s) {\n",
-			indent, firstResVarName, secondResVarName,
+			"%sif %s!reflect.DeepEqual(%s, %s) {\n",
+			indent, emptyCond, firstResVarName, secondResVarName,
 		)
 	default:
 		panic("Unsupported shape type in generate.code.compareSlice: " + shape.Type)
@@ -501,6 +948,154 @@ func compareSlice(
 	return out
 }
 
+// compareUnorderedSlice outputs Go code that compares the elements of two
+// slices as multisets, regardless of their order, and, if there is a
+// difference, adds the difference to a variable representing an
+// `ackcompare.Delta`. Duplicate elements are counted, so that `[x, x]` differs
+// from `[x]`. The elements are grouped by their key member, if any, elements
+// sharing a key being compared in order. The occurrences of the JSON
+// encodings of the other elements, including the elements without a key, are
+// counted.
+//
+// Output code will look something like this:
+//
+//	{
+//		unequal := len(a.ko.Spec.IPPermissions) != len(b.ko.Spec.IPPermissions)
+//		counts := map[string]int{}
+//		elemsA := map[string][]interface{}{}
+//		for _, iter := range a.ko.Spec.IPPermissions {
+//			if iter == nil || iter.IPProtocol == nil {
+//				key, _ := json.Marshal(iter)
+//				counts[string(key)]++
+//				continue
+//			}
+//			elemsA[*iter.IPProtocol] = append(elemsA[*iter.IPProtocol], iter)
+//		}
+//		elemsB := map[string][]interface{}{}
+//		for _, iter := range b.ko.Spec.IPPermissions {
+//			if iter == nil || iter.IPProtocol == nil {
+//				key, _ := json.Marshal(iter)
+//				counts[string(key)]--
+//				continue
+//			}
+//			elemsB[*iter.IPProtocol] = append(elemsB[*iter.IPProtocol], iter)
+//		}
+//		for _, count := range counts {
+//			if count != 0 {
+//				unequal = true
+//			}
+//		}
+//		if unequal || !reflect.DeepEqual(elemsA, elemsB) {
+//			delta.Add("Spec.IPPermissions", a.ko.Spec.IPPermissions, b.ko.Spec.IPPermissions)
+//		}
+//	}
+func compareUnorderedSlice(
+	// Name of the member identifying the elements, if any
+	keyMemberName string,
+	// struct describing the SDK type of the field being compared
+	shape *awssdkmodel.Shape,
+	// String representing the name of the variable that is of type
+	// `*ackcompare.Delta`. We will generate Go code that calls the `Add()`
+	// method of this variable when differences between fields are detected.
+	deltaVarName string,
+	// String representing the name of the variable that represents the first
+	// CR under comparison. This will typically be something like
+	// "a.ko.Spec.Name". See `templates/pkg/resource/delta.go.tpl`.
+	firstResVarName string,
+	// String representing the name of the variable that represents the second
+	// CR under comparison. This will typically be something like
+	// "b.ko.Spec.Name". See `templates/pkg/resource/delta.go.tpl`.
+	secondResVarName string,
+	// String indicating the current field path being evaluated, e.g.
+	// "Author.Name". This does not include the top-level Spec or Status
+	// struct.
+	fieldPath string,
+	// Number of levels of indentation to use
+	indentLevel int,
+) string {
+	keyFieldName := ""
+	if keyMemberName != "" {
+		elemShape := shape.MemberRef.Shape
+		if elemShape.Type != "structure" {
+			msg := fmt.Sprintf(
+				"compare key %s of %s requires a list of structures",
+				keyMemberName, fieldPath,
+			)
+			panic(msg)
+		}
+		keyRef, found := elemShape.MemberRefs[keyMemberName]
+		if !found || keyRef.Shape.Type != "string" {
+			msg := fmt.Sprintf(
+				"compare key %s of %s must be a string member of %s",
+				keyMemberName, fieldPath, elemShape.ShapeName,
+			)
+			panic(msg)
+		}
+		keyFieldName = names.New(keyMemberName).Camel
+	}
+
+	out := ""
+	indent := strings.Repeat("\t", indentLevel)
+	out += fmt.Sprintf("%s{\n", indent)
+	//	unequal := len(a.ko.Spec.IPPermissions) != len(b.ko.Spec.IPPermissions)
+	out += fmt.Sprintf(
+		"%s\tunequal := len(%s) != len(%s)\n",
+		indent, firstResVarName, secondResVarName,
+	)
+	out += fmt.Sprintf("%s\tcounts := map[string]int{}\n", indent)
+	if keyFieldName != "" {
+		for _, elems := range [][3]string{
+			{"elemsA", "++", firstResVarName},
+			{"elemsB", "--", secondResVarName},
+		} {
+			out += fmt.Sprintf("%s\t%s := map[string][]interface{}{}\n", indent, elems[0])
+			out += fmt.Sprintf("%s\tfor _, iter := range %s {\n", indent, elems[2])
+			out += fmt.Sprintf(
+				"%s\t\tif iter == nil || iter.%s == nil {\n",
+				indent, keyFieldName,
+			)
+			out += fmt.Sprintf("%s\t\t\tkey, _ := json.Marshal(iter)\n", indent)
+			out += fmt.Sprintf("%s\t\t\tcounts[string(key)]%s\n", indent, elems[1])
+			out += fmt.Sprintf("%s\t\t\tcontinue\n", indent)
+			out += fmt.Sprintf("%s\t\t}\n", indent)
+			out += fmt.Sprintf(
+				"%s\t\t%s[*iter.%s] = append(%s[*iter.%s], iter)\n",
+				indent, elems[0], keyFieldName, elems[0], keyFieldName,
+			)
+			out += fmt.Sprintf("%s\t}\n", indent)
+		}
+	} else {
+		for _, elems := range [][2]string{
+			{"++", firstResVarName},
+			{"--", secondResVarName},
+		} {
+			out += fmt.Sprintf("%s\tfor _, iter := range %s {\n", indent, elems[1])
+			out += fmt.Sprintf("%s\t\tkey, _ := json.Marshal(iter)\n", indent)
+			out += fmt.Sprintf("%s\t\tcounts[string(key)]%s\n", indent, elems[0])
+			out += fmt.Sprintf("%s\t}\n", indent)
+		}
+	}
+	out += fmt.Sprintf("%s\tfor _, count := range counts {\n", indent)
+	out += fmt.Sprintf("%s\t\tif count != 0 {\n", indent)
+	out += fmt.Sprintf("%s\t\t\tunequal = true\n", indent)
+	out += fmt.Sprintf("%s\t\t}\n", indent)
+	out += fmt.Sprintf("%s\t}\n", indent)
+	if keyFieldName != "" {
+		out += fmt.Sprintf(
+			"%s\tif unequal || !reflect.DeepEqual(elemsA, elemsB) {\n", indent,
+		)
+	} else {
+		out += fmt.Sprintf("%s\tif unequal {\n", indent)
+	}
+	out += fmt.Sprintf(
+		"%s\t\t%s.Add(\"%s\", %s, %s)\n",
+		indent, deltaVarName, fieldPath, firstResVarName, secondResVarName,
+	)
+	out += fmt.Sprintf("%s\t}\n", indent)
+	out += fmt.Sprintf("%s}\n", indent)
+	return out
+}
+
 // CompareStruct outputs Go code that compares two struct values from two
 // resource fields and, if there is a difference, adds the difference to a
 // variable representing an `ackcompare.Delta`.
@@ -558,6 +1153,14 @@ func CompareStruct(
 		if compareConfig != nil && compareConfig.IsIgnored {
 			continue
 		}
+		if fieldConfig != nil && fieldConfig.IsCreateOnly {
+			// The field can't be updated so its changes are ignored
+			continue
+		}
+		specPrefix := strings.TrimPrefix(cfg.PrefixConfig.SpecField, ".") + "."
+		if r.IgnoresDrift(strings.TrimPrefix(memberFieldPath, specPrefix)) {
+			continue
+		}
 
 		memberShape := memberShapeRef.Shape
 
@@ -565,6 +1168,7 @@ func CompareStruct(
 		//   delta.Add("Spec.Name", a.ko.Spec.Name, b.ko.Spec.Name)
 		// }
 		nilCode := compareNil(
+			cfg,
 			compareConfig,
 			memberShape,
 			deltaVarName,
@@ -582,7 +1186,37 @@ func CompareStruct(
 			)
 			indentLevel++
 		}
-		switch memberShape.Type {
+		memberShapeType := memberShape.Type
+		if r.IsDocument(memberShape) {
+			memberShapeType = "document"
+		} else if r.IsRecursiveMember(shape, memberName) {
+			// Generating the comparison of recursive members field by field
+			// would never end
+			memberShapeType = "recursive"
+		}
+		switch memberShapeType {
+		case "document":
+			out += compareDocument(
+				deltaVarName,
+				firstResAdaptedVarName,
+				secondResAdaptedVarName,
+				memberFieldPath,
+				indentLevel,
+			)
+		case "recursive":
+			//   if !reflect.DeepEqual(a.ko.Spec.Filter.Not, b.ko.Spec.Filter.Not) {
+			//     delta.Add("Spec.Filter.Not", a.ko.Spec.Filter.Not, b.ko.Spec.Filter.Not)
+			//   }
+			memberIndent := strings.Repeat("\t", indentLevel)
+			out += fmt.Sprintf(
+				"%sif !reflect.DeepEqual(%s, %s) {\n",
+				memberIndent, firstResAdaptedVarName, secondResAdaptedVarName,
+			)
+			out += fmt.Sprintf(
+				"%s\t%s.Add(\"%s\", %s, %s)\n", memberIndent, deltaVarName,
+				memberFieldPath, firstResAdaptedVarName, secondResAdaptedVarName,
+			)
+			out += fmt.Sprintf("%s}\n", memberIndent)
 		case "structure":
 			// Recurse through all the struct's fields and subfields, building
 			// nested conditionals and calls to `delta.Add()`...
@@ -627,6 +1261,7 @@ func CompareStruct(
 			out += compareScalar(
 				compareConfig,
 				memberShape,
+				cfg.GetTimestampFormat(),
 				deltaVarName,
 				firstResAdaptedVarName,
 				secondResAdaptedVarName,
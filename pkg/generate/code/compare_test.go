@@ -14,6 +14,13 @@
 package code_test
 
 import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -173,6 +180,46 @@ func TestCompareResource_Lambda_CodeSigningConfig(t *testing.T) {
 	)
 }
 
+func TestCompareResource_Lambda_CodeSigningConfig_CreateOnly(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	g := testutil.NewModelForServiceWithOptions(t, "lambda", &testutil.TestingModelOptions{
+		GeneratorConfigFile: "generator-with-create-only-fields.yaml",
+	})
+
+	crd := testutil.GetCRDByName(t, g, "CodeSigningConfig")
+	require.NotNil(crd)
+
+	// Description can't be updated so it isn't compared
+	expected := `
+	if ackcompare.HasNilDifference(a.ko.Spec.AllowedPublishers, b.ko.Spec.AllowedPublishers) {
+		delta.Add("Spec.AllowedPublishers", a.ko.Spec.AllowedPublishers, b.ko.Spec.AllowedPublishers)
+	} else if a.ko.Spec.AllowedPublishers != nil && b.ko.Spec.AllowedPublishers != nil {
+		if !ackcompare.SliceStringPEqual(a.ko.Spec.AllowedPublishers.SigningProfileVersionARNs, b.ko.Spec.AllowedPublishers.SigningProfileVersionARNs) {
+			delta.Add("Spec.AllowedPublishers.SigningProfileVersionARNs", a.ko.Spec.AllowedPublishers.SigningProfileVersionARNs, b.ko.Spec.AllowedPublishers.SigningProfileVersionARNs)
+		}
+	}
+	if ackcompare.HasNilDifference(a.ko.Spec.CodeSigningPolicies, b.ko.Spec.CodeSigningPolicies) {
+		delta.Add("Spec.CodeSigningPolicies", a.ko.Spec.CodeSigningPolicies, b.ko.Spec.CodeSigningPolicies)
+	} else if a.ko.Spec.CodeSigningPolicies != nil && b.ko.Spec.CodeSigningPolicies != nil {
+		if ackcompare.HasNilDifference(a.ko.Spec.CodeSigningPolicies.UntrustedArtifactOnDeployment, b.ko.Spec.CodeSigningPolicies.UntrustedArtifactOnDeployment) {
+			delta.Add("Spec.CodeSigningPolicies.UntrustedArtifactOnDeployment", a.ko.Spec.CodeSigningPolicies.UntrustedArtifactOnDeployment, b.ko.Spec.CodeSigningPolicies.UntrustedArtifactOnDeployment)
+		} else if a.ko.Spec.CodeSigningPolicies.UntrustedArtifactOnDeployment != nil && b.ko.Spec.CodeSigningPolicies.UntrustedArtifactOnDeployment != nil {
+			if *a.ko.Spec.CodeSigningPolicies.UntrustedArtifactOnDeployment != *b.ko.Spec.CodeSigningPolicies.UntrustedArtifactOnDeployment {
+				delta.Add("Spec.CodeSigningPolicies.UntrustedArtifactOnDeployment", a.ko.Spec.CodeSigningPolicies.UntrustedArtifactOnDeployment, b.ko.Spec.CodeSigningPolicies.UntrustedArtifactOnDeployment)
+			}
+		}
+	}
+`
+	assert.Equal(
+		expected,
+		code.CompareResource(
+			crd.Config(), crd, "delta", "a.ko", "b.ko", 1,
+		),
+	)
+}
+
 func TestCompareResource_Lambda_Function(t *testing.T) {
 	assert := assert.New(t)
 	require := require.New(t)
@@ -475,3 +522,387 @@ func TestCompareResource_APIGatewayv2_Route(t *testing.T) {
 		),
 	)
 }
+
+func TestCompareResource_ECR_Repository_CustomFields(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	g := testutil.NewModelForServiceWithOptions(t, "ecr", &testutil.TestingModelOptions{
+		GeneratorConfigFile: "generator-with-custom-fields.yaml",
+	})
+
+	crd := testutil.GetCRDByName(t, g, "Repository")
+	require.NotNil(crd)
+
+	// Go types supplied by hook code have no shape to guide the comparison
+	expected := `
+	if !reflect.DeepEqual(a.ko.Spec.Mirror, b.ko.Spec.Mirror) {
+		delta.Add("Spec.Mirror", a.ko.Spec.Mirror, b.ko.Spec.Mirror)
+	}
+`
+	got := code.CompareResource(crd.Config(), crd, "delta", "a.ko", "b.ko", 1)
+	assert.Contains(got, expected)
+	assert.Contains(got, "a.ko.Spec.Scanning.ScanOnPush")
+}
+
+func TestCompareResource_Elasticache_ReplicationGroup_UnorderedLists(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	g := testutil.NewModelForServiceWithOptions(t, "elasticache", &testutil.TestingModelOptions{
+		GeneratorConfigFile: "generator-with-unordered-lists.yaml",
+	})
+
+	crd := testutil.GetCRDByName(t, g, "ReplicationGroup")
+	require.NotNil(crd)
+
+	actual := code.CompareResource(
+		crd.Config(), crd, "delta", "a.ko", "b.ko", 1,
+	)
+
+	// LogDeliveryConfigurations elements are compared by their LogType
+	assert.Contains(actual, `
+	{
+		unequal := len(a.ko.Spec.LogDeliveryConfigurations) != len(b.ko.Spec.LogDeliveryConfigurations)
+		counts := map[string]int{}
+		elemsA := map[string][]interface{}{}
+		for _, iter := range a.ko.Spec.LogDeliveryConfigurations {
+			if iter == nil || iter.LogType == nil {
+				key, _ := json.Marshal(iter)
+				counts[string(key)]++
+				continue
+			}
+			elemsA[*iter.LogType] = append(elemsA[*iter.LogType], iter)
+		}
+		elemsB := map[string][]interface{}{}
+		for _, iter := range b.ko.Spec.LogDeliveryConfigurations {
+			if iter == nil || iter.LogType == nil {
+				key, _ := json.Marshal(iter)
+				counts[string(key)]--
+				continue
+			}
+			elemsB[*iter.LogType] = append(elemsB[*iter.LogType], iter)
+		}
+		for _, count := range counts {
+			if count != 0 {
+				unequal = true
+			}
+		}
+		if unequal || !reflect.DeepEqual(elemsA, elemsB) {
+			delta.Add("Spec.LogDeliveryConfigurations", a.ko.Spec.LogDeliveryConfigurations, b.ko.Spec.LogDeliveryConfigurations)
+		}
+	}
+`)
+	// NodeGroupConfiguration elements are compared as a whole
+	assert.Contains(actual, `
+	{
+		unequal := len(a.ko.Spec.NodeGroupConfiguration) != len(b.ko.Spec.NodeGroupConfiguration)
+		counts := map[string]int{}
+		for _, iter := range a.ko.Spec.NodeGroupConfiguration {
+			key, _ := json.Marshal(iter)
+			counts[string(key)]++
+		}
+		for _, iter := range b.ko.Spec.NodeGroupConfiguration {
+			key, _ := json.Marshal(iter)
+			counts[string(key)]--
+		}
+		for _, count := range counts {
+			if count != 0 {
+				unequal = true
+			}
+		}
+		if unequal {
+			delta.Add("Spec.NodeGroupConfiguration", a.ko.Spec.NodeGroupConfiguration, b.ko.Spec.NodeGroupConfiguration)
+		}
+	}
+`)
+}
+
+func TestCompareResource_Elasticache_ReplicationGroup_UnorderedLists_Duplicates(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	g := testutil.NewModelForServiceWithOptions(t, "elasticache", &testutil.TestingModelOptions{
+		GeneratorConfigFile: "generator-with-unordered-lists.yaml",
+	})
+
+	crd := testutil.GetCRDByName(t, g, "ReplicationGroup")
+	require.NotNil(crd)
+
+	actual := code.CompareResource(
+		crd.Config(), crd, "delta", "a.ko", "b.ko", 1,
+	)
+
+	// [x, x] differs from [x]: the lengths of the lists are compared before
+	// their elements, which are counted instead of being indexed
+	assert.Contains(actual, `
+		unequal := len(a.ko.Spec.NodeGroupConfiguration) != len(b.ko.Spec.NodeGroupConfiguration)
+`)
+	assert.Contains(actual, `
+			counts[string(key)]++
+`)
+	assert.NotContains(actual, "elemsA[string(key)] = iter")
+	// Duplicate keys are grouped rather than overwriting each other
+	assert.Contains(actual, `
+			elemsA[*iter.LogType] = append(elemsA[*iter.LogType], iter)
+`)
+}
+
+func TestCompareResource_Elasticache_ReplicationGroup_UnorderedLists_NilKey(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	goBin, err := exec.LookPath("go")
+	if err != nil {
+		t.Skip("go is required to run the generated code")
+	}
+
+	g := testutil.NewModelForServiceWithOptions(t, "elasticache", &testutil.TestingModelOptions{
+		GeneratorConfigFile: "generator-with-unordered-lists.yaml",
+	})
+
+	crd := testutil.GetCRDByName(t, g, "ReplicationGroup")
+	require.NotNil(crd)
+
+	actual := code.CompareResource(
+		crd.Config(), crd, "delta", "a.ko", "b.ko", 1,
+	)
+	start := strings.Index(
+		actual, "\t{\n\t\tunequal := len(a.ko.Spec.LogDeliveryConfigurations)",
+	)
+	require.True(start >= 0)
+	end := start + strings.Index(actual[start:], "\n\t}\n") + len("\n\t}\n")
+	compareCode := actual[start:end]
+
+	// Elements without a LogType are compared as a whole, like the elements
+	// of lists without a key
+	testCases := []struct {
+		name      string
+		a         string
+		b         string
+		different bool
+	}{
+		{
+			"equal with a nil key",
+			`[{"logType": "slow-log"}, {"destinationType": "kinesis"}]`,
+			`[{"destinationType": "kinesis"}, {"logType": "slow-log"}]`,
+			false,
+		},
+		{
+			"equal with a nil element",
+			`[null, {"logType": "slow-log"}]`,
+			`[{"logType": "slow-log"}, null]`,
+			false,
+		},
+		{
+			"different elements with a nil key",
+			`[{"logType": "slow-log"}, {"destinationType": "kinesis"}]`,
+			`[{"logType": "slow-log"}, {"destinationType": "cloudwatch-logs"}]`,
+			true,
+		},
+		{
+			"extra element with a nil key",
+			`[{"logType": "slow-log"}, {"destinationType": "kinesis"}]`,
+			`[{"logType": "slow-log"}]`,
+			true,
+		},
+		{
+			"duplicate elements with a nil key",
+			`[{"destinationType": "kinesis"}, {"destinationType": "kinesis"}]`,
+			`[{"destinationType": "kinesis"}, {"destinationType": "cloudwatch-logs"}]`,
+			true,
+		},
+	}
+	cases := []string{}
+	for _, tt := range testCases {
+		cases = append(cases, fmt.Sprintf("{%s, %s}", strconv.Quote(tt.a), strconv.Quote(tt.b)))
+	}
+
+	dir, err := ioutil.TempDir("", "compare")
+	require.Nil(err)
+	defer os.RemoveAll(dir)
+	require.Nil(ioutil.WriteFile(
+		filepath.Join(dir, "go.mod"), []byte("module compare\n\ngo 1.14\n"), 0644,
+	))
+	require.Nil(ioutil.WriteFile(filepath.Join(dir, "main.go"), []byte(`package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+)
+
+type LogDeliveryConfigurationRequest struct {
+	DestinationType *string
+	LogType         *string
+}
+
+type resource struct {
+	ko *struct {
+		Spec struct {
+			LogDeliveryConfigurations []*LogDeliveryConfigurationRequest
+		}
+	}
+}
+
+type compareDelta struct {
+	paths []string
+}
+
+func (d *compareDelta) Add(path string, a interface{}, b interface{}) {
+	d.paths = append(d.paths, path)
+}
+
+func different(a *resource, b *resource) bool {
+	delta := &compareDelta{}
+`+compareCode+`	return len(delta.paths) > 0
+}
+
+func newResource(elems string) *resource {
+	r := &resource{}
+	r.ko = &struct {
+		Spec struct {
+			LogDeliveryConfigurations []*LogDeliveryConfigurationRequest
+		}
+	}{}
+	err := json.Unmarshal([]byte(elems), &r.ko.Spec.LogDeliveryConfigurations)
+	if err != nil {
+		panic(err)
+	}
+	return r
+}
+
+func main() {
+	for _, c := range [][2]string{
+		`+strings.Join(cases, ",\n\t\t")+`,
+	} {
+		fmt.Println(different(newResource(c[0]), newResource(c[1])))
+	}
+}
+`), 0644))
+
+	cmd := exec.Command(goBin, "run", ".")
+	cmd.Dir = dir
+	out, err := cmd.CombinedOutput()
+	require.Nil(err, string(out))
+	results := strings.Fields(string(out))
+	require.Len(results, len(testCases), string(out))
+	for x, tt := range testCases {
+		assert.Equal(strconv.FormatBool(tt.different), results[x], tt.name)
+	}
+}
+
+func TestCompareResource_Elasticache_ReplicationGroup_TolerantCompare(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	g := testutil.NewModelForServiceWithOptions(t, "elasticache", &testutil.TestingModelOptions{
+		GeneratorConfigFile: "generator-with-tolerant-compare.yaml",
+	})
+
+	crd := testutil.GetCRDByName(t, g, "ReplicationGroup")
+	require.NotNil(crd)
+
+	actual := code.CompareResource(
+		crd.Config(), crd, "delta", "a.ko", "b.ko", 1,
+	)
+
+	assert.Contains(actual, `
+	} else if a.ko.Spec.PreferredMaintenanceWindow != nil && b.ko.Spec.PreferredMaintenanceWindow != nil {
+		if !strings.EqualFold(*a.ko.Spec.PreferredMaintenanceWindow, *b.ko.Spec.PreferredMaintenanceWindow) {
+			delta.Add("Spec.PreferredMaintenanceWindow", a.ko.Spec.PreferredMaintenanceWindow, b.ko.Spec.PreferredMaintenanceWindow)
+		}
+	}
+`)
+	assert.Contains(actual, `
+	} else if a.ko.Spec.ReplicationGroupDescription != nil && b.ko.Spec.ReplicationGroupDescription != nil {
+		if strings.Join(strings.Fields(*a.ko.Spec.ReplicationGroupDescription), " ") != strings.Join(strings.Fields(*b.ko.Spec.ReplicationGroupDescription), " ") {
+			delta.Add("Spec.ReplicationGroupDescription", a.ko.Spec.ReplicationGroupDescription, b.ko.Spec.ReplicationGroupDescription)
+		}
+	}
+`)
+	assert.Contains(actual, `
+	} else if a.ko.Spec.SnapshotRetentionLimit != nil && b.ko.Spec.SnapshotRetentionLimit != nil {
+		if math.Abs(float64(*a.ko.Spec.SnapshotRetentionLimit)-float64(*b.ko.Spec.SnapshotRetentionLimit)) > 1 {
+			delta.Add("Spec.SnapshotRetentionLimit", a.ko.Spec.SnapshotRetentionLimit, b.ko.Spec.SnapshotRetentionLimit)
+		}
+	}
+`)
+}
+
+func TestCompareResource_SQS_Queue_JSONCompare(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	g := testutil.NewModelForServiceWithOptions(t, "sqs", &testutil.TestingModelOptions{
+		GeneratorConfigFile: "generator-with-json-compare.yaml",
+	})
+
+	crd := testutil.GetCRDByName(t, g, "Queue")
+	require.NotNil(crd)
+
+	actual := code.CompareResource(
+		crd.Config(), crd, "delta", "a.ko", "b.ko", 1,
+	)
+
+	assert.Contains(actual, `
+	} else if a.ko.Spec.Policy != nil && b.ko.Spec.Policy != nil {
+		if !equalJSONStrings(*a.ko.Spec.Policy, *b.ko.Spec.Policy) {
+			delta.Add("Spec.Policy", a.ko.Spec.Policy, b.ko.Spec.Policy)
+		}
+	}
+`)
+}
+
+func TestCompareResource_Elasticache_ReplicationGroup_NilEqualsEmpty(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	g := testutil.NewModelForServiceWithOptions(t, "elasticache", &testutil.TestingModelOptions{
+		GeneratorConfigFile: "generator-with-nil-equals-empty.yaml",
+	})
+
+	crd := testutil.GetCRDByName(t, g, "ReplicationGroup")
+	require.NotNil(crd)
+
+	actual := code.CompareResource(
+		crd.Config(), crd, "delta", "a.ko", "b.ko", 1,
+	)
+
+	assert.Contains(actual, `
+	if (len(a.ko.Spec.SecurityGroupIDs) > 0 || len(b.ko.Spec.SecurityGroupIDs) > 0) && !ackcompare.SliceStringPEqual(a.ko.Spec.SecurityGroupIDs, b.ko.Spec.SecurityGroupIDs) {
+		delta.Add("Spec.SecurityGroupIDs", a.ko.Spec.SecurityGroupIDs, b.ko.Spec.SecurityGroupIDs)
+	}
+`)
+	assert.Contains(actual, `
+	if (len(a.ko.Spec.NodeGroupConfiguration) > 0 || len(b.ko.Spec.NodeGroupConfiguration) > 0) && !reflect.DeepEqual(a.ko.Spec.NodeGroupConfiguration, b.ko.Spec.NodeGroupConfiguration) {
+		delta.Add("Spec.NodeGroupConfiguration", a.ko.Spec.NodeGroupConfiguration, b.ko.Spec.NodeGroupConfiguration)
+	}
+`)
+	// The field's compare config overrides the API-wide setting
+	assert.Contains(actual, `
+	if !ackcompare.SliceStringPEqual(a.ko.Spec.SnapshotARNs, b.ko.Spec.SnapshotARNs) {
+		delta.Add("Spec.SnapshotARNs", a.ko.Spec.SnapshotARNs, b.ko.Spec.SnapshotARNs)
+	}
+`)
+}
+
+func TestCompareResource_DynamoDB_Table_IgnoreDrift(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	g := testutil.NewModelForServiceWithOptions(t, "dynamodb", &testutil.TestingModelOptions{
+		GeneratorConfigFile: "generator-with-ignore-drift.yaml",
+	})
+
+	crd := testutil.GetCRDByName(t, g, "Table")
+	require.NotNil(crd)
+
+	actual := code.CompareResource(
+		crd.Config(), crd, "delta", "a.ko", "b.ko", 1,
+	)
+
+	assert.NotContains(actual, `"Spec.BillingMode"`)
+	assert.NotContains(actual, `"Spec.ProvisionedThroughput.ReadCapacityUnits"`)
+	assert.Contains(actual, `delta.Add("Spec.ProvisionedThroughput.WriteCapacityUnits", a.ko.Spec.ProvisionedThroughput.WriteCapacityUnits, b.ko.Spec.ProvisionedThroughput.WriteCapacityUnits)`)
+}
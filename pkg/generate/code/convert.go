@@ -0,0 +1,364 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package code
+
+import (
+	"fmt"
+	"strings"
+
+	awssdkmodel "github.com/aws/aws-sdk-go/private/model/api"
+
+	"github.com/aws-controllers-k8s/code-generator/pkg/model"
+	"github.com/aws-controllers-k8s/code-generator/pkg/model/multiversion"
+	"github.com/aws-controllers-k8s/code-generator/pkg/names"
+)
+
+// ConvertResource returns the Go code that copies the Spec and Status fields
+// of a resource in one API version to the same resource in another API
+// version, following the field renames computed in the supplied CRDDelta.
+//
+// The delta is always computed with the spoke version as the source and the
+// hub version as the destination. When toHub is false, the delta is walked in
+// reverse to produce hub-to-spoke conversion code.
+//
+// Assume a Repository CRD in which the `RepositoryName` field of v1alpha1 was
+// renamed to `Name` in v1 (the hub version), the output for toHub=true would
+// look like this:
+//
+//	dst.Spec.Name = src.Spec.RepositoryName
+//	if src.Spec.ImageScanningConfiguration != nil {
+//		f1 := &v1.ImageScanningConfiguration{}
+//		f1.ScanOnPush = src.Spec.ImageScanningConfiguration.ScanOnPush
+//		dst.Spec.ImageScanningConfiguration = f1
+//	}
+//
+// Fields that were added or removed between the two versions have no
+// counterpart to copy to or from and are skipped.
+func ConvertResource(
+	delta *multiversion.CRDDelta,
+	// true when converting from the spoke version to the hub version, false
+	// when converting from the hub version to the spoke version
+	toHub bool,
+	// The import alias of the hub API version package (e.g. "v1")
+	hubImportAlias string,
+	// The variable name of the resource we're converting from
+	sourceVarName string,
+	// The variable name of the resource we're converting to
+	targetVarName string,
+	indentLevel int,
+) string {
+	targetPkg := ""
+	if toHub {
+		targetPkg = hubImportAlias
+	}
+	out := ""
+	fieldIndex := 0
+	for _, fieldDeltas := range []struct {
+		adaptedName string
+		deltas      []multiversion.FieldDelta
+	}{
+		{"Spec", delta.SpecDeltas},
+		{"Status", delta.StatusDeltas},
+	} {
+		for _, fieldDelta := range fieldDeltas.deltas {
+			sourceField := fieldDelta.Source
+			targetField := fieldDelta.Destination
+			if !toHub {
+				sourceField, targetField = targetField, sourceField
+			}
+			out += convertResourceField(
+				fieldDelta.ChangeType,
+				sourceField,
+				targetField,
+				targetPkg,
+				fmt.Sprintf("%s.%s", sourceVarName, fieldDeltas.adaptedName),
+				fmt.Sprintf("%s.%s", targetVarName, fieldDeltas.adaptedName),
+				fieldIndex,
+				indentLevel,
+			)
+			fieldIndex++
+		}
+	}
+	return out
+}
+
+// convertResourceField returns the Go code that copies a single top-level
+// Spec or Status field from the source resource to the target resource.
+func convertResourceField(
+	changeType multiversion.FieldChangeType,
+	sourceField *model.Field,
+	targetField *model.Field,
+	// The package alias of the target resource's types, empty if the types
+	// are in the package the code is generated into
+	targetPkg string,
+	// The Spec or Status struct of the source resource
+	sourceAdaptedVarName string,
+	// The Spec or Status struct of the target resource
+	targetAdaptedVarName string,
+	fieldIndex int,
+	indentLevel int,
+) string {
+	if sourceField == nil || targetField == nil {
+		// Field was added or removed; nothing to copy
+		return ""
+	}
+	indent := strings.Repeat("\t", indentLevel)
+	sourceVar := fmt.Sprintf("%s.%s", sourceAdaptedVarName, sourceField.Names.Camel)
+	targetVar := fmt.Sprintf("%s.%s", targetAdaptedVarName, targetField.Names.Camel)
+
+	switch changeType {
+	case multiversion.FieldChangeTypeShapeChangedFromStringToSecret,
+		multiversion.FieldChangeTypeShapeChangedFromSecretToString:
+		return fmt.Sprintf(
+			"%s// %s cannot be converted to %s: field changed between a string and a Secret reference\n",
+			indent, sourceVar, targetVar,
+		)
+	}
+
+	// Fields without a shape (for instance fields unpacked from attribute
+	// maps) are always scalars
+	if sourceField.ShapeRef == nil || targetField.ShapeRef == nil ||
+		!shapeNeedsConversion(targetField.CRD, sourceField.ShapeRef.Shape) {
+		if sourceField.GoType != targetField.GoType {
+			return fmt.Sprintf(
+				"%s// %s cannot be converted to %s: field type changed from %s to %s\n",
+				indent, sourceVar, targetVar,
+				sourceField.GoType, targetField.GoType,
+			)
+		}
+		return fmt.Sprintf("%s%s = %s\n", indent, targetVar, sourceVar)
+	}
+	if sourceField.ShapeRef.Shape.Type != targetField.ShapeRef.Shape.Type {
+		return fmt.Sprintf(
+			"%s// %s cannot be converted to %s: field type changed from %s to %s\n",
+			indent, sourceVar, targetVar,
+			sourceField.ShapeRef.Shape.Type, targetField.ShapeRef.Shape.Type,
+		)
+	}
+
+	out := ""
+	fieldVarName := fmt.Sprintf("f%d", fieldIndex)
+	// if src.Spec.Tags != nil {
+	out += fmt.Sprintf("%sif %s != nil {\n", indent, sourceVar)
+	out += convertForContainer(
+		targetField.CRD,
+		targetPkg,
+		fieldVarName,
+		targetField.ShapeRef,
+		sourceVar,
+		sourceField.ShapeRef,
+		indentLevel+1,
+	)
+	//     dst.Spec.Tags = f0
+	out += fmt.Sprintf("%s\t%s = %s\n", indent, targetVar, fieldVarName)
+	out += fmt.Sprintf("%s}\n", indent)
+	return out
+}
+
+// convertForContainer returns the Go code that declares a variable named
+// targetVarName holding a copy of a source struct, slice or map value that
+// has been converted to the types of the target API version. Struct members
+// that only exist in one of the two versions are skipped.
+func convertForContainer(
+	// The CRD the target types belong to
+	r *model.CRD,
+	// The package alias of the target types
+	targetPkg string,
+	// The variable name to declare
+	targetVarName string,
+	// ShapeRef of the target value
+	targetShapeRef *awssdkmodel.ShapeRef,
+	// The variable we access our (non-nil) source value from
+	sourceVarName string,
+	// ShapeRef of the source value
+	sourceShapeRef *awssdkmodel.ShapeRef,
+	indentLevel int,
+) string {
+	out := ""
+	indent := strings.Repeat("\t", indentLevel)
+	sourceShape := sourceShapeRef.Shape
+	targetShape := targetShapeRef.Shape
+
+	switch sourceShape.Type {
+	case "structure":
+		// f0 := &v1.ImageScanningConfiguration{}
+		out += fmt.Sprintf(
+			"%s%s := &%s{}\n", indent, targetVarName,
+			convertTypeName(r, targetShape, targetPkg),
+		)
+		for memberIndex, memberName := range sourceShape.MemberNames() {
+			targetMemberRef, found := targetShape.MemberRefs[memberName]
+			if !found {
+				continue
+			}
+			sourceMemberRef := sourceShape.MemberRefs[memberName]
+			cleanNames := names.New(memberName)
+			sourceMemberVar := fmt.Sprintf("%s.%s", sourceVarName, cleanNames.Camel)
+			targetMemberVar := fmt.Sprintf("%s.%s", targetVarName, cleanNames.Camel)
+			if !shapeNeedsConversion(r, sourceMemberRef.Shape) {
+				// f0.ScanOnPush = src.Spec.ImageScanningConfiguration.ScanOnPush
+				out += fmt.Sprintf("%s%s = %s\n", indent, targetMemberVar, sourceMemberVar)
+				continue
+			}
+			if sourceMemberRef.Shape.Type != targetMemberRef.Shape.Type {
+				continue
+			}
+			memberVarName := fmt.Sprintf("%sf%d", targetVarName, memberIndex)
+			out += fmt.Sprintf("%sif %s != nil {\n", indent, sourceMemberVar)
+			if r.IsRecursiveMember(targetShape, memberName) {
+				// var f0f1 *v1.Filter
+				out += fmt.Sprintf(
+					"%s\tvar %s %s\n", indent, memberVarName,
+					convertGoType(r, targetMemberRef.Shape, targetPkg),
+				)
+				out += copyViaJSON(
+					memberVarName, sourceMemberVar, "err", indentLevel+1,
+				)
+			} else {
+				out += convertForContainer(
+					r, targetPkg,
+					memberVarName,
+					targetMemberRef,
+					sourceMemberVar,
+					sourceMemberRef,
+					indentLevel+1,
+				)
+			}
+			out += fmt.Sprintf("%s\t%s = %s\n", indent, targetMemberVar, memberVarName)
+			out += fmt.Sprintf("%s}\n", indent)
+		}
+	case "list":
+		iterVarName := fmt.Sprintf("%siter", targetVarName)
+		elemVarName := fmt.Sprintf("%selem", targetVarName)
+		// f0 := []*v1.Tag{}
+		out += fmt.Sprintf(
+			"%s%s := %s{}\n", indent, targetVarName,
+			convertGoType(r, targetShape, targetPkg),
+		)
+		// for _, f0iter := range src.Spec.Tags {
+		out += fmt.Sprintf("%sfor _, %s := range %s {\n", indent, iterVarName, sourceVarName)
+		out += convertForContainer(
+			r, targetPkg,
+			elemVarName,
+			&targetShape.MemberRef,
+			iterVarName,
+			&sourceShape.MemberRef,
+			indentLevel+1,
+		)
+		//     f0 = append(f0, f0elem)
+		out += fmt.Sprintf(
+			"%s\t%s = append(%s, %s)\n", indent,
+			targetVarName, targetVarName, elemVarName,
+		)
+		out += fmt.Sprintf("%s}\n", indent)
+	case "map":
+		keyVarName := fmt.Sprintf("%skey", targetVarName)
+		valIterVarName := fmt.Sprintf("%svaliter", targetVarName)
+		valVarName := fmt.Sprintf("%sval", targetVarName)
+		// f0 := map[string]*v1.Tag{}
+		out += fmt.Sprintf(
+			"%s%s := %s{}\n", indent, targetVarName,
+			convertGoType(r, targetShape, targetPkg),
+		)
+		// for f0key, f0valiter := range src.Spec.Tags {
+		out += fmt.Sprintf(
+			"%sfor %s, %s := range %s {\n", indent,
+			keyVarName, valIterVarName, sourceVarName,
+		)
+		out += convertForContainer(
+			r, targetPkg,
+			valVarName,
+			&targetShape.ValueRef,
+			valIterVarName,
+			&sourceShape.ValueRef,
+			indentLevel+1,
+		)
+		//     f0[f0key] = f0val
+		out += fmt.Sprintf(
+			"%s\t%s[%s] = %s\n", indent, targetVarName, keyVarName, valVarName,
+		)
+		out += fmt.Sprintf("%s}\n", indent)
+	default:
+		// f0 := (*v1.ImageTagMutability)(src.Spec.ImageTagMutability)
+		out += fmt.Sprintf(
+			"%s%s := (%s)(%s)\n", indent, targetVarName,
+			convertGoType(r, targetShape, targetPkg), sourceVarName,
+		)
+	}
+	return out
+}
+
+// shapeNeedsConversion returns true if a value of the supplied shape cannot
+// simply be assigned across API versions, which is the case for any struct
+// or enum type or container of struct or enum types since those are defined
+// in each API version's package. Documents are runtime.RawExtension values in
+// every API version.
+func shapeNeedsConversion(r *model.CRD, shape *awssdkmodel.Shape) bool {
+	if shape == nil {
+		return false
+	}
+	switch shape.Type {
+	case "structure":
+		return !r.IsDocument(shape)
+	case "list":
+		return shapeNeedsConversion(r, shape.MemberRef.Shape)
+	case "map":
+		return shapeNeedsConversion(r, shape.ValueRef.Shape)
+	}
+	return r.EnumTypeName(shape) != ""
+}
+
+// convertGoType returns the Go type of a struct, slice or map shape in the
+// supplied API version package.
+func convertGoType(
+	r *model.CRD,
+	shape *awssdkmodel.Shape,
+	pkg string,
+) string {
+	switch shape.Type {
+	case "structure":
+		return "*" + convertTypeName(r, shape, pkg)
+	case "list":
+		return "[]" + convertGoType(r, shape.MemberRef.Shape, pkg)
+	case "map":
+		return "map[string]" + convertGoType(r, shape.ValueRef.Shape, pkg)
+	}
+	if enumType := r.EnumTypeName(shape); enumType != "" {
+		if pkg == "" {
+			return "*" + enumType
+		}
+		return "*" + pkg + "." + enumType
+	}
+	// Other scalar container elements are never converted, so the package
+	// does not matter.
+	return shape.GoType()
+}
+
+// convertTypeName returns the (optionally package-qualified) name of the type
+// definition generated for a struct shape, taking into account any type
+// renames for the CRD's API version.
+func convertTypeName(
+	r *model.CRD,
+	shape *awssdkmodel.Shape,
+	pkg string,
+) string {
+	typeName := names.New(shape.ShapeName).Camel
+	if altTypeName, renamed := r.TypeRenames()[shape.ShapeName]; renamed {
+		typeName = altTypeName
+	}
+	if pkg == "" {
+		return typeName
+	}
+	return pkg + "." + typeName
+}
@@ -0,0 +1,101 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//	 http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package code_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/aws-controllers-k8s/code-generator/pkg/generate/code"
+	"github.com/aws-controllers-k8s/code-generator/pkg/model/multiversion"
+	"github.com/aws-controllers-k8s/code-generator/pkg/testutil"
+)
+
+func TestConvertResource_ECR_Repository(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	spoke := testutil.NewModelForServiceWithOptions(t, "ecr", &testutil.TestingModelOptions{
+		APIVersion:          "v1alpha1",
+		GeneratorConfigFile: "generator-v1alpha1.yaml",
+	})
+	hub := testutil.NewModelForServiceWithOptions(t, "ecr", &testutil.TestingModelOptions{
+		APIVersion:          "v1alpha2",
+		GeneratorConfigFile: "generator-v1alpha2.yaml",
+	})
+
+	spokeCRD := testutil.GetCRDByName(t, spoke, "Repository")
+	require.NotNil(spokeCRD)
+	hubCRD := testutil.GetCRDByName(t, hub, "Repository")
+	require.NotNil(hubCRD)
+
+	delta, err := multiversion.ComputeCRDFieldDeltas(spokeCRD, hubCRD)
+	require.Nil(err)
+
+	expectedToHub := `
+	dst.Spec.ImageTagMutability = src.Spec.ImageTagMutability
+	dst.Spec.Name = src.Spec.RepositoryName
+	if src.Spec.ImageScanningConfiguration != nil {
+		f2 := &v1alpha2.ImageScanningConfiguration{}
+		f2.ScanOnPush = src.Spec.ImageScanningConfiguration.ScanOnPush
+		dst.Spec.ScanConfig = f2
+	}
+	if src.Spec.Tags != nil {
+		f3 := []*v1alpha2.Tag{}
+		for _, f3iter := range src.Spec.Tags {
+			f3elem := &v1alpha2.Tag{}
+			f3elem.Key = f3iter.Key
+			f3elem.Value = f3iter.Value
+			f3 = append(f3, f3elem)
+		}
+		dst.Spec.Tags = f3
+	}
+	dst.Status.CreatedAt = src.Status.CreatedAt
+	dst.Status.RegistryID = src.Status.RegistryID
+	dst.Status.RepositoryURI = src.Status.RepositoryURI
+`
+	assert.Equal(
+		expectedToHub,
+		"\n"+code.ConvertResource(delta, true, "v1alpha2", "src", "dst", 1),
+	)
+
+	expectedFromHub := `
+	dst.Spec.ImageTagMutability = src.Spec.ImageTagMutability
+	dst.Spec.RepositoryName = src.Spec.Name
+	if src.Spec.ScanConfig != nil {
+		f2 := &ImageScanningConfiguration{}
+		f2.ScanOnPush = src.Spec.ScanConfig.ScanOnPush
+		dst.Spec.ImageScanningConfiguration = f2
+	}
+	if src.Spec.Tags != nil {
+		f3 := []*Tag{}
+		for _, f3iter := range src.Spec.Tags {
+			f3elem := &Tag{}
+			f3elem.Key = f3iter.Key
+			f3elem.Value = f3iter.Value
+			f3 = append(f3, f3elem)
+		}
+		dst.Spec.Tags = f3
+	}
+	dst.Status.CreatedAt = src.Status.CreatedAt
+	dst.Status.RegistryID = src.Status.RegistryID
+	dst.Status.RepositoryURI = src.Status.RepositoryURI
+`
+	assert.Equal(
+		expectedFromHub,
+		"\n"+code.ConvertResource(delta, false, "v1alpha2", "src", "dst", 1),
+	)
+}
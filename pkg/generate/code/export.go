@@ -0,0 +1,155 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package code
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/aws-controllers-k8s/code-generator/pkg/model"
+	"github.com/aws-controllers-k8s/code-generator/pkg/names"
+)
+
+// metadataFieldGoTypes are the Go types of the scalar fields of the
+// resource's ACKResourceMetadata, keyed by their name
+var metadataFieldGoTypes = map[string]string{
+	"ARN":            "*ackv1alpha1.AWSResourceName",
+	"OwnerAccountID": "*ackv1alpha1.AWSAccountID",
+}
+
+// ExportData returns the Go code that sets the keys of a map[string]string
+// variable to the values of the resource's exported fields, skipping the
+// fields that are not set.
+//
+// Assume a DBInstance exporting its `Status.Endpoint.Address` to the `host`
+// key and its `Spec.MasterUsername` to the `username` key. The output would
+// look like this:
+//
+//	if ko.Status.Endpoint != nil && ko.Status.Endpoint.Address != nil {
+//		data["host"] = fmt.Sprint(*ko.Status.Endpoint.Address)
+//	}
+//	if ko.Spec.MasterUsername != nil {
+//		data["username"] = fmt.Sprint(*ko.Spec.MasterUsername)
+//	}
+func ExportData(
+	r *model.CRD,
+	// The variable name of the resource's Kubernetes object
+	sourceVarName string,
+	// The variable name of the map[string]string to set the keys of
+	targetVarName string,
+	indentLevel int,
+) string {
+	export := r.Export()
+	if export == nil {
+		return ""
+	}
+	keys := []string{}
+	for key := range export.Fields {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	out := ""
+	indent := strings.Repeat("\t", indentLevel)
+	for _, key := range keys {
+		goPath, goType := scalarFieldGoPath(r, export.Fields[key])
+		value, conds := scalarFieldValue(sourceVarName, goPath, goType)
+		setValue := fmt.Sprintf("%s[%q] = fmt.Sprint(%s)\n", targetVarName, key, value)
+		if len(conds) == 0 {
+			out += indent + setValue
+			continue
+		}
+		out += fmt.Sprintf("%sif %s {\n", indent, strings.Join(conds, " && "))
+		out += fmt.Sprintf("%s\t%s", indent, setValue)
+		out += fmt.Sprintf("%s}\n", indent)
+	}
+	return out
+}
+
+// scalarFieldGoPath returns the Go field names of the supplied path of a
+// field, starting with "Spec" or "Status", and the Go type of the field.
+// Panics if the path doesn't lead to a scalar field of the resource.
+func scalarFieldGoPath(
+	r *model.CRD,
+	path string,
+) ([]string, string) {
+	parts := strings.Split(path, ".")
+	if len(parts) < 2 {
+		panic(fmt.Sprintf(
+			"%s: field path %q has no field name", r.Names.Original, path,
+		))
+	}
+	if len(parts) == 3 && parts[0] == "Status" && parts[1] == "ACKResourceMetadata" {
+		if goType, found := metadataFieldGoTypes[parts[2]]; found {
+			return parts, goType
+		}
+	}
+	goPath := []string{parts[0]}
+	for _, part := range parts[1:] {
+		goPath = append(goPath, names.New(part).Camel)
+	}
+	topFields := r.SpecFields
+	if goPath[0] == "Status" {
+		topFields = r.StatusFields
+	}
+	var field *model.Field
+	for _, topField := range topFields {
+		if topField.Names.Camel == goPath[1] {
+			field = topField
+			break
+		}
+	}
+	if field != nil && len(goPath) > 2 {
+		field = r.Fields[field.Path+"."+strings.Join(goPath[2:], ".")]
+	}
+	if field == nil {
+		panic(fmt.Sprintf(
+			"%s: field path %q is not a field of the resource",
+			r.Names.Original, path,
+		))
+	}
+	if strings.HasPrefix(field.GoType, "[]") ||
+		strings.HasPrefix(field.GoType, "map[") ||
+		(field.ShapeRef != nil && field.ShapeRef.Shape.Type == "structure") {
+		panic(fmt.Sprintf(
+			"%s: field path %q must lead to a scalar field, not %s",
+			r.Names.Original, path, field.GoType,
+		))
+	}
+	return goPath, field.GoType
+}
+
+// scalarFieldValue returns the Go expression of the value of the scalar field
+// having the supplied Go field names and type, and the conditions under which
+// the field is set
+func scalarFieldValue(
+	sourceVarName string,
+	goPath []string,
+	goType string,
+) (string, []string) {
+	conds := []string{}
+	// The Spec and Status structs are never nil, the structs nested in them
+	// may be
+	for i := 2; i < len(goPath); i++ {
+		conds = append(conds, fmt.Sprintf(
+			"%s.%s != nil", sourceVarName, strings.Join(goPath[:i], "."),
+		))
+	}
+	value := sourceVarName + "." + strings.Join(goPath, ".")
+	if strings.HasPrefix(goType, "*") {
+		conds = append(conds, value+" != nil")
+		value = "*" + value
+	}
+	return value, conds
+}
@@ -0,0 +1,67 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//	 http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package code_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/aws-controllers-k8s/code-generator/pkg/generate/code"
+	"github.com/aws-controllers-k8s/code-generator/pkg/testutil"
+)
+
+func TestExportData_RDS_DBInstance(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	g := testutil.NewModelForServiceWithOptions(t, "rds", &testutil.TestingModelOptions{
+		GeneratorConfigFile: "generator-with-export.yaml",
+	})
+
+	crd := testutil.GetCRDByName(t, g, "DBInstance")
+	require.NotNil(crd)
+
+	expected := `
+	if ko.Status.ACKResourceMetadata != nil && ko.Status.ACKResourceMetadata.ARN != nil {
+		data["arn"] = fmt.Sprint(*ko.Status.ACKResourceMetadata.ARN)
+	}
+	if ko.Status.Endpoint != nil && ko.Status.Endpoint.Address != nil {
+		data["host"] = fmt.Sprint(*ko.Status.Endpoint.Address)
+	}
+	if ko.Status.Endpoint != nil && ko.Status.Endpoint.Port != nil {
+		data["port"] = fmt.Sprint(*ko.Status.Endpoint.Port)
+	}
+	if ko.Spec.MasterUsername != nil {
+		data["username"] = fmt.Sprint(*ko.Spec.MasterUsername)
+	}
+`
+	assert.Equal(
+		expected,
+		"\n"+code.ExportData(crd, "ko", "data", 1),
+	)
+}
+
+func TestExportData_RDS_DBInstance_NoExport(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	g := testutil.NewModelForService(t, "rds")
+
+	crd := testutil.GetCRDByName(t, g, "DBInstance")
+	require.NotNil(crd)
+
+	assert.Equal("", code.ExportData(crd, "ko", "data", 1))
+}
@@ -74,7 +74,9 @@ func getSortedLateInitFieldsAndConfig(
 // LateInitializeFromReadOne returns the gocode to set LateInitialization fields from the ReadOne output
 // Field path separated by '.' indicates members in a struct
 // Field path separated by '..' indicates member/key in a map
-// Note: Unlike Map, updating individual element of a list is not supported. LateInitializing complete list is supported.
+// Field path part suffixed by '[Member]' indicates a list whose elements are matched by their Member member,
+// and suffixed by '[]' a map whose values are matched by their key, e.g. "LogDeliveryConfigurations[LogType].DestinationType"
+// LateInitializing complete list is also supported.
 //
 // Sample generator config:
 // fields:
@@ -155,46 +157,50 @@ func LateInitializeFromReadOne(
 	out += fmt.Sprintf("%slatestKo := rm.concreteResource(%s).ko.DeepCopy()\n", indent, targetResVarName)
 	// TODO(vijat@): Add validation for correct field path in lateInitializedFieldNames
 	for _, fName := range lateInitializedFieldNames {
-		// split the field name by period
-		// each substring represents a field.
-		fNameParts := strings.Split(fName, ".")
+		fNameParts := parseLateInitFieldPath(fName)
 		// fNameIndentLevel tracks the indentation level for every new line added
 		// This variable is incremented when building nested if blocks and decremented when closing those if blocks.
 		fNameIndentLevel := indentLevel
-		// fParentPath keeps track of parent path for any fNamePart
-		fParentPath := ""
-		mapShapedParent := false
-		// for every part except last, perform the nil check
-		// entries in both source and target koVarName should not be nil
+		// observedPath and latestPath are the Go code accessing the parent
+		// of the part in the observed and latest resources
+		observedPath := "observedKo.Spec"
+		latestPath := "latestKo.Spec"
 		for i, fNamePart := range fNameParts {
-			if fNamePart == "" {
-				mapShapedParent = true
-				continue
-			}
 			indent := strings.Repeat("\t", fNameIndentLevel)
-			fNamePartAccesor := fmt.Sprintf("Spec%s.%s", fParentPath, fNamePart)
-			if mapShapedParent {
-				fNamePartAccesor = fmt.Sprintf("Spec%s[%q]", fParentPath, fNamePart)
-			}
+			observedPartPath := observedPath + fNamePart.accessor
+			latestPartPath := latestPath + fNamePart.accessor
 			// Handling for all parts except last one
 			if i != len(fNameParts)-1 {
-				out += fmt.Sprintf("%sif observedKo.%s != nil && latestKo.%s != nil {\n", indent, fNamePartAccesor, fNamePartAccesor)
-				// update fParentPath and fNameIndentLevel for next iteration
-				if mapShapedParent {
-					fParentPath = fmt.Sprintf("%s[%q]", fParentPath, fNamePart)
-					mapShapedParent = false
-				} else {
-					fParentPath = fmt.Sprintf("%s.%s", fParentPath, fNamePart)
-				}
+				out += fmt.Sprintf("%sif %s != nil && %s != nil {\n", indent, observedPartPath, latestPartPath)
 				fNameIndentLevel = fNameIndentLevel + 1
+				observedPath = observedPartPath
+				latestPath = latestPartPath
+				if fNamePart.isList || fNamePart.isMap {
+					// Late initialize the members of the elements of the
+					// latest resource's list or map from the observed
+					// resource's element having the same key
+					observedPath = fmt.Sprintf("observedElem%d", i)
+					latestPath = fmt.Sprintf("latestElem%d", i)
+					out += lateInitializeElements(
+						fNamePart, observedPartPath, latestPartPath,
+						observedPath, latestPath, i, fNameIndentLevel,
+					)
+					fNameIndentLevel = fNameIndentLevel + 1
+					if fNamePart.isList {
+						fNameIndentLevel = fNameIndentLevel + 1
+					}
+				}
 			} else {
+				if fNamePart.isList || fNamePart.isMap {
+					panic(fmt.Sprintf("late initialized field path %s can't end with a list or map element", fName))
+				}
 				// handle last part here
 				// for last part, set the lateInitialized field if user did not specify field value and readOne has server side defaulted value.
 				// i.e. field is not nil in sourceKoVarName but is nil in targetkoVarName
-				out += fmt.Sprintf("%sif observedKo.%s != nil && latestKo.%s == nil {\n", indent, fNamePartAccesor, fNamePartAccesor)
+				out += fmt.Sprintf("%sif %s != nil && %s == nil {\n", indent, observedPartPath, latestPartPath)
 				fNameIndentLevel = fNameIndentLevel + 1
 				indent = strings.Repeat("\t", fNameIndentLevel)
-				out += fmt.Sprintf("%slatestKo.%s = observedKo.%s\n", indent, fNamePartAccesor, fNamePartAccesor)
+				out += fmt.Sprintf("%s%s = %s\n", indent, latestPartPath, observedPartPath)
 			}
 		}
 		// Close all if blocks with proper indentation
@@ -290,41 +296,39 @@ func IncompleteLateInitialization(
 	}
 	out += fmt.Sprintf("%sko := rm.concreteResource(%s).ko.DeepCopy()\n", indent, resVarName)
 	for _, fName := range sortedLateInitFieldNames {
-		// split the field name by period
-		// each substring represents a field.
-		fNameParts := strings.Split(fName, ".")
+		fNameParts := parseLateInitFieldPath(fName)
 		// fNameIndentLevel tracks the indentation level for every new line added
 		// This variable is incremented when building nested if blocks and decremented when closing those if blocks.
 		fNameIndentLevel := indentLevel
-		// fParentPath keeps track of parent path for any fNamePart
-		fParentPath := ""
-		mapShapedParent := false
+		// parentPath is the Go code accessing the parent of the part
+		parentPath := "ko.Spec"
 		for i, fNamePart := range fNameParts {
-			if fNamePart == "" {
-				mapShapedParent = true
-				continue
-			}
 			indent := strings.Repeat("\t", fNameIndentLevel)
-			fNamePartAccesor := fmt.Sprintf("Spec%s.%s", fParentPath, fNamePart)
-			if mapShapedParent {
-				fNamePartAccesor = fmt.Sprintf("Spec%s[%q]", fParentPath, fNamePart)
-			}
+			partPath := parentPath + fNamePart.accessor
 			// Handling for all parts except last one
 			if i != len(fNameParts)-1 {
-				out += fmt.Sprintf("%sif ko.%s != nil {\n", indent, fNamePartAccesor)
-				// update fParentPath and fNameIndentLevel for next iteration
-				if mapShapedParent {
-					fParentPath = fmt.Sprintf("%s[%q]", fParentPath, fNamePart)
-					mapShapedParent = false
-				} else {
-					fParentPath = fmt.Sprintf("%s.%s", fParentPath, fNamePart)
-				}
+				out += fmt.Sprintf("%sif %s != nil {\n", indent, partPath)
 				fNameIndentLevel = fNameIndentLevel + 1
+				parentPath = partPath
+				if fNamePart.isList || fNamePart.isMap {
+					// Every element of the list or map must be late
+					// initialized
+					parentPath = fmt.Sprintf("elem%d", i)
+					indent = strings.Repeat("\t", fNameIndentLevel)
+					out += fmt.Sprintf("%sfor _, %s := range %s {\n", indent, parentPath, partPath)
+					out += fmt.Sprintf("%s\tif %s == nil {\n", indent, parentPath)
+					out += fmt.Sprintf("%s\t\tcontinue\n", indent)
+					out += fmt.Sprintf("%s\t}\n", indent)
+					fNameIndentLevel = fNameIndentLevel + 1
+				}
 			} else {
+				if fNamePart.isList || fNamePart.isMap {
+					panic(fmt.Sprintf("late initialized field path %s can't end with a list or map element", fName))
+				}
 				// handle last part here
 				// for last part, if the late initialized field is still nil, calculate the retry backoff using
 				// acktypes.LateInitializationRetryConfig abstraction and set the incompleteInitialization flag to true
-				out += fmt.Sprintf("%sif ko.%s == nil {\n", indent, fNamePartAccesor)
+				out += fmt.Sprintf("%sif %s == nil {\n", indent, partPath)
 				fNameIndentLevel = fNameIndentLevel + 1
 				indent = strings.Repeat("\t", fNameIndentLevel)
 				out += fmt.Sprintf("%sreturn true\n", indent)
@@ -340,3 +344,155 @@ func IncompleteLateInitialization(
 	out += fmt.Sprintf("%sreturn false", indent)
 	return out
 }
+
+// lateInitPathPart is a part of the path of a late initialized field
+type lateInitPathPart struct {
+	// accessor is the Go code accessing the part from its parent, e.g.
+	// ".Name" for a struct member or `["key"]` for a map value
+	accessor string
+	// isList is true if the part is a list whose elements are matched by
+	// their listKey member, e.g. "LogDeliveryConfigurations[LogType]"
+	isList bool
+	// listKey is the name of the member identifying the list's elements
+	listKey string
+	// isMap is true if the part is a map whose values are matched by their
+	// key, e.g. "Tags[]"
+	isMap bool
+}
+
+// parseLateInitFieldPath returns the parts of the supplied late initialized
+// field path. Parts are separated by '.', and the part following '..' is the
+// key of a value in a map. A part suffixed by '[Member]' is a list whose
+// elements are identified by their Member member, and a part suffixed by
+// '[]' is a map whose values are all late initialized.
+func parseLateInitFieldPath(fName string) []lateInitPathPart {
+	parts := []lateInitPathPart{}
+	mapShapedParent := false
+	for _, fNamePart := range strings.Split(fName, ".") {
+		if fNamePart == "" {
+			mapShapedParent = true
+			continue
+		}
+		part := lateInitPathPart{}
+		if open := strings.Index(fNamePart, "["); open > 0 && strings.HasSuffix(fNamePart, "]") {
+			part.listKey = fNamePart[open+1 : len(fNamePart)-1]
+			part.isList = part.listKey != ""
+			part.isMap = part.listKey == ""
+			fNamePart = fNamePart[:open]
+		}
+		if mapShapedParent {
+			part.accessor = fmt.Sprintf("[%q]", fNamePart)
+			mapShapedParent = false
+		} else {
+			part.accessor = "." + fNamePart
+		}
+		parts = append(parts, part)
+	}
+	return parts
+}
+
+// lateInitializeElements returns the Go code that iterates over the elements
+// of the latest resource's list or map and pairs them with the elements of
+// the observed resource's list or map having the same key.
+//
+// For a list part "LogDeliveryConfigurations[LogType]", the output looks
+// like this:
+//
+//	for _, latestElem0 := range latestKo.Spec.LogDeliveryConfigurations {
+//		for _, observedElem0 := range observedKo.Spec.LogDeliveryConfigurations {
+//			if latestElem0 == nil || observedElem0 == nil ||
+//				latestElem0.LogType == nil || observedElem0.LogType == nil ||
+//				*latestElem0.LogType != *observedElem0.LogType {
+//				continue
+//			}
+//
+// And for a map part "Configs[]":
+//
+//	for key0, latestElem0 := range latestKo.Spec.Configs {
+//		observedElem0 := observedKo.Spec.Configs[key0]
+//		if latestElem0 == nil || observedElem0 == nil {
+//			continue
+//		}
+func lateInitializeElements(
+	part lateInitPathPart,
+	// Go code accessing the observed and latest resources' list or map
+	observedVarName string,
+	latestVarName string,
+	// Names of the variables holding the paired elements
+	observedElemVarName string,
+	latestElemVarName string,
+	// Index of the part in the field path, used to name the variables
+	partIndex int,
+	// Number of levels of indentation to use
+	indentLevel int,
+) string {
+	out := ""
+	indent := strings.Repeat("\t", indentLevel)
+	if part.isMap {
+		keyVarName := fmt.Sprintf("key%d", partIndex)
+		out += fmt.Sprintf("%sfor %s, %s := range %s {\n", indent, keyVarName, latestElemVarName, latestVarName)
+		out += fmt.Sprintf("%s\t%s := %s[%s]\n", indent, observedElemVarName, observedVarName, keyVarName)
+		out += fmt.Sprintf("%s\tif %s == nil || %s == nil {\n", indent, latestElemVarName, observedElemVarName)
+		out += fmt.Sprintf("%s\t\tcontinue\n", indent)
+		out += fmt.Sprintf("%s\t}\n", indent)
+		return out
+	}
+	latestKey := latestElemVarName + "." + part.listKey
+	observedKey := observedElemVarName + "." + part.listKey
+	out += fmt.Sprintf("%sfor _, %s := range %s {\n", indent, latestElemVarName, latestVarName)
+	out += fmt.Sprintf("%s\tfor _, %s := range %s {\n", indent, observedElemVarName, observedVarName)
+	out += fmt.Sprintf("%s\t\tif %s == nil || %s == nil ||\n", indent, latestElemVarName, observedElemVarName)
+	out += fmt.Sprintf("%s\t\t\t%s == nil || %s == nil ||\n", indent, latestKey, observedKey)
+	out += fmt.Sprintf("%s\t\t\t*%s != *%s {\n", indent, latestKey, observedKey)
+	out += fmt.Sprintf("%s\t\t\tcontinue\n", indent)
+	out += fmt.Sprintf("%s\t\t}\n", indent)
+	return out
+}
+
+// ReadBackSpecDefaults returns the Go code setting the Spec fields of a
+// resource the user didn't set from the same fields of the resource read back
+// from the AWS service API, so they take the defaults the service assigned to
+// them. Secret fields and fields having a custom Go type are left as is.
+//
+// Sample output:
+//
+//	if ko.Spec.ImageTagMutability == nil {
+//		ko.Spec.ImageTagMutability = observed.ko.Spec.ImageTagMutability
+//	}
+func ReadBackSpecDefaults(
+	cfg *ackgenconfig.Config,
+	r *model.CRD,
+	// String representing the name of the variable holding the CR read back,
+	// e.g. "observed.ko"
+	sourceVarName string,
+	// String representing the name of the variable holding the CR being
+	// defaulted, e.g. "ko"
+	targetVarName string,
+	// Number of levels of indentation to use
+	indentLevel int,
+) string {
+	out := ""
+	indent := strings.Repeat("\t", indentLevel)
+	fieldConfigs := cfg.ResourceFields(r.Names.Original)
+
+	fieldNames := []string{}
+	for fieldName := range r.SpecFields {
+		fieldNames = append(fieldNames, fieldName)
+	}
+	sort.Strings(fieldNames)
+	for _, fieldName := range fieldNames {
+		field := r.SpecFields[fieldName]
+		if field.HasCustomGoType() {
+			continue
+		}
+		if fConfig := fieldConfigs[fieldName]; fConfig != nil && fConfig.IsSecret {
+			continue
+		}
+		target := targetVarName + cfg.PrefixConfig.SpecField + "." + field.Names.Camel
+		source := sourceVarName + cfg.PrefixConfig.SpecField + "." + field.Names.Camel
+		out += fmt.Sprintf("%sif %s == nil {\n", indent, target)
+		out += fmt.Sprintf("%s\t%s = %s\n", indent, target, source)
+		out += fmt.Sprintf("%s}\n", indent)
+	}
+	return out
+}
@@ -233,3 +233,105 @@ func Test_IncompleteLateInitialization(t *testing.T) {
 	return false`
 	assert.Equal(expected, code.IncompleteLateInitialization(crd.Config(), crd, "latest", 1))
 }
+
+func Test_LateInitializeFromReadOne_ListElements(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	g := testutil.NewModelForServiceWithOptions(t, "elasticache", &testutil.TestingModelOptions{GeneratorConfigFile: "generator-with-list-late-initialize.yaml"})
+
+	crd := testutil.GetCRDByName(t, g, "ReplicationGroup")
+	require.NotNil(crd)
+	expected :=
+		`	observedKo := rm.concreteResource(observed).ko.DeepCopy()
+	latestKo := rm.concreteResource(latest).ko.DeepCopy()
+	if observedKo.Spec.LogDeliveryConfigurations != nil && latestKo.Spec.LogDeliveryConfigurations != nil {
+		for _, latestElem0 := range latestKo.Spec.LogDeliveryConfigurations {
+			for _, observedElem0 := range observedKo.Spec.LogDeliveryConfigurations {
+				if latestElem0 == nil || observedElem0 == nil ||
+					latestElem0.LogType == nil || observedElem0.LogType == nil ||
+					*latestElem0.LogType != *observedElem0.LogType {
+					continue
+				}
+				if observedElem0.DestinationDetails != nil && latestElem0.DestinationDetails != nil {
+					if observedElem0.DestinationDetails.CloudWatchLogsDetails != nil && latestElem0.DestinationDetails.CloudWatchLogsDetails != nil {
+						if observedElem0.DestinationDetails.CloudWatchLogsDetails.LogGroup != nil && latestElem0.DestinationDetails.CloudWatchLogsDetails.LogGroup == nil {
+							latestElem0.DestinationDetails.CloudWatchLogsDetails.LogGroup = observedElem0.DestinationDetails.CloudWatchLogsDetails.LogGroup
+						}
+					}
+				}
+			}
+		}
+	}
+	if observedKo.Spec.LogDeliveryConfigurations != nil && latestKo.Spec.LogDeliveryConfigurations != nil {
+		for _, latestElem0 := range latestKo.Spec.LogDeliveryConfigurations {
+			for _, observedElem0 := range observedKo.Spec.LogDeliveryConfigurations {
+				if latestElem0 == nil || observedElem0 == nil ||
+					latestElem0.LogType == nil || observedElem0.LogType == nil ||
+					*latestElem0.LogType != *observedElem0.LogType {
+					continue
+				}
+				if observedElem0.LogFormat != nil && latestElem0.LogFormat == nil {
+					latestElem0.LogFormat = observedElem0.LogFormat
+				}
+			}
+		}
+	}
+	return &resource{latestKo}`
+	assert.Equal(expected, code.LateInitializeFromReadOne(crd.Config(), crd, "observed", "latest", 1))
+
+	expected =
+		`	ko := rm.concreteResource(latest).ko.DeepCopy()
+	if ko.Spec.LogDeliveryConfigurations != nil {
+		for _, elem0 := range ko.Spec.LogDeliveryConfigurations {
+			if elem0 == nil {
+				continue
+			}
+			if elem0.DestinationDetails != nil {
+				if elem0.DestinationDetails.CloudWatchLogsDetails != nil {
+					if elem0.DestinationDetails.CloudWatchLogsDetails.LogGroup == nil {
+						return true
+					}
+				}
+			}
+		}
+	}
+	if ko.Spec.LogDeliveryConfigurations != nil {
+		for _, elem0 := range ko.Spec.LogDeliveryConfigurations {
+			if elem0 == nil {
+				continue
+			}
+			if elem0.LogFormat == nil {
+				return true
+			}
+		}
+	}
+	return false`
+	assert.Equal(expected, code.IncompleteLateInitialization(crd.Config(), crd, "latest", 1))
+}
+
+func Test_ReadBackSpecDefaults(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	g := testutil.NewModelForServiceWithOptions(t, "ecr", &testutil.TestingModelOptions{GeneratorConfigFile: "generator-with-read-back-on-create.yaml"})
+
+	crd := testutil.GetCRDByName(t, g, "Repository")
+	require.NotNil(crd)
+	assert.True(crd.ReadBackOnCreate())
+
+	expected := `	if ko.Spec.ImageScanningConfiguration == nil {
+		ko.Spec.ImageScanningConfiguration = observed.ko.Spec.ImageScanningConfiguration
+	}
+	if ko.Spec.ImageTagMutability == nil {
+		ko.Spec.ImageTagMutability = observed.ko.Spec.ImageTagMutability
+	}
+	if ko.Spec.RepositoryName == nil {
+		ko.Spec.RepositoryName = observed.ko.Spec.RepositoryName
+	}
+	if ko.Spec.Tags == nil {
+		ko.Spec.Tags = observed.ko.Spec.Tags
+	}
+`
+	assert.Equal(expected, code.ReadBackSpecDefaults(crd.Config(), crd, "observed.ko", "ko", 1))
+}
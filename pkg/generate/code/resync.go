@@ -0,0 +1,91 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package code
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/aws-controllers-k8s/code-generator/pkg/model"
+)
+
+// ResyncPeriod returns the Go code that returns the `time.Duration` after
+// which a resource is requeued once it is reconciled, from the resync config
+// of its fields, checked in the order of their paths, and the resync period of
+// the resource.
+//
+// Assume a DBInstance requeued every 15 seconds while its
+// `Status.DBInstanceStatus` is "creating" or "modifying", and every 300
+// seconds otherwise. The output would look like this:
+//
+//	if ko.Status.DBInstanceStatus != nil {
+//		switch *ko.Status.DBInstanceStatus {
+//		case "creating", "modifying":
+//			return 15 * time.Second
+//		}
+//	}
+//	return 300 * time.Second
+func ResyncPeriod(
+	r *model.CRD,
+	// The variable name of the resource's Kubernetes object
+	sourceVarName string,
+	indentLevel int,
+) string {
+	out := ""
+	indent := strings.Repeat("\t", indentLevel)
+	resyncFields := r.ResyncFields()
+	paths := []string{}
+	for path := range resyncFields {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+	for _, path := range paths {
+		resync := resyncFields[path]
+		goPath, goType := scalarFieldGoPath(r, path)
+		if strings.TrimPrefix(goType, "*") != "string" {
+			field := r.Fields[strings.Join(goPath[1:], ".")]
+			if field == nil || field.ShapeRef == nil || field.ShapeRef.Shape.Type != "string" {
+				panic(fmt.Sprintf(
+					"%s: resync field %s must be a string field, not %s",
+					r.Names.Original, path, goType,
+				))
+			}
+		}
+		value, conds := scalarFieldValue(sourceVarName, goPath, goType)
+		period := fmt.Sprintf("return %d * time.Second\n", resync.Seconds)
+		bodyIndent := indent
+		if len(conds) > 0 {
+			out += fmt.Sprintf("%sif %s {\n", indent, strings.Join(conds, " && "))
+			bodyIndent += "\t"
+		}
+		if len(resync.Values) == 0 {
+			out += bodyIndent + period
+		} else {
+			values := []string{}
+			for _, v := range resync.Values {
+				values = append(values, fmt.Sprintf("%q", v))
+			}
+			out += fmt.Sprintf("%sswitch %s {\n", bodyIndent, value)
+			out += fmt.Sprintf("%scase %s:\n", bodyIndent, strings.Join(values, ", "))
+			out += fmt.Sprintf("%s\t%s", bodyIndent, period)
+			out += fmt.Sprintf("%s}\n", bodyIndent)
+		}
+		if len(conds) > 0 {
+			out += fmt.Sprintf("%s}\n", indent)
+		}
+	}
+	out += fmt.Sprintf("%sreturn %d * time.Second\n", indent, r.ResyncSeconds())
+	return out
+}
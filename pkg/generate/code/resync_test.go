@@ -0,0 +1,60 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//	 http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package code_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/aws-controllers-k8s/code-generator/pkg/generate/code"
+	"github.com/aws-controllers-k8s/code-generator/pkg/testutil"
+)
+
+func TestResyncPeriod_RDS_DBInstance(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	g := testutil.NewModelForServiceWithOptions(t, "rds", &testutil.TestingModelOptions{
+		GeneratorConfigFile: "generator-with-resync.yaml",
+	})
+
+	crd := testutil.GetCRDByName(t, g, "DBInstance")
+	require.NotNil(crd)
+	require.True(crd.HasResync())
+
+	expected := `
+	if r.ko.Status.DBInstanceStatus != nil {
+		switch *r.ko.Status.DBInstanceStatus {
+		case "creating", "modifying":
+			return 15 * time.Second
+		}
+	}
+	if r.ko.Status.Endpoint != nil && r.ko.Status.Endpoint.Address != nil {
+		return 60 * time.Second
+	}
+	return 300 * time.Second
+`
+	assert.Equal(
+		expected,
+		"\n"+code.ResyncPeriod(crd, "r.ko", 1),
+	)
+
+	// Resources without resync config aren't requeued
+	crd = testutil.GetCRDByName(t, g, "DBSubnetGroup")
+	require.NotNil(crd)
+	assert.False(crd.HasResync())
+	assert.Equal("\treturn 0 * time.Second\n", code.ResyncPeriod(crd, "r.ko", 1))
+}
@@ -138,10 +138,26 @@ func SetResource(
 	// Recursively descend through the set of fields on the Output shape,
 	// creating temporary variables, populating those temporary variables'
 	// fields with further-nested fields as needed
+	batchOp := r.BatchOperation(op)
 	for memberIndex, memberName := range outputShape.MemberNames() {
 		//TODO: (vijat@) should these field be renamed before looking them up in spec?
 		sourceAdaptedVarName := sourceVarName + "." + memberName
 
+		if batchOp != nil && memberName == batchOp.ErrorsMember {
+			continue
+		}
+		if batchOp != nil && memberName == batchOp.OutputMember {
+			out += setResourceForBatchOutput(
+				cfg, r, op,
+				memberName,
+				outputShape.MemberRefs[memberName],
+				sourceVarName,
+				targetVarName,
+				indentLevel,
+			)
+			continue
+		}
+
 		// Handle the special case of ARN for primary resource identifier
 		if r.IsPrimaryARNField(memberName) {
 			// if ko.Status.ACKResourceMetadata == nil {
@@ -204,6 +220,10 @@ func SetResource(
 			// TODO(jaypipes): check generator config for exceptions?
 			continue
 		}
+		if f.IsCustomField() {
+			// Custom fields are set by hook code
+			continue
+		}
 
 		targetMemberShapeRef = f.ShapeRef
 
@@ -289,7 +309,19 @@ func SetResource(
 			"%s.%s", targetAdaptedVarName, f.Names.Camel,
 		)
 
-		switch sourceMemberShape.Type {
+		sourceMemberShapeType := sourceMemberShape.Type
+		if f.TagFormat() != "" {
+			sourceMemberShapeType = "tags"
+		}
+		switch sourceMemberShapeType {
+		case "tags":
+			out += setResourceForTags(
+				f.TagFormat(),
+				qualifiedTargetVar,
+				fmt.Sprintf("f%d", memberIndex),
+				sourceAdaptedVarName,
+				indentLevel+1,
+			)
 		case "list", "structure", "map":
 			{
 				memberVarName := fmt.Sprintf("f%d", memberIndex)
@@ -310,7 +342,9 @@ func SetResource(
 					indentLevel+1,
 				)
 				out += setResourceForScalar(
+					cfg, r,
 					qualifiedTargetVar,
+					targetMemberShapeRef,
 					memberVarName,
 					sourceMemberShapeRef,
 					indentLevel+1,
@@ -334,7 +368,9 @@ func SetResource(
 				}
 			}
 			out += setResourceForScalar(
+				cfg, r,
 				qualifiedTargetVar,
+				targetMemberShapeRef,
 				sourceAdaptedVarName,
 				sourceMemberShapeRef,
 				indentLevel+1,
@@ -361,8 +397,9 @@ func ListMemberNameInReadManyOutput(
 	// resources. This heuristic is simplistic (just look for the field with a
 	// list type) but seems to be followed consistently by the aws-sdk-go for
 	// List operations.
-	for memberName, memberShapeRef := range r.Ops.ReadMany.OutputRef.Shape.MemberRefs {
-		if memberShapeRef.Shape.Type == "list" {
+	outputShape := r.Ops.ReadMany.OutputRef.Shape
+	for _, memberName := range outputShape.MemberNames() {
+		if outputShape.MemberRefs[memberName].Shape.Type == "list" {
 			return memberName
 		}
 	}
@@ -451,7 +488,8 @@ func setResourceReadMany(
 	// resources. This heuristic is simplistic (just look for the field with a
 	// list type) but seems to be followed consistently by the aws-sdk-go for
 	// List operations.
-	for memberName, memberShapeRef := range outputShape.MemberRefs {
+	for _, memberName := range outputShape.MemberNames() {
+		memberShapeRef := outputShape.MemberRefs[memberName]
 		if memberShapeRef.Shape.Type == "list" {
 			listShapeName = memberName
 			sourceElemShape = memberShapeRef.Shape.MemberRef.Shape
@@ -485,6 +523,9 @@ func setResourceReadMany(
 		"%sfor _, elem := range %s.%s {\n",
 		indent, sourceVarName, listShapeName,
 	)
+	out += setResourceReadManyMatch(
+		cfg, r, op, sourceElemShape, "elem", targetVarName, indentLevel+1,
+	)
 	for memberIndex, memberName := range sourceElemShape.MemberNames() {
 		sourceMemberShapeRef := sourceElemShape.MemberRefs[memberName]
 		sourceMemberShape := sourceMemberShapeRef.Shape
@@ -543,7 +584,11 @@ func setResourceReadMany(
 		} else if inStatus {
 			targetAdaptedVarName += cfg.PrefixConfig.StatusField
 			f = r.StatusFields[fieldName]
-		} else if foundFieldRename {
+		} else if foundFieldRename && cfg.ResourceFieldRenameIsLiteral(
+			r.Names.Original, op.Name, memberName,
+		) {
+			// Rename patterns may match members that aren't fields, but
+			// explicit renames must refer to a field
 			msg := fmt.Sprintf(
 				"Field rename %s for operation %s is not part of %s Spec or"+
 					" Status fields", memberName, op.Name, r.Names.Camel)
@@ -552,6 +597,10 @@ func setResourceReadMany(
 			// field not found in Spec or Status
 			continue
 		}
+		if f.IsCustomField() {
+			// Custom fields are set by hook code
+			continue
+		}
 
 		// We may have some special instructions for how to handle setting the
 		// field value...
@@ -570,7 +619,19 @@ func setResourceReadMany(
 		qualifiedTargetVar := fmt.Sprintf(
 			"%s.%s", targetAdaptedVarName, f.Names.Camel,
 		)
-		switch sourceMemberShape.Type {
+		sourceMemberShapeType := sourceMemberShape.Type
+		if f.TagFormat() != "" {
+			sourceMemberShapeType = "tags"
+		}
+		switch sourceMemberShapeType {
+		case "tags":
+			out += setResourceForTags(
+				f.TagFormat(),
+				qualifiedTargetVar,
+				fmt.Sprintf("f%d", memberIndex),
+				sourceAdaptedVarName,
+				indentLevel+2,
+			)
 		case "list", "structure", "map":
 			{
 				memberVarName := fmt.Sprintf("f%d", memberIndex)
@@ -591,7 +652,9 @@ func setResourceReadMany(
 					indentLevel+2,
 				)
 				out += setResourceForScalar(
+					cfg, r,
 					qualifiedTargetVar,
+					targetMemberShapeRef,
 					memberVarName,
 					sourceMemberShapeRef,
 					indentLevel+2,
@@ -610,12 +673,17 @@ func setResourceReadMany(
 					targetAdaptedVarName,
 					f.Names.Camel,
 				)
+				matchVar := fmt.Sprintf(
+					"*%s.%s", targetAdaptedVarName, f.Names.Camel,
+				)
+				if r.EnumTypeName(f.ShapeRef.Shape) != "" {
+					matchVar = "string(" + matchVar + ")"
+				}
 				out += fmt.Sprintf(
-					"%s\t\t\tif *%s != *%s.%s {\n",
+					"%s\t\t\tif *%s != %s {\n",
 					indent,
 					sourceAdaptedVarName,
-					targetAdaptedVarName,
-					f.Names.Camel,
+					matchVar,
 				)
 				out += fmt.Sprintf(
 					"%s\t\t\t\tcontinue\n", indent,
@@ -629,7 +697,9 @@ func setResourceReadMany(
 			}
 			//          r.ko.Spec.CacheClusterID = elem.CacheClusterId
 			out += setResourceForScalar(
+				cfg, r,
 				qualifiedTargetVar,
+				targetMemberShapeRef,
 				sourceAdaptedVarName,
 				sourceMemberShapeRef,
 				indentLevel+2,
@@ -668,6 +738,153 @@ func setResourceReadMany(
 	return out
 }
 
+// setResourceReadManyMatch returns the Go code that skips the elements of a
+// List operation's Output shape not meeting all the match criteria configured
+// for the resource, before any field of the target variable is set from them.
+//
+// For the ImageScanningConfiguration.ScanOnPush and ImageTagMutability
+// criteria of an ECR Repository, the returned code looks like this:
+//
+//	if ko.Spec.ImageScanningConfiguration != nil && ko.Spec.ImageScanningConfiguration.ScanOnPush != nil {
+//		if elem.ImageScanningConfiguration == nil || elem.ImageScanningConfiguration.ScanOnPush == nil || *elem.ImageScanningConfiguration.ScanOnPush != *ko.Spec.ImageScanningConfiguration.ScanOnPush {
+//			continue
+//		}
+//	}
+//	if elem.ImageTagMutability == nil || *elem.ImageTagMutability != "MUTABLE" {
+//		continue
+//	}
+func setResourceReadManyMatch(
+	cfg *ackgenconfig.Config,
+	r *model.CRD,
+	// The ReadMany operation descriptor
+	op *awssdkmodel.Operation,
+	// The Shape of the elements of the List operation's Output shape
+	elemShape *awssdkmodel.Shape,
+	// String representing the name of the variable holding the list element,
+	// e.g. "elem"
+	sourceVarName string,
+	// String representing the name of the variable holding the CR, e.g.
+	// "ko"
+	targetVarName string,
+	// Number of levels of indentation to use
+	indentLevel int,
+) string {
+	out := ""
+	indent := strings.Repeat("\t", indentLevel)
+	for _, match := range r.ListOpMatches() {
+		// elem.ImageScanningConfiguration == nil ||
+		// elem.ImageScanningConfiguration.ScanOnPush == nil
+		sourceVarPath := sourceVarName
+		sourceConds := []string{}
+		shape := elemShape
+		for _, memberName := range strings.Split(match.Path, ".") {
+			if shape == nil || shape.Type != "structure" {
+				// This is a compile-time failure, just bomb out...
+				msg := fmt.Sprintf(
+					"match path %s of resource %s traverses a non-structure member",
+					match.Path, r.Names.Original,
+				)
+				panic(msg)
+			}
+			memberRef, found := shape.MemberRefs[memberName]
+			if !found {
+				// This is a compile-time failure, just bomb out...
+				msg := fmt.Sprintf(
+					"match path %s of resource %s is not in shape %s",
+					match.Path, r.Names.Original, elemShape.ShapeName,
+				)
+				panic(msg)
+			}
+			sourceVarPath += "." + memberName
+			sourceConds = append(sourceConds, sourceVarPath+" == nil")
+			shape = memberRef.Shape
+		}
+		switch shape.Type {
+		case "list", "map", "structure", "timestamp", "blob":
+			// This is a compile-time failure, just bomb out...
+			msg := fmt.Sprintf(
+				"match path %s of resource %s does not refer to a comparable scalar member",
+				match.Path, r.Names.Original,
+			)
+			panic(msg)
+		}
+
+		if match.Value != nil {
+			if shape.Type != "string" {
+				// This is a compile-time failure, just bomb out...
+				msg := fmt.Sprintf(
+					"match path %s of resource %s must refer to a string member to match a value",
+					match.Path, r.Names.Original,
+				)
+				panic(msg)
+			}
+			// if elem.ImageTagMutability == nil || *elem.ImageTagMutability != "MUTABLE" {
+			//     continue
+			// }
+			out += fmt.Sprintf(
+				"%sif %s || *%s != %q {\n",
+				indent, strings.Join(sourceConds, " || "), sourceVarPath,
+				*match.Value,
+			)
+			out += fmt.Sprintf("%s\tcontinue\n", indent)
+			out += fmt.Sprintf("%s}\n", indent)
+			continue
+		}
+
+		fieldPath := match.Field
+		if fieldPath == "" {
+			// The field the matched member is set into
+			parts := strings.Split(match.Path, ".")
+			fieldName, _ := cfg.ResourceFieldRename(
+				r.Names.Original, op.Name, parts[0],
+			)
+			fieldPath = names.New(fieldName).Camel
+			for _, part := range parts[1:] {
+				fieldPath += "." + names.New(part).Camel
+			}
+		}
+		field, found := r.Fields[fieldPath]
+		if !found || field.ShapeRef == nil {
+			// This is a compile-time failure, just bomb out...
+			msg := fmt.Sprintf(
+				"match field %s is not a field of resource %s",
+				fieldPath, r.Names.Original,
+			)
+			panic(msg)
+		}
+		fieldParts := strings.Split(fieldPath, ".")
+		targetVarPath := targetVarName
+		topField := r.Fields[fieldParts[0]]
+		if _, inSpec := r.SpecFields[topField.Names.Original]; inSpec {
+			targetVarPath += cfg.PrefixConfig.SpecField
+		} else {
+			targetVarPath += cfg.PrefixConfig.StatusField
+		}
+		// ko.Spec.ImageScanningConfiguration != nil &&
+		// ko.Spec.ImageScanningConfiguration.ScanOnPush != nil
+		targetConds := []string{}
+		for _, part := range fieldParts {
+			targetVarPath += "." + part
+			targetConds = append(targetConds, targetVarPath+" != nil")
+		}
+		matchVar := "*" + targetVarPath
+		if r.EnumTypeName(field.ShapeRef.Shape) != "" {
+			matchVar = "string(" + matchVar + ")"
+		}
+		out += fmt.Sprintf(
+			"%sif %s {\n", indent, strings.Join(targetConds, " && "),
+		)
+		out += fmt.Sprintf(
+			"%s\tif %s || *%s != %s {\n",
+			indent, strings.Join(sourceConds, " || "), sourceVarPath, matchVar,
+		)
+		out += fmt.Sprintf("%s\t\tcontinue\n", indent)
+		out += fmt.Sprintf("%s\t}\n", indent)
+		out += fmt.Sprintf("%s}\n", indent)
+	}
+	return out
+}
+
 // ackResourceMetadataGuardConstructor returns Go code representing a nil-guard
 // and constructor for an ACKResourceMetadata struct:
 //
@@ -766,6 +983,7 @@ func SetResourceGetAttributes(
 	out := "\n"
 	indent := strings.Repeat("\t", indentLevel)
 	adaptiveTargetVarName := targetVarName + cfg.PrefixConfig.StatusField
+	attrsMemberName := r.AttributesMapMemberName()
 
 	// did we output an ACKResourceMetadata guard and constructor snippet?
 	mdGuardOut := false
@@ -786,10 +1004,11 @@ func SetResourceGetAttributes(
 				mdGuardOut = true
 			}
 			out += fmt.Sprintf(
-				"%stmpARN := ackv1alpha1.AWSResourceName(*%s.Attributes[\"%s\"])\n",
+				"%stmpARN := ackv1alpha1.AWSResourceName(*%s.%s[\"%s\"])\n",
 				indent,
 				sourceVarName,
-				fieldName,
+				attrsMemberName,
+				r.AttributeKey(fieldName),
 			)
 			out += fmt.Sprintf(
 				"%s%s.ACKResourceMetadata.ARN = &tmpARN\n",
@@ -808,10 +1027,11 @@ func SetResourceGetAttributes(
 				mdGuardOut = true
 			}
 			out += fmt.Sprintf(
-				"%stmpOwnerID := ackv1alpha1.AWSAccountID(*%s.Attributes[\"%s\"])\n",
+				"%stmpOwnerID := ackv1alpha1.AWSAccountID(*%s.%s[\"%s\"])\n",
 				indent,
 				sourceVarName,
-				fieldName,
+				attrsMemberName,
+				r.AttributeKey(fieldName),
 			)
 			out += fmt.Sprintf(
 				"%s%s.ACKResourceMetadata.OwnerAccountID = &tmpOwnerID\n",
@@ -824,12 +1044,13 @@ func SetResourceGetAttributes(
 		fieldNames := names.New(fieldName)
 		if fieldConfig.IsReadOnly {
 			out += fmt.Sprintf(
-				"%s%s.%s = %s.Attributes[\"%s\"]\n",
+				"%s%s.%s = %s.%s[\"%s\"]\n",
 				indent,
 				adaptiveTargetVarName,
 				fieldNames.Camel,
 				sourceVarName,
-				fieldName,
+				attrsMemberName,
+				r.AttributeKey(fieldName),
 			)
 		}
 	}
@@ -843,7 +1064,9 @@ func SetResourceGetAttributes(
 //
 // The method will attempt to look for the field denoted with a value of true
 // for `is_primary_key`, or will use the ARN if the resource has a value of true
-// for `is_arn_primary_key`. Otherwise, the method will attempt to use the
+// for `is_arn_primary_key` or if the ARN is the only required member of its
+// ReadOne (or GetAttributes) operation's Input shape. Otherwise, the method
+// will attempt to use the
 // `ReadOne` operation, if present, falling back to using `ReadMany`.
 // If it detects the operation uses an ARN to identify the resource it will read
 // it from the metadata status field. Otherwise it will use any field with a
@@ -906,6 +1129,25 @@ func SetResourceIdentifiers(
 	// Number of levels of indentation to use
 	indentLevel int,
 ) string {
+	indent := strings.Repeat("\t", indentLevel)
+
+	// if r.ko.Status.ACKResourceMetadata == nil {
+	//  r.ko.Status.ACKResourceMetadata = &ackv1alpha1.ResourceMetadata{}
+	// }
+	// r.ko.Status.ACKResourceMetadata.ARN = identifier.ARN
+	arnOut := "\n"
+	arnOut += ackResourceMetadataGuardConstructor(fmt.Sprintf("%s.Status", targetVarName), indentLevel)
+	arnOut += fmt.Sprintf(
+		"%s%s.Status.ACKResourceMetadata.ARN = %s.ARN\n",
+		indent, targetVarName, sourceVarName,
+	)
+
+	// Check if the CRD defines the primary keys, or is only identified by its
+	// ARN
+	if r.IsARNPrimaryKey() {
+		return arnOut
+	}
+
 	op := r.Ops.ReadOne
 	if op == nil {
 		if r.Ops.GetAttributes != nil {
@@ -915,6 +1157,11 @@ func SetResourceIdentifiers(
 		// If single lookups can only be done using ReadMany
 		op = r.Ops.ReadMany
 	}
+	if op == nil {
+		// The resource can't be read, e.g. SNS PlatformEndpoint, so there are
+		// no identifiers to set its fields from
+		return ""
+	}
 	inputShape := op.InputRef.Shape
 	if inputShape == nil {
 		return ""
@@ -923,26 +1170,8 @@ func SetResourceIdentifiers(
 	primaryKeyOut := ""
 	additionalKeyOut := "\n"
 
-	indent := strings.Repeat("\t", indentLevel)
-
 	primaryKeyConditionalOut := "\n"
 	primaryKeyConditionalOut += identifierNameOrIDGuardConstructor(sourceVarName, indentLevel)
-
-	// if r.ko.Status.ACKResourceMetadata == nil {
-	//  r.ko.Status.ACKResourceMetadata = &ackv1alpha1.ResourceMetadata{}
-	// }
-	// r.ko.Status.ACKResourceMetadata.ARN = identifier.ARN
-	arnOut := "\n"
-	arnOut += ackResourceMetadataGuardConstructor(fmt.Sprintf("%s.Status", targetVarName), indentLevel)
-	arnOut += fmt.Sprintf(
-		"%s%s.Status.ACKResourceMetadata.ARN = %s.ARN\n",
-		indent, targetVarName, sourceVarName,
-	)
-
-	// Check if the CRD defines the primary keys
-	if r.IsARNPrimaryKey() {
-		return arnOut
-	}
 	primaryField, err := r.GetPrimaryKeyField()
 	if err != nil {
 		panic(err)
@@ -965,6 +1194,16 @@ func SetResourceIdentifiers(
 		}
 	}
 
+	// The parts of a composite primary key are required additional keys
+	keyFields := map[*model.Field]bool{}
+	for _, keyField := range r.PrimaryKeyFields() {
+		keyFields[keyField] = true
+	}
+	setFields := map[*model.Field]bool{}
+	if isPrimarySet {
+		setFields[primaryField] = true
+	}
+
 	paginatorFieldLookup := []string{
 		"NextToken",
 		"MaxResults",
@@ -1029,6 +1268,7 @@ func SetResourceIdentifiers(
 		}
 
 		targetVarPath := fmt.Sprintf("%s%s", targetVarName, memberPath)
+		setFields[targetField] = true
 		if isPrimaryIdentifier {
 			primaryKeyOut += setResourceIdentifierPrimaryIdentifier(cfg, r,
 				targetField,
@@ -1043,10 +1283,30 @@ func SetResourceIdentifiers(
 				targetVarPath,
 				sourceVarName,
 				names.New(fieldName).CamelLower,
+				keyFields[targetField],
 				indentLevel)
 		}
 	}
 
+	// Key parts only required by the Delete operation
+	fieldIndex := len(inputShape.MemberNames())
+	for _, keyField := range r.PrimaryKeyFields() {
+		if setFields[keyField] {
+			continue
+		}
+		memberPath, _ := findFieldInCR(cfg, r, keyField.Names.Original)
+		additionalKeyOut += setResourceIdentifierAdditionalKey(
+			cfg, r,
+			fieldIndex,
+			keyField,
+			fmt.Sprintf("%s%s", targetVarName, memberPath),
+			sourceVarName,
+			keyField.Names.CamelLower,
+			true,
+			indentLevel)
+		fieldIndex++
+	}
+
 	return primaryKeyConditionalOut + primaryKeyOut + additionalKeyOut
 }
 
@@ -1094,7 +1354,9 @@ func setResourceIdentifierPrimaryIdentifier(
 	qualifiedTargetVar := fmt.Sprintf("%s.%s", targetVarName, targetField.Path)
 
 	return setResourceForScalar(
+		cfg, r,
 		qualifiedTargetVar,
+		targetField.ShapeRef,
 		adaptedMemberPath,
 		targetField.ShapeRef,
 		indentLevel,
@@ -1121,6 +1383,9 @@ func setResourceIdentifierAdditionalKey(
 	sourceVarName string,
 	// The key in the `AdditionalKeys` map storing the source variable
 	sourceVarKey string,
+	// Whether the key is part of a composite primary key, and must be
+	// supplied
+	required bool,
 	// Number of levels of indentation to use
 	indentLevel int,
 ) string {
@@ -1130,14 +1395,36 @@ func setResourceIdentifierAdditionalKey(
 
 	fieldIndexName := fmt.Sprintf("f%d", fieldIndex)
 	sourceAdaptedVarName := fmt.Sprintf("%s.AdditionalKeys[\"%s\"]", sourceVarName, sourceVarKey)
+	qualifiedTargetVar := fmt.Sprintf("%s.%s", targetVarName, targetField.Path)
 
-	// TODO(RedbackThomson): If the identifiers don't exist, we should be
-	// throwing an error accessible to the user
 	additionalKeyOut += fmt.Sprintf("%s%s, %sok := %s\n", indent, fieldIndexName, fieldIndexName, sourceAdaptedVarName)
+	if required {
+		// f0, f0ok := identifier.AdditionalKeys["clusterName"]
+		// if !f0ok {
+		// 	return fmt.Errorf("missing required additional key: clusterName")
+		// }
+		// r.ko.Spec.ClusterName = &f0
+		additionalKeyOut += fmt.Sprintf("%sif !%sok {\n", indent, fieldIndexName)
+		additionalKeyOut += fmt.Sprintf(
+			"%s\treturn fmt.Errorf(\"missing required additional key: %s\")\n",
+			indent, sourceVarKey,
+		)
+		additionalKeyOut += fmt.Sprintf("%s}\n", indent)
+		additionalKeyOut += setResourceForScalar(
+			cfg, r,
+			qualifiedTargetVar,
+			targetField.ShapeRef,
+			fmt.Sprintf("&%s", fieldIndexName),
+			targetField.ShapeRef,
+			indentLevel,
+		)
+		return additionalKeyOut
+	}
 	additionalKeyOut += fmt.Sprintf("%sif %sok {\n", indent, fieldIndexName)
-	qualifiedTargetVar := fmt.Sprintf("%s.%s", targetVarName, targetField.Path)
 	additionalKeyOut += setResourceForScalar(
+		cfg, r,
 		qualifiedTargetVar,
+		targetField.ShapeRef,
 		fmt.Sprintf("&%s", fieldIndexName),
 		targetField.ShapeRef,
 		indentLevel+1,
@@ -1168,6 +1455,13 @@ func setResourceForContainer(
 	sourceShapeRef *awssdkmodel.ShapeRef,
 	indentLevel int,
 ) string {
+	if r.IsDocument(sourceShapeRef.Shape) {
+		return setResourceForDocument(
+			targetVarName,
+			sourceVarName,
+			indentLevel,
+		)
+	}
 	switch sourceShapeRef.Shape.Type {
 	case "structure":
 		return SetResourceForStruct(
@@ -1204,7 +1498,9 @@ func setResourceForContainer(
 		)
 	default:
 		return setResourceForScalar(
+			cfg, r,
 			fmt.Sprintf("%s.%s", targetFieldName, targetVarName),
+			targetShapeRef,
 			sourceVarName,
 			sourceShapeRef,
 			indentLevel,
@@ -1212,6 +1508,135 @@ func setResourceForContainer(
 	}
 }
 
+// setResourceForDocument returns a string of Go code that sets a target
+// variable of type runtime.RawExtension to the JSON document held by a source
+// variable.
+//
+// The Go code output from this function looks like this:
+//
+//	f0raw, err := json.Marshal(resp.Definition)
+//	if err != nil {
+//	    return nil, err
+//	}
+//	f0.Raw = f0raw
+func setResourceForDocument(
+	// The variable name that we want to set a value to
+	targetVarName string,
+	// The struct or struct field that we access our source value from
+	sourceVarName string,
+	indentLevel int,
+) string {
+	out := ""
+	indent := strings.Repeat("\t", indentLevel)
+	rawVarName := targetVarName + "raw"
+	out += fmt.Sprintf(
+		"%s%s, err := json.Marshal(%s)\n",
+		indent, rawVarName, sourceVarName,
+	)
+	out += fmt.Sprintf("%sif err != nil {\n", indent)
+	out += fmt.Sprintf("%s\treturn nil, err\n", indent)
+	out += fmt.Sprintf("%s}\n", indent)
+	out += fmt.Sprintf("%s%s.Raw = %s\n", indent, targetVarName, rawVarName)
+	return out
+}
+
+// setResourceForTags returns a string of Go code that sets a target variable
+// to the tags held by a source variable whose tags are represented in the
+// other format.
+//
+// When the CR represents tags as a map, the Go code output from this function
+// looks like this:
+//
+//	f3 := map[string]*string{}
+//	for _, f3iter := range resp.Repository.Tags {
+//	    if f3iter.Key != nil {
+//	        f3[*f3iter.Key] = f3iter.Value
+//	    }
+//	}
+//	ko.Spec.Tags = f3
+//
+// When the CR represents tags as a list, the tags are sorted by key so that
+// the CR doesn't change every time the resource is read:
+//
+//	f3keys := []string{}
+//	for f3key := range resp.Tags {
+//	    f3keys = append(f3keys, f3key)
+//	}
+//	sort.Strings(f3keys)
+//	f3 := []*svcapitypes.Tag{}
+//	for _, f3key := range f3keys {
+//	    f3elem := &svcapitypes.Tag{}
+//	    f3elem.Key = aws.String(f3key)
+//	    f3elem.Value = resp.Tags[f3key]
+//	    f3 = append(f3, f3elem)
+//	}
+//	ko.Spec.Tags = f3
+func setResourceForTags(
+	// The format of the tags in the CR
+	tagFormat string,
+	// The fully-qualified variable that will be set to the tags
+	targetVar string,
+	// The name of the temporary variable holding the converted tags
+	varName string,
+	// The struct field that we access our source value from
+	sourceVarName string,
+	indentLevel int,
+) string {
+	out := ""
+	indent := strings.Repeat("\t", indentLevel)
+	if tagFormat == ackgenconfig.TagFormatMap {
+		iterVarName := varName + "iter"
+		out += fmt.Sprintf("%s%s := map[string]*string{}\n", indent, varName)
+		out += fmt.Sprintf(
+			"%sfor _, %s := range %s {\n", indent, iterVarName, sourceVarName,
+		)
+		out += fmt.Sprintf("%s\tif %s.Key != nil {\n", indent, iterVarName)
+		out += fmt.Sprintf(
+			"%s\t\t%s[*%s.Key] = %s.Value\n",
+			indent, varName, iterVarName, iterVarName,
+		)
+		out += fmt.Sprintf("%s\t}\n", indent)
+		out += fmt.Sprintf("%s}\n", indent)
+		out += fmt.Sprintf("%s%s = %s\n", indent, targetVar, varName)
+		return out
+	}
+	keysVarName := varName + "keys"
+	keyVarName := varName + "key"
+	elemVarName := varName + "elem"
+	out += fmt.Sprintf("%s%s := []string{}\n", indent, keysVarName)
+	out += fmt.Sprintf(
+		"%sfor %s := range %s {\n", indent, keyVarName, sourceVarName,
+	)
+	out += fmt.Sprintf(
+		"%s\t%s = append(%s, %s)\n",
+		indent, keysVarName, keysVarName, keyVarName,
+	)
+	out += fmt.Sprintf("%s}\n", indent)
+	out += fmt.Sprintf("%ssort.Strings(%s)\n", indent, keysVarName)
+	out += fmt.Sprintf(
+		"%s%s := []*svcapitypes.%s{}\n", indent, varName, model.TagTypeName,
+	)
+	out += fmt.Sprintf(
+		"%sfor _, %s := range %s {\n", indent, keyVarName, keysVarName,
+	)
+	out += fmt.Sprintf(
+		"%s\t%s := &svcapitypes.%s{}\n", indent, elemVarName, model.TagTypeName,
+	)
+	out += fmt.Sprintf(
+		"%s\t%s.Key = aws.String(%s)\n", indent, elemVarName, keyVarName,
+	)
+	out += fmt.Sprintf(
+		"%s\t%s.Value = %s[%s]\n",
+		indent, elemVarName, sourceVarName, keyVarName,
+	)
+	out += fmt.Sprintf(
+		"%s\t%s = append(%s, %s)\n", indent, varName, varName, elemVarName,
+	)
+	out += fmt.Sprintf("%s}\n", indent)
+	out += fmt.Sprintf("%s%s = %s\n", indent, targetVar, varName)
+	return out
+}
+
 // SetResourceForStruct returns a string of Go code that sets a target variable
 // value to a source variable when the type of the source variable is a struct.
 func SetResourceForStruct(
@@ -1241,6 +1666,10 @@ func SetResourceForStruct(
 		if targetMemberShapeRef == nil {
 			continue
 		}
+		if r.IsSecretShapeMember(targetShapeRef.ShapeName, memberName) {
+			// Secrets are never read back from the service
+			continue
+		}
 		memberVarName := fmt.Sprintf("%sf%d", targetVarName, memberIndex)
 		memberShapeRef := sourceShape.MemberRefs[memberName]
 		memberShape := memberShapeRef.Shape
@@ -1261,18 +1690,29 @@ func SetResourceForStruct(
 					targetMemberShapeRef.Shape,
 					indentLevel+1,
 				)
-				out += setResourceForContainer(
-					cfg, r,
-					cleanNames.Camel,
-					memberVarName,
-					targetMemberShapeRef,
-					nil,
-					sourceAdaptedVarName,
-					memberShapeRef,
-					indentLevel+1,
-				)
+				if r.IsRecursiveMember(sourceShape, memberName) {
+					out += copyViaJSON(
+						memberVarName,
+						sourceAdaptedVarName,
+						"nil, err",
+						indentLevel+1,
+					)
+				} else {
+					out += setResourceForContainer(
+						cfg, r,
+						cleanNames.Camel,
+						memberVarName,
+						targetMemberShapeRef,
+						nil,
+						sourceAdaptedVarName,
+						memberShapeRef,
+						indentLevel+1,
+					)
+				}
 				out += setResourceForScalar(
+					cfg, r,
 					qualifiedTargetVar,
+					targetMemberShapeRef,
 					memberVarName,
 					memberShapeRef,
 					indentLevel+1,
@@ -1280,7 +1720,9 @@ func SetResourceForStruct(
 			}
 		default:
 			out += setResourceForScalar(
+				cfg, r,
 				qualifiedTargetVar,
+				targetMemberShapeRef,
 				sourceAdaptedVarName,
 				memberShapeRef,
 				indentLevel+1,
@@ -1357,7 +1799,9 @@ func setResourceForSlice(
 	if targetSetCfg != nil && targetSetCfg.From != nil {
 		if sourceMemberShapeRef, found := sourceShape.MemberRef.Shape.MemberRefs[*targetSetCfg.From]; found {
 			out += setResourceForScalar(
+				cfg, r,
 				elemVarName,
+				&targetShape.MemberRef,
 				fmt.Sprintf("*%s.%s", iterVarName, *targetSetCfg.From),
 				sourceMemberShapeRef,
 				indentLevel+1,
@@ -1472,8 +1916,12 @@ func setResourceForMap(
 // value to a source variable when the type of the source variable is a scalar
 // type (not a map, slice or struct).
 func setResourceForScalar(
+	cfg *ackgenconfig.Config,
+	r *model.CRD,
 	// The fully-qualified variable that will be set to sourceVar
 	targetVar string,
+	// Shape Ref of the target variable
+	targetShapeRef *awssdkmodel.ShapeRef,
 	// The struct or struct field that we access our source value from
 	sourceVar string,
 	shapeRef *awssdkmodel.ShapeRef,
@@ -1483,13 +1931,97 @@ func setResourceForScalar(
 	indent := strings.Repeat("\t", indentLevel)
 	setTo := sourceVar
 	shape := shapeRef.Shape
+	enumType := ""
+	if shape.Type == "string" && targetShapeRef != nil {
+		enumType = r.EnumTypeName(targetShapeRef.Shape)
+	}
 	if shape.Type == "timestamp" {
-		setTo = "&metav1.Time{*" + sourceVar + "}"
+		switch resourceTimestampFormat(cfg, r, targetShapeRef) {
+		case ackgenconfig.TimestampFormatRFC3339:
+			// ko.Status.CreatedAt = aws.String(resp.CreatedAt.UTC().Format(time.RFC3339))
+			setTo = "aws.String(" + sourceVar + ".UTC().Format(time.RFC3339))"
+		case ackgenconfig.TimestampFormatEpoch:
+			// ko.Status.CreatedAt = aws.Int64(resp.CreatedAt.Unix())
+			setTo = "aws.Int64(" + sourceVar + ".Unix())"
+		default:
+			setTo = "&metav1.Time{*" + sourceVar + "}"
+		}
+	}
+	if f := resourceTopLevelField(r, targetShapeRef); f != nil && f.NumberFormat() != "" {
+		switch f.NumberFormat() {
+		case ackgenconfig.NumberFormatInt32:
+			// tmpVal := int32(*resp.MaxResults)
+			// ko.Spec.MaxResults = &tmpVal
+			out += fmt.Sprintf("%stmpVal := int32(*%s)\n", indent, sourceVar)
+			setTo = "&tmpVal"
+		case ackgenconfig.NumberFormatQuantity:
+			// tmpVal, err := k8sresource.ParseQuantity(strconv.FormatFloat(*resp.Weight, 'f', -1, 64))
+			// if err != nil {
+			//     return nil, err
+			// }
+			// ko.Spec.Weight = &tmpVal
+			out += fmt.Sprintf(
+				"%stmpVal, err := k8sresource.ParseQuantity(strconv.FormatFloat(*%s, 'f', -1, 64))\n",
+				indent, sourceVar,
+			)
+			out += fmt.Sprintf("%sif err != nil {\n", indent)
+			out += fmt.Sprintf("%s\treturn nil, err\n", indent)
+			out += fmt.Sprintf("%s}\n", indent)
+			setTo = "&tmpVal"
+		case ackgenconfig.NumberFormatString:
+			// ko.Spec.Weight = aws.String(strconv.FormatFloat(*resp.Weight, 'f', -1, 64))
+			setTo = "aws.String(strconv.FormatFloat(*" + sourceVar + ", 'f', -1, 64))"
+		}
 	}
 	if strings.HasPrefix(targetVar, ".") {
 		targetVar = targetVar[1:]
 		setTo = "*" + setTo
 	}
+	if enumType != "" {
+		if strings.HasPrefix(setTo, "*") {
+			// f0elem = svcapitypes.ScanType(*f0iter)
+			setTo = fmt.Sprintf("svcapitypes.%s(%s)", enumType, setTo)
+		} else {
+			// ko.Spec.ImageTagMutability = (*svcapitypes.ImageTagMutability)(resp.ImageTagMutability)
+			setTo = fmt.Sprintf("(*svcapitypes.%s)(%s)", enumType, setTo)
+		}
+	}
 	out += fmt.Sprintf("%s%s = %s\n", indent, targetVar, setTo)
 	return out
 }
+
+// resourceTimestampFormat returns the format of the timestamp value set to
+// the supplied target. Only top-level fields may have their own format, all
+// other timestamps use the API-wide format.
+func resourceTimestampFormat(
+	cfg *ackgenconfig.Config,
+	r *model.CRD,
+	// Shape Ref of the target variable
+	targetShapeRef *awssdkmodel.ShapeRef,
+) string {
+	if f := resourceTopLevelField(r, targetShapeRef); f != nil {
+		return f.TimestampFormat()
+	}
+	return cfg.GetTimestampFormat()
+}
+
+// resourceTopLevelField returns the top-level Spec or Status field with the
+// supplied Shape Ref, or nil if the Shape Ref isn't the one of a top-level
+// field
+func resourceTopLevelField(
+	r *model.CRD,
+	// Shape Ref of the target variable
+	targetShapeRef *awssdkmodel.ShapeRef,
+) *model.Field {
+	if targetShapeRef == nil {
+		return nil
+	}
+	for _, fields := range []map[string]*model.Field{r.SpecFields, r.StatusFields} {
+		for _, f := range fields {
+			if f.ShapeRef == targetShapeRef {
+				return f
+			}
+		}
+	}
+	return nil
+}
@@ -783,6 +783,196 @@ func TestSetResource_ECR_Repository_Create(t *testing.T) {
 	)
 }
 
+func TestSetResource_ECR_Repository_Create_EnumTypes(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	g := testutil.NewModelForServiceWithOptions(t, "ecr", &testutil.TestingModelOptions{
+		GeneratorConfigFile: "generator-with-enum-types.yaml",
+	})
+
+	crd := testutil.GetCRDByName(t, g, "Repository")
+	require.NotNil(crd)
+
+	// The ImageTagMutability string returned by the API is converted to the
+	// generated ImageTagMutability type of the Spec field.
+	expected := `
+	if resp.Repository.CreatedAt != nil {
+		ko.Status.CreatedAt = &metav1.Time{*resp.Repository.CreatedAt}
+	} else {
+		ko.Status.CreatedAt = nil
+	}
+	if resp.Repository.ImageScanningConfiguration != nil {
+		f1 := &svcapitypes.ImageScanningConfiguration{}
+		if resp.Repository.ImageScanningConfiguration.ScanOnPush != nil {
+			f1.ScanOnPush = resp.Repository.ImageScanningConfiguration.ScanOnPush
+		}
+		ko.Spec.ImageScanningConfiguration = f1
+	} else {
+		ko.Spec.ImageScanningConfiguration = nil
+	}
+	if resp.Repository.ImageTagMutability != nil {
+		ko.Spec.ImageTagMutability = (*svcapitypes.ImageTagMutability)(resp.Repository.ImageTagMutability)
+	} else {
+		ko.Spec.ImageTagMutability = nil
+	}
+	if resp.Repository.RegistryId != nil {
+		ko.Status.RegistryID = resp.Repository.RegistryId
+	} else {
+		ko.Status.RegistryID = nil
+	}
+	if ko.Status.ACKResourceMetadata == nil {
+		ko.Status.ACKResourceMetadata = &ackv1alpha1.ResourceMetadata{}
+	}
+	if resp.Repository.RepositoryArn != nil {
+		arn := ackv1alpha1.AWSResourceName(*resp.Repository.RepositoryArn)
+		ko.Status.ACKResourceMetadata.ARN = &arn
+	}
+	if resp.Repository.RepositoryName != nil {
+		ko.Spec.RepositoryName = resp.Repository.RepositoryName
+	} else {
+		ko.Spec.RepositoryName = nil
+	}
+	if resp.Repository.RepositoryUri != nil {
+		ko.Status.RepositoryURI = resp.Repository.RepositoryUri
+	} else {
+		ko.Status.RepositoryURI = nil
+	}
+`
+	assert.Equal(
+		expected,
+		code.SetResource(crd.Config(), crd, model.OpTypeCreate, "resp", "ko", 1),
+	)
+}
+
+func TestSetResource_ECR_Repository_Create_Document(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	g := testutil.NewModelForService(t, "ecr")
+	// None of the ECR shapes are documents, so pretend one is
+	g.SDKAPI.ShapeConstraints["ImageScanningConfiguration"] = &model.ShapeConstraints{
+		Document: true,
+	}
+
+	crd := testutil.GetCRDByName(t, g, "Repository")
+	require.NotNil(crd)
+
+	expected := `
+	if resp.Repository.ImageScanningConfiguration != nil {
+		f1 := &runtime.RawExtension{}
+		f1raw, err := json.Marshal(resp.Repository.ImageScanningConfiguration)
+		if err != nil {
+			return nil, err
+		}
+		f1.Raw = f1raw
+		ko.Spec.ImageScanningConfiguration = f1
+	} else {
+		ko.Spec.ImageScanningConfiguration = nil
+	}
+`
+	actual := code.SetResource(crd.Config(), crd, model.OpTypeCreate, "resp", "ko", 1)
+	assert.Contains(actual, expected)
+}
+
+func TestSetResource_ECR_Repository_Create_TimestampFormat(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	g := testutil.NewModelForServiceWithOptions(t, "ecr", &testutil.TestingModelOptions{
+		GeneratorConfigFile: "generator-with-timestamp-format.yaml",
+	})
+
+	crd := testutil.GetCRDByName(t, g, "Repository")
+	require.NotNil(crd)
+
+	expected := `
+	if resp.Repository.CreatedAt != nil {
+		ko.Status.CreatedAt = aws.String(resp.Repository.CreatedAt.UTC().Format(time.RFC3339))
+	} else {
+		ko.Status.CreatedAt = nil
+	}
+`
+	actual := code.SetResource(crd.Config(), crd, model.OpTypeCreate, "resp", "ko", 1)
+	assert.Contains(actual, expected)
+}
+
+func TestSetResource_Lambda_Function_Create_NumberFormat(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	g := testutil.NewModelForServiceWithOptions(t, "lambda", &testutil.TestingModelOptions{
+		GeneratorConfigFile: "generator-with-number-format.yaml",
+	})
+
+	crd := testutil.GetCRDByName(t, g, "Function")
+	require.NotNil(crd)
+
+	expected := `
+	if resp.MemorySize != nil {
+		tmpVal := int32(*resp.MemorySize)
+		ko.Spec.MemorySize = &tmpVal
+	} else {
+		ko.Spec.MemorySize = nil
+	}
+`
+	actual := code.SetResource(crd.Config(), crd, model.OpTypeCreate, "resp", "ko", 1)
+	assert.Contains(actual, expected)
+}
+
+func TestSetResource_Lambda_Function_Create_NestedMapSecret(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	g := testutil.NewModelForServiceWithOptions(t, "lambda", &testutil.TestingModelOptions{
+		GeneratorConfigFile: "generator-with-nested-secrets.yaml",
+	})
+
+	crd := testutil.GetCRDByName(t, g, "Function")
+	require.NotNil(crd)
+
+	// The environment variables reference Secrets in the Spec and are never
+	// read back from the service
+	actual := code.SetResource(crd.Config(), crd, model.OpTypeCreate, "resp", "ko", 1)
+	assert.Contains(actual, "ko.Spec.Environment = ")
+	assert.NotContains(actual, "Environment.Variables")
+}
+
+func TestSetResource_Lambda_Function_ReadOne_TagFormat(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	g := testutil.NewModelForServiceWithOptions(t, "lambda", &testutil.TestingModelOptions{
+		GeneratorConfigFile: "generator-with-tag-format.yaml",
+	})
+
+	crd := testutil.GetCRDByName(t, g, "Function")
+	require.NotNil(crd)
+
+	// Tags are sorted by key, so the Spec doesn't change on every read
+	expected := `
+	if resp.Tags != nil {
+		f3keys := []string{}
+		for f3key := range resp.Tags {
+			f3keys = append(f3keys, f3key)
+		}
+		sort.Strings(f3keys)
+		f3 := []*svcapitypes.Tag{}
+		for _, f3key := range f3keys {
+			f3elem := &svcapitypes.Tag{}
+			f3elem.Key = aws.String(f3key)
+			f3elem.Value = resp.Tags[f3key]
+			f3 = append(f3, f3elem)
+		}
+		ko.Spec.Tags = f3
+	} else {
+		ko.Spec.Tags = nil
+	}
+`
+	actual := code.SetResource(crd.Config(), crd, model.OpTypeGet, "resp", "ko", 1)
+	assert.Contains(actual, expected)
+}
+
 func TestSetResource_ECR_Repository_ReadMany(t *testing.T) {
 	assert := assert.New(t)
 	require := require.New(t)
@@ -859,6 +1049,90 @@ func TestSetResource_ECR_Repository_ReadMany(t *testing.T) {
 	)
 }
 
+func TestSetResource_ECR_Repository_ReadMany_Match(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	g := testutil.NewModelForServiceWithOptions(t, "ecr", &testutil.TestingModelOptions{
+		GeneratorConfigFile: "generator-with-list-match.yaml",
+	})
+
+	crd := testutil.GetCRDByName(t, g, "Repository")
+	require.NotNil(crd)
+
+	// Elements not meeting all the match criteria, including the nested
+	// ImageScanningConfiguration.ScanOnPush one, are skipped before any
+	// field of the CR is set from them
+	expected := `
+	found := false
+	for _, elem := range resp.Repositories {
+		if ko.Spec.RepositoryName != nil {
+			if elem.RepositoryName == nil || *elem.RepositoryName != *ko.Spec.RepositoryName {
+				continue
+			}
+		}
+		if ko.Spec.ImageScanningConfiguration != nil && ko.Spec.ImageScanningConfiguration.ScanOnPush != nil {
+			if elem.ImageScanningConfiguration == nil || elem.ImageScanningConfiguration.ScanOnPush == nil || *elem.ImageScanningConfiguration.ScanOnPush != *ko.Spec.ImageScanningConfiguration.ScanOnPush {
+				continue
+			}
+		}
+		if elem.ImageTagMutability == nil || *elem.ImageTagMutability != "MUTABLE" {
+			continue
+		}
+		if elem.CreatedAt != nil {
+			ko.Status.CreatedAt = &metav1.Time{*elem.CreatedAt}
+		} else {
+			ko.Status.CreatedAt = nil
+		}
+		if elem.ImageScanningConfiguration != nil {
+			f1 := &svcapitypes.ImageScanningConfiguration{}
+			if elem.ImageScanningConfiguration.ScanOnPush != nil {
+				f1.ScanOnPush = elem.ImageScanningConfiguration.ScanOnPush
+			}
+			ko.Spec.ImageScanningConfiguration = f1
+		} else {
+			ko.Spec.ImageScanningConfiguration = nil
+		}
+		if elem.ImageTagMutability != nil {
+			ko.Spec.ImageTagMutability = elem.ImageTagMutability
+		} else {
+			ko.Spec.ImageTagMutability = nil
+		}
+		if elem.RegistryId != nil {
+			ko.Status.RegistryID = elem.RegistryId
+		} else {
+			ko.Status.RegistryID = nil
+		}
+		if elem.RepositoryArn != nil {
+			if ko.Status.ACKResourceMetadata == nil {
+				ko.Status.ACKResourceMetadata = &ackv1alpha1.ResourceMetadata{}
+			}
+			tmpARN := ackv1alpha1.AWSResourceName(*elem.RepositoryArn)
+			ko.Status.ACKResourceMetadata.ARN = &tmpARN
+		}
+		if elem.RepositoryName != nil {
+			ko.Spec.RepositoryName = elem.RepositoryName
+		} else {
+			ko.Spec.RepositoryName = nil
+		}
+		if elem.RepositoryUri != nil {
+			ko.Status.RepositoryURI = elem.RepositoryUri
+		} else {
+			ko.Status.RepositoryURI = nil
+		}
+		found = true
+		break
+	}
+	if !found {
+		return nil, ackerr.NotFound
+	}
+`
+	assert.Equal(
+		expected,
+		code.SetResource(crd.Config(), crd, model.OpTypeList, "resp", "ko", 1),
+	)
+}
+
 func TestSetResource_Elasticache_ReplicationGroup_Create(t *testing.T) {
 	assert := assert.New(t)
 	require := require.New(t)
@@ -2780,6 +3054,33 @@ func TestSetResource_SQS_Queue_GetAttributes(t *testing.T) {
 	)
 }
 
+func TestSetResource_SQS_Queue_GetAttributes_AttributeKeys(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	g := testutil.NewModelForServiceWithOptions(t, "sqs", &testutil.TestingModelOptions{
+		GeneratorConfigFile: "generator-with-attribute-keys.yaml",
+	})
+
+	crd := testutil.GetCRDByName(t, g, "Queue")
+	require.NotNil(crd)
+
+	// Status fields are read from the Attributes map under their configured
+	// attribute keys
+	expected := `
+	ko.Status.CreatedAt = resp.Attributes["CreatedTimestamp"]
+	if ko.Status.ACKResourceMetadata == nil {
+		ko.Status.ACKResourceMetadata = &ackv1alpha1.ResourceMetadata{}
+	}
+	tmpARN := ackv1alpha1.AWSResourceName(*resp.Attributes["QueueArn"])
+	ko.Status.ACKResourceMetadata.ARN = &tmpARN
+`
+	assert.Equal(
+		expected,
+		code.SetResourceGetAttributes(crd.Config(), crd, "resp", "ko", 1),
+	)
+}
+
 func TestSetResource_RDS_DBSubnetGroup_ReadMany(t *testing.T) {
 	assert := assert.New(t)
 	require := require.New(t)
@@ -2996,9 +3297,39 @@ func TestSetResource_APIGWV2_ApiMapping_SetResourceIdentifiers(t *testing.T) {
 	r.ko.Status.APIMappingID = &identifier.NameOrID
 
 	f1, f1ok := identifier.AdditionalKeys["domainName"]
-	if f1ok {
-		r.ko.Spec.DomainName = &f1
+	if !f1ok {
+		return fmt.Errorf("missing required additional key: domainName")
+	}
+	r.ko.Spec.DomainName = &f1
+`
+	assert.Equal(
+		expected,
+		code.SetResourceIdentifiers(crd.Config(), crd, "identifier", "r.ko", 1),
+	)
+}
+
+func TestSetResource_EKS_FargateProfile_SetResourceIdentifiers(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	g := testutil.NewModelForService(t, "eks")
+
+	crd := testutil.GetCRDByName(t, g, "FargateProfile")
+	require.NotNil(crd)
+
+	// A FargateProfile is identified by its name and the name of its cluster,
+	// so the cluster name must be supplied along with the name
+	expected := `
+	if identifier.NameOrID == "" {
+		return ackerrors.MissingNameIdentifier
+	}
+	r.ko.Spec.Name = &identifier.NameOrID
+
+	f0, f0ok := identifier.AdditionalKeys["clusterName"]
+	if !f0ok {
+		return fmt.Errorf("missing required additional key: clusterName")
 	}
+	r.ko.Spec.ClusterName = &f0
 `
 	assert.Equal(
 		expected,
@@ -3027,6 +3358,30 @@ func TestSetResource_SageMaker_ModelPackage_SetResourceIdentifiers(t *testing.T)
 	)
 }
 
+func TestSetResource_SNS_PlatformEndpoint_SetResourceIdentifiers(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	g := testutil.NewModelForServiceWithOptions(t, "sns", &testutil.TestingModelOptions{
+		GeneratorConfigFile: "generator-with-arn-identified-resources.yaml",
+	})
+
+	crd := testutil.GetCRDByName(t, g, "PlatformEndpoint")
+	require.NotNil(crd)
+
+	// The ARN is the only required member of the GetAttributes Input shape
+	expected := `
+	if r.ko.Status.ACKResourceMetadata == nil {
+		r.ko.Status.ACKResourceMetadata = &ackv1alpha1.ResourceMetadata{}
+	}
+	r.ko.Status.ACKResourceMetadata.ARN = identifier.ARN
+`
+	assert.Equal(
+		expected,
+		code.SetResourceIdentifiers(crd.Config(), crd, "identifier", "r.ko", 1),
+	)
+}
+
 func TestSetResource_EC2_VPC_SetResourceIdentifiers(t *testing.T) {
 	assert := assert.New(t)
 	require := require.New(t)
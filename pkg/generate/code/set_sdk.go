@@ -15,7 +15,9 @@ package code
 
 import (
 	"fmt"
+	"math"
 	"sort"
+	"strconv"
 	"strings"
 
 	awssdkmodel "github.com/aws/aws-sdk-go/private/model/api"
@@ -23,6 +25,7 @@ import (
 	ackgenconfig "github.com/aws-controllers-k8s/code-generator/pkg/generate/config"
 	"github.com/aws-controllers-k8s/code-generator/pkg/model"
 	"github.com/aws-controllers-k8s/code-generator/pkg/names"
+	"github.com/aws-controllers-k8s/code-generator/pkg/util"
 )
 
 // SetSDK returns the Go code that sets an SDK input shape's member fields from
@@ -107,6 +110,48 @@ func SetSDK(
 	default:
 		return ""
 	}
+	return setSDKForOperation(
+		cfg, r, opType, op, sourceVarName, targetVarName, indentLevel,
+	)
+}
+
+// SetSDKForOperation returns the Go code that sets the Input shape of the
+// supplied operation from the resource's fields, like SetSDK does for the
+// resource's CRUD operations. It is used for the resource's secondary
+// operations, which are sent all the fields of their Input shape, including
+// the create-only and update-only fields.
+func SetSDKForOperation(
+	cfg *ackgenconfig.Config,
+	r *model.CRD,
+	op *awssdkmodel.Operation,
+	// String representing the name of the variable that we will grab the Input
+	// shape from, e.g. "r.ko"
+	sourceVarName string,
+	// String representing the name of the variable that we will be **setting**
+	// with values from the resource, e.g. "res"
+	targetVarName string,
+	// Number of levels of indentation to use
+	indentLevel int,
+) string {
+	return setSDKForOperation(
+		cfg, r, model.OpTypeUnknown, op, sourceVarName, targetVarName, indentLevel,
+	)
+}
+
+// setSDKForOperation returns the Go code that sets the Input shape of the
+// supplied operation from the resource's fields. The create-only fields are
+// left out of the Input shape of the Update operation, and the update-only
+// fields out of that of the Create operation.
+func setSDKForOperation(
+	cfg *ackgenconfig.Config,
+	r *model.CRD,
+	// The type of the operation, OpTypeUnknown for secondary operations
+	opType model.OpType,
+	op *awssdkmodel.Operation,
+	sourceVarName string,
+	targetVarName string,
+	indentLevel int,
+) string {
 	if op == nil {
 		return ""
 	}
@@ -120,55 +165,35 @@ func SetSDK(
 
 	// Some input shapes for APIs that use GetAttributes API calls don't have
 	// an Attributes member (example: all the Delete shapes...)
-	_, foundAttrs := inputShape.MemberRefs["Attributes"]
+	attrsMemberName := r.AttributesMapMemberName()
+	_, foundAttrs := inputShape.MemberRefs[attrsMemberName]
 	if r.UnpacksAttributesMap() && foundAttrs {
 		// For APIs that use a pattern of a parameter called "Attributes" that
 		// is of type `map[string]*string` to represent real, schema'd fields,
 		// we need to set the input shape's "Attributes" member field to the
 		// re-constructed, packed set of fields.
-		//
-		// Therefore, we output here something like this (example from SNS
-		// Topic's Attributes map):
-		//
-		// attrMap := map[string]*string{}
-		// attrMap["DeliveryPolicy"] = r.ko.Spec.DeliveryPolicy
-		// attrMap["DisplayName"} = r.ko.Spec.DisplayName
-		// attrMap["KmsMasterKeyId"] = r.ko.Spec.KMSMasterKeyID
-		// attrMap["Policy"] = r.ko.Spec.Policy
-		// res.SetAttributes(attrMap)
-		fieldConfigs := cfg.ResourceFields(r.Names.Original)
-		out += fmt.Sprintf("%sattrMap := map[string]*string{}\n", indent)
-		sortedAttrFieldNames := []string{}
-		for fName, fConfig := range fieldConfigs {
-			if fConfig.IsAttribute {
-				sortedAttrFieldNames = append(sortedAttrFieldNames, fName)
-			}
-		}
-		sort.Strings(sortedAttrFieldNames)
-		for _, fieldName := range sortedAttrFieldNames {
-			fieldConfig := fieldConfigs[fieldName]
-			fieldNames := names.New(fieldName)
-			if !fieldConfig.IsReadOnly {
-				sourceAdaptedVarName := sourceVarName + cfg.PrefixConfig.SpecField + "." + fieldNames.Camel
-				out += fmt.Sprintf(
-					"%sif %s != nil {\n",
-					indent, sourceAdaptedVarName,
-				)
-				out += fmt.Sprintf(
-					"%s\tattrMap[\"%s\"] = %s\n",
-					indent, fieldName, sourceAdaptedVarName,
-				)
-				out += fmt.Sprintf(
-					"%s}\n", indent,
-				)
-			}
-		}
-		out += fmt.Sprintf("%s%s.SetAttributes(attrMap)\n", indent, targetVarName)
+		out += setSDKAttributesMap(
+			cfg, r, sourceVarName, targetVarName, indentLevel,
+		)
 	}
 
 	opConfig, override := cfg.OverrideValues(op.Name)
+	batchOp := r.BatchOperation(op)
 	for memberIndex, memberName := range inputShape.MemberNames() {
-		if r.UnpacksAttributesMap() && memberName == "Attributes" {
+		if r.UnpacksAttributesMap() && memberName == attrsMemberName {
+			continue
+		}
+
+		if batchOp != nil && memberName == batchOp.InputMember {
+			out += setSDKForBatchInput(
+				cfg, r, op,
+				memberIndex,
+				memberName,
+				inputShape.MemberRefs[memberName],
+				sourceVarName,
+				targetVarName,
+				indentLevel,
+			)
 			continue
 		}
 
@@ -191,6 +216,13 @@ func SetSDK(
 			}
 		}
 
+		if inputShape.MemberRefs[memberName].IdempotencyToken && r.AutoIdempotencyToken() {
+			out += setSDKIdempotencyToken(
+				cfg, r, op, memberName, sourceVarName, targetVarName, indentLevel,
+			)
+			continue
+		}
+
 		if r.IsPrimaryARNField(memberName) {
 			// if ko.Status.ACKResourceMetadata != nil && ko.Status.ACKResourceMetadata.ARN != nil {
 			//     res.SetTopicArn(string(*ko.Status.ACKResourceMetadata.ARN))
@@ -231,6 +263,20 @@ func SetSDK(
 			// TODO(jaypipes): check generator config for exceptions?
 			continue
 		}
+		if f.IsCustomField() {
+			// Custom fields are set by hook code
+			continue
+		}
+		if f.FieldConfig != nil {
+			if opType == model.OpTypeUpdate && f.FieldConfig.IsCreateOnly {
+				// Only the Create operation accepts the field
+				continue
+			}
+			if opType == model.OpTypeCreate && f.FieldConfig.IsUpdateOnly {
+				// Only the Update operation accepts the field
+				continue
+			}
+		}
 
 		sourceAdaptedVarName += "." + f.Names.Camel
 		sourceFieldPath := f.Names.Camel
@@ -348,6 +394,17 @@ func SetSDK(
 				)
 			}
 		}
+		// Fields that are optional in the CRD but required by the operation
+		// are set to their default value when they aren't set in the CR.
+		//
+		// } else {
+		//     res.SetImageTagMutability("MUTABLE")
+		if defaultVal, ok := sdkDefaultValue(f, memberName, inputShape); ok {
+			out += fmt.Sprintf("%s} else {\n", indent)
+			out += fmt.Sprintf(
+				"%s\t%s.Set%s(%s)\n", indent, targetVarName, memberName, defaultVal,
+			)
+		}
 		out += fmt.Sprintf(
 			"%s}\n", indent,
 		)
@@ -355,6 +412,45 @@ func SetSDK(
 	return out
 }
 
+// sdkDefaultValue returns the Go literal of the default value of a field that
+// is optional in the CRD but is a required member of the supplied Input
+// shape. The second return value is false if the member isn't required, the
+// field is required in the CRD, or the field has no scalar default value.
+func sdkDefaultValue(
+	f *model.Field,
+	memberName string,
+	inputShape *awssdkmodel.Shape,
+) (string, bool) {
+	if f.IsRequired() || !util.InStrings(memberName, inputShape.Required) {
+		return "", false
+	}
+	value := f.GetDefaultValue()
+	if value == nil {
+		return "", false
+	}
+	memberShape := inputShape.MemberRefs[memberName].Shape
+	switch v := value.(type) {
+	case string:
+		if memberShape.Type == "string" {
+			return strconv.Quote(v), true
+		}
+	case bool:
+		if memberShape.Type == "boolean" {
+			return strconv.FormatBool(v), true
+		}
+	case float64:
+		switch memberShape.Type {
+		case "integer", "long":
+			if v == math.Trunc(v) {
+				return strconv.FormatInt(int64(v), 10), true
+			}
+		case "float", "double":
+			return strconv.FormatFloat(v, 'f', -1, 64), true
+		}
+	}
+	return "", false
+}
+
 // SetSDKGetAttributes returns the Go code that sets the Input shape for a
 // resource's GetAttributes operation.
 //
@@ -429,24 +525,8 @@ func SetSDKGetAttributes(
 			// } else {
 			//     res.SetTopicArn(rm.ARNFromName(*ko.Spec.Name))
 			// }
-			out += fmt.Sprintf(
-				"%sif %s.Status.ACKResourceMetadata != nil && %s.Status.ACKResourceMetadata.ARN != nil {\n",
-				indent, sourceVarName, sourceVarName,
-			)
-			out += fmt.Sprintf(
-				"%s\t%s.Set%s(string(*%s.Status.ACKResourceMetadata.ARN))\n",
-				indent, targetVarName, memberName, sourceVarName,
-			)
-			out += fmt.Sprintf(
-				"%s} else {\n", indent,
-			)
-			nameField := *r.SpecIdentifierField()
-			out += fmt.Sprintf(
-				"%s\t%s.Set%s(rm.ARNFromName(*%s.Spec.%s))\n",
-				indent, targetVarName, memberName, sourceVarName, nameField,
-			)
-			out += fmt.Sprintf(
-				"%s}\n", indent,
+			out += setSDKPrimaryARN(
+				r, memberName, sourceVarName, targetVarName, indentLevel,
 			)
 			continue
 		}
@@ -603,78 +683,19 @@ func SetSDKSetAttributes(
 			// } else {
 			//     res.SetTopicArn(rm.ARNFromName(*ko.Spec.Name))
 			// }
-			out += fmt.Sprintf(
-				"%sif %s.Status.ACKResourceMetadata != nil && %s.Status.ACKResourceMetadata.ARN != nil {\n",
-				indent, sourceVarName, sourceVarName,
-			)
-			out += fmt.Sprintf(
-				"%s\t%s.Set%s(string(*%s.Status.ACKResourceMetadata.ARN))\n",
-				indent, targetVarName, memberName, sourceVarName,
-			)
-			out += fmt.Sprintf(
-				"%s} else {\n", indent,
-			)
-			nameField := *r.SpecIdentifierField()
-			out += fmt.Sprintf(
-				"%s\t%s.Set%s(rm.ARNFromName(*%s.Spec.%s))\n",
-				indent, targetVarName, memberName, sourceVarName, nameField,
-			)
-			out += fmt.Sprintf(
-				"%s}\n", indent,
+			out += setSDKPrimaryARN(
+				r, memberName, sourceVarName, targetVarName, indentLevel,
 			)
 			continue
 		}
-		if memberName == "Attributes" {
+		if memberName == r.AttributesMapMemberName() {
 			// For APIs that use a pattern of a parameter called "Attributes" that
 			// is of type `map[string]*string` to represent real, schema'd fields,
 			// we need to set the input shape's "Attributes" member field to the
 			// re-constructed, packed set of fields.
-			//
-			// Therefore, we output here something like this (example from SNS
-			// Topic's Attributes map):
-			//
-			// attrMap := map[string]*string{}
-			// if r.ko.Spec.DeliveryPolicy != nil {
-			//     attrMap["DeliveryPolicy"] = r.ko.Spec.DeliveryPolicy
-			// }
-			// if r.ko.Spec.DisplayName != nil {
-			//     attrMap["DisplayName"} = r.ko.Spec.DisplayName
-			// }
-			// if r.ko.Spec.KMSMasterKeyID != nil {
-			//     attrMap["KmsMasterKeyId"] = r.ko.Spec.KMSMasterKeyID
-			// }
-			// if r.ko.Spec.Policy != nil {
-			//     attrMap["Policy"] = r.ko.Spec.Policy
-			// }
-			// res.SetAttributes(attrMap)
-			fieldConfigs := cfg.ResourceFields(r.Names.Original)
-			out += fmt.Sprintf("%sattrMap := map[string]*string{}\n", indent)
-			sortedAttrFieldNames := []string{}
-			for fName, fConfig := range fieldConfigs {
-				if fConfig.IsAttribute {
-					sortedAttrFieldNames = append(sortedAttrFieldNames, fName)
-				}
-			}
-			sort.Strings(sortedAttrFieldNames)
-			for _, fieldName := range sortedAttrFieldNames {
-				fieldConfig := fieldConfigs[fieldName]
-				fieldNames := names.New(fieldName)
-				if !fieldConfig.IsReadOnly {
-					sourceAdaptedVarName := sourceVarName + cfg.PrefixConfig.SpecField + "." + fieldNames.Camel
-					out += fmt.Sprintf(
-						"%sif %s != nil {\n",
-						indent, sourceAdaptedVarName,
-					)
-					out += fmt.Sprintf(
-						"%s\tattrMap[\"%s\"] = %s\n",
-						indent, fieldName, sourceAdaptedVarName,
-					)
-					out += fmt.Sprintf(
-						"%s}\n", indent,
-					)
-				}
-			}
-			out += fmt.Sprintf("%s%s.SetAttributes(attrMap)\n", indent, targetVarName)
+			out += setSDKAttributesMap(
+				cfg, r, sourceVarName, targetVarName, indentLevel,
+			)
 			continue
 		}
 
@@ -717,6 +738,166 @@ func SetSDKSetAttributes(
 	return out
 }
 
+// setSDKIdempotencyToken returns the Go code that sets the supplied
+// idempotency token member of the Input shape from the resource's field, when
+// set, or else from a token derived from the CR's UID and generation. The
+// token is the same when a call is retried and changes with the CR's Spec,
+// so a changed request isn't rejected as a mismatched retry.
+//
+//	if r.ko.Spec.CreatorRequestID != nil {
+//		res.SetCreatorRequestId(*r.ko.Spec.CreatorRequestID)
+//	} else {
+//		res.SetCreatorRequestId(fmt.Sprintf("%s-%d", r.ko.UID, r.ko.Generation))
+//	}
+func setSDKIdempotencyToken(
+	cfg *ackgenconfig.Config,
+	r *model.CRD,
+	op *awssdkmodel.Operation,
+	// The name of the idempotency token member of the Input shape
+	memberName string,
+	// The variable name holding the CR, e.g. "r.ko"
+	sourceVarName string,
+	// The variable name holding the Input shape, e.g. "res"
+	targetVarName string,
+	// Number of levels of indentation to use
+	indentLevel int,
+) string {
+	out := ""
+	indent := strings.Repeat("\t", indentLevel)
+	setToken := fmt.Sprintf(
+		"%s.Set%s(fmt.Sprintf(\"%%s-%%d\", %s.UID, %s.Generation))\n",
+		targetVarName, memberName, sourceVarName, sourceVarName,
+	)
+
+	fieldName, _ := cfg.ResourceFieldRename(
+		r.Names.Original, op.Name, memberName,
+	)
+	var f *model.Field
+	sourceAdaptedVarName := sourceVarName
+	inSpec, inStatus := r.HasMember(fieldName, op.Name)
+	if inSpec {
+		sourceAdaptedVarName += cfg.PrefixConfig.SpecField
+		f = r.SpecFields[fieldName]
+	} else if inStatus {
+		sourceAdaptedVarName += cfg.PrefixConfig.StatusField
+		f = r.StatusFields[fieldName]
+	}
+	if f == nil || f.IsCustomField() {
+		return indent + setToken
+	}
+	sourceAdaptedVarName += "." + f.Names.Camel
+	out += fmt.Sprintf("%sif %s != nil {\n", indent, sourceAdaptedVarName)
+	out += fmt.Sprintf(
+		"%s\t%s.Set%s(*%s)\n",
+		indent, targetVarName, memberName, sourceAdaptedVarName,
+	)
+	out += fmt.Sprintf("%s} else {\n", indent)
+	out += fmt.Sprintf("%s\t%s", indent, setToken)
+	out += fmt.Sprintf("%s}\n", indent)
+	return out
+}
+
+// setSDKPrimaryARN returns the Go code that sets the supplied Input shape
+// member to the resource's ARN, constructed from the resource's name if the
+// ARN isn't known yet. The else branch is omitted for resources without a
+// name, which are only identified by their ARN. For SNS Topic:
+//
+//	if r.ko.Status.ACKResourceMetadata != nil && r.ko.Status.ACKResourceMetadata.ARN != nil {
+//		res.SetTopicArn(string(*r.ko.Status.ACKResourceMetadata.ARN))
+//	} else {
+//		res.SetTopicArn(rm.ARNFromName(*r.ko.Spec.Name))
+//	}
+func setSDKPrimaryARN(
+	r *model.CRD,
+	memberName string,
+	sourceVarName string,
+	targetVarName string,
+	indentLevel int,
+) string {
+	out := ""
+	indent := strings.Repeat("\t", indentLevel)
+	out += fmt.Sprintf(
+		"%sif %s.Status.ACKResourceMetadata != nil && %s.Status.ACKResourceMetadata.ARN != nil {\n",
+		indent, sourceVarName, sourceVarName,
+	)
+	out += fmt.Sprintf(
+		"%s\t%s.Set%s(string(*%s.Status.ACKResourceMetadata.ARN))\n",
+		indent, targetVarName, memberName, sourceVarName,
+	)
+	nameField := r.SpecIdentifierField()
+	if nameField != nil {
+		out += fmt.Sprintf(
+			"%s} else {\n", indent,
+		)
+		out += fmt.Sprintf(
+			"%s\t%s.Set%s(rm.ARNFromName(*%s.Spec.%s))\n",
+			indent, targetVarName, memberName, sourceVarName, *nameField,
+		)
+	}
+	out += fmt.Sprintf(
+		"%s}\n", indent,
+	)
+	return out
+}
+
+// setSDKAttributesMap returns the Go code that sets the attributes map member
+// of an Input shape from the resource's attribute Spec fields, keyed by their
+// attribute keys.
+//
+// The returned code looks something like this (example from SNS Topic's
+// Attributes map):
+//
+//	attrMap := map[string]*string{}
+//	if r.ko.Spec.DeliveryPolicy != nil {
+//		attrMap["DeliveryPolicy"] = r.ko.Spec.DeliveryPolicy
+//	}
+//	if r.ko.Spec.KMSMasterKeyID != nil {
+//		attrMap["KmsMasterKeyId"] = r.ko.Spec.KMSMasterKeyID
+//	}
+//	res.SetAttributes(attrMap)
+func setSDKAttributesMap(
+	cfg *ackgenconfig.Config,
+	r *model.CRD,
+	sourceVarName string,
+	targetVarName string,
+	indentLevel int,
+) string {
+	out := ""
+	indent := strings.Repeat("\t", indentLevel)
+	fieldConfigs := cfg.ResourceFields(r.Names.Original)
+	out += fmt.Sprintf("%sattrMap := map[string]*string{}\n", indent)
+	sortedAttrFieldNames := []string{}
+	for fName, fConfig := range fieldConfigs {
+		if fConfig.IsAttribute {
+			sortedAttrFieldNames = append(sortedAttrFieldNames, fName)
+		}
+	}
+	sort.Strings(sortedAttrFieldNames)
+	for _, fieldName := range sortedAttrFieldNames {
+		fieldConfig := fieldConfigs[fieldName]
+		fieldNames := names.New(fieldName)
+		if !fieldConfig.IsReadOnly {
+			sourceAdaptedVarName := sourceVarName + cfg.PrefixConfig.SpecField + "." + fieldNames.Camel
+			out += fmt.Sprintf(
+				"%sif %s != nil {\n",
+				indent, sourceAdaptedVarName,
+			)
+			out += fmt.Sprintf(
+				"%s\tattrMap[\"%s\"] = %s\n",
+				indent, r.AttributeKey(fieldName), sourceAdaptedVarName,
+			)
+			out += fmt.Sprintf(
+				"%s}\n", indent,
+			)
+		}
+	}
+	out += fmt.Sprintf(
+		"%s%s.Set%s(attrMap)\n",
+		indent, targetVarName, r.AttributesMapMemberName(),
+	)
+	return out
+}
+
 // setSDKReadMany is a special-case handling of those APIs where there is no
 // ReadOne operation and instead the only way to grab information for a single
 // object is to call the ReadMany/List operation with one of more filtering
@@ -876,6 +1057,23 @@ func setSDKForContainer(
 	targetShapeRef *awssdkmodel.ShapeRef,
 	indentLevel int,
 ) string {
+	if r.IsDocument(targetShapeRef.Shape) {
+		return setSDKForDocument(
+			targetVarName,
+			sourceVarName,
+			indentLevel,
+		)
+	}
+	if f, found := r.Fields[sourceFieldPath]; found && f.TagFormat() != "" {
+		return setSDKForTags(
+			cfg, r,
+			f.TagFormat(),
+			targetVarName,
+			targetShapeRef,
+			sourceVarName,
+			indentLevel,
+		)
+	}
 	switch targetShapeRef.Shape.Type {
 	case "structure":
 		return SetSDKForStruct(
@@ -908,7 +1106,8 @@ func setSDKForContainer(
 			indentLevel,
 		)
 	default:
-		if r.IsSecretField(sourceFieldPath) {
+		// Map values are found at the map's field path followed by a dot
+		if r.IsSecretField(strings.TrimSuffix(sourceFieldPath, ".")) {
 			indent := strings.Repeat("\t", indentLevel)
 			// if ko.Spec.MasterUserPassword != nil {
 			out := fmt.Sprintf(
@@ -1013,6 +1212,111 @@ func setSDKForSecret(
 	return out
 }
 
+// setSDKForDocument returns a string of Go code that sets a target variable to
+// the JSON document held by a source variable of type runtime.RawExtension.
+//
+// The Go code output from this function looks like this:
+//
+//	if err := json.Unmarshal(ko.Spec.Definition.Raw, f0); err != nil {
+//	    return nil, err
+//	}
+func setSDKForDocument(
+	// The variable name that we want to set a value on
+	targetVarName string,
+	// The CR field that we access our source value from
+	sourceVarName string,
+	indentLevel int,
+) string {
+	out := ""
+	indent := strings.Repeat("\t", indentLevel)
+	out += fmt.Sprintf(
+		"%sif err := json.Unmarshal(%s.Raw, %s); err != nil {\n",
+		indent, sourceVarName, targetVarName,
+	)
+	out += fmt.Sprintf("%s\treturn nil, err\n", indent)
+	out += fmt.Sprintf("%s}\n", indent)
+	return out
+}
+
+// setSDKForTags returns a string of Go code that sets a target variable to
+// the tags held by a source variable whose tags are represented in the other
+// format.
+//
+// When the CR represents tags as a map, the Go code output from this function
+// looks like this:
+//
+//	for f3key, f3val := range r.ko.Spec.Tags {
+//	    f3elem := &svcsdk.Tag{}
+//	    f3elem.SetKey(f3key)
+//	    if f3val != nil {
+//	        f3elem.SetValue(*f3val)
+//	    }
+//	    f3 = append(f3, f3elem)
+//	}
+//
+// When the CR represents tags as a list, it looks like this:
+//
+//	for _, f3iter := range r.ko.Spec.Tags {
+//	    if f3iter.Key != nil {
+//	        f3[*f3iter.Key] = f3iter.Value
+//	    }
+//	}
+func setSDKForTags(
+	cfg *ackgenconfig.Config,
+	r *model.CRD,
+	// The format of the tags in the CR
+	tagFormat string,
+	// The variable name that we want to set a value to
+	targetVarName string,
+	// ShapeRef of the target tags field
+	targetShapeRef *awssdkmodel.ShapeRef,
+	// The CR field that we access our source value from
+	sourceVarName string,
+	indentLevel int,
+) string {
+	out := ""
+	indent := strings.Repeat("\t", indentLevel)
+	if tagFormat == ackgenconfig.TagFormatList {
+		iterVarName := fmt.Sprintf("%siter", targetVarName)
+		out += fmt.Sprintf(
+			"%sfor _, %s := range %s {\n", indent, iterVarName, sourceVarName,
+		)
+		out += fmt.Sprintf("%s\tif %s.Key != nil {\n", indent, iterVarName)
+		out += fmt.Sprintf(
+			"%s\t\t%s[*%s.Key] = %s.Value\n",
+			indent, targetVarName, iterVarName, iterVarName,
+		)
+		out += fmt.Sprintf("%s\t}\n", indent)
+		out += fmt.Sprintf("%s}\n", indent)
+		return out
+	}
+	keyVarName := fmt.Sprintf("%skey", targetVarName)
+	valVarName := fmt.Sprintf("%sval", targetVarName)
+	elemVarName := fmt.Sprintf("%selem", targetVarName)
+	out += fmt.Sprintf(
+		"%sfor %s, %s := range %s {\n",
+		indent, keyVarName, valVarName, sourceVarName,
+	)
+	out += varEmptyConstructorSDKType(
+		cfg, r,
+		elemVarName,
+		targetShapeRef.Shape.MemberRef.Shape,
+		indentLevel+1,
+	)
+	out += fmt.Sprintf("%s\t%s.SetKey(%s)\n", indent, elemVarName, keyVarName)
+	out += fmt.Sprintf("%s\tif %s != nil {\n", indent, valVarName)
+	out += fmt.Sprintf(
+		"%s\t\t%s.SetValue(*%s)\n", indent, elemVarName, valVarName,
+	)
+	out += fmt.Sprintf("%s\t}\n", indent)
+	out += fmt.Sprintf(
+		"%s\t%s = append(%s, %s)\n",
+		indent, targetVarName, targetVarName, elemVarName,
+	)
+	out += fmt.Sprintf("%s}\n", indent)
+	return out
+}
+
 // SetSDKForStruct returns a string of Go code that sets a target variable
 // value to a source variable when the type of the source variable is a struct.
 func SetSDKForStruct(
@@ -1058,15 +1362,24 @@ func SetSDKForStruct(
 					memberShape,
 					indentLevel+1,
 				)
-				out += setSDKForContainer(
-					cfg, r,
-					memberName,
-					memberVarName,
-					memberFieldPath,
-					sourceAdaptedVarName,
-					memberShapeRef,
-					indentLevel+1,
-				)
+				if r.IsRecursiveMember(targetShape, memberName) {
+					out += copyViaJSON(
+						memberVarName,
+						sourceAdaptedVarName,
+						"nil, err",
+						indentLevel+1,
+					)
+				} else {
+					out += setSDKForContainer(
+						cfg, r,
+						memberName,
+						memberVarName,
+						memberFieldPath,
+						sourceAdaptedVarName,
+						memberShapeRef,
+						indentLevel+1,
+					)
+				}
 				out += setSDKForScalar(
 					cfg, r,
 					memberName,
@@ -1264,6 +1577,59 @@ func varEmptyConstructorSDKType(
 	return out
 }
 
+// isTypedEnumSource returns true if a string value of the supplied SDK shape
+// is read from a field whose Go type is a generated enum type. The SDK member
+// shape usually is the enum shape of the field, but it can also be a plain
+// string shape in some of a resource's operations.
+func isTypedEnumSource(
+	r *model.CRD,
+	shape *awssdkmodel.Shape,
+	// The path to the field that we access our source value from
+	sourceFieldPath string,
+) bool {
+	if shape.Type != "string" {
+		return false
+	}
+	if r.EnumTypeName(shape) != "" {
+		return true
+	}
+	f, found := r.Fields[sourceFieldPath]
+	return found && f.ShapeRef != nil && r.EnumTypeName(f.ShapeRef.Shape) != ""
+}
+
+// sdkTimestampFormat returns the format of the timestamp value found at the
+// supplied source field path
+func sdkTimestampFormat(
+	cfg *ackgenconfig.Config,
+	r *model.CRD,
+	// The path to the field that we access our source value from
+	sourceFieldPath string,
+) string {
+	if f, found := r.Fields[sourceFieldPath]; found {
+		return f.TimestampFormat()
+	}
+	return cfg.GetTimestampFormat()
+}
+
+// sdkNumberFormat returns the number format of the top-level field found at
+// the supplied source field path, if any
+func sdkNumberFormat(
+	r *model.CRD,
+	// The path to the field that we access our source value from
+	sourceFieldPath string,
+) string {
+	if f, found := r.Fields[sourceFieldPath]; found {
+		return f.NumberFormat()
+	}
+	return ""
+}
+
+// scalarTmpVarName returns the name of the variable holding the converted
+// value of a scalar before it is set to the supplied target
+func scalarTmpVarName(targetVarName string, targetFieldName string) string {
+	return "tmp" + strings.Replace(targetVarName, ".", "", -1) + targetFieldName
+}
+
 func varEmptyConstructorK8sType(
 	cfg *ackgenconfig.Config,
 	r *model.CRD,
@@ -1299,6 +1665,12 @@ func varEmptyConstructorK8sType(
 	if hadPkg {
 		goType = goPkg + "." + goType
 	}
+	if enumGoType := k8sEnumGoType(r, shape); enumGoType != "" {
+		goType = enumGoType
+	}
+	if documentGoType := k8sDocumentGoType(r, shape); documentGoType != "" {
+		goType = documentGoType
+	}
 
 	switch shape.Type {
 	case "structure":
@@ -1314,6 +1686,60 @@ func varEmptyConstructorK8sType(
 	return out
 }
 
+// k8sEnumGoType returns the Go type of a variable of the supplied shape when
+// the shape, or the element shape of a list or map shape, uses a generated
+// enum type. It returns the empty string for any other shape.
+func k8sEnumGoType(
+	r *model.CRD,
+	shape *awssdkmodel.Shape,
+) string {
+	switch shape.Type {
+	case "list":
+		// f0 := []*svcapitypes.ScanType{}
+		if enumType := r.EnumTypeName(shape.MemberRef.Shape); enumType != "" {
+			return "[]*svcapitypes." + enumType
+		}
+	case "map":
+		// f0 := map[string]*svcapitypes.ScanType{}
+		if enumType := r.EnumTypeName(shape.ValueRef.Shape); enumType != "" {
+			return "map[string]*svcapitypes." + enumType
+		}
+	default:
+		// var f0 svcapitypes.ScanType
+		if enumType := r.EnumTypeName(shape); enumType != "" {
+			return "svcapitypes." + enumType
+		}
+	}
+	return ""
+}
+
+// k8sDocumentGoType returns the Go type of a variable of the supplied shape
+// when the shape, or the element shape of a list or map shape, is a document
+// shape. It returns the empty string for any other shape.
+func k8sDocumentGoType(
+	r *model.CRD,
+	shape *awssdkmodel.Shape,
+) string {
+	switch shape.Type {
+	case "list":
+		// f0 := []*runtime.RawExtension{}
+		if r.IsDocument(shape.MemberRef.Shape) {
+			return "[]" + model.RawExtensionGoType
+		}
+	case "map":
+		// f0 := map[string]*runtime.RawExtension{}
+		if r.IsDocument(shape.ValueRef.Shape) {
+			return "map[string]" + model.RawExtensionGoType
+		}
+	case "structure":
+		// f0 := &runtime.RawExtension{}
+		if r.IsDocument(shape) {
+			return strings.TrimPrefix(model.RawExtensionGoType, "*")
+		}
+	}
+	return ""
+}
+
 // setSDKForScalar returns the Go code that sets the value of a target variable
 // or field to a scalar value. For target variables that are structs, we output
 // the aws-sdk-go's common SetXXX() method. For everything else, we output
@@ -1339,10 +1765,57 @@ func setSDKForScalar(
 	setTo := sourceVarName
 	shape := shapeRef.Shape
 	if shape.Type == "timestamp" {
-		setTo += ".Time"
+		switch sdkTimestampFormat(cfg, r, sourceFieldPath) {
+		case ackgenconfig.TimestampFormatRFC3339:
+			// tmpTime, err := time.Parse(time.RFC3339, *ko.Status.CreatedAt)
+			// if err != nil {
+			//     return nil, err
+			// }
+			timeVarName := scalarTmpVarName(targetVarName, targetFieldName)
+			out += fmt.Sprintf(
+				"%s%s, err := time.Parse(time.RFC3339, *%s)\n",
+				indent, timeVarName, sourceVarName,
+			)
+			out += fmt.Sprintf("%sif err != nil {\n", indent)
+			out += fmt.Sprintf("%s\treturn nil, err\n", indent)
+			out += fmt.Sprintf("%s}\n", indent)
+			setTo = timeVarName
+		case ackgenconfig.TimestampFormatEpoch:
+			setTo = "time.Unix(*" + sourceVarName + ", 0)"
+		default:
+			setTo += ".Time"
+		}
+	} else if nf := sdkNumberFormat(r, sourceFieldPath); nf != "" {
+		switch nf {
+		case ackgenconfig.NumberFormatInt32:
+			// res.SetMaxResults(int64(*ko.Spec.MaxResults))
+			setTo = "int64(*" + sourceVarName + ")"
+		default:
+			// tmpresWeight, err := strconv.ParseFloat(*ko.Spec.Weight, 64)
+			// if err != nil {
+			//     return nil, err
+			// }
+			floatVarName := scalarTmpVarName(targetVarName, targetFieldName)
+			floatString := "*" + sourceVarName
+			if nf == ackgenconfig.NumberFormatQuantity {
+				floatString = sourceVarName + ".AsDec().String()"
+			}
+			out += fmt.Sprintf(
+				"%s%s, err := strconv.ParseFloat(%s, 64)\n",
+				indent, floatVarName, floatString,
+			)
+			out += fmt.Sprintf("%sif err != nil {\n", indent)
+			out += fmt.Sprintf("%s\treturn nil, err\n", indent)
+			out += fmt.Sprintf("%s}\n", indent)
+			setTo = floatVarName
+		}
 	} else if shapeRef.UseIndirection() {
 		setTo = "*" + setTo
 	}
+	if isTypedEnumSource(r, shape, sourceFieldPath) {
+		// res.SetImageTagMutability(string(*ko.Spec.ImageTagMutability))
+		setTo = "string(" + setTo + ")"
+	}
 	if targetVarType == "structure" {
 		out += fmt.Sprintf("%s%s.Set%s(%s)\n", indent, targetVarName, targetFieldName, setTo)
 	} else {
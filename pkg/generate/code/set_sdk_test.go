@@ -16,6 +16,7 @@ package code_test
 import (
 	"testing"
 
+	awssdkmodel "github.com/aws/aws-sdk-go/private/model/api"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
@@ -739,6 +740,311 @@ func TestSetSDK_ECR_Repository_Create(t *testing.T) {
 	)
 }
 
+func TestSetSDK_ECR_Repository_Create_OptionalRequiredField(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	g := testutil.NewModelForServiceWithOptions(t, "ecr", &testutil.TestingModelOptions{
+		GeneratorConfigFile: "generator-with-optional-required-field.yaml",
+	})
+
+	crd := testutil.GetCRDByName(t, g, "Repository")
+	require.NotNil(crd)
+
+	// RepositoryName is required by CreateRepository but optional in the CRD,
+	// so its default value is used when the Spec field isn't set.
+	expected := `
+	if r.ko.Spec.ImageScanningConfiguration != nil {
+		f0 := &svcsdk.ImageScanningConfiguration{}
+		if r.ko.Spec.ImageScanningConfiguration.ScanOnPush != nil {
+			f0.SetScanOnPush(*r.ko.Spec.ImageScanningConfiguration.ScanOnPush)
+		}
+		res.SetImageScanningConfiguration(f0)
+	}
+	if r.ko.Spec.ImageTagMutability != nil {
+		res.SetImageTagMutability(*r.ko.Spec.ImageTagMutability)
+	}
+	if r.ko.Spec.RepositoryName != nil {
+		res.SetRepositoryName(*r.ko.Spec.RepositoryName)
+	} else {
+		res.SetRepositoryName("my-repository")
+	}
+	if r.ko.Spec.Tags != nil {
+		f3 := []*svcsdk.Tag{}
+		for _, f3iter := range r.ko.Spec.Tags {
+			f3elem := &svcsdk.Tag{}
+			if f3iter.Key != nil {
+				f3elem.SetKey(*f3iter.Key)
+			}
+			if f3iter.Value != nil {
+				f3elem.SetValue(*f3iter.Value)
+			}
+			f3 = append(f3, f3elem)
+		}
+		res.SetTags(f3)
+	}
+`
+	assert.Equal(
+		expected,
+		code.SetSDK(crd.Config(), crd, model.OpTypeCreate, "r.ko", "res", 1),
+	)
+}
+
+func TestSetSDK_ECR_Repository_Create_EnumTypes(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	g := testutil.NewModelForServiceWithOptions(t, "ecr", &testutil.TestingModelOptions{
+		GeneratorConfigFile: "generator-with-enum-types.yaml",
+	})
+
+	crd := testutil.GetCRDByName(t, g, "Repository")
+	require.NotNil(crd)
+
+	// ImageTagMutability is an enum, so the Spec field has the generated
+	// ImageTagMutability type and needs to be converted to a string.
+	expected := `
+	if r.ko.Spec.ImageScanningConfiguration != nil {
+		f0 := &svcsdk.ImageScanningConfiguration{}
+		if r.ko.Spec.ImageScanningConfiguration.ScanOnPush != nil {
+			f0.SetScanOnPush(*r.ko.Spec.ImageScanningConfiguration.ScanOnPush)
+		}
+		res.SetImageScanningConfiguration(f0)
+	}
+	if r.ko.Spec.ImageTagMutability != nil {
+		res.SetImageTagMutability(string(*r.ko.Spec.ImageTagMutability))
+	}
+	if r.ko.Spec.RepositoryName != nil {
+		res.SetRepositoryName(*r.ko.Spec.RepositoryName)
+	}
+	if r.ko.Spec.Tags != nil {
+		f3 := []*svcsdk.Tag{}
+		for _, f3iter := range r.ko.Spec.Tags {
+			f3elem := &svcsdk.Tag{}
+			if f3iter.Key != nil {
+				f3elem.SetKey(*f3iter.Key)
+			}
+			if f3iter.Value != nil {
+				f3elem.SetValue(*f3iter.Value)
+			}
+			f3 = append(f3, f3elem)
+		}
+		res.SetTags(f3)
+	}
+`
+	assert.Equal(
+		expected,
+		code.SetSDK(crd.Config(), crd, model.OpTypeCreate, "r.ko", "res", 1),
+	)
+}
+
+func TestSetSDK_ECR_Repository_Create_Document(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	g := testutil.NewModelForService(t, "ecr")
+	// None of the ECR shapes are documents, so pretend one is
+	g.SDKAPI.ShapeConstraints["ImageScanningConfiguration"] = &model.ShapeConstraints{
+		Document: true,
+	}
+
+	crd := testutil.GetCRDByName(t, g, "Repository")
+	require.NotNil(crd)
+
+	expected := `
+	if r.ko.Spec.ImageScanningConfiguration != nil {
+		f0 := &svcsdk.ImageScanningConfiguration{}
+		if err := json.Unmarshal(r.ko.Spec.ImageScanningConfiguration.Raw, f0); err != nil {
+			return nil, err
+		}
+		res.SetImageScanningConfiguration(f0)
+	}
+`
+	actual := code.SetSDK(crd.Config(), crd, model.OpTypeCreate, "r.ko", "res", 1)
+	assert.Contains(actual, expected)
+}
+
+func TestSetSDK_ECR_Repository_Create_RecursiveShape(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	g := testutil.NewModelForService(t, "ecr")
+	// None of the ECR shapes are recursive, so make one recursive
+	shape := g.SDKAPI.API.Shapes["ImageScanningConfiguration"]
+	require.NotNil(shape)
+	shape.MemberRefs["Nested"] = &awssdkmodel.ShapeRef{
+		ShapeName: shape.ShapeName,
+		Shape:     shape,
+	}
+
+	crd := testutil.GetCRDByName(t, g, "Repository")
+	require.NotNil(crd)
+
+	// The recursive member is copied through JSON instead of member by
+	// member
+	expected := `
+	if r.ko.Spec.ImageScanningConfiguration != nil {
+		f0 := &svcsdk.ImageScanningConfiguration{}
+		if r.ko.Spec.ImageScanningConfiguration.Nested != nil {
+			f0f0 := &svcsdk.ImageScanningConfiguration{}
+			f0f0raw, err := json.Marshal(r.ko.Spec.ImageScanningConfiguration.Nested)
+			if err != nil {
+				return nil, err
+			}
+			if err := json.Unmarshal(f0f0raw, &f0f0); err != nil {
+				return nil, err
+			}
+			f0.SetNested(f0f0)
+		}
+		if r.ko.Spec.ImageScanningConfiguration.ScanOnPush != nil {
+			f0.SetScanOnPush(*r.ko.Spec.ImageScanningConfiguration.ScanOnPush)
+		}
+		res.SetImageScanningConfiguration(f0)
+	}
+`
+	actual := code.SetSDK(crd.Config(), crd, model.OpTypeCreate, "r.ko", "res", 1)
+	assert.Contains(actual, expected)
+}
+
+func TestSetSDK_Lambda_Function_Create_NumberFormat(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	g := testutil.NewModelForServiceWithOptions(t, "lambda", &testutil.TestingModelOptions{
+		GeneratorConfigFile: "generator-with-number-format.yaml",
+	})
+
+	crd := testutil.GetCRDByName(t, g, "Function")
+	require.NotNil(crd)
+
+	// MemorySize is an int32 in the Spec, but an int64 in the SDK
+	expected := `
+	if r.ko.Spec.MemorySize != nil {
+		res.SetMemorySize(int64(*r.ko.Spec.MemorySize))
+	}
+`
+	assert.Contains(
+		code.SetSDK(crd.Config(), crd, model.OpTypeCreate, "r.ko", "res", 1),
+		expected,
+	)
+}
+
+func TestSetSDK_Lambda_Function_Create_NestedMapSecret(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	g := testutil.NewModelForServiceWithOptions(t, "lambda", &testutil.TestingModelOptions{
+		GeneratorConfigFile: "generator-with-nested-secrets.yaml",
+	})
+
+	crd := testutil.GetCRDByName(t, g, "Function")
+	require.NotNil(crd)
+
+	// Each environment variable's value is read from its referenced Secret
+	expected := `
+			for f4f0key, f4f0valiter := range r.ko.Spec.Environment.Variables {
+				var f4f0val string
+				if f4f0valiter != nil {
+					tmpSecret, err := rm.rr.SecretValueFromReference(ctx, f4f0valiter)
+					if err != nil {
+						return nil, err
+					}
+					if tmpSecret != "" {
+						f4f0val = tmpSecret
+					}
+				}
+				f4f0[f4f0key] = &f4f0val
+			}
+`
+	assert.Contains(
+		code.SetSDK(crd.Config(), crd, model.OpTypeCreate, "r.ko", "res", 1),
+		expected,
+	)
+}
+
+func TestSetSDK_ECR_Repository_Create_TagFormat(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	g := testutil.NewModelForServiceWithOptions(t, "ecr", &testutil.TestingModelOptions{
+		GeneratorConfigFile: "generator-with-tag-format.yaml",
+	})
+
+	crd := testutil.GetCRDByName(t, g, "Repository")
+	require.NotNil(crd)
+
+	// The Spec has a map of tags, the SDK a list of Tag structs
+	expected := `
+	if r.ko.Spec.Tags != nil {
+		f3 := []*svcsdk.Tag{}
+		for f3key, f3val := range r.ko.Spec.Tags {
+			f3elem := &svcsdk.Tag{}
+			f3elem.SetKey(f3key)
+			if f3val != nil {
+				f3elem.SetValue(*f3val)
+			}
+			f3 = append(f3, f3elem)
+		}
+		res.SetTags(f3)
+	}
+`
+	assert.Contains(
+		code.SetSDK(crd.Config(), crd, model.OpTypeCreate, "r.ko", "res", 1),
+		expected,
+	)
+}
+
+func TestSetSDK_Lambda_CodeSigningConfig_CreateOnlyFields(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	g := testutil.NewModelForServiceWithOptions(t, "lambda", &testutil.TestingModelOptions{
+		GeneratorConfigFile: "generator-with-create-only-fields.yaml",
+	})
+
+	crd := testutil.GetCRDByName(t, g, "CodeSigningConfig")
+	require.NotNil(crd)
+
+	// Description is only sent to the Create operation, and
+	// CodeSigningPolicies only to the Update operation
+	create := code.SetSDK(crd.Config(), crd, model.OpTypeCreate, "r.ko", "res", 1)
+	assert.Contains(create, "res.SetDescription(*r.ko.Spec.Description)")
+	assert.NotContains(create, "res.SetCodeSigningPolicies(")
+
+	update := code.SetSDK(crd.Config(), crd, model.OpTypeUpdate, "r.ko", "res", 1)
+	assert.NotContains(update, "res.SetDescription(")
+	assert.Contains(update, "res.SetCodeSigningPolicies(")
+}
+
+func TestSetSDK_Lambda_Function_Create_TagFormat(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	g := testutil.NewModelForServiceWithOptions(t, "lambda", &testutil.TestingModelOptions{
+		GeneratorConfigFile: "generator-with-tag-format.yaml",
+	})
+
+	crd := testutil.GetCRDByName(t, g, "Function")
+	require.NotNil(crd)
+
+	// The Spec has a list of Tag structs, the SDK a map of tags
+	expected := `
+	if r.ko.Spec.Tags != nil {
+		f16 := map[string]*string{}
+		for _, f16iter := range r.ko.Spec.Tags {
+			if f16iter.Key != nil {
+				f16[*f16iter.Key] = f16iter.Value
+			}
+		}
+		res.SetTags(f16)
+	}
+`
+	assert.Contains(
+		code.SetSDK(crd.Config(), crd, model.OpTypeCreate, "r.ko", "res", 1),
+		expected,
+	)
+}
+
 func TestSetSDK_Elasticache_ReplicationGroup_Create(t *testing.T) {
 	assert := assert.New(t)
 	require := require.New(t)
@@ -1493,6 +1799,30 @@ func TestSetSDK_SNS_Topic_GetAttributes(t *testing.T) {
 	)
 }
 
+func TestSetSDK_SNS_PlatformEndpoint_GetAttributes(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	g := testutil.NewModelForServiceWithOptions(t, "sns", &testutil.TestingModelOptions{
+		GeneratorConfigFile: "generator-with-arn-identified-resources.yaml",
+	})
+
+	crd := testutil.GetCRDByName(t, g, "PlatformEndpoint")
+	require.NotNil(crd)
+
+	// A PlatformEndpoint has no name to construct its ARN from, so the
+	// EndpointArn can only be read from the resource's metadata
+	expected := `
+	if r.ko.Status.ACKResourceMetadata != nil && r.ko.Status.ACKResourceMetadata.ARN != nil {
+		res.SetEndpointArn(string(*r.ko.Status.ACKResourceMetadata.ARN))
+	}
+`
+	assert.Equal(
+		expected,
+		code.SetSDKGetAttributes(crd.Config(), crd, "r.ko", "res", 1),
+	)
+}
+
 func TestSetSDK_SQS_Queue_Create(t *testing.T) {
 	assert := assert.New(t)
 	require := require.New(t)
@@ -1587,6 +1917,53 @@ func TestSetSDK_SQS_Queue_GetAttributes(t *testing.T) {
 	)
 }
 
+func TestSetSDK_SQS_Queue_Create_AttributeKeys(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	g := testutil.NewModelForServiceWithOptions(t, "sqs", &testutil.TestingModelOptions{
+		GeneratorConfigFile: "generator-with-attribute-keys.yaml",
+	})
+
+	crd := testutil.GetCRDByName(t, g, "Queue")
+	require.NotNil(crd)
+
+	// Attribute fields are packed into the Attributes map under their
+	// configured attribute keys
+	expected := `
+	attrMap := map[string]*string{}
+	if r.ko.Spec.DelaySeconds != nil {
+		attrMap["DelaySeconds"] = r.ko.Spec.DelaySeconds
+	}
+	if r.ko.Spec.EncryptionKey != nil {
+		attrMap["KmsMasterKeyId"] = r.ko.Spec.EncryptionKey
+	}
+	if r.ko.Spec.Policy != nil {
+		attrMap["Policy"] = r.ko.Spec.Policy
+	}
+	if r.ko.Spec.RetentionPeriod != nil {
+		attrMap["MessageRetentionPeriod"] = r.ko.Spec.RetentionPeriod
+	}
+	res.SetAttributes(attrMap)
+	if r.ko.Spec.QueueName != nil {
+		res.SetQueueName(*r.ko.Spec.QueueName)
+	}
+	if r.ko.Spec.Tags != nil {
+		f2 := map[string]*string{}
+		for f2key, f2valiter := range r.ko.Spec.Tags {
+			var f2val string
+			f2val = *f2valiter
+			f2[f2key] = &f2val
+		}
+		res.SetTags(f2)
+	}
+`
+	assert.Equal(
+		expected,
+		code.SetSDK(crd.Config(), crd, model.OpTypeCreate, "r.ko", "res", 1),
+	)
+}
+
 func TestSetSDK_MQ_Broker_Create(t *testing.T) {
 	assert := assert.New(t)
 	require := require.New(t)
@@ -1618,6 +1995,8 @@ func TestSetSDK_MQ_Broker_Create(t *testing.T) {
 	}
 	if r.ko.Spec.CreatorRequestID != nil {
 		res.SetCreatorRequestId(*r.ko.Spec.CreatorRequestID)
+	} else {
+		res.SetCreatorRequestId(fmt.Sprintf("%s-%d", r.ko.UID, r.ko.Generation))
 	}
 	if r.ko.Spec.DeploymentMode != nil {
 		res.SetDeploymentMode(*r.ko.Spec.DeploymentMode)
@@ -1779,6 +2158,28 @@ func TestSetSDK_MQ_Broker_Create(t *testing.T) {
 	)
 }
 
+func TestSetSDK_MQ_Broker_Create_NoAutoIdempotencyToken(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	g := testutil.NewModelForServiceWithOptions(t, "mq", &testutil.TestingModelOptions{
+		GeneratorConfigFile: "generator-without-idempotency-token.yaml",
+	})
+
+	crd := testutil.GetCRDByName(t, g, "Broker")
+	require.NotNil(crd)
+
+	// The CreatorRequestId idempotency token is only set from the Spec
+	expected := `
+	if r.ko.Spec.CreatorRequestID != nil {
+		res.SetCreatorRequestId(*r.ko.Spec.CreatorRequestID)
+	}
+`
+	actual := code.SetSDK(crd.Config(), crd, model.OpTypeCreate, "r.ko", "res", 1)
+	assert.Contains(actual, expected)
+	assert.NotContains(actual, "r.ko.Generation")
+}
+
 func TestSetSDK_EC2_VPC_ReadMany(t *testing.T) {
 	assert := assert.New(t)
 	require := require.New(t)
@@ -1800,3 +2201,74 @@ func TestSetSDK_EC2_VPC_ReadMany(t *testing.T) {
 		code.SetSDK(crd.Config(), crd, model.OpTypeList, "r.ko", "res", 1),
 	)
 }
+
+func TestSetSDK_S3_Bucket_SecondaryOperation(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	g := testutil.NewModelForServiceWithOptions(t, "s3", &testutil.TestingModelOptions{
+		GeneratorConfigFile: "generator-with-secondary-operations.yaml",
+	})
+
+	crd := testutil.GetCRDByName(t, g, "Bucket")
+	require.NotNil(crd)
+	require.Len(crd.SecondaryOps, 2)
+
+	// The Bucket member of the PutBucketVersioning Input shape is set from
+	// the renamed Name field and the VersioningConfiguration member from the
+	// Versioning field added for the secondary operation
+	expected := `
+	if r.ko.Spec.Name != nil {
+		res.SetBucket(*r.ko.Spec.Name)
+	}
+	if r.ko.Spec.Versioning != nil {
+		f1 := &svcsdk.VersioningConfiguration{}
+		if r.ko.Spec.Versioning.MFADelete != nil {
+			f1.SetMFADelete(*r.ko.Spec.Versioning.MFADelete)
+		}
+		if r.ko.Spec.Versioning.Status != nil {
+			f1.SetStatus(*r.ko.Spec.Versioning.Status)
+		}
+		res.SetVersioningConfiguration(f1)
+	}
+`
+	assert.Equal(
+		expected,
+		code.SetSDKForOperation(
+			crd.Config(), crd, crd.SecondaryOps[1].Operation, "r.ko", "res", 1,
+		),
+	)
+}
+
+func TestSetSDK_ECR_Repository_UpdateOperation(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	g := testutil.NewModelForServiceWithOptions(t, "ecr", &testutil.TestingModelOptions{
+		GeneratorConfigFile: "generator-with-update-operations.yaml",
+	})
+
+	crd := testutil.GetCRDByName(t, g, "Repository")
+	require.NotNil(crd)
+	require.Len(crd.UpdateOps, 2)
+
+	// The registryId member of the PutImageTagMutability Input shape is set
+	// from the Status field populated by the CreateRepository call
+	expected := `
+	if r.ko.Spec.ImageTagMutability != nil {
+		res.SetImageTagMutability(*r.ko.Spec.ImageTagMutability)
+	}
+	if r.ko.Status.RegistryID != nil {
+		res.SetRegistryId(*r.ko.Status.RegistryID)
+	}
+	if r.ko.Spec.RepositoryName != nil {
+		res.SetRepositoryName(*r.ko.Spec.RepositoryName)
+	}
+`
+	assert.Equal(
+		expected,
+		code.SetSDKForOperation(
+			crd.Config(), crd, crd.UpdateOps[1].Operation, "r.ko", "res", 1,
+		),
+	)
+}
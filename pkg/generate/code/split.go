@@ -0,0 +1,127 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package code
+
+import (
+	"fmt"
+	"strings"
+
+	awssdkmodel "github.com/aws/aws-sdk-go/private/model/api"
+
+	ackgenconfig "github.com/aws-controllers-k8s/code-generator/pkg/generate/config"
+	"github.com/aws-controllers-k8s/code-generator/pkg/model"
+)
+
+// MergeSplitInput returns the Go code that sets the members of the supplied
+// operation's Input shape that belong to the other CRDs split from the same
+// resource as the supplied CRD, from the Output shape of the ReadOne
+// operation. Only the members having the same shape in both are merged.
+//
+// Given the BrokerEngine CRD split from MQ's Broker resource, with the
+// AuthenticationStrategy and SecurityGroups fields owned by another split:
+//
+//	op:             UpdateBroker
+//	sourceVarName:  resp
+//	targetVarName:  input
+//	indentLevel:    1
+//
+// Then this function should output something like this:
+//
+//	input.AuthenticationStrategy = resp.AuthenticationStrategy
+//	input.SecurityGroups = resp.SecurityGroups
+func MergeSplitInput(
+	cfg *ackgenconfig.Config,
+	r *model.CRD,
+	op *awssdkmodel.Operation,
+	// String representing the name of the variable holding the Output shape
+	// of the ReadOne operation, e.g. "resp"
+	sourceVarName string,
+	// String representing the name of the variable holding the Input shape
+	// of the operation, e.g. "input"
+	targetVarName string,
+	// Number of levels of indentation to use
+	indentLevel int,
+) string {
+	if r.SplitFrom == nil || op == nil || op.InputRef.Shape == nil {
+		return ""
+	}
+	outputShape, _ := r.GetOutputShape(r.Ops.ReadOne)
+	if outputShape == nil {
+		return ""
+	}
+
+	// Like the ReadOne output is set into the resource, use the wrapper
+	// field path if there is one, or unwrap a single structure member
+	wrapperPath := ""
+	if wrapperFieldPath := r.GetOutputWrapperFieldPath(r.Ops.ReadOne); wrapperFieldPath != nil {
+		wrapperPath = *wrapperFieldPath
+	} else if outputShape.UsedAsOutput && len(outputShape.MemberRefs) == 1 {
+		for memberName, memberRef := range outputShape.MemberRefs {
+			if memberRef.Shape.Type == "structure" {
+				wrapperPath = memberName
+				outputShape = memberRef.Shape
+			}
+		}
+	}
+
+	indent := strings.Repeat("\t", indentLevel)
+	merges := []string{}
+	inputShape := op.InputRef.Shape
+	for _, memberName := range inputShape.MemberNames() {
+		if r.IsPrimaryARNField(memberName) {
+			continue
+		}
+		fieldName, _ := cfg.ResourceFieldRename(
+			r.Names.Original, op.Name, memberName,
+		)
+		if inSpec, inStatus := r.HasMember(fieldName, op.Name); inSpec || inStatus {
+			// Set from the CRD's own fields
+			continue
+		}
+		inputMemberRef := inputShape.MemberRefs[memberName]
+		outputMemberRef, found := outputShape.MemberRefs[memberName]
+		if !found || inputMemberRef.Shape == nil || outputMemberRef.Shape == nil ||
+			outputMemberRef.Shape.ShapeName != inputMemberRef.Shape.ShapeName {
+			continue
+		}
+		merges = append(merges, memberName)
+	}
+	if len(merges) == 0 {
+		return ""
+	}
+
+	out := "\n"
+	sourceAdaptedVarName := sourceVarName
+	if wrapperPath != "" {
+		// if resp.Broker != nil {
+		conds := []string{}
+		for _, part := range strings.Split(wrapperPath, ".") {
+			sourceAdaptedVarName += "." + part
+			conds = append(conds, sourceAdaptedVarName+" != nil")
+		}
+		out += fmt.Sprintf("%sif %s {\n", indent, strings.Join(conds, " && "))
+		indent += "\t"
+	}
+	for _, memberName := range merges {
+		// input.SecurityGroups = resp.SecurityGroups
+		out += fmt.Sprintf(
+			"%s%s.%s = %s.%s\n",
+			indent, targetVarName, memberName, sourceAdaptedVarName, memberName,
+		)
+	}
+	if wrapperPath != "" {
+		out += fmt.Sprintf("%s}\n", indent[1:])
+	}
+	return out
+}
@@ -0,0 +1,76 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//	 http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package code_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/aws-controllers-k8s/code-generator/pkg/generate/code"
+	"github.com/aws-controllers-k8s/code-generator/pkg/testutil"
+)
+
+func TestMergeSplitInput_MQ_Broker(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	g := testutil.NewModelForServiceWithOptions(t, "mq", &testutil.TestingModelOptions{
+		GeneratorConfigFile: "generator-with-splits.yaml",
+	})
+
+	// The LdapServerMetadata member owned by the BrokerSecurity split isn't
+	// merged because its shape in the DescribeBroker Output shape differs
+	// from its shape in the UpdateBroker Input shape
+	crd := testutil.GetCRDByName(t, g, "BrokerEngine")
+	require.NotNil(crd)
+
+	expected := `
+	input.AuthenticationStrategy = resp.AuthenticationStrategy
+	input.SecurityGroups = resp.SecurityGroups
+`
+	assert.Equal(
+		expected,
+		code.MergeSplitInput(crd.Config(), crd, crd.Ops.Update, "resp", "input", 1),
+	)
+
+	crd = testutil.GetCRDByName(t, g, "BrokerSecurity")
+	require.NotNil(crd)
+
+	expected = `
+	input.AutoMinorVersionUpgrade = resp.AutoMinorVersionUpgrade
+	input.EngineVersion = resp.EngineVersion
+	input.HostInstanceType = resp.HostInstanceType
+`
+	assert.Equal(
+		expected,
+		code.MergeSplitInput(crd.Config(), crd, crd.Ops.Update, "resp", "input", 1),
+	)
+}
+
+func TestMergeSplitInput_NotSplit(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	g := testutil.NewModelForService(t, "mq")
+
+	crd := testutil.GetCRDByName(t, g, "Broker")
+	require.NotNil(crd)
+
+	assert.Equal(
+		"",
+		code.MergeSplitInput(crd.Config(), crd, crd.Ops.Update, "resp", "input", 1),
+	)
+}
@@ -0,0 +1,155 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package code
+
+import (
+	"fmt"
+	"strings"
+
+	ackgenconfig "github.com/aws-controllers-k8s/code-generator/pkg/generate/config"
+	"github.com/aws-controllers-k8s/code-generator/pkg/model"
+)
+
+// CRTagsToMap returns Go code that copies the tags held by a variable of the
+// type of the resource's Spec.Tags field into a map[string]*string variable.
+//
+// When the resource holds its tags in a list, the output code looks like
+// this:
+//
+//	for _, iter := range desired.ko.Spec.Tags {
+//	    if iter.Key != nil {
+//	        desiredTags[*iter.Key] = iter.Value
+//	    }
+//	}
+func CRTagsToMap(
+	r *model.CRD,
+	// The variable holding the tags of the resource
+	sourceVarName string,
+	// The map variable the tags are copied to
+	targetVarName string,
+	// Number of levels of indentation to use
+	indentLevel int,
+) string {
+	return tagsToMap(r.TagsAreMap(), sourceVarName, targetVarName, indentLevel)
+}
+
+// ListTagsOutputToMap returns Go code that copies the tags held by the output
+// of the AWS API's operation listing the tags of a resource into a
+// map[string]*string variable.
+//
+// When the AWS API returns tags in a map, the output code looks like this:
+//
+//	for key, val := range listResp.Tags {
+//	    latestTags[key] = val
+//	}
+func ListTagsOutputToMap(
+	r *model.CRD,
+	// The variable holding the output of the operation
+	sourceVarName string,
+	// The map variable the tags are copied to
+	targetVarName string,
+	// Number of levels of indentation to use
+	indentLevel int,
+) string {
+	ops := r.TaggingOps()
+	if ops == nil {
+		return ""
+	}
+	return tagsToMap(
+		ops.List.TagsShapeRef.Shape.Type == "map",
+		sourceVarName+"."+ops.List.TagsMemberName,
+		targetVarName,
+		indentLevel,
+	)
+}
+
+// SetTagResourceInputTags returns Go code that sets the tags of the input of
+// the AWS API's operation adding tags to a resource to the tags held by a
+// map[string]*string variable.
+//
+// When the AWS API takes tags in a list, the output code looks like this:
+//
+//	f0 := []*svcsdk.Tag{}
+//	for f0key, f0val := range toAdd {
+//	    f0elem := &svcsdk.Tag{}
+//	    f0elem.SetKey(f0key)
+//	    if f0val != nil {
+//	        f0elem.SetValue(*f0val)
+//	    }
+//	    f0 = append(f0, f0elem)
+//	}
+//	tagInput.SetTags(f0)
+func SetTagResourceInputTags(
+	cfg *ackgenconfig.Config,
+	r *model.CRD,
+	// The map variable holding the tags to add
+	sourceVarName string,
+	// The variable holding the input of the operation
+	targetVarName string,
+	// Number of levels of indentation to use
+	indentLevel int,
+) string {
+	ops := r.TaggingOps()
+	if ops == nil {
+		return ""
+	}
+	out := ""
+	indent := strings.Repeat("\t", indentLevel)
+	tagsShapeRef := ops.Tag.TagsShapeRef
+	setTo := sourceVarName
+	if tagsShapeRef.Shape.Type == "list" {
+		setTo = "f0"
+		out += varEmptyConstructorSDKType(
+			cfg, r, setTo, tagsShapeRef.Shape, indentLevel,
+		)
+		out += setSDKForTags(
+			cfg, r,
+			ackgenconfig.TagFormatMap,
+			setTo,
+			tagsShapeRef,
+			sourceVarName,
+			indentLevel,
+		)
+	}
+	out += fmt.Sprintf(
+		"%s%s.Set%s(%s)\n",
+		indent, targetVarName, ops.Tag.TagsMemberName, setTo,
+	)
+	return out
+}
+
+// tagsToMap returns Go code that copies the tags held by a source variable,
+// either a map or a list of Key/Value structs, into a map[string]*string
+// variable
+func tagsToMap(
+	isMap bool,
+	sourceVarName string,
+	targetVarName string,
+	indentLevel int,
+) string {
+	out := ""
+	indent := strings.Repeat("\t", indentLevel)
+	if isMap {
+		out += fmt.Sprintf("%sfor key, val := range %s {\n", indent, sourceVarName)
+		out += fmt.Sprintf("%s\t%s[key] = val\n", indent, targetVarName)
+		out += fmt.Sprintf("%s}\n", indent)
+		return out
+	}
+	out += fmt.Sprintf("%sfor _, iter := range %s {\n", indent, sourceVarName)
+	out += fmt.Sprintf("%s\tif iter.Key != nil {\n", indent)
+	out += fmt.Sprintf("%s\t\t%s[*iter.Key] = iter.Value\n", indent, targetVarName)
+	out += fmt.Sprintf("%s\t}\n", indent)
+	out += fmt.Sprintf("%s}\n", indent)
+	return out
+}
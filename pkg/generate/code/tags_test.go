@@ -0,0 +1,86 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package code_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/aws-controllers-k8s/code-generator/pkg/generate/code"
+	"github.com/aws-controllers-k8s/code-generator/pkg/testutil"
+)
+
+func TestSyncTags_ECR_Repository(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	g := testutil.NewModelForServiceWithOptions(t, "ecr", &testutil.TestingModelOptions{
+		GeneratorConfigFile: "generator-with-sync-tags.yaml",
+	})
+
+	crd := testutil.GetCRDByName(t, g, "Repository")
+	require.NotNil(crd)
+	require.NotNil(crd.TaggingOps())
+
+	// ECR models tags as a list of Tag structs everywhere
+	expected := `	for _, iter := range desired.ko.Spec.Tags {
+		if iter.Key != nil {
+			desiredTags[*iter.Key] = iter.Value
+		}
+	}
+`
+	assert.Equal(
+		expected,
+		code.CRTagsToMap(crd, "desired.ko.Spec.Tags", "desiredTags", 1),
+	)
+
+	expected = `	for _, iter := range listResp.Tags {
+		if iter.Key != nil {
+			latestTags[*iter.Key] = iter.Value
+		}
+	}
+`
+	assert.Equal(
+		expected,
+		code.ListTagsOutputToMap(crd, "listResp", "latestTags", 1),
+	)
+
+	expected = `	f0 := []*svcsdk.Tag{}
+	for f0key, f0val := range toAdd {
+		f0elem := &svcsdk.Tag{}
+		f0elem.SetKey(f0key)
+		if f0val != nil {
+			f0elem.SetValue(*f0val)
+		}
+		f0 = append(f0, f0elem)
+	}
+	tagInput.SetTags(f0)
+`
+	assert.Equal(
+		expected,
+		code.SetTagResourceInputTags(crd.Config(), crd, "toAdd", "tagInput", 1),
+	)
+}
+
+func TestSyncTags_ECR_Repository_Disabled(t *testing.T) {
+	require := require.New(t)
+
+	g := testutil.NewModelForService(t, "ecr")
+
+	crd := testutil.GetCRDByName(t, g, "Repository")
+	require.NotNil(crd)
+	require.Nil(crd.TaggingOps())
+}
@@ -0,0 +1,174 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package code
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/aws-controllers-k8s/code-generator/pkg/model"
+	"github.com/aws-controllers-k8s/code-generator/pkg/names"
+)
+
+// ValidateImmutableFields returns the Go code that appends a
+// `field.Forbidden` error to a `field.ErrorList` variable for every immutable
+// Spec field that is set in the old version of a resource, and either unset
+// or set to another value in the new version. Like the validation rules of
+// the Spec, it lets immutable fields be set once, including along with their
+// parent structs.
+//
+// Assume a resource with immutable fields `Name` and `Config.Engine`. The
+// output would look like this:
+//
+//	if old.Spec.Name != nil && !reflect.DeepEqual(old.Spec.Name, r.Spec.Name) {
+//		allErrs = append(allErrs, field.Forbidden(field.NewPath("spec", "name"), "field is immutable"))
+//	}
+//	if old.Spec.Config != nil && old.Spec.Config.Engine != nil &&
+//		(r.Spec.Config == nil || !reflect.DeepEqual(old.Spec.Config.Engine, r.Spec.Config.Engine)) {
+//		allErrs = append(allErrs, field.Forbidden(field.NewPath("spec", "config", "engine"), "field is immutable"))
+//	}
+func ValidateImmutableFields(
+	r *model.CRD,
+	// The variable name of the resource before the update
+	oldVarName string,
+	// The variable name of the resource after the update
+	newVarName string,
+	// The variable name of the field.ErrorList to append errors to
+	errsVarName string,
+	indentLevel int,
+) string {
+	out := ""
+	indent := strings.Repeat("\t", indentLevel)
+	for _, immutablePath := range r.GetImmutableFieldPaths() {
+		parts := strings.Split(immutablePath, ".")
+		goPath := []string{}
+		jsonPath := []string{`"spec"`}
+		for _, part := range parts {
+			cleanNames := names.New(part)
+			goPath = append(goPath, cleanNames.Camel)
+			jsonPath = append(jsonPath, fmt.Sprintf("%q", cleanNames.CamelLower))
+		}
+		goType := ""
+		if field, found := r.Fields[strings.Join(goPath, ".")]; found {
+			goType = field.GoType
+		}
+		out += fmt.Sprintf(
+			"%sif %s {\n", indent,
+			immutableFieldChangedCondition(
+				oldVarName+".Spec", newVarName+".Spec", goPath, goType,
+			),
+		)
+		out += fmt.Sprintf(
+			"%s\t%s = append(%s, field.Forbidden(field.NewPath(%s), \"field is immutable\"))\n",
+			indent, errsVarName, errsVarName, strings.Join(jsonPath, ", "),
+		)
+		out += fmt.Sprintf("%s}\n", indent)
+	}
+	return out
+}
+
+// immutableFieldChangedCondition returns a Go boolean expression that is true
+// when the value at the supplied field path is set in the old struct, and
+// either unset or set to another value in the new struct, guarding every
+// intermediate struct pointer against nil dereferences.
+func immutableFieldChangedCondition(
+	oldVarName string,
+	newVarName string,
+	goPath []string,
+	// The Go type of the field
+	goType string,
+) string {
+	// The field is set if none of its parents is nil
+	oldConds := []string{}
+	newConds := []string{}
+	for i := 1; i < len(goPath); i++ {
+		parentPath := strings.Join(goPath[:i], ".")
+		oldConds = append(oldConds, fmt.Sprintf("%s.%s != nil", oldVarName, parentPath))
+		newConds = append(newConds, fmt.Sprintf("%s.%s == nil", newVarName, parentPath))
+	}
+	oldValue := oldVarName + "." + strings.Join(goPath, ".")
+	newValue := newVarName + "." + strings.Join(goPath, ".")
+	if strings.HasPrefix(goType, "[]") || strings.HasPrefix(goType, "map[") {
+		oldConds = append(oldConds, fmt.Sprintf("len(%s) > 0", oldValue))
+	} else {
+		oldConds = append(oldConds, oldValue+" != nil")
+	}
+	newConds = append(newConds, fmt.Sprintf("!reflect.DeepEqual(%s, %s)", oldValue, newValue))
+	if len(newConds) == 1 {
+		return strings.Join(oldConds, " && ") + " && " + newConds[0]
+	}
+	return fmt.Sprintf(
+		"%s &&\n\t(%s)",
+		strings.Join(oldConds, " && "), strings.Join(newConds, " || "),
+	)
+}
+
+// DeprecationWarnings returns the Go code that appends a warning to a
+// `[]string` variable for every deprecated Spec field set in a resource.
+//
+// Assume a resource with the deprecated fields `DBSecurityGroups`, replaced
+// by `VPCSecurityGroupIDs`, and `Config.Engine`. The output would look like
+// this:
+//
+//	if len(r.Spec.DBSecurityGroups) > 0 {
+//		warnings = append(warnings, "spec.dbSecurityGroups is deprecated: Use spec.vpcSecurityGroupIDs instead.")
+//	}
+//	if r.Spec.Config != nil && r.Spec.Config.Engine != nil {
+//		warnings = append(warnings, "spec.config.engine is deprecated")
+//	}
+func DeprecationWarnings(
+	r *model.CRD,
+	// The variable name of the resource
+	varName string,
+	// The variable name of the []string to append warnings to
+	warningsVarName string,
+	indentLevel int,
+) string {
+	out := ""
+	indent := strings.Repeat("\t", indentLevel)
+	for _, deprecatedPath := range r.GetDeprecatedFieldPaths() {
+		goPath := []string{}
+		for _, part := range strings.Split(deprecatedPath, ".") {
+			goPath = append(goPath, names.New(part).Camel)
+		}
+		// The structs nested in the Spec may be nil
+		conds := []string{}
+		for i := 1; i < len(goPath); i++ {
+			conds = append(conds, fmt.Sprintf(
+				"%s.Spec.%s != nil", varName, strings.Join(goPath[:i], "."),
+			))
+		}
+		value := varName + ".Spec." + strings.Join(goPath, ".")
+		goType := ""
+		if field, found := r.Fields[strings.Join(goPath, ".")]; found {
+			goType = field.GoType
+		}
+		if strings.HasPrefix(goType, "[]") || strings.HasPrefix(goType, "map[") {
+			conds = append(conds, fmt.Sprintf("len(%s) > 0", value))
+		} else {
+			conds = append(conds, value+" != nil")
+		}
+		warning := model.SpecJSONPath(deprecatedPath) + " is deprecated"
+		if notice := r.DeprecationNotice(deprecatedPath); notice != "" {
+			warning += ": " + notice
+		}
+		out += fmt.Sprintf("%sif %s {\n", indent, strings.Join(conds, " && "))
+		out += fmt.Sprintf(
+			"%s\t%s = append(%s, %q)\n",
+			indent, warningsVarName, warningsVarName, warning,
+		)
+		out += fmt.Sprintf("%s}\n", indent)
+	}
+	return out
+}
@@ -0,0 +1,264 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//	 http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package code_test
+
+import (
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"reflect"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/aws-controllers-k8s/code-generator/pkg/generate/code"
+	"github.com/aws-controllers-k8s/code-generator/pkg/testutil"
+)
+
+func TestValidateImmutableFields_ECR_Repository(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	g := testutil.NewModelForServiceWithOptions(t, "ecr", &testutil.TestingModelOptions{
+		GeneratorConfigFile: "generator-with-immutable-fields.yaml",
+	})
+
+	crd := testutil.GetCRDByName(t, g, "Repository")
+	require.NotNil(crd)
+
+	expected := `
+	if old.Spec.ImageScanningConfiguration != nil && old.Spec.ImageScanningConfiguration.ScanOnPush != nil &&
+	(r.Spec.ImageScanningConfiguration == nil || !reflect.DeepEqual(old.Spec.ImageScanningConfiguration.ScanOnPush, r.Spec.ImageScanningConfiguration.ScanOnPush)) {
+		allErrs = append(allErrs, field.Forbidden(field.NewPath("spec", "imageScanningConfiguration", "scanOnPush"), "field is immutable"))
+	}
+	if old.Spec.RepositoryName != nil && !reflect.DeepEqual(old.Spec.RepositoryName, r.Spec.RepositoryName) {
+		allErrs = append(allErrs, field.Forbidden(field.NewPath("spec", "repositoryName"), "field is immutable"))
+	}
+`
+	assert.Equal(
+		expected,
+		"\n"+code.ValidateImmutableFields(crd, "old", "r", "allErrs", 1),
+	)
+}
+
+// testRepository mirrors the Spec of the ECR Repository custom resource
+type testRepository struct {
+	Spec struct {
+		ImageScanningConfiguration *struct {
+			ScanOnPush *bool
+		}
+		RepositoryName *string
+	}
+}
+
+func TestValidateImmutableFields_ECR_Repository_Changes(t *testing.T) {
+	g := testutil.NewModelForServiceWithOptions(t, "ecr", &testutil.TestingModelOptions{
+		GeneratorConfigFile: "generator-with-immutable-fields.yaml",
+	})
+
+	crd := testutil.GetCRDByName(t, g, "Repository")
+	require.NotNil(t, crd)
+
+	goCode := code.ValidateImmutableFields(crd, "old", "r", "allErrs", 1)
+
+	name := func(v string) *string { return &v }
+	scanOnPush := func(v bool) *struct{ ScanOnPush *bool } {
+		return &struct{ ScanOnPush *bool }{ScanOnPush: &v}
+	}
+	newRepository := func(
+		scanningConfig *struct{ ScanOnPush *bool },
+		repositoryName *string,
+	) *testRepository {
+		r := &testRepository{}
+		r.Spec.ImageScanningConfiguration = scanningConfig
+		r.Spec.RepositoryName = repositoryName
+		return r
+	}
+
+	testCases := []struct {
+		name          string
+		old           *testRepository
+		new           *testRepository
+		expectedPaths []string
+	}{
+		{
+			"unchanged",
+			newRepository(scanOnPush(true), name("repo")),
+			newRepository(scanOnPush(true), name("repo")),
+			nil,
+		},
+		{
+			"unset to set",
+			newRepository(&struct{ ScanOnPush *bool }{}, nil),
+			newRepository(scanOnPush(true), name("repo")),
+			nil,
+		},
+		{
+			"nil parent to non-nil parent",
+			newRepository(nil, name("repo")),
+			newRepository(scanOnPush(true), name("repo")),
+			nil,
+		},
+		{
+			"set to another value",
+			newRepository(scanOnPush(true), name("repo")),
+			newRepository(scanOnPush(false), name("other-repo")),
+			[]string{"spec.imageScanningConfiguration.scanOnPush", "spec.repositoryName"},
+		},
+		{
+			"set to unset",
+			newRepository(scanOnPush(true), name("repo")),
+			newRepository(&struct{ ScanOnPush *bool }{}, nil),
+			[]string{"spec.imageScanningConfiguration.scanOnPush", "spec.repositoryName"},
+		},
+		{
+			"non-nil parent to nil parent",
+			newRepository(scanOnPush(true), name("repo")),
+			newRepository(nil, name("repo")),
+			[]string{"spec.imageScanningConfiguration.scanOnPush"},
+		},
+	}
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(
+				t, tt.expectedPaths,
+				forbiddenPaths(t, goCode, map[string]interface{}{"old": tt.old, "r": tt.new}),
+			)
+		})
+	}
+}
+
+// forbiddenPaths returns the paths of the fields the Go code generated by
+// ValidateImmutableFields forbids changing, by evaluating its conditions with
+// the supplied variables
+func forbiddenPaths(
+	t *testing.T,
+	goCode string,
+	vars map[string]interface{},
+) []string {
+	f, err := parser.ParseFile(
+		token.NewFileSet(), "", "package p\nfunc f() {\n"+goCode+"}\n", 0,
+	)
+	require.Nil(t, err)
+	var paths []string
+	for _, stmt := range f.Decls[0].(*ast.FuncDecl).Body.List {
+		ifStmt := stmt.(*ast.IfStmt)
+		if !evalExpr(t, ifStmt.Cond, vars).(bool) {
+			continue
+		}
+		// allErrs = append(allErrs, field.Forbidden(field.NewPath(...), ...))
+		appendCall := ifStmt.Body.List[0].(*ast.AssignStmt).Rhs[0].(*ast.CallExpr)
+		forbiddenCall := appendCall.Args[1].(*ast.CallExpr)
+		pathParts := []string{}
+		for _, arg := range forbiddenCall.Args[0].(*ast.CallExpr).Args {
+			part, err := strconv.Unquote(arg.(*ast.BasicLit).Value)
+			require.Nil(t, err)
+			pathParts = append(pathParts, part)
+		}
+		paths = append(paths, strings.Join(pathParts, "."))
+	}
+	return paths
+}
+
+// evalExpr evaluates the subset of Go expressions used by the conditions of
+// the Go code generated by ValidateImmutableFields. It fails the test when a
+// nil pointer would be dereferenced.
+func evalExpr(
+	t *testing.T,
+	expr ast.Expr,
+	vars map[string]interface{},
+) interface{} {
+	switch e := expr.(type) {
+	case *ast.ParenExpr:
+		return evalExpr(t, e.X, vars)
+	case *ast.UnaryExpr:
+		require.Equal(t, token.NOT, e.Op)
+		return !evalExpr(t, e.X, vars).(bool)
+	case *ast.BinaryExpr:
+		switch e.Op {
+		case token.LAND:
+			return evalExpr(t, e.X, vars).(bool) && evalExpr(t, e.Y, vars).(bool)
+		case token.LOR:
+			return evalExpr(t, e.X, vars).(bool) || evalExpr(t, e.Y, vars).(bool)
+		case token.EQL, token.NEQ:
+			require.Equal(t, "nil", e.Y.(*ast.Ident).Name)
+			isNil := evalExpr(t, e.X, vars).(reflect.Value).IsNil()
+			return isNil == (e.Op == token.EQL)
+		case token.GTR:
+			return evalExpr(t, e.X, vars).(int) > evalExpr(t, e.Y, vars).(int)
+		}
+	case *ast.BasicLit:
+		v, err := strconv.Atoi(e.Value)
+		require.Nil(t, err)
+		return v
+	case *ast.Ident:
+		v, found := vars[e.Name]
+		require.True(t, found, "unknown variable %s", e.Name)
+		return reflect.ValueOf(v)
+	case *ast.SelectorExpr:
+		v := evalExpr(t, e.X, vars).(reflect.Value)
+		if v.Kind() == reflect.Ptr {
+			require.False(t, v.IsNil(), "nil pointer dereference selecting %s", e.Sel.Name)
+			v = v.Elem()
+		}
+		return v.FieldByName(e.Sel.Name)
+	case *ast.CallExpr:
+		args := []reflect.Value{}
+		for _, arg := range e.Args {
+			args = append(args, evalExpr(t, arg, vars).(reflect.Value))
+		}
+		if ident, ok := e.Fun.(*ast.Ident); ok && ident.Name == "len" {
+			return args[0].Len()
+		}
+		require.Equal(t, "DeepEqual", e.Fun.(*ast.SelectorExpr).Sel.Name)
+		return reflect.DeepEqual(args[0].Interface(), args[1].Interface())
+	}
+	require.FailNow(t, "unsupported expression", "%T", expr)
+	return nil
+}
+
+func TestDeprecationWarnings_ECR_Repository(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	g := testutil.NewModelForServiceWithOptions(t, "ecr", &testutil.TestingModelOptions{
+		GeneratorConfigFile: "generator-with-deprecated-fields.yaml",
+	})
+
+	crd := testutil.GetCRDByName(t, g, "Repository")
+	require.NotNil(crd)
+
+	expected := `
+	if r.Spec.ImageScanningConfiguration != nil && r.Spec.ImageScanningConfiguration.ScanOnPush != nil {
+		warnings = append(warnings, "spec.imageScanningConfiguration.scanOnPush is deprecated")
+	}
+	if r.Spec.ImageTagMutability != nil {
+		warnings = append(warnings, "spec.imageTagMutability is deprecated: Tag mutability is now set by the registry policy. Use spec.registryPolicy instead.")
+	}
+	if len(r.Spec.Tags) > 0 {
+		warnings = append(warnings, "spec.tags is deprecated: Tags are synced by the TagSet resource.")
+	}
+`
+	assert.Equal(
+		expected,
+		"\n"+code.DeprecationWarnings(crd, "r", "warnings", 1),
+	)
+	assert.Contains(
+		crd.SpecFields["ImageTagMutability"].Documentation(),
+		"// Deprecated: Tag mutability is now set by the registry policy. Use spec.registryPolicy instead.",
+	)
+}
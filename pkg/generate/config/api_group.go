@@ -0,0 +1,59 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package config
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	k8svalidation "k8s.io/apimachinery/pkg/util/validation"
+)
+
+// validateAPIGroups returns an error if the API group of a resource isn't a
+// DNS subdomain
+func (c *Config) validateAPIGroups() error {
+	resNames := []string{}
+	for resName := range c.Resources {
+		resNames = append(resNames, resName)
+	}
+	sort.Strings(resNames)
+	for _, resName := range resNames {
+		group := c.Resources[resName].APIGroup
+		if group == "" {
+			continue
+		}
+		if errs := k8svalidation.IsDNS1123Subdomain(group); len(errs) > 0 {
+			return fmt.Errorf(
+				"%s: invalid api_group %q: %s",
+				resName, group, strings.Join(errs, "; "),
+			)
+		}
+	}
+	return nil
+}
+
+// ResourceAPIGroup returns the API group the given resource is placed into,
+// as configured, or an empty string if the resource is in the API group of
+// the service
+func (c *Config) ResourceAPIGroup(resourceName string) string {
+	if c == nil {
+		return ""
+	}
+	rConfig, ok := c.Resources[resourceName]
+	if !ok {
+		return ""
+	}
+	return rConfig.APIGroup
+}
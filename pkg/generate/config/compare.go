@@ -0,0 +1,117 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package config
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+const (
+	// CompareNormalizeWhitespace compares string values regardless of their
+	// leading, trailing and repeated whitespace
+	CompareNormalizeWhitespace = "whitespace"
+	// CompareNormalizeJSON compares string values as JSON documents,
+	// regardless of their formatting and of the order of their object keys
+	CompareNormalizeJSON = "json"
+)
+
+// validCompareNormalize returns an error if the supplied compare
+// normalization is unknown
+func validCompareNormalize(normalize string) error {
+	switch normalize {
+	case "", CompareNormalizeWhitespace, CompareNormalizeJSON:
+		return nil
+	}
+	return fmt.Errorf(
+		"unknown compare normalize %q, must be %q or %q", normalize,
+		CompareNormalizeWhitespace, CompareNormalizeJSON,
+	)
+}
+
+// validateCompares returns an error if a field's compare config has an
+// unknown normalization, a negative tolerance, more than one of
+// is_case_insensitive, normalize and tolerance, or a tolerance on a field
+// whose number format isn't a Go number
+func (c *Config) validateCompares() error {
+	resNames := []string{}
+	for resName := range c.Resources {
+		resNames = append(resNames, resName)
+	}
+	sort.Strings(resNames)
+	for _, resName := range resNames {
+		fields := c.Resources[resName].Fields
+		fieldNames := []string{}
+		for fieldName := range fields {
+			fieldNames = append(fieldNames, fieldName)
+		}
+		sort.Strings(fieldNames)
+		for _, fieldName := range fieldNames {
+			fConfig := fields[fieldName]
+			if fConfig == nil || fConfig.Compare == nil {
+				continue
+			}
+			compare := fConfig.Compare
+			if err := validCompareNormalize(compare.Normalize); err != nil {
+				return fmt.Errorf("%s.%s: %v", resName, fieldName, err)
+			}
+			set := []string{}
+			if compare.IsCaseInsensitive {
+				set = append(set, "is_case_insensitive")
+			}
+			if compare.Normalize != "" {
+				set = append(set, "normalize")
+			}
+			if compare.Tolerance != nil {
+				set = append(set, "tolerance")
+			}
+			if len(set) > 1 {
+				return fmt.Errorf(
+					"%s.%s: only one of compare %s can be set",
+					resName, fieldName, strings.Join(set, ", "),
+				)
+			}
+			if compare.Tolerance == nil {
+				continue
+			}
+			if *compare.Tolerance < 0 {
+				return fmt.Errorf(
+					"%s.%s: compare tolerance must not be negative",
+					resName, fieldName,
+				)
+			}
+			if fConfig.NumberFormat == NumberFormatQuantity ||
+				fConfig.NumberFormat == NumberFormatString {
+				return fmt.Errorf(
+					"%s.%s: compare tolerance is not supported with number_format %q",
+					resName, fieldName, fConfig.NumberFormat,
+				)
+			}
+		}
+	}
+	return nil
+}
+
+// CompareNilEqualsEmpty returns true if a nil and an empty value of a list or
+// map field having the supplied compare config are considered equal
+func (c *Config) CompareNilEqualsEmpty(compareConfig *CompareFieldConfig) bool {
+	if compareConfig != nil && compareConfig.NilEqualsEmpty != nil {
+		return *compareConfig.NilEqualsEmpty
+	}
+	if c == nil {
+		return false
+	}
+	return c.NilEqualsEmpty
+}
@@ -14,11 +14,15 @@
 package config
 
 import (
-	"io/ioutil"
+	"fmt"
 
 	"github.com/ghodss/yaml"
+
+	ackgenlog "github.com/aws-controllers-k8s/code-generator/pkg/log"
 )
 
+var log = ackgenlog.Log().WithName("config")
+
 // Config represents instructions to the ACK code generator for a particular
 // AWS service API
 type Config struct {
@@ -45,6 +49,71 @@ type Config struct {
 	// model name is `opensearch` and the service package is called
 	// `opensearchservice`.
 	ModelName string `json:"model_name,omitempty"`
+	// Plugins contains the external generators to run after the code
+	// generator's own templates have been rendered, so that service-specific
+	// files can be generated without resorting to inline hooks.
+	Plugins []PluginConfig `json:"plugins,omitempty"`
+	// Includes lists generator config files, relative to the directory of
+	// this file, whose contents are deep-merged into this one. Values in this
+	// file take precedence over included ones.
+	Includes []string `json:"includes,omitempty"`
+	// EnumTypes lets you specify whether fields of enum shapes should use the
+	// string type generated for the enum, which lists the enum's values as
+	// constants, instead of *string. Default is false.
+	EnumTypes bool `json:"enum_types,omitempty"`
+	// Docstrings instructs the code generator how to clean up the AWS API
+	// model's documentation. By default the documentation is left as is.
+	Docstrings *DocstringConfig `json:"docstrings,omitempty"`
+	// TimestampFormat lets you specify how timestamps are represented in the
+	// CRDs: "time" (metav1.Time, the default), "rfc3339" (a string in the RFC
+	// 3339 format) or "epoch" (the number of seconds since the Unix epoch).
+	TimestampFormat string `json:"timestamp_format,omitempty"`
+	// TagFormat lets you normalize how the top-level Tags fields of the
+	// resources are represented, whatever the shape of the tags in the AWS
+	// API model: "map" (a map[string]*string keyed by the tag key) or "list"
+	// (a list of Tag structs with a Key and a Value). By default the tags are
+	// represented like the AWS API model does.
+	TagFormat string `json:"tag_format,omitempty"`
+	// SyncTags instructs the code generator to output the code keeping the
+	// tags of the resources having a top-level Tags field in sync with the
+	// AWS API, using the API's TagResource, UntagResource and
+	// ListTagsForResource operations. Default is false.
+	SyncTags bool `json:"sync_tags,omitempty"`
+	// NilEqualsEmpty instructs the code generator to consider nil and empty
+	// list and map fields equal when comparing resources, for the APIs
+	// returning empty collections for the fields that were not set. Fields
+	// may override it with their compare config. Default is false.
+	NilEqualsEmpty bool `json:"nil_equals_empty,omitempty"`
+	// RateLimit limits the rate of all the calls the controller makes to the
+	// AWS service API, see RateLimitConfig. By default the calls aren't rate
+	// limited.
+	RateLimit *RateLimitConfig `json:"rate_limit,omitempty"`
+	// Retry instructs the code generator how the calls to the AWS service
+	// API are retried, see RetryConfig. By default they are retried like the
+	// AWS SDK does.
+	Retry *RetryConfig `json:"retry,omitempty"`
+	// Endpoint gives the defaults of the flags determining whether the
+	// controller calls the FIPS and dual-stack endpoints of the AWS service
+	// API, and whether the endpoint URL of the resources may be overridden,
+	// see EndpointConfig. By default it calls the standard endpoints.
+	Endpoint *EndpointConfig `json:"endpoint,omitempty"`
+	// UserAgent adds metadata to the User-Agent of the calls to the AWS
+	// service API, see UserAgentConfig
+	UserAgent *UserAgentConfig `json:"user_agent,omitempty"`
+	// IAMActionPrefix is the prefix of the IAM actions of the AWS service
+	// API in the recommended IAM policy of the controller, e.g. "rds".
+	// Defaults to the signing name of the API.
+	IAMActionPrefix string `json:"iam_action_prefix,omitempty"`
+	// Conversion instructs the code generator how the resources are
+	// converted between the API versions of the service, see
+	// ConversionConfig
+	Conversion *ConversionConfig `json:"conversion,omitempty"`
+	// CRDMetadata are the labels and annotations stamped onto all the CRDs
+	// of the API, see CRDMetadataConfig
+	CRDMetadata *CRDMetadataConfig `json:"crd_metadata,omitempty"`
+	// ModelOverrides are the edits of the AWS service API model applied
+	// before the code generator loads it, see ModelOverrideConfig
+	ModelOverrides []ModelOverrideConfig `json:"model_overrides,omitempty"`
 }
 
 // IgnoreSpec represents instructions to the ACK code generator to
@@ -57,11 +126,15 @@ type IgnoreSpec struct {
 	// generator
 	ResourceNames []string `json:"resource_names"`
 	// Set of shapes to ignore when constructing API type definitions and
-	// associated SDK code for structs that have these shapes as members
+	// associated SDK code for structs that have these shapes as members. The
+	// shape names may be wildcard patterns, e.g. "*Internal*".
 	ShapeNames []string `json:"shape_names"`
 	// Set of field paths to ignore. The name here should be the original name of
 	// the field as it appears in AWS SDK objects. You can refer to a field by
 	// giving its "<shape_name>.<field_name>". For example, "CreateApiInput.Name".
+	// Nested fields are referred to by further dot-separated member names,
+	// skipping over list and map elements, and any element of the path may be
+	// a wildcard pattern. For example, "CreateApiInput.Rules.*.Internal*".
 	FieldPaths []string `json:"field_paths"`
 }
 
@@ -119,6 +192,19 @@ func (c *Config) ResourceContainsSecret() bool {
 	return false
 }
 
+// ResourceContainsReferences returns true if any of the fields in any
+// resource may be read from other Kubernetes objects
+func (c *Config) ResourceContainsReferences() bool {
+	for _, resource := range c.Resources {
+		for _, field := range resource.Fields {
+			if field != nil && field.References != nil {
+				return true
+			}
+		}
+	}
+	return false
+}
+
 // New returns a new Config object given a supplied
 // path to a config file
 func New(
@@ -128,7 +214,7 @@ func New(
 	if configPath == "" {
 		return defaultConfig, nil
 	}
-	content, err := ioutil.ReadFile(configPath)
+	content, err := loadConfigContent(configPath)
 	if err != nil {
 		return Config{}, err
 	}
@@ -136,5 +222,94 @@ func New(
 	if err = yaml.Unmarshal(content, &gc); err != nil {
 		return Config{}, err
 	}
+	if gc.Docstrings != nil {
+		if err = gc.Docstrings.validate(); err != nil {
+			return Config{}, fmt.Errorf("%s: %v", configPath, err)
+		}
+	}
+	if err = gc.validateFieldFormats(); err != nil {
+		return Config{}, fmt.Errorf("%s: %v", configPath, err)
+	}
+	if err = validTagFormat(gc.TagFormat); err != nil {
+		return Config{}, fmt.Errorf("%s: %v", configPath, err)
+	}
+	if err = gc.validateRenames(); err != nil {
+		return Config{}, fmt.Errorf("%s: %v", configPath, err)
+	}
+	if err = gc.validateAttributeKeys(); err != nil {
+		return Config{}, fmt.Errorf("%s: %v", configPath, err)
+	}
+	if err = gc.validateFieldChanges(); err != nil {
+		return Config{}, fmt.Errorf("%s: %v", configPath, err)
+	}
+	if err = gc.validateCompares(); err != nil {
+		return Config{}, fmt.Errorf("%s: %v", configPath, err)
+	}
+	if err = gc.validateReferences(); err != nil {
+		return Config{}, fmt.Errorf("%s: %v", configPath, err)
+	}
+	if err = gc.validateExports(); err != nil {
+		return Config{}, fmt.Errorf("%s: %v", configPath, err)
+	}
+	if err = gc.validateModelOverrides(); err != nil {
+		return Config{}, fmt.Errorf("%s: %v", configPath, err)
+	}
+	if err = gc.validateAPIGroups(); err != nil {
+		return Config{}, fmt.Errorf("%s: %v", configPath, err)
+	}
+	if err = gc.validateCRDMetadata(); err != nil {
+		return Config{}, fmt.Errorf("%s: %v", configPath, err)
+	}
+	if err = gc.validatePrintColumns(); err != nil {
+		return Config{}, fmt.Errorf("%s: %v", configPath, err)
+	}
+	if err = gc.validateKubectlNames(); err != nil {
+		return Config{}, fmt.Errorf("%s: %v", configPath, err)
+	}
+	if err = gc.validateScopes(); err != nil {
+		return Config{}, fmt.Errorf("%s: %v", configPath, err)
+	}
+	if err = gc.validateScales(); err != nil {
+		return Config{}, fmt.Errorf("%s: %v", configPath, err)
+	}
+	if err = gc.validateStatuses(); err != nil {
+		return Config{}, fmt.Errorf("%s: %v", configPath, err)
+	}
+	if err = gc.validateSensitiveFields(); err != nil {
+		return Config{}, fmt.Errorf("%s: %v", configPath, err)
+	}
+	if err = gc.validateTerminalMessages(); err != nil {
+		return Config{}, fmt.Errorf("%s: %v", configPath, err)
+	}
+	if err = gc.validateErrorRequeues(); err != nil {
+		return Config{}, fmt.Errorf("%s: %v", configPath, err)
+	}
+	if err = gc.validateResyncs(); err != nil {
+		return Config{}, fmt.Errorf("%s: %v", configPath, err)
+	}
+	if err = gc.validateRateLimits(); err != nil {
+		return Config{}, fmt.Errorf("%s: %v", configPath, err)
+	}
+	if err = gc.validateRetries(); err != nil {
+		return Config{}, fmt.Errorf("%s: %v", configPath, err)
+	}
+	if err = gc.validateEndpoints(); err != nil {
+		return Config{}, fmt.Errorf("%s: %v", configPath, err)
+	}
+	if gc.UserAgent != nil {
+		if err = gc.UserAgent.validate(); err != nil {
+			return Config{}, fmt.Errorf("%s: %v", configPath, err)
+		}
+	}
+	if err = gc.validateIAMPolicies(); err != nil {
+		return Config{}, fmt.Errorf("%s: %v", configPath, err)
+	}
+	if err = gc.validateConversions(); err != nil {
+		return Config{}, fmt.Errorf("%s: %v", configPath, err)
+	}
+	if err = gc.applyResourceSplits(); err != nil {
+		return Config{}, fmt.Errorf("%s: %v", configPath, err)
+	}
+	log.V(1).Info("loaded generator config", "path", configPath)
 	return gc, nil
 }
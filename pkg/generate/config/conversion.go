@@ -0,0 +1,129 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package config
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+)
+
+// apiVersionRegexp matches the Kubernetes API versions, e.g. v1alpha1 or v1
+var apiVersionRegexp = regexp.MustCompile(`^v[1-9][0-9]*((alpha|beta)[1-9][0-9]*)?$`)
+
+// ConversionConfig instructs the code generator how the resources are
+// converted between the API versions of the service, when it has several.
+// The resources of every API version are converted to and from the hub API
+// version, which is also the version they are stored in.
+//
+// For example, the following generator.yaml:
+//
+//	conversion:
+//	  hub_version: v1alpha1
+//
+// Keeps storing the resources in v1alpha1 when generating v1beta1.
+type ConversionConfig struct {
+	// HubVersion is the API version the resources are converted to and from
+	// and stored in. Defaults to the API version being generated.
+	HubVersion string `json:"hub_version,omitempty"`
+}
+
+// ResourceConversionConfig instructs the code generator how the fields of a
+// resource are converted between the API versions of the service, on top of
+// the field renames of their generator configs.
+//
+// For example, the following generator.yaml:
+//
+//	resources:
+//	  DBInstance:
+//	    conversion:
+//	      field_mappings:
+//	        DBSecurityGroups: SecurityGroupNames
+//
+// Converts the DBSecurityGroups field of the other API versions to the
+// SecurityGroupNames field of this one, and back.
+type ResourceConversionConfig struct {
+	// FieldMappings maps the names of the Spec and Status fields of the other
+	// API versions to the names of the fields of this API version they are
+	// converted to and from
+	FieldMappings map[string]string `json:"field_mappings,omitempty"`
+}
+
+// validateConversions returns an error if the hub API version isn't a
+// Kubernetes API version, or if several fields of a resource are mapped to
+// the same field
+func (c *Config) validateConversions() error {
+	if c.Conversion != nil && c.Conversion.HubVersion != "" &&
+		!apiVersionRegexp.MatchString(c.Conversion.HubVersion) {
+		return fmt.Errorf(
+			"conversion hub_version %q must look like v1alpha1 or v1",
+			c.Conversion.HubVersion,
+		)
+	}
+	resNames := []string{}
+	for resName := range c.Resources {
+		resNames = append(resNames, resName)
+	}
+	sort.Strings(resNames)
+	for _, resName := range resNames {
+		mappings := c.ResourceConversionFieldMappings(resName)
+		otherNames := []string{}
+		for otherName := range mappings {
+			otherNames = append(otherNames, otherName)
+		}
+		sort.Strings(otherNames)
+		mappedFrom := map[string]string{}
+		for _, otherName := range otherNames {
+			fieldName := mappings[otherName]
+			if otherName == "" || fieldName == "" || otherName == fieldName {
+				return fmt.Errorf(
+					"%s: conversion field mapping %q: %q must map a field to another one",
+					resName, otherName, fieldName,
+				)
+			}
+			if previous, found := mappedFrom[fieldName]; found {
+				return fmt.Errorf(
+					"%s: conversion field mappings %s and %s both map to %s",
+					resName, previous, otherName, fieldName,
+				)
+			}
+			mappedFrom[fieldName] = otherName
+		}
+	}
+	return nil
+}
+
+// GetHubVersion returns the API version the resources are converted to and
+// from and stored in, or the supplied API version being generated if it isn't
+// specified
+func (c *Config) GetHubVersion(apiVersion string) string {
+	if c != nil && c.Conversion != nil && c.Conversion.HubVersion != "" {
+		return c.Conversion.HubVersion
+	}
+	return apiVersion
+}
+
+// ResourceConversionFieldMappings returns the names of the fields of the
+// supplied resource in the other API versions mapped to the names of its
+// fields in this API version, if specified in generator config
+func (c *Config) ResourceConversionFieldMappings(resourceName string) map[string]string {
+	if c == nil {
+		return nil
+	}
+	rConfig, found := c.Resources[resourceName]
+	if !found || rConfig.Conversion == nil {
+		return nil
+	}
+	return rConfig.Conversion.FieldMappings
+}
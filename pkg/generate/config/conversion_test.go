@@ -0,0 +1,92 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package config_test
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/aws-controllers-k8s/code-generator/pkg/generate/config"
+)
+
+func TestConversions(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	dir := writeConfigFiles(t, map[string]string{
+		"generator.yaml": `
+conversion:
+  hub_version: v1alpha1
+resources:
+  DBInstance:
+    conversion:
+      field_mappings:
+        DBSecurityGroups: SecurityGroupNames
+`,
+	})
+	cfg, err := config.New(filepath.Join(dir, "generator.yaml"), config.Config{})
+	require.Nil(err)
+	assert.Equal("v1alpha1", cfg.GetHubVersion("v1beta1"))
+	assert.Equal(
+		map[string]string{"DBSecurityGroups": "SecurityGroupNames"},
+		cfg.ResourceConversionFieldMappings("DBInstance"),
+	)
+	assert.Nil(cfg.ResourceConversionFieldMappings("DBCluster"))
+
+	cfg, err = config.New("", config.Config{})
+	require.Nil(err)
+	assert.Equal("v1beta1", cfg.GetHubVersion("v1beta1"))
+}
+
+func TestConversions_Invalid(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		wantErr string
+	}{
+		{
+			name: "invalid hub version",
+			content: `
+conversion:
+  hub_version: beta1
+`,
+			wantErr: `conversion hub_version "beta1"`,
+		},
+		{
+			name: "fields mapped to the same field",
+			content: `
+resources:
+  DBInstance:
+    conversion:
+      field_mappings:
+        DBSecurityGroups: SecurityGroupNames
+        SecurityGroups: SecurityGroupNames
+`,
+			wantErr: "DBInstance: conversion field mappings DBSecurityGroups and SecurityGroups both map to SecurityGroupNames",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require := require.New(t)
+
+			dir := writeConfigFiles(t, map[string]string{"generator.yaml": tt.content})
+			_, err := config.New(filepath.Join(dir, "generator.yaml"), config.Config{})
+			require.NotNil(err)
+			require.Contains(err.Error(), tt.wantErr)
+		})
+	}
+}
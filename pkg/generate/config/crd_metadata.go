@@ -0,0 +1,114 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package config
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	k8svalidation "k8s.io/apimachinery/pkg/util/validation"
+)
+
+// CRDMetadataConfig instructs the code generator to stamp labels and
+// annotations onto the generated CRD manifests, e.g. to record the team
+// owning the CRDs or hints for the operator bundles.
+//
+// For example, the following generator.yaml:
+//
+//	crd_metadata:
+//	  labels:
+//	    app.kubernetes.io/part-of: ack
+//	resources:
+//	  Repository:
+//	    crd_metadata:
+//	      annotations:
+//	        operators.operatorframework.io/internal-objects: "false"
+//
+// Labels all the CRDs of the API with `app.kubernetes.io/part-of: ack`, and
+// annotates the Repository CRD.
+type CRDMetadataConfig struct {
+	// Labels are the labels of the CRDs, keyed by their key
+	Labels map[string]string `json:"labels,omitempty"`
+	// Annotations are the annotations of the CRDs, keyed by their key
+	Annotations map[string]string `json:"annotations,omitempty"`
+}
+
+// validate returns an error if a label or annotation key isn't a qualified
+// name, or if a label value isn't a valid label value
+func (m *CRDMetadataConfig) validate() error {
+	if m == nil {
+		return nil
+	}
+	for key, value := range m.Labels {
+		if errs := k8svalidation.IsQualifiedName(key); len(errs) > 0 {
+			return fmt.Errorf("invalid CRD label key %q: %s", key, strings.Join(errs, "; "))
+		}
+		if errs := k8svalidation.IsValidLabelValue(value); len(errs) > 0 {
+			return fmt.Errorf("invalid CRD label %s value %q: %s", key, value, strings.Join(errs, "; "))
+		}
+	}
+	for key := range m.Annotations {
+		if errs := k8svalidation.IsQualifiedName(key); len(errs) > 0 {
+			return fmt.Errorf("invalid CRD annotation key %q: %s", key, strings.Join(errs, "; "))
+		}
+	}
+	return nil
+}
+
+// validateCRDMetadata returns an error if the labels or annotations of the
+// CRDs of the API or of a resource are invalid
+func (c *Config) validateCRDMetadata() error {
+	if err := c.CRDMetadata.validate(); err != nil {
+		return err
+	}
+	resNames := []string{}
+	for resName := range c.Resources {
+		resNames = append(resNames, resName)
+	}
+	sort.Strings(resNames)
+	for _, resName := range resNames {
+		if err := c.Resources[resName].CRDMetadata.validate(); err != nil {
+			return fmt.Errorf("%s: %v", resName, err)
+		}
+	}
+	return nil
+}
+
+// ResourceCRDMetadata returns the labels and annotations of the given
+// resource's CRD, those of the resource overriding those of all the CRDs of
+// the API
+func (c *Config) ResourceCRDMetadata(resourceName string) (map[string]string, map[string]string) {
+	labels := map[string]string{}
+	annotations := map[string]string{}
+	if c == nil {
+		return labels, annotations
+	}
+	metadatas := []*CRDMetadataConfig{c.CRDMetadata}
+	if rConfig, ok := c.Resources[resourceName]; ok {
+		metadatas = append(metadatas, rConfig.CRDMetadata)
+	}
+	for _, metadata := range metadatas {
+		if metadata == nil {
+			continue
+		}
+		for key, value := range metadata.Labels {
+			labels[key] = value
+		}
+		for key, value := range metadata.Annotations {
+			annotations[key] = value
+		}
+	}
+	return labels, annotations
+}
@@ -0,0 +1,38 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package config
+
+// DeprecatedFieldConfig marks a Spec field deprecated. The field keeps
+// working, but its documentation says it is deprecated, the admission webhook
+// warns the users setting it and it is left out of the sample resources.
+//
+// For example, the following generator.yaml:
+//
+//	resources:
+//	  DBInstance:
+//	    fields:
+//	      DBSecurityGroups:
+//	        deprecated:
+//	          message: DB security groups are only supported by EC2-Classic.
+//	          replaced_by: VPCSecurityGroupIDs
+//
+// Warns the users setting the DBInstance's Spec.DBSecurityGroups that they
+// should set Spec.VPCSecurityGroupIDs instead.
+type DeprecatedFieldConfig struct {
+	// Message explains why the field is deprecated
+	Message string `json:"message,omitempty"`
+	// ReplacedBy is the path of the Spec field to set instead of the
+	// deprecated one, if any
+	ReplacedBy string `json:"replaced_by,omitempty"`
+}
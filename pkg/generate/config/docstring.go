@@ -0,0 +1,84 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package config
+
+import "fmt"
+
+const (
+	// DocstringFormatText converts the HTML of AWS API documentation to
+	// plain text
+	DocstringFormatText = "text"
+	// DocstringFormatMarkdown converts the HTML of AWS API documentation to
+	// Markdown
+	DocstringFormatMarkdown = "markdown"
+)
+
+// DocstringConfig instructs the code generator how to clean up the AWS API
+// model's documentation before it is placed in the comments of the generated
+// Go types, and from there in the descriptions of the CRD schema that
+// `kubectl explain` displays.
+//
+// For example, the following generator.yaml:
+//
+//	docstrings:
+//	  format: markdown
+//	  max_length: 1000
+//	  link_rewrites:
+//	    - from: /AmazonECR/latest/
+//	      to: https://docs.aws.amazon.com/AmazonECR/latest/
+//	  api_reference_links: true
+//
+// Converts the documentation's HTML to Markdown, makes the relative links to
+// the ECR user guide absolute, truncates documentation longer than 1000
+// characters and links each field to its AWS API reference page.
+type DocstringConfig struct {
+	// Format is the format the documentation's HTML is converted to, either
+	// "text" or "markdown". When empty, the documentation is left as is.
+	Format string `json:"format,omitempty"`
+	// LinkRewrites contains the replacements to make to the URLs in the
+	// documentation, applied in order
+	LinkRewrites []LinkRewriteConfig `json:"link_rewrites,omitempty"`
+	// MaxLength is the number of characters longer documentation is
+	// truncated to. Zero means no limit.
+	MaxLength int `json:"max_length,omitempty"`
+	// APIReferenceLinks, when true, appends a link to the AWS API reference
+	// page of the operation or data type a field belongs to to the field's
+	// documentation
+	APIReferenceLinks bool `json:"api_reference_links,omitempty"`
+}
+
+// LinkRewriteConfig replaces a string in the URLs of the documentation
+type LinkRewriteConfig struct {
+	// From is the string to replace
+	From string `json:"from"`
+	// To is the string to replace it with
+	To string `json:"to"`
+}
+
+// validate returns an error if the docstring config has an unknown format or
+// a negative maximum length
+func (c *DocstringConfig) validate() error {
+	switch c.Format {
+	case "", DocstringFormatText, DocstringFormatMarkdown:
+	default:
+		return fmt.Errorf(
+			"unknown docstrings format %q, must be %q or %q",
+			c.Format, DocstringFormatText, DocstringFormatMarkdown,
+		)
+	}
+	if c.MaxLength < 0 {
+		return fmt.Errorf("docstrings max_length must not be negative")
+	}
+	return nil
+}
@@ -0,0 +1,229 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package config
+
+import (
+	"fmt"
+	"sort"
+)
+
+const (
+	// EndpointURLFromAnnotation overrides the endpoint URL of a resource with
+	// the value of its `<API group>/endpoint-url` annotation
+	EndpointURLFromAnnotation = "annotation"
+	// EndpointURLFromSpecField overrides the endpoint URL of a resource with
+	// the value of the `EndpointURL` field added to its Spec
+	EndpointURLFromSpecField = "spec_field"
+)
+
+// EndpointConfig instructs the code generator which variant of the AWS
+// service API's endpoints the controller calls. It may be set at the top
+// level of the generator config, giving the defaults of the controller's
+// --use-fips-endpoint and --use-dual-stack-endpoint flags, and in a
+// resource's config to override the flags for the resources of this kind,
+// e.g. when the operations of the resource have no FIPS endpoint.
+//
+// For example, the following generator.yaml:
+//
+//	endpoint:
+//	  dual_stack: true
+//	resources:
+//	  DBProxy:
+//	    endpoint:
+//	      fips: false
+//
+// Calls the dual-stack endpoints unless the controller is started with
+// --use-dual-stack-endpoint=false, and never calls the FIPS endpoints for
+// the DBProxy resources, even if the controller is started with
+// --use-fips-endpoint.
+//
+// The endpoint URL of each resource may also be overridden, e.g. to call
+// LocalStack or a private link endpoint, by setting URLFrom:
+//
+//	resources:
+//	  DBInstance:
+//	    endpoint:
+//	      url_from: annotation
+//
+// Sends the calls for a DBInstance annotated with
+// `rds.services.k8s.aws/endpoint-url: http://localstack:4566` to LocalStack.
+//
+// Likewise, RegionFrom lets each resource select the AWS region it is
+// managed in, rather than the region of its namespace, from its
+// `<API group>/region` annotation or from the `Region` field added to its
+// Spec.
+type EndpointConfig struct {
+	// FIPS determines whether the FIPS endpoints of the AWS service API are
+	// called
+	FIPS *bool `json:"fips,omitempty"`
+	// DualStack determines whether the dual-stack endpoints of the AWS
+	// service API, reachable over IPv4 and IPv6, are called
+	DualStack *bool `json:"dual_stack,omitempty"`
+	// URLFrom is where the URL overriding the endpoint of each resource is
+	// read from, "annotation" or "spec_field". When set at the top level of
+	// the generator config, it applies to all the resources that don't set
+	// it. By default the endpoint URL can't be overridden.
+	URLFrom string `json:"url_from,omitempty"`
+	// RegionFrom is where the AWS region each resource is managed in is read
+	// from, "annotation" or "spec_field". When set at the top level of the
+	// generator config, it applies to all the resources that don't set it.
+	// By default the resources are managed in the region of their namespace.
+	RegionFrom string `json:"region_from,omitempty"`
+}
+
+// validate returns an error if the endpoint URL or region is read from an
+// unknown place
+func (ec *EndpointConfig) validate() error {
+	if err := validateEndpointFrom("url_from", ec.URLFrom); err != nil {
+		return err
+	}
+	return validateEndpointFrom("region_from", ec.RegionFrom)
+}
+
+// validateEndpointFrom returns an error if the supplied endpoint option reads
+// its value from an unknown place
+func validateEndpointFrom(option string, from string) error {
+	switch from {
+	case "", EndpointURLFromAnnotation, EndpointURLFromSpecField:
+		return nil
+	}
+	return fmt.Errorf(
+		"unknown endpoint %s %q, must be %q or %q",
+		option, from, EndpointURLFromAnnotation, EndpointURLFromSpecField,
+	)
+}
+
+// validateEndpoints returns an error if the endpoint config of the service
+// API or of one of its resources is invalid
+func (c *Config) validateEndpoints() error {
+	if c.Endpoint != nil {
+		if err := c.Endpoint.validate(); err != nil {
+			return err
+		}
+	}
+	resNames := []string{}
+	for resName := range c.Resources {
+		resNames = append(resNames, resName)
+	}
+	sort.Strings(resNames)
+	for _, resName := range resNames {
+		endpoint := c.Resources[resName].Endpoint
+		if endpoint == nil {
+			continue
+		}
+		if err := endpoint.validate(); err != nil {
+			return fmt.Errorf("%s: %v", resName, err)
+		}
+	}
+	return nil
+}
+
+// OverridesFIPS returns true if the endpoint config determines whether the
+// FIPS endpoints are called
+func (ec *EndpointConfig) OverridesFIPS() bool {
+	return ec != nil && ec.FIPS != nil
+}
+
+// GetFIPS returns true if the FIPS endpoints are called
+func (ec *EndpointConfig) GetFIPS() bool {
+	return ec.OverridesFIPS() && *ec.FIPS
+}
+
+// OverridesDualStack returns true if the endpoint config determines whether
+// the dual-stack endpoints are called
+func (ec *EndpointConfig) OverridesDualStack() bool {
+	return ec != nil && ec.DualStack != nil
+}
+
+// GetDualStack returns true if the dual-stack endpoints are called
+func (ec *EndpointConfig) GetDualStack() bool {
+	return ec.OverridesDualStack() && *ec.DualStack
+}
+
+// ResourceEndpoint returns the config of the endpoints called for the given
+// resource, if any
+func (c *Config) ResourceEndpoint(resourceName string) *EndpointConfig {
+	if c == nil {
+		return nil
+	}
+	rConfig, ok := c.Resources[resourceName]
+	if !ok {
+		return nil
+	}
+	return rConfig.Endpoint
+}
+
+// ResourceEndpointURLFrom returns where the URL overriding the endpoint of
+// the given resource is read from, empty if it can't be overridden
+func (c *Config) ResourceEndpointURLFrom(resourceName string) string {
+	if c == nil {
+		return ""
+	}
+	if endpoint := c.ResourceEndpoint(resourceName); endpoint != nil && endpoint.URLFrom != "" {
+		return endpoint.URLFrom
+	}
+	if c.Endpoint == nil {
+		return ""
+	}
+	return c.Endpoint.URLFrom
+}
+
+// HasEndpointURLOverrides returns true if the endpoint URL of any resource
+// may be overridden
+func (c *Config) HasEndpointURLOverrides() bool {
+	if c == nil {
+		return false
+	}
+	if c.Endpoint != nil && c.Endpoint.URLFrom != "" {
+		return true
+	}
+	for _, resource := range c.Resources {
+		if resource.Endpoint != nil && resource.Endpoint.URLFrom != "" {
+			return true
+		}
+	}
+	return false
+}
+
+// ResourceRegionFrom returns where the AWS region the given resource is
+// managed in is read from, empty if it is the region of its namespace
+func (c *Config) ResourceRegionFrom(resourceName string) string {
+	if c == nil {
+		return ""
+	}
+	if endpoint := c.ResourceEndpoint(resourceName); endpoint != nil && endpoint.RegionFrom != "" {
+		return endpoint.RegionFrom
+	}
+	if c.Endpoint == nil {
+		return ""
+	}
+	return c.Endpoint.RegionFrom
+}
+
+// HasRegionOverrides returns true if any resource may select the AWS region
+// it is managed in
+func (c *Config) HasRegionOverrides() bool {
+	if c == nil {
+		return false
+	}
+	if c.Endpoint != nil && c.Endpoint.RegionFrom != "" {
+		return true
+	}
+	for _, resource := range c.Resources {
+		if resource.Endpoint != nil && resource.Endpoint.RegionFrom != "" {
+			return true
+		}
+	}
+	return false
+}
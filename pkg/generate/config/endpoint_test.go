@@ -0,0 +1,91 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package config_test
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/aws-controllers-k8s/code-generator/pkg/generate/config"
+)
+
+func TestEndpoints(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	dir := writeConfigFiles(t, map[string]string{
+		"generator.yaml": `
+endpoint:
+  dual_stack: true
+resources:
+  DBProxy:
+    endpoint:
+      fips: false
+  DBInstance:
+    is_upsert: false
+`,
+	})
+	cfg, err := config.New(filepath.Join(dir, "generator.yaml"), config.Config{})
+	require.Nil(err)
+
+	assert.False(cfg.Endpoint.OverridesFIPS())
+	assert.False(cfg.Endpoint.GetFIPS())
+	assert.True(cfg.Endpoint.OverridesDualStack())
+	assert.True(cfg.Endpoint.GetDualStack())
+
+	// A resource may opt out of the FIPS endpoints, whatever the flag says
+	proxyEndpoint := cfg.ResourceEndpoint("DBProxy")
+	require.NotNil(proxyEndpoint)
+	assert.True(proxyEndpoint.OverridesFIPS())
+	assert.False(proxyEndpoint.GetFIPS())
+	assert.False(proxyEndpoint.OverridesDualStack())
+
+	instanceEndpoint := cfg.ResourceEndpoint("DBInstance")
+	assert.Nil(instanceEndpoint)
+	assert.False(instanceEndpoint.OverridesFIPS())
+	assert.False(instanceEndpoint.OverridesDualStack())
+}
+
+func TestEndpoints_InvalidURLFrom(t *testing.T) {
+	require := require.New(t)
+
+	dir := writeConfigFiles(t, map[string]string{
+		"generator.yaml": `
+resources:
+  DBInstance:
+    endpoint:
+      url_from: label
+`,
+	})
+	_, err := config.New(filepath.Join(dir, "generator.yaml"), config.Config{})
+	require.NotNil(err)
+	require.Contains(err.Error(), `DBInstance: unknown endpoint url_from "label"`)
+}
+
+func TestEndpoints_InvalidRegionFrom(t *testing.T) {
+	require := require.New(t)
+
+	dir := writeConfigFiles(t, map[string]string{
+		"generator.yaml": `
+endpoint:
+  region_from: namespace
+`,
+	})
+	_, err := config.New(filepath.Join(dir, "generator.yaml"), config.Config{})
+	require.NotNil(err)
+	require.Contains(err.Error(), `unknown endpoint region_from "namespace"`)
+}
@@ -0,0 +1,115 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package config
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+const (
+	// ExportKindSecret exports the field values to a Secret
+	ExportKindSecret = "Secret"
+	// ExportKindConfigMap exports the field values to a ConfigMap
+	ExportKindConfigMap = "ConfigMap"
+)
+
+// ExportConfig instructs the code generator to write the values of some of
+// a resource's fields, such as connection endpoints or generated
+// credentials, to a Secret or ConfigMap in the namespace of the resource. An
+// `ExportTo` field naming the object is added to the Spec, and the object is
+// created, owned by the resource, or updated on every reconciliation.
+//
+// For example, the following generator.yaml:
+//
+//	resources:
+//	  DBInstance:
+//	    export:
+//	      fields:
+//	        host: Status.Endpoint.Address
+//	        port: Status.Endpoint.Port
+//	        username: Spec.MasterUsername
+//
+// Writes the address and port of the DBInstance's endpoint and its master
+// username to the `host`, `port` and `username` keys of the Secret named by
+// its Spec.ExportTo.
+type ExportConfig struct {
+	// Kind is the kind of the object the field values are written to,
+	// "Secret" or "ConfigMap". Defaults to "Secret".
+	Kind string `json:"kind,omitempty"`
+	// Fields maps the keys of the object to the paths of the fields whose
+	// values are written to them. The paths start with "Spec" or "Status",
+	// e.g. "Status.Endpoint.Address" or "Status.ACKResourceMetadata.ARN", and
+	// must lead to scalar fields.
+	Fields map[string]string `json:"fields"`
+}
+
+// GetKind returns the kind of the object the field values are written to
+func (ec *ExportConfig) GetKind() string {
+	if ec == nil || ec.Kind == "" {
+		return ExportKindSecret
+	}
+	return ec.Kind
+}
+
+// validateExports returns an error if a resource exports its fields to an
+// unknown kind of object, exports no fields, or exports a field whose path
+// doesn't start with "Spec" or "Status"
+func (c *Config) validateExports() error {
+	resNames := []string{}
+	for resName := range c.Resources {
+		resNames = append(resNames, resName)
+	}
+	sort.Strings(resNames)
+	for _, resName := range resNames {
+		export := c.Resources[resName].Export
+		if export == nil {
+			continue
+		}
+		switch export.GetKind() {
+		case ExportKindSecret, ExportKindConfigMap:
+		default:
+			return fmt.Errorf(
+				"%s: unknown export kind %q, must be %q or %q",
+				resName, export.Kind, ExportKindSecret, ExportKindConfigMap,
+			)
+		}
+		if len(export.Fields) == 0 {
+			return fmt.Errorf("%s: export must have fields", resName)
+		}
+		for key, path := range export.Fields {
+			if !strings.HasPrefix(path, "Spec.") && !strings.HasPrefix(path, "Status.") {
+				return fmt.Errorf(
+					"%s: export field path %q of key %q must start with Spec or Status",
+					resName, path, key,
+				)
+			}
+		}
+	}
+	return nil
+}
+
+// ResourceExport returns the instructions to write the values of some of the
+// given resource's fields to a Secret or ConfigMap, if any
+func (c *Config) ResourceExport(resourceName string) *ExportConfig {
+	if c == nil {
+		return nil
+	}
+	rConfig, ok := c.Resources[resourceName]
+	if !ok {
+		return nil
+	}
+	return rConfig.Export
+}
@@ -13,6 +13,10 @@
 
 package config
 
+// OnChangeRecreate is the FieldConfig.OnChange value replacing the resource
+// when the field changes
+const OnChangeRecreate = "recreate"
+
 // SourceFieldConfig instructs the code generator how to handle a field in the
 // Resource's SpecFields/StatusFields collection that takes its value from an
 // abnormal source -- in other words, not the Create operation's Input or
@@ -217,6 +221,65 @@ type CompareFieldConfig struct {
 	// NilEqualsZeroValue indicates a nil pointer and zero-value pointed-to
 	// value should be considered equal for the purposes of comparison
 	NilEqualsZeroValue bool `json:"nil_equals_zero_value"`
+	// IsUnordered indicates the elements of a list field should be compared
+	// as a set, regardless of their order, for the APIs returning them in
+	// another order than they were set in. For example, the following
+	// compares a security group's rules by their IpProtocol member:
+	//
+	//	fields:
+	//	  IpPermissions:
+	//	    compare:
+	//	      is_unordered: true
+	//	      key: IpProtocol
+	IsUnordered bool `json:"is_unordered"`
+	// Key is the name of the string member identifying the elements of an
+	// unordered list of structures. Elements having the same key are
+	// compared together, and an element without a key is always a
+	// difference. When not set, elements are compared as a whole. In both
+	// cases duplicate elements are counted, so `[x, x]` differs from `[x]`.
+	Key string `json:"key,omitempty"`
+	// IsCaseInsensitive indicates the values of a string field should be
+	// compared regardless of their case, for the APIs returning them in
+	// another case than they were set in
+	IsCaseInsensitive bool `json:"is_case_insensitive"`
+	// Normalize is how the values of a string field are normalized before
+	// being compared: "whitespace" compares them regardless of their leading,
+	// trailing and repeated whitespace, "json" compares them as JSON
+	// documents, regardless of their formatting and of the order of their
+	// object keys, e.g. policy documents.
+	Normalize string `json:"normalize,omitempty"`
+	// Tolerance is the largest absolute difference between the values of a
+	// number field that are considered equal, for the APIs rounding the
+	// values they are set to
+	Tolerance *float64 `json:"tolerance,omitempty"`
+	// NilEqualsEmpty indicates a nil and an empty value of a list or map
+	// field should be considered equal for the purposes of comparison. When
+	// not set, the API-wide nil_equals_empty setting is used.
+	NilEqualsEmpty *bool `json:"nil_equals_empty,omitempty"`
+}
+
+// DocumentationConfig instructs the code generator how to change the
+// documentation of a field in the generated Go types and CRD schema. This is
+// useful to fix misleading AWS API documentation or to add guidance specific
+// to Kubernetes.
+//
+// For example, the following generator.yaml:
+//
+//	resources:
+//	  Repository:
+//	    fields:
+//	      RepositoryName:
+//	        documentation:
+//	          append: Changing the name of a repository replaces it.
+//
+// Adds a paragraph to the end of the RepositoryName field's documentation.
+type DocumentationConfig struct {
+	// Replace is the documentation to use instead of the AWS API model's
+	Replace string `json:"replace,omitempty"`
+	// Prepend is a paragraph to add before the documentation
+	Prepend string `json:"prepend,omitempty"`
+	// Append is a paragraph to add after the documentation
+	Append string `json:"append,omitempty"`
 }
 
 // PrintFieldConfig instructs the code generator how to handle kubebuilder:printcolumn
@@ -239,16 +302,52 @@ type PrintFieldConfig struct {
 	Index int `json:"index"`
 }
 
-// CustomField instructs the code generator to create a new list or map field
-// type using a shape that exists in the SDK.
+// CustomField instructs the code generator to create a new field whose type
+// is a list or map of a shape that exists in the SDK, any shape that exists in
+// the SDK or a Go type supplied by hook code.
+//
+// Custom fields are not members of the Operations' Input and Output shapes,
+// so the generated code never sets them. Setting them is delegated to hook
+// code, e.g. in the `sdk_create_post_build_request` and
+// `sdk_read_one_post_set_output` hooks. For example, the following
+// generator.yaml:
+//
+//	resources:
+//	  Repository:
+//	    fields:
+//	      ReplicationRules:
+//	        custom_field:
+//	          list_of: ReplicationRule
+//	      Scanning:
+//	        custom_field:
+//	          shape: ImageScanningConfiguration
+//	      Mirror:
+//	        custom_field:
+//	          go_type: "*MirrorConfig"
+//
+// Adds three fields to the Repository's Spec, the last one being of the
+// `MirrorConfig` type declared by hand in the API package.
 type CustomFieldConfig struct {
 	// ListOf provides the name of the SDK shape which will become the
-	// member of a custom slice field.
+	// member of a custom slice field. The member may itself be a list or map,
+	// given as a type expression such as "map[string]Rule" or as the name of
+	// the custom shape of another custom field, e.g. "RuleMap".
 	ListOf string `json:"list_of,omitempty"`
 	// MapOf provides the name of the SDK shape which will become the value
 	// shape for a custom map field. All maps will have `string` as their key
-	// type.
+	// type. Like for ListOf, the value may itself be a list or map, e.g.
+	// "[]Rule" for a `map[string][]*Rule` field.
 	MapOf string `json:"map_of,omitempty"`
+	// Shape provides the name of the SDK shape which will become the type of
+	// the custom field. Unlike member shapes, this may be any shape in the
+	// API model.
+	Shape string `json:"shape,omitempty"`
+	// GoType provides the Go type of a custom field whose type is not a shape
+	// in the API model but is supplied by hook code, e.g. "*MirrorConfig".
+	GoType string `json:"go_type,omitempty"`
+	// GoTypeImport is the path of the package to import for GoType, if it is
+	// not declared in the API package
+	GoTypeImport string `json:"go_type_import,omitempty"`
 }
 
 // LateInitializeConfig contains instructions for how to handle the
@@ -267,6 +366,32 @@ type LateInitializeConfig struct {
 	MaxBackoffSeconds int `json:"max_backoff_seconds"`
 }
 
+// ResyncFieldConfig instructs the code generator to requeue the resource after
+// a particular period while a field has particular values, e.g. to observe a
+// resource more often while it is being created.
+//
+// For example, the following generator.yaml:
+//
+//	resources:
+//	  DBInstance:
+//	    fields:
+//	      DBInstanceStatus:
+//	        resync:
+//	          seconds: 15
+//	          values:
+//	            - creating
+//	            - modifying
+//
+// Requeues the DBInstances every 15 seconds while they are being created or
+// modified.
+type ResyncFieldConfig struct {
+	// Seconds is the number of seconds after which the resource is requeued
+	Seconds int `json:"seconds"`
+	// Values are the values of the field for which the resource is requeued.
+	// The resource is requeued whenever the field is set if it is empty.
+	Values []string `json:"values,omitempty"`
+}
+
 // FieldConfig contains instructions to the code generator about how
 // to interpret the value of an Attribute and how to map it to a CRD's Spec or
 // Status field
@@ -279,11 +404,17 @@ type FieldConfig struct {
 	// the primary resource, and that those fields should be "unpacked" from
 	// the raw map and into CRD's Spec and Status struct fields.
 	IsAttribute bool `json:"is_attribute"`
+	// AttributeKey is the key of the field's value in the "Attributes Map",
+	// for fields not named after their attribute. Setting it implies
+	// IsAttribute.
+	AttributeKey string `json:"attribute_key,omitempty"`
 	// IsReadOnly indicates the field's value can not be set by a Kubernetes
 	// user; in other words, the field should go in the CR's Status struct
 	IsReadOnly bool `json:"is_read_only"`
 	// Required indicates whether this field is a required member or not.
 	// This field is used to configure '+kubebuilder:validation:Required' on API object's members.
+	// When a field required by an operation is made optional, the field's
+	// Default value is sent to the operation if the field isn't set.
 	IsRequired *bool `json:"is_required,omitempty"`
 	// IsPrimaryKey indicates the field represents the primary name/string
 	// identifier field for the resource.  This allows the generator config to
@@ -302,9 +433,43 @@ type FieldConfig struct {
 	// IsSecret instructs the code generator that this field should be a
 	// SecretKeyReference.
 	IsSecret bool `json:"is_secret"`
+	// IsSensitive instructs the code generator that the field's value, e.g. a
+	// password the AWS API requires in plain text, must not be written to the
+	// controller logs: the values of the field are redacted from the deltas of
+	// the resource logged by the resource manager, while updates are made
+	// with the actual values. Sensitive fields can't be printed in the `kubectl get`
+	// columns nor exported to a ConfigMap.
+	IsSensitive bool `json:"is_sensitive"`
+	// References instructs the code generator that the field's value may be
+	// read from other Kubernetes objects, see ReferencesConfig
+	References *ReferencesConfig `json:"references,omitempty"`
 	// IsImmutable instructs the code generator to add advisory conditions
-	// if user modifies the spec field after resource was created.
+	// if user modifies the spec field after resource was created. The CRD
+	// schema also gets a CEL validation rule rejecting such modifications
+	// on Kubernetes 1.25+ clusters.
 	IsImmutable bool `json:"is_immutable"`
+	// OnChange instructs the code generator how a change to the field after
+	// the resource was created is applied, when the resource's update
+	// operations can't. The only supported value is "recreate": the resource
+	// is deleted and created again with the new value, if the CR opts in to
+	// it with the "services.k8s.aws/recreate-on-change: true" annotation.
+	// Otherwise, changing the field sets an advisory condition like changing
+	// an immutable field does.
+	OnChange string `json:"on_change,omitempty"`
+	// IsCreateOnly instructs the code generator that the field is only
+	// accepted by the Create operation. The field is left out of the Update
+	// operation's payload and of the comparison of the desired and latest
+	// states of the resource, so changing it doesn't trigger an update.
+	IsCreateOnly bool `json:"is_create_only"`
+	// IsUpdateOnly instructs the code generator that the field is only
+	// accepted by the Update operation. The field is left out of the Create
+	// operation's payload and set by the update following the creation.
+	IsUpdateOnly bool `json:"is_update_only"`
+	// Default is the value the field is set to by the Kubernetes API server
+	// when a resource is created without one. It may be a string, number,
+	// boolean, or a list or map of those. When not set, the default value of
+	// the field's shape in the API model, if any, is used.
+	Default interface{} `json:"default,omitempty"`
 	// From instructs the code generator that the value of the field should
 	// be retrieved from the specified operation and member path
 	From *SourceFieldConfig `json:"from,omitempty"`
@@ -325,4 +490,22 @@ type FieldConfig struct {
 	// Late Initialize instructs the code generator how to handle the late initialization
 	// of the field.
 	LateInitialize *LateInitializeConfig `json:"late_initialize,omitempty"`
+	// Resync instructs the code generator to requeue the resource after a
+	// particular period while the field, a string, has particular values. It
+	// overrides the resource's reconcile resync_seconds.
+	Resync *ResyncFieldConfig `json:"resync,omitempty"`
+	// Documentation instructs the code generator how to change the
+	// documentation of the field that comes from the AWS API model
+	Documentation *DocumentationConfig `json:"documentation,omitempty"`
+	// TimestampFormat overrides how the value of a top-level timestamp field
+	// is represented, see Config.TimestampFormat
+	TimestampFormat string `json:"timestamp_format,omitempty"`
+	// NumberFormat overrides how the value of a top-level number field is
+	// represented. Integer and long fields may be "int32" instead of int64.
+	// Float and double fields may be "quantity" (a resource.Quantity) or
+	// "string" instead of float64. The format is ignored for fields of any
+	// other type.
+	NumberFormat string `json:"number_format,omitempty"`
+	// Deprecated marks the field deprecated, see DeprecatedFieldConfig
+	Deprecated *DeprecatedFieldConfig `json:"deprecated,omitempty"`
 }
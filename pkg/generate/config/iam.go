@@ -0,0 +1,96 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package config
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// IAMPolicyConfig instructs the code generator how to write the statement of
+// the recommended IAM policy granting the actions the controller performs
+// for a resource. The actions of the API operations the controller calls for
+// the resource are always granted.
+//
+// For example, the following generator.yaml:
+//
+//	resources:
+//	  DBInstance:
+//	    iam_policy:
+//	      resources:
+//	        - arn:aws:rds:*:*:db:*
+//	      extra_actions:
+//	        - iam:PassRole
+//
+// Grants the actions of the DBInstance's operations and iam:PassRole on the
+// DB instances only.
+type IAMPolicyConfig struct {
+	// Resources are the ARN patterns of the AWS resources the actions are
+	// granted on. Defaults to "*".
+	Resources []string `json:"resources,omitempty"`
+	// ExtraActions are the IAM actions granted besides those of the API
+	// operations, e.g. for the AWS resources the API calls other services
+	// for, like "iam:PassRole"
+	ExtraActions []string `json:"extra_actions,omitempty"`
+}
+
+// validate returns an error if a resource isn't an ARN pattern or an action
+// has no service prefix
+func (pc *IAMPolicyConfig) validate() error {
+	for _, resource := range pc.Resources {
+		if resource != "*" && !strings.HasPrefix(resource, "arn:") {
+			return fmt.Errorf("iam_policy resource %q must be \"*\" or an ARN pattern", resource)
+		}
+	}
+	for _, action := range pc.ExtraActions {
+		if parts := strings.Split(action, ":"); len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+			return fmt.Errorf("iam_policy extra action %q must look like \"<service>:<action>\"", action)
+		}
+	}
+	return nil
+}
+
+// validateIAMPolicies returns an error if the IAM policy config of one of the
+// resources is invalid
+func (c *Config) validateIAMPolicies() error {
+	resNames := []string{}
+	for resName := range c.Resources {
+		resNames = append(resNames, resName)
+	}
+	sort.Strings(resNames)
+	for _, resName := range resNames {
+		policy := c.Resources[resName].IAMPolicy
+		if policy == nil {
+			continue
+		}
+		if err := policy.validate(); err != nil {
+			return fmt.Errorf("%s: %v", resName, err)
+		}
+	}
+	return nil
+}
+
+// ResourceIAMPolicy returns the IAM policy config of the given resource, if
+// any
+func (c *Config) ResourceIAMPolicy(resourceName string) *IAMPolicyConfig {
+	if c == nil {
+		return nil
+	}
+	rConfig, ok := c.Resources[resourceName]
+	if !ok {
+		return nil
+	}
+	return rConfig.IAMPolicy
+}
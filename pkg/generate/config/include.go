@@ -0,0 +1,167 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package config
+
+import (
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"sort"
+
+	"github.com/ghodss/yaml"
+)
+
+// includesKey is the generator config key listing the files to include
+const includesKey = "includes"
+
+// loadConfigContent returns the YAML content of the generator config file at
+// the supplied path, with the contents of all the files listed in its
+// `includes` directive (and theirs, recursively) deep-merged into it.
+//
+// Included files are merged in the order they are listed, and the including
+// file is merged last, so that its values take precedence. Mappings are
+// merged key by key; any other value (lists included) replaces the value
+// merged before it.
+func loadConfigContent(configPath string) ([]byte, error) {
+	content, err := readConfigFile(configPath)
+	if err != nil {
+		return nil, err
+	}
+	doc := map[string]interface{}{}
+	if err = yaml.Unmarshal(content, &doc); err != nil {
+		return nil, err
+	}
+	if _, found := doc[includesKey]; !found {
+		return content, nil
+	}
+	merged, err := loadConfigDocument(configPath, doc, map[string]bool{})
+	if err != nil {
+		return nil, err
+	}
+	return yaml.Marshal(merged)
+}
+
+// loadConfigDocument returns the supplied parsed generator config document
+// with its includes merged into it
+func loadConfigDocument(
+	configPath string,
+	doc map[string]interface{},
+	// The absolute paths of the files currently being loaded, used to detect
+	// include cycles
+	loading map[string]bool,
+) (map[string]interface{}, error) {
+	absPath, err := filepath.Abs(configPath)
+	if err != nil {
+		return nil, err
+	}
+	if loading[absPath] {
+		return nil, fmt.Errorf("include cycle detected at %s", configPath)
+	}
+	loading[absPath] = true
+	defer delete(loading, absPath)
+
+	includePaths, err := resolveIncludes(configPath, doc[includesKey])
+	if err != nil {
+		return nil, err
+	}
+	delete(doc, includesKey)
+
+	merged := map[string]interface{}{}
+	for _, includePath := range includePaths {
+		log.V(1).Info("including generator config", "file", configPath, "include", includePath)
+		content, err := readConfigFile(includePath)
+		if err != nil {
+			return nil, err
+		}
+		includeDoc := map[string]interface{}{}
+		if err = yaml.Unmarshal(content, &includeDoc); err != nil {
+			return nil, fmt.Errorf("%s: %v", includePath, err)
+		}
+		includeDoc, err = loadConfigDocument(includePath, includeDoc, loading)
+		if err != nil {
+			return nil, err
+		}
+		merged = mergeConfigValues(merged, includeDoc).(map[string]interface{})
+	}
+	return mergeConfigValues(merged, doc).(map[string]interface{}), nil
+}
+
+// readConfigFile reads and validates a single generator config file
+func readConfigFile(configPath string) ([]byte, error) {
+	content, err := ioutil.ReadFile(configPath)
+	if err != nil {
+		return nil, err
+	}
+	if err = Validate(configPath, content); err != nil {
+		return nil, err
+	}
+	return content, nil
+}
+
+// resolveIncludes returns the paths of the files listed in an `includes`
+// directive. Paths are relative to the directory of the including file and
+// may contain glob patterns, the matches of which are included in lexical
+// order.
+func resolveIncludes(
+	configPath string,
+	includes interface{},
+) ([]string, error) {
+	if includes == nil {
+		return nil, nil
+	}
+	entries, ok := includes.([]interface{})
+	if !ok {
+		return nil, fmt.Errorf("%s: %s must be a list of file paths", configPath, includesKey)
+	}
+	baseDir := filepath.Dir(configPath)
+	paths := []string{}
+	for _, entry := range entries {
+		pattern, ok := entry.(string)
+		if !ok {
+			return nil, fmt.Errorf("%s: %s must be a list of file paths", configPath, includesKey)
+		}
+		if !filepath.IsAbs(pattern) {
+			pattern = filepath.Join(baseDir, pattern)
+		}
+		matches, err := filepath.Glob(pattern)
+		if err != nil {
+			return nil, fmt.Errorf("%s: invalid include %q: %v", configPath, entry, err)
+		}
+		if len(matches) == 0 {
+			return nil, fmt.Errorf("%s: included file %q not found", configPath, entry)
+		}
+		sort.Strings(matches)
+		paths = append(paths, matches...)
+	}
+	return paths, nil
+}
+
+// mergeConfigValues deep-merges the src value into the dst value and returns
+// the result. Mappings are merged recursively; for any other type the src
+// value wins.
+func mergeConfigValues(dst interface{}, src interface{}) interface{} {
+	dstMap, dstIsMap := dst.(map[string]interface{})
+	srcMap, srcIsMap := src.(map[string]interface{})
+	if !dstIsMap || !srcIsMap {
+		return src
+	}
+	for key, srcVal := range srcMap {
+		if dstVal, found := dstMap[key]; found {
+			dstMap[key] = mergeConfigValues(dstVal, srcVal)
+		} else {
+			dstMap[key] = srcVal
+		}
+	}
+	return dstMap
+}
@@ -0,0 +1,127 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package config_test
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/aws-controllers-k8s/code-generator/pkg/generate/config"
+)
+
+func writeConfigFiles(t *testing.T, files map[string]string) string {
+	dir, err := ioutil.TempDir("", "generator-config")
+	require.Nil(t, err)
+	for path, content := range files {
+		fullPath := filepath.Join(dir, path)
+		require.Nil(t, os.MkdirAll(filepath.Dir(fullPath), 0755))
+		require.Nil(t, ioutil.WriteFile(fullPath, []byte(content), 0644))
+	}
+	return dir
+}
+
+func TestNew_Includes(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	dir := writeConfigFiles(t, map[string]string{
+		"generator.yaml": `
+includes:
+- resources/*.yaml
+resources:
+  Repository:
+    fields:
+      Name:
+        is_required: true
+ignore:
+  resource_names:
+  - Policy
+`,
+		"resources/repository.yaml": `
+resources:
+  Repository:
+    fields:
+      Name:
+        is_primary_key: true
+        is_required: false
+ignore:
+  resource_names:
+  - LifecyclePolicy
+`,
+		"resources/topic.yaml": `
+includes:
+- ../shared/topic-fields.yaml
+resources:
+  Topic:
+    exceptions:
+      errors:
+        404:
+          code: NotFound
+`,
+		"shared/topic-fields.yaml": `
+resources:
+  Topic:
+    fields:
+      Name:
+        is_primary_key: true
+`,
+	})
+	defer os.RemoveAll(dir)
+
+	cfg, err := config.New(filepath.Join(dir, "generator.yaml"), config.Config{})
+	require.Nil(err)
+
+	repoName := cfg.Resources["Repository"].Fields["Name"]
+	require.NotNil(repoName)
+	assert.True(repoName.IsPrimaryKey)
+	// The including file takes precedence
+	require.NotNil(repoName.IsRequired)
+	assert.True(*repoName.IsRequired)
+	// Lists are replaced rather than merged
+	assert.Equal([]string{"Policy"}, cfg.Ignore.ResourceNames)
+
+	topic := cfg.Resources["Topic"]
+	require.NotNil(topic.Fields["Name"])
+	assert.True(topic.Fields["Name"].IsPrimaryKey)
+	require.NotNil(topic.Exceptions)
+	assert.Equal("NotFound", topic.Exceptions.Errors[404].Code)
+}
+
+func TestNew_IncludeCycle(t *testing.T) {
+	dir := writeConfigFiles(t, map[string]string{
+		"generator.yaml": "includes:\n- other.yaml\n",
+		"other.yaml":     "includes:\n- generator.yaml\n",
+	})
+	defer os.RemoveAll(dir)
+
+	_, err := config.New(filepath.Join(dir, "generator.yaml"), config.Config{})
+	require.NotNil(t, err)
+	assert.Contains(t, err.Error(), "include cycle")
+}
+
+func TestNew_IncludeNotFound(t *testing.T) {
+	dir := writeConfigFiles(t, map[string]string{
+		"generator.yaml": "includes:\n- missing.yaml\n",
+	})
+	defer os.RemoveAll(dir)
+
+	_, err := config.New(filepath.Join(dir, "generator.yaml"), config.Config{})
+	require.NotNil(t, err)
+	assert.Contains(t, err.Error(), "not found")
+}
@@ -0,0 +1,63 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package config
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+)
+
+// kubectlNameRegexp matches the short names and categories of the CRDs, which
+// must be lower-case DNS labels
+var kubectlNameRegexp = regexp.MustCompile(`^[a-z]([-a-z0-9]*[a-z0-9])?$`)
+
+// validateKubectlNames returns an error if a short name or a category of a
+// resource isn't a lower-case DNS label, or if two resources have the same
+// short name
+func (c *Config) validateKubectlNames() error {
+	resNames := []string{}
+	for resName := range c.Resources {
+		resNames = append(resNames, resName)
+	}
+	sort.Strings(resNames)
+	shortNameResources := map[string]string{}
+	for _, resName := range resNames {
+		rConfig := c.Resources[resName]
+		for _, shortName := range rConfig.ShortNames {
+			if !kubectlNameRegexp.MatchString(shortName) {
+				return fmt.Errorf(
+					"%s: short name %q must be a lower-case DNS label",
+					resName, shortName,
+				)
+			}
+			if other, found := shortNameResources[shortName]; found {
+				return fmt.Errorf(
+					"%s: short name %q is already a short name of %s",
+					resName, shortName, other,
+				)
+			}
+			shortNameResources[shortName] = resName
+		}
+		for _, category := range rConfig.Categories {
+			if !kubectlNameRegexp.MatchString(category) {
+				return fmt.Errorf(
+					"%s: category %q must be a lower-case DNS label",
+					resName, category,
+				)
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,86 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package config
+
+import (
+	"fmt"
+	"strings"
+)
+
+const (
+	// ModelOverrideAdd adds the value at the path, replacing the value of an
+	// existing object member or inserting it into an array
+	ModelOverrideAdd = "add"
+	// ModelOverrideRemove removes the value at the path, which must exist
+	ModelOverrideRemove = "remove"
+	// ModelOverrideReplace replaces the value at the path, which must exist
+	ModelOverrideReplace = "replace"
+)
+
+// ModelOverrideConfig is an edit of the AWS service API model file
+// (api-2.json) applied before the code generator loads it, to work around
+// bugs of the API model such as missing members, wrong enum values or broken
+// shapes. The edits are modelled after the JSON Patch (RFC 6902) operations:
+//
+// model_overrides:
+//   - op: add
+//     path: /shapes/Repository/members/description
+//     value: {shape: String}
+//   - op: replace
+//     path: /shapes/ImageTagMutability/enum
+//     value: [MUTABLE, IMMUTABLE]
+//   - op: remove
+//     path: /shapes/BrokenShape
+type ModelOverrideConfig struct {
+	// Op is the edit: "add", "remove" or "replace"
+	Op string `json:"op"`
+	// Path is the JSON Pointer (RFC 6901) of the edited value in the API
+	// model, e.g. "/shapes/Repository/members/description". The last token
+	// of the path of a value added to an array is the index it is inserted
+	// at, or "-" to append it.
+	Path string `json:"path"`
+	// Value is the added or replacing value
+	Value interface{} `json:"value,omitempty"`
+}
+
+// validateModelOverrides returns an error if a model override has an
+// unknown op, a path that isn't a JSON Pointer to a value within the API
+// model, or lacks the value it adds or replaces with
+func (c *Config) validateModelOverrides() error {
+	for i, override := range c.ModelOverrides {
+		switch override.Op {
+		case ModelOverrideAdd, ModelOverrideReplace:
+			if override.Value == nil {
+				return fmt.Errorf(
+					"model_overrides[%d]: %s %s has no value",
+					i, override.Op, override.Path,
+				)
+			}
+		case ModelOverrideRemove:
+		default:
+			return fmt.Errorf(
+				"model_overrides[%d]: unknown op %q, must be %q, %q or %q",
+				i, override.Op, ModelOverrideAdd, ModelOverrideRemove,
+				ModelOverrideReplace,
+			)
+		}
+		if !strings.HasPrefix(override.Path, "/") {
+			return fmt.Errorf(
+				"model_overrides[%d]: path %q must start with a /",
+				i, override.Path,
+			)
+		}
+	}
+	return nil
+}
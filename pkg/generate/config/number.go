@@ -0,0 +1,42 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package config
+
+import (
+	"fmt"
+)
+
+const (
+	// NumberFormatInt32 represents integer and long values as int32 values,
+	// like most other Kubernetes APIs do
+	NumberFormatInt32 = "int32"
+	// NumberFormatQuantity represents float and double values as
+	// resource.Quantity values, e.g. "1.5" or "500m"
+	NumberFormatQuantity = "quantity"
+	// NumberFormatString represents float and double values as strings, e.g.
+	// "1.5"
+	NumberFormatString = "string"
+)
+
+// validNumberFormat returns an error if the supplied number format is unknown
+func validNumberFormat(format string) error {
+	switch format {
+	case "", NumberFormatInt32, NumberFormatQuantity, NumberFormatString:
+		return nil
+	}
+	return fmt.Errorf(
+		"unknown number_format %q, must be %q, %q or %q", format,
+		NumberFormatInt32, NumberFormatQuantity, NumberFormatString,
+	)
+}
@@ -45,6 +45,12 @@ type OperationConfig struct {
 	// An example of this is `Put...` or `Register...` API operations not being correctly classified as `Create` op type
 	// OperationType []string `json:"operation_type"`
 	OperationType StringArray `json:"operation_type"`
+	// RateLimit limits the rate of the calls to the operation, in addition
+	// to the rate limit of the service API, see RateLimitConfig
+	RateLimit *RateLimitConfig `json:"rate_limit,omitempty"`
+	// Retry overrides how the calls to the operation are retried, see
+	// RetryConfig
+	Retry *RetryConfig `json:"retry,omitempty"`
 }
 
 // IsIgnoredOperation returns true if Operation Name is configured to be ignored
@@ -79,6 +85,38 @@ func (c *Config) ListOpMatchFieldNames(
 	return rConfig.ListOperation.MatchFields
 }
 
+// ListOpMatches returns the criteria, in the generator config, that an
+// element of the List operation's Output shape must meet to be the supplied
+// resource
+func (c *Config) ListOpMatches(
+	resName string,
+) []*ListMatchConfig {
+	if c == nil {
+		return nil
+	}
+	rConfig, found := c.Resources[resName]
+	if !found || rConfig.ListOperation == nil {
+		return nil
+	}
+	return rConfig.ListOperation.Match
+}
+
+// ListOpMaxPages returns the maximum number of pages of the List operation's
+// results to read looking for the supplied resource, or 0 if the generator
+// config doesn't set it
+func (c *Config) ListOpMaxPages(
+	resName string,
+) int {
+	if c == nil {
+		return 0
+	}
+	rConfig, found := c.Resources[resName]
+	if !found || rConfig.ListOperation == nil {
+		return 0
+	}
+	return rConfig.ListOperation.MaxPages
+}
+
 // UnmarshalJSON parses input for a either a string or
 // or a list and returns a StringArray.
 func (a *StringArray) UnmarshalJSON(b []byte) error {
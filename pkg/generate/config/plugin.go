@@ -0,0 +1,48 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package config
+
+// PluginConfig instructs the code generator to run an external generator
+// after its own templates have been rendered. The external generator is an
+// executable that receives a JSON representation of the code generation model
+// on its standard input and writes a JSON document listing the additional
+// files to emit on its standard output.
+type PluginConfig struct {
+	// Name identifies the plugin in log and error messages
+	Name string `json:"name"`
+	// Command is the path to the plugin executable. Relative paths containing
+	// a path separator are resolved against the directory containing the
+	// generator config file. Commands without a path separator are looked up
+	// in the PATH.
+	Command string `json:"command"`
+	// Args are passed as command-line arguments to the plugin executable
+	Args []string `json:"args,omitempty"`
+	// Targets is the list of ack-generate commands (e.g. "apis",
+	// "controller") after which the plugin runs. Defaults to "controller".
+	Targets []string `json:"targets,omitempty"`
+}
+
+// RunsFor returns true if the plugin should run after the supplied
+// ack-generate command
+func (c *PluginConfig) RunsFor(target string) bool {
+	if len(c.Targets) == 0 {
+		return target == "controller"
+	}
+	for _, t := range c.Targets {
+		if t == target {
+			return true
+		}
+	}
+	return false
+}
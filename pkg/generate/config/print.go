@@ -0,0 +1,134 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package config
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// printColumnTypes are the OpenAPI types of the printer columns
+var printColumnTypes = []string{"string", "integer", "number", "boolean", "date"}
+
+// PrintColumnConfig instructs the code generator to add a printer column
+// showing the value at an arbitrary JSONPath of the resource.
+//
+// For example, the following generator.yaml:
+//
+//	resources:
+//	  DBInstance:
+//	    print:
+//	      columns:
+//	        - name: Endpoint
+//	          json_path: .status.endpoint.address
+//	          priority: 1
+//
+// Adds an Endpoint column to the `kubectl get -o wide` output of the
+// DBInstance resources.
+type PrintColumnConfig struct {
+	// Name is the name of the column
+	Name string `json:"name"`
+	// Type is the OpenAPI type of the value: "string", "integer", "number",
+	// "boolean" or "date". Default is "string".
+	Type string `json:"type,omitempty"`
+	// JSONPath is the JSONPath of the value in the resource, e.g.
+	// ".status.endpoint.address"
+	JSONPath string `json:"json_path"`
+	// Priority differentiates between the columns shown in the standard view
+	// (0) or only in the wide view (greater than 0). Default is 0.
+	Priority int `json:"priority,omitempty"`
+	// Index is the position of the column when the columns are ordered by
+	// index
+	Index int `json:"index,omitempty"`
+}
+
+// GetType returns the OpenAPI type of the value shown in the column
+func (c PrintColumnConfig) GetType() string {
+	if c.Type == "" {
+		return "string"
+	}
+	return c.Type
+}
+
+// validatePrintColumns returns an error if a printer column has no name or
+// JSONPath, or has an unknown type
+func (c *Config) validatePrintColumns() error {
+	resNames := []string{}
+	for resName := range c.Resources {
+		resNames = append(resNames, resName)
+	}
+	sort.Strings(resNames)
+	for _, resName := range resNames {
+		printCfg := c.Resources[resName].Print
+		if printCfg == nil {
+			continue
+		}
+		for _, column := range printCfg.Columns {
+			if column.Name == "" || column.JSONPath == "" {
+				return fmt.Errorf(
+					"%s: printer columns must have a name and a json_path",
+					resName,
+				)
+			}
+			if !strings.HasPrefix(column.JSONPath, ".") {
+				return fmt.Errorf(
+					"%s: printer column %s json_path %q must start with a dot",
+					resName, column.Name, column.JSONPath,
+				)
+			}
+			found := false
+			for _, t := range printColumnTypes {
+				if column.GetType() == t {
+					found = true
+					break
+				}
+			}
+			if !found {
+				return fmt.Errorf(
+					"%s: printer column %s has unknown type %q, must be one of %s",
+					resName, column.Name, column.Type,
+					strings.Join(printColumnTypes, ", "),
+				)
+			}
+		}
+	}
+	return nil
+}
+
+// GetResourcePrintColumns returns the additional printer columns showing the
+// values at arbitrary JSONPaths of the given resource
+func (c *Config) GetResourcePrintColumns(resourceName string) []PrintColumnConfig {
+	if c == nil {
+		return nil
+	}
+	rConfig, ok := c.Resources[resourceName]
+	if !ok || rConfig.Print == nil {
+		return nil
+	}
+	return rConfig.Print.Columns
+}
+
+// GetResourcePrintDefaultColumns returns whether the default printer columns
+// are appended to the printer columns of the given resource
+func (c *Config) GetResourcePrintDefaultColumns(resourceName string) bool {
+	if c == nil {
+		return true
+	}
+	rConfig, ok := c.Resources[resourceName]
+	if !ok || rConfig.Print == nil || rConfig.Print.DefaultColumns == nil {
+		return true
+	}
+	return *rConfig.Print.DefaultColumns
+}
@@ -0,0 +1,117 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package config
+
+import (
+	"fmt"
+	"math"
+	"sort"
+)
+
+// RateLimitConfig instructs the code generator to limit the rate of the
+// calls the controller makes to the AWS API, with a token bucket, so that an
+// aggressive reconciliation doesn't get the AWS account throttled. The limit
+// applies to all the calls of the service API when it is set at the top level
+// of the generator config, or to the calls of a single operation when it is
+// set in the operation's config. A call waits for both.
+//
+// For example, the following generator.yaml:
+//
+//	rate_limit:
+//	  qps: 20
+//	  burst: 40
+//	operations:
+//	  DescribeDBInstances:
+//	    rate_limit:
+//	      qps: 5
+//
+// Limits the controller to 20 calls per second, with bursts of 40 calls, of
+// which 5 calls per second may be DescribeDBInstances calls.
+type RateLimitConfig struct {
+	// QPS is the number of calls per second the tokens are refilled at
+	QPS float64 `json:"qps"`
+	// Burst is the maximum number of calls made at once, the size of the
+	// bucket. Defaults to QPS rounded up.
+	Burst int `json:"burst,omitempty"`
+}
+
+// GetBurst returns the maximum number of calls made at once
+func (rl *RateLimitConfig) GetBurst() int {
+	if rl.Burst > 0 {
+		return rl.Burst
+	}
+	return int(math.Ceil(rl.QPS))
+}
+
+// validate returns an error if the rate limit's QPS isn't positive or its
+// burst is negative
+func (rl *RateLimitConfig) validate() error {
+	if rl.QPS <= 0 {
+		return fmt.Errorf("rate_limit qps must be positive")
+	}
+	if rl.Burst < 0 {
+		return fmt.Errorf("rate_limit burst must not be negative")
+	}
+	return nil
+}
+
+// validateRateLimits returns an error if the rate limit of the service API or
+// of one of its operations is invalid
+func (c *Config) validateRateLimits() error {
+	if c.RateLimit != nil {
+		if err := c.RateLimit.validate(); err != nil {
+			return err
+		}
+	}
+	for _, opName := range c.OperationRateLimitNames() {
+		if err := c.Operations[opName].RateLimit.validate(); err != nil {
+			return fmt.Errorf("%s: %v", opName, err)
+		}
+	}
+	return nil
+}
+
+// HasRateLimits returns true if the rate of the calls to the service API or
+// to one of its operations is limited
+func (c *Config) HasRateLimits() bool {
+	if c == nil {
+		return false
+	}
+	return c.RateLimit != nil || len(c.OperationRateLimitNames()) > 0
+}
+
+// OperationRateLimitNames returns the sorted names of the operations whose
+// calls are rate limited
+func (c *Config) OperationRateLimitNames() []string {
+	opNames := []string{}
+	if c == nil {
+		return opNames
+	}
+	for opName, opConfig := range c.Operations {
+		if opConfig.RateLimit != nil {
+			opNames = append(opNames, opName)
+		}
+	}
+	sort.Strings(opNames)
+	return opNames
+}
+
+// OperationRateLimit returns the rate limit of the calls to the supplied
+// operation, if any
+func (c *Config) OperationRateLimit(opName string) *RateLimitConfig {
+	if c == nil {
+		return nil
+	}
+	return c.Operations[opName].RateLimit
+}
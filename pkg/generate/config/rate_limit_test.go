@@ -0,0 +1,78 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package config_test
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/aws-controllers-k8s/code-generator/pkg/generate/config"
+)
+
+func TestRateLimits(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	dir := writeConfigFiles(t, map[string]string{
+		"generator.yaml": `
+rate_limit:
+  qps: 20
+  burst: 40
+operations:
+  DescribeDBInstances:
+    rate_limit:
+      qps: 2.5
+  CreateDBInstance:
+    override_values:
+      Engine: mysql
+`,
+	})
+	cfg, err := config.New(filepath.Join(dir, "generator.yaml"), config.Config{})
+	require.Nil(err)
+
+	require.True(cfg.HasRateLimits())
+	require.NotNil(cfg.RateLimit)
+	assert.Equal(40, cfg.RateLimit.GetBurst())
+	assert.Equal([]string{"DescribeDBInstances"}, cfg.OperationRateLimitNames())
+
+	// The burst defaults to the QPS rounded up
+	rateLimit := cfg.OperationRateLimit("DescribeDBInstances")
+	require.NotNil(rateLimit)
+	assert.Equal(2.5, rateLimit.QPS)
+	assert.Equal(3, rateLimit.GetBurst())
+	assert.Nil(cfg.OperationRateLimit("CreateDBInstance"))
+
+	cfg, err = config.New("", config.Config{})
+	require.Nil(err)
+	assert.False(cfg.HasRateLimits())
+}
+
+func TestRateLimits_Invalid(t *testing.T) {
+	require := require.New(t)
+
+	dir := writeConfigFiles(t, map[string]string{
+		"generator.yaml": `
+operations:
+  DescribeDBInstances:
+    rate_limit:
+      qps: 0
+`,
+	})
+	_, err := config.New(filepath.Join(dir, "generator.yaml"), config.Config{})
+	require.NotNil(err)
+	require.Contains(err.Error(), "DescribeDBInstances: rate_limit qps must be positive")
+}
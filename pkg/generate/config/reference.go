@@ -0,0 +1,127 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package config
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+const (
+	// DeleteOrderChildrenFirst defers the deletion of the referenced objects
+	// until the resources referencing them are deleted
+	DeleteOrderChildrenFirst = "children_first"
+	// DeleteOrderParentFirst defers the deletion of the resources until the
+	// objects they reference are deleted
+	DeleteOrderParentFirst = "parent_first"
+)
+
+// ReferencesConfig instructs the code generator that the value of a string
+// or list of strings Spec field may be read from other Kubernetes objects,
+// of any kind. A `<Field>Ref` field (or
+// `<Field>Refs` field for a list) naming the objects is added to the Spec,
+// and the field is set to the value at the supplied JSONPath of the
+// referenced objects before the resource is created or updated.
+//
+// For example, the following generator.yaml:
+//
+//	resources:
+//	  Function:
+//	    fields:
+//	      Role:
+//	        references:
+//	          api_version: iam.services.k8s.aws/v1alpha1
+//	          kind: Role
+//	          path: .status.ackResourceMetadata.arn
+//
+// Adds a `RoleRef` field to the Spec of the Function CRD, and sets its Role
+// field to the ARN of the referenced Role.
+type ReferencesConfig struct {
+	// APIVersion is the group and version of the referenced objects, e.g.
+	// "v1" or "iam.services.k8s.aws/v1alpha1"
+	APIVersion string `json:"api_version"`
+	// Kind is the kind of the referenced objects, e.g. "Role"
+	Kind string `json:"kind"`
+	// Resource is the plural resource name of the referenced objects, e.g.
+	// "roles". Defaults to the lower-cased plural of Kind.
+	Resource string `json:"resource,omitempty"`
+	// Path is the JSONPath of the value in the referenced objects, e.g.
+	// ".status.ackResourceMetadata.arn" or "{.data.name}"
+	Path string `json:"path"`
+	// Owner instructs the code generator to make the referenced objects the
+	// owners of the resource, for the resources that are children of the
+	// referenced objects or can't be used without them. The resource gets
+	// an ownerReference to each referenced object in its namespace, so that
+	// it is garbage collected along with them, and a label naming its
+	// owner, e.g. "apigatewayv2.services.k8s.aws/api: my-api".
+	Owner bool `json:"owner,omitempty"`
+	// DeleteOrder orders the deletion of the resource and of the referenced
+	// objects, instead of relying on the AWS service API rejecting the
+	// deletion of resources still in use: "children_first" defers the
+	// deletion of the referenced objects, which must be resources of the
+	// same API, until the resources referencing them are deleted, and
+	// "parent_first" defers the deletion of the resource until the
+	// referenced objects are deleted. By default the deletions aren't
+	// ordered.
+	DeleteOrder string `json:"delete_order,omitempty"`
+}
+
+// validateReferences returns an error if a field references other objects
+// without supplying their API version, kind or the path of their value, with
+// an unknown delete order, or if the field isn't a top-level field
+func (c *Config) validateReferences() error {
+	resNames := []string{}
+	for resName := range c.Resources {
+		resNames = append(resNames, resName)
+	}
+	sort.Strings(resNames)
+	for _, resName := range resNames {
+		fields := c.Resources[resName].Fields
+		fieldNames := []string{}
+		for fieldName := range fields {
+			fieldNames = append(fieldNames, fieldName)
+		}
+		sort.Strings(fieldNames)
+		for _, fieldName := range fieldNames {
+			fConfig := fields[fieldName]
+			if fConfig == nil || fConfig.References == nil {
+				continue
+			}
+			refs := fConfig.References
+			if strings.Contains(fieldName, ".") {
+				return fmt.Errorf(
+					"%s.%s: references are only supported on top-level fields",
+					resName, fieldName,
+				)
+			}
+			if refs.APIVersion == "" || refs.Kind == "" || refs.Path == "" {
+				return fmt.Errorf(
+					"%s.%s: references must have an api_version, a kind and a path",
+					resName, fieldName,
+				)
+			}
+			switch refs.DeleteOrder {
+			case "", DeleteOrderChildrenFirst, DeleteOrderParentFirst:
+			default:
+				return fmt.Errorf(
+					"%s.%s: unknown references delete_order %q, must be %q or %q",
+					resName, fieldName, refs.DeleteOrder,
+					DeleteOrderChildrenFirst, DeleteOrderParentFirst,
+				)
+			}
+		}
+	}
+	return nil
+}
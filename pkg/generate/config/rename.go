@@ -0,0 +1,175 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package config
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// A key in the renames configuration is a pattern when it is either a glob
+// containing one or more '*' wildcards or a regular expression enclosed in
+// slashes. For example, both of the following rename every field ending in
+// "KmsKeyId":
+//
+//	"*KmsKeyId": "*KMSKeyID"
+//	"/^(.*)KmsKeyId$/": "${1}KMSKeyID"
+//
+// Each '*' in the target of a glob rename is replaced by the text matched by
+// the corresponding '*' in the glob. The target of a regular expression
+// rename may refer to the expression's capture groups.
+
+// isRenamePattern returns true if the supplied renames key is a glob or a
+// regular expression rather than a literal name
+func isRenamePattern(key string) bool {
+	return isRenameRegexp(key) || strings.Contains(key, "*")
+}
+
+// isRenameRegexp returns true if the supplied renames key is a regular
+// expression enclosed in slashes
+func isRenameRegexp(key string) bool {
+	return len(key) > 2 && strings.HasPrefix(key, "/") && strings.HasSuffix(key, "/")
+}
+
+// compileRename returns the regular expression and expansion template
+// equivalent to the supplied renames pattern and target
+func compileRename(pattern string, target string) (*regexp.Regexp, string, error) {
+	if isRenameRegexp(pattern) {
+		re, err := regexp.Compile(pattern[1 : len(pattern)-1])
+		if err != nil {
+			return nil, "", fmt.Errorf("invalid rename pattern %q: %v", pattern, err)
+		}
+		return re, target, nil
+	}
+	globParts := strings.Split(pattern, "*")
+	targetParts := strings.Split(target, "*")
+	if len(targetParts) > len(globParts) {
+		return nil, "", fmt.Errorf(
+			"rename target %q has more wildcards than pattern %q",
+			target, pattern,
+		)
+	}
+	exprParts := make([]string, len(globParts))
+	for x, part := range globParts {
+		exprParts[x] = regexp.QuoteMeta(part)
+	}
+	re := regexp.MustCompile("^" + strings.Join(exprParts, "(.*)") + "$")
+	tmpl := ""
+	for x, part := range targetParts {
+		if x > 0 {
+			tmpl += fmt.Sprintf("${%d}", x)
+		}
+		tmpl += strings.Replace(part, "$", "$$", -1)
+	}
+	return re, tmpl, nil
+}
+
+// matchRename returns the name the supplied original name is renamed to by
+// the supplied renames and whether a rename was found. Literal names take
+// precedence over patterns, which are tried in sorted order.
+func matchRename(renames map[string]string, origName string) (string, bool) {
+	if renamed, ok := renames[origName]; ok {
+		return renamed, true
+	}
+	for _, pattern := range sortedRenamePatterns(renames) {
+		re, tmpl, err := compileRename(pattern, renames[pattern])
+		if err != nil {
+			continue
+		}
+		match := re.FindStringSubmatchIndex(origName)
+		if match == nil {
+			continue
+		}
+		return string(re.ExpandString(nil, tmpl, origName, match)), true
+	}
+	return origName, false
+}
+
+// sortedRenamePatterns returns the sorted keys of the supplied map that are
+// patterns
+func sortedRenamePatterns(renames map[string]string) []string {
+	patterns := []string{}
+	for key := range renames {
+		if isRenamePattern(key) {
+			patterns = append(patterns, key)
+		}
+	}
+	sort.Strings(patterns)
+	return patterns
+}
+
+// operationRenames returns the rename configurations applying to the
+// supplied Operation ID. The configuration keyed by the Operation ID, if any,
+// comes first, followed by those keyed by a matching pattern.
+func (r *RenamesConfig) operationRenames(opID string) []*OperationRenamesConfig {
+	res := []*OperationRenamesConfig{}
+	if r == nil {
+		return res
+	}
+	if oRenames, ok := r.Operations[opID]; ok {
+		res = append(res, oRenames)
+	}
+	patterns := []string{}
+	for key := range r.Operations {
+		if isRenamePattern(key) {
+			patterns = append(patterns, key)
+		}
+	}
+	sort.Strings(patterns)
+	for _, pattern := range patterns {
+		re, _, err := compileRename(pattern, "")
+		if err != nil || !re.MatchString(opID) {
+			continue
+		}
+		res = append(res, r.Operations[pattern])
+	}
+	return res
+}
+
+// validateRenames returns an error if any of the rename patterns in the
+// generator config is invalid
+func (c *Config) validateRenames() error {
+	for resName, rConfig := range c.Resources {
+		if rConfig.Renames == nil {
+			continue
+		}
+		for opID, oRenames := range rConfig.Renames.Operations {
+			if isRenamePattern(opID) {
+				if _, _, err := compileRename(opID, ""); err != nil {
+					return fmt.Errorf("resource %s: %v", resName, err)
+				}
+			}
+			if oRenames == nil {
+				continue
+			}
+			for _, renames := range []map[string]string{
+				oRenames.InputFields, oRenames.OutputFields,
+			} {
+				for pattern, target := range renames {
+					if !isRenamePattern(pattern) {
+						continue
+					}
+					if _, _, err := compileRename(pattern, target); err != nil {
+						return fmt.Errorf(
+							"resource %s operation %s: %v", resName, opID, err,
+						)
+					}
+				}
+			}
+		}
+	}
+	return nil
+}
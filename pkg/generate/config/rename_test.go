@@ -0,0 +1,83 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package config_test
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/aws-controllers-k8s/code-generator/pkg/generate/config"
+)
+
+func TestResourceFieldRename_Patterns(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	dir := writeConfigFiles(t, map[string]string{
+		"generator.yaml": `
+resources:
+  Repository:
+    renames:
+      operations:
+        "*":
+          input_fields:
+            "*KmsKeyId": "*KMSKeyID"
+        CreateRepository:
+          input_fields:
+            RepositoryName: Name
+            "/^Image(.+)Configuration$/": "${1}Config"
+`,
+	})
+	cfg, err := config.New(filepath.Join(dir, "generator.yaml"), config.Config{})
+	require.Nil(err)
+
+	renamed, found := cfg.ResourceFieldRename("Repository", "CreateRepository", "RepositoryName")
+	assert.True(found)
+	assert.Equal("Name", renamed)
+
+	renamed, found = cfg.ResourceFieldRename("Repository", "CreateRepository", "ImageScanningConfiguration")
+	assert.True(found)
+	assert.Equal("ScanningConfig", renamed)
+
+	renamed, found = cfg.ResourceFieldRename("Repository", "DescribeRepositories", "EncryptionKmsKeyId")
+	assert.True(found)
+	assert.Equal("EncryptionKMSKeyID", renamed)
+
+	// The regular expression is only configured for CreateRepository
+	renamed, found = cfg.ResourceFieldRename("Repository", "DescribeRepositories", "ImageScanningConfiguration")
+	assert.False(found)
+	assert.Equal("ImageScanningConfiguration", renamed)
+}
+
+func TestResourceFieldRename_InvalidPattern(t *testing.T) {
+	require := require.New(t)
+
+	dir := writeConfigFiles(t, map[string]string{
+		"generator.yaml": `
+resources:
+  Repository:
+    renames:
+      operations:
+        CreateRepository:
+          input_fields:
+            "*KmsKeyId": "*KMS*KeyID"
+`,
+	})
+	_, err := config.New(filepath.Join(dir, "generator.yaml"), config.Config{})
+	require.NotNil(err)
+	require.Contains(err.Error(), "more wildcards")
+}
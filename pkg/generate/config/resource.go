@@ -14,6 +14,12 @@
 package config
 
 import (
+	"fmt"
+	"path"
+	"regexp"
+	"sort"
+	"strings"
+
 	"github.com/aws-controllers-k8s/code-generator/pkg/util"
 )
 
@@ -35,6 +41,26 @@ type ResourceConfig struct {
 	Hooks map[string]*HooksConfig `json:"hooks"`
 	// Renames identifies fields in Operations that should be renamed.
 	Renames *RenamesConfig `json:"renames,omitempty"`
+	// Operations pins the API operations serving as the resource's Create,
+	// ReadOne, Update, Delete and List operations. Operations are otherwise
+	// inferred from their names, which misfires for APIs like
+	// `CreateOrUpdateX` or `PutX`.
+	Operations *ResourceOperationsConfig `json:"operations,omitempty"`
+	// SecondaryOperations lists, in the order they are called, the API
+	// operations configuring parts of the resource that its Create and
+	// Update operations don't, e.g. S3's PutBucketEncryption for a Bucket.
+	// The members of their Input shapes become Spec fields, and the
+	// operations are called after the resource is created and whenever one
+	// of these fields changes.
+	SecondaryOperations []*SecondaryOperationConfig `json:"secondary_operations,omitempty"`
+	// Splits carves the resource into multiple CRDs, each reconciling a
+	// subset of the resource's fields with the resource's API operations.
+	// The resource itself doesn't get a CRD.
+	Splits []*SplitConfig `json:"splits,omitempty"`
+	// BatchOperations is a map, keyed by operation ID, of instructions for
+	// wrapping the resource into the request and response shapes of batch
+	// operations, for APIs only creating or deleting resources in batches
+	BatchOperations map[string]*BatchOperationConfig `json:"batch_operations,omitempty"`
 	// ListOperation contains instructions for the code generator to generate
 	// Go code that filters the results of a List operation looking for a
 	// singular object. Certain AWS services (e.g. S3's ListBuckets API) have
@@ -75,6 +101,10 @@ type ResourceConfig struct {
 	// All ShortNames must be distinct from any other ShortNames installed into the cluster,
 	// otherwise the CRD will fail to install.
 	ShortNames []string `json:"shortNames,omitempty"`
+	// Categories are the groups of resources the CRD belongs to, e.g. "ack"
+	// or "networking", so that `kubectl get <category>` lists its resources
+	// along with the ones of the other CRDs of the categories.
+	Categories []string `json:"categories,omitempty"`
 	// IsAdoptable determines whether the CRD should be accepted by the adoption reconciler.
 	// If set to false, the user will be given an error if they attempt to adopt a resource
 	// with this type.
@@ -85,6 +115,63 @@ type ResourceConfig struct {
 	// IsARNPrimaryKey determines whether the CRD uses the ARN as the primary
 	// identifier in the ReadOne operations.
 	IsARNPrimaryKey bool `json:"is_arn_primary_key"`
+	// AutoIdempotencyToken determines whether the idempotency token members of
+	// the resource's operations' Input shapes are filled, when not set in the
+	// CR, with a token derived from the CR's UID and generation, so retried
+	// calls don't create duplicate resources. Defaults to true.
+	AutoIdempotencyToken *bool `json:"auto_idempotency_token,omitempty"`
+	// IsUpsert determines whether the resource's Create operation is an
+	// idempotent Put creating the resource or replacing the existing one.
+	// The resource is then updated by calling the Create operation again and
+	// only its ReadOne operation tells whether it exists.
+	IsUpsert bool `json:"is_upsert"`
+	// ReadBackOnCreate determines whether the resource is read back right
+	// after it is created, so the fields of its Spec the user didn't set take
+	// the defaults the AWS service assigned to them before the first
+	// reconciliation compares it with the latest observed state
+	ReadBackOnCreate bool `json:"read_back_on_create"`
+	// Export instructs the code generator to write the values of some of the
+	// resource's fields to a Secret or ConfigMap named in its Spec
+	Export *ExportConfig `json:"export,omitempty"`
+	// Endpoint overrides, for the resources of this kind, whether the FIPS
+	// and dual-stack endpoints of the AWS service API are called
+	Endpoint *EndpointConfig `json:"endpoint,omitempty"`
+	// IAMPolicy instructs the code generator how to write the statement of
+	// the recommended IAM policy granting the actions the controller performs
+	// for the resource
+	IAMPolicy *IAMPolicyConfig `json:"iam_policy,omitempty"`
+	// Conversion instructs the code generator how the resource's fields are
+	// converted between the API versions of the service
+	Conversion *ResourceConversionConfig `json:"conversion,omitempty"`
+	// Scope is the scope of the resource's objects: "Namespaced", the
+	// default, or "Cluster" for the AWS resources that are unique within the
+	// account and region. Cluster-scoped resources can't export their fields
+	// nor be owned by the objects they reference.
+	Scope string `json:"scope,omitempty"`
+	// Scale instructs the code generator to expose the scale subresource of
+	// the CRD, for the resources having a replica-like field
+	Scale *ScaleConfig `json:"scale,omitempty"`
+	// Status instructs the code generator how the Status of the CRD is
+	// exposed, for the resources whose Status is huge
+	Status *StatusConfig `json:"status,omitempty"`
+	// CRDMetadata are the labels and annotations stamped onto the CRD,
+	// overriding those of all the CRDs of the API
+	CRDMetadata *CRDMetadataConfig `json:"crd_metadata,omitempty"`
+	// APIGroup places the resource into another API group than the one of
+	// the service, e.g. to split the networking and compute resources of
+	// EC2. A name without dots, e.g. "networking", is prefixed to the API
+	// group of the service: "networking.ec2.services.k8s.aws". The types of
+	// the resources of another API group are generated in the
+	// apis/$API_VERSION/$NAME directory, $NAME being the first label of the
+	// API group.
+	APIGroup string `json:"api_group,omitempty"`
+	// SDKAPIVersion is the version, e.g. "2015-01-01", of the service's API
+	// model the resource is generated from, for the services whose API
+	// model directory contains several versions. The resource's operations
+	// and shapes are read from the API model of that version instead of the
+	// one loaded for the service; the type definitions of the shapes both
+	// versions contain are those of the latter. The version must exist.
+	SDKAPIVersion string `json:"sdk_api_version,omitempty"`
 }
 
 // HooksConfig instructs the code generator how to inject custom callback hooks
@@ -123,6 +210,16 @@ type HooksConfig struct {
 type CompareConfig struct {
 	// Ignore is a list of field paths to ignore when comparing two objects
 	Ignore []string `json:"ignore"`
+	// IgnoreDrift is a list of paths of the Spec fields whose differences
+	// are excluded from the Delta, for the fields the AWS service or other
+	// automation changes, e.g. auto-scaled capacities. The fields remain in
+	// the Spec and are still set when the resource is created. For example:
+	//
+	//	compare:
+	//	  ignore_drift:
+	//	  - ProvisionedThroughput.ReadCapacityUnits
+	//	  - ProvisionedThroughput.WriteCapacityUnits
+	IgnoreDrift []string `json:"ignore_drift,omitempty"`
 }
 
 // UnpackAttributesMapConfig informs the code generator that the API follows a
@@ -158,8 +255,32 @@ type CompareConfig struct {
 //   policy, taking system defaults into account.
 //
 // This structure instructs the code generator about the above real, schema'd
-// fields that are masquerading as raw key/value pairs.
+// fields that are masquerading as raw key/value pairs. The fields themselves
+// are the resource's fields configured with `is_attribute` or
+// `attribute_key`. APIs that don't follow the SNS/SQS naming can declare the
+// attribute map member and operations explicitly:
+//
+//	resources:
+//	  Queue:
+//	    unpack_attributes_map:
+//	      member_name: Attributes
+//	      get_attributes_operation: GetQueueAttributes
+//	      set_attributes_operation: SetQueueAttributes
+//	    fields:
+//	      RetentionPeriod:
+//	        attribute_key: MessageRetentionPeriod
 type UnpackAttributesMapConfig struct {
+	// MemberName is the name of the `map[string]*string` member holding the
+	// attributes in the Input and Output shapes. Defaults to "Attributes".
+	MemberName string `json:"member_name,omitempty"`
+	// GetAttributesOperation is the ID of the operation returning the
+	// resource's attributes, e.g. "GetQueueAttributes". It is otherwise
+	// inferred from operation names.
+	GetAttributesOperation string `json:"get_attributes_operation,omitempty"`
+	// SetAttributesOperation is the ID of the operation setting the
+	// resource's attributes, e.g. "SetQueueAttributes". It is otherwise
+	// inferred from operation names.
+	SetAttributesOperation string `json:"set_attributes_operation,omitempty"`
 	// SetAttributesSingleAttribute indicates that the SetAttributes API call
 	// doesn't actually set multiple attributes but rather must be called
 	// multiple times, once for each attribute that needs to change. See SNS
@@ -172,6 +293,22 @@ type UnpackAttributesMapConfig struct {
 	GetAttributesInput *GetAttributesInputConfig `json:"get_attributes_input,omitempty"`
 }
 
+// ByOperationType returns a map, keyed by operation type name
+// ("GetAttributes" or "SetAttributes"), of the configured operation IDs
+func (c *UnpackAttributesMapConfig) ByOperationType() map[string]string {
+	res := map[string]string{}
+	if c == nil {
+		return res
+	}
+	if c.GetAttributesOperation != "" {
+		res["GetAttributes"] = c.GetAttributesOperation
+	}
+	if c.SetAttributesOperation != "" {
+		res["SetAttributes"] = c.SetAttributesOperation
+	}
+	return res
+}
+
 // GetAttributesInputConfig is used to instruct the code generator how to
 // handle the GetAttributes API operation's Input shape.
 type GetAttributesInputConfig struct {
@@ -204,6 +341,36 @@ type ExceptionsConfig struct {
 	Errors map[int]ErrorConfig `json:"errors"`
 	// Set of aws exception codes that are terminal exceptions for this resource
 	TerminalCodes []string `json:"terminal_codes"`
+	// TerminalMessages lists the exceptions that are terminal for this
+	// resource depending on their message, for the APIs returning the same
+	// exception code, e.g. ValidationException, for terminal and retryable
+	// errors
+	TerminalMessages []TerminalMessageConfig `json:"terminal_messages,omitempty"`
+}
+
+// TerminalMessageConfig describes exceptions that are terminal when their
+// message matches a regular expression.
+//
+// For example, the following generator.yaml:
+//
+//	resources:
+//	  TrainingJob:
+//	    exceptions:
+//	      terminal_messages:
+//	        - code: ValidationException
+//	          pattern: "^Could not (access|assume) role"
+//
+// Makes the ValidationExceptions reporting the IAM role can't be used
+// terminal, while the other ValidationExceptions are retried.
+type TerminalMessageConfig struct {
+	// Code is the aws exception code the message is checked for. The message
+	// of any exception is checked if it is empty.
+	// In AWS Go SDK terms - awsErr.Code()
+	Code string `json:"code,omitempty"`
+	// Pattern is the regular expression, in the Go syntax, matching the
+	// messages of the terminal exceptions.
+	// In AWS Go SDK terms - awsErr.Message()
+	Pattern string `json:"pattern"`
 }
 
 // ErrorConfig contains instructions to the code generator about the exception
@@ -236,19 +403,156 @@ type ErrorConfig struct {
 // fields in various Operation payloads
 type RenamesConfig struct {
 	// Operations is a map, keyed by Operation ID, of instructions on how to
-	// handle renamed fields in Input and Output shapes.
+	// handle renamed fields in Input and Output shapes. The key may be a glob
+	// such as "*" to apply the instructions to every matching Operation.
 	Operations map[string]*OperationRenamesConfig `json:"operations"`
 }
 
 // OperationRenamesConfig contains instructions to the code generator on how to
 // rename fields in an Operation's input and output payload shapes
 type OperationRenamesConfig struct {
-	// InputFields is a map of Input shape fields to renamed field name.
+	// InputFields is a map of Input shape fields to renamed field name. The
+	// field may be a glob such as "*KmsKeyId" or a regular expression
+	// enclosed in slashes, in which case the wildcards or capture groups may
+	// be referred to in the renamed field name, e.g. "*KMSKeyID".
 	InputFields map[string]string `json:"input_fields"`
-	// OutputFields is a map of Output shape fields to renamed field name.
+	// OutputFields is a map of Output shape fields to renamed field name,
+	// supporting the same patterns as InputFields.
 	OutputFields map[string]string `json:"output_fields"`
 }
 
+// ResourceOperationsConfig contains the IDs of the API operations serving as
+// a resource's CRUD operations. Operations that are left empty are inferred
+// from the API operation names as usual.
+type ResourceOperationsConfig struct {
+	// Create is the ID of the operation creating the resource
+	Create string `json:"create,omitempty"`
+	// ReadOne is the ID of the operation describing a single resource
+	ReadOne string `json:"read_one,omitempty"`
+	// Update is the ID of the operation updating the resource
+	Update string `json:"update,omitempty"`
+	// Delete is the ID of the operation deleting the resource
+	Delete string `json:"delete,omitempty"`
+	// List is the ID of the operation listing resources
+	List string `json:"list,omitempty"`
+}
+
+// ByOperationType returns a map, keyed by operation type name ("Create",
+// "Get", "Update", "Delete" or "List"), of the configured operation IDs
+func (c *ResourceOperationsConfig) ByOperationType() map[string]string {
+	res := map[string]string{}
+	if c == nil {
+		return res
+	}
+	for opType, opID := range map[string]string{
+		"Create": c.Create,
+		"Get":    c.ReadOne,
+		"Update": c.Update,
+		"Delete": c.Delete,
+		"List":   c.List,
+	} {
+		if opID != "" {
+			res[opType] = opID
+		}
+	}
+	return res
+}
+
+// SecondaryOperationConfig identifies an API operation called, in addition to
+// the Create and Update operations, to configure part of a resource. For
+// example, the following generator.yaml:
+//
+//	resources:
+//	  Bucket:
+//	    secondary_operations:
+//	      - operation: PutBucketEncryption
+//	      - operation: PutBucketVersioning
+//
+// Adds the ServerSideEncryptionConfiguration and VersioningConfiguration
+// fields to the Bucket's Spec. Members of the secondary operations' Input
+// shapes can be renamed and ignored like any other field.
+type SecondaryOperationConfig struct {
+	// Operation is the ID of the API operation
+	Operation string `json:"operation"`
+}
+
+// BatchOperationConfig describes how a single resource is wrapped into the
+// request and response shapes of a batch operation. For example, the
+// following generator.yaml:
+//
+//	resources:
+//	  FlowLog:
+//	    operations:
+//	      create: CreateFlowLogs
+//	      delete: DeleteFlowLogs
+//	    batch_operations:
+//	      CreateFlowLogs:
+//	        input_member: ResourceIds
+//	        output_member: FlowLogIds
+//	        errors_member: Unsuccessful
+//	      DeleteFlowLogs:
+//	        input_member: FlowLogIds
+//	        errors_member: Unsuccessful
+//
+// Gives the FlowLog CRD a ResourceID Spec field instead of the ResourceIds
+// list and a FlowLogID Status field set from the first element of the
+// FlowLogIds list returned by CreateFlowLogs. DeleteFlowLogs is then called
+// with a FlowLogIds list holding the FlowLogID field's value.
+//
+// When the elements of a list are structures, the members of the element
+// structure take the place of the list. Input elements are built from the
+// Spec fields named like their members, and the scalar members of output
+// elements become Status fields.
+type BatchOperationConfig struct {
+	// InputMember is the name of the list member of the operation's Input
+	// shape holding the resources
+	InputMember string `json:"input_member"`
+	// OutputMember is the name of the list member of the operation's Output
+	// shape holding the results for the resources, if any
+	OutputMember string `json:"output_member,omitempty"`
+	// ErrorsMember is the name of the list member of the operation's Output
+	// shape holding the errors for the resources the operation failed for,
+	// if any. The generated code returns an error when it isn't empty.
+	ErrorsMember string `json:"errors_member,omitempty"`
+}
+
+// SplitConfig describes one of the CRDs a resource is split into. For
+// example, the following generator.yaml:
+//
+//	resources:
+//	  Broker:
+//	    splits:
+//	      - name: BrokerEngine
+//	        fields:
+//	          - EngineVersion
+//	          - HostInstanceType
+//	      - name: BrokerSecurity
+//	        fields:
+//	          - AuthenticationStrategy
+//	          - SecurityGroups
+//
+// Generates BrokerEngine and BrokerSecurity CRDs instead of a Broker CRD.
+// The fields of the resource that no split lists, typically its identifiers,
+// are kept by every split, and all splits get the resource's Status fields.
+//
+// A split resource only exists in the backend AWS service API once, so
+// usually only one of its CRDs creates it and the others update it. Before
+// calling the Update operation, the generated code reads the resource with
+// its ReadOne operation and merges the current values of the members of the
+// Update operation's Input shape owned by the other splits, so that they
+// aren't reset. Members are merged when they have the same shape in the
+// ReadOne operation's Output shape.
+//
+// A split without resource config of its own uses a copy of the resource's
+// config. A split with one uses the resource's renames and fields config
+// when it has none.
+type SplitConfig struct {
+	// Name is the name of the CRD
+	Name string `json:"name"`
+	// Fields are the names of the resource's fields the CRD owns
+	Fields []string `json:"fields"`
+}
+
 // ListOperationConfig contains instructions for the code generator to handle
 // List operations for service APIs that have no built-in filtering ability and
 // whose List Operation always returns all objects.
@@ -256,6 +560,37 @@ type ListOperationConfig struct {
 	// MatchFields lists the names of fields in the Shape of the
 	// list element in the List Operation's Output shape.
 	MatchFields []string `json:"match_fields"`
+	// Match lists criteria, possibly over nested members, that the list
+	// element must all meet to be the resource. For example, the following
+	// only reads the repository having the CR's name and scan-on-push
+	// setting, and mutable tags:
+	//
+	//	list_operation:
+	//	  match:
+	//	    - path: RepositoryName
+	//	    - path: ImageScanningConfiguration.ScanOnPush
+	//	    - path: ImageTagMutability
+	//	      value: MUTABLE
+	Match []*ListMatchConfig `json:"match,omitempty"`
+	// MaxPages is the maximum number of pages of the List Operation's results
+	// read looking for the resource, when the List Operation is paginated.
+	// Defaults to 10 pages. Set to 1 to only read the first page.
+	MaxPages int `json:"max_pages,omitempty"`
+}
+
+// ListMatchConfig is a criterion a list element returned by the List
+// Operation must meet to be the resource
+type ListMatchConfig struct {
+	// Path is the dot-notation path of the member of the list element's
+	// Shape to match, e.g. "ImageScanningConfiguration.ScanOnPush". The path
+	// can't traverse lists or maps.
+	Path string `json:"path"`
+	// Field is the dot-notation path of the resource's field the member must
+	// equal, when set in the CR. Defaults to the field the Path is set into.
+	Field string `json:"field,omitempty"`
+	// Value is a literal string value the member must equal, instead of the
+	// value of a field
+	Value *string `json:"value,omitempty"`
 }
 
 // UpdateOperationConfig contains instructions for the code generator to handle
@@ -265,6 +600,38 @@ type UpdateOperationConfig struct {
 	// CustomMethodName is a string for the method name to replace the
 	// sdkUpdate() method implementation for this resource
 	CustomMethodName string `json:"custom_method_name"`
+	// Operations lists, in the order they are called, the API operations
+	// updating groups of the resource's fields. When set, the generated
+	// sdkUpdate() method calls the operations whose fields differ between
+	// the desired and latest states of the resource instead of the
+	// resource's Update operation. For example, the following
+	// generator.yaml:
+	//
+	//	resources:
+	//	  Repository:
+	//	    update_operation:
+	//	      operations:
+	//	        - operation: PutImageScanningConfiguration
+	//	          fields:
+	//	            - ImageScanningConfiguration
+	//	        - operation: PutImageTagMutability
+	//	          fields:
+	//	            - ImageTagMutability
+	//
+	// Updates an ECR Repository's ImageTagMutability field with the
+	// PutImageTagMutability API call. The resource's Update operation can be
+	// listed too, with the fields it updates.
+	Operations []*FieldsOperationConfig `json:"operations,omitempty"`
+}
+
+// FieldsOperationConfig identifies an API operation updating a group of a
+// resource's fields
+type FieldsOperationConfig struct {
+	// Operation is the ID of the API operation
+	Operation string `json:"operation"`
+	// Fields are the names of the resource's Spec fields the operation
+	// updates
+	Fields []string `json:"fields"`
 }
 
 // PrintConfig informs instruct the code generator on how to sort kubebuilder
@@ -279,6 +646,14 @@ type PrintConfig struct {
 	AddAgeColumn bool `json:"add_age_column"`
 	// OrderBy is the field used to sort the list of PrinterColumn options.
 	OrderBy string `json:"order_by"`
+	// Columns are additional printer columns showing the values at
+	// arbitrary JSONPaths of the resource, e.g. the elements of its Status
+	// conditions, that can't be configured on a single field
+	Columns []PrintColumnConfig `json:"columns,omitempty"`
+	// DefaultColumns determines whether the default State, Synced and Age
+	// columns are appended to the printer columns. A default column is
+	// omitted when another column has the same name. Default is true.
+	DefaultColumns *bool `json:"default_columns,omitempty"`
 }
 
 // ReconcileConfig describes options for controlling the reconciliation
@@ -294,6 +669,45 @@ type ReconcileConfig struct {
 	// causing ACK controllers to refresh the status views of all watched resources, but this
 	// behaviour is expensive and may be turned off in future ACK runtime options.
 	RequeueOnSuccessSeconds int `json:"requeue_on_success_seconds,omitempty"`
+	// ObserveOnly indicates the controller only reads the resource, to import
+	// existing AWS resources the other resources can refer to. The generated
+	// code never calls the operations creating, updating or deleting the
+	// resource, so the controller doesn't need the permissions to call them.
+	// Deleting the CR leaves the AWS resource in place.
+	ObserveOnly bool `json:"observe_only,omitempty"`
+	// ErrorRequeues maps the codes of AWS errors to the way the resource is
+	// requeued when they are returned, instead of the exponential backoff of
+	// the controller runtime. For example:
+	//
+	//	reconcile:
+	//	  error_requeues:
+	//	    ThrottlingException:
+	//	      delay_seconds: 30
+	//	    InvalidParameterCombination:
+	//	      delay_seconds: 60
+	//	      max_retries: 5
+	ErrorRequeues map[string]ErrorRequeueConfig `json:"error_requeues,omitempty"`
+	// ResyncSeconds is the number of seconds after which each resource is
+	// requeued once it is reconciled, whether it is synced or not. Unlike
+	// RequeueOnSuccessSeconds, the period is computed for every resource
+	// from its current state, so the resync config of its fields may
+	// override it.
+	ResyncSeconds int `json:"resync_seconds,omitempty"`
+}
+
+// ErrorRequeueConfig describes how a resource is requeued when an AWS error
+// with a particular code is returned
+type ErrorRequeueConfig struct {
+	// DelaySeconds is the number of seconds after which the resource is
+	// requeued. The exponential backoff of the controller runtime is used if
+	// it is 0.
+	DelaySeconds int `json:"delay_seconds,omitempty"`
+	// MaxRetries is the number of times the resource is retried when the
+	// error is returned for the same generation of the resource. Once they
+	// are exhausted, the controller gives up: the resource gets a Terminal
+	// condition and isn't requeued until its Spec changes. The resource is
+	// retried indefinitely if it is 0.
+	MaxRetries int `json:"max_retries,omitempty"`
 }
 
 // ResourceConfig returns the ResourceConfig for a given named resource
@@ -302,6 +716,76 @@ func (c *Config) ResourceConfig(name string) (*ResourceConfig, bool) {
 	return &rc, ok
 }
 
+// BatchOperation returns the batch operation config of a given named
+// resource for the supplied operation ID, if any
+func (c *Config) BatchOperation(
+	resourceName string,
+	opID string,
+) *BatchOperationConfig {
+	if c == nil {
+		return nil
+	}
+	rConfig, found := c.Resources[resourceName]
+	if !found {
+		return nil
+	}
+	return rConfig.BatchOperations[opID]
+}
+
+// ResourceSplits returns the configs of the CRDs a given named resource is
+// split into, if any
+func (c *Config) ResourceSplits(resourceName string) []*SplitConfig {
+	if c == nil {
+		return nil
+	}
+	rConfig, found := c.Resources[resourceName]
+	if !found {
+		return nil
+	}
+	return rConfig.Splits
+}
+
+// applyResourceSplits adds the resource config of the CRDs resources are
+// split into, and returns an error if a split is invalid
+func (c *Config) applyResourceSplits() error {
+	for resName, rConfig := range c.Resources {
+		for _, split := range rConfig.Splits {
+			if split == nil || split.Name == "" {
+				return fmt.Errorf("resource %s: split has no name", resName)
+			}
+			if len(split.Fields) == 0 {
+				return fmt.Errorf(
+					"resource %s: split %s has no fields", resName, split.Name,
+				)
+			}
+			if split.Name == resName {
+				return fmt.Errorf(
+					"resource %s: split has the resource's name", resName,
+				)
+			}
+			splitConfig, found := c.Resources[split.Name]
+			if found && len(splitConfig.Splits) > 0 {
+				return fmt.Errorf(
+					"resource %s: split %s is itself split", resName, split.Name,
+				)
+			}
+			if !found {
+				splitConfig = rConfig
+				splitConfig.Splits = nil
+				splitConfig.Operations = nil
+			}
+			if splitConfig.Renames == nil {
+				splitConfig.Renames = rConfig.Renames
+			}
+			if splitConfig.Fields == nil {
+				splitConfig.Fields = rConfig.Fields
+			}
+			c.Resources[split.Name] = splitConfig
+		}
+	}
+	return nil
+}
+
 // UnpacksAttributesMap returns true if the underlying API has
 // Get{Resource}Attributes/Set{Resource}Attributes API calls that map real,
 // schema'd fields to a raw `map[string]*string` for this resource (see SNS and
@@ -324,6 +808,263 @@ func (c *Config) UnpacksAttributesMap(resourceName string) bool {
 	return false
 }
 
+// AttributesMapMemberName returns the name of the Input and Output shape
+// member holding the attributes map of the supplied resource, "Attributes"
+// unless configured otherwise
+func (c *Config) AttributesMapMemberName(resourceName string) string {
+	if c != nil {
+		resGenConfig, found := c.Resources[resourceName]
+		if found && resGenConfig.UnpackAttributesMapConfig != nil &&
+			resGenConfig.UnpackAttributesMapConfig.MemberName != "" {
+			return resGenConfig.UnpackAttributesMapConfig.MemberName
+		}
+	}
+	return "Attributes"
+}
+
+// AttributeKey returns the key, in the attributes map of the supplied
+// resource, of the value of the supplied attribute field. This is the field
+// name unless the field is configured with an `attribute_key`.
+func (c *Config) AttributeKey(resourceName string, fieldName string) string {
+	fConfig, found := c.ResourceFields(resourceName)[fieldName]
+	if found && fConfig.AttributeKey != "" {
+		return fConfig.AttributeKey
+	}
+	return fieldName
+}
+
+// validateAttributeKeys marks the fields configured with an attribute key as
+// attribute fields, and returns an error if two attribute fields of a
+// resource map to the same attribute key
+func (c *Config) validateAttributeKeys() error {
+	for resName, rConfig := range c.Resources {
+		keyFields := map[string]string{}
+		fieldNames := []string{}
+		for fieldName := range rConfig.Fields {
+			fieldNames = append(fieldNames, fieldName)
+		}
+		sort.Strings(fieldNames)
+		for _, fieldName := range fieldNames {
+			fConfig := rConfig.Fields[fieldName]
+			if fConfig == nil {
+				continue
+			}
+			if fConfig.AttributeKey != "" {
+				fConfig.IsAttribute = true
+			}
+			if !fConfig.IsAttribute {
+				continue
+			}
+			key := c.AttributeKey(resName, fieldName)
+			if other, found := keyFields[key]; found {
+				return fmt.Errorf(
+					"resource %s: fields %s and %s both map to attribute %s",
+					resName, other, fieldName, key,
+				)
+			}
+			keyFields[key] = fieldName
+		}
+	}
+	return nil
+}
+
+// validateFieldChanges returns an error if a field is configured with an
+// unknown on_change value, or with contradictory instructions about how its
+// changes are applied: is_immutable, whose CRD validation rule rejects any
+// change, on_change, is_create_only and is_update_only
+func (c *Config) validateFieldChanges() error {
+	resNames := []string{}
+	for resName := range c.Resources {
+		resNames = append(resNames, resName)
+	}
+	sort.Strings(resNames)
+	for _, resName := range resNames {
+		fields := c.Resources[resName].Fields
+		fieldNames := []string{}
+		for fieldName := range fields {
+			fieldNames = append(fieldNames, fieldName)
+		}
+		sort.Strings(fieldNames)
+		for _, fieldName := range fieldNames {
+			fConfig := fields[fieldName]
+			if fConfig == nil {
+				continue
+			}
+			if fConfig.OnChange != "" && fConfig.OnChange != OnChangeRecreate {
+				return fmt.Errorf(
+					"%s.%s: unknown on_change value %q, must be %q",
+					resName, fieldName, fConfig.OnChange, OnChangeRecreate,
+				)
+			}
+			set := []string{}
+			if fConfig.IsImmutable {
+				set = append(set, "is_immutable")
+			}
+			if fConfig.OnChange != "" {
+				set = append(set, "on_change")
+			}
+			if fConfig.IsCreateOnly {
+				set = append(set, "is_create_only")
+			}
+			if fConfig.IsUpdateOnly {
+				set = append(set, "is_update_only")
+			}
+			if len(set) > 1 {
+				return fmt.Errorf(
+					"%s.%s: only one of %s can be set",
+					resName, fieldName, strings.Join(set, ", "),
+				)
+			}
+		}
+	}
+	return nil
+}
+
+// validateSensitiveFields returns an error if a sensitive field is printed in
+// the `kubectl get` columns or exported to a ConfigMap, which would disclose
+// its value
+func (c *Config) validateSensitiveFields() error {
+	resNames := []string{}
+	for resName := range c.Resources {
+		resNames = append(resNames, resName)
+	}
+	sort.Strings(resNames)
+	for _, resName := range resNames {
+		rConfig := c.Resources[resName]
+		fieldNames := []string{}
+		for fieldName := range rConfig.Fields {
+			fieldNames = append(fieldNames, fieldName)
+		}
+		sort.Strings(fieldNames)
+		for _, fieldName := range fieldNames {
+			fConfig := rConfig.Fields[fieldName]
+			if fConfig == nil || !fConfig.IsSensitive {
+				continue
+			}
+			if fConfig.Print != nil {
+				return fmt.Errorf(
+					"%s.%s: sensitive fields can't be printed", resName, fieldName,
+				)
+			}
+			if rConfig.Export == nil || rConfig.Export.GetKind() != ExportKindConfigMap {
+				continue
+			}
+			for key, path := range rConfig.Export.Fields {
+				if path == "Spec."+fieldName || path == "Status."+fieldName {
+					return fmt.Errorf(
+						"%s.%s: sensitive fields can't be exported to a ConfigMap, see key %q",
+						resName, fieldName, key,
+					)
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// validateTerminalMessages returns an error if a resource's terminal
+// exception messages have no pattern or a pattern that isn't a valid regular
+// expression
+func (c *Config) validateTerminalMessages() error {
+	resNames := []string{}
+	for resName := range c.Resources {
+		resNames = append(resNames, resName)
+	}
+	sort.Strings(resNames)
+	for _, resName := range resNames {
+		exceptions := c.Resources[resName].Exceptions
+		if exceptions == nil {
+			continue
+		}
+		for x, tm := range exceptions.TerminalMessages {
+			if tm.Pattern == "" {
+				return fmt.Errorf(
+					"%s: terminal_messages[%d] must have a pattern", resName, x,
+				)
+			}
+			if _, err := regexp.Compile(tm.Pattern); err != nil {
+				return fmt.Errorf(
+					"%s: terminal_messages[%d] pattern %q: %v",
+					resName, x, tm.Pattern, err,
+				)
+			}
+		}
+	}
+	return nil
+}
+
+// validateErrorRequeues returns an error if a resource's error requeue
+// policy has a negative delay or number of retries, or neither of them
+func (c *Config) validateErrorRequeues() error {
+	resNames := []string{}
+	for resName := range c.Resources {
+		resNames = append(resNames, resName)
+	}
+	sort.Strings(resNames)
+	for _, resName := range resNames {
+		reconcile := c.Resources[resName].Reconcile
+		if reconcile == nil {
+			continue
+		}
+		codes := []string{}
+		for code := range reconcile.ErrorRequeues {
+			codes = append(codes, code)
+		}
+		sort.Strings(codes)
+		for _, code := range codes {
+			policy := reconcile.ErrorRequeues[code]
+			if policy.DelaySeconds < 0 || policy.MaxRetries < 0 {
+				return fmt.Errorf(
+					"%s: error_requeues %s delay_seconds and max_retries must not be negative",
+					resName, code,
+				)
+			}
+			if policy.DelaySeconds == 0 && policy.MaxRetries == 0 {
+				return fmt.Errorf(
+					"%s: error_requeues %s must have delay_seconds or max_retries",
+					resName, code,
+				)
+			}
+		}
+	}
+	return nil
+}
+
+// validateResyncs returns an error if a resource's resync period or the
+// resync period of one of its fields isn't positive
+func (c *Config) validateResyncs() error {
+	resNames := []string{}
+	for resName := range c.Resources {
+		resNames = append(resNames, resName)
+	}
+	sort.Strings(resNames)
+	for _, resName := range resNames {
+		rConfig := c.Resources[resName]
+		if rConfig.Reconcile != nil && rConfig.Reconcile.ResyncSeconds < 0 {
+			return fmt.Errorf(
+				"%s: reconcile resync_seconds must not be negative", resName,
+			)
+		}
+		fieldNames := []string{}
+		for fieldName := range rConfig.Fields {
+			fieldNames = append(fieldNames, fieldName)
+		}
+		sort.Strings(fieldNames)
+		for _, fieldName := range fieldNames {
+			fConfig := rConfig.Fields[fieldName]
+			if fConfig == nil || fConfig.Resync == nil {
+				continue
+			}
+			if fConfig.Resync.Seconds <= 0 {
+				return fmt.Errorf(
+					"%s.%s: resync seconds must be positive", resName, fieldName,
+				)
+			}
+		}
+	}
+	return nil
+}
+
 // SetAttributesSingleAttribute returns true if the supplied resource name has
 // a SetAttributes operation that only actually changes a single attribute at a
 // time. See: SNS SetTopicAttributes API call, which is entirely different from
@@ -382,6 +1123,19 @@ func (c *Config) GetCompareIgnoredFields(resName string) []string {
 	return rConfig.Compare.Ignore
 }
 
+// GetCompareIgnoreDriftFields returns the list of paths of the Spec fields
+// whose differences are excluded from the Delta of the supplied resource
+func (c *Config) GetCompareIgnoreDriftFields(resName string) []string {
+	if c == nil {
+		return nil
+	}
+	rConfig, ok := c.Resources[resName]
+	if !ok || rConfig.Compare == nil {
+		return nil
+	}
+	return rConfig.Compare.IgnoreDrift
+}
+
 // IsIgnoredResource returns true if Operation Name is configured to be ignored
 // in generator config for the AWS service
 func (c *Config) IsIgnoredResource(resourceName string) bool {
@@ -394,9 +1148,25 @@ func (c *Config) IsIgnoredResource(resourceName string) bool {
 	return util.InStrings(resourceName, c.Ignore.ResourceNames)
 }
 
+// IsIgnoredShape returns true if the supplied shape name matches one of the
+// shape names, which may be wildcard patterns, configured to be ignored in
+// generator config for the AWS service
+func (c *Config) IsIgnoredShape(shapeName string) bool {
+	if c == nil {
+		return false
+	}
+	for _, pattern := range c.Ignore.ShapeNames {
+		if matched, err := path.Match(pattern, shapeName); err == nil && matched {
+			return true
+		}
+	}
+	return false
+}
+
 // ResourceFieldRename returns the renamed field for a Resource, a
 // supplied Operation ID and original field name and whether or not a renamed
-// override field name was found
+// override field name was found. Both the Operation IDs and the field names
+// in the renames configuration may be glob or regular expression patterns.
 func (c *Config) ResourceFieldRename(
 	resName string,
 	opID string,
@@ -409,21 +1179,44 @@ func (c *Config) ResourceFieldRename(
 	if !ok {
 		return origFieldName, false
 	}
-	if rConfig.Renames == nil {
-		return origFieldName, false
+	for _, oRenames := range rConfig.Renames.operationRenames(opID) {
+		if oRenames == nil {
+			continue
+		}
+		if renamed, ok := matchRename(oRenames.InputFields, origFieldName); ok {
+			return renamed, true
+		}
+		if renamed, ok := matchRename(oRenames.OutputFields, origFieldName); ok {
+			return renamed, true
+		}
+	}
+	return origFieldName, false
+}
+
+// ResourceFieldRenameIsLiteral returns true if the renamed field for a
+// Resource, a supplied Operation ID and original field name is configured
+// explicitly rather than resulting from a rename pattern
+func (c *Config) ResourceFieldRenameIsLiteral(
+	resName string,
+	opID string,
+	origFieldName string,
+) bool {
+	if c == nil {
+		return false
+	}
+	rConfig, ok := c.Resources[resName]
+	if !ok || rConfig.Renames == nil {
+		return false
 	}
 	oRenames, ok := rConfig.Renames.Operations[opID]
-	if !ok {
-		return origFieldName, false
+	if !ok || oRenames == nil {
+		return false
 	}
-	renamed, ok := oRenames.InputFields[origFieldName]
-	if !ok {
-		renamed, ok = oRenames.OutputFields[origFieldName]
-		if !ok {
-			return origFieldName, false
-		}
+	if _, ok := oRenames.InputFields[origFieldName]; ok {
+		return true
 	}
-	return renamed, true
+	_, ok = oRenames.OutputFields[origFieldName]
+	return ok
 }
 
 // ResourceShortNames returns the CRD list of aliases
@@ -438,6 +1231,18 @@ func (c *Config) ResourceShortNames(resourceName string) []string {
 	return rConfig.ShortNames
 }
 
+// ResourceCategories returns the categories the CRD belongs to
+func (c *Config) ResourceCategories(resourceName string) []string {
+	if c == nil {
+		return nil
+	}
+	rConfig, ok := c.Resources[resourceName]
+	if !ok {
+		return nil
+	}
+	return rConfig.Categories
+}
+
 // ResourceIsAdoptable returns whether the given CRD is adoptable
 func (c *Config) ResourceIsAdoptable(resourceName string) bool {
 	if c == nil {
@@ -454,6 +1259,49 @@ func (c *Config) ResourceIsAdoptable(resourceName string) bool {
 	return *rConfig.IsAdoptable
 }
 
+// ResourceIsUpsert returns whether the given resource's Create operation
+// creates or replaces the resource
+func (c *Config) ResourceIsUpsert(resourceName string) bool {
+	if c == nil {
+		return false
+	}
+	rConfig, ok := c.Resources[resourceName]
+	if !ok {
+		return false
+	}
+	return rConfig.IsUpsert
+}
+
+// ResourceReadBackOnCreate returns whether the given resource is read back
+// right after it is created to default the fields of its Spec
+func (c *Config) ResourceReadBackOnCreate(resourceName string) bool {
+	if c == nil {
+		return false
+	}
+	rConfig, ok := c.Resources[resourceName]
+	if !ok {
+		return false
+	}
+	return rConfig.ReadBackOnCreate
+}
+
+// ResourceAutoIdempotencyToken returns whether the idempotency tokens of the
+// given resource's operations are filled when not set in the CR
+func (c *Config) ResourceAutoIdempotencyToken(resourceName string) bool {
+	if c == nil {
+		return true
+	}
+	rConfig, ok := c.Resources[resourceName]
+	if !ok {
+		return true
+	}
+	// Default to True
+	if rConfig.AutoIdempotencyToken == nil {
+		return true
+	}
+	return *rConfig.AutoIdempotencyToken
+}
+
 // GetResourcePrintOrderByName returns the Printer Column order-by field name
 func (c *Config) GetResourcePrintOrderByName(resourceName string) string {
 	if c == nil {
@@ -0,0 +1,138 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package config_test
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/aws-controllers-k8s/code-generator/pkg/generate/config"
+)
+
+func TestValidateSensitiveFields(t *testing.T) {
+	require := require.New(t)
+
+	dir := writeConfigFiles(t, map[string]string{
+		"valid.yaml": `
+resources:
+  DBInstance:
+    fields:
+      MasterUserPassword:
+        is_sensitive: true
+    export:
+      fields:
+        password: Spec.MasterUserPassword
+`,
+		"printed.yaml": `
+resources:
+  DBInstance:
+    fields:
+      MasterUserPassword:
+        is_sensitive: true
+        print:
+          name: PASSWORD
+`,
+		"configmap.yaml": `
+resources:
+  DBInstance:
+    fields:
+      MasterUserPassword:
+        is_sensitive: true
+    export:
+      kind: ConfigMap
+      fields:
+        password: Spec.MasterUserPassword
+`,
+	})
+
+	// Sensitive fields may be exported to a Secret
+	_, err := config.New(filepath.Join(dir, "valid.yaml"), config.Config{})
+	require.Nil(err)
+
+	_, err = config.New(filepath.Join(dir, "printed.yaml"), config.Config{})
+	require.NotNil(err)
+	require.Contains(err.Error(), "DBInstance.MasterUserPassword: sensitive fields can't be printed")
+
+	_, err = config.New(filepath.Join(dir, "configmap.yaml"), config.Config{})
+	require.NotNil(err)
+	require.Contains(err.Error(), "can't be exported to a ConfigMap")
+}
+
+func TestValidateTerminalMessages_InvalidPattern(t *testing.T) {
+	require := require.New(t)
+
+	dir := writeConfigFiles(t, map[string]string{
+		"generator.yaml": `
+resources:
+  TrainingJob:
+    exceptions:
+      terminal_messages:
+        - code: ValidationException
+          pattern: "^Could not (access|assume role"
+`,
+	})
+	_, err := config.New(filepath.Join(dir, "generator.yaml"), config.Config{})
+	require.NotNil(err)
+	require.Contains(err.Error(), "TrainingJob: terminal_messages[0] pattern")
+}
+
+func TestValidateErrorRequeues(t *testing.T) {
+	require := require.New(t)
+
+	dir := writeConfigFiles(t, map[string]string{
+		"negative.yaml": `
+resources:
+  Endpoint:
+    reconcile:
+      error_requeues:
+        ThrottlingException:
+          delay_seconds: -30
+`,
+		"empty.yaml": `
+resources:
+  Endpoint:
+    reconcile:
+      error_requeues:
+        ThrottlingException: {}
+`,
+	})
+	_, err := config.New(filepath.Join(dir, "negative.yaml"), config.Config{})
+	require.NotNil(err)
+	require.Contains(err.Error(), "Endpoint: error_requeues ThrottlingException delay_seconds and max_retries must not be negative")
+
+	_, err = config.New(filepath.Join(dir, "empty.yaml"), config.Config{})
+	require.NotNil(err)
+	require.Contains(err.Error(), "must have delay_seconds or max_retries")
+}
+
+func TestValidateResyncs(t *testing.T) {
+	require := require.New(t)
+
+	dir := writeConfigFiles(t, map[string]string{
+		"generator.yaml": `
+resources:
+  DBInstance:
+    fields:
+      DBInstanceStatus:
+        resync:
+          values:
+            - creating
+`,
+	})
+	_, err := config.New(filepath.Join(dir, "generator.yaml"), config.Config{})
+	require.NotNil(err)
+	require.Contains(err.Error(), "DBInstance.DBInstanceStatus: resync seconds must be positive")
+}
@@ -0,0 +1,156 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package config
+
+import (
+	"fmt"
+	"sort"
+)
+
+const (
+	// RetryModeStandard retries the failed calls with an exponential
+	// backoff, the default behavior of the AWS SDK
+	RetryModeStandard = "standard"
+	// RetryModeAdaptive retries the failed calls like RetryModeStandard, and
+	// also slows down all the calls to the AWS API while it throttles them,
+	// speeding them up again as they succeed
+	RetryModeAdaptive = "adaptive"
+)
+
+// RetryConfig instructs the code generator how the AWS SDK clients of the
+// controller retry the failed calls to the AWS API. It may be set at the top
+// level of the generator config for all the calls of the service API, and in
+// an operation's config to override the number of attempts and delays of the
+// calls to the operation.
+//
+// For example, the following generator.yaml:
+//
+//	retry:
+//	  mode: adaptive
+//	  max_attempts: 5
+//	  max_delay_ms: 20000
+//	operations:
+//	  CreateDBInstance:
+//	    retry:
+//	      max_attempts: 1
+//
+// Makes up to 5 attempts to call the operations, waiting up to 20 seconds
+// between them, except CreateDBInstance which is never retried, and slows
+// down the calls while the API throttles them.
+type RetryConfig struct {
+	// Mode is "standard" or "adaptive", only at the top level of the
+	// generator config. Defaults to "standard".
+	Mode string `json:"mode,omitempty"`
+	// MaxAttempts is the maximum number of attempts to make a call, including
+	// the first one. Defaults to the AWS SDK's default.
+	MaxAttempts int `json:"max_attempts,omitempty"`
+	// MinDelayMilliseconds is the minimum delay before retrying a call.
+	// Defaults to the AWS SDK's default.
+	MinDelayMilliseconds int `json:"min_delay_ms,omitempty"`
+	// MaxDelayMilliseconds is the maximum delay before retrying a call.
+	// Defaults to the AWS SDK's default.
+	MaxDelayMilliseconds int `json:"max_delay_ms,omitempty"`
+}
+
+// GetMode returns the retry mode
+func (rc *RetryConfig) GetMode() string {
+	if rc == nil || rc.Mode == "" {
+		return RetryModeStandard
+	}
+	return rc.Mode
+}
+
+// validate returns an error if the retry config has an unknown mode, a
+// negative number of attempts or delay, or a minimum delay greater than its
+// maximum delay
+func (rc *RetryConfig) validate() error {
+	switch rc.GetMode() {
+	case RetryModeStandard, RetryModeAdaptive:
+	default:
+		return fmt.Errorf(
+			"unknown retry mode %q, must be %q or %q",
+			rc.Mode, RetryModeStandard, RetryModeAdaptive,
+		)
+	}
+	if rc.MaxAttempts < 0 || rc.MinDelayMilliseconds < 0 || rc.MaxDelayMilliseconds < 0 {
+		return fmt.Errorf("retry max_attempts, min_delay_ms and max_delay_ms must not be negative")
+	}
+	if rc.MaxDelayMilliseconds > 0 && rc.MinDelayMilliseconds > rc.MaxDelayMilliseconds {
+		return fmt.Errorf("retry min_delay_ms must not be greater than max_delay_ms")
+	}
+	return nil
+}
+
+// validateRetries returns an error if the retry config of the service API or
+// of one of its operations is invalid, or if an operation sets a retry mode
+func (c *Config) validateRetries() error {
+	if c.Retry != nil {
+		if err := c.Retry.validate(); err != nil {
+			return err
+		}
+	}
+	for _, opName := range c.OperationRetryNames() {
+		retry := c.Operations[opName].Retry
+		if retry.Mode != "" {
+			return fmt.Errorf("%s: retry mode can only be set for the service API", opName)
+		}
+		if err := retry.validate(); err != nil {
+			return fmt.Errorf("%s: %v", opName, err)
+		}
+	}
+	return nil
+}
+
+// HasRetryConfig returns true if the way the calls to the service API or to
+// one of its operations are retried is configured
+func (c *Config) HasRetryConfig() bool {
+	if c == nil {
+		return false
+	}
+	return c.Retry != nil || len(c.OperationRetryNames()) > 0
+}
+
+// IsAdaptiveRetryMode returns true if the calls to the service API are
+// slowed down while it throttles them
+func (c *Config) IsAdaptiveRetryMode() bool {
+	if c == nil || c.Retry == nil {
+		return false
+	}
+	return c.Retry.GetMode() == RetryModeAdaptive
+}
+
+// OperationRetryNames returns the sorted names of the operations whose calls
+// are retried differently than the other operations
+func (c *Config) OperationRetryNames() []string {
+	opNames := []string{}
+	if c == nil {
+		return opNames
+	}
+	for opName, opConfig := range c.Operations {
+		if opConfig.Retry != nil {
+			opNames = append(opNames, opName)
+		}
+	}
+	sort.Strings(opNames)
+	return opNames
+}
+
+// OperationRetry returns the retry config of the calls to the supplied
+// operation, if any
+func (c *Config) OperationRetry(opName string) *RetryConfig {
+	if c == nil {
+		return nil
+	}
+	return c.Operations[opName].Retry
+}
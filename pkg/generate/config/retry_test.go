@@ -0,0 +1,111 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package config_test
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/aws-controllers-k8s/code-generator/pkg/generate/config"
+)
+
+func TestRetries(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	dir := writeConfigFiles(t, map[string]string{
+		"generator.yaml": `
+retry:
+  mode: adaptive
+  max_attempts: 5
+  max_delay_ms: 20000
+operations:
+  CreateDBInstance:
+    retry:
+      max_attempts: 1
+  DeleteDBInstance:
+    override_values:
+      SkipFinalSnapshot: "true"
+`,
+	})
+	cfg, err := config.New(filepath.Join(dir, "generator.yaml"), config.Config{})
+	require.Nil(err)
+
+	require.True(cfg.HasRetryConfig())
+	assert.True(cfg.IsAdaptiveRetryMode())
+	require.NotNil(cfg.Retry)
+	assert.Equal(5, cfg.Retry.MaxAttempts)
+	assert.Equal(20000, cfg.Retry.MaxDelayMilliseconds)
+	assert.Equal([]string{"CreateDBInstance"}, cfg.OperationRetryNames())
+
+	retry := cfg.OperationRetry("CreateDBInstance")
+	require.NotNil(retry)
+	assert.Equal(1, retry.MaxAttempts)
+	assert.Equal(config.RetryModeStandard, retry.GetMode())
+	assert.Nil(cfg.OperationRetry("DeleteDBInstance"))
+
+	cfg, err = config.New("", config.Config{})
+	require.Nil(err)
+	assert.False(cfg.HasRetryConfig())
+	assert.False(cfg.IsAdaptiveRetryMode())
+}
+
+func TestRetries_Invalid(t *testing.T) {
+	require := require.New(t)
+
+	tests := []struct {
+		name      string
+		config    string
+		expectErr string
+	}{
+		{
+			"unknown mode",
+			`
+retry:
+  mode: legacy
+`,
+			`unknown retry mode "legacy"`,
+		},
+		{
+			"operation mode",
+			`
+operations:
+  CreateDBInstance:
+    retry:
+      mode: adaptive
+`,
+			"CreateDBInstance: retry mode can only be set for the service API",
+		},
+		{
+			"min delay greater than max delay",
+			`
+retry:
+  min_delay_ms: 500
+  max_delay_ms: 100
+`,
+			"retry min_delay_ms must not be greater than max_delay_ms",
+		},
+	}
+	for _, test := range tests {
+		dir := writeConfigFiles(t, map[string]string{
+			"generator.yaml": test.config,
+		})
+		_, err := config.New(filepath.Join(dir, "generator.yaml"), config.Config{})
+		require.NotNil(err, test.name)
+		require.Contains(err.Error(), test.expectErr, test.name)
+	}
+}
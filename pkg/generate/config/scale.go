@@ -0,0 +1,87 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package config
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// ScaleConfig instructs the code generator to expose the `scale` subresource
+// of a CRD whose resources have a replica-like field, e.g. the desired count
+// of an ECS Service, so that `kubectl scale` and the HorizontalPodAutoscaler
+// can change it.
+//
+// For example, the following generator.yaml:
+//
+//	resources:
+//	  Service:
+//	    scale:
+//	      spec_replicas_path: DesiredCount
+//	      status_replicas_path: RunningCount
+//
+// Makes `kubectl scale` set the DesiredCount Spec field of the Service CRD
+// and read the number of replicas from its RunningCount Status field.
+type ScaleConfig struct {
+	// SpecReplicasPath is the name of the integer top-level Spec field
+	// holding the desired number of replicas
+	SpecReplicasPath string `json:"spec_replicas_path"`
+	// StatusReplicasPath is the name of the integer top-level Status field
+	// holding the observed number of replicas. By default a Replicas Status
+	// field is added, mirroring the value of the Spec field last observed
+	// in the AWS resource.
+	StatusReplicasPath string `json:"status_replicas_path,omitempty"`
+}
+
+// validateScales returns an error if the scale subresource of a resource has
+// no Spec field, or if its fields aren't top-level fields
+func (c *Config) validateScales() error {
+	resNames := []string{}
+	for resName := range c.Resources {
+		resNames = append(resNames, resName)
+	}
+	sort.Strings(resNames)
+	for _, resName := range resNames {
+		scale := c.Resources[resName].Scale
+		if scale == nil {
+			continue
+		}
+		if scale.SpecReplicasPath == "" {
+			return fmt.Errorf("%s: scale must have a spec_replicas_path", resName)
+		}
+		for _, path := range []string{scale.SpecReplicasPath, scale.StatusReplicasPath} {
+			if strings.Contains(path, ".") {
+				return fmt.Errorf(
+					"%s: scale replicas path %q must be a top-level field",
+					resName, path,
+				)
+			}
+		}
+	}
+	return nil
+}
+
+// ResourceScale returns the instructions to expose the scale subresource of
+// the given resource's CRD, if any
+func (c *Config) ResourceScale(resourceName string) *ScaleConfig {
+	if c == nil {
+		return nil
+	}
+	rConfig, ok := c.Resources[resourceName]
+	if !ok {
+		return nil
+	}
+	return rConfig.Scale
+}
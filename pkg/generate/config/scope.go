@@ -0,0 +1,87 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package config
+
+import (
+	"fmt"
+	"sort"
+)
+
+const (
+	// ScopeNamespaced makes the resources of a CRD namespaced objects, the
+	// default
+	ScopeNamespaced = "Namespaced"
+	// ScopeCluster makes the resources of a CRD cluster-scoped objects, for
+	// the AWS resources that are unique within the account and region, e.g.
+	// account settings
+	ScopeCluster = "Cluster"
+)
+
+// validateScopes returns an error if a resource has an unknown scope, or if
+// a cluster-scoped resource exports its fields or is owned by the objects it
+// references, which are namespaced
+func (c *Config) validateScopes() error {
+	resNames := []string{}
+	for resName := range c.Resources {
+		resNames = append(resNames, resName)
+	}
+	sort.Strings(resNames)
+	for _, resName := range resNames {
+		rConfig := c.Resources[resName]
+		switch rConfig.Scope {
+		case "", ScopeNamespaced:
+			continue
+		case ScopeCluster:
+		default:
+			return fmt.Errorf(
+				"%s: unknown scope %q, must be %q or %q",
+				resName, rConfig.Scope, ScopeNamespaced, ScopeCluster,
+			)
+		}
+		if rConfig.Export != nil {
+			return fmt.Errorf(
+				"%s: export is not supported on %s scoped resources",
+				resName, ScopeCluster,
+			)
+		}
+		fieldNames := []string{}
+		for fieldName := range rConfig.Fields {
+			fieldNames = append(fieldNames, fieldName)
+		}
+		sort.Strings(fieldNames)
+		for _, fieldName := range fieldNames {
+			fConfig := rConfig.Fields[fieldName]
+			if fConfig != nil && fConfig.References != nil && fConfig.References.Owner {
+				return fmt.Errorf(
+					"%s.%s: references owner is not supported on %s scoped resources",
+					resName, fieldName, ScopeCluster,
+				)
+			}
+		}
+	}
+	return nil
+}
+
+// ResourceIsClusterScoped returns true if the given resource's objects are
+// cluster-scoped instead of namespaced
+func (c *Config) ResourceIsClusterScoped(resourceName string) bool {
+	if c == nil {
+		return false
+	}
+	rConfig, ok := c.Resources[resourceName]
+	if !ok {
+		return false
+	}
+	return rConfig.Scope == ScopeCluster
+}
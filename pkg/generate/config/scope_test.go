@@ -0,0 +1,100 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package config_test
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/aws-controllers-k8s/code-generator/pkg/generate/config"
+)
+
+func TestScopes(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	dir := writeConfigFiles(t, map[string]string{
+		"generator.yaml": `
+resources:
+  AccountSettings:
+    scope: Cluster
+  Broker:
+    scope: Namespaced
+`,
+	})
+	cfg, err := config.New(filepath.Join(dir, "generator.yaml"), config.Config{})
+	require.Nil(err)
+	assert.True(cfg.ResourceIsClusterScoped("AccountSettings"))
+	assert.False(cfg.ResourceIsClusterScoped("Broker"))
+	assert.False(cfg.ResourceIsClusterScoped("User"))
+}
+
+func TestScopes_Invalid(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		wantErr string
+	}{
+		{
+			name: "unknown scope",
+			content: `
+resources:
+  Broker:
+    scope: Region
+`,
+			wantErr: `Broker: unknown scope "Region"`,
+		},
+		{
+			name: "cluster-scoped export",
+			content: `
+resources:
+  Broker:
+    scope: Cluster
+    export:
+      fields:
+        arn: Status.ACKResourceMetadata.ARN
+`,
+			wantErr: "Broker: export is not supported on Cluster scoped resources",
+		},
+		{
+			name: "cluster-scoped owner reference",
+			content: `
+resources:
+  Route:
+    scope: Cluster
+    fields:
+      ApiId:
+        references:
+          api_version: apigatewayv2.services.k8s.aws/v1alpha1
+          kind: API
+          path: .status.apiID
+          owner: true
+`,
+			wantErr: "Route.ApiId: references owner is not supported on Cluster scoped resources",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require := require.New(t)
+
+			dir := writeConfigFiles(t, map[string]string{"generator.yaml": tt.content})
+			_, err := config.New(filepath.Join(dir, "generator.yaml"), config.Config{})
+			require.NotNil(err)
+			require.Contains(err.Error(), tt.wantErr)
+		})
+	}
+}
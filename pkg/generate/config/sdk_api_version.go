@@ -0,0 +1,45 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package config
+
+// ResourceSDKAPIVersion returns the version of the service's API model the
+// given resource is generated from, as configured, or an empty string if the
+// resource is generated from the API model the generator loads for the
+// service
+func (c *Config) ResourceSDKAPIVersion(resourceName string) string {
+	if c == nil {
+		return ""
+	}
+	rConfig, ok := c.Resources[resourceName]
+	if !ok {
+		return ""
+	}
+	return rConfig.SDKAPIVersion
+}
+
+// ResourceSDKAPIVersions returns a map, keyed by resource name, of the
+// versions of the service's API model the resources are configured to be
+// generated from
+func (c *Config) ResourceSDKAPIVersions() map[string]string {
+	versions := map[string]string{}
+	if c == nil {
+		return versions
+	}
+	for resName, rConfig := range c.Resources {
+		if rConfig.SDKAPIVersion != "" {
+			versions[resName] = rConfig.SDKAPIVersion
+		}
+	}
+	return versions
+}
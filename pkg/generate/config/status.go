@@ -0,0 +1,112 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package config
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// StatusConfig instructs the code generator how the Status of a CRD is
+// exposed, for the resources whose Status is huge, e.g. EC2 Instances.
+//
+// For example, the following generator.yaml:
+//
+//	resources:
+//	  Instance:
+//	    status:
+//	      prune_fields:
+//	        - BlockDeviceMappings
+//	        - NetworkInterfaces
+//
+// Removes the BlockDeviceMappings and NetworkInterfaces Status fields from
+// the schema of the Instance CRD.
+type StatusConfig struct {
+	// Subresource determines whether the CRD has the status subresource.
+	// Without it the Status is written along with the rest of the resource,
+	// which the controller's runtime must support. Default is true.
+	Subresource *bool `json:"subresource,omitempty"`
+	// PruneFields are the names of the top-level Status fields removed from
+	// the CRD schema. The fields are still set from the AWS resource in the
+	// Go types, for the controller's hooks to use while reconciling the
+	// resource, but they are neither stored nor shown in the resource.
+	PruneFields []string `json:"prune_fields,omitempty"`
+}
+
+// HasSubresource returns true if the CRD has the status subresource
+func (s *StatusConfig) HasSubresource() bool {
+	return s == nil || s.Subresource == nil || *s.Subresource
+}
+
+// validateStatuses returns an error if a pruned Status field isn't a
+// top-level field, or if it is printed, exported or holds the replicas of
+// the scale subresource, which need the field in the CRD schema
+func (c *Config) validateStatuses() error {
+	resNames := []string{}
+	for resName := range c.Resources {
+		resNames = append(resNames, resName)
+	}
+	sort.Strings(resNames)
+	for _, resName := range resNames {
+		rConfig := c.Resources[resName]
+		if rConfig.Status == nil {
+			continue
+		}
+		for _, fieldName := range rConfig.Status.PruneFields {
+			if strings.Contains(fieldName, ".") {
+				return fmt.Errorf(
+					"%s: pruned Status field %q must be a top-level field",
+					resName, fieldName,
+				)
+			}
+			if fConfig := rConfig.Fields[fieldName]; fConfig != nil && fConfig.Print != nil {
+				return fmt.Errorf(
+					"%s: pruned Status field %s can't be printed",
+					resName, fieldName,
+				)
+			}
+			if rConfig.Export != nil {
+				for key, path := range rConfig.Export.Fields {
+					if path == "Status."+fieldName || strings.HasPrefix(path, "Status."+fieldName+".") {
+						return fmt.Errorf(
+							"%s: pruned Status field %s can't be exported to key %q",
+							resName, fieldName, key,
+						)
+					}
+				}
+			}
+			if rConfig.Scale != nil && rConfig.Scale.StatusReplicasPath == fieldName {
+				return fmt.Errorf(
+					"%s: pruned Status field %s can't be the scale status_replicas_path",
+					resName, fieldName,
+				)
+			}
+		}
+	}
+	return nil
+}
+
+// ResourceStatus returns the instructions how the Status of the given
+// resource's CRD is exposed, if any
+func (c *Config) ResourceStatus(resourceName string) *StatusConfig {
+	if c == nil {
+		return nil
+	}
+	rConfig, ok := c.Resources[resourceName]
+	if !ok {
+		return nil
+	}
+	return rConfig.Status
+}
@@ -0,0 +1,83 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package config_test
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/aws-controllers-k8s/code-generator/pkg/generate/config"
+)
+
+func TestStatuses_Invalid(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		wantErr string
+	}{
+		{
+			name: "nested pruned field",
+			content: `
+resources:
+  Instance:
+    status:
+      prune_fields:
+        - State.Name
+`,
+			wantErr: `Instance: pruned Status field "State.Name" must be a top-level field`,
+		},
+		{
+			name: "printed pruned field",
+			content: `
+resources:
+  Instance:
+    fields:
+      PublicIpAddress:
+        is_read_only: true
+        print:
+          name: IP
+    status:
+      prune_fields:
+        - PublicIpAddress
+`,
+			wantErr: "Instance: pruned Status field PublicIpAddress can't be printed",
+		},
+		{
+			name: "exported pruned field",
+			content: `
+resources:
+  Instance:
+    export:
+      fields:
+        ip: Status.PublicIpAddress
+    status:
+      prune_fields:
+        - PublicIpAddress
+`,
+			wantErr: `Instance: pruned Status field PublicIpAddress can't be exported to key "ip"`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require := require.New(t)
+
+			dir := writeConfigFiles(t, map[string]string{"generator.yaml": tt.content})
+			_, err := config.New(filepath.Join(dir, "generator.yaml"), config.Config{})
+			require.NotNil(err)
+			require.Contains(err.Error(), tt.wantErr)
+		})
+	}
+}
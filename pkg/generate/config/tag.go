@@ -0,0 +1,37 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package config
+
+import "fmt"
+
+const (
+	// TagFormatMap represents tags as a map[string]*string, keyed by the tag
+	// key
+	TagFormatMap = "map"
+	// TagFormatList represents tags as a list of Tag structs, each having a
+	// Key and a Value
+	TagFormatList = "list"
+)
+
+// validTagFormat returns an error if the supplied tag format is unknown
+func validTagFormat(format string) error {
+	switch format {
+	case "", TagFormatMap, TagFormatList:
+		return nil
+	}
+	return fmt.Errorf(
+		"unknown tag_format %q, must be %q or %q",
+		format, TagFormatMap, TagFormatList,
+	)
+}
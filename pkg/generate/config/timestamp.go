@@ -0,0 +1,96 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package config
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+const (
+	// TimestampFormatTime represents timestamps as metav1.Time values
+	TimestampFormatTime = "time"
+	// TimestampFormatRFC3339 represents timestamps as strings in the RFC 3339
+	// format, e.g. "2021-03-04T05:06:07Z"
+	TimestampFormatRFC3339 = "rfc3339"
+	// TimestampFormatEpoch represents timestamps as the number of seconds
+	// since the Unix epoch
+	TimestampFormatEpoch = "epoch"
+)
+
+// GetTimestampFormat returns how the timestamp fields of the API's resources,
+// and of the structs used in those resources, are represented
+func (c *Config) GetTimestampFormat() string {
+	if c == nil || c.TimestampFormat == "" {
+		return TimestampFormatTime
+	}
+	return c.TimestampFormat
+}
+
+// validTimestampFormat returns an error if the supplied timestamp format is
+// unknown
+func validTimestampFormat(format string) error {
+	switch format {
+	case "", TimestampFormatTime, TimestampFormatRFC3339, TimestampFormatEpoch:
+		return nil
+	}
+	return fmt.Errorf(
+		"unknown timestamp_format %q, must be %q, %q or %q", format,
+		TimestampFormatTime, TimestampFormatRFC3339, TimestampFormatEpoch,
+	)
+}
+
+// validateFieldFormats returns an error if the API-wide timestamp format or
+// the timestamp or number format of any field is unknown, or if a nested
+// field has a format. The Go types of nested fields are shared by every field
+// using the same struct, so they can only use the API-wide formats.
+func (c *Config) validateFieldFormats() error {
+	if err := validTimestampFormat(c.TimestampFormat); err != nil {
+		return err
+	}
+	resourceNames := make([]string, 0, len(c.Resources))
+	for resourceName := range c.Resources {
+		resourceNames = append(resourceNames, resourceName)
+	}
+	sort.Strings(resourceNames)
+	for _, resourceName := range resourceNames {
+		fields := c.Resources[resourceName].Fields
+		fieldNames := make([]string, 0, len(fields))
+		for fieldName := range fields {
+			fieldNames = append(fieldNames, fieldName)
+		}
+		sort.Strings(fieldNames)
+		for _, fieldName := range fieldNames {
+			fieldCfg := fields[fieldName]
+			if fieldCfg == nil ||
+				(fieldCfg.TimestampFormat == "" && fieldCfg.NumberFormat == "") {
+				continue
+			}
+			if strings.Contains(fieldName, ".") {
+				return fmt.Errorf(
+					"%s.%s: timestamp_format and number_format are only supported on top-level fields",
+					resourceName, fieldName,
+				)
+			}
+			if err := validTimestampFormat(fieldCfg.TimestampFormat); err != nil {
+				return fmt.Errorf("%s.%s: %v", resourceName, fieldName, err)
+			}
+			if err := validNumberFormat(fieldCfg.NumberFormat); err != nil {
+				return fmt.Errorf("%s.%s: %v", resourceName, fieldName, err)
+			}
+		}
+	}
+	return nil
+}
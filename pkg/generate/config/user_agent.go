@@ -0,0 +1,80 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package config
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// UserAgentConfig instructs the code generator to add metadata to the
+// User-Agent of the calls the controller makes to the AWS service API. The
+// name and version of the controller and the kind of the resource the calls
+// are made for are always added.
+//
+// For example, the following generator.yaml:
+//
+//	user_agent:
+//	  extra:
+//	    team: databases
+//
+// Makes the controller send a User-Agent ending like
+// `rds-controller/v0.0.1 (kind/DBInstance; team/databases)`.
+type UserAgentConfig struct {
+	// Extra maps the keys of the additional metadata to their values
+	Extra map[string]string `json:"extra,omitempty"`
+}
+
+// validate returns an error if a key or value of the extra metadata is empty
+// or contains characters that aren't allowed in a User-Agent token
+func (uc *UserAgentConfig) validate() error {
+	for _, key := range uc.ExtraKeys() {
+		for _, token := range []string{key, uc.Extra[key]} {
+			if token == "" || strings.ContainsAny(token, " \t/;()") {
+				return fmt.Errorf(
+					"user_agent extra %q: keys and values must be non-empty and must not contain spaces, '/', ';' or parentheses",
+					key,
+				)
+			}
+		}
+	}
+	return nil
+}
+
+// ExtraKeys returns the sorted keys of the extra metadata
+func (uc *UserAgentConfig) ExtraKeys() []string {
+	keys := []string{}
+	if uc == nil {
+		return keys
+	}
+	for key := range uc.Extra {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// UserAgentExtra returns the extra metadata added to the User-Agent, as
+// sorted "key/value" strings
+func (c *Config) UserAgentExtra() []string {
+	extra := []string{}
+	if c == nil {
+		return extra
+	}
+	for _, key := range c.UserAgent.ExtraKeys() {
+		extra = append(extra, key+"/"+c.UserAgent.Extra[key])
+	}
+	return extra
+}
@@ -0,0 +1,260 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+var jsonUnmarshalerType = reflect.TypeOf((*json.Unmarshaler)(nil)).Elem()
+
+// FieldError describes an unknown key found in a generator config file
+type FieldError struct {
+	// Line is the line number of the unknown key
+	Line int
+	// Column is the column number of the unknown key
+	Column int
+	// Path is the dotted path of the object containing the unknown key, e.g.
+	// "resources.Repository"
+	Path string
+	// Key is the unknown key
+	Key string
+	// Suggestion is the closest known key, if any is close enough to be a
+	// likely typo
+	Suggestion string
+}
+
+func (e *FieldError) Error() string {
+	msg := fmt.Sprintf("%d:%d: unknown field %q", e.Line, e.Column, e.Key)
+	if e.Path != "" {
+		msg += fmt.Sprintf(" in %s", e.Path)
+	}
+	if e.Suggestion != "" {
+		msg += fmt.Sprintf(" (did you mean %q?)", e.Suggestion)
+	}
+	return msg
+}
+
+// ValidationError contains all the unknown keys found in a generator config
+// file
+type ValidationError struct {
+	// Source is the path to the generator config file
+	Source string
+	Errors []*FieldError
+}
+
+func (e *ValidationError) Error() string {
+	msgs := make([]string, 0, len(e.Errors))
+	for _, fe := range e.Errors {
+		msgs = append(msgs, e.Source+":"+fe.Error())
+	}
+	return "invalid generator config:\n" + strings.Join(msgs, "\n")
+}
+
+// Validate checks that the supplied generator config YAML document only
+// contains keys known to the Config struct, returning a *ValidationError
+// listing the position of every unknown key otherwise. Type mismatches are
+// not checked here and are left to the YAML decoder to report.
+func Validate(source string, content []byte) error {
+	doc := yaml.Node{}
+	if err := yaml.Unmarshal(content, &doc); err != nil {
+		return fmt.Errorf("%s: %v", source, err)
+	}
+	errs := []*FieldError{}
+	validateNode(&doc, reflect.TypeOf(Config{}), "", &errs)
+	if len(errs) > 0 {
+		return &ValidationError{
+			Source: source,
+			Errors: errs,
+		}
+	}
+	return nil
+}
+
+// validateNode walks a YAML node alongside the Go type it will be decoded
+// into, recording any mapping keys that have no corresponding struct field
+func validateNode(
+	node *yaml.Node,
+	t reflect.Type,
+	path string,
+	errs *[]*FieldError,
+) {
+	switch node.Kind {
+	case yaml.DocumentNode:
+		for _, child := range node.Content {
+			validateNode(child, t, path, errs)
+		}
+		return
+	case yaml.AliasNode:
+		validateNode(node.Alias, t, path, errs)
+		return
+	}
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	// Types with custom decoding accept whatever they please
+	if reflect.PtrTo(t).Implements(jsonUnmarshalerType) {
+		return
+	}
+
+	switch t.Kind() {
+	case reflect.Struct:
+		if node.Kind != yaml.MappingNode {
+			return
+		}
+		fields := structFields(t)
+		for x := 0; x+1 < len(node.Content); x += 2 {
+			keyNode, valNode := node.Content[x], node.Content[x+1]
+			if keyNode.Value == "<<" {
+				validateNode(valNode, t, path, errs)
+				continue
+			}
+			fieldType, found := lookupField(fields, keyNode.Value)
+			if !found {
+				*errs = append(*errs, &FieldError{
+					Line:       keyNode.Line,
+					Column:     keyNode.Column,
+					Path:       path,
+					Key:        keyNode.Value,
+					Suggestion: suggestField(fields, keyNode.Value),
+				})
+				continue
+			}
+			validateNode(valNode, fieldType, joinPath(path, keyNode.Value), errs)
+		}
+	case reflect.Map:
+		if node.Kind != yaml.MappingNode {
+			return
+		}
+		for x := 0; x+1 < len(node.Content); x += 2 {
+			keyNode, valNode := node.Content[x], node.Content[x+1]
+			validateNode(valNode, t.Elem(), joinPath(path, keyNode.Value), errs)
+		}
+	case reflect.Slice, reflect.Array:
+		if node.Kind != yaml.SequenceNode {
+			return
+		}
+		for x, item := range node.Content {
+			validateNode(item, t.Elem(), fmt.Sprintf("%s[%d]", path, x), errs)
+		}
+	}
+}
+
+// structFields returns a map, keyed by JSON name, of the types of the fields
+// of the supplied struct type, including the fields of embedded structs
+func structFields(t reflect.Type) map[string]reflect.Type {
+	fields := map[string]reflect.Type{}
+	for x := 0; x < t.NumField(); x++ {
+		f := t.Field(x)
+		name := strings.Split(f.Tag.Get("json"), ",")[0]
+		if name == "-" {
+			continue
+		}
+		if name == "" {
+			if f.Anonymous && f.Type.Kind() == reflect.Struct {
+				for embeddedName, embeddedType := range structFields(f.Type) {
+					fields[embeddedName] = embeddedType
+				}
+				continue
+			}
+			name = f.Name
+		}
+		fields[name] = f.Type
+	}
+	return fields
+}
+
+// lookupField returns the type of the struct field with the supplied JSON
+// name. Like encoding/json, which ultimately decodes the generator config,
+// the match is case-insensitive.
+func lookupField(
+	fields map[string]reflect.Type,
+	key string,
+) (reflect.Type, bool) {
+	if t, found := fields[key]; found {
+		return t, true
+	}
+	for name, t := range fields {
+		if strings.EqualFold(name, key) {
+			return t, true
+		}
+	}
+	return nil, false
+}
+
+// suggestField returns the known field name closest to the supplied unknown
+// key, or the empty string if none is close enough to be a likely typo
+func suggestField(fields map[string]reflect.Type, key string) string {
+	best := ""
+	bestDistance := 0
+	for name := range fields {
+		d := levenshtein(strings.ToLower(key), strings.ToLower(name))
+		if best == "" || d < bestDistance || (d == bestDistance && name < best) {
+			best, bestDistance = name, d
+		}
+	}
+	maxDistance := len(key) / 3
+	if maxDistance < 2 {
+		maxDistance = 2
+	}
+	if best == "" || bestDistance > maxDistance {
+		return ""
+	}
+	return best
+}
+
+// levenshtein returns the edit distance between two strings
+func levenshtein(a, b string) int {
+	ra, rb := []rune(a), []rune(b)
+	prev := make([]int, len(rb)+1)
+	curr := make([]int, len(rb)+1)
+	for y := range prev {
+		prev[y] = y
+	}
+	for x := 1; x <= len(ra); x++ {
+		curr[0] = x
+		for y := 1; y <= len(rb); y++ {
+			cost := 1
+			if ra[x-1] == rb[y-1] {
+				cost = 0
+			}
+			curr[y] = min3(prev[y]+1, curr[y-1]+1, prev[y-1]+cost)
+		}
+		prev, curr = curr, prev
+	}
+	return prev[len(rb)]
+}
+
+func min3(a, b, c int) int {
+	if b < a {
+		a = b
+	}
+	if c < a {
+		a = c
+	}
+	return a
+}
+
+// joinPath appends a key to a dotted path
+func joinPath(path string, key string) string {
+	if path == "" {
+		return key
+	}
+	return path + "." + key
+}
@@ -0,0 +1,83 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package config_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/aws-controllers-k8s/code-generator/pkg/generate/config"
+)
+
+func TestValidate_Valid(t *testing.T) {
+	content := `
+resources:
+  Repository:
+    fields:
+      Name:
+        is_primary_key: true
+    exceptions:
+      errors:
+        404:
+          code: RepositoryNotFoundException
+operations:
+  CreateRepository:
+    operation_type: Create
+ignore:
+  resource_names:
+  - Policy
+`
+	assert.Nil(t, config.Validate("generator.yaml", []byte(content)))
+}
+
+func TestValidate_UnknownFields(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	content := `resources:
+  Repository:
+    feilds:
+      Name:
+        is_primary_key: true
+  Topic:
+    fields:
+      Name:
+        is_primray_key: true
+        totally_unrelated: true
+`
+	err := config.Validate("generator.yaml", []byte(content))
+	require.NotNil(err)
+	verr, ok := err.(*config.ValidationError)
+	require.True(ok)
+	require.Len(verr.Errors, 3)
+
+	assert.Equal(3, verr.Errors[0].Line)
+	assert.Equal(5, verr.Errors[0].Column)
+	assert.Equal("resources.Repository", verr.Errors[0].Path)
+	assert.Equal("feilds", verr.Errors[0].Key)
+	assert.Equal("fields", verr.Errors[0].Suggestion)
+
+	assert.Equal(9, verr.Errors[1].Line)
+	assert.Equal("resources.Topic.fields.Name", verr.Errors[1].Path)
+	assert.Equal("is_primary_key", verr.Errors[1].Suggestion)
+
+	assert.Equal("", verr.Errors[2].Suggestion)
+
+	assert.Contains(
+		err.Error(),
+		`generator.yaml:3:5: unknown field "feilds" in resources.Repository (did you mean "fields"?)`,
+	)
+}
@@ -1,13 +1,17 @@
 package olm
 
 import (
+	"encoding/json"
 	"fmt"
 	"strings"
 	ttpl "text/template"
 	"time"
 
+	"github.com/ghodss/yaml"
+
 	"github.com/aws-controllers-k8s/code-generator/pkg/generate/templateset"
 	ackmodel "github.com/aws-controllers-k8s/code-generator/pkg/model"
+	"github.com/aws-controllers-k8s/code-generator/pkg/names"
 	opsv1alpha1 "github.com/operator-framework/api/pkg/operators/v1alpha1"
 )
 
@@ -57,6 +61,8 @@ func BundleAssets(
 		return nil, err
 	}
 
+	serviceConfig.Samples = withoutDeprecatedFields(serviceConfig.Samples, crds)
+	serviceConfig.Samples = withAPIGroups(serviceConfig.Samples, crds, m.APIGroup())
 	olmVars := templateOLMVars{
 		vers,
 		time.Now().Format("2006-01-02 15:04:05"),
@@ -96,6 +102,71 @@ type templateOLMVars struct {
 	CRDs []*ackmodel.CRD
 }
 
+// withAPIGroups returns the supplied samples with the API group of their kind
+// set, defaulting to the supplied API group of the service for the samples
+// whose kind isn't a CRD.
+func withAPIGroups(
+	samples []Sample,
+	crds []*ackmodel.CRD,
+	defaultGroup string,
+) []Sample {
+	result := make([]Sample, 0, len(samples))
+	for _, sample := range samples {
+		sample.APIGroup = defaultGroup
+		for _, crd := range crds {
+			if crd.Kind == sample.Kind {
+				sample.APIGroup = crd.APIGroup()
+				break
+			}
+		}
+		result = append(result, sample)
+	}
+	return result
+}
+
+// withoutDeprecatedFields returns the supplied samples without the
+// top-level deprecated Spec fields of their kind, so that the samples don't
+// promote them. The Spec of the samples whose fields are removed is
+// rewritten as a single-line YAML flow mapping. The samples whose Spec can't
+// be parsed are returned as is.
+func withoutDeprecatedFields(
+	samples []Sample,
+	crds []*ackmodel.CRD,
+) []Sample {
+	result := make([]Sample, 0, len(samples))
+	for _, sample := range samples {
+		for _, crd := range crds {
+			if crd.Kind != sample.Kind || !crd.HasDeprecatedFields() {
+				continue
+			}
+			spec := map[string]interface{}{}
+			if err := yaml.Unmarshal([]byte(sample.Spec), &spec); err != nil {
+				break
+			}
+			removed := false
+			for _, path := range crd.GetDeprecatedFieldPaths() {
+				if strings.Contains(path, ".") {
+					continue
+				}
+				key := names.New(path).CamelLower
+				if _, found := spec[key]; found {
+					delete(spec, key)
+					removed = true
+				}
+			}
+			if removed {
+				// JSON is valid YAML and keeps the Spec on a single line
+				if content, err := json.Marshal(spec); err == nil {
+					sample.Spec = string(content)
+				}
+			}
+			break
+		}
+		result = append(result, sample)
+	}
+	return result
+}
+
 // DefaultServiceConfig returns a default representation of ServiceConfig to be
 // used as a base. The various values are expected to be overridden (if
 // needed) by an input YAML manifest for the given service controller.
@@ -70,4 +70,7 @@ type Sample struct {
 	Spec        string `json:"spec"`
 	DisplayName string `json:"displayName"`
 	Description string `json:"description"`
+	// APIGroup is the API group of the sample's kind. It is set by the
+	// generator, not read from the OLM config.
+	APIGroup string `json:"-"`
 }
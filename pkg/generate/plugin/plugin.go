@@ -0,0 +1,109 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package plugin
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	ackgenconfig "github.com/aws-controllers-k8s/code-generator/pkg/generate/config"
+	ackgenlog "github.com/aws-controllers-k8s/code-generator/pkg/log"
+	ackmodel "github.com/aws-controllers-k8s/code-generator/pkg/model"
+)
+
+var log = ackgenlog.Log().WithName("plugin")
+
+// Request is the JSON document written to a plugin's standard input
+type Request struct {
+	// Target is the ack-generate command the plugin runs after, e.g.
+	// "controller"
+	Target string `json:"target"`
+	// Model is the serializable representation of the code generation model
+	Model *ackmodel.Document `json:"model"`
+}
+
+// Response is the JSON document a plugin writes to its standard output
+type Response struct {
+	// Files is a map, keyed by path relative to the output directory, of the
+	// contents of the files to emit
+	Files map[string]string `json:"files"`
+}
+
+// Run executes the supplied plugin, passing it the request on its standard
+// input, and returns the files it emitted keyed by their path relative to the
+// output directory. configDir is the directory containing the generator
+// config file, against which relative plugin commands are resolved.
+func Run(
+	ctx context.Context,
+	cfg ackgenconfig.PluginConfig,
+	configDir string,
+	req *Request,
+) (map[string][]byte, error) {
+	if cfg.Command == "" {
+		return nil, fmt.Errorf("plugin %q: no command specified", cfg.Name)
+	}
+	command := cfg.Command
+	if !filepath.IsAbs(command) && strings.ContainsRune(command, filepath.Separator) {
+		command = filepath.Join(configDir, command)
+	}
+
+	input, err := json.Marshal(req)
+	if err != nil {
+		return nil, err
+	}
+	log.V(1).Info("running plugin", "plugin", cfg.Name, "command", command, "target", req.Target)
+	var stdout, stderr bytes.Buffer
+	cmd := exec.CommandContext(ctx, command, cfg.Args...)
+	cmd.Stdin = bytes.NewReader(input)
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if err = cmd.Run(); err != nil {
+		return nil, fmt.Errorf(
+			"plugin %q failed: %v: %s",
+			cfg.Name, err, strings.TrimSpace(stderr.String()),
+		)
+	}
+
+	resp := Response{}
+	if err = json.Unmarshal(stdout.Bytes(), &resp); err != nil {
+		return nil, fmt.Errorf("plugin %q returned invalid output: %v", cfg.Name, err)
+	}
+	files := make(map[string][]byte, len(resp.Files))
+	for path, contents := range resp.Files {
+		cleanPath, err := cleanOutputPath(path)
+		if err != nil {
+			return nil, fmt.Errorf("plugin %q: %v", cfg.Name, err)
+		}
+		files[cleanPath] = []byte(contents)
+		log.V(2).Info("plugin emitted file", "plugin", cfg.Name, "path", cleanPath)
+	}
+	return files, nil
+}
+
+// cleanOutputPath returns the cleaned version of a path emitted by a plugin,
+// or an error if the path would be written outside of the output directory
+func cleanOutputPath(path string) (string, error) {
+	cleanPath := filepath.Clean(filepath.FromSlash(path))
+	if path == "" || filepath.IsAbs(cleanPath) ||
+		cleanPath == ".." ||
+		strings.HasPrefix(cleanPath, ".."+string(filepath.Separator)) {
+		return "", fmt.Errorf("invalid output path %q", path)
+	}
+	return cleanPath, nil
+}
@@ -0,0 +1,72 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package plugin_test
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	ackgenconfig "github.com/aws-controllers-k8s/code-generator/pkg/generate/config"
+	"github.com/aws-controllers-k8s/code-generator/pkg/generate/plugin"
+)
+
+func TestRun(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	cfg := ackgenconfig.PluginConfig{
+		Name:    "echo",
+		Command: "sh",
+		Args: []string{
+			"-c",
+			`cat > /dev/null; printf '%s' '{"files": {"pkg/extra/extra.go": "package extra\n"}}'`,
+		},
+	}
+	files, err := plugin.Run(context.TODO(), cfg, "", &plugin.Request{Target: "controller"})
+	require.Nil(err)
+	assert.Equal(
+		map[string][]byte{
+			filepath.Join("pkg", "extra", "extra.go"): []byte("package extra\n"),
+		},
+		files,
+	)
+}
+
+func TestRun_InvalidOutputPath(t *testing.T) {
+	cfg := ackgenconfig.PluginConfig{
+		Name:    "escape",
+		Command: "sh",
+		Args: []string{
+			"-c",
+			`cat > /dev/null; printf '%s' '{"files": {"../outside.go": ""}}'`,
+		},
+	}
+	_, err := plugin.Run(context.TODO(), cfg, "", &plugin.Request{Target: "controller"})
+	assert.NotNil(t, err)
+}
+
+func TestRun_Failure(t *testing.T) {
+	cfg := ackgenconfig.PluginConfig{
+		Name:    "fail",
+		Command: "sh",
+		Args:    []string{"-c", "echo boom >&2; exit 1"},
+	}
+	_, err := plugin.Run(context.TODO(), cfg, "", &plugin.Request{Target: "controller"})
+	require.NotNil(t, err)
+	assert.Contains(t, err.Error(), "boom")
+}
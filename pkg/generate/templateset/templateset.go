@@ -19,6 +19,7 @@ import (
 	"io/ioutil"
 	"os"
 	"path/filepath"
+	"sort"
 	ttpl "text/template"
 
 	"github.com/pkg/errors"
@@ -46,6 +47,11 @@ type templateWithVars struct {
 	v interface{}
 }
 
+// ProgressFunc is called by TemplateSet.Execute() after each template has
+// been executed, with the number of templates executed so far, the total
+// number of templates and the output path of the executed template
+type ProgressFunc func(done int, total int, path string)
+
 // TemplateSet contains a set of templates and copy files for a particular
 // target
 type TemplateSet struct {
@@ -57,6 +63,7 @@ type TemplateSet struct {
 	templates       map[string]templateWithVars
 	funcMap         ttpl.FuncMap
 	executed        map[string]*bytes.Buffer
+	progress        ProgressFunc
 }
 
 // New returns a pointer to a TemplateSet
@@ -76,6 +83,12 @@ func New(
 	}
 }
 
+// SetProgress sets a function that is notified of the progress of Execute().
+// Rendering the templates of services with many resources can take a while.
+func (ts *TemplateSet) SetProgress(fn ProgressFunc) {
+	ts.progress = fn
+}
+
 // Add constructs a named template from a path and variables
 func (ts *TemplateSet) Add(
 	outPath string,
@@ -112,10 +125,14 @@ func (ts *TemplateSet) Add(
 	return nil
 }
 
-// joinIncludes adds all include templates to the supplied template
+// joinIncludes adds all include templates to the supplied template. Base
+// search paths are walked in reverse order so that the definitions found in
+// the earlier paths are parsed last and override those of the later ones,
+// consistent with how templates themselves are located.
 func (ts *TemplateSet) joinIncludes(t *ttpl.Template) error {
 	var err error
-	for _, basePath := range ts.baseSearchPaths {
+	for x := len(ts.baseSearchPaths) - 1; x >= 0; x-- {
+		basePath := ts.baseSearchPaths[x]
 		for _, includePath := range ts.includePaths {
 			tplPath := filepath.Join(basePath, includePath)
 			if !ackutil.FileExists(tplPath) {
@@ -134,15 +151,28 @@ func (ts *TemplateSet) joinIncludes(t *ttpl.Template) error {
 // Execute() is run, `TemplateSet.Executed()` can be used to iterate over a set
 // of byte buffers containing the output of executed templates
 func (ts *TemplateSet) Execute() error {
-	for path, tv := range ts.templates {
+	// Templates are executed in output path order so that, should more than
+	// one of them fail, the same error is reported on every run
+	paths := make([]string, 0, len(ts.templates))
+	for path := range ts.templates {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+	for x, path := range paths {
+		tv := ts.templates[path]
 		var b bytes.Buffer
 		if err := tv.t.Execute(&b, tv.v); err != nil {
 			return err
 		}
 		ts.executed[path] = &b
+		if ts.progress != nil {
+			ts.progress(x+1, len(paths), path)
+		}
 	}
-	for _, basePath := range ts.baseSearchPaths {
-		for _, path := range ts.copyPaths {
+	for _, path := range ts.copyPaths {
+		// Like templates, copy files are taken from the first base search
+		// path they are found in
+		for _, basePath := range ts.baseSearchPaths {
 			copyPath := filepath.Join(basePath, path)
 			if !ackutil.FileExists(copyPath) {
 				continue
@@ -152,6 +182,7 @@ func (ts *TemplateSet) Execute() error {
 				return err
 			}
 			ts.executed[path] = b
+			break
 		}
 	}
 	return nil
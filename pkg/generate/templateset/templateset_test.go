@@ -0,0 +1,102 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package templateset_test
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	ttpl "text/template"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/aws-controllers-k8s/code-generator/pkg/generate/templateset"
+)
+
+func writeTemplates(t *testing.T, files map[string]string) string {
+	dir, err := ioutil.TempDir("", "templateset")
+	require.Nil(t, err)
+	for path, content := range files {
+		fullPath := filepath.Join(dir, path)
+		require.Nil(t, os.MkdirAll(filepath.Dir(fullPath), 0755))
+		require.Nil(t, ioutil.WriteFile(fullPath, []byte(content), 0644))
+	}
+	return dir
+}
+
+func TestTemplateSet_Override(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	builtinDir := writeTemplates(t, map[string]string{
+		"main.go.tpl":       `{{ template "header" }} builtin main`,
+		"other.go.tpl":      `{{ template "header" }} builtin other`,
+		"header.go.tpl":     `{{ define "header" }}builtin header{{ end }}`,
+		"config/static.txt": "builtin static",
+		"config/kept.txt":   "builtin kept",
+	})
+	defer os.RemoveAll(builtinDir)
+	overrideDir := writeTemplates(t, map[string]string{
+		"main.go.tpl":       `{{ template "header" }} override main`,
+		"header.go.tpl":     `{{ define "header" }}override header{{ end }}`,
+		"config/static.txt": "override static",
+	})
+	defer os.RemoveAll(overrideDir)
+
+	ts := templateset.New(
+		[]string{overrideDir, builtinDir},
+		[]string{"header.go.tpl"},
+		[]string{"config/static.txt", "config/kept.txt"},
+		ttpl.FuncMap{},
+	)
+	require.Nil(ts.Add("main.go", "main.go.tpl", nil))
+	require.Nil(ts.Add("other.go", "other.go.tpl", nil))
+	require.Nil(ts.Execute())
+
+	executed := ts.Executed()
+	assert.Equal("override header override main", executed["main.go"].String())
+	assert.Equal("override header builtin other", executed["other.go"].String())
+	assert.Equal("override static", executed["config/static.txt"].String())
+	assert.Equal("builtin kept", executed["config/kept.txt"].String())
+}
+
+func TestTemplateSet_NotFound(t *testing.T) {
+	ts := templateset.New([]string{}, []string{}, []string{}, ttpl.FuncMap{})
+	err := ts.Add("main.go", "main.go.tpl", nil)
+	assert.NotNil(t, err)
+}
+
+func TestTemplateSet_Progress(t *testing.T) {
+	require := require.New(t)
+
+	dir := writeTemplates(t, map[string]string{
+		"a.go.tpl": "a",
+		"b.go.tpl": "b",
+	})
+	defer os.RemoveAll(dir)
+
+	ts := templateset.New([]string{dir}, []string{}, []string{}, ttpl.FuncMap{})
+	require.Nil(ts.Add("b.go", "b.go.tpl", nil))
+	require.Nil(ts.Add("a.go", "a.go.tpl", nil))
+
+	reports := []string{}
+	ts.SetProgress(func(done int, total int, path string) {
+		reports = append(reports, fmt.Sprintf("%d/%d %s", done, total, path))
+	})
+	require.Nil(ts.Execute())
+	assert.Equal(t, []string{"1/2 a.go", "2/2 b.go"}, reports)
+}
@@ -25,6 +25,11 @@ type MetaVars struct {
 	// service API in the aws-sdk-go `service/` directory. It is also used as
 	// the identifier for the ACK controller's name and packages.
 	ServicePackageName string
+	// SDKPackageName is the name of the aws-sdk-go `service/` package the
+	// controller calls. It is the ServicePackageName, except for the
+	// controllers generated from AWS Cloud Control resource schemas, which
+	// call the "cloudcontrolapi" package.
+	SDKPackageName string
 	// ServiceID is the exact string that appears in the AWS service API's
 	// api-2.json descriptor file under `metadata.serviceId`
 	ServiceID string
@@ -40,4 +45,36 @@ type MetaVars struct {
 	APIInterfaceTypeName string
 	//CRDNames contains all crds names lowercased and in plural
 	CRDNames []string
+	// NamespacedCRDNames contains the names, lowercased and in plural, of
+	// the crds whose resources are namespaced
+	NamespacedCRDNames []string
+	// ClusterScopedCRDNames contains the names, lowercased and in plural, of
+	// the crds whose resources are cluster-scoped
+	ClusterScopedCRDNames []string
+	// CRDBases contains the names of the files controller-gen outputs the
+	// crds to, e.g. "sns.services.k8s.aws_topics.yaml"
+	CRDBases []string
+	// APIPackagePath is the path, relative to the root of the service
+	// controller, of the Go package of the API types, e.g. "apis/v1alpha1".
+	// For the templates of a single crd placed into another API group than
+	// the service's, it is the path of the package of that API group.
+	APIPackagePath string
+	// AdditionalAPIGroups contains the API groups, other than the service's,
+	// some crds are placed into
+	AdditionalAPIGroups []APIGroupVars
+}
+
+// APIGroupVars describes an API group some crds are placed into, other than
+// the API group of the service
+type APIGroupVars struct {
+	// Group is the name of the API group, e.g.
+	// "networking.ec2.services.k8s.aws"
+	Group string
+	// PackagePath is the path, relative to the root of the service
+	// controller, of the Go package of the API group's types, e.g.
+	// "apis/v1alpha1/networking"
+	PackagePath string
+	// PackageAlias is the alias the Go package of the API group's types is
+	// imported with, e.g. "networkingtypes"
+	PackageAlias string
 }
@@ -0,0 +1,234 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package log
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/go-logr/logr"
+)
+
+const (
+	// FormatText writes one human-readable line per log entry
+	FormatText = "text"
+	// FormatJSON writes one JSON object per log entry
+	FormatJSON = "json"
+)
+
+// root is the sink shared by the logger returned by Log() and every logger
+// derived from it, so that package-level loggers created before the command
+// line flags are parsed pick up the configured verbosity and format
+var root = &sink{
+	out:    os.Stderr,
+	format: FormatText,
+}
+
+// Log returns the logger used by the code generator packages to record the
+// decisions they make. Until Configure is called, only entries at verbosity
+// level 0 and errors are written, to stderr.
+func Log() logr.Logger {
+	return &logger{sink: root}
+}
+
+// Configure sets the writer, verbosity and format of the logger returned by
+// Log() and all loggers derived from it.
+func Configure(out io.Writer, verbosity int, format string) {
+	root.mu.Lock()
+	defer root.mu.Unlock()
+	root.out = out
+	root.verbosity = verbosity
+	root.format = format
+}
+
+// ValidFormat returns true if the supplied string is a supported log format
+func ValidFormat(format string) bool {
+	return format == FormatText || format == FormatJSON
+}
+
+// New returns a logr.Logger that writes entries in the supplied format to the
+// supplied writer. Info entries logged at a V() level greater than the
+// supplied verbosity are discarded. Error entries are always written.
+func New(out io.Writer, verbosity int, format string) logr.Logger {
+	return &logger{
+		sink: &sink{
+			out:       out,
+			format:    format,
+			verbosity: verbosity,
+		},
+	}
+}
+
+// sink is where log entries end up
+type sink struct {
+	mu        sync.Mutex
+	out       io.Writer
+	format    string
+	verbosity int
+}
+
+// logger is a minimal leveled implementation of logr.Logger
+type logger struct {
+	sink   *sink
+	level  int
+	name   string
+	values []interface{}
+}
+
+func (l *logger) Enabled() bool {
+	l.sink.mu.Lock()
+	defer l.sink.mu.Unlock()
+	return l.level <= l.sink.verbosity
+}
+
+func (l *logger) Info(msg string, keysAndValues ...interface{}) {
+	l.write("info", nil, msg, keysAndValues)
+}
+
+func (l *logger) Error(err error, msg string, keysAndValues ...interface{}) {
+	l.write("error", err, msg, keysAndValues)
+}
+
+func (l *logger) V(level int) logr.Logger {
+	c := *l
+	c.level += level
+	return &c
+}
+
+func (l *logger) WithValues(keysAndValues ...interface{}) logr.Logger {
+	c := *l
+	c.values = append(append([]interface{}{}, l.values...), keysAndValues...)
+	return &c
+}
+
+func (l *logger) WithName(name string) logr.Logger {
+	c := *l
+	if c.name == "" {
+		c.name = name
+	} else {
+		c.name += "." + name
+	}
+	return &c
+}
+
+// write formats and writes a single log entry. Info entries above the
+// configured verbosity are dropped.
+func (l *logger) write(
+	severity string,
+	err error,
+	msg string,
+	keysAndValues []interface{},
+) {
+	l.sink.mu.Lock()
+	defer l.sink.mu.Unlock()
+	if severity != "error" && l.level > l.sink.verbosity {
+		return
+	}
+	kvs := append(append([]interface{}{}, l.values...), keysAndValues...)
+	if len(kvs)%2 != 0 {
+		kvs = append(kvs, "(MISSING)")
+	}
+	var line string
+	if l.sink.format == FormatJSON {
+		line = l.jsonLine(severity, err, msg, kvs)
+	} else {
+		line = l.textLine(severity, err, msg, kvs)
+	}
+	fmt.Fprintln(l.sink.out, line)
+}
+
+// textLine returns a log entry looking like this:
+//
+//	[model] matched operations crd=Repository create=CreateRepository
+func (l *logger) textLine(
+	severity string,
+	err error,
+	msg string,
+	kvs []interface{},
+) string {
+	parts := []string{}
+	if severity == "error" {
+		parts = append(parts, "ERROR")
+	}
+	if l.name != "" {
+		parts = append(parts, "["+l.name+"]")
+	}
+	parts = append(parts, msg)
+	if err != nil {
+		parts = append(parts, "error="+textValue(err.Error()))
+	}
+	for x := 0; x < len(kvs); x += 2 {
+		parts = append(parts, fmt.Sprintf("%v=%s", kvs[x], textValue(kvs[x+1])))
+	}
+	return strings.Join(parts, " ")
+}
+
+// textValue quotes string values that would otherwise be ambiguous in a
+// space-separated log line
+func textValue(v interface{}) string {
+	s := fmt.Sprintf("%v", v)
+	if s == "" || strings.ContainsAny(s, " \t\n\"=") {
+		return fmt.Sprintf("%q", s)
+	}
+	return s
+}
+
+// jsonLine returns a log entry as a JSON object. Keys are sorted by the
+// encoding/json package, so entries are stable across runs.
+func (l *logger) jsonLine(
+	severity string,
+	err error,
+	msg string,
+	kvs []interface{},
+) string {
+	entry := map[string]interface{}{}
+	for x := 0; x < len(kvs); x += 2 {
+		entry[fmt.Sprintf("%v", kvs[x])] = jsonValue(kvs[x+1])
+	}
+	entry["ts"] = time.Now().UTC().Format(time.RFC3339)
+	entry["level"] = severity
+	entry["v"] = l.level
+	entry["msg"] = msg
+	if l.name != "" {
+		entry["logger"] = l.name
+	}
+	if err != nil {
+		entry["error"] = err.Error()
+	}
+	b, merr := json.Marshal(entry)
+	if merr != nil {
+		return fmt.Sprintf(`{"level":"error","msg":%q}`, merr.Error())
+	}
+	return string(b)
+}
+
+// jsonValue returns a value that is safe to pass to json.Marshal. Values that
+// cannot be marshaled are logged using their default string representation.
+func jsonValue(v interface{}) interface{} {
+	switch tv := v.(type) {
+	case error:
+		return tv.Error()
+	case fmt.Stringer:
+		return tv.String()
+	}
+	if _, err := json.Marshal(v); err != nil {
+		return fmt.Sprintf("%v", v)
+	}
+	return v
+}
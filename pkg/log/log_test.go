@@ -0,0 +1,63 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package log_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	ackgenlog "github.com/aws-controllers-k8s/code-generator/pkg/log"
+)
+
+func TestTextFormat(t *testing.T) {
+	assert := assert.New(t)
+
+	var b bytes.Buffer
+	l := ackgenlog.New(&b, 1, ackgenlog.FormatText).WithName("model")
+
+	l.V(1).Info("matched operations", "crd", "Repository", "create", "CreateRepository")
+	l.V(2).Info("not written")
+	l.WithValues("crd", "Repository").Error(errors.New("boom"), "bad field", "field", "Name Prefix")
+
+	assert.Equal(
+		"[model] matched operations crd=Repository create=CreateRepository\n"+
+			"ERROR [model] bad field error=boom crd=Repository field=\"Name Prefix\"\n",
+		b.String(),
+	)
+}
+
+func TestJSONFormat(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	var b bytes.Buffer
+	l := ackgenlog.New(&b, 0, ackgenlog.FormatJSON).WithName("hook")
+
+	l.Info("hook fired", "hook", "sdk_create_pre_build_request", "inline", true)
+	l.V(1).Info("not written")
+
+	entry := map[string]interface{}{}
+	require.Nil(json.Unmarshal(b.Bytes(), &entry))
+	assert.Equal("info", entry["level"])
+	assert.Equal("hook", entry["logger"])
+	assert.Equal("hook fired", entry["msg"])
+	assert.Equal("sdk_create_pre_build_request", entry["hook"])
+	assert.Equal(true, entry["inline"])
+	assert.Contains(entry, "ts")
+}
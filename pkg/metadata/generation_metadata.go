@@ -41,7 +41,6 @@ const (
 
 	// UpdateReasonConversionFunctionsGeneration Should be used when
 	// an API package is modified by conversion functions generator.
-	// TODO(hilalymh) ack-generate conversion-functions
 	UpdateReasonConversionFunctionsGeneration UpdateReason = "Conversion functions generation"
 )
 
@@ -64,6 +63,10 @@ type GenerationMetadata struct {
 	ACKGenerateInfo ackGenerateInfo `json:"ack_generate_info"`
 	// Information about the generator config file used to generate the APIs
 	GeneratorConfigInfo generatorConfigInfo `json:"generator_config_info"`
+	// The checksums of the individual files generated within the APIs
+	// directory, keyed by their slash-separated path relative to the
+	// directory
+	FileChecksums map[string]string `json:"file_checksums,omitempty"`
 }
 
 // ack-generate binary information
@@ -101,6 +104,11 @@ func CreateGenerationMetadata(
 		return err
 	}
 
+	fileHashes, err := hashDirectoryFiles(filesDirectory)
+	if err != nil {
+		return err
+	}
+
 	generatorFileHash, err := hashFile(generatorFileName)
 	if err != nil {
 		return err
@@ -123,6 +131,7 @@ func CreateGenerationMetadata(
 			OriginalFileName: filepath.Base(generatorFileName),
 			FileChecksum:     generatorFileHash,
 		},
+		FileChecksums: fileHashes,
 	}
 
 	data, err := yaml.Marshal(generationMetadata)
@@ -142,6 +151,25 @@ func CreateGenerationMetadata(
 	return nil
 }
 
+// LoadGenerationMetadata reads the ack-generate-metadata.yaml file saved in
+// the given API version directory
+func LoadGenerationMetadata(
+	apisPath string,
+	apiVersion string,
+) (*GenerationMetadata, error) {
+	data, err := ioutil.ReadFile(
+		filepath.Join(apisPath, apiVersion, outputFileName),
+	)
+	if err != nil {
+		return nil, err
+	}
+	generationMetadata := &GenerationMetadata{}
+	if err = yaml.Unmarshal(data, generationMetadata); err != nil {
+		return nil, err
+	}
+	return generationMetadata, nil
+}
+
 // hashDirectoryContent returns the sha1 checksum of a given directory. It will walk
 // the file tree of a directory and combine and the file contents before hashing it.
 func hashDirectoryContent(directory string) (string, error) {
@@ -176,6 +204,35 @@ func hashDirectoryContent(directory string) (string, error) {
 	return hash, nil
 }
 
+// hashDirectoryFiles returns a map, keyed by slash-separated path relative to
+// the directory, of the sha1 checksums of the files in a given directory.
+// Like hashDirectoryContent, it ignores yaml files.
+func hashDirectoryFiles(directory string) (map[string]string, error) {
+	hashes := map[string]string{}
+	err := filepath.Walk(directory, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if info.IsDir() || filepath.Ext(info.Name()) == ".yaml" {
+			return nil
+		}
+		relPath, err := filepath.Rel(directory, path)
+		if err != nil {
+			return err
+		}
+		hash, err := hashFile(path)
+		if err != nil {
+			return err
+		}
+		hashes[filepath.ToSlash(relPath)] = hash
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return hashes, nil
+}
+
 // hashFile returns the sha1 hash of a given file
 func hashFile(filename string) (string, error) {
 	h := sha1.New()
@@ -183,6 +240,7 @@ func hashFile(filename string) (string, error) {
 	if err != nil {
 		return "", err
 	}
+	defer fileReader.Close()
 	_, err = io.Copy(h, fileReader)
 	if err != nil {
 		return "", err
@@ -0,0 +1,63 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package metadata_test
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/aws-controllers-k8s/code-generator/pkg/metadata"
+)
+
+func TestCreateGenerationMetadata_FileChecksums(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	apisPath, err := ioutil.TempDir("", "apis")
+	require.Nil(err)
+	defer os.RemoveAll(apisPath)
+
+	versionPath := filepath.Join(apisPath, "v1alpha1")
+	require.Nil(os.MkdirAll(filepath.Join(versionPath, "nested"), 0755))
+	files := map[string]string{
+		"repository.go":   "package v1alpha1\n",
+		"nested/types.go": "package nested\n",
+		"generator.yaml":  "resources: {}\n",
+	}
+	for path, content := range files {
+		require.Nil(ioutil.WriteFile(filepath.Join(versionPath, path), []byte(content), 0644))
+	}
+
+	err = metadata.CreateGenerationMetadata(
+		"v1alpha1", apisPath, metadata.UpdateReasonAPIGeneration,
+		"v1.37.10", filepath.Join(versionPath, "generator.yaml"),
+	)
+	require.Nil(err)
+
+	gm, err := metadata.LoadGenerationMetadata(apisPath, "v1alpha1")
+	require.Nil(err)
+	assert.Equal(
+		map[string]string{
+			// sha1 checksums of the file contents
+			"repository.go":   "d42d6f9c95f0876d06d864558cf36df6e542d01e",
+			"nested/types.go": "be33578f1f15830d73da805907f83a0270a25b1c",
+		},
+		gm.FileChecksums,
+	)
+}
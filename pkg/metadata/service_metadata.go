@@ -15,9 +15,13 @@ package metadata
 
 import (
 	"errors"
+	"fmt"
 	"io/ioutil"
+	"regexp"
+	"sort"
 
 	"github.com/ghodss/yaml"
+	k8sversion "k8s.io/apimachinery/pkg/version"
 )
 
 var (
@@ -51,6 +55,24 @@ type ServiceDetails struct {
 type ServiceVersion struct {
 	APIVersion string    `json:"api_version"`
 	Status     APIStatus `json:"status"`
+	// DeprecationWarning is the warning returned to the clients calling a
+	// deprecated API version, instead of the default warning of the API
+	// server
+	DeprecationWarning string `json:"deprecation_warning,omitempty"`
+	// Served tells whether the API version is served by the API server.
+	// Versions that are not served anymore are kept in the CRDs until the
+	// resources stored in them are migrated. Defaults to true.
+	Served *bool `json:"served,omitempty"`
+}
+
+// IsDeprecated returns true if the API version is deprecated
+func (v ServiceVersion) IsDeprecated() bool {
+	return v.Status == APIStatusDeprecated
+}
+
+// IsServed returns true if the API version is served by the API server
+func (v ServiceVersion) IsServed() bool {
+	return v.Served == nil || *v.Served
 }
 
 // GetLatestAPIVersion returns the latest available API version.
@@ -84,6 +106,44 @@ func (m *ServiceMetadata) GetAvailableAPIVersions() []string {
 	return m.getVersionsByStatus(APIStatusAvailable)
 }
 
+// GetServiceVersion returns the supplied API version, as listed in the
+// service metadata, or an available API version if it isn't listed
+func (m *ServiceMetadata) GetServiceVersion(apiVersion string) ServiceVersion {
+	for _, v := range m.APIVersions {
+		if v.APIVersion == apiVersion {
+			return v
+		}
+	}
+	return ServiceVersion{APIVersion: apiVersion, Status: APIStatusAvailable}
+}
+
+// GetCRDVersions returns the API versions that have not been removed, which
+// are the versions of the CRDs, in the order they are listed in the CRDs
+func (m *ServiceMetadata) GetCRDVersions() []ServiceVersion {
+	versions := []ServiceVersion{}
+	for _, v := range m.APIVersions {
+		if v.Status != APIStatusRemoved {
+			versions = append(versions, v)
+		}
+	}
+	// controller-gen sorts the versions of the CRDs by name
+	sort.Slice(versions, func(i, j int) bool {
+		return versions[i].APIVersion < versions[j].APIVersion
+	})
+	return versions
+}
+
+// HasVersionsSunset returns true if any of the versions of the CRDs is
+// deprecated or not served anymore
+func (m *ServiceMetadata) HasVersionsSunset() bool {
+	for _, v := range m.GetCRDVersions() {
+		if v.IsDeprecated() || !v.IsServed() {
+			return true
+		}
+	}
+	return false
+}
+
 // getVersionsByStatus filters all of the versions by their respective statuses
 // and returns their API versions
 func (m *ServiceMetadata) getVersionsByStatus(status APIStatus) []string {
@@ -100,6 +160,45 @@ func (m *ServiceMetadata) getVersionsByStatus(status APIStatus) []string {
 	return versions
 }
 
+// apiVersionRegexp matches the Kubernetes API versions, e.g. v1alpha1, v1beta2
+// or v1
+var apiVersionRegexp = regexp.MustCompile(`^v[1-9][0-9]*((alpha|beta)[1-9][0-9]*)?$`)
+
+// BumpAPIVersion adds the supplied API version, which must be more stable
+// than the latest available API version, to the available API versions and
+// returns the latest API version it supersedes
+func (m *ServiceMetadata) BumpAPIVersion(apiVersion string) (string, error) {
+	if !apiVersionRegexp.MatchString(apiVersion) {
+		return "", fmt.Errorf("invalid API version %q, must look like v1beta1 or v1", apiVersion)
+	}
+	for _, v := range m.APIVersions {
+		if v.APIVersion == apiVersion {
+			return "", fmt.Errorf("API version %s already exists with status %s", apiVersion, v.Status)
+		}
+	}
+	latest, err := m.GetLatestAPIVersion()
+	if err != nil {
+		return "", err
+	}
+	if k8sversion.CompareKubeAwareVersionStrings(apiVersion, latest) <= 0 {
+		return "", fmt.Errorf("API version %s must be more recent than the latest API version %s", apiVersion, latest)
+	}
+	m.APIVersions = append(m.APIVersions, ServiceVersion{
+		APIVersion: apiVersion,
+		Status:     APIStatusAvailable,
+	})
+	return latest, nil
+}
+
+// Save writes the service metadata to the supplied path
+func (m *ServiceMetadata) Save(metadataPath string) error {
+	content, err := yaml.Marshal(m)
+	if err != nil {
+		return err
+	}
+	return ioutil.WriteFile(metadataPath, content, 0644)
+}
+
 // NewServiceMetadata returns a new Metadata object given a supplied
 // path to a metadata file
 func NewServiceMetadata(
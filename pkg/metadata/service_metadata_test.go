@@ -0,0 +1,110 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package metadata_test
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/aws-controllers-k8s/code-generator/pkg/metadata"
+)
+
+func TestServiceMetadata_BumpAPIVersion(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	dir, err := ioutil.TempDir("", "metadata")
+	require.Nil(err)
+	defer os.RemoveAll(dir)
+
+	metadataPath := filepath.Join(dir, "metadata.yaml")
+	require.Nil(ioutil.WriteFile(metadataPath, []byte(`service:
+  full_name: Amazon Elastic Container Registry
+  short_name: ECR
+api_versions:
+- api_version: v1alpha1
+  status: available
+`), 0644))
+
+	m, err := metadata.NewServiceMetadata(metadataPath)
+	require.Nil(err)
+
+	_, err = m.BumpAPIVersion("beta1")
+	assert.Error(err)
+	_, err = m.BumpAPIVersion("v1alpha1")
+	assert.Error(err)
+	_, err = m.BumpAPIVersion("v1alpha0")
+	assert.Error(err)
+
+	previous, err := m.BumpAPIVersion("v1beta1")
+	require.Nil(err)
+	assert.Equal("v1alpha1", previous)
+	_, err = m.BumpAPIVersion("v1alpha2")
+	assert.Error(err)
+
+	require.Nil(m.Save(metadataPath))
+	saved, err := metadata.NewServiceMetadata(metadataPath)
+	require.Nil(err)
+	assert.Equal("ECR", saved.Service.ShortName)
+	assert.Equal([]string{"v1alpha1", "v1beta1"}, saved.GetAvailableAPIVersions())
+	latest, err := saved.GetLatestAPIVersion()
+	require.Nil(err)
+	assert.Equal("v1beta1", latest)
+}
+
+func TestServiceMetadata_GetCRDVersions(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	dir, err := ioutil.TempDir("", "metadata")
+	require.Nil(err)
+	defer os.RemoveAll(dir)
+
+	metadataPath := filepath.Join(dir, "metadata.yaml")
+	require.Nil(ioutil.WriteFile(metadataPath, []byte(`api_versions:
+- api_version: v1alpha1
+  status: removed
+- api_version: v1alpha2
+  status: deprecated
+  deprecation_warning: v1alpha2 is deprecated, use v1 instead
+- api_version: v1beta1
+  status: available
+  served: false
+- api_version: v1
+  status: available
+`), 0644))
+
+	m, err := metadata.NewServiceMetadata(metadataPath)
+	require.Nil(err)
+	assert.True(m.HasVersionsSunset())
+
+	versions := m.GetCRDVersions()
+	require.Len(versions, 3)
+	assert.Equal("v1", versions[0].APIVersion)
+	assert.True(versions[0].IsServed())
+	assert.False(versions[0].IsDeprecated())
+	assert.Equal("v1alpha2", versions[1].APIVersion)
+	assert.True(versions[1].IsServed())
+	assert.True(versions[1].IsDeprecated())
+	assert.Equal("v1alpha2 is deprecated, use v1 instead", versions[1].DeprecationWarning)
+	assert.Equal("v1beta1", versions[2].APIVersion)
+	assert.False(versions[2].IsServed())
+
+	assert.Equal(metadata.APIStatusAvailable, m.GetServiceVersion("v2").Status)
+}
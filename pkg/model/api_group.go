@@ -0,0 +1,159 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package model
+
+import (
+	"fmt"
+	"path"
+	"sort"
+	"strings"
+
+	"github.com/aws-controllers-k8s/code-generator/pkg/generate/templateset"
+)
+
+// APIGroup returns the Kubernetes API group of the CRD, e.g.
+// "ec2.services.k8s.aws", which is the API group of the service unless the
+// resource is configured to be placed into another one
+func (r *CRD) APIGroup() string {
+	return r.apiGroup
+}
+
+// crdAPIGroup returns the API group the supplied CRD is placed into
+func (m *Model) crdAPIGroup(crd *CRD) string {
+	group := m.cfg.ResourceAPIGroup(crd.Names.Original)
+	if group == "" {
+		return m.APIGroup()
+	}
+	if !strings.Contains(group, ".") {
+		return group + "." + m.APIGroup()
+	}
+	return group
+}
+
+// setAPIGroups records in the supplied CRDs the API group they are placed
+// into, returning an error if the Go packages of two API groups would be
+// generated in the same directory
+func (m *Model) setAPIGroups(crds []*CRD) error {
+	groupDirs := map[string]string{}
+	for _, crd := range crds {
+		crd.apiGroup = m.crdAPIGroup(crd)
+		dir := m.APIGroupDir(crd.apiGroup)
+		if dir == "" {
+			continue
+		}
+		if other, found := groupDirs[dir]; found && other != crd.apiGroup {
+			return fmt.Errorf(
+				"API groups %s and %s would both be generated in apis/%s/%s",
+				other, crd.apiGroup, m.apiVersion, dir,
+			)
+		}
+		groupDirs[dir] = crd.apiGroup
+	}
+	return nil
+}
+
+// APIGroupDir returns the directory, relative to the apis/$API_VERSION
+// directory, of the Go package of the types of the supplied API group: the
+// first label of the API group, e.g. "networking", or an empty string for
+// the API group of the service
+func (m *Model) APIGroupDir(group string) string {
+	if group == m.APIGroup() {
+		return ""
+	}
+	return strings.SplitN(group, ".", 2)[0]
+}
+
+// APIPackagePath returns the path, relative to the root of the service
+// controller, of the Go package of the types of the supplied API group, e.g.
+// "apis/v1alpha1" or "apis/v1alpha1/networking"
+func (m *Model) APIPackagePath(group string) string {
+	return path.Join("apis", m.apiVersion, m.APIGroupDir(group))
+}
+
+// AdditionalAPIGroups returns the API groups, other than the service's, the
+// CRDs are placed into, sorted by name
+func (m *Model) AdditionalAPIGroups() []string {
+	crds, _ := m.GetCRDs()
+	seen := map[string]bool{}
+	groups := []string{}
+	for _, crd := range crds {
+		group := crd.APIGroup()
+		if group == m.APIGroup() || seen[group] {
+			continue
+		}
+		seen[group] = true
+		groups = append(groups, group)
+	}
+	sort.Strings(groups)
+	return groups
+}
+
+// GetCRDsInAPIGroup returns the CRDs placed into the supplied API group
+func (m *Model) GetCRDsInAPIGroup(group string) ([]*CRD, error) {
+	crds, err := m.GetCRDs()
+	if err != nil {
+		return nil, err
+	}
+	groupCRDs := []*CRD{}
+	for _, crd := range crds {
+		if crd.APIGroup() == group {
+			groupCRDs = append(groupCRDs, crd)
+		}
+	}
+	return groupCRDs, nil
+}
+
+// APIGroupMetaVars returns the MetaVars of the templates of the Go package
+// of the supplied API group, whose APIGroup and APIPackagePath are those of
+// the API group
+func (m *Model) APIGroupMetaVars(group string) templateset.MetaVars {
+	metaVars := m.MetaVars()
+	metaVars.APIGroup = group
+	metaVars.APIPackagePath = m.APIPackagePath(group)
+	return metaVars
+}
+
+// CRDMetaVars returns the MetaVars of the templates of the supplied CRD,
+// whose APIGroup and APIPackagePath are those of the API group of the CRD
+func (m *Model) CRDMetaVars(crd *CRD) templateset.MetaVars {
+	return m.APIGroupMetaVars(crd.APIGroup())
+}
+
+// additionalAPIGroupVars returns the template variables describing the API
+// groups, other than the service's, the CRDs are placed into
+func (m *Model) additionalAPIGroupVars() []templateset.APIGroupVars {
+	groupVars := []templateset.APIGroupVars{}
+	for _, group := range m.AdditionalAPIGroups() {
+		dir := m.APIGroupDir(group)
+		groupVars = append(groupVars, templateset.APIGroupVars{
+			Group:        group,
+			PackagePath:  m.APIPackagePath(group),
+			PackageAlias: strings.ReplaceAll(dir, "-", "") + "types",
+		})
+	}
+	return groupVars
+}
+
+// crdBases returns the names of the files controller-gen outputs the CRDs
+// to
+func (m *Model) crdBases() []string {
+	var bases []string
+
+	crds, _ := m.GetCRDs()
+	for _, crd := range crds {
+		bases = append(bases, crd.APIGroup()+"_"+strings.ToLower(crd.Plural)+".yaml")
+	}
+
+	return bases
+}
@@ -23,6 +23,12 @@ type Attr struct {
 	Names  names.Names
 	GoType string
 	Shape  *awssdkmodel.Shape
+	// Documentation is the documentation of the attribute, formatted as a Go
+	// comment
+	Documentation string
+	// ValidationMarkers contains the kubebuilder validation markers enforcing
+	// the constraints of the attribute's shape
+	ValidationMarkers []string
 }
 
 func NewAttr(
@@ -30,9 +36,14 @@ func NewAttr(
 	goType string,
 	shape *awssdkmodel.Shape,
 ) *Attr {
+	doc := ""
+	if shape != nil {
+		doc = shape.Documentation
+	}
 	return &Attr{
-		Names:  names,
-		GoType: goType,
-		Shape:  shape,
+		Names:         names,
+		GoType:        goType,
+		Shape:         shape,
+		Documentation: doc,
 	}
 }
@@ -0,0 +1,161 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package model
+
+import (
+	"fmt"
+	"strings"
+
+	awssdkmodel "github.com/aws/aws-sdk-go/private/model/api"
+	"github.com/gertd/go-pluralize"
+
+	ackgenconfig "github.com/aws-controllers-k8s/code-generator/pkg/generate/config"
+	"github.com/aws-controllers-k8s/code-generator/pkg/names"
+)
+
+// BatchOperation returns the instructions for wrapping the resource into the
+// request and response shapes of the supplied operation, or nil if the
+// operation isn't a batch operation
+func (r *CRD) BatchOperation(
+	op *awssdkmodel.Operation,
+) *ackgenconfig.BatchOperationConfig {
+	if op == nil {
+		return nil
+	}
+	return r.cfg.BatchOperation(r.Names.Original, op.Name)
+}
+
+// BatchErrorsMember returns the name of the member of the supplied batch
+// operation's Output shape holding the errors for the resources the
+// operation failed for, or the empty string if there is none
+func (r *CRD) BatchErrorsMember(op *awssdkmodel.Operation) string {
+	batchOp := r.BatchOperation(op)
+	if batchOp == nil {
+		return ""
+	}
+	return batchOp.ErrorsMember
+}
+
+// BatchElementName returns the name of the field holding the element of the
+// supplied list member of a batch operation's shapes, e.g. "ResourceId" for
+// "ResourceIds"
+func BatchElementName(memberName string) string {
+	if strings.HasSuffix(memberName, "List") {
+		return strings.TrimSuffix(memberName, "List")
+	}
+	return pluralize.NewClient().Singular(memberName)
+}
+
+// BatchElementShapeRef returns the ShapeRef of the elements of the supplied
+// list member of a batch operation's shapes
+func BatchElementShapeRef(
+	opName string,
+	memberName string,
+	shapeRef *awssdkmodel.ShapeRef,
+) (*awssdkmodel.ShapeRef, error) {
+	if shapeRef == nil || shapeRef.Shape == nil {
+		return nil, ErrNilShapePointer
+	}
+	if shapeRef.Shape.Type != "list" || shapeRef.Shape.MemberRef.Shape == nil {
+		return nil, fmt.Errorf(
+			"member %s of batch operation %s is not a list", memberName, opName,
+		)
+	}
+	return &shapeRef.Shape.MemberRef, nil
+}
+
+// addBatchSpecFields adds the Spec fields for the element of the supplied
+// list member of a batch operation's Input shape
+func (r *CRD) addBatchSpecFields(
+	op *awssdkmodel.Operation,
+	memberName string,
+	shapeRef *awssdkmodel.ShapeRef,
+) error {
+	elemRef, err := BatchElementShapeRef(op.Name, memberName, shapeRef)
+	if err != nil {
+		return err
+	}
+	if elemRef.Shape.Type != "structure" {
+		r.addBatchField(op, BatchElementName(memberName), elemRef, false)
+		return nil
+	}
+	for _, elemMemberName := range elemRef.Shape.MemberNames() {
+		r.addBatchField(
+			op, elemMemberName, elemRef.Shape.MemberRefs[elemMemberName], false,
+		)
+	}
+	return nil
+}
+
+// addBatchStatusFields adds the Status fields for the element of the
+// supplied list member of a batch operation's Output shape. Only scalar
+// members of structure elements become fields.
+func (r *CRD) addBatchStatusFields(
+	op *awssdkmodel.Operation,
+	memberName string,
+	shapeRef *awssdkmodel.ShapeRef,
+) error {
+	elemRef, err := BatchElementShapeRef(op.Name, memberName, shapeRef)
+	if err != nil {
+		return err
+	}
+	if elemRef.Shape.Type != "structure" {
+		r.addBatchField(op, BatchElementName(memberName), elemRef, true)
+		return nil
+	}
+	for _, elemMemberName := range elemRef.Shape.MemberNames() {
+		elemMemberRef := elemRef.Shape.MemberRefs[elemMemberName]
+		if !IsBatchScalar(elemMemberRef) {
+			continue
+		}
+		r.addBatchField(op, elemMemberName, elemMemberRef, true)
+	}
+	return nil
+}
+
+// addBatchField adds a Spec or Status field, unless the CRD already has it
+func (r *CRD) addBatchField(
+	op *awssdkmodel.Operation,
+	memberName string,
+	shapeRef *awssdkmodel.ShapeRef,
+	isStatus bool,
+) {
+	fieldName, _ := r.cfg.ResourceFieldRename(
+		r.Names.Original, op.Name, memberName,
+	)
+	if inSpec, inStatus := r.HasMember(fieldName, op.Name); inSpec || inStatus {
+		return
+	}
+	fieldNames := names.New(fieldName)
+	fieldNames.ModelOriginal = memberName
+	if isStatus {
+		r.AddStatusField(fieldNames, shapeRef)
+	} else {
+		r.AddSpecField(fieldNames, shapeRef)
+	}
+}
+
+// IsBatchScalar returns true if the elements of batch operations' lists, or
+// their members, of the supplied shape can be copied as is between the
+// resource and the SDK shapes
+func IsBatchScalar(shapeRef *awssdkmodel.ShapeRef) bool {
+	if shapeRef == nil || shapeRef.Shape == nil {
+		return false
+	}
+	switch shapeRef.Shape.Type {
+	case "string", "boolean", "integer", "long", "float", "double":
+		return true
+	}
+	return false
+}
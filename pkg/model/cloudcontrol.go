@@ -0,0 +1,240 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package model
+
+import (
+	"sort"
+
+	awssdkmodel "github.com/aws/aws-sdk-go/private/model/api"
+
+	"github.com/aws-controllers-k8s/code-generator/pkg/names"
+)
+
+const (
+	// CloudControlSDKPackageName is the name of the aws-sdk-go package of the
+	// AWS Cloud Control API, which the controllers generated from resource
+	// schemas call
+	CloudControlSDKPackageName = "cloudcontrolapi"
+	// cloudControlIAMActionPrefix is the prefix of the IAM actions of the
+	// AWS Cloud Control API
+	cloudControlIAMActionPrefix = "cloudformation"
+)
+
+// CloudControlResource describes a resource type of the AWS Cloud Control
+// API, from its CloudFormation registry schema
+type CloudControlResource struct {
+	// TypeName is the name of the resource type, e.g. "AWS::Logs::LogGroup"
+	TypeName string
+	// PrimaryIdentifier contains the names of the properties whose values,
+	// joined by "|", identify a resource
+	PrimaryIdentifier []string
+	// HandlerPermissions contains, keyed by handler ("create", "read",
+	// "update", "delete" or "list"), the IAM permissions the handlers of the
+	// resource type require
+	HandlerPermissions map[string][]string
+}
+
+// CloudControlIdentifier is a property of a resource identifying it in the
+// AWS Cloud Control API
+type CloudControlIdentifier struct {
+	// Property is the name of the property in the resource schema
+	Property string
+	// Path is the path of the resource's field holding the value of the
+	// property, from the resource, e.g. "Spec.LogGroupName"
+	Path string
+	// IsString is true if the field is a *string; the values of the other
+	// fields are formatted with fmt.Sprint
+	IsString bool
+	// IsARN is true if the property is the resource's ARN, which is held in
+	// Status.ACKResourceMetadata.ARN
+	IsARN bool
+}
+
+// IsCloudControl returns true if the resources are generated from AWS Cloud
+// Control resource schemas instead of an AWS service API model
+func (m *Model) IsCloudControl() bool {
+	return m.SDKAPI != nil && len(m.SDKAPI.CloudControlResources) > 0
+}
+
+// SDKPackageName returns the name of the aws-sdk-go package the controller
+// calls, e.g. "ecr", or "cloudcontrolapi" for the controllers generated from
+// AWS Cloud Control resource schemas
+func (m *Model) SDKPackageName() string {
+	if m.IsCloudControl() {
+		return CloudControlSDKPackageName
+	}
+	return m.servicePackageName
+}
+
+// CloudControlResource returns the AWS Cloud Control resource type the CRD
+// is generated from, or nil if the CRD is generated from an AWS service API
+// model
+func (r *CRD) CloudControlResource() *CloudControlResource {
+	if r.sdkAPI == nil {
+		return nil
+	}
+	return r.sdkAPI.CloudControlResources[r.Names.Original]
+}
+
+// CloudControlIdentifiers returns, in order, the properties identifying the
+// resource in the AWS Cloud Control API. Properties that aren't fields of
+// the resource are skipped.
+func (r *CRD) CloudControlIdentifiers() []*CloudControlIdentifier {
+	ccr := r.CloudControlResource()
+	if ccr == nil {
+		return nil
+	}
+	identifiers := []*CloudControlIdentifier{}
+	for _, property := range ccr.PrimaryIdentifier {
+		if r.IsPrimaryARNField(property) {
+			identifiers = append(identifiers, &CloudControlIdentifier{
+				Property: property,
+				Path:     "Status.ACKResourceMetadata.ARN",
+				IsARN:    true,
+			})
+			continue
+		}
+		path := ""
+		var field *Field
+		for fieldName, f := range r.SpecFields {
+			if cloudControlPropertyName(f) == property {
+				path, field = "Spec."+fieldName, f
+			}
+		}
+		for fieldName, f := range r.StatusFields {
+			if field == nil && cloudControlPropertyName(f) == property {
+				path, field = "Status."+fieldName, f
+			}
+		}
+		if field == nil {
+			continue
+		}
+		identifiers = append(identifiers, &CloudControlIdentifier{
+			Property: property,
+			Path:     path,
+			IsString: field.GoType == "*string",
+		})
+	}
+	return identifiers
+}
+
+// CloudControlARNProperty returns the name of the property holding the ARN
+// of the resource, which is read into Status.ACKResourceMetadata.ARN, or an
+// empty string if the resource has none
+func (r *CRD) CloudControlARNProperty() string {
+	if r.CloudControlResource() == nil || r.Ops.Create == nil {
+		return ""
+	}
+	outputShape := r.Ops.Create.OutputRef.Shape
+	if outputShape == nil {
+		return ""
+	}
+	for _, memberName := range outputShape.MemberNames() {
+		if r.IsPrimaryARNField(memberName) {
+			return memberName
+		}
+	}
+	return ""
+}
+
+// CloudControlPropertyNames returns a map, keyed by the JSON key of the
+// resource's fields and the fields of their types, of the names of the
+// properties of the resource schema they hold, for the keys that differ from
+// the property names. The fields of the resource win over the fields of
+// their types when their keys collide.
+func (r *CRD) CloudControlPropertyNames() map[string]string {
+	propertyNames := map[string]string{}
+	fieldPaths := []string{}
+	for fieldPath := range r.Fields {
+		fieldPaths = append(fieldPaths, fieldPath)
+	}
+	// Visit the fields in a stable order so that the same property wins on
+	// every run when two properties have the same JSON key
+	sort.Strings(fieldPaths)
+	visited := map[string]bool{}
+	for _, fieldPath := range fieldPaths {
+		field := r.Fields[fieldPath]
+		property := cloudControlPropertyName(field)
+		if property != "" && property != field.Names.CamelLower {
+			propertyNames[field.Names.CamelLower] = property
+		}
+		if field.ShapeRef != nil {
+			addCloudControlMemberNames(field.ShapeRef.Shape, propertyNames, visited)
+		}
+	}
+	return propertyNames
+}
+
+// addCloudControlMemberNames adds to the supplied map the JSON keys of the
+// members of the supplied shape and of the shapes they contain, for the keys
+// that differ from the member names
+func addCloudControlMemberNames(
+	shape *awssdkmodel.Shape,
+	propertyNames map[string]string,
+	visited map[string]bool,
+) {
+	if shape == nil || visited[shape.ShapeName] {
+		return
+	}
+	visited[shape.ShapeName] = true
+	switch shape.Type {
+	case "structure":
+		for _, memberName := range shape.MemberNames() {
+			key := names.New(memberName).CamelLower
+			if _, found := propertyNames[key]; !found && key != memberName {
+				propertyNames[key] = memberName
+			}
+			addCloudControlMemberNames(shape.MemberRefs[memberName].Shape, propertyNames, visited)
+		}
+	case "list":
+		addCloudControlMemberNames(shape.MemberRef.Shape, propertyNames, visited)
+	case "map":
+		addCloudControlMemberNames(shape.ValueRef.Shape, propertyNames, visited)
+	}
+}
+
+// cloudControlPropertyName returns the name of the resource schema property
+// the supplied field holds
+func cloudControlPropertyName(f *Field) string {
+	if f.Names.ModelOriginal != "" {
+		return f.Names.ModelOriginal
+	}
+	return f.Names.Original
+}
+
+// cloudControlIAMActions returns the IAM actions the controller performs for
+// the supplied AWS Cloud Control resource type: the actions of the Cloud
+// Control API operations and the permissions of the handlers they invoke
+func cloudControlIAMActions(ccr *CloudControlResource, ops *pathOperations) *IAMActions {
+	actions := func(ccOps []string, handler string, called []*awssdkmodel.Operation) []string {
+		if len(called) == 0 {
+			return []string{}
+		}
+		all := []string{}
+		for _, op := range ccOps {
+			all = append(all, cloudControlIAMActionPrefix+":"+op)
+		}
+		all = append(all, ccr.HandlerPermissions[handler]...)
+		sort.Strings(all)
+		return all
+	}
+	return &IAMActions{
+		Create: actions([]string{"CreateResource", "GetResourceRequestStatus"}, "create", ops.create),
+		Read:   actions([]string{"GetResource"}, "read", ops.read),
+		Update: actions([]string{"UpdateResource", "GetResourceRequestStatus"}, "update", ops.update),
+		Delete: actions([]string{"DeleteResource", "GetResourceRequestStatus"}, "delete", ops.delete),
+		Tag:    []string{},
+		Extra:  []string{},
+	}
+}
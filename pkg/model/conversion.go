@@ -0,0 +1,36 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package model
+
+import (
+	"github.com/aws-controllers-k8s/code-generator/pkg/names"
+)
+
+// HubVersion returns the API version the resources are converted to and from
+// and stored in, the model's API version unless specified in generator config
+func (m *Model) HubVersion() string {
+	return m.cfg.GetHubVersion(m.apiVersion)
+}
+
+// ConversionFieldMappings returns the names of the custom resource's fields
+// in the other API versions mapped to the names of its fields in this API
+// version, if specified in generator config. The names are camel-cased like
+// the keys of SpecFields and StatusFields.
+func (r *CRD) ConversionFieldMappings() map[string]string {
+	mappings := map[string]string{}
+	for otherName, fieldName := range r.cfg.ResourceConversionFieldMappings(r.Names.Original) {
+		mappings[names.New(otherName).Camel] = names.New(fieldName).Camel
+	}
+	return mappings
+}
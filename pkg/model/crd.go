@@ -17,6 +17,7 @@ import (
 	"errors"
 	"fmt"
 	"sort"
+	"strconv"
 	"strings"
 
 	awssdkmodel "github.com/aws/aws-sdk-go/private/model/api"
@@ -59,6 +60,58 @@ func (ops Ops) IterOps() []*awssdkmodel.Operation {
 	return res
 }
 
+// OpTypeOf returns the type of operation the supplied Operation serves for the
+// resource, falling back to the type inferred from the Operation's name when
+// the Operation is not one of the resource's operations
+func (ops Ops) OpTypeOf(
+	op *awssdkmodel.Operation,
+	cfg *ackgenconfig.Config,
+) OpType {
+	switch {
+	case op == nil:
+		return OpTypeUnknown
+	case op == ops.Create:
+		return OpTypeCreate
+	case op == ops.ReadOne:
+		return OpTypeGet
+	case op == ops.ReadMany:
+		return OpTypeList
+	case op == ops.Update:
+		return OpTypeUpdate
+	case op == ops.Delete:
+		return OpTypeDelete
+	case op == ops.GetAttributes:
+		return OpTypeGetAttributes
+	case op == ops.SetAttributes:
+		return OpTypeSetAttributes
+	}
+	opType, _ := GetOpTypeAndResourceNameFromOpID(op.Name, cfg)
+	return opType
+}
+
+// logValues returns the names of the operations, keyed by operation type, as
+// key/value pairs suitable for passing to a logr.Logger
+func (ops Ops) logValues(crdName string) []interface{} {
+	res := []interface{}{"resource", crdName}
+	for _, opType := range []struct {
+		key string
+		op  *awssdkmodel.Operation
+	}{
+		{"create", ops.Create},
+		{"read_one", ops.ReadOne},
+		{"read_many", ops.ReadMany},
+		{"update", ops.Update},
+		{"delete", ops.Delete},
+		{"get_attributes", ops.GetAttributes},
+		{"set_attributes", ops.SetAttributes},
+	} {
+		if opType.op != nil {
+			res = append(res, opType.key, opType.op.Name)
+		}
+	}
+	return res
+}
+
 // CRD describes a single top-level resource in an AWS service API
 type CRD struct {
 	sdkAPI *SDKAPI
@@ -68,6 +121,16 @@ type CRD struct {
 	Plural string
 	// Ops are the CRUD operations controlling this resource
 	Ops Ops
+	// SecondaryOps are the operations configuring parts of this resource
+	// that its CRUD operations don't, in the order they are called
+	SecondaryOps []*SecondaryOp
+	// UpdateOps are the operations updating groups of this resource's Spec
+	// fields in place of its Update operation, in the order they are called
+	UpdateOps []*UpdateOp
+	// SplitFrom is the resource this CRD was split from, if any. The
+	// resource's other splits own the fields of its API operations' shapes
+	// that this CRD doesn't have.
+	SplitFrom *CRD
 	// additionalPrinterColumns is an array of PrinterColumn objects
 	// representing the printer column settings for the CRD
 	additionalPrinterColumns []*PrinterColumn
@@ -89,6 +152,14 @@ type CRD struct {
 	// ShortNames represent the CRD list of aliases. Short names allow shorter
 	// strings to match a CR on the CLI.
 	ShortNames []string
+	// Categories are the groups of resources the CRD belongs to, allowing
+	// `kubectl get <category>` to list its resources.
+	Categories []string
+	// apiGroup is the Kubernetes API group of the CRD
+	apiGroup string
+	// deletionDependents are the Spec fields of the API's other CRDs
+	// referencing this CRD, whose resources must be deleted first
+	deletionDependents []*ReferenceField
 }
 
 // Config returns a pointer to the generator config
@@ -96,6 +167,63 @@ func (r *CRD) Config() *ackgenconfig.Config {
 	return r.cfg
 }
 
+// ResourceMarkerArgs returns the arguments of the CRD's
+// `+kubebuilder:resource` marker, e.g.
+// "shortName=sg,categories=ack;networking,scope=Cluster", or an empty string
+// if the CRD has no short names nor categories and is namespaced
+func (r *CRD) ResourceMarkerArgs() string {
+	args := []string{}
+	if len(r.ShortNames) > 0 {
+		args = append(args, "shortName="+strings.Join(r.ShortNames, ";"))
+	}
+	if len(r.Categories) > 0 {
+		args = append(args, "categories="+strings.Join(r.Categories, ";"))
+	}
+	if r.IsClusterScoped() {
+		args = append(args, "scope=Cluster")
+	}
+	return strings.Join(args, ",")
+}
+
+// MetadataMarkerArgs returns the arguments of the CRD's
+// `+kubebuilder:metadata` marker stamping labels and annotations onto the
+// CRD, e.g. `annotations={"a=b"},labels={"c=d","e=f"}`, or an empty string if
+// the CRD has no labels nor annotations, in which case the marker must be
+// omitted. The marker requires controller-gen v0.9.0 or later, see
+// CONTROLLER_TOOLS_VERSION in scripts/lib/common.sh.
+func (r *CRD) MetadataMarkerArgs() string {
+	labels, annotations := r.cfg.ResourceCRDMetadata(r.Names.Original)
+	args := []string{}
+	for _, kv := range []struct {
+		name   string
+		values map[string]string
+	}{
+		{"annotations", annotations},
+		{"labels", labels},
+	} {
+		if len(kv.values) == 0 {
+			continue
+		}
+		keys := []string{}
+		for key := range kv.values {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		pairs := []string{}
+		for _, key := range keys {
+			pairs = append(pairs, strconv.Quote(key+"="+kv.values[key]))
+		}
+		args = append(args, kv.name+"={"+strings.Join(pairs, ",")+"}")
+	}
+	return strings.Join(args, ",")
+}
+
+// IsClusterScoped returns true if the resources of the CRD are cluster-scoped
+// objects instead of namespaced ones
+func (r *CRD) IsClusterScoped() bool {
+	return r.cfg.ResourceIsClusterScoped(r.Names.Original)
+}
+
 // SDKAPIPackageName returns the aws-sdk-go package name used for this
 // resource's API
 func (r *CRD) SDKAPIPackageName() string {
@@ -115,7 +243,7 @@ func (r *CRD) Documentation() string {
 	shape, ok := r.sdkAPI.API.Shapes[r.Names.Original]
 	if ok {
 		// Separate with a double newline to force a newline in the CRD base
-		docString += "\n//\n" + shape.Documentation
+		docString += "\n//\n" + sanitizeDocumentation(shape.Documentation, r.cfg)
 	}
 	return docString
 }
@@ -144,35 +272,24 @@ func (r *CRD) HasShapeAsMember(toFind string) bool {
 		}
 	}
 	for _, field := range r.SpecFields {
-		if shapeHasMember(field.ShapeRef.Shape, toFind) {
+		if field.ShapeRef != nil && shapeHasMember(field.ShapeRef.Shape, toFind) {
 			return true
 		}
 	}
 	for _, field := range r.StatusFields {
-		if shapeHasMember(field.ShapeRef.Shape, toFind) {
+		if field.ShapeRef != nil && shapeHasMember(field.ShapeRef.Shape, toFind) {
 			return true
 		}
 	}
 	return false
 }
 
+// shapeHasMember returns true if the shape with the supplied name is the
+// supplied shape or can be reached from it
 func shapeHasMember(shape *awssdkmodel.Shape, toFind string) bool {
-	if shape.ShapeName == toFind {
-		return true
-	}
-	switch shape.Type {
-	case "structure":
-		for _, memberShapeRef := range shape.MemberRefs {
-			if shapeHasMember(memberShapeRef.Shape, toFind) {
-				return true
-			}
-		}
-	case "list":
-		return shapeHasMember(shape.MemberRef.Shape, toFind)
-	case "map":
-		return shapeHasMember(shape.ValueRef.Shape, toFind)
-	}
-	return false
+	reached := map[string]bool{}
+	collectReachableShapes(shape, reached)
+	return reached[toFind]
 }
 
 // AddSpecField adds a new Field of a given name and shape into the Spec
@@ -188,6 +305,9 @@ func (r *CRD) AddSpecField(
 	if fConfig != nil && fConfig.Print != nil {
 		r.addSpecPrintableColumn(f)
 	}
+	r.addRawExtensionImport(f)
+	r.addQuantityImport(f)
+	r.addCustomGoTypeImport(f)
 	r.SpecFields[memberNames.Original] = f
 	r.Fields[fPath] = f
 }
@@ -205,6 +325,9 @@ func (r *CRD) AddStatusField(
 	if fConfig != nil && fConfig.Print != nil {
 		r.addStatusPrintableColumn(f)
 	}
+	r.addRawExtensionImport(f)
+	r.addQuantityImport(f)
+	r.addCustomGoTypeImport(f)
 	r.StatusFields[memberNames.Original] = f
 	r.Fields[fPath] = f
 }
@@ -239,11 +362,46 @@ func (r *CRD) UnpacksAttributesMap() bool {
 	return r.cfg.UnpacksAttributesMap(r.Names.Original)
 }
 
+// AttributesMapMemberName returns the name of the Input and Output shape
+// member holding the resource's attributes map
+func (r *CRD) AttributesMapMemberName() string {
+	return r.cfg.AttributesMapMemberName(r.Names.Original)
+}
+
+// AttributeKey returns the key, in the resource's attributes map, of the
+// value of the supplied attribute field
+func (r *CRD) AttributeKey(fieldName string) string {
+	return r.cfg.AttributeKey(r.Names.Original, fieldName)
+}
+
 // CompareIgnoredFields returns the list of fields compare logic should ignore
 func (r *CRD) CompareIgnoredFields() []string {
 	return r.cfg.GetCompareIgnoredFields(r.Names.Original)
 }
 
+// IgnoresDrift returns true if the differences of the Spec field at the
+// supplied path, e.g. "ProvisionedThroughput.ReadCapacityUnits", are excluded
+// from the resource's Delta. The path's parts are compared once normalized,
+// so the original or the Go names of the fields may be used.
+func (r *CRD) IgnoresDrift(fieldPath string) bool {
+	for _, ignoredPath := range r.cfg.GetCompareIgnoreDriftFields(r.Names.Original) {
+		if normalizeFieldPath(ignoredPath) == normalizeFieldPath(fieldPath) {
+			return true
+		}
+	}
+	return false
+}
+
+// normalizeFieldPath returns the supplied dot-separated field path with each
+// of its parts camel-cased
+func normalizeFieldPath(fieldPath string) string {
+	parts := strings.Split(fieldPath, ".")
+	for i, part := range parts {
+		parts[i] = names.New(part).Camel
+	}
+	return strings.Join(parts, ".")
+}
+
 // SetAttributesSingleAttribute returns true if the supplied resource name has
 // a SetAttributes operation that only actually changes a single attribute at a
 // time. See: SNS SetTopicAttributes API call, which is entirely different from
@@ -312,7 +470,36 @@ func (r *CRD) IsSecretField(path string) bool {
 	return false
 }
 
-// GetImmutableFieldPaths returns list of immutable field paths present in CRD
+// IsSecretShapeMember returns true if the supplied member of the struct shape
+// having the supplied name is a nested field that is a SecretKeyReference,
+// e.g. the Password member of the User shape for the `Users..Password` field
+func (r *CRD) IsSecretShapeMember(shapeName string, memberName string) bool {
+	for fieldPath, field := range r.Fields {
+		if !strings.Contains(fieldPath, ".") ||
+			field.FieldConfig == nil || !field.FieldConfig.IsSecret ||
+			field.Names.Original != memberName {
+			continue
+		}
+		parentField, found := r.Fields[ParentFieldPath(fieldPath)]
+		if !found || parentField.ShapeRef == nil {
+			continue
+		}
+		parentShapeRef := parentField.ShapeRef
+		switch parentShapeRef.Shape.Type {
+		case "list":
+			parentShapeRef = &parentShapeRef.Shape.MemberRef
+		case "map":
+			parentShapeRef = &parentShapeRef.Shape.ValueRef
+		}
+		if parentShapeRef.ShapeName == shapeName {
+			return true
+		}
+	}
+	return false
+}
+
+// GetImmutableFieldPaths returns a sorted list of immutable field paths
+// present in CRD
 func (r *CRD) GetImmutableFieldPaths() []string {
 	fConfigs := r.cfg.ResourceFields(r.Names.Original)
 	var immutableFields []string
@@ -322,16 +509,73 @@ func (r *CRD) GetImmutableFieldPaths() []string {
 			immutableFields = append(immutableFields, field)
 		}
 	}
-
+	sort.Strings(immutableFields)
 	return immutableFields
 }
 
-// HasImmutableFieldChanges helper function that return true if there are any immutable field changes
+// GetRecreateFieldPaths returns a sorted list of the paths of the fields
+// whose change replaces the resource
+func (r *CRD) GetRecreateFieldPaths() []string {
+	fConfigs := r.cfg.ResourceFields(r.Names.Original)
+	var recreateFields []string
+
+	for field, fieldConfig := range fConfigs {
+		if fieldConfig.OnChange == ackgenconfig.OnChangeRecreate {
+			recreateFields = append(recreateFields, field)
+		}
+	}
+	sort.Strings(recreateFields)
+	return recreateFields
+}
+
+// GetSensitiveFieldPaths returns a sorted list of the paths of the sensitive
+// fields, prefixed with "Spec." or "Status.", as they appear in the deltas of
+// the resource, e.g. "Spec.MasterUserPassword"
+func (r *CRD) GetSensitiveFieldPaths() []string {
+	fConfigs := r.cfg.ResourceFields(r.Names.Original)
+	var sensitiveFields []string
+
+	for field, fieldConfig := range fConfigs {
+		if fieldConfig == nil || !fieldConfig.IsSensitive {
+			continue
+		}
+		if path, found := r.resourceFieldPath(field); found {
+			sensitiveFields = append(sensitiveFields, path)
+		}
+	}
+	sort.Strings(sensitiveFields)
+	return sensitiveFields
+}
+
+// resourceFieldPath returns the path, prefixed with "Spec." or "Status.", of
+// the field having the supplied field config path, and whether the field is
+// a Spec or Status field
+func (r *CRD) resourceFieldPath(fieldConfigPath string) (string, bool) {
+	parts := strings.Split(fieldConfigPath, ".")
+	prefix := ""
+	if _, found := r.SpecFields[parts[0]]; found {
+		prefix = "Spec"
+	} else if _, found := r.StatusFields[parts[0]]; found {
+		prefix = "Status"
+	} else {
+		return "", false
+	}
+	path := []string{prefix}
+	for _, part := range parts {
+		path = append(path, names.New(part).Camel)
+	}
+	return strings.Join(path, "."), true
+}
+
+// HasImmutableFieldChanges helper function that return true if there are any
+// immutable field changes, including the changes of the fields replacing the
+// resource, which are reported like immutable field changes when the
+// resource doesn't opt in to being replaced
 func (r *CRD) HasImmutableFieldChanges() bool {
 	fConfigs := r.cfg.ResourceFields(r.Names.Original)
 
 	for _, fieldConfig := range fConfigs {
-		if fieldConfig.IsImmutable {
+		if fieldConfig.IsImmutable || fieldConfig.OnChange == ackgenconfig.OnChangeRecreate {
 			return true
 		}
 	}
@@ -339,16 +583,26 @@ func (r *CRD) HasImmutableFieldChanges() bool {
 }
 
 // IsARNPrimaryKey returns true if the CRD uses its ARN as its primary key in
-// ReadOne calls.
+// ReadOne calls, either because the generator config says so or because the
+// ARN is the only required member of the ReadOne (or, lacking one, the
+// GetAttributes) operation's Input shape.
 func (r *CRD) IsARNPrimaryKey() bool {
 	if r.cfg == nil {
 		return false
 	}
 	resGenConfig, found := r.cfg.Resources[r.Names.Original]
-	if !found {
+	if found && resGenConfig.IsARNPrimaryKey {
+		return true
+	}
+	op := r.Ops.ReadOne
+	if op == nil {
+		op = r.Ops.GetAttributes
+	}
+	if op == nil || op.InputRef.Shape == nil {
 		return false
 	}
-	return resGenConfig.IsARNPrimaryKey
+	required := op.InputRef.Shape.Required
+	return len(required) == 1 && r.IsPrimaryARNField(required[0])
 }
 
 // GetPrimaryKeyField returns the field designated as the primary key, nil if
@@ -379,6 +633,51 @@ func (r *CRD) GetPrimaryKeyField() (*Field, error) {
 	return primaryField, nil
 }
 
+// PrimaryKeyFields returns the fields that together identify the resource in
+// its ReadOne and Delete API calls, i.e. the string fields backing the
+// required members of these operations' Input shapes, other than the
+// resource's ARN. A resource with more than one such field, like an EKS
+// Nodegroup identified by its ClusterName and NodegroupName, has a composite
+// primary key and all of them are needed to read, delete or adopt it.
+func (r *CRD) PrimaryKeyFields() []*Field {
+	res := []*Field{}
+	added := map[*Field]bool{}
+	if primaryField, err := r.GetPrimaryKeyField(); err == nil && primaryField != nil {
+		res = append(res, primaryField)
+		added[primaryField] = true
+	}
+	for _, op := range []*awssdkmodel.Operation{r.Ops.ReadOne, r.Ops.Delete} {
+		if op == nil || op.InputRef.Shape == nil {
+			continue
+		}
+		for _, memberName := range op.InputRef.Shape.Required {
+			if r.IsPrimaryARNField(memberName) {
+				continue
+			}
+			fieldName, _ := r.cfg.ResourceFieldRename(
+				r.Names.Original, op.Name, memberName,
+			)
+			field, found := r.SpecFields[fieldName]
+			if !found {
+				field, found = r.StatusFields[fieldName]
+			}
+			if !found || added[field] || field.ShapeRef == nil ||
+				field.ShapeRef.Shape.Type != "string" {
+				continue
+			}
+			res = append(res, field)
+			added[field] = true
+		}
+	}
+	return res
+}
+
+// HasCompositePrimaryKey returns true if the resource is identified by more
+// than one field
+func (r *CRD) HasCompositePrimaryKey() bool {
+	return len(r.PrimaryKeyFields()) > 1
+}
+
 // SetOutputCustomMethodName returns custom set output operation as *string for
 // given operation on custom resource, if specified in generator config
 func (r *CRD) SetOutputCustomMethodName(
@@ -583,6 +882,24 @@ func (r *CRD) IsAdoptable() bool {
 	return r.cfg.ResourceIsAdoptable(r.Names.Original)
 }
 
+// IsUpsert returns true if the resource's Create operation creates the
+// resource or replaces the existing one, and is also called to update it
+func (r *CRD) IsUpsert() bool {
+	return r.cfg.ResourceIsUpsert(r.Names.Original)
+}
+
+// ReadBackOnCreate returns true if the resource is read back right after it
+// is created, to default the fields of its Spec the user didn't set
+func (r *CRD) ReadBackOnCreate() bool {
+	return r.cfg.ResourceReadBackOnCreate(r.Names.Original)
+}
+
+// AutoIdempotencyToken returns true if the idempotency token members of the
+// resource's operations' Input shapes are filled when not set in the CR
+func (r *CRD) AutoIdempotencyToken() bool {
+	return r.cfg.ResourceAutoIdempotencyToken(r.Names.Original)
+}
+
 // GetResourcePrintOrderByName returns the Printer Column order-by field name
 func (r *CRD) GetResourcePrintOrderByName() string {
 	orderBy := r.cfg.GetResourcePrintOrderByName(r.Names.Camel)
@@ -593,9 +910,14 @@ func (r *CRD) GetResourcePrintOrderByName() string {
 }
 
 // PrintAgeColumn returns whether the code generator should append 'Age'
-// kubebuilder:printcolumn comment marker
+// kubebuilder:printcolumn comment marker, either because the resource's print
+// config asks for it or as a default column
 func (r *CRD) PrintAgeColumn() bool {
-	return r.cfg.GetResourcePrintAddAgeColumn(r.Names.Camel)
+	if r.cfg.GetResourcePrintAddAgeColumn(r.Names.Camel) {
+		return true
+	}
+	return r.cfg.GetResourcePrintDefaultColumns(r.Names.Original) &&
+		!r.hasPrinterColumn("Age", ".metadata.creationTimestamp")
 }
 
 // ReconcileRequeuOnSuccessSeconds returns the duration after which to requeue
@@ -616,6 +938,79 @@ func (r *CRD) ReconcileRequeuOnSuccessSeconds() int {
 	return 0
 }
 
+// IsObserveOnly returns true if the controller only reads the resource and
+// never creates, updates or deletes it, according to the generator config
+func (r *CRD) IsObserveOnly() bool {
+	if r.cfg == nil {
+		return false
+	}
+	resGenConfig, found := r.cfg.Resources[r.Names.Original]
+	if !found || resGenConfig.Reconcile == nil {
+		return false
+	}
+	return resGenConfig.Reconcile.ObserveOnly
+}
+
+// ResyncSeconds returns the number of seconds after which the custom
+// resource is requeued once it is reconciled, if specified in generator config
+func (r *CRD) ResyncSeconds() int {
+	if r.cfg == nil {
+		return 0
+	}
+	resGenConfig, found := r.cfg.Resources[r.Names.Original]
+	if !found || resGenConfig.Reconcile == nil {
+		return 0
+	}
+	return resGenConfig.Reconcile.ResyncSeconds
+}
+
+// ResyncFields returns the resync config of the custom resource's fields,
+// keyed by their path prefixed with "Spec." or "Status.", e.g.
+// "Status.DBInstanceStatus"
+func (r *CRD) ResyncFields() map[string]*ackgenconfig.ResyncFieldConfig {
+	fConfigs := r.cfg.ResourceFields(r.Names.Original)
+	resyncFields := map[string]*ackgenconfig.ResyncFieldConfig{}
+	for field, fieldConfig := range fConfigs {
+		if fieldConfig == nil || fieldConfig.Resync == nil {
+			continue
+		}
+		if path, found := r.resourceFieldPath(field); found {
+			resyncFields[path] = fieldConfig.Resync
+		}
+	}
+	return resyncFields
+}
+
+// HasResync returns true if the period after which the custom resource is
+// requeued is computed from its current state
+func (r *CRD) HasResync() bool {
+	return r.ResyncSeconds() > 0 || len(r.ResyncFields()) > 0
+}
+
+// ErrorRequeues returns the way the custom resource is requeued when AWS
+// errors are returned, keyed by their code, if specified in generator config
+func (r *CRD) ErrorRequeues() map[string]ackgenconfig.ErrorRequeueConfig {
+	if r.cfg == nil {
+		return nil
+	}
+	resGenConfig, found := r.cfg.Resources[r.Names.Original]
+	if !found || resGenConfig.Reconcile == nil {
+		return nil
+	}
+	return resGenConfig.Reconcile.ErrorRequeues
+}
+
+// ErrorRequeueCodes returns the sorted codes of the AWS errors the custom
+// resource has a requeue policy for
+func (r *CRD) ErrorRequeueCodes() []string {
+	codes := []string{}
+	for code := range r.ErrorRequeues() {
+		codes = append(codes, code)
+	}
+	sort.Strings(codes)
+	return codes
+}
+
 // CustomUpdateMethodName returns the name of the custom resourceManager method
 // for updating the resource state, if any has been specified in the generator
 // config
@@ -639,8 +1034,14 @@ func (r *CRD) ListOpMatchFieldNames() []string {
 	return r.cfg.ListOpMatchFieldNames(r.Names.Original)
 }
 
+// ListOpMatches returns the criteria an element of the List operation's
+// Output shape must meet to be the resource
+func (r *CRD) ListOpMatches() []*ackgenconfig.ListMatchConfig {
+	return r.cfg.ListOpMatches(r.Names.Original)
+}
+
 // GetAllRenames returns all the field renames observed in the generator config
-// for a given OpType.
+// for a given OpType, keyed by original field name.
 func (r *CRD) GetAllRenames(op OpType) (map[string]string, error) {
 	renames := make(map[string]string)
 	resourceConfig, ok := r.cfg.Resources[r.Names.Original]
@@ -669,9 +1070,50 @@ func (r *CRD) GetAllRenames(op OpType) (map[string]string, error) {
 			}
 		}
 	}
+	// Renames may also be patterns, which we can only resolve against the
+	// names of the members of the Operations' payload shapes
+	for _, op := range operations {
+		for _, memberName := range r.renameCandidates(op) {
+			if _, found := renames[memberName]; found {
+				continue
+			}
+			renamed, found := r.cfg.ResourceFieldRename(
+				r.Names.Original, op.Name, memberName,
+			)
+			if found {
+				renames[memberName] = renamed
+			}
+		}
+	}
 	return renames, nil
 }
 
+// renameCandidates returns the names of the members of the supplied
+// Operation's Input and Output shapes, including those of the structure the
+// Output shape wraps, if any
+func (r *CRD) renameCandidates(op *awssdkmodel.Operation) []string {
+	candidates := []string{}
+	if op == nil {
+		return candidates
+	}
+	if op.InputRef.Shape != nil {
+		candidates = append(candidates, op.InputRef.Shape.MemberNames()...)
+	}
+	outputShape, err := r.GetOutputShape(op)
+	if err != nil {
+		return candidates
+	}
+	candidates = append(candidates, outputShape.MemberNames()...)
+	if len(outputShape.MemberRefs) == 1 {
+		for _, memberRef := range outputShape.MemberRefs {
+			if memberRef.Shape != nil && memberRef.Shape.Type == "structure" {
+				candidates = append(candidates, memberRef.Shape.MemberNames()...)
+			}
+		}
+	}
+	return candidates
+}
+
 // GetIdentifiers returns the identifier fields of a given CRD which
 // can be singular or plural. Note, these fields will be the *original* field
 // names from the API model shape, not renamed field names.
@@ -780,5 +1222,6 @@ func NewCRD(
 		StatusFields:             map[string]*Field{},
 		Fields:                   map[string]*Field{},
 		ShortNames:               cfg.ResourceShortNames(kind),
+		Categories:               cfg.ResourceCategories(kind),
 	}
 }
@@ -0,0 +1,84 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package model
+
+import (
+	"strings"
+
+	awssdkmodel "github.com/aws/aws-sdk-go/private/model/api"
+
+	ackgenconfig "github.com/aws-controllers-k8s/code-generator/pkg/generate/config"
+)
+
+// IsCustomField returns true if the field is declared by a custom_field in
+// generator config. Custom fields are set by hook code, never by generated
+// code.
+func (f *Field) IsCustomField() bool {
+	return f.FieldConfig != nil && f.FieldConfig.CustomField != nil
+}
+
+// HasCustomGoType returns true if the field's Go type is supplied by hook code
+// instead of being derived from a shape, in which case the field has no
+// ShapeRef
+func (f *Field) HasCustomGoType() bool {
+	return f.IsCustomField() && f.FieldConfig.CustomField.GoType != ""
+}
+
+// customGoType returns the Go type, element Go type and Go type with package
+// name of a field whose Go type is supplied by hook code
+func customGoType(
+	customField *ackgenconfig.CustomFieldConfig,
+) (string, string, string) {
+	gt := customField.GoType
+	gte := strings.TrimLeft(gt, "*[]")
+	return gte, gt, gt
+}
+
+// addCustomGoTypeImport adds the import of the package declaring the Go type
+// of the supplied field, if the field has a custom Go type from another
+// package
+func (r *CRD) addCustomGoTypeImport(f *Field) {
+	if !f.HasCustomGoType() || f.FieldConfig.CustomField.GoTypeImport == "" {
+		return
+	}
+	r.AddTypeImport(f.FieldConfig.CustomField.GoTypeImport, "")
+}
+
+// customFieldShapeRef returns the ShapeRef giving its type to a field
+// declared by the supplied custom field config and whether the config
+// refers to a known shape. The ShapeRef is nil for custom Go types.
+func (m *Model) customFieldShapeRef(
+	customField *ackgenconfig.CustomFieldConfig,
+) (*awssdkmodel.ShapeRef, bool) {
+	switch {
+	case customField.GoType != "":
+		return nil, true
+	case customField.Shape != "":
+		shape, found := m.SDKAPI.API.Shapes[customField.Shape]
+		if !found {
+			return nil, false
+		}
+		return &awssdkmodel.ShapeRef{
+			API:           m.SDKAPI.API,
+			Shape:         shape,
+			Documentation: shape.Documentation,
+			ShapeName:     shape.ShapeName,
+		}, true
+	case customField.ListOf != "":
+		shapeRef := m.SDKAPI.GetCustomShapeRef(customField.ListOf)
+		return shapeRef, shapeRef != nil
+	}
+	shapeRef := m.SDKAPI.GetCustomShapeRef(customField.MapOf)
+	return shapeRef, shapeRef != nil
+}
@@ -0,0 +1,99 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package model
+
+import (
+	"sort"
+	"strings"
+
+	ackgenconfig "github.com/aws-controllers-k8s/code-generator/pkg/generate/config"
+	"github.com/aws-controllers-k8s/code-generator/pkg/names"
+)
+
+// IsDeprecated returns true if the field is marked deprecated in generator
+// config
+func (f *Field) IsDeprecated() bool {
+	return f.FieldConfig != nil && f.FieldConfig.Deprecated != nil
+}
+
+// GetDeprecatedFieldPaths returns a sorted list of the paths of the Spec
+// fields marked deprecated
+func (r *CRD) GetDeprecatedFieldPaths() []string {
+	fConfigs := r.cfg.ResourceFields(r.Names.Original)
+	var deprecatedFields []string
+
+	for field, fieldConfig := range fConfigs {
+		if fieldConfig.Deprecated != nil {
+			deprecatedFields = append(deprecatedFields, field)
+		}
+	}
+	sort.Strings(deprecatedFields)
+	return deprecatedFields
+}
+
+// HasDeprecatedFields returns true if any of the Spec fields is marked
+// deprecated
+func (r *CRD) HasDeprecatedFields() bool {
+	return len(r.GetDeprecatedFieldPaths()) > 0
+}
+
+// DeprecationNotice returns the explanation of the deprecation of the Spec
+// field at the supplied path, along with the field to set instead, e.g. "DB
+// security groups are only supported by EC2-Classic. Use
+// spec.vpcSecurityGroupIDs instead."
+func (r *CRD) DeprecationNotice(fieldPath string) string {
+	fConfig, found := r.cfg.ResourceFields(r.Names.Original)[fieldPath]
+	if !found || fConfig.Deprecated == nil {
+		return ""
+	}
+	return deprecationNotice(fConfig.Deprecated)
+}
+
+// SpecJSONPath returns the path of the Spec field at the supplied field path
+// as the JSON field names users set, e.g. "spec.config.engineVersion"
+func SpecJSONPath(fieldPath string) string {
+	jsonPath := []string{"spec"}
+	for _, part := range strings.Split(fieldPath, ".") {
+		jsonPath = append(jsonPath, names.New(part).CamelLower)
+	}
+	return strings.Join(jsonPath, ".")
+}
+
+// deprecationNotice returns the explanation of a field's deprecation, along
+// with the field to set instead
+func deprecationNotice(depCfg *ackgenconfig.DeprecatedFieldConfig) string {
+	notice := strings.TrimSpace(depCfg.Message)
+	if depCfg.ReplacedBy != "" {
+		if notice != "" && !strings.HasSuffix(notice, ".") {
+			notice += "."
+		}
+		notice = strings.TrimSpace(
+			notice + " Use " + SpecJSONPath(depCfg.ReplacedBy) + " instead.",
+		)
+	}
+	return notice
+}
+
+// appendDeprecationNotice returns the supplied Go comment with a paragraph
+// saying the field is deprecated, in the format recognized by Go tools
+func appendDeprecationNotice(
+	doc string,
+	depCfg *ackgenconfig.DeprecatedFieldConfig,
+) string {
+	notice := deprecationNotice(depCfg)
+	if notice == "" {
+		notice = "This field will be removed in a future API version."
+	}
+	return joinCommentParagraphs(doc, goComment("Deprecated: "+notice))
+}
@@ -0,0 +1,243 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package model
+
+import (
+	"encoding/json"
+	"sort"
+
+	awssdkmodel "github.com/aws/aws-sdk-go/private/model/api"
+
+	ackgenconfig "github.com/aws-controllers-k8s/code-generator/pkg/generate/config"
+	"github.com/aws-controllers-k8s/code-generator/pkg/names"
+)
+
+// DocumentSchemaVersion is the version of the Document JSON schema. It is
+// incremented whenever a backwards-incompatible change is made to the
+// schema.
+const DocumentSchemaVersion = "v1"
+
+// Document is a stable, serializable representation of a Model, meant to be
+// consumed by tooling external to the code generator. The Model itself
+// references the aws-sdk-go API model, which contains cycles and cannot be
+// serialized, so only the information inferred by the code generator is
+// copied over. All collections are sorted so that the same Model always
+// produces the same Document.
+type Document struct {
+	SchemaVersion      string             `json:"schemaVersion"`
+	ServiceModelName   string             `json:"serviceModelName,omitempty"`
+	ServicePackageName string             `json:"servicePackageName"`
+	ServiceID          string             `json:"serviceID"`
+	APIGroup           string             `json:"apiGroup"`
+	APIVersion         string             `json:"apiVersion"`
+	CRDs               []*CRDDocument     `json:"crds"`
+	TypeDefs           []*TypeDefDocument `json:"typeDefs"`
+	EnumDefs           []*EnumDefDocument `json:"enumDefs"`
+	// Source contains the inputs the Model was inferred from. It is only
+	// present when explicitly requested, since the API model files are large.
+	Source *DocumentSource `json:"source,omitempty"`
+}
+
+// DocumentSource contains the inputs a Model was inferred from, which allow
+// the Model to be inferred again without access to the AWS SDK
+type DocumentSource struct {
+	// ModelName is the name identifying the service API model in the SDK
+	ModelName string `json:"modelName"`
+	// ModelFormat is the format of the model files, either "api-2",
+	// "smithy" or "cloudcontrol"
+	ModelFormat string `json:"modelFormat"`
+	// SDKVersion is the version of the SDK the model files were read from
+	SDKVersion string `json:"sdkVersion,omitempty"`
+	// ModelFiles is a map, keyed by file name, of the contents of the service
+	// API model files
+	ModelFiles map[string]json.RawMessage `json:"modelFiles"`
+	// GeneratorConfig is the generator config, with includes resolved
+	GeneratorConfig *ackgenconfig.Config `json:"generatorConfig"`
+}
+
+// CRDDocument is the serializable representation of a CRD
+type CRDDocument struct {
+	Names        names.Names      `json:"names"`
+	Kind         string           `json:"kind"`
+	Plural       string           `json:"plural"`
+	Operations   OpsDocument      `json:"operations"`
+	SpecFields   []*FieldDocument `json:"specFields"`
+	StatusFields []*FieldDocument `json:"statusFields"`
+}
+
+// OpsDocument contains the names of the API operations controlling a CRD
+type OpsDocument struct {
+	Create        string `json:"create,omitempty"`
+	ReadOne       string `json:"readOne,omitempty"`
+	ReadMany      string `json:"readMany,omitempty"`
+	Update        string `json:"update,omitempty"`
+	Delete        string `json:"delete,omitempty"`
+	GetAttributes string `json:"getAttributes,omitempty"`
+	SetAttributes string `json:"setAttributes,omitempty"`
+}
+
+// FieldDocument is the serializable representation of a Field
+type FieldDocument struct {
+	Names        names.Names `json:"names"`
+	Path         string      `json:"path"`
+	GoType       string      `json:"goType"`
+	ShapeName    string      `json:"shapeName,omitempty"`
+	IsRequired   bool        `json:"isRequired"`
+	IsSecret     bool        `json:"isSecret"`
+	IsImmutable  bool        `json:"isImmutable"`
+	IsPrimaryKey bool        `json:"isPrimaryKey"`
+}
+
+// TypeDefDocument is the serializable representation of a TypeDef
+type TypeDefDocument struct {
+	Names names.Names     `json:"names"`
+	Attrs []*AttrDocument `json:"attrs"`
+}
+
+// AttrDocument is the serializable representation of an Attr
+type AttrDocument struct {
+	Names  names.Names `json:"names"`
+	GoType string      `json:"goType"`
+}
+
+// EnumDefDocument is the serializable representation of an EnumDef
+type EnumDefDocument struct {
+	Names  names.Names `json:"names"`
+	Values []string    `json:"values"`
+}
+
+// Document returns the serializable representation of the Model
+func (m *Model) Document() (*Document, error) {
+	crds, err := m.GetCRDs()
+	if err != nil {
+		return nil, err
+	}
+	typeDefs, err := m.GetTypeDefs()
+	if err != nil {
+		return nil, err
+	}
+	enumDefs, err := m.GetEnumDefs()
+	if err != nil {
+		return nil, err
+	}
+
+	metaVars := m.MetaVars()
+	doc := &Document{
+		SchemaVersion:      DocumentSchemaVersion,
+		ServiceModelName:   metaVars.ServiceModelName,
+		ServicePackageName: metaVars.ServicePackageName,
+		ServiceID:          metaVars.ServiceID,
+		APIGroup:           metaVars.APIGroup,
+		APIVersion:         metaVars.APIVersion,
+		CRDs:               []*CRDDocument{},
+		TypeDefs:           []*TypeDefDocument{},
+		EnumDefs:           []*EnumDefDocument{},
+	}
+	for _, crd := range crds {
+		doc.CRDs = append(doc.CRDs, &CRDDocument{
+			Names:  crd.Names,
+			Kind:   crd.Kind,
+			Plural: crd.Plural,
+			Operations: OpsDocument{
+				Create:        opName(crd.Ops.Create),
+				ReadOne:       opName(crd.Ops.ReadOne),
+				ReadMany:      opName(crd.Ops.ReadMany),
+				Update:        opName(crd.Ops.Update),
+				Delete:        opName(crd.Ops.Delete),
+				GetAttributes: opName(crd.Ops.GetAttributes),
+				SetAttributes: opName(crd.Ops.SetAttributes),
+			},
+			SpecFields:   fieldDocuments(crd.SpecFields),
+			StatusFields: fieldDocuments(crd.StatusFields),
+		})
+	}
+	sort.Slice(doc.CRDs, func(i, j int) bool {
+		return doc.CRDs[i].Kind < doc.CRDs[j].Kind
+	})
+	for _, td := range typeDefs {
+		attrNames := []string{}
+		for attrName := range td.Attrs {
+			attrNames = append(attrNames, attrName)
+		}
+		sort.Strings(attrNames)
+		attrs := []*AttrDocument{}
+		for _, attrName := range attrNames {
+			attr := td.Attrs[attrName]
+			attrs = append(attrs, &AttrDocument{
+				Names:  attr.Names,
+				GoType: attr.GoType,
+			})
+		}
+		doc.TypeDefs = append(doc.TypeDefs, &TypeDefDocument{
+			Names: td.Names,
+			Attrs: attrs,
+		})
+	}
+	sort.Slice(doc.TypeDefs, func(i, j int) bool {
+		return doc.TypeDefs[i].Names.Camel < doc.TypeDefs[j].Names.Camel
+	})
+	for _, ed := range enumDefs {
+		values := []string{}
+		for _, v := range ed.Values {
+			values = append(values, v.Original)
+		}
+		doc.EnumDefs = append(doc.EnumDefs, &EnumDefDocument{
+			Names:  ed.Names,
+			Values: values,
+		})
+	}
+	sort.Slice(doc.EnumDefs, func(i, j int) bool {
+		return doc.EnumDefs[i].Names.Camel < doc.EnumDefs[j].Names.Camel
+	})
+	return doc, nil
+}
+
+// opName returns the name of the supplied operation, or the empty string if
+// the operation is nil
+func opName(op *awssdkmodel.Operation) string {
+	if op == nil {
+		return ""
+	}
+	return op.Name
+}
+
+// fieldDocuments returns the serializable representation of a map of fields,
+// sorted by field name
+func fieldDocuments(fields map[string]*Field) []*FieldDocument {
+	fieldNames := []string{}
+	for fieldName := range fields {
+		fieldNames = append(fieldNames, fieldName)
+	}
+	sort.Strings(fieldNames)
+	res := []*FieldDocument{}
+	for _, fieldName := range fieldNames {
+		f := fields[fieldName]
+		fd := &FieldDocument{
+			Names:      f.Names,
+			Path:       f.Path,
+			GoType:     f.GoType,
+			IsRequired: f.IsRequired(),
+		}
+		if f.ShapeRef != nil {
+			fd.ShapeName = f.ShapeRef.ShapeName
+		}
+		if f.FieldConfig != nil {
+			fd.IsSecret = f.FieldConfig.IsSecret
+			fd.IsImmutable = f.FieldConfig.IsImmutable
+			fd.IsPrimaryKey = f.FieldConfig.IsPrimaryKey
+		}
+		res = append(res, fd)
+	}
+	return res
+}
@@ -0,0 +1,56 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package model
+
+import (
+	"strings"
+
+	awssdkmodel "github.com/aws/aws-sdk-go/private/model/api"
+)
+
+const (
+	// RawExtensionGoType is the Go type of fields holding free-form JSON
+	// documents
+	RawExtensionGoType = "*runtime.RawExtension"
+	// RawExtensionImport is the package RawExtensionGoType is defined in
+	RawExtensionImport = "k8s.io/apimachinery/pkg/runtime"
+	// preserveUnknownFieldsMarker is the kubebuilder marker that stops the
+	// API server from pruning the members of documents, which aren't known
+	preserveUnknownFieldsMarker = "+kubebuilder:pruning:PreserveUnknownFields"
+)
+
+// IsDocument returns true if the supplied shape is a document shape, holding
+// a free-form JSON document. The aws-sdk-go model loader represents document
+// shapes as structures without any members.
+func (a *SDKAPI) IsDocument(shape *awssdkmodel.Shape) bool {
+	if shape == nil || shape.Type != "structure" {
+		return false
+	}
+	c := a.GetShapeConstraints(shape)
+	return c != nil && c.Document
+}
+
+// IsDocument returns true if the supplied shape is a document shape, whose
+// values are stored in runtime.RawExtension fields of the CR
+func (r *CRD) IsDocument(shape *awssdkmodel.Shape) bool {
+	return r.sdkAPI.IsDocument(shape)
+}
+
+// addRawExtensionImport adds the import of the runtime.RawExtension type to
+// the CRD's type imports if the supplied field holds documents
+func (r *CRD) addRawExtensionImport(f *Field) {
+	if strings.Contains(f.GoType, strings.TrimPrefix(RawExtensionGoType, "*")) {
+		r.AddTypeImport(RawExtensionImport, "")
+	}
+}
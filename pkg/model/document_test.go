@@ -0,0 +1,68 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package model_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/aws-controllers-k8s/code-generator/pkg/model"
+	"github.com/aws-controllers-k8s/code-generator/pkg/testutil"
+)
+
+func TestDocument_ECR(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	g := testutil.NewModelForService(t, "ecr")
+
+	doc, err := g.Document()
+	require.Nil(err)
+
+	assert.Equal(model.DocumentSchemaVersion, doc.SchemaVersion)
+	assert.Equal("ecr", doc.ServicePackageName)
+
+	require.Len(doc.CRDs, 1)
+	crd := doc.CRDs[0]
+	assert.Equal("Repository", crd.Kind)
+	assert.Equal("CreateRepository", crd.Operations.Create)
+	assert.Equal("DescribeRepositories", crd.Operations.ReadMany)
+	assert.Equal("DeleteRepository", crd.Operations.Delete)
+
+	specFieldNames := []string{}
+	for _, f := range crd.SpecFields {
+		specFieldNames = append(specFieldNames, f.Names.Camel)
+	}
+	assert.Equal(
+		[]string{
+			"ImageScanningConfiguration",
+			"ImageTagMutability",
+			"RepositoryName",
+			"Tags",
+		},
+		specFieldNames,
+	)
+
+	// The document must be serializable and stable across calls
+	first, err := json.Marshal(doc)
+	require.Nil(err)
+	doc, err = g.Document()
+	require.Nil(err)
+	second, err := json.Marshal(doc)
+	require.Nil(err)
+	assert.Equal(string(first), string(second))
+}
@@ -0,0 +1,292 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package model
+
+import (
+	"fmt"
+	"html"
+	"regexp"
+	"strings"
+	"unicode/utf8"
+
+	awssdkmodel "github.com/aws/aws-sdk-go/private/model/api"
+
+	ackgenconfig "github.com/aws-controllers-k8s/code-generator/pkg/generate/config"
+)
+
+// apiReferenceURLFormat is the format of the URL of the AWS API reference page
+// of an operation or data type, given the API's UID, such as
+// "ecr-2015-09-21", and the name of the operation or data type
+const apiReferenceURLFormat = "https://docs.aws.amazon.com/goto/WebAPI/%s/%s"
+
+// docWrapWidth is the number of characters lines of documentation converted
+// from HTML are wrapped at
+const docWrapWidth = 72
+
+var (
+	docLinkRegex      = regexp.MustCompile(`(?is)<a\s[^>]*?href\s*=\s*"([^"]*)"[^>]*>(.*?)</a>`)
+	docCodeRegex      = regexp.MustCompile(`(?is)<code>(.*?)</code>`)
+	docBoldRegex      = regexp.MustCompile(`(?is)<(?:b|strong)>(.*?)</(?:b|strong)>`)
+	docItalicRegex    = regexp.MustCompile(`(?is)<(?:i|em)>(.*?)</(?:i|em)>`)
+	docListItemRegex  = regexp.MustCompile(`(?i)<li>\s*`)
+	docLineBreakRegex = regexp.MustCompile(`(?i)<br\s*/?>`)
+	docBlockRegex     = regexp.MustCompile(`(?i)</?(?:p|ul|ol|note|important|div)(?:\s[^>]*)?>`)
+	docTagRegex       = regexp.MustCompile(`(?s)<[^>]*>`)
+	docBlankRegex     = regexp.MustCompile(`\n\s*\n\s*`)
+)
+
+// Documentation returns the documentation of the field, formatted as a Go
+// comment. Overrides in the field's config are applied to the documentation
+// of the field's shape in the AWS API model, after it has been cleaned up as
+// instructed by the generator config. Deprecated fields get a deprecation
+// notice, which also ends up in the description of the CRD's OpenAPI schema.
+// If enabled, a link to the AWS API reference page of the operation or data
+// type the field belongs to is appended.
+func (f *Field) Documentation() string {
+	doc := ""
+	if f.ShapeRef != nil {
+		doc = sanitizeDocumentation(f.ShapeRef.Documentation, f.CRD.cfg)
+	}
+	if f.FieldConfig != nil {
+		doc = applyDocumentationConfig(doc, f.FieldConfig.Documentation)
+	}
+	if f.IsDeprecated() {
+		doc = appendDeprecationNotice(doc, f.FieldConfig.Deprecated)
+	}
+	if apiReferenceLinksEnabled(f.CRD.cfg) {
+		doc = appendAPIReferenceLink(
+			doc, f.CRD.sdkAPI.APIReferenceURL(f.apiReferenceName()),
+		)
+	}
+	return doc
+}
+
+// APIReferenceURL returns the URL of the AWS API reference page of the
+// supplied operation or data type, or the empty string if the API model has
+// no UID to build it from
+func (a *SDKAPI) APIReferenceURL(name string) string {
+	if a == nil || a.API == nil || a.API.Metadata.UID == "" || name == "" {
+		return ""
+	}
+	return fmt.Sprintf(apiReferenceURLFormat, a.API.Metadata.UID, name)
+}
+
+// apiReferenceName returns the name of the operation or data type whose AWS
+// API reference page documents the field. Top-level fields are documented on
+// the page of the first of the resource's operations that has them as an
+// input or output member. Nested fields are documented on the page of their
+// parent field's data type.
+func (f *Field) apiReferenceName() string {
+	if !strings.Contains(f.Path, ".") {
+		for _, op := range f.CRD.Ops.IterOps() {
+			for _, ref := range []*awssdkmodel.ShapeRef{&op.InputRef, &op.OutputRef} {
+				if ref.Shape == nil {
+					continue
+				}
+				if _, found := ref.Shape.MemberRefs[f.Names.ModelOriginal]; found {
+					return op.Name
+				}
+			}
+		}
+		return ""
+	}
+	parent, found := f.CRD.Fields[ParentFieldPath(f.Path)]
+	if !found || parent.ShapeRef == nil || parent.ShapeRef.Shape == nil {
+		return ""
+	}
+	shape := parent.ShapeRef.Shape
+	switch shape.Type {
+	case "list":
+		shape = shape.MemberRef.Shape
+	case "map":
+		shape = shape.ValueRef.Shape
+	}
+	if shape == nil {
+		return ""
+	}
+	return shape.ShapeName
+}
+
+// apiReferenceLinksEnabled returns true if the generator config instructs the
+// code generator to link field documentation to the AWS API reference
+func apiReferenceLinksEnabled(cfg *ackgenconfig.Config) bool {
+	return cfg != nil && cfg.Docstrings != nil && cfg.Docstrings.APIReferenceLinks
+}
+
+// appendAPIReferenceLink returns the supplied Go comment with a paragraph
+// linking to the supplied AWS API reference URL
+func appendAPIReferenceLink(doc string, url string) string {
+	if url == "" {
+		return doc
+	}
+	return joinCommentParagraphs(doc, "// See: "+url)
+}
+
+// applyDocumentationConfig returns the supplied Go comment with the
+// replacement, prepended and appended paragraphs of the supplied config
+func applyDocumentationConfig(
+	doc string,
+	docCfg *ackgenconfig.DocumentationConfig,
+) string {
+	if docCfg == nil {
+		return doc
+	}
+	if docCfg.Replace != "" {
+		doc = goComment(docCfg.Replace)
+	}
+	if docCfg.Prepend != "" {
+		doc = joinCommentParagraphs(goComment(docCfg.Prepend), doc)
+	}
+	if docCfg.Append != "" {
+		doc = joinCommentParagraphs(doc, goComment(docCfg.Append))
+	}
+	return doc
+}
+
+// goComment returns the supplied text formatted as a Go comment
+func goComment(text string) string {
+	lines := strings.Split(strings.TrimSpace(text), "\n")
+	for x, line := range lines {
+		line = strings.TrimRight(line, " \t")
+		if line == "" {
+			lines[x] = "//"
+		} else {
+			lines[x] = "// " + line
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+// joinCommentParagraphs joins two Go comments with an empty comment line
+func joinCommentParagraphs(first string, second string) string {
+	if first == "" {
+		return second
+	}
+	if second == "" {
+		return first
+	}
+	return first + "\n//\n" + second
+}
+
+// sanitizeDocumentation returns the supplied Go comment, containing AWS API
+// model documentation, cleaned up as instructed by the generator config
+func sanitizeDocumentation(
+	doc string,
+	cfg *ackgenconfig.Config,
+) string {
+	if doc == "" || cfg == nil || cfg.Docstrings == nil {
+		return doc
+	}
+	docCfg := cfg.Docstrings
+	text := commentText(doc)
+	if docCfg.Format != "" {
+		text = convertDocumentationHTML(text, docCfg.Format)
+	}
+	for _, rewrite := range docCfg.LinkRewrites {
+		if rewrite.From != "" {
+			text = strings.Replace(text, rewrite.From, rewrite.To, -1)
+		}
+	}
+	if docCfg.MaxLength > 0 {
+		text = truncateText(text, docCfg.MaxLength)
+	}
+	if docCfg.Format != "" {
+		text = wrapParagraphs(text, docWrapWidth)
+	}
+	return goComment(text)
+}
+
+// commentText returns the text of a Go comment
+func commentText(doc string) string {
+	lines := strings.Split(doc, "\n")
+	for x, line := range lines {
+		line = strings.TrimSpace(line)
+		line = strings.TrimPrefix(line, "//")
+		lines[x] = strings.TrimPrefix(line, " ")
+	}
+	return strings.TrimSpace(strings.Join(lines, "\n"))
+}
+
+// convertDocumentationHTML converts the HTML markup of AWS API documentation
+// to plain text or Markdown. Paragraphs are separated by an empty line and
+// list items start with "- ".
+func convertDocumentationHTML(text string, format string) string {
+	markdown := format == ackgenconfig.DocstringFormatMarkdown
+	text = docLinkRegex.ReplaceAllStringFunc(text, func(link string) string {
+		parts := docLinkRegex.FindStringSubmatch(link)
+		url := strings.TrimSpace(parts[1])
+		linkText := strings.Join(strings.Fields(docTagRegex.ReplaceAllString(parts[2], "")), " ")
+		switch {
+		case linkText == "" || linkText == url:
+			return url
+		case markdown:
+			return "[" + linkText + "](" + url + ")"
+		default:
+			return linkText + " (" + url + ")"
+		}
+	})
+	if markdown {
+		text = docCodeRegex.ReplaceAllString(text, "`$1`")
+		text = docBoldRegex.ReplaceAllString(text, "**$1**")
+		text = docItalicRegex.ReplaceAllString(text, "*$1*")
+	}
+	text = docListItemRegex.ReplaceAllString(text, "\n\n- ")
+	text = docLineBreakRegex.ReplaceAllString(text, "\n\n")
+	text = docBlockRegex.ReplaceAllString(text, "\n\n")
+	text = docTagRegex.ReplaceAllString(text, "")
+	text = html.UnescapeString(text)
+	return strings.TrimSpace(docBlankRegex.ReplaceAllString(text, "\n\n"))
+}
+
+// truncateText shortens text longer than the supplied number of characters
+// at the last word boundary before the limit and appends an ellipsis
+func truncateText(text string, maxLength int) string {
+	if utf8.RuneCountInString(text) <= maxLength {
+		return text
+	}
+	runes := []rune(text)
+	cut := string(runes[:maxLength])
+	if x := strings.LastIndexAny(cut, " \n"); x > 0 {
+		cut = cut[:x]
+	}
+	return strings.TrimSpace(cut) + "..."
+}
+
+// wrapParagraphs reflows the paragraphs of the supplied text, which are
+// separated by empty lines, to lines no longer than the supplied width.
+// Continuation lines of list items are indented.
+func wrapParagraphs(text string, width int) string {
+	paragraphs := strings.Split(text, "\n\n")
+	for x, paragraph := range paragraphs {
+		indent := ""
+		if strings.HasPrefix(paragraph, "- ") {
+			indent = "  "
+		}
+		lines := []string{}
+		line := ""
+		for _, word := range strings.Fields(paragraph) {
+			switch {
+			case line == "":
+				line = word
+			case utf8.RuneCountInString(line)+1+utf8.RuneCountInString(word) > width:
+				lines = append(lines, line)
+				line = indent + word
+			default:
+				line += " " + word
+			}
+		}
+		paragraphs[x] = strings.Join(append(lines, line), "\n")
+	}
+	return strings.Join(paragraphs, "\n\n")
+}
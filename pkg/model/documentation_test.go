@@ -0,0 +1,138 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package model_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/aws-controllers-k8s/code-generator/pkg/testutil"
+)
+
+func TestDocumentation_ECRRepository(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	g := testutil.NewModelForServiceWithOptions(t, "ecr", &testutil.TestingModelOptions{
+		GeneratorConfigFile: "generator-with-documentation.yaml",
+	})
+
+	crds, err := g.GetCRDs()
+	require.Nil(err)
+
+	crd := getCRDByName("Repository", crds)
+	require.NotNil(crd)
+
+	// Appended paragraphs follow the AWS API model's documentation
+	repoName := crd.SpecFields["RepositoryName"]
+	require.NotNil(repoName)
+	doc := repoName.Documentation()
+	assert.True(strings.HasPrefix(doc, repoName.ShapeRef.Documentation))
+	assert.True(strings.HasSuffix(doc, "\n//\n// Changing the name of a repository replaces it."))
+
+	// Fields without a documentation override are unchanged
+	tagMutability := crd.SpecFields["ImageTagMutability"]
+	assert.Equal(tagMutability.ShapeRef.Documentation, tagMutability.Documentation())
+
+	// Nested field overrides are applied to the attributes of type
+	// definitions
+	tdefs, err := g.GetTypeDefs()
+	require.Nil(err)
+	var scanOnPushDoc string
+	for _, tdef := range tdefs {
+		if tdef.Names.Camel == "ImageScanningConfiguration" {
+			scanOnPushDoc = tdef.Attrs["ScanOnPush"].Documentation
+		}
+	}
+	assert.Equal(
+		"// Whether images are scanned after being pushed.\n"+
+			"//\n"+
+			"// Images that are already in the repository are not scanned.",
+		scanOnPushDoc,
+	)
+}
+
+func TestDocumentation_ECRRepository_Docstrings(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	g := testutil.NewModelForServiceWithOptions(t, "ecr", &testutil.TestingModelOptions{
+		GeneratorConfigFile: "generator-with-docstrings.yaml",
+	})
+
+	crds, err := g.GetCRDs()
+	require.Nil(err)
+
+	crd := getCRDByName("Repository", crds)
+	require.NotNil(crd)
+
+	// Documentation longer than max_length is cut at a word boundary
+	tagMutability := crd.SpecFields["ImageTagMutability"]
+	require.NotNil(tagMutability)
+	assert.Equal(
+		"// The tag mutability setting for the repository. If this...",
+		tagMutability.Documentation(),
+	)
+
+	// Documentation of type definition attributes is sanitized too
+	tdefs, err := g.GetTypeDefs()
+	require.Nil(err)
+	for _, tdef := range tdefs {
+		for _, attr := range tdef.Attrs {
+			for _, line := range strings.Split(attr.Documentation, "\n") {
+				assert.True(len(line) <= len("// ")+60+len("..."), line)
+			}
+		}
+	}
+}
+
+func TestDocumentation_ECRRepository_APIReferenceLinks(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	g := testutil.NewModelForServiceWithOptions(t, "ecr", &testutil.TestingModelOptions{
+		GeneratorConfigFile: "generator-with-api-reference-links.yaml",
+	})
+
+	crds, err := g.GetCRDs()
+	require.Nil(err)
+
+	crd := getCRDByName("Repository", crds)
+	require.NotNil(crd)
+
+	// Top-level fields link to the operation they are a member of
+	repoName := crd.SpecFields["RepositoryName"]
+	require.NotNil(repoName)
+	assert.True(strings.HasSuffix(
+		repoName.Documentation(),
+		"\n//\n// See: https://docs.aws.amazon.com/goto/WebAPI/ecr-2015-09-21/CreateRepository",
+	))
+
+	// Attributes of type definitions link to their data type
+	tdefs, err := g.GetTypeDefs()
+	require.Nil(err)
+	var scanOnPushDoc string
+	for _, tdef := range tdefs {
+		if tdef.Names.Camel == "ImageScanningConfiguration" {
+			scanOnPushDoc = tdef.Attrs["ScanOnPush"].Documentation
+		}
+	}
+	assert.True(strings.HasSuffix(
+		scanOnPushDoc,
+		"// See: https://docs.aws.amazon.com/goto/WebAPI/ecr-2015-09-21/ImageScanningConfiguration",
+	))
+}
@@ -0,0 +1,130 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package model
+
+import (
+	"fmt"
+
+	ackgenconfig "github.com/aws-controllers-k8s/code-generator/pkg/generate/config"
+	"github.com/aws-controllers-k8s/code-generator/pkg/names"
+)
+
+// EndpointURLFieldName is the name of the Spec field overriding the URL of
+// the AWS API endpoint called for a resource
+const EndpointURLFieldName = "EndpointURL"
+
+// RegionFieldName is the name of the Spec field selecting the AWS region a
+// resource is managed in
+const RegionFieldName = "Region"
+
+// Endpoint returns the config of the endpoints called for the custom
+// resource, if specified in generator config
+func (r *CRD) Endpoint() *ackgenconfig.EndpointConfig {
+	return r.cfg.ResourceEndpoint(r.Names.Original)
+}
+
+// EndpointURLFrom returns where the URL overriding the AWS API endpoint
+// called for the custom resource is read from, "annotation" or "spec_field",
+// empty if it can't be overridden
+func (r *CRD) EndpointURLFrom() string {
+	return r.cfg.ResourceEndpointURLFrom(r.Names.Original)
+}
+
+// RegionFrom returns where the AWS region the custom resource is managed in
+// is read from, "annotation" or "spec_field", empty if it is the region of
+// its namespace
+func (r *CRD) RegionFrom() string {
+	return r.cfg.ResourceRegionFrom(r.Names.Original)
+}
+
+// addEndpointURLField adds to the Spec the field overriding the URL of the
+// AWS API endpoint called for the resource, when configured to be read from
+// a Spec field. Changes of the field don't need the AWS resource to be
+// updated, so they are not compared.
+func (r *CRD) addEndpointURLField() error {
+	if r.EndpointURLFrom() != ackgenconfig.EndpointURLFromSpecField {
+		return nil
+	}
+	if _, found := r.SpecFields[EndpointURLFieldName]; found {
+		return fmt.Errorf(
+			"%s: endpoint URL field %s is already a Spec field",
+			r.Names.Original, EndpointURLFieldName,
+		)
+	}
+	fieldNames := names.New(EndpointURLFieldName)
+	field := NewField(r, fieldNames.Camel, fieldNames, nil, &ackgenconfig.FieldConfig{
+		CustomField: &ackgenconfig.CustomFieldConfig{GoType: "*string"},
+		Compare:     &ackgenconfig.CompareFieldConfig{IsIgnored: true},
+		Documentation: &ackgenconfig.DocumentationConfig{
+			Replace: fmt.Sprintf(
+				"%s overrides the URL of the AWS API endpoint called for the resource, e.g. to call LocalStack or a private link endpoint.",
+				fieldNames.Camel,
+			),
+		},
+	})
+	r.SpecFields[EndpointURLFieldName] = field
+	r.Fields[field.Path] = field
+	return nil
+}
+
+// addRegionFields adds to the Status the field recording the AWS region the
+// resource is managed in, when the resource may select its region, and to
+// the Spec the field selecting it, when configured to be read from a Spec
+// field. Changes of the Spec field don't need the AWS resource to be
+// updated, so they are not compared.
+func (r *CRD) addRegionFields() error {
+	regionFrom := r.RegionFrom()
+	if regionFrom == "" {
+		return nil
+	}
+	if _, found := r.StatusFields[RegionFieldName]; found {
+		return fmt.Errorf(
+			"%s: region field %s is already a Status field",
+			r.Names.Original, RegionFieldName,
+		)
+	}
+	fieldNames := names.New(RegionFieldName)
+	statusField := NewField(r, fieldNames.Camel, fieldNames, nil, &ackgenconfig.FieldConfig{
+		CustomField: &ackgenconfig.CustomFieldConfig{GoType: "*string"},
+		Documentation: &ackgenconfig.DocumentationConfig{
+			Replace: fmt.Sprintf(
+				"%s is the AWS region the resource is managed in.",
+				fieldNames.Camel,
+			),
+		},
+	})
+	r.StatusFields[RegionFieldName] = statusField
+	if regionFrom != ackgenconfig.EndpointURLFromSpecField {
+		return nil
+	}
+	if _, found := r.SpecFields[RegionFieldName]; found {
+		return fmt.Errorf(
+			"%s: region field %s is already a Spec field",
+			r.Names.Original, RegionFieldName,
+		)
+	}
+	specField := NewField(r, fieldNames.Camel, fieldNames, nil, &ackgenconfig.FieldConfig{
+		CustomField: &ackgenconfig.CustomFieldConfig{GoType: "*string"},
+		Compare:     &ackgenconfig.CompareFieldConfig{IsIgnored: true},
+		Documentation: &ackgenconfig.DocumentationConfig{
+			Replace: fmt.Sprintf(
+				"%s is the AWS region the resource is managed in, instead of the region of its namespace. Changing it makes the controller manage the resource of the same name in the new region.",
+				fieldNames.Camel,
+			),
+		},
+	})
+	r.SpecFields[RegionFieldName] = specField
+	r.Fields[specField.Path] = specField
+	return nil
+}
@@ -16,6 +16,9 @@ package model
 import (
 	"bytes"
 
+	awssdkmodel "github.com/aws/aws-sdk-go/private/model/api"
+
+	ackgenconfig "github.com/aws-controllers-k8s/code-generator/pkg/generate/config"
 	"github.com/aws-controllers-k8s/code-generator/pkg/names"
 )
 
@@ -56,3 +59,37 @@ func newEnumVal(orig string) EnumValue {
 		Clean:    string(clean),
 	}
 }
+
+// IsTypedEnum returns true if the supplied shape is a string enum and the
+// generator config asks for fields of enum shapes to use the type generated
+// for the enum instead of *string
+func IsTypedEnum(cfg *ackgenconfig.Config, shape *awssdkmodel.Shape) bool {
+	if cfg == nil || !cfg.EnumTypes || shape == nil {
+		return false
+	}
+	return shape.Type == "string" && shape.IsEnum()
+}
+
+// GetEnumTypeName returns the name of the type generated for the enum shape
+// with the supplied name
+func (a *SDKAPI) GetEnumTypeName(
+	shapeName string,
+	cfg *ackgenconfig.Config,
+) string {
+	enumNames := names.New(shapeName)
+	// Handle name conflicts with top-level CRD.Spec or CRD.Status types
+	if a.HasConflictingTypeName(shapeName, cfg) {
+		enumNames.Camel += ConflictingNameSuffix
+	}
+	return enumNames.Camel
+}
+
+// EnumTypeName returns the name of the type generated for the supplied enum
+// shape if fields of the shape use that type, or the empty string if they use
+// *string
+func (r *CRD) EnumTypeName(shape *awssdkmodel.Shape) string {
+	if !IsTypedEnum(r.cfg, shape) {
+		return ""
+	}
+	return r.sdkAPI.GetEnumTypeName(shape.ShapeName, r.cfg)
+}
@@ -13,6 +13,10 @@
 
 package model
 
+import (
+	ackgenconfig "github.com/aws-controllers-k8s/code-generator/pkg/generate/config"
+)
+
 // TerminalExceptionCodes returns terminal exception codes as
 // []string for custom resource, if specified in generator config
 func (r *CRD) TerminalExceptionCodes() []string {
@@ -26,6 +30,19 @@ func (r *CRD) TerminalExceptionCodes() []string {
 	return nil
 }
 
+// TerminalExceptionMessages returns the exceptions that are terminal for the
+// custom resource depending on their message, if specified in generator config
+func (r *CRD) TerminalExceptionMessages() []ackgenconfig.TerminalMessageConfig {
+	if r.cfg == nil {
+		return nil
+	}
+	resGenConfig, found := r.cfg.Resources[r.Names.Original]
+	if found && resGenConfig.Exceptions != nil {
+		return resGenConfig.Exceptions.TerminalMessages
+	}
+	return nil
+}
+
 // ExceptionCode returns the name of the resource's Exception code for the
 // Exception having the exception code. If the generator config has
 // instructions for overriding the name of an exception code for a resource for
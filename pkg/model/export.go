@@ -0,0 +1,61 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package model
+
+import (
+	"fmt"
+
+	ackgenconfig "github.com/aws-controllers-k8s/code-generator/pkg/generate/config"
+	"github.com/aws-controllers-k8s/code-generator/pkg/names"
+)
+
+// ExportToFieldName is the name of the Spec field naming the Secret or
+// ConfigMap the values of a resource's exported fields are written to
+const ExportToFieldName = "ExportTo"
+
+// Export returns the instructions to write the values of some of the
+// resource's fields to a Secret or ConfigMap, if any
+func (r *CRD) Export() *ackgenconfig.ExportConfig {
+	return r.cfg.ResourceExport(r.Names.Original)
+}
+
+// addExportField adds to the Spec the field naming the Secret or ConfigMap
+// the values of the resource's exported fields are written to. Changes of the
+// field don't need the AWS resource to be updated, so they are not compared.
+func (r *CRD) addExportField() error {
+	export := r.Export()
+	if export == nil {
+		return nil
+	}
+	if _, found := r.SpecFields[ExportToFieldName]; found {
+		return fmt.Errorf(
+			"%s: export field %s is already a Spec field",
+			r.Names.Original, ExportToFieldName,
+		)
+	}
+	fieldNames := names.New(ExportToFieldName)
+	field := NewField(r, fieldNames.Camel, fieldNames, nil, &ackgenconfig.FieldConfig{
+		CustomField: &ackgenconfig.CustomFieldConfig{GoType: "*string"},
+		Compare:     &ackgenconfig.CompareFieldConfig{IsIgnored: true},
+		Documentation: &ackgenconfig.DocumentationConfig{
+			Replace: fmt.Sprintf(
+				"%s is the name of the %s in the namespace of the resource some of its field values are written to.",
+				fieldNames.Camel, export.GetKind(),
+			),
+		},
+	})
+	r.SpecFields[ExportToFieldName] = field
+	r.Fields[field.Path] = field
+	return nil
+}
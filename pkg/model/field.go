@@ -60,6 +60,10 @@ func (f *Field) IsRequired() bool {
 	if f.FieldConfig != nil && f.FieldConfig.IsRequired != nil {
 		return *f.FieldConfig.IsRequired
 	}
+	if f.FieldConfig != nil && f.FieldConfig.References != nil {
+		// The value may be read from the referenced objects instead
+		return false
+	}
 	return util.InStrings(f.Names.ModelOriginal, f.CRD.Ops.Create.InputRef.Shape.Required)
 }
 
@@ -112,7 +116,15 @@ func NewField(
 		shape = shapeRef.Shape
 	}
 
-	if shape != nil {
+	if cfg != nil && cfg.CustomField != nil && cfg.CustomField.GoType != "" {
+		// The Go type is supplied by hook code
+		gte, gt, gtwp = customGoType(cfg.CustomField)
+	} else if shape != nil && isTopLevelTagField(path, fieldNames) &&
+		tagFormat(crd.cfg, shape) != "" {
+		// Tags are converted to the configured format
+		gte, gt = tagGoType(tagFormat(crd.cfg, shape))
+		gtwp = gt
+	} else if shape != nil {
 		gte, gt, gtwp = CleanGoType(crd.sdkAPI, crd.cfg, shape, cfg)
 	} else {
 		gte = "string"
@@ -0,0 +1,249 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package model
+
+import (
+	"encoding/json"
+	"sort"
+
+	awssdkmodel "github.com/aws/aws-sdk-go/private/model/api"
+
+	ackgenconfig "github.com/aws-controllers-k8s/code-generator/pkg/generate/config"
+)
+
+// IAMPolicy is the IAM policy granting the actions a controller performs
+type IAMPolicy struct {
+	Version   string                `json:"Version"`
+	Statement []*IAMPolicyStatement `json:"Statement"`
+}
+
+// IAMPolicyStatement grants the actions the controller performs for the
+// resources of one kind
+type IAMPolicyStatement struct {
+	Sid      string   `json:"Sid"`
+	Effect   string   `json:"Effect"`
+	Action   []string `json:"Action"`
+	Resource []string `json:"Resource"`
+}
+
+// IAMActionPrefix returns the prefix of the IAM actions of the AWS service
+// API, e.g. "rds"
+func (m *Model) IAMActionPrefix() string {
+	return iamActionPrefix(m.cfg, m.SDKAPI)
+}
+
+// iamActionPrefix returns the prefix of the IAM actions of the supplied AWS
+// service API, configured in the supplied generator config or else its
+// signing name
+func iamActionPrefix(cfg *ackgenconfig.Config, sdkAPI *SDKAPI) string {
+	if cfg != nil && cfg.IAMActionPrefix != "" {
+		return cfg.IAMActionPrefix
+	}
+	if sdkAPI.API.Metadata.SigningName != "" {
+		return sdkAPI.API.Metadata.SigningName
+	}
+	return sdkAPI.API.Metadata.EndpointPrefix
+}
+
+// IAMPolicy returns the least privileged IAM policy granting the actions of
+// all the API operations the controller calls, with a statement for each kind
+// of resource
+func (m *Model) IAMPolicy() (*IAMPolicy, error) {
+	crds, err := m.GetCRDs()
+	if err != nil {
+		return nil, err
+	}
+	policy := &IAMPolicy{
+		Version:   "2012-10-17",
+		Statement: []*IAMPolicyStatement{},
+	}
+	for _, crd := range crds {
+		actions := crd.IAMActions().All()
+		if len(actions) == 0 {
+			continue
+		}
+		resources := []string{"*"}
+		policyConfig := crd.cfg.ResourceIAMPolicy(crd.Names.Original)
+		if policyConfig != nil && len(policyConfig.Resources) > 0 {
+			resources = policyConfig.Resources
+		}
+		policy.Statement = append(policy.Statement, &IAMPolicyStatement{
+			Sid:      crd.Names.Camel,
+			Effect:   "Allow",
+			Action:   actions,
+			Resource: resources,
+		})
+	}
+	return policy, nil
+}
+
+// IAMPolicyJSON returns the indented JSON document of the controller's IAM
+// policy
+func (m *Model) IAMPolicyJSON() (string, error) {
+	policy, err := m.IAMPolicy()
+	if err != nil {
+		return "", err
+	}
+	b, err := json.MarshalIndent(policy, "", "  ")
+	if err != nil {
+		return "", err
+	}
+	return string(b), nil
+}
+
+// IAMActions lists the IAM actions the controller performs for the resources
+// of one kind, by the path of the reconciliation performing them
+type IAMActions struct {
+	// Create are the actions performed to create the resources
+	Create []string `json:"create"`
+	// Read are the actions performed to read the latest state of the
+	// resources
+	Read []string `json:"read"`
+	// Update are the actions performed to update the resources
+	Update []string `json:"update"`
+	// Delete are the actions performed to delete the resources
+	Delete []string `json:"delete"`
+	// Tag are the actions performed to keep the tags of the resources in
+	// sync
+	Tag []string `json:"tag"`
+	// Extra are the actions granted by the generator config besides those
+	// of the API operations, e.g. for the AWS resources the API calls other
+	// services for
+	Extra []string `json:"extra"`
+}
+
+// All returns the sorted actions of all the paths
+func (a *IAMActions) All() []string {
+	seen := map[string]bool{}
+	all := []string{}
+	for _, actions := range [][]string{
+		a.Create, a.Read, a.Update, a.Delete, a.Tag, a.Extra,
+	} {
+		for _, action := range actions {
+			if !seen[action] {
+				seen[action] = true
+				all = append(all, action)
+			}
+		}
+	}
+	sort.Strings(all)
+	return all
+}
+
+// IAMActions returns the IAM actions the controller performs for the
+// resource, by the path of the reconciliation performing them. Only the read
+// actions are performed for the resources the controller observes without
+// managing them.
+func (r *CRD) IAMActions() *IAMActions {
+	prefix := iamActionPrefix(r.cfg, r.sdkAPI)
+	actions := func(ops []*awssdkmodel.Operation) []string {
+		names := []string{}
+		for _, opName := range operationNames(ops) {
+			names = append(names, prefix+":"+opName)
+		}
+		return names
+	}
+	ops := r.operationsByPath()
+	var a *IAMActions
+	if ccr := r.CloudControlResource(); ccr != nil {
+		a = cloudControlIAMActions(ccr, ops)
+	} else {
+		a = &IAMActions{
+			Create: actions(ops.create),
+			Read:   actions(ops.read),
+			Update: actions(ops.update),
+			Delete: actions(ops.delete),
+			Tag:    actions(ops.tag),
+			Extra:  []string{},
+		}
+	}
+	if policyConfig := r.cfg.ResourceIAMPolicy(r.Names.Original); policyConfig != nil {
+		a.Extra = append(a.Extra, policyConfig.ExtraActions...)
+		sort.Strings(a.Extra)
+	}
+	return a
+}
+
+// SDKOperationNames returns the sorted names of the API operations the
+// controller calls for the resource. Only the read operations are called for
+// the resources the controller observes without managing them.
+func (r *CRD) SDKOperationNames() []string {
+	ops := r.operationsByPath()
+	all := []*awssdkmodel.Operation{}
+	for _, pathOps := range [][]*awssdkmodel.Operation{
+		ops.create, ops.read, ops.update, ops.delete, ops.tag,
+	} {
+		all = append(all, pathOps...)
+	}
+	return operationNames(all)
+}
+
+// pathOperations are the API operations the controller calls for a resource,
+// by the path of the reconciliation calling them
+type pathOperations struct {
+	create []*awssdkmodel.Operation
+	read   []*awssdkmodel.Operation
+	update []*awssdkmodel.Operation
+	delete []*awssdkmodel.Operation
+	tag    []*awssdkmodel.Operation
+}
+
+// operationsByPath returns the API operations the controller calls for the
+// resource, by the path of the reconciliation calling them. The secondary
+// operations are called after the resource is created and when it is
+// updated.
+func (r *CRD) operationsByPath() *pathOperations {
+	ops := &pathOperations{
+		read: []*awssdkmodel.Operation{
+			r.Ops.ReadOne, r.Ops.ReadMany, r.Ops.GetAttributes,
+		},
+	}
+	taggingOps := r.TaggingOps()
+	if taggingOps != nil {
+		ops.tag = append(ops.tag, taggingOps.List.Operation)
+	}
+	if r.IsObserveOnly() {
+		return ops
+	}
+	ops.create = append(ops.create, r.Ops.Create)
+	ops.update = append(ops.update, r.Ops.Update, r.Ops.SetAttributes)
+	for _, op := range r.SecondaryOps {
+		ops.create = append(ops.create, op.Operation)
+		ops.update = append(ops.update, op.Operation)
+	}
+	for _, op := range r.UpdateOps {
+		ops.update = append(ops.update, op.Operation)
+	}
+	ops.delete = append(ops.delete, r.Ops.Delete)
+	if taggingOps != nil {
+		ops.tag = append(ops.tag, taggingOps.Tag.Operation, taggingOps.Untag.Operation)
+	}
+	return ops
+}
+
+// operationNames returns the sorted, unique names of the supplied operations,
+// skipping the nil ones
+func operationNames(ops []*awssdkmodel.Operation) []string {
+	seen := map[string]bool{}
+	opNames := []string{}
+	for _, op := range ops {
+		if op == nil || seen[op.Name] {
+			continue
+		}
+		seen[op.Name] = true
+		opNames = append(opNames, op.Name)
+	}
+	sort.Strings(opNames)
+	return opNames
+}
@@ -16,11 +16,13 @@ package model
 import (
 	"errors"
 	"fmt"
+	"path"
 	"sort"
 	"strings"
 
 	ackgenconfig "github.com/aws-controllers-k8s/code-generator/pkg/generate/config"
 	"github.com/aws-controllers-k8s/code-generator/pkg/generate/templateset"
+	ackgenlog "github.com/aws-controllers-k8s/code-generator/pkg/log"
 	"github.com/aws-controllers-k8s/code-generator/pkg/names"
 	"github.com/aws-controllers-k8s/code-generator/pkg/util"
 	awssdkmodel "github.com/aws/aws-sdk-go/private/model/api"
@@ -29,6 +31,8 @@ import (
 var (
 	// ErrNilShapePointer indicates an unexpected nil Shape pointer
 	ErrNilShapePointer = errors.New("found nil Shape pointer")
+
+	log = ackgenlog.Log().WithName("model")
 )
 
 // Model contains the ACK model for the generator to process and apply
@@ -50,13 +54,19 @@ type Model struct {
 // service API
 func (m *Model) MetaVars() templateset.MetaVars {
 	return templateset.MetaVars{
-		ServicePackageName:   m.servicePackageName,
-		ServiceID:            m.SDKAPI.ServiceID(),
-		ServiceModelName:     m.cfg.ModelName,
-		APIGroup:             m.APIGroup(),
-		APIVersion:           m.apiVersion,
-		APIInterfaceTypeName: m.SDKAPI.APIInterfaceTypeName(),
-		CRDNames:             m.crdNames(),
+		ServicePackageName:    m.servicePackageName,
+		SDKPackageName:        m.SDKPackageName(),
+		ServiceID:             m.SDKAPI.ServiceID(),
+		ServiceModelName:      m.cfg.ModelName,
+		APIGroup:              m.APIGroup(),
+		APIVersion:            m.apiVersion,
+		APIInterfaceTypeName:  m.SDKAPI.APIInterfaceTypeName(),
+		CRDNames:              m.crdNames(),
+		NamespacedCRDNames:    m.scopedCRDNames(false),
+		ClusterScopedCRDNames: m.scopedCRDNames(true),
+		CRDBases:              m.crdBases(),
+		APIPackagePath:        m.APIPackagePath(m.APIGroup()),
+		AdditionalAPIGroups:   m.additionalAPIGroupVars(),
 	}
 }
 
@@ -72,6 +82,40 @@ func (m *Model) crdNames() []string {
 	return crdConfigs
 }
 
+// scopedCRDNames returns the names, lowercased and in plural, of the crds
+// whose resources are cluster-scoped if clusterScoped is true, or namespaced
+// otherwise
+func (m *Model) scopedCRDNames(clusterScoped bool) []string {
+	var crdConfigs []string
+
+	crds, _ := m.GetCRDs()
+	for _, crd := range crds {
+		if crd.IsClusterScoped() == clusterScoped {
+			crdConfigs = append(crdConfigs, strings.ToLower(crd.Plural))
+		}
+	}
+
+	return crdConfigs
+}
+
+// createOps returns, keyed by resource name, the Create operations of the
+// resources, read from the API model each resource is generated from
+func (m *Model) createOps() map[string]*awssdkmodel.Operation {
+	createOps := map[string]*awssdkmodel.Operation{}
+	for crdName, op := range (*m.SDKAPI.GetOperationMap(m.cfg))[OpTypeCreate] {
+		createOps[crdName] = op
+	}
+	for crdName := range m.SDKAPI.ResourceAPIs {
+		opMap := *m.SDKAPI.ResourceOperationMap(crdName, m.cfg)
+		if op, found := opMap[OpTypeCreate][crdName]; found {
+			createOps[crdName] = op
+		} else {
+			delete(createOps, crdName)
+		}
+	}
+	return createOps
+}
+
 // GetCRDs returns a slice of `CRD` structs that describe the
 // top-level resources discovered by the code generator for an AWS service API
 func (m *Model) GetCRDs() ([]*CRD, error) {
@@ -80,32 +124,28 @@ func (m *Model) GetCRDs() ([]*CRD, error) {
 	}
 	crds := []*CRD{}
 
-	opMap := m.SDKAPI.GetOperationMap(m.cfg)
-
-	createOps := (*opMap)[OpTypeCreate]
-	readOneOps := (*opMap)[OpTypeGet]
-	readManyOps := (*opMap)[OpTypeList]
-	updateOps := (*opMap)[OpTypeUpdate]
-	deleteOps := (*opMap)[OpTypeDelete]
-	getAttributesOps := (*opMap)[OpTypeGetAttributes]
-	setAttributesOps := (*opMap)[OpTypeSetAttributes]
-
-	for crdName, createOp := range createOps {
+	for crdName, createOp := range m.createOps() {
 		if m.cfg.IsIgnoredResource(crdName) {
+			log.V(1).Info("ignoring resource", "resource", crdName)
 			continue
 		}
 		crdNames := names.New(crdName)
+		// Resources may be generated from another version of the service
+		// API, whose operations they use
+		sdkAPI := m.SDKAPI.ResourceSDKAPI(crdName)
+		opMap := *m.SDKAPI.ResourceOperationMap(crdName, m.cfg)
 		ops := Ops{
-			Create:        createOps[crdName],
-			ReadOne:       readOneOps[crdName],
-			ReadMany:      readManyOps[crdName],
-			Update:        updateOps[crdName],
-			Delete:        deleteOps[crdName],
-			GetAttributes: getAttributesOps[crdName],
-			SetAttributes: setAttributesOps[crdName],
+			Create:        createOp,
+			ReadOne:       opMap[OpTypeGet][crdName],
+			ReadMany:      opMap[OpTypeList][crdName],
+			Update:        opMap[OpTypeUpdate][crdName],
+			Delete:        opMap[OpTypeDelete][crdName],
+			GetAttributes: opMap[OpTypeGetAttributes][crdName],
+			SetAttributes: opMap[OpTypeSetAttributes][crdName],
 		}
 		m.RemoveIgnoredOperations(&ops)
-		crd := NewCRD(m.SDKAPI, m.cfg, crdNames, ops)
+		log.V(1).Info("matched operations to resource", ops.logValues(crdName)...)
+		crd := NewCRD(sdkAPI, m.cfg, crdNames, ops)
 
 		// OK, begin to gather the CRDFields that will go into the Spec struct.
 		// These fields are those members of the Create operation's Input
@@ -118,15 +158,28 @@ func (m *Model) GetCRDs() ([]*CRD, error) {
 			if memberShapeRef.Shape == nil {
 				return nil, ErrNilShapePointer
 			}
+			if batchOp := crd.BatchOperation(createOp); batchOp != nil && memberName == batchOp.InputMember {
+				// The Spec holds a single element of the batch
+				if err := crd.addBatchSpecFields(createOp, memberName, memberShapeRef); err != nil {
+					return nil, err
+				}
+				continue
+			}
 			// Handles field renames, if applicable
-			fieldName, _ := m.cfg.ResourceFieldRename(
+			fieldName, renamed := m.cfg.ResourceFieldRename(
 				crd.Names.Original,
 				createOp.Name,
 				memberName,
 			)
+			if renamed {
+				log.V(2).Info(
+					"renamed field", "resource", crdName,
+					"operation", createOp.Name, "from", memberName, "to", fieldName,
+				)
+			}
 			memberNames := names.New(fieldName)
 			memberNames.ModelOriginal = memberName
-			if memberName == "Attributes" && m.cfg.UnpacksAttributesMap(crdName) {
+			if memberName == m.cfg.AttributesMapMemberName(crdName) && m.cfg.UnpacksAttributesMap(crdName) {
 				crd.UnpackAttributes()
 				continue
 			}
@@ -146,7 +199,7 @@ func (m *Model) GetCRDs() ([]*CRD, error) {
 
 			if fieldConfig.From != nil {
 				from := fieldConfig.From
-				memberShapeRef, found = m.SDKAPI.GetInputShapeRef(
+				memberShapeRef, found = sdkAPI.GetInputShapeRef(
 					from.Operation, from.Path,
 				)
 				if !found {
@@ -159,12 +212,8 @@ func (m *Model) GetCRDs() ([]*CRD, error) {
 				}
 			} else if fieldConfig.CustomField != nil {
 				customField := fieldConfig.CustomField
-				if customField.ListOf != "" {
-					memberShapeRef = m.SDKAPI.GetCustomShapeRef(customField.ListOf)
-				} else {
-					memberShapeRef = m.SDKAPI.GetCustomShapeRef(customField.MapOf)
-				}
-				if memberShapeRef == nil {
+				memberShapeRef, found = m.customFieldShapeRef(customField)
+				if !found {
 					// This is a compile-time failure, just bomb out...
 					msg := fmt.Sprintf(
 						"unknown additional Spec field with custom field %+v",
@@ -177,10 +226,56 @@ func (m *Model) GetCRDs() ([]*CRD, error) {
 				continue
 			}
 
+			log.V(2).Info(
+				"added Spec field from generator config",
+				"resource", crdName, "field", targetFieldName,
+			)
 			memberNames := names.New(targetFieldName)
 			crd.AddSpecField(memberNames, memberShapeRef)
 		}
 
+		// And the Spec fields referencing the objects other Spec fields'
+		// values are read from
+		if err := crd.addReferenceFields(); err != nil {
+			return nil, err
+		}
+
+		// And the Spec field naming the object the exported fields' values
+		// are written to
+		if err := crd.addExportField(); err != nil {
+			return nil, err
+		}
+
+		// And the Spec field overriding the URL of the AWS API endpoint
+		if err := crd.addEndpointURLField(); err != nil {
+			return nil, err
+		}
+
+		// And the fields selecting and recording the AWS region of the
+		// resource
+		if err := crd.addRegionFields(); err != nil {
+			return nil, err
+		}
+
+		// And the Spec fields for the Input shapes of the secondary
+		// operations configuring parts of the resource
+		if err := m.addSecondaryOps(crd); err != nil {
+			return nil, err
+		}
+
+		// And the operations updating groups of the Spec fields
+		if err := m.addUpdateOps(crd); err != nil {
+			return nil, err
+		}
+
+		// The Create operation of an upsert resource succeeds whether or not
+		// the resource exists, so only the ReadOne operation can tell it
+		if crd.IsUpsert() && crd.Ops.ReadOne == nil {
+			return nil, fmt.Errorf(
+				"upsert resource %s has no ReadOne operation", crdName,
+			)
+		}
+
 		// Now process the fields that will go into the Status struct. We want
 		// fields that are in the Create operation's Output Shape but that are
 		// not in the Input Shape.
@@ -202,6 +297,18 @@ func (m *Model) GetCRDs() ([]*CRD, error) {
 			if memberShapeRef.Shape == nil {
 				return nil, ErrNilShapePointer
 			}
+			if batchOp := crd.BatchOperation(createOp); batchOp != nil {
+				if memberName == batchOp.ErrorsMember {
+					continue
+				}
+				if memberName == batchOp.OutputMember {
+					// The Status holds a single element of the batch
+					if err := crd.addBatchStatusFields(createOp, memberName, memberShapeRef); err != nil {
+						return nil, err
+					}
+					continue
+				}
+			}
 			// Check that the field in the output shape isn't the same as
 			// fields in the input shape (handles field renames, if applicable)
 			fieldName, _ := m.cfg.ResourceFieldRename(
@@ -217,7 +324,7 @@ func (m *Model) GetCRDs() ([]*CRD, error) {
 			memberNames := names.New(fieldName)
 
 			//TODO:(brycahta) should we support overriding these fields?
-			if memberName == "Attributes" && m.cfg.UnpacksAttributesMap(crdName) {
+			if memberName == m.cfg.AttributesMapMemberName(crdName) && m.cfg.UnpacksAttributesMap(crdName) {
 				continue
 			}
 			if crd.IsPrimaryARNField(memberName) {
@@ -241,7 +348,7 @@ func (m *Model) GetCRDs() ([]*CRD, error) {
 
 			if fieldConfig.From != nil {
 				from := fieldConfig.From
-				memberShapeRef, found = m.SDKAPI.GetOutputShapeRef(
+				memberShapeRef, found = sdkAPI.GetOutputShapeRef(
 					from.Operation, from.Path,
 				)
 				if !found {
@@ -254,12 +361,8 @@ func (m *Model) GetCRDs() ([]*CRD, error) {
 				}
 			} else if fieldConfig.CustomField != nil {
 				customField := fieldConfig.CustomField
-				if customField.ListOf != "" {
-					memberShapeRef = m.SDKAPI.GetCustomShapeRef(customField.ListOf)
-				} else {
-					memberShapeRef = m.SDKAPI.GetCustomShapeRef(customField.MapOf)
-				}
-				if memberShapeRef == nil {
+				memberShapeRef, found = m.customFieldShapeRef(customField)
+				if !found {
 					// This is a compile-time failure, just bomb out...
 					msg := fmt.Sprintf(
 						"unknown additional Status field with custom field %+v",
@@ -272,10 +375,36 @@ func (m *Model) GetCRDs() ([]*CRD, error) {
 				continue
 			}
 
+			log.V(2).Info(
+				"added Status field from generator config",
+				"resource", crdName, "field", targetFieldName,
+			)
 			memberNames := names.New(targetFieldName)
 			crd.AddStatusField(memberNames, memberShapeRef)
 		}
 
+		// And the Status field mirroring the number of replicas of the
+		// scale subresource
+		if err := crd.addScaleFields(); err != nil {
+			return nil, err
+		}
+
+		// And the printer columns showing arbitrary JSONPaths
+		crd.addCustomPrintableColumns()
+
+		// The pruned Status fields must be Status fields of the resource
+		if err := crd.checkPrunedStatusFields(); err != nil {
+			return nil, err
+		}
+
+		if splits := m.cfg.ResourceSplits(crdName); len(splits) > 0 {
+			splitCRDs, err := m.splitCRD(crd, splits)
+			if err != nil {
+				return nil, err
+			}
+			crds = append(crds, splitCRDs...)
+			continue
+		}
 		crds = append(crds, crd)
 	}
 	sort.Slice(crds, func(i, j int) bool {
@@ -285,6 +414,12 @@ func (m *Model) GetCRDs() ([]*CRD, error) {
 	// `pkg/model.Field` objects that represent the non-top-level Spec and
 	// Status fields.
 	m.processNestedFields(crds)
+	if err := m.setAPIGroups(crds); err != nil {
+		return nil, err
+	}
+	if err := m.linkDeletionDependents(crds); err != nil {
+		return nil, err
+	}
 	m.crds = crds
 	return crds, nil
 }
@@ -293,26 +428,22 @@ func (m *Model) GetCRDs() ([]*CRD, error) {
 // operations to nil that are configured to be ignored in generator config for
 // the AWS service
 func (m *Model) RemoveIgnoredOperations(ops *Ops) {
-	if m.cfg.IsIgnoredOperation(ops.Create) {
-		ops.Create = nil
-	}
-	if m.cfg.IsIgnoredOperation(ops.ReadOne) {
-		ops.ReadOne = nil
-	}
-	if m.cfg.IsIgnoredOperation(ops.ReadMany) {
-		ops.ReadMany = nil
-	}
-	if m.cfg.IsIgnoredOperation(ops.Update) {
-		ops.Update = nil
-	}
-	if m.cfg.IsIgnoredOperation(ops.Delete) {
-		ops.Delete = nil
-	}
-	if m.cfg.IsIgnoredOperation(ops.GetAttributes) {
-		ops.GetAttributes = nil
-	}
-	if m.cfg.IsIgnoredOperation(ops.SetAttributes) {
-		ops.SetAttributes = nil
+	for _, op := range []**awssdkmodel.Operation{
+		&ops.Create,
+		&ops.ReadOne,
+		&ops.ReadMany,
+		&ops.Update,
+		&ops.Delete,
+		&ops.GetAttributes,
+		&ops.SetAttributes,
+	} {
+		if *op == nil {
+			continue
+		}
+		if m.cfg.IsIgnoredOperation(*op) {
+			log.V(1).Info("ignoring operation", "operation", (*op).Name)
+			*op = nil
+		}
 	}
 }
 
@@ -328,6 +459,20 @@ func (m *Model) IsShapeUsedInCRDs(shapeName string) bool {
 	return false
 }
 
+// isShapeUsedInStatus returns true if the supplied shape name is the shape of
+// any CRD's Status field or one of those fields' sub-member shapes
+func (m *Model) isShapeUsedInStatus(shapeName string) bool {
+	crds, _ := m.GetCRDs()
+	for _, crd := range crds {
+		for _, field := range crd.StatusFields {
+			if field.ShapeRef != nil && shapeHasMember(field.ShapeRef.Shape, shapeName) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
 // GetTypeDefs returns a slice of `TypeDef` pointers
 func (m *Model) GetTypeDefs() ([]*TypeDef, error) {
 	if m.typeDefs != nil {
@@ -339,45 +484,80 @@ func (m *Model) GetTypeDefs() ([]*TypeDef, error) {
 	// renamed type name (due to conflicting names)
 	trenames := map[string]string{}
 
-	payloads := m.SDKAPI.GetPayloads()
+	// The type definitions of the shapes several versions of the service API
+	// contain are those of the API model loaded for the service
+	seen := map[string]bool{}
+	for _, sdkAPI := range m.SDKAPI.sdkAPIs() {
+		payloads := sdkAPI.GetPayloads()
 
-	for shapeName, shape := range m.SDKAPI.API.Shapes {
-		if util.InStrings(shapeName, payloads) && !m.IsShapeUsedInCRDs(shapeName) {
-			// Payloads are not type defs, unless explicitly used
-			continue
-		}
-		if shape.Type != "structure" {
-			continue
-		}
-		if shape.Exception {
-			// Neither are exceptions
-			continue
-		}
-		tdefNames := names.New(shapeName)
-		if m.SDKAPI.HasConflictingTypeName(shapeName, m.cfg) {
-			tdefNames.Camel += ConflictingNameSuffix
-			trenames[shapeName] = tdefNames.Camel
-		}
+		for shapeName, shape := range sdkAPI.API.Shapes {
+			if seen[shapeName] {
+				continue
+			}
+			seen[shapeName] = true
+			if util.InStrings(shapeName, payloads) && !m.IsShapeUsedInCRDs(shapeName) {
+				// Payloads are not type defs, unless explicitly used
+				continue
+			}
+			if shape.Type != "structure" {
+				continue
+			}
+			if shape.Exception {
+				// Neither are exceptions
+				continue
+			}
+			tdefNames := names.New(shapeName)
+			if sdkAPI.HasConflictingTypeName(shapeName, m.cfg) {
+				tdefNames.Camel += ConflictingNameSuffix
+				trenames[shapeName] = tdefNames.Camel
+			}
 
-		attrs := map[string]*Attr{}
-		for memberName, memberRef := range shape.MemberRefs {
-			memberNames := names.New(memberName)
-			memberShape := memberRef.Shape
-			if !m.IsShapeUsedInCRDs(memberShape.ShapeName) {
+			// Values returned by the AWS API don't always honor the constraints
+			// of the API model, so we only validate types that can't end up in a
+			// resource's Status
+			validated := !m.isShapeUsedInStatus(shapeName)
+			attrs := map[string]*Attr{}
+			for memberName, memberRef := range shape.MemberRefs {
+				memberNames := names.New(memberName)
+				memberShape := memberRef.Shape
+				if !m.IsShapeUsedInCRDs(memberShape.ShapeName) {
+					continue
+				}
+				gt := m.getShapeCleanGoType(memberShape)
+				attr := NewAttr(memberNames, gt, memberShape)
+				attr.Documentation = sanitizeDocumentation(attr.Documentation, m.cfg)
+				if apiReferenceLinksEnabled(m.cfg) {
+					attr.Documentation = appendAPIReferenceLink(
+						attr.Documentation, sdkAPI.APIReferenceURL(shape.ShapeName),
+					)
+				}
+				if sdkAPI.IsRecursiveMember(shape, memberName) {
+					attr.ValidationMarkers = recursiveMemberMarkers
+				} else if validated {
+					attr.ValidationMarkers = sdkAPI.ValidationMarkers(memberShape)
+				} else if sdkAPI.IsDocument(memberShape) {
+					// Documents returned by the AWS API must not be pruned
+					attr.ValidationMarkers = []string{preserveUnknownFieldsMarker}
+				}
+				attrs[memberName] = attr
+			}
+			if len(attrs) == 0 {
+				// Just ignore these...
 				continue
 			}
-			gt := m.getShapeCleanGoType(memberShape)
-			attrs[memberName] = NewAttr(memberNames, gt, memberShape)
-		}
-		if len(attrs) == 0 {
-			// Just ignore these...
-			continue
+			tdefs = append(tdefs, &TypeDef{
+				Shape: shape,
+				Names: tdefNames,
+				Attrs: attrs,
+			})
 		}
-		tdefs = append(tdefs, &TypeDef{
-			Shape: shape,
-			Names: tdefNames,
-			Attrs: attrs,
-		})
+	}
+	tagTDef, err := m.tagTypeDef(tdefs)
+	if err != nil {
+		return nil, err
+	}
+	if tagTDef != nil {
+		tdefs = append(tdefs, tagTDef)
 	}
 	sort.Slice(tdefs, func(i, j int) bool {
 		return tdefs[i].Names.Camel < tdefs[j].Names.Camel
@@ -400,10 +580,12 @@ func (m *Model) getShapeCleanGoType(shape *awssdkmodel.Shape) string {
 		// Camel-cased name
 		return "[]" + m.getShapeCleanGoType(shape.MemberRef.Shape)
 	case "timestamp":
-		// time.Time needs to be converted to apimachinery/metav1.Time
-		// otherwise there is no DeepCopy support
-		return "*metav1.Time"
+		// Fields of structs can't override the API's timestamp format
+		return "*" + timestampGoType(m.cfg.GetTimestampFormat())
 	case "structure":
+		if m.SDKAPI.IsDocument(shape) {
+			return RawExtensionGoType
+		}
 		// There are shapes that are called things like DBProxyStatus that are
 		// fields in a DBProxy CRD... we need to ensure the type names don't
 		// conflict. Also, the name of the Go type in the generated code is
@@ -414,6 +596,11 @@ func (m *Model) getShapeCleanGoType(shape *awssdkmodel.Shape) string {
 			typeNames.Camel += ConflictingNameSuffix
 		}
 		return "*" + typeNames.Camel
+	case "string":
+		if IsTypedEnum(m.cfg, shape) {
+			return "*" + m.SDKAPI.GetEnumTypeName(shape.ShapeName, m.cfg)
+		}
+		return shape.GoType()
 	default:
 		return shape.GoType()
 	}
@@ -421,7 +608,7 @@ func (m *Model) getShapeCleanGoType(shape *awssdkmodel.Shape) string {
 
 // processNestedFieldTypeDefs updates the supplied TypeDef structs' if a nested
 // field has been configured with a type overriding FieldConfig -- such as
-// FieldConfig.IsSecret.
+// FieldConfig.IsSecret -- or a FieldConfig.Documentation override.
 func (m *Model) processNestedFieldTypeDefs(
 	tdefs []*TypeDef,
 ) {
@@ -450,17 +637,44 @@ func (m *Model) processNestedFieldTypeDefs(
 				// struct)
 				replaceSecretAttrGoType(crd, field, tdefs)
 			}
+			if field.FieldConfig.Documentation != nil {
+				attr := nestedFieldAttr(crd, field, tdefs)
+				attr.Documentation = applyDocumentationConfig(
+					attr.Documentation, field.FieldConfig.Documentation,
+				)
+			}
 		}
 	}
 }
 
 // replaceSecretAttrGoType replaces a nested field Attr's GoType with
-// `*ackv1alpha1.SecretKeyReference`.
+// `*ackv1alpha1.SecretKeyReference`, or a list or map of them for list and
+// map fields.
 func replaceSecretAttrGoType(
 	crd *CRD,
 	field *Field,
 	tdefs []*TypeDef,
 ) {
+	attr := nestedFieldAttr(crd, field, tdefs)
+	attr.GoType = "*ackv1alpha1.SecretKeyReference"
+	if field.ShapeRef != nil {
+		switch field.ShapeRef.Shape.Type {
+		case "list":
+			attr.GoType = "[]" + attr.GoType
+		case "map":
+			attr.GoType = "map[string]" + attr.GoType
+		}
+	}
+	attr.ValidationMarkers = nil
+}
+
+// nestedFieldAttr returns the Attr of the TypeDef of a nested field's parent
+// field that corresponds to the nested field
+func nestedFieldAttr(
+	crd *CRD,
+	field *Field,
+	tdefs []*TypeDef,
+) *Attr {
 	fieldPath := field.Path
 	parentFieldPath := ParentFieldPath(field.Path)
 	parentField, ok := crd.Fields[parentFieldPath]
@@ -519,8 +733,6 @@ func replaceSecretAttrGoType(
 		)
 		panic(msg)
 	}
-	// Now we modify the parent type def's Attr that corresponds to
-	// the secret field...
 	attr, found := parentTypeDef.Attrs[field.Names.Camel]
 	if !found {
 		msg := fmt.Sprintf(
@@ -532,7 +744,7 @@ func replaceSecretAttrGoType(
 		)
 		panic(msg)
 	}
-	attr.GoType = "*ackv1alpha1.SecretKeyReference"
+	return attr
 }
 
 // processNestedFields is responsible for walking all of the CRDs' Spec and
@@ -557,11 +769,11 @@ func (m *Model) processNestedField(
 	crd *CRD,
 	field *Field,
 ) {
-	if field.ShapeRef == nil && (field.FieldConfig == nil || !field.FieldConfig.IsAttribute) {
-		fmt.Printf(
-			"WARNING: Field %s:%s has nil ShapeRef and is not defined as an Attribute-based Field!\n",
-			crd.Names.Original,
-			field.Names.Original,
+	if field.ShapeRef == nil && !field.HasCustomGoType() &&
+		(field.FieldConfig == nil || !field.FieldConfig.IsAttribute) {
+		log.Info(
+			"WARNING: field has nil ShapeRef and is not defined as an Attribute-based field",
+			"resource", crd.Names.Original, "field", field.Names.Original,
 		)
 		return
 	}
@@ -595,6 +807,11 @@ func (m *Model) processNestedStructField(
 		fieldPath := baseFieldPath + memberNames.Camel
 		fieldConfig := fieldConfigs[fieldPath]
 		field := NewField(crd, fieldPath, memberNames, memberRef, fieldConfig)
+		if m.SDKAPI.IsRecursiveMember(baseFieldShape, memberName) {
+			// The member's own members are already on the field path
+			crd.Fields[fieldPath] = field
+			continue
+		}
 		switch memberShapeType {
 		case "structure":
 			m.processNestedStructField(crd, fieldPath+".", field)
@@ -628,6 +845,11 @@ func (m *Model) processNestedListField(
 		fieldPath := baseFieldPath + memberNames.Camel
 		fieldConfig := fieldConfigs[fieldPath]
 		field := NewField(crd, fieldPath, memberNames, memberRef, fieldConfig)
+		if m.SDKAPI.IsRecursiveMember(elementFieldShape, memberName) {
+			// The member's own members are already on the field path
+			crd.Fields[fieldPath] = field
+			continue
+		}
 		switch memberShapeType {
 		case "structure":
 			m.processNestedStructField(crd, fieldPath+".", field)
@@ -661,6 +883,11 @@ func (m *Model) processNestedMapField(
 		fieldPath := baseFieldPath + memberNames.Camel
 		fieldConfig := fieldConfigs[fieldPath]
 		field := NewField(crd, fieldPath, memberNames, memberRef, fieldConfig)
+		if m.SDKAPI.IsRecursiveMember(valueFieldShape, memberName) {
+			// The member's own members are already on the field path
+			crd.Fields[fieldPath] = field
+			continue
+		}
 		switch memberShapeType {
 		case "structure":
 			m.processNestedStructField(crd, fieldPath+".", field)
@@ -679,21 +906,21 @@ func (m *Model) processNestedMapField(
 func (m *Model) GetEnumDefs() ([]*EnumDef, error) {
 	edefs := []*EnumDef{}
 
-	for shapeName, shape := range m.SDKAPI.API.Shapes {
-		if !shape.IsEnum() {
-			continue
-		}
-		enumNames := names.New(shapeName)
-		// Handle name conflicts with top-level CRD.Spec or CRD.Status
-		// types
-		if m.SDKAPI.HasConflictingTypeName(shapeName, m.cfg) {
-			enumNames.Camel += ConflictingNameSuffix
-		}
-		edef, err := NewEnumDef(enumNames, shape.Enum)
-		if err != nil {
-			return nil, err
+	seen := map[string]bool{}
+	for _, sdkAPI := range m.SDKAPI.sdkAPIs() {
+		for shapeName, shape := range sdkAPI.API.Shapes {
+			if !shape.IsEnum() || seen[shapeName] {
+				continue
+			}
+			seen[shapeName] = true
+			enumNames := names.New(shapeName)
+			enumNames.Camel = sdkAPI.GetEnumTypeName(shapeName, m.cfg)
+			edef, err := NewEnumDef(enumNames, shape.Enum)
+			if err != nil {
+				return nil, err
+			}
+			edefs = append(edefs, edef)
 		}
-		edefs = append(edefs, edef)
 	}
 	sort.Slice(edefs, func(i, j int) bool {
 		return edefs[i].Names.Camel < edefs[j].Names.Camel
@@ -703,34 +930,84 @@ func (m *Model) GetEnumDefs() ([]*EnumDef, error) {
 
 // ApplyShapeIgnoreRules removes the ignored shapes and fields from the API object
 // so that they are not considered in any of the calculations of code generator.
+// Both the ignored shape names and the segments of the ignored field paths may
+// contain wildcards. Because shapes are shared, ignoring a field of a shape
+// ignores it wherever the shape is used.
 func (m *Model) ApplyShapeIgnoreRules() {
 	if m.cfg == nil || m.SDKAPI == nil {
 		return
 	}
-	for sdkShapeID, shape := range m.SDKAPI.API.Shapes {
+	for _, sdkAPI := range m.SDKAPI.sdkAPIs() {
+		m.applyShapeIgnoreRules(sdkAPI)
+	}
+}
+
+// applyShapeIgnoreRules removes the ignored shapes and fields from the
+// supplied API model
+func (m *Model) applyShapeIgnoreRules(sdkAPI *SDKAPI) {
+	for sdkShapeID, shape := range sdkAPI.API.Shapes {
 		for _, fieldpath := range m.cfg.Ignore.FieldPaths {
-			sn := strings.Split(fieldpath, ".")[0]
-			fn := strings.Split(fieldpath, ".")[1]
-			if shape.ShapeName != sn {
+			parts := strings.Split(fieldpath, ".")
+			if len(parts) < 2 || !globMatch(parts[0], shape.ShapeName) {
 				continue
 			}
-			delete(shape.MemberRefs, fn)
+			ignoreMemberPath(shape, parts[1:])
 		}
-		for _, sn := range m.cfg.Ignore.ShapeNames {
-			if shape.ShapeName == sn {
-				delete(m.SDKAPI.API.Shapes, sdkShapeID)
-				continue
-			}
-			// NOTE(muvaf): We need to remove the usage of the shape as well.
-			for sdkMemberID, memberRef := range shape.MemberRefs {
-				if memberRef.ShapeName == sn {
-					delete(shape.MemberRefs, sdkMemberID)
-				}
+		if m.cfg.IsIgnoredShape(shape.ShapeName) {
+			log.V(1).Info("ignoring shape", "shape", shape.ShapeName)
+			delete(sdkAPI.API.Shapes, sdkShapeID)
+			continue
+		}
+		// NOTE(muvaf): We need to remove the usage of the shape as well.
+		for sdkMemberID, memberRef := range shape.MemberRefs {
+			if m.cfg.IsIgnoredShape(memberRef.ShapeName) {
+				log.V(2).Info(
+					"ignoring field referencing ignored shape",
+					"shape", shape.ShapeName, "field", sdkMemberID,
+					"ignored_shape", memberRef.ShapeName,
+				)
+				delete(shape.MemberRefs, sdkMemberID)
 			}
 		}
 	}
 }
 
+// ignoreMemberPath removes the members of the supplied shape found at the
+// supplied path. Each element of the path is the name of a member, or a
+// wildcard pattern matching the names of several members, of a structure.
+// List and map shapes are traversed transparently to their elements.
+func ignoreMemberPath(shape *awssdkmodel.Shape, path []string) {
+	if shape == nil || len(path) == 0 {
+		return
+	}
+	switch shape.Type {
+	case "list":
+		ignoreMemberPath(shape.MemberRef.Shape, path)
+		return
+	case "map":
+		ignoreMemberPath(shape.ValueRef.Shape, path)
+		return
+	}
+	for memberName, memberRef := range shape.MemberRefs {
+		if !globMatch(path[0], memberName) {
+			continue
+		}
+		if len(path) > 1 {
+			ignoreMemberPath(memberRef.Shape, path[1:])
+			continue
+		}
+		log.V(1).Info("ignoring field", "shape", shape.ShapeName, "field", memberName)
+		delete(shape.MemberRefs, memberName)
+	}
+}
+
+// globMatch returns true if the supplied name matches the supplied shell
+// pattern, e.g. "*Internal*"
+func globMatch(pattern string, name string) bool {
+	matched, err := path.Match(pattern, name)
+	return err == nil && matched
+}
+
 // GetConfig returns the configuration option used to define the current
 // generator.
 func (m *Model) GetConfig() *ackgenconfig.Config {
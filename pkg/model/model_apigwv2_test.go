@@ -175,3 +175,71 @@ func TestAPIGatewayV2_Route(t *testing.T) {
 	}
 	assert.Equal(expStatusFieldCamel, attrCamelNames(statusFields))
 }
+
+func TestAPIGatewayV2_Route_OwnerReferences(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	g := testutil.NewModelForServiceWithOptions(t, "apigatewayv2", &testutil.TestingModelOptions{
+		GeneratorConfigFile: "generator-with-owner-references.yaml",
+	})
+
+	crds, err := g.GetCRDs()
+	require.Nil(err)
+
+	// Routes are children of their API and are garbage collected along with
+	// it
+	crd := getCRDByName("Route", crds)
+	require.NotNil(crd)
+	assert.True(crd.HasOwnerReferenceFields())
+
+	refFields := crd.ReferenceFields()
+	require.Len(refFields, 1)
+	assert.Equal("APIIDRef", refFields[0].RefField.Names.Camel)
+	assert.True(refFields[0].IsOwner())
+	assert.Equal("apigatewayv2.services.k8s.aws/api", refFields[0].OwnerLabel())
+
+	// Integrations only reference their API
+	crd = getCRDByName("Integration", crds)
+	require.NotNil(crd)
+	assert.False(crd.HasOwnerReferenceFields())
+
+	refFields = crd.ReferenceFields()
+	require.Len(refFields, 1)
+	assert.False(refFields[0].IsOwner())
+}
+
+func TestAPIGatewayV2_DeleteOrder(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	g := testutil.NewModelForServiceWithOptions(t, "apigatewayv2", &testutil.TestingModelOptions{
+		GeneratorConfigFile: "generator-with-delete-order.yaml",
+	})
+
+	crds, err := g.GetCRDs()
+	require.Nil(err)
+
+	// APIs are deleted after the Routes referencing them...
+	crd := getCRDByName("Api", crds)
+	require.NotNil(crd)
+	assert.True(crd.HasDeletionOrder())
+	assert.Empty(crd.DeletionPrerequisites())
+	dependents := crd.DeletionDependents()
+	require.Len(dependents, 1)
+	assert.Equal("Route", dependents[0].Field.CRD.Names.Camel)
+	assert.Equal("APIIDRef", dependents[0].RefField.Names.Camel)
+
+	crd = getCRDByName("Route", crds)
+	require.NotNil(crd)
+	assert.False(crd.HasDeletionOrder())
+
+	// ...and before the Integrations referencing them
+	crd = getCRDByName("Integration", crds)
+	require.NotNil(crd)
+	assert.True(crd.HasDeletionOrder())
+	assert.Empty(crd.DeletionDependents())
+	prereqs := crd.DeletionPrerequisites()
+	require.Len(prereqs, 1)
+	assert.Equal("APIIDRef", prereqs[0].RefField.Names.Camel)
+}
@@ -152,3 +152,36 @@ func TestEC2_Volume(t *testing.T) {
 	// field
 	assert.NotNil(testutil.GetTypeDefByName(t, g, "VolumeAttachment"))
 }
+
+func TestEC2_FlowLog_BatchOperations(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	g := testutil.NewModelForServiceWithOptions(t, "ec2", &testutil.TestingModelOptions{
+		GeneratorConfigFile: "generator-with-batch-operations.yaml",
+	})
+
+	crds, err := g.GetCRDs()
+	require.Nil(err)
+
+	crd := getCRDByName("FlowLog", crds)
+	require.NotNil(crd)
+
+	assert.Equal("CreateFlowLogs", crd.Ops.Create.Name)
+	assert.Equal("DeleteFlowLogs", crd.Ops.Delete.Name)
+
+	// The ResourceIds list of the CreateFlowLogs Input shape is replaced by
+	// a single ResourceID field
+	assert.Contains(crd.SpecFields, "ResourceId")
+	assert.NotContains(crd.SpecFields, "ResourceIds")
+	assert.Equal("*string", crd.SpecFields["ResourceId"].GoType)
+
+	// And the FlowLogIds list of its Output shape by a single FlowLogID
+	// field, while the errors aren't part of the resource
+	assert.Contains(crd.StatusFields, "FlowLogId")
+	assert.NotContains(crd.StatusFields, "FlowLogIds")
+	assert.NotContains(crd.StatusFields, "Unsuccessful")
+
+	assert.Equal("Unsuccessful", crd.BatchErrorsMember(crd.Ops.Create))
+	assert.Equal("Unsuccessful", crd.BatchErrorsMember(crd.Ops.Delete))
+}
@@ -16,9 +16,11 @@ package model_test
 import (
 	"testing"
 
+	awssdkmodel "github.com/aws/aws-sdk-go/private/model/api"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
+	"github.com/aws-controllers-k8s/code-generator/pkg/model"
 	"github.com/aws-controllers-k8s/code-generator/pkg/testutil"
 )
 
@@ -130,3 +132,598 @@ func TestECRRepository(t *testing.T) {
 	}
 	assert.Equal(expStatusFieldCamel, attrCamelNames(statusFields))
 }
+
+func TestECRRepository_EnumTypes(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	g := testutil.NewModelForServiceWithOptions(t, "ecr", &testutil.TestingModelOptions{
+		GeneratorConfigFile: "generator-with-enum-types.yaml",
+	})
+
+	crds, err := g.GetCRDs()
+	require.Nil(err)
+
+	crd := getCRDByName("Repository", crds)
+	require.NotNil(crd)
+
+	// Enum fields use the type generated for the enum shape
+	field := crd.SpecFields["ImageTagMutability"]
+	require.NotNil(field)
+	assert.Equal("*ImageTagMutability", field.GoType)
+	assert.Equal("ImageTagMutability", field.GoTypeElem)
+	assert.Equal("ImageTagMutability", crd.EnumTypeName(field.ShapeRef.Shape))
+
+	// Other string fields are unchanged
+	assert.Equal("*string", crd.SpecFields["RepositoryName"].GoType)
+	assert.Equal("", crd.EnumTypeName(crd.SpecFields["RepositoryName"].ShapeRef.Shape))
+}
+
+func TestECRRepository_Document(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	g := testutil.NewModelForService(t, "ecr")
+	// None of the ECR shapes are documents, so pretend one is
+	g.SDKAPI.ShapeConstraints["ImageScanningConfiguration"] = &model.ShapeConstraints{
+		Document: true,
+	}
+
+	crds, err := g.GetCRDs()
+	require.Nil(err)
+
+	crd := getCRDByName("Repository", crds)
+	require.NotNil(crd)
+
+	field := crd.SpecFields["ImageScanningConfiguration"]
+	require.NotNil(field)
+	assert.Equal("*runtime.RawExtension", field.GoType)
+	assert.Equal(
+		[]string{"+kubebuilder:pruning:PreserveUnknownFields"},
+		field.ValidationMarkers(),
+	)
+	assert.Contains(crd.TypeImports, "k8s.io/apimachinery/pkg/runtime")
+}
+
+func TestECRRepository_RecursiveShape(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	g := testutil.NewModelForService(t, "ecr")
+	// None of the ECR shapes are recursive, so make one recursive
+	shape := g.SDKAPI.API.Shapes["ImageScanningConfiguration"]
+	require.NotNil(shape)
+	shape.MemberRefs["Nested"] = &awssdkmodel.ShapeRef{
+		ShapeName: shape.ShapeName,
+		Shape:     shape,
+	}
+	assert.True(g.SDKAPI.IsRecursiveMember(shape, "Nested"))
+	assert.False(g.SDKAPI.IsRecursiveMember(shape, "ScanOnPush"))
+
+	crds, err := g.GetCRDs()
+	require.Nil(err)
+
+	crd := getCRDByName("Repository", crds)
+	require.NotNil(crd)
+
+	// Nested fields stop at the recursive member
+	assert.Contains(crd.Fields, "ImageScanningConfiguration.Nested")
+	assert.NotContains(crd.Fields, "ImageScanningConfiguration.Nested.ScanOnPush")
+
+	// The recursive member keeps its Go type, but has no schema
+	tdefs, err := g.GetTypeDefs()
+	require.Nil(err)
+	var nested *model.Attr
+	for _, tdef := range tdefs {
+		if tdef.Names.Camel == "ImageScanningConfiguration" {
+			nested = tdef.Attrs["Nested"]
+		}
+	}
+	require.NotNil(nested)
+	assert.Equal("*ImageScanningConfiguration", nested.GoType)
+	assert.Equal(
+		[]string{
+			"+kubebuilder:validation:Schemaless",
+			"+kubebuilder:pruning:PreserveUnknownFields",
+		},
+		nested.ValidationMarkers,
+	)
+}
+
+func TestECRRepository_TimestampFormat(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	g := testutil.NewModelForServiceWithOptions(t, "ecr", &testutil.TestingModelOptions{
+		GeneratorConfigFile: "generator-with-timestamp-format.yaml",
+	})
+
+	crds, err := g.GetCRDs()
+	require.Nil(err)
+
+	crd := getCRDByName("Repository", crds)
+	require.NotNil(crd)
+
+	// The field's format takes precedence over the API-wide format
+	createdAt := crd.StatusFields["CreatedAt"]
+	require.NotNil(createdAt)
+	assert.Equal("rfc3339", createdAt.TimestampFormat())
+	assert.Equal("*string", createdAt.GoType)
+	assert.Equal("epoch", crd.Config().GetTimestampFormat())
+}
+
+func TestECRRepository_TagFormat(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	g := testutil.NewModelForServiceWithOptions(t, "ecr", &testutil.TestingModelOptions{
+		GeneratorConfigFile: "generator-with-tag-format.yaml",
+	})
+
+	crds, err := g.GetCRDs()
+	require.Nil(err)
+
+	crd := getCRDByName("Repository", crds)
+	require.NotNil(crd)
+
+	// ECR models tags as a list of Tag structs
+	tags := crd.SpecFields["Tags"]
+	require.NotNil(tags)
+	assert.Equal("map", tags.TagFormat())
+	assert.Equal("map[string]*string", tags.GoType)
+	assert.Empty(tags.ValidationMarkers())
+}
+
+func TestECR_TaggingOps(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	g := testutil.NewModelForService(t, "ecr")
+
+	ops := g.SDKAPI.GetTaggingOps()
+	require.NotNil(ops)
+	assert.Equal("TagResource", ops.Tag.Name)
+	assert.Equal("ResourceArn", ops.Tag.ARNMemberName)
+	assert.Equal("Tags", ops.Tag.TagsMemberName)
+	assert.Equal("UntagResource", ops.Untag.Name)
+	assert.Equal("TagKeys", ops.Untag.TagsMemberName)
+	assert.Equal("ListTagsForResource", ops.List.Name)
+	assert.Equal("Tags", ops.List.TagsMemberName)
+}
+
+func TestECRRepository_IgnorePatterns(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	g := testutil.NewModelForServiceWithOptions(t, "ecr", &testutil.TestingModelOptions{
+		GeneratorConfigFile: "generator-with-ignore-patterns.yaml",
+	})
+
+	crds, err := g.GetCRDs()
+	require.Nil(err)
+
+	crd := getCRDByName("Repository", crds)
+	require.NotNil(crd)
+
+	// ImageScanningConfiguration matches an ignored shape name pattern
+	assert.NotContains(crd.SpecFields, "ImageScanningConfiguration")
+	assert.NotContains(g.SDKAPI.API.Shapes, "ImageScanningConfiguration")
+	assert.Contains(crd.SpecFields, "ImageTagMutability")
+
+	// The ignored field path reaches through the Tags list into the Tag
+	// shape, so Value is ignored wherever the Tag shape is used
+	require.Contains(crd.SpecFields, "Tags")
+	tagShape := g.SDKAPI.API.Shapes["Tag"]
+	require.NotNil(tagShape)
+	assert.Contains(tagShape.MemberRefs, "Key")
+	assert.NotContains(tagShape.MemberRefs, "Value")
+}
+
+func TestECRRepository_CustomFields(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	g := testutil.NewModelForServiceWithOptions(t, "ecr", &testutil.TestingModelOptions{
+		GeneratorConfigFile: "generator-with-custom-fields.yaml",
+	})
+
+	crds, err := g.GetCRDs()
+	require.Nil(err)
+
+	crd := getCRDByName("Repository", crds)
+	require.NotNil(crd)
+
+	scanning := crd.SpecFields["Scanning"]
+	require.NotNil(scanning)
+	assert.True(scanning.IsCustomField())
+	assert.False(scanning.HasCustomGoType())
+	assert.Equal("*ImageScanningConfiguration", scanning.GoType)
+	assert.Contains(crd.Fields, "Scanning.ScanOnPush")
+
+	// The shape doesn't belong to any of the Repository's operations
+	preview := crd.StatusFields["LifecyclePolicyPreview"]
+	require.NotNil(preview)
+	assert.Equal("*LifecyclePolicyPreviewSummary", preview.GoType)
+
+	mirror := crd.SpecFields["Mirror"]
+	require.NotNil(mirror)
+	assert.True(mirror.HasCustomGoType())
+	assert.Nil(mirror.ShapeRef)
+	assert.Equal("*MirrorConfig", mirror.GoType)
+	assert.Equal("MirrorConfig", mirror.GoTypeElem)
+}
+
+func TestECRRepository_UpdateOperations(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	g := testutil.NewModelForServiceWithOptions(t, "ecr", &testutil.TestingModelOptions{
+		GeneratorConfigFile: "generator-with-update-operations.yaml",
+	})
+
+	crds, err := g.GetCRDs()
+	require.Nil(err)
+
+	crd := getCRDByName("Repository", crds)
+	require.NotNil(crd)
+
+	// The update operations are kept in the configured order and refer to
+	// the Spec fields they update
+	require.Len(crd.UpdateOps, 2)
+	assert.Equal("PutImageScanningConfiguration", crd.UpdateOps[0].Name)
+	require.Len(crd.UpdateOps[0].Fields, 1)
+	assert.Equal(crd.SpecFields["ImageScanningConfiguration"], crd.UpdateOps[0].Fields[0])
+	assert.Equal("PutImageTagMutability", crd.UpdateOps[1].Name)
+	require.Len(crd.UpdateOps[1].Fields, 1)
+	assert.Equal(crd.SpecFields["ImageTagMutability"], crd.UpdateOps[1].Fields[0])
+}
+
+func TestECRRepository_ReadManyPagination(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	g := testutil.NewModelForService(t, "ecr")
+
+	crds, err := g.GetCRDs()
+	require.Nil(err)
+
+	crd := getCRDByName("Repository", crds)
+	require.NotNil(crd)
+
+	// DescribeRepositories pages its results with the nextToken members
+	pagination := crd.ReadManyPagination()
+	require.NotNil(pagination)
+	assert.Equal("NextToken", pagination.InputToken)
+	assert.Equal("NextToken", pagination.OutputToken)
+	assert.Equal("Repositories", pagination.ResultMember)
+	assert.Equal(model.DefaultReadManyMaxPages, pagination.MaxPages)
+
+	g = testutil.NewModelForServiceWithOptions(t, "ecr", &testutil.TestingModelOptions{
+		GeneratorConfigFile: "generator-with-list-match.yaml",
+	})
+
+	crds, err = g.GetCRDs()
+	require.Nil(err)
+
+	crd = getCRDByName("Repository", crds)
+	require.NotNil(crd)
+
+	pagination = crd.ReadManyPagination()
+	require.NotNil(pagination)
+	assert.Equal(25, pagination.MaxPages)
+}
+
+func TestECRLifecyclePolicy_Upsert(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	g := testutil.NewModelForServiceWithOptions(t, "ecr", &testutil.TestingModelOptions{
+		GeneratorConfigFile: "generator-with-upsert.yaml",
+	})
+
+	crds, err := g.GetCRDs()
+	require.Nil(err)
+
+	crd := getCRDByName("LifecyclePolicy", crds)
+	require.NotNil(crd)
+
+	// PutLifecyclePolicy creates the policy or replaces the existing one,
+	// and GetLifecyclePolicy tells whether it exists
+	assert.True(crd.IsUpsert())
+	require.NotNil(crd.Ops.Create)
+	assert.Equal("PutLifecyclePolicy", crd.Ops.Create.Name)
+	require.NotNil(crd.Ops.ReadOne)
+	assert.Equal("GetLifecyclePolicy", crd.Ops.ReadOne.Name)
+
+	crd = getCRDByName("Repository", crds)
+	require.NotNil(crd)
+	assert.False(crd.IsUpsert())
+}
+
+func TestECRRepository_RecreateFields(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	g := testutil.NewModelForServiceWithOptions(t, "ecr", &testutil.TestingModelOptions{
+		GeneratorConfigFile: "generator-with-recreate-fields.yaml",
+	})
+
+	crds, err := g.GetCRDs()
+	require.Nil(err)
+
+	crd := getCRDByName("Repository", crds)
+	require.NotNil(crd)
+
+	assert.Equal(
+		[]string{"EncryptionConfiguration", "ImageTagMutability"},
+		crd.GetRecreateFieldPaths(),
+	)
+	assert.Equal([]string{"RepositoryName"}, crd.GetImmutableFieldPaths())
+	assert.True(crd.HasImmutableFieldChanges())
+	// The fields replacing the resource don't get a rule rejecting changes
+	assert.Equal(
+		[]string{"+kubebuilder:validation:Enum=MUTABLE;IMMUTABLE"},
+		crd.SpecFields["ImageTagMutability"].ValidationMarkers(),
+	)
+}
+
+func TestECRRepository_ObserveOnly(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	g := testutil.NewModelForService(t, "ecr")
+
+	crds, err := g.GetCRDs()
+	require.Nil(err)
+
+	crd := getCRDByName("Repository", crds)
+	require.NotNil(crd)
+	assert.False(crd.IsObserveOnly())
+
+	g = testutil.NewModelForServiceWithOptions(t, "ecr", &testutil.TestingModelOptions{
+		GeneratorConfigFile: "generator-with-observe-only.yaml",
+	})
+
+	crds, err = g.GetCRDs()
+	require.Nil(err)
+
+	crd = getCRDByName("Repository", crds)
+	require.NotNil(crd)
+	assert.True(crd.IsObserveOnly())
+	// The Spec is still built from the Create operation's Input shape
+	assert.Contains(crd.SpecFields, "RepositoryName")
+}
+
+func TestECRRepository_ClusterScope(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	g := testutil.NewModelForService(t, "ecr")
+
+	crds, err := g.GetCRDs()
+	require.Nil(err)
+
+	crd := getCRDByName("Repository", crds)
+	require.NotNil(crd)
+	assert.False(crd.IsClusterScoped())
+	assert.Equal([]string{"repositories"}, g.MetaVars().NamespacedCRDNames)
+	assert.Empty(g.MetaVars().ClusterScopedCRDNames)
+
+	g = testutil.NewModelForServiceWithOptions(t, "ecr", &testutil.TestingModelOptions{
+		GeneratorConfigFile: "generator-with-cluster-scope.yaml",
+	})
+
+	crds, err = g.GetCRDs()
+	require.Nil(err)
+
+	crd = getCRDByName("Repository", crds)
+	require.NotNil(crd)
+	assert.True(crd.IsClusterScoped())
+	assert.Empty(g.MetaVars().NamespacedCRDNames)
+	assert.Equal([]string{"repositories"}, g.MetaVars().ClusterScopedCRDNames)
+	// All the CRDs are still listed in the kustomization
+	assert.Equal([]string{"repositories"}, g.MetaVars().CRDNames)
+}
+
+func TestECRRepository_ResourceMarkerArgs(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	g := testutil.NewModelForService(t, "ecr")
+
+	crds, err := g.GetCRDs()
+	require.Nil(err)
+
+	crd := getCRDByName("Repository", crds)
+	require.NotNil(crd)
+	assert.Equal("", crd.ResourceMarkerArgs())
+
+	g = testutil.NewModelForServiceWithOptions(t, "ecr", &testutil.TestingModelOptions{
+		GeneratorConfigFile: "generator-with-categories.yaml",
+	})
+
+	crds, err = g.GetCRDs()
+	require.Nil(err)
+
+	crd = getCRDByName("Repository", crds)
+	require.NotNil(crd)
+	assert.Equal([]string{"ack", "containers"}, crd.Categories)
+	assert.Equal("shortName=repo,categories=ack;containers", crd.ResourceMarkerArgs())
+
+	g = testutil.NewModelForServiceWithOptions(t, "ecr", &testutil.TestingModelOptions{
+		GeneratorConfigFile: "generator-with-cluster-scope.yaml",
+	})
+
+	crds, err = g.GetCRDs()
+	require.Nil(err)
+
+	crd = getCRDByName("Repository", crds)
+	require.NotNil(crd)
+	assert.Equal("scope=Cluster", crd.ResourceMarkerArgs())
+}
+
+func TestECRRepository_MetadataMarkerArgs(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	g := testutil.NewModelForService(t, "ecr")
+
+	crds, err := g.GetCRDs()
+	require.Nil(err)
+
+	crd := getCRDByName("Repository", crds)
+	require.NotNil(crd)
+	assert.Equal("", crd.MetadataMarkerArgs())
+
+	g = testutil.NewModelForServiceWithOptions(t, "ecr", &testutil.TestingModelOptions{
+		GeneratorConfigFile: "generator-with-crd-metadata.yaml",
+	})
+
+	crds, err = g.GetCRDs()
+	require.Nil(err)
+
+	crd = getCRDByName("Repository", crds)
+	require.NotNil(crd)
+	// The labels of the resource override those of all the CRDs
+	assert.Equal(
+		`annotations={"operators.operatorframework.io/internal-objects=false"},`+
+			`labels={"app.kubernetes.io/part-of=ack","team=registry"}`,
+		crd.MetadataMarkerArgs(),
+	)
+}
+
+func TestECRRepository_APIGroup(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	g := testutil.NewModelForService(t, "ecr")
+
+	crds, err := g.GetCRDs()
+	require.Nil(err)
+
+	crd := getCRDByName("Repository", crds)
+	require.NotNil(crd)
+	assert.Equal("ecr.services.k8s.aws", crd.APIGroup())
+	assert.Empty(g.AdditionalAPIGroups())
+	assert.Equal("apis/v1alpha1", g.MetaVars().APIPackagePath)
+
+	g = testutil.NewModelForServiceWithOptions(t, "ecr", &testutil.TestingModelOptions{
+		GeneratorConfigFile: "generator-with-api-group.yaml",
+	})
+
+	crds, err = g.GetCRDs()
+	require.Nil(err)
+
+	crd = getCRDByName("Repository", crds)
+	require.NotNil(crd)
+	// A dotless API group is a subgroup of the API group of the service
+	assert.Equal("registry.ecr.services.k8s.aws", crd.APIGroup())
+	assert.Equal(
+		[]string{"registry.ecr.services.k8s.aws"}, g.AdditionalAPIGroups(),
+	)
+
+	crdMetaVars := g.CRDMetaVars(crd)
+	assert.Equal("registry.ecr.services.k8s.aws", crdMetaVars.APIGroup)
+	assert.Equal("apis/v1alpha1/registry", crdMetaVars.APIPackagePath)
+
+	metaVars := g.MetaVars()
+	assert.Equal("ecr.services.k8s.aws", metaVars.APIGroup)
+	assert.Equal("apis/v1alpha1", metaVars.APIPackagePath)
+	assert.Equal(
+		[]string{"registry.ecr.services.k8s.aws_repositories.yaml"},
+		metaVars.CRDBases,
+	)
+	require.Len(metaVars.AdditionalAPIGroups, 1)
+	assert.Equal("registrytypes", metaVars.AdditionalAPIGroups[0].PackageAlias)
+	assert.Equal(
+		"apis/v1alpha1/registry", metaVars.AdditionalAPIGroups[0].PackagePath,
+	)
+}
+
+func TestECRRepository_SDKAPIVersion(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	g := testutil.NewModelForService(t, "ecr")
+
+	crds, err := g.GetCRDs()
+	require.Nil(err)
+
+	crd := getCRDByName("Repository", crds)
+	require.NotNil(crd)
+	assert.NotContains(crd.SpecFields, "EncryptionConfiguration")
+
+	g = testutil.NewModelForServiceWithOptions(t, "ecr", &testutil.TestingModelOptions{
+		GeneratorConfigFile: "generator-with-sdk-api-version.yaml",
+	})
+
+	crds, err = g.GetCRDs()
+	require.Nil(err)
+
+	// The Repository is generated from the 0000-00-01 version of the API,
+	// which adds the encryption configuration
+	crd = getCRDByName("Repository", crds)
+	require.NotNil(crd)
+	assert.Contains(crd.SpecFields, "EncryptionConfiguration")
+	assert.Equal("CreateRepository", crd.Ops.Create.Name)
+
+	tdefs, err := g.GetTypeDefs()
+	require.Nil(err)
+	tdefNames := []string{}
+	for _, tdef := range tdefs {
+		tdefNames = append(tdefNames, tdef.Names.Camel)
+	}
+	assert.Contains(tdefNames, "EncryptionConfiguration")
+}
+
+func TestECR_IAMPolicy(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	g := testutil.NewModelForService(t, "ecr")
+	assert.Equal("ecr", g.IAMActionPrefix())
+
+	policy, err := g.IAMPolicy()
+	require.Nil(err)
+	assert.Equal("2012-10-17", policy.Version)
+
+	var statement *model.IAMPolicyStatement
+	for _, s := range policy.Statement {
+		if s.Sid == "Repository" {
+			statement = s
+		}
+	}
+	require.NotNil(statement)
+	assert.Equal("Allow", statement.Effect)
+	assert.Equal([]string{"*"}, statement.Resource)
+	assert.Equal(
+		[]string{
+			"ecr:CreateRepository",
+			"ecr:DeleteRepository",
+			"ecr:DescribeRepositories",
+		},
+		statement.Action,
+	)
+
+	crds, err := g.GetCRDs()
+	require.Nil(err)
+	crd := getCRDByName("Repository", crds)
+	require.NotNil(crd)
+	actions := crd.IAMActions()
+	assert.Equal([]string{"ecr:CreateRepository"}, actions.Create)
+	assert.Equal([]string{"ecr:DescribeRepositories"}, actions.Read)
+	assert.Equal([]string{"ecr:DeleteRepository"}, actions.Delete)
+	assert.Empty(actions.Tag)
+	assert.Equal(statement.Action, actions.All())
+
+	// Only the read operations are granted for the observed resources
+	g = testutil.NewModelForServiceWithOptions(t, "ecr", &testutil.TestingModelOptions{
+		GeneratorConfigFile: "generator-with-observe-only.yaml",
+	})
+	crds, err = g.GetCRDs()
+	require.Nil(err)
+	crd = getCRDByName("Repository", crds)
+	require.NotNil(crd)
+	assert.Equal([]string{"DescribeRepositories"}, crd.SDKOperationNames())
+	assert.Empty(crd.IAMActions().Create)
+}
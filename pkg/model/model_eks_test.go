@@ -0,0 +1,45 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//	 http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package model_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/aws-controllers-k8s/code-generator/pkg/testutil"
+)
+
+func TestEKS_FargateProfile_CompositePrimaryKey(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	g := testutil.NewModelForService(t, "eks")
+
+	crds, err := g.GetCRDs()
+	require.Nil(err)
+
+	crd := getCRDByName("FargateProfile", crds)
+	require.NotNil(crd)
+
+	// DescribeFargateProfile and DeleteFargateProfile both require the
+	// cluster name along with the (renamed) profile name
+	assert.True(crd.HasCompositePrimaryKey())
+	keyFieldNames := []string{}
+	for _, field := range crd.PrimaryKeyFields() {
+		keyFieldNames = append(keyFieldNames, field.Names.Camel)
+	}
+	assert.Equal([]string{"ClusterName", "Name"}, keyFieldNames)
+}
@@ -19,6 +19,7 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
+	"github.com/aws-controllers-k8s/code-generator/pkg/model"
 	"github.com/aws-controllers-k8s/code-generator/pkg/testutil"
 )
 
@@ -284,3 +285,99 @@ func TestElasticache_ValidateAuthTokenIsSecret(t *testing.T) {
 	assert.Equal("SecretKeyReference", crd.SpecFields["Passwords"].GoTypeElem)
 	assert.Equal("[]*ackv1alpha1.SecretKeyReference", crd.SpecFields["Passwords"].GoTypeWithPkgName)
 }
+
+func TestElasticache_ReplicationGroup_Scale(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	g := testutil.NewModelForService(t, "elasticache")
+
+	crd := testutil.GetCRDByName(t, g, "ReplicationGroup")
+	require.NotNil(crd)
+	assert.Nil(crd.Scale())
+	assert.Equal("", crd.ScaleMarkerArgs())
+	assert.NotContains(crd.StatusFields, model.ScaleReplicasFieldName)
+
+	g = testutil.NewModelForServiceWithOptions(t, "elasticache", &testutil.TestingModelOptions{
+		GeneratorConfigFile: "generator-with-scale.yaml",
+	})
+
+	crd = testutil.GetCRDByName(t, g, "ReplicationGroup")
+	require.NotNil(crd)
+	require.Contains(crd.StatusFields, model.ScaleReplicasFieldName)
+	assert.Equal("*int64", crd.StatusFields[model.ScaleReplicasFieldName].GoType)
+	assert.True(crd.MirrorsScaleReplicas())
+	assert.Equal(
+		"specpath=.spec.numCacheClusters,statuspath=.status.replicas",
+		crd.ScaleMarkerArgs(),
+	)
+}
+
+func TestElasticache_ReplicationGroup_PrunedStatus(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	g := testutil.NewModelForService(t, "elasticache")
+
+	crd := testutil.GetCRDByName(t, g, "ReplicationGroup")
+	require.NotNil(crd)
+	assert.True(crd.HasStatusSubresource())
+	assert.False(crd.IsPrunedStatusField("NodeGroups"))
+
+	g = testutil.NewModelForServiceWithOptions(t, "elasticache", &testutil.TestingModelOptions{
+		GeneratorConfigFile: "generator-with-pruned-status.yaml",
+	})
+
+	crd = testutil.GetCRDByName(t, g, "ReplicationGroup")
+	require.NotNil(crd)
+	assert.False(crd.HasStatusSubresource())
+	// The pruned fields remain in the Go types
+	assert.Contains(crd.StatusFields, "MemberClusters")
+	assert.Contains(crd.StatusFields, "NodeGroups")
+	assert.True(crd.IsPrunedStatusField("MemberClusters"))
+	assert.True(crd.IsPrunedStatusField("NodeGroups"))
+	assert.False(crd.IsPrunedStatusField("Status"))
+}
+
+func TestElasticache_PrinterColumns(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	columnNames := func(columns []*model.PrinterColumn) []string {
+		names := []string{}
+		for _, column := range columns {
+			names = append(names, column.Name)
+		}
+		return names
+	}
+
+	g := testutil.NewModelForService(t, "elasticache")
+
+	crd := testutil.GetCRDByName(t, g, "ReplicationGroup")
+	require.NotNil(crd)
+	assert.Empty(crd.AdditionalPrinterColumns())
+	defaults := crd.DefaultPrinterColumns()
+	assert.Equal([]string{"State", "Synced"}, columnNames(defaults))
+	assert.Equal(".status.status", defaults[0].JSONPath)
+	assert.True(crd.PrintAgeColumn())
+
+	g = testutil.NewModelForServiceWithOptions(t, "elasticache", &testutil.TestingModelOptions{
+		GeneratorConfigFile: "generator-with-print-columns.yaml",
+	})
+
+	crd = testutil.GetCRDByName(t, g, "ReplicationGroup")
+	require.NotNil(crd)
+	columns := crd.AdditionalPrinterColumns()
+	assert.Equal([]string{"Primary", "Synced"}, columnNames(columns))
+	assert.Equal(".status.nodeGroups[0].primaryEndpoint.address", columns[0].JSONPath)
+	assert.Equal("string", columns[0].Type)
+	assert.Equal(1, columns[0].Priority)
+	// The Synced column overrides the default one
+	assert.Equal([]string{"State"}, columnNames(crd.DefaultPrinterColumns()))
+	assert.True(crd.PrintAgeColumn())
+
+	crd = testutil.GetCRDByName(t, g, "CacheSubnetGroup")
+	require.NotNil(crd)
+	assert.Empty(crd.DefaultPrinterColumns())
+	assert.False(crd.PrintAgeColumn())
+}
@@ -19,6 +19,7 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
+	"github.com/aws-controllers-k8s/code-generator/pkg/model"
 	"github.com/aws-controllers-k8s/code-generator/pkg/testutil"
 )
 
@@ -103,3 +104,177 @@ func TestLambda_Function(t *testing.T) {
 	}
 	assert.Equal(expStatusFieldCamel, attrCamelNames(statusFields))
 }
+
+func TestLambda_Function_NumberFormat(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	g := testutil.NewModelForServiceWithOptions(t, "lambda", &testutil.TestingModelOptions{
+		GeneratorConfigFile: "generator-with-number-format.yaml",
+	})
+
+	crds, err := g.GetCRDs()
+	require.Nil(err)
+
+	crd := getCRDByName("Function", crds)
+	require.NotNil(crd)
+
+	memorySize := crd.SpecFields["MemorySize"]
+	require.NotNil(memorySize)
+	assert.Equal("int32", memorySize.NumberFormat())
+	assert.Equal("*int32", memorySize.GoType)
+
+	// The quantity format doesn't apply to integers, so it is ignored
+	timeout := crd.SpecFields["Timeout"]
+	require.NotNil(timeout)
+	assert.Equal("", timeout.NumberFormat())
+	assert.Equal("*int64", timeout.GoType)
+	assert.NotContains(crd.TypeImports, "k8s.io/apimachinery/pkg/api/resource")
+}
+
+func TestLambda_Function_TagFormat(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	g := testutil.NewModelForServiceWithOptions(t, "lambda", &testutil.TestingModelOptions{
+		GeneratorConfigFile: "generator-with-tag-format.yaml",
+	})
+
+	crds, err := g.GetCRDs()
+	require.Nil(err)
+
+	crd := getCRDByName("Function", crds)
+	require.NotNil(crd)
+
+	// Lambda models tags as a map
+	tags := crd.SpecFields["Tags"]
+	require.NotNil(tags)
+	assert.Equal("list", tags.TagFormat())
+	assert.Equal("[]*Tag", tags.GoType)
+
+	// Lambda has no Tag structure, so one is defined
+	tdefs, err := g.GetTypeDefs()
+	require.Nil(err)
+	var tagTDef *model.TypeDef
+	for _, tdef := range tdefs {
+		if tdef.Names.Camel == "Tag" {
+			tagTDef = tdef
+		}
+	}
+	require.NotNil(tagTDef)
+	assert.Equal("*string", tagTDef.Attrs["Key"].GoType)
+	assert.Equal("*string", tagTDef.Attrs["Value"].GoType)
+}
+
+func TestLambda_ResourceOperations(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	g := testutil.NewModelForServiceWithOptions(t, "lambda", &testutil.TestingModelOptions{
+		GeneratorConfigFile: "generator-with-resource-operations.yaml",
+	})
+
+	crds, err := g.GetCRDs()
+	require.Nil(err)
+
+	// PutProvisionedConcurrencyConfig isn't recognized as a Create operation
+	// from its name, so the resource only exists because it is pinned. The
+	// other operations are still inferred.
+	crd := getCRDByName("ProvisionedConcurrencyConfig", crds)
+	require.NotNil(crd)
+	require.NotNil(crd.Ops.Create)
+	assert.Equal("PutProvisionedConcurrencyConfig", crd.Ops.Create.Name)
+	require.NotNil(crd.Ops.ReadOne)
+	assert.Equal("GetProvisionedConcurrencyConfig", crd.Ops.ReadOne.Name)
+	require.NotNil(crd.Ops.Delete)
+	assert.Equal("DeleteProvisionedConcurrencyConfig", crd.Ops.Delete.Name)
+
+	crd = getCRDByName("Function", crds)
+	require.NotNil(crd)
+	require.NotNil(crd.Ops.Update)
+	assert.Equal("UpdateFunctionConfiguration", crd.Ops.Update.Name)
+	assert.Equal(model.OpTypeUpdate, crd.Ops.OpTypeOf(crd.Ops.Update, crd.Config()))
+
+	// A pinned operation no longer serves the resource it was inferred for
+	opMap := g.SDKAPI.GetOperationMap(crd.Config())
+	assert.NotContains((*opMap)[model.OpTypeUpdate], "FunctionConfiguration")
+}
+
+func TestLambda_Function_References(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	g := testutil.NewModelForServiceWithOptions(t, "lambda", &testutil.TestingModelOptions{
+		GeneratorConfigFile: "generator-with-references.yaml",
+	})
+
+	crds, err := g.GetCRDs()
+	require.Nil(err)
+
+	crd := getCRDByName("Function", crds)
+	require.NotNil(crd)
+
+	// The API package declares KubernetesObjectReference and the controller
+	// gets the --allow-cross-namespace-references flag
+	assert.True(g.GetConfig().ResourceContainsReferences())
+
+	// Role is required by CreateFunction but may be read from the
+	// referenced Role instead
+	assert.False(crd.SpecFields["Role"].IsRequired())
+
+	roleRef := crd.SpecFields["RoleRef"]
+	require.NotNil(roleRef)
+	assert.Equal("*KubernetesObjectReference", roleRef.GoType)
+	layersRefs := crd.SpecFields["LayersRefs"]
+	require.NotNil(layersRefs)
+	assert.Equal("[]*KubernetesObjectReference", layersRefs.GoType)
+
+	refFields := crd.ReferenceFields()
+	require.Len(refFields, 2)
+
+	layers := refFields[0]
+	assert.Equal("Layers", layers.Field.Names.Camel)
+	assert.Equal("LayersRefs", layers.RefField.Names.Camel)
+	assert.True(layers.IsList)
+	assert.Equal("", layers.Group())
+	assert.Equal("v1", layers.Version())
+	assert.Equal("configmaps", layers.Resource())
+	assert.Equal("{.data.layerARN}", layers.JSONPath())
+	assert.Equal("LayersRefsResolved", layers.ConditionType())
+
+	role := refFields[1]
+	assert.Equal("Role", role.Field.Names.Camel)
+	assert.Equal("RoleRef", role.RefField.Names.Camel)
+	assert.False(role.IsList)
+	assert.Equal("iam.services.k8s.aws", role.Group())
+	assert.Equal("v1alpha1", role.Version())
+	assert.Equal("roles", role.Resource())
+	assert.Equal("iam.services.k8s.aws/v1alpha1", role.APIVersion())
+	assert.Equal("{.status.ackResourceMetadata.arn}", role.JSONPath())
+	assert.Equal("RoleRefResolved", role.ConditionType())
+}
+
+func TestLambda_Function_NestedMapSecret(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	g := testutil.NewModelForServiceWithOptions(t, "lambda", &testutil.TestingModelOptions{
+		GeneratorConfigFile: "generator-with-nested-secrets.yaml",
+	})
+
+	crd := testutil.GetCRDByName(t, g, "Function")
+	require.NotNil(crd)
+
+	field := crd.Fields["Environment.Variables"]
+	require.NotNil(field)
+	assert.Equal("map[string]*ackv1alpha1.SecretKeyReference", field.GoType)
+	assert.True(crd.IsSecretShapeMember("Environment", "Variables"))
+	assert.False(crd.IsSecretShapeMember("EnvironmentResponse", "Variables"))
+
+	tdef := testutil.GetTypeDefByName(t, g, "Environment")
+	require.NotNil(tdef)
+	assert.Equal(
+		"map[string]*ackv1alpha1.SecretKeyReference",
+		tdef.Attrs["Variables"].GoType,
+	)
+}
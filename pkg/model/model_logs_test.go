@@ -0,0 +1,65 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package model_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/aws-controllers-k8s/code-generator/pkg/sdk"
+	"github.com/aws-controllers-k8s/code-generator/pkg/testutil"
+)
+
+func TestLogs_LogGroup_CloudControl(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	g := testutil.NewModelForServiceWithOptions(t, "logs", &testutil.TestingModelOptions{
+		ModelFormat: sdk.ModelFormatCloudControl,
+	})
+	assert.True(g.IsCloudControl())
+	assert.Equal("cloudcontrolapi", g.SDKPackageName())
+
+	crds, err := g.GetCRDs()
+	require.Nil(err)
+
+	crd := getCRDByName("LogGroup", crds)
+	require.NotNil(crd)
+	require.NotNil(crd.CloudControlResource())
+	assert.Equal("AWS::Logs::LogGroup", crd.CloudControlResource().TypeName)
+
+	assert.Contains(crd.SpecFields, "LogGroupName")
+	assert.Contains(crd.SpecFields, "Tags")
+	// The ARN is read into Status.ACKResourceMetadata.ARN
+	assert.NotContains(crd.StatusFields, "Arn")
+	assert.Equal("Arn", crd.CloudControlARNProperty())
+
+	identifiers := crd.CloudControlIdentifiers()
+	require.Len(identifiers, 1)
+	assert.Equal("LogGroupName", identifiers[0].Property)
+	assert.Equal("Spec.LogGroupName", identifiers[0].Path)
+	assert.True(identifiers[0].IsString)
+
+	propertyNames := crd.CloudControlPropertyNames()
+	assert.Equal("LogGroupName", propertyNames["logGroupName"])
+	// The members of the fields' types are renamed too
+	assert.Equal("Key", propertyNames["key"])
+
+	actions := crd.IAMActions()
+	assert.Contains(actions.Create, "cloudformation:CreateResource")
+	assert.Contains(actions.Create, "logs:CreateLogGroup")
+	assert.Equal([]string{"cloudformation:GetResource", "logs:DescribeLogGroups"}, actions.Read)
+}
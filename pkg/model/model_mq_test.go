@@ -19,6 +19,7 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
+	"github.com/aws-controllers-k8s/code-generator/pkg/model"
 	"github.com/aws-controllers-k8s/code-generator/pkg/testutil"
 )
 
@@ -65,3 +66,47 @@ func TestMQ_GetOutputShapeGoType(t *testing.T) {
 	otype := crd.GetOutputShapeGoType(crd.Ops.Create)
 	assert.Equal(exp, otype)
 }
+
+func TestMQ_Broker_Splits(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	g := testutil.NewModelForServiceWithOptions(t, "mq", &testutil.TestingModelOptions{
+		GeneratorConfigFile: "generator-with-splits.yaml",
+	})
+
+	crds, err := g.GetCRDs()
+	require.Nil(err)
+
+	// The Broker resource is replaced by the CRDs it is split into
+	assert.Nil(getCRDByName("Broker", crds))
+
+	engine := getCRDByName("BrokerEngine", crds)
+	require.NotNil(engine)
+	security := getCRDByName("BrokerSecurity", crds)
+	require.NotNil(security)
+
+	for _, crd := range []*model.CRD{engine, security} {
+		require.NotNil(crd.SplitFrom)
+		assert.Equal("Broker", crd.SplitFrom.Names.Original)
+		assert.Equal("CreateBroker", crd.Ops.Create.Name)
+		assert.Equal("UpdateBroker", crd.Ops.Update.Name)
+
+		// Fields no split lists are kept by both splits, along with all the
+		// Status fields
+		assert.Contains(crd.SpecFields, "BrokerName")
+		assert.Contains(crd.SpecFields, "Users")
+		assert.Contains(crd.StatusFields, "BrokerId")
+
+		// Field configs of the resource apply to its splits
+		passField, found := crd.Fields["Users..Password"]
+		require.True(found)
+		require.NotNil(passField.FieldConfig)
+		assert.True(passField.FieldConfig.IsSecret)
+	}
+
+	assert.Contains(engine.SpecFields, "EngineVersion")
+	assert.NotContains(engine.SpecFields, "SecurityGroups")
+	assert.Contains(security.SpecFields, "SecurityGroups")
+	assert.NotContains(security.SpecFields, "EngineVersion")
+}
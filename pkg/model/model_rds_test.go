@@ -19,6 +19,7 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
+	"github.com/aws-controllers-k8s/code-generator/pkg/model"
 	"github.com/aws-controllers-k8s/code-generator/pkg/testutil"
 )
 
@@ -174,3 +175,152 @@ func TestRDS_DBInstance(t *testing.T) {
 	}
 	assert.Equal(expStatusFieldCamel, attrCamelNames(statusFields))
 }
+
+func TestRDS_DBInstance_RenamePatterns(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	g := testutil.NewModelForServiceWithOptions(t, "rds", &testutil.TestingModelOptions{
+		GeneratorConfigFile: "generator-with-rename-patterns.yaml",
+	})
+
+	crds, err := g.GetCRDs()
+	require.Nil(err)
+
+	crd := getCRDByName("DBInstance", crds)
+	require.NotNil(crd)
+
+	// The glob renames KmsKeyId but leaves PerformanceInsightsKMSKeyId alone
+	assert.Contains(crd.SpecFields, "KMSKeyID")
+	assert.NotContains(crd.SpecFields, "KmsKeyId")
+	assert.Contains(crd.SpecFields, "PerformanceInsightsKMSKeyId")
+
+	// The regular expression renames all the Enable* fields
+	assert.Contains(crd.SpecFields, "PerformanceInsightsEnabled")
+	assert.Contains(crd.SpecFields, "IAMDatabaseAuthenticationEnabled")
+	assert.NotContains(crd.SpecFields, "EnablePerformanceInsights")
+
+	renames, err := crd.GetAllRenames(model.OpTypeCreate)
+	require.Nil(err)
+	assert.Equal("KMSKeyID", renames["KmsKeyId"])
+	assert.Equal("PerformanceInsightsEnabled", renames["EnablePerformanceInsights"])
+
+	// Renames apply consistently to the other operations' payloads
+	renames, err = crd.GetAllRenames(model.OpTypeUpdate)
+	require.Nil(err)
+	assert.Equal("PerformanceInsightsEnabled", renames["EnablePerformanceInsights"])
+}
+
+func TestRDS_DBInstance_Export(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	g := testutil.NewModelForServiceWithOptions(t, "rds", &testutil.TestingModelOptions{
+		GeneratorConfigFile: "generator-with-export.yaml",
+	})
+
+	crds, err := g.GetCRDs()
+	require.Nil(err)
+
+	crd := getCRDByName("DBInstance", crds)
+	require.NotNil(crd)
+
+	export := crd.Export()
+	require.NotNil(export)
+	assert.Equal("Secret", export.GetKind())
+
+	// The Spec field naming the Secret is added, and isn't compared
+	exportTo, found := crd.SpecFields[model.ExportToFieldName]
+	require.True(found)
+	assert.Equal("*string", exportTo.GoType)
+	require.NotNil(exportTo.FieldConfig.Compare)
+	assert.True(exportTo.FieldConfig.Compare.IsIgnored)
+
+	// Resources without an export config don't have the field
+	crd = getCRDByName("DBSubnetGroup", crds)
+	require.NotNil(crd)
+	assert.Nil(crd.Export())
+	assert.NotContains(crd.SpecFields, model.ExportToFieldName)
+}
+
+func TestRDS_DBInstance_SensitiveFields(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	g := testutil.NewModelForServiceWithOptions(t, "rds", &testutil.TestingModelOptions{
+		GeneratorConfigFile: "generator-with-sensitive-fields.yaml",
+	})
+
+	crds, err := g.GetCRDs()
+	require.Nil(err)
+
+	crd := getCRDByName("DBInstance", crds)
+	require.NotNil(crd)
+	assert.Equal([]string{"Spec.MasterUserPassword"}, crd.GetSensitiveFieldPaths())
+
+	crd = getCRDByName("DBSubnetGroup", crds)
+	require.NotNil(crd)
+	assert.Empty(crd.GetSensitiveFieldPaths())
+}
+
+func TestRDS_DBInstance_EndpointURL(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	g := testutil.NewModelForServiceWithOptions(t, "rds", &testutil.TestingModelOptions{
+		GeneratorConfigFile: "generator-with-endpoint-url.yaml",
+	})
+
+	crds, err := g.GetCRDs()
+	require.Nil(err)
+
+	crd := getCRDByName("DBInstance", crds)
+	require.NotNil(crd)
+	assert.Equal("spec_field", crd.EndpointURLFrom())
+
+	// The Spec field overriding the endpoint URL is added, and isn't compared
+	endpointURL, found := crd.SpecFields[model.EndpointURLFieldName]
+	require.True(found)
+	assert.Equal("*string", endpointURL.GoType)
+	require.NotNil(endpointURL.FieldConfig.Compare)
+	assert.True(endpointURL.FieldConfig.Compare.IsIgnored)
+
+	// The other resources read the endpoint URL from an annotation, as
+	// configured at the top level of the generator config
+	crd = getCRDByName("DBSubnetGroup", crds)
+	require.NotNil(crd)
+	assert.Equal("annotation", crd.EndpointURLFrom())
+	assert.NotContains(crd.SpecFields, model.EndpointURLFieldName)
+}
+
+func TestRDS_DBInstance_Region(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	g := testutil.NewModelForServiceWithOptions(t, "rds", &testutil.TestingModelOptions{
+		GeneratorConfigFile: "generator-with-region.yaml",
+	})
+
+	crds, err := g.GetCRDs()
+	require.Nil(err)
+
+	crd := getCRDByName("DBInstance", crds)
+	require.NotNil(crd)
+	assert.Equal("spec_field", crd.RegionFrom())
+
+	// The Spec field selecting the region is added, and isn't compared
+	region, found := crd.SpecFields[model.RegionFieldName]
+	require.True(found)
+	assert.Equal("*string", region.GoType)
+	require.NotNil(region.FieldConfig.Compare)
+	assert.True(region.FieldConfig.Compare.IsIgnored)
+	assert.Contains(crd.StatusFields, model.RegionFieldName)
+
+	// The other resources select their region with an annotation, and only
+	// record it in their Status
+	crd = getCRDByName("DBSubnetGroup", crds)
+	require.NotNil(crd)
+	assert.Equal("annotation", crd.RegionFrom())
+	assert.NotContains(crd.SpecFields, model.RegionFieldName)
+	assert.Contains(crd.StatusFields, model.RegionFieldName)
+}
@@ -99,3 +99,49 @@ func TestS3_Bucket(t *testing.T) {
 		assert.NotNil(testutil.GetTypeDefByName(t, g, typeDef))
 	}
 }
+
+func TestS3_Bucket_SecondaryOperations(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	g := testutil.NewModelForServiceWithOptions(t, "s3", &testutil.TestingModelOptions{
+		GeneratorConfigFile: "generator-with-secondary-operations.yaml",
+	})
+
+	crds, err := g.GetCRDs()
+	require.Nil(err)
+
+	crd := getCRDByName("Bucket", crds)
+	require.NotNil(crd)
+
+	// The Bucket identifier in the Input shapes of the secondary operations
+	// is renamed to the existing Name field, so only the configuration
+	// members are added to the Spec
+	require.Len(crd.SecondaryOps, 2)
+	assert.Equal("PutBucketEncryption", crd.SecondaryOps[0].Name)
+	require.Len(crd.SecondaryOps[0].Fields, 1)
+	assert.Equal("Encryption", crd.SecondaryOps[0].Fields[0].Names.Camel)
+	assert.Equal("PutBucketVersioning", crd.SecondaryOps[1].Name)
+	require.Len(crd.SecondaryOps[1].Fields, 1)
+	assert.Equal("Versioning", crd.SecondaryOps[1].Fields[0].Names.Camel)
+
+	encryption := crd.SpecFields["Encryption"]
+	require.NotNil(encryption)
+	assert.Equal("ServerSideEncryptionConfiguration", encryption.Names.ModelOriginal)
+	assert.Equal("*ServerSideEncryptionConfiguration", encryption.GoType)
+
+	expSpecFieldCamel := []string{
+		"ACL",
+		"CreateBucketConfiguration",
+		"Encryption",
+		"GrantFullControl",
+		"GrantRead",
+		"GrantReadACP",
+		"GrantWrite",
+		"GrantWriteACP",
+		"Name",
+		"ObjectLockEnabledForBucket",
+		"Versioning",
+	}
+	assert.Equal(expSpecFieldCamel, attrCamelNames(crd.SpecFields))
+}
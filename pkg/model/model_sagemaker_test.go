@@ -106,6 +106,31 @@ func TestSageMaker_Error_Prefix_Message(t *testing.T) {
 	assert.Equal("&& strings.HasPrefix(awsErr.Message(), \"Requested resource not found\") ", code.CheckExceptionMessage(crd.Config(), crd, 404))
 }
 
+func TestSageMaker_Terminal_Messages(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	g := testutil.NewModelForService(t, "sagemaker")
+
+	crds, err := g.GetCRDs()
+	require.Nil(err)
+
+	crd := getCRDByName("TrainingJob", crds)
+	require.NotNil(crd)
+
+	// TrainingJob's ValidationExceptions are terminal only when the IAM role
+	// can't be used
+	assert.Empty(crd.TerminalExceptionCodes())
+	terminalMessages := crd.TerminalExceptionMessages()
+	require.Len(terminalMessages, 1)
+	assert.Equal("ValidationException", terminalMessages[0].Code)
+	assert.Equal("^Could not (access|assume) role", terminalMessages[0].Pattern)
+
+	crd = getCRDByName("ModelPackageGroup", crds)
+	require.NotNil(crd)
+	assert.Empty(crd.TerminalExceptionMessages())
+}
+
 func TestSageMaker_Error_Suffix_Message(t *testing.T) {
 	assert := assert.New(t)
 	require := require.New(t)
@@ -226,3 +251,32 @@ func TestSageMaker_RequeueOnSuccessSeconds_Default(t *testing.T) {
 	assert.Equal(0, crd.ReconcileRequeuOnSuccessSeconds())
 
 }
+
+func TestSageMaker_ErrorRequeues(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	g := testutil.NewModelForService(t, "sagemaker")
+
+	crds, err := g.GetCRDs()
+	require.Nil(err)
+
+	crd := getCRDByName("Endpoint", crds)
+	require.NotNil(crd)
+
+	assert.Equal(
+		[]string{"ResourceLimitExceeded", "ThrottlingException"},
+		crd.ErrorRequeueCodes(),
+	)
+	policy := crd.ErrorRequeues()["ResourceLimitExceeded"]
+	assert.Equal(60, policy.DelaySeconds)
+	assert.Equal(5, policy.MaxRetries)
+	policy = crd.ErrorRequeues()["ThrottlingException"]
+	assert.Equal(30, policy.DelaySeconds)
+	assert.Equal(0, policy.MaxRetries)
+
+	// Resources without error requeue policies use the runtime's backoff
+	crd = getCRDByName("DataQualityJobDefinition", crds)
+	require.NotNil(crd)
+	assert.Empty(crd.ErrorRequeueCodes())
+}
@@ -126,3 +126,41 @@ func TestSNS_Topic(t *testing.T) {
 	}
 	assert.Equal(expStatusFieldCamel, attrCamelNames(statusFields))
 }
+
+func TestSNS_PlatformEndpoint_ARNPrimaryKey(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	g := testutil.NewModelForServiceWithOptions(t, "sns", &testutil.TestingModelOptions{
+		GeneratorConfigFile: "generator-with-arn-identified-resources.yaml",
+	})
+
+	crds, err := g.GetCRDs()
+	require.Nil(err)
+
+	crd := getCRDByName("PlatformEndpoint", crds)
+	require.NotNil(crd)
+
+	require.NotNil(crd.Ops.GetAttributes)
+	assert.Equal("GetEndpointAttributes", crd.Ops.GetAttributes.Name)
+
+	// A PlatformEndpoint has no name. It is only identified by the
+	// EndpointArn returned by CreatePlatformEndpoint, which is the only
+	// required member of the GetEndpointAttributes Input shape.
+	assert.True(crd.IsARNPrimaryKey())
+	assert.Nil(crd.SpecIdentifierField())
+
+	expSpecFieldCamel := []string{
+		"CustomUserData",
+		"Enabled",
+		"PlatformApplicationARN",
+		"Token",
+	}
+	assert.Equal(expSpecFieldCamel, attrCamelNames(crd.SpecFields))
+	assert.Empty(crd.StatusFields)
+
+	// The Topic's GetTopicAttributes Input shape only requires the TopicArn
+	topic := getCRDByName("Topic", crds)
+	require.NotNil(topic)
+	assert.True(topic.IsARNPrimaryKey())
+}
@@ -56,6 +56,9 @@ func TestSQS_Queue(t *testing.T) {
 	assert.Nil(crd.Ops.ReadOne)
 	assert.Nil(crd.Ops.Update)
 
+	// The GetQueueAttributes Input shape requires the QueueUrl, not an ARN
+	assert.False(crd.IsARNPrimaryKey())
+
 	specFields := crd.SpecFields
 	statusFields := crd.StatusFields
 
@@ -86,3 +89,47 @@ func TestSQS_Queue(t *testing.T) {
 	}
 	assert.Equal(expStatusFieldCamel, attrCamelNames(statusFields))
 }
+
+func TestSQS_Queue_AttributeKeys(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	g := testutil.NewModelForServiceWithOptions(t, "sqs", &testutil.TestingModelOptions{
+		GeneratorConfigFile: "generator-with-attribute-keys.yaml",
+	})
+
+	crds, err := g.GetCRDs()
+	require.Nil(err)
+
+	crd := getCRDByName("Queue", crds)
+	require.NotNil(crd)
+
+	require.NotNil(crd.Ops.GetAttributes)
+	assert.Equal("GetQueueAttributes", crd.Ops.GetAttributes.Name)
+	require.NotNil(crd.Ops.SetAttributes)
+	assert.Equal("SetQueueAttributes", crd.Ops.SetAttributes.Name)
+
+	assert.Equal("Attributes", crd.AttributesMapMemberName())
+
+	// Fields configured with an attribute_key are attribute fields named
+	// independently of their key in the Attributes map
+	expSpecFieldCamel := []string{
+		"DelaySeconds",
+		"EncryptionKey",
+		"Policy",
+		"QueueName",
+		"RetentionPeriod",
+		"Tags",
+	}
+	assert.Equal(expSpecFieldCamel, attrCamelNames(crd.SpecFields))
+
+	expStatusFieldCamel := []string{
+		"CreatedAt",
+		"QueueURL",
+	}
+	assert.Equal(expStatusFieldCamel, attrCamelNames(crd.StatusFields))
+
+	assert.Equal("MessageRetentionPeriod", crd.AttributeKey("RetentionPeriod"))
+	assert.Equal("KmsMasterKeyId", crd.AttributeKey("EncryptionKey"))
+	assert.Equal("DelaySeconds", crd.AttributeKey("DelaySeconds"))
+}
@@ -83,6 +83,7 @@ func ComputeCRDFieldDeltas(src, dst *ackmodel.CRD) (*CRDDelta, error) {
 	if err != nil {
 		return nil, fmt.Errorf("cannot compute the field renames delta: %v", err)
 	}
+	addConversionFieldMappings(renames, src, dst)
 
 	specDeltas, err := ComputeFieldDeltas(src.SpecFields, dst.SpecFields, renames)
 	if err != nil {
@@ -100,6 +101,30 @@ func ComputeCRDFieldDeltas(src, dst *ackmodel.CRD) (*CRDDelta, error) {
 	}, nil
 }
 
+// addConversionFieldMappings adds to the supplied renames delta the field
+// mappings of the generator configs of both API versions. The mappings of the
+// destination version map the source field names to its own, the mappings of
+// the source version map the destination field names to its own. Mappings
+// naming fields missing from either CRD concern other API versions and are
+// ignored.
+func addConversionFieldMappings(renames map[string]string, src, dst *ackmodel.CRD) {
+	hasField := func(crd *ackmodel.CRD, name string) bool {
+		_, inSpec := crd.SpecFields[name]
+		_, inStatus := crd.StatusFields[name]
+		return inSpec || inStatus
+	}
+	for srcName, dstName := range dst.ConversionFieldMappings() {
+		if hasField(src, srcName) && hasField(dst, dstName) {
+			renames[srcName] = dstName
+		}
+	}
+	for dstName, srcName := range src.ConversionFieldMappings() {
+		if hasField(src, srcName) && hasField(dst, dstName) {
+			renames[srcName] = dstName
+		}
+	}
+}
+
 // fieldChangedToSecret returns true if field changed from string to secret.
 func fieldChangedToSecret(src, dst *ackmodel.Field) bool {
 	return (src.FieldConfig == nil ||
@@ -259,6 +284,24 @@ func getFieldNameFromDelta(delta FieldDelta) string {
 // given shapes are not equal, it will return an error representing the first type mismatch
 // detected.
 func AreEqualShapes(a, b *awssdkmodel.Shape, allowMemberNamesInequality bool) (bool, error) {
+	return areEqualShapes(a, b, allowMemberNamesInequality, map[[2]string]bool{})
+}
+
+// areEqualShapes compares two shapes like AreEqualShapes. Pairs of shapes that
+// are already being compared higher up in recursive shapes are considered
+// equal, which stops the comparison from recursing forever.
+func areEqualShapes(
+	a, b *awssdkmodel.Shape,
+	allowMemberNamesInequality bool,
+	comparing map[[2]string]bool,
+) (bool, error) {
+	pair := [2]string{a.ShapeName, b.ShapeName}
+	if comparing[pair] {
+		return true, nil
+	}
+	comparing[pair] = true
+	defer delete(comparing, pair)
+
 	if a.Type != b.Type {
 		return false, fmt.Errorf("found different shape types (%s and %s)", a.ShapeName, a.ShapeName)
 	}
@@ -283,21 +326,21 @@ func AreEqualShapes(a, b *awssdkmodel.Shape, allowMemberNamesInequality bool) (b
 			}
 			// if two members with the same name doesn't have the same shape
 			// return false.
-			if equal, err := AreEqualShapes(memberRefA.Shape, memberRefB.Shape, false); !equal {
+			if equal, err := areEqualShapes(memberRefA.Shape, memberRefB.Shape, false, comparing); !equal {
 				return false, fmt.Errorf("member %s have two different shapes in %s: %v", memberName, memberRefA.ShapeName, err)
 			}
 		}
 	case "map":
 		// for maps we check that the keys and values have the same types
-		if equal, err := AreEqualShapes(a.KeyRef.Shape, b.KeyRef.Shape, false); !equal {
+		if equal, err := areEqualShapes(a.KeyRef.Shape, b.KeyRef.Shape, false, comparing); !equal {
 			return false, fmt.Errorf("map key shape mismatch in %s: %v", a.ShapeName, err)
 		}
-		if equal, err := AreEqualShapes(a.ValueRef.Shape, b.ValueRef.Shape, false); !equal {
+		if equal, err := areEqualShapes(a.ValueRef.Shape, b.ValueRef.Shape, false, comparing); !equal {
 			return false, fmt.Errorf("map value shape mismatch in %s: %v", a.ShapeName, err)
 		}
 	case "list":
 		// for lists we check that the members have the same types
-		if equal, err := AreEqualShapes(a.MemberRef.Shape, b.MemberRef.Shape, false); !equal {
+		if equal, err := areEqualShapes(a.MemberRef.Shape, b.MemberRef.Shape, false, comparing); !equal {
 			return false, fmt.Errorf("member shape mismatch in %s: %v", a.ShapeName, err)
 		}
 	}
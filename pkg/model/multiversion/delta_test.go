@@ -508,3 +508,49 @@ func TestComputeCRDDeltas_APIGatewayV2_DomainName(t *testing.T) {
 		assert.Equal(delta.ChangeType, multiversion.FieldChangeTypeNone)
 	}
 }
+
+func TestComputeCRDDeltas_ECR_ConversionFieldMappings(t *testing.T) {
+	require := require.New(t)
+	assert := assert.New(t)
+
+	srcModel := testutil.NewModelForServiceWithOptions(t, "ecr", &testutil.TestingModelOptions{
+		GeneratorConfigFile: "generator-v1alpha1.yaml",
+		APIVersion:          "v1alpha1",
+	})
+	dstModel := testutil.NewModelForServiceWithOptions(t, "ecr", &testutil.TestingModelOptions{
+		GeneratorConfigFile: "generator-with-conversion.yaml",
+		APIVersion:          "v1beta1",
+	})
+	srcCRDs, err := srcModel.GetCRDs()
+	require.Nil(err)
+	dstCRDs, err := dstModel.GetCRDs()
+	require.Nil(err)
+	require.Len(srcCRDs, 1)
+	require.Len(dstCRDs, 1)
+
+	expect := map[string]multiversion.FieldChangeType{
+		"ImageTagMutability": multiversion.FieldChangeTypeNone,
+		"RepositoryName":     multiversion.FieldChangeTypeNone,
+		"Scanning":           multiversion.FieldChangeTypeRenamed,
+		"Tags":               multiversion.FieldChangeTypeNone,
+	}
+
+	// The mappings of the hub's config apply when converting to the hub
+	deltas, err := multiversion.ComputeCRDFieldDeltas(srcCRDs[0], dstCRDs[0])
+	require.Nil(err)
+	require.Len(deltas.SpecDeltas, len(expect))
+	for _, delta := range deltas.SpecDeltas {
+		assert.Equal(expect[delta.Destination.Names.Camel], delta.ChangeType)
+	}
+	renamed := deltas.SpecDeltas[2]
+	assert.Equal("ImageScanningConfiguration", renamed.Source.Names.Camel)
+
+	// and so do the mappings of the spoke's config
+	deltas, err = multiversion.ComputeCRDFieldDeltas(dstCRDs[0], srcCRDs[0])
+	require.Nil(err)
+	require.Len(deltas.SpecDeltas, len(expect))
+	renamed = deltas.SpecDeltas[0]
+	assert.Equal(multiversion.FieldChangeTypeRenamed, renamed.ChangeType)
+	assert.Equal("Scanning", renamed.Source.Names.Camel)
+	assert.Equal("ImageScanningConfiguration", renamed.Destination.Names.Camel)
+}
@@ -147,6 +147,11 @@ func (m *APIVersionManager) GetHubVersion() string {
 	return m.hubVersion
 }
 
+// GetServiceVersion returns the service metadata of an API version.
+func (m *APIVersionManager) GetServiceVersion(apiVersion string) ackmetadata.ServiceVersion {
+	return m.metadata.GetServiceVersion(apiVersion)
+}
+
 // CompareHubWith compares a given api version with the hub version and returns
 // a string to *CRDDelta map.
 func (m *APIVersionManager) CompareHubWith(apiVersion string) (map[string]*CRDDelta, error) {
@@ -0,0 +1,81 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package model
+
+import (
+	awssdkmodel "github.com/aws/aws-sdk-go/private/model/api"
+
+	ackgenconfig "github.com/aws-controllers-k8s/code-generator/pkg/generate/config"
+)
+
+const (
+	// QuantityGoType is the Go type of number fields using the "quantity"
+	// number format
+	QuantityGoType = "*resource.Quantity"
+	// QuantityImport is the package QuantityGoType is defined in
+	QuantityImport = "k8s.io/apimachinery/pkg/api/resource"
+)
+
+// NumberFormat returns how the value of the field is represented if the field
+// is a number whose representation was overridden in the generator config, or
+// the empty string if the field uses the SDK's representation.
+func (f *Field) NumberFormat() string {
+	if f.ShapeRef == nil {
+		return ""
+	}
+	return numberFormat(f.ShapeRef.Shape, f.FieldConfig)
+}
+
+// numberFormat returns the number format configured for a field of the
+// supplied shape, or the empty string if none is configured or the format
+// doesn't apply to the shape
+func numberFormat(
+	shape *awssdkmodel.Shape,
+	fieldCfg *ackgenconfig.FieldConfig,
+) string {
+	if shape == nil || fieldCfg == nil {
+		return ""
+	}
+	switch fieldCfg.NumberFormat {
+	case ackgenconfig.NumberFormatInt32:
+		if shape.Type == "integer" || shape.Type == "long" {
+			return fieldCfg.NumberFormat
+		}
+	case ackgenconfig.NumberFormatQuantity, ackgenconfig.NumberFormatString:
+		if shape.Type == "float" || shape.Type == "double" {
+			return fieldCfg.NumberFormat
+		}
+	}
+	return ""
+}
+
+// numberGoType returns the Go type, without the pointer, of number values in
+// the supplied format
+func numberGoType(format string) string {
+	switch format {
+	case ackgenconfig.NumberFormatInt32:
+		return "int32"
+	case ackgenconfig.NumberFormatQuantity:
+		return "resource.Quantity"
+	}
+	return "string"
+}
+
+// addQuantityImport adds the import of the resource.Quantity type to the
+// CRD's type imports if the supplied field holds quantities
+func (r *CRD) addQuantityImport(f *Field) {
+	if f.NumberFormat() == ackgenconfig.NumberFormatQuantity {
+		r.AddTypeImport(QuantityImport, "")
+	}
+}
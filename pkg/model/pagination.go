@@ -0,0 +1,164 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package model
+
+import (
+	"strings"
+
+	awssdkmodel "github.com/aws/aws-sdk-go/private/model/api"
+)
+
+// DefaultReadManyMaxPages is the number of pages of a paginated ReadMany
+// operation's results read looking for a resource, unless the generator
+// config sets another
+const DefaultReadManyMaxPages = 10
+
+// paginationTokenNames are the names of the Input and Output shape members
+// holding the page token of the paginated operations whose model doesn't
+// describe their paginator, in order of preference
+var paginationTokenNames = [][2]string{
+	{"NextToken", "NextToken"},
+	{"Marker", "NextMarker"},
+	{"Marker", "Marker"},
+	{"NextMarker", "NextMarker"},
+	{"ContinuationToken", "NextContinuationToken"},
+	{"PaginationToken", "PaginationToken"},
+	{"NextPageToken", "NextPageToken"},
+}
+
+// Pagination describes how to read the successive pages of a paginated
+// operation's results
+type Pagination struct {
+	// InputToken is the name of the Input shape member taking the token of
+	// the page to read, e.g. "NextToken"
+	InputToken string
+	// OutputToken is the name of the Output shape member returning the token
+	// of the next page, e.g. "NextToken" or "NextMarker"
+	OutputToken string
+	// ResultMember is the name of the Output shape list member holding the
+	// page's elements, e.g. "Repositories"
+	ResultMember string
+	// MaxPages is the maximum number of pages read
+	MaxPages int
+}
+
+// ReadManyPagination returns how to read the successive pages of the
+// results of the resource's ReadMany operation, or nil if the operation isn't
+// paginated or the generator config only reads its first page
+func (r *CRD) ReadManyPagination() *Pagination {
+	op := r.Ops.ReadMany
+	if op == nil || op.InputRef.Shape == nil || op.OutputRef.Shape == nil {
+		return nil
+	}
+	maxPages := r.cfg.ListOpMaxPages(r.Names.Original)
+	if maxPages == 0 {
+		maxPages = DefaultReadManyMaxPages
+	}
+	if maxPages == 1 {
+		return nil
+	}
+	inputToken, outputToken := paginationTokens(op)
+	if inputToken == "" {
+		return nil
+	}
+	// Like the List operation's output is set into the resource, the page's
+	// elements are the first list member of the Output shape
+	outputShape := op.OutputRef.Shape
+	resultMember := ""
+	for _, memberName := range outputShape.MemberNames() {
+		if outputShape.MemberRefs[memberName].Shape.Type == "list" {
+			resultMember = memberName
+			break
+		}
+	}
+	if resultMember == "" {
+		return nil
+	}
+	return &Pagination{
+		InputToken:   inputToken,
+		OutputToken:  outputToken,
+		ResultMember: resultMember,
+		MaxPages:     maxPages,
+	}
+}
+
+// paginationTokens returns the names of the supplied operation's Input and
+// Output shape members holding the page token, or empty strings if the
+// operation isn't paginated by a single string token. The operation's
+// paginator is used when the model describes it, otherwise well-known token
+// member names are looked for.
+func paginationTokens(
+	op *awssdkmodel.Operation,
+) (string, string) {
+	inputShape := op.InputRef.Shape
+	outputShape := op.OutputRef.Shape
+	if op.Paginator != nil {
+		inputToken := stringTokenMember(inputShape, singleToken(op.Paginator.InputTokens))
+		outputToken := stringTokenMember(outputShape, singleToken(op.Paginator.OutputTokens))
+		if inputToken == "" || outputToken == "" {
+			return "", ""
+		}
+		return inputToken, outputToken
+	}
+	for _, names := range paginationTokenNames {
+		inputToken := stringTokenMember(inputShape, names[0])
+		outputToken := stringTokenMember(outputShape, names[1])
+		if inputToken != "" && outputToken != "" {
+			return inputToken, outputToken
+		}
+	}
+	return "", ""
+}
+
+// singleToken returns the name of the token of a paginator's input or output
+// tokens, or an empty string if there are several of them
+func singleToken(tokens interface{}) string {
+	switch t := tokens.(type) {
+	case string:
+		return t
+	case []interface{}:
+		if len(t) == 1 {
+			token, _ := t[0].(string)
+			return token
+		}
+	case []string:
+		if len(t) == 1 {
+			return t[0]
+		}
+	}
+	return ""
+}
+
+// stringTokenMember returns the name of the supplied shape's string member
+// having the supplied name, compared case-insensitively, or an empty string
+// if there is none. Tokens nested in structures aren't supported.
+func stringTokenMember(
+	shape *awssdkmodel.Shape,
+	name string,
+) string {
+	if name == "" || strings.Contains(name, ".") {
+		return ""
+	}
+	for _, memberName := range shape.MemberNames() {
+		if !strings.EqualFold(memberName, name) {
+			continue
+		}
+		memberShape := shape.MemberRefs[memberName].Shape
+		if memberShape == nil || memberShape.Type != "string" {
+			return ""
+		}
+		return memberName
+	}
+	return ""
+}
@@ -17,6 +17,8 @@ import (
 	"fmt"
 	"sort"
 	"strings"
+
+	ackgenconfig "github.com/aws-controllers-k8s/code-generator/pkg/generate/config"
 )
 
 // PrinterColumn represents a single field in the CRD's Spec or Status objects
@@ -104,6 +106,10 @@ func (r *CRD) addPrintableColumn(
 	jsonPath string,
 ) {
 	fieldColumnType := field.GoTypeElem
+	if field.ShapeRef != nil && IsTypedEnum(r.cfg, field.ShapeRef.Shape) {
+		// Enum types are strings
+		fieldColumnType = "string"
+	}
 
 	// Printable columns must be primitives supported by the OpenAPI list of data
 	// types as defined by
@@ -126,8 +132,15 @@ func (r *CRD) addPrintableColumn(
 		"float32":     "number",
 		"float64":     "number",
 		"metav1.Time": "date",
+		// Quantities are printed like "500m"
+		"resource.Quantity": "string",
 	}
 	printColumnType, exists := acceptableColumnMaps[fieldColumnType]
+	if exists && field.ShapeRef != nil && field.ShapeRef.Shape.Type == "timestamp" &&
+		field.TimestampFormat() == ackgenconfig.TimestampFormatRFC3339 {
+		// RFC 3339 strings are dates too
+		printColumnType = "date"
+	}
 
 	if !exists {
 		msg := fmt.Sprintf(
@@ -176,3 +189,73 @@ func (r *CRD) addStatusPrintableColumn(
 		fmt.Sprintf("%s.%s", ".status", field.Names.CamelLower),
 	)
 }
+
+// syncedColumnJSONPath is the JSONPath of the status of the resource's
+// ResourceSynced condition, shown in the default Synced column
+const syncedColumnJSONPath = `.status.conditions[?(@.type=="ACK.ResourceSynced")].status`
+
+// stateFieldNames are the names of the Status fields holding the state of the
+// AWS resource, shown in the default State column, by order of preference
+var stateFieldNames = []string{"State", "Status"}
+
+// addCustomPrintableColumns adds to the list of additional printer columns the
+// columns configured with arbitrary JSONPaths
+func (r *CRD) addCustomPrintableColumns() {
+	for _, column := range r.cfg.GetResourcePrintColumns(r.Names.Original) {
+		r.additionalPrinterColumns = append(r.additionalPrinterColumns, &PrinterColumn{
+			CRD:      r,
+			Name:     column.Name,
+			Type:     column.GetType(),
+			Priority: column.Priority,
+			JSONPath: column.JSONPath,
+			Index:    column.Index,
+		})
+	}
+}
+
+// hasPrinterColumn returns true if the resource has an additional printer
+// column with the supplied name or JSONPath
+func (r *CRD) hasPrinterColumn(name string, jsonPath string) bool {
+	for _, column := range r.additionalPrinterColumns {
+		if strings.EqualFold(column.Name, name) || column.JSONPath == jsonPath {
+			return true
+		}
+	}
+	return false
+}
+
+// DefaultPrinterColumns returns the default State and Synced printer columns
+// of the resource, printed after its additional printer columns unless the
+// resource has columns of the same name. The State column shows the string
+// Status field holding the state of the AWS resource, if any.
+func (r *CRD) DefaultPrinterColumns() []*PrinterColumn {
+	if !r.cfg.GetResourcePrintDefaultColumns(r.Names.Original) {
+		return nil
+	}
+	columns := []*PrinterColumn{}
+	for _, fieldName := range stateFieldNames {
+		field, found := r.StatusFields[fieldName]
+		if !found || field.GoType != "*string" {
+			continue
+		}
+		jsonPath := ".status." + field.Names.CamelLower
+		if !r.hasPrinterColumn("State", jsonPath) {
+			columns = append(columns, &PrinterColumn{
+				CRD:      r,
+				Name:     "State",
+				Type:     "string",
+				JSONPath: jsonPath,
+			})
+		}
+		break
+	}
+	if !r.hasPrinterColumn("Synced", syncedColumnJSONPath) {
+		columns = append(columns, &PrinterColumn{
+			CRD:      r,
+			Name:     "Synced",
+			Type:     "string",
+			JSONPath: syncedColumnJSONPath,
+		})
+	}
+	return columns
+}
@@ -0,0 +1,92 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package model
+
+import (
+	awssdkmodel "github.com/aws/aws-sdk-go/private/model/api"
+)
+
+// recursiveMemberMarkers are the kubebuilder markers of struct fields that
+// lead back to their own struct type. controller-gen can't generate the
+// schema of recursive types, so the schema of these fields is left out and
+// their values are preserved as is. The schema of a recursive type is thereby
+// limited to the depth at which the first of its shapes repeats.
+var recursiveMemberMarkers = []string{
+	"+kubebuilder:validation:Schemaless",
+	preserveUnknownFieldsMarker,
+}
+
+// IsRecursiveMember returns true if the supplied member of the supplied
+// structure shape leads back to the structure shape, either directly or
+// through other structure, list or map shapes. For example, the `Not` member
+// of a `Filter` structure whose `Not` member is itself a `Filter`.
+func (a *SDKAPI) IsRecursiveMember(
+	shape *awssdkmodel.Shape,
+	memberName string,
+) bool {
+	if shape == nil || shape.Type != "structure" {
+		return false
+	}
+	memberRef, found := shape.MemberRefs[memberName]
+	if !found || memberRef.Shape == nil {
+		return false
+	}
+	return a.reachableShapes(memberRef.Shape)[shape.ShapeName]
+}
+
+// IsRecursiveMember returns true if the supplied member of the supplied
+// structure shape leads back to the structure shape
+func (r *CRD) IsRecursiveMember(
+	shape *awssdkmodel.Shape,
+	memberName string,
+) bool {
+	return r.sdkAPI.IsRecursiveMember(shape, memberName)
+}
+
+// reachableShapes returns the set of names of the shapes that can be reached
+// from the supplied shape, including the shape itself
+func (a *SDKAPI) reachableShapes(shape *awssdkmodel.Shape) map[string]bool {
+	if a.shapeReach == nil {
+		a.shapeReach = map[string]map[string]bool{}
+	}
+	if reached, found := a.shapeReach[shape.ShapeName]; found {
+		return reached
+	}
+	reached := map[string]bool{}
+	collectReachableShapes(shape, reached)
+	a.shapeReach[shape.ShapeName] = reached
+	return reached
+}
+
+// collectReachableShapes adds the names of the supplied shape and every shape
+// reachable from it that isn't in the supplied set yet to the set
+func collectReachableShapes(
+	shape *awssdkmodel.Shape,
+	reached map[string]bool,
+) {
+	if shape == nil || reached[shape.ShapeName] {
+		return
+	}
+	reached[shape.ShapeName] = true
+	switch shape.Type {
+	case "structure":
+		for _, memberRef := range shape.MemberRefs {
+			collectReachableShapes(memberRef.Shape, reached)
+		}
+	case "list":
+		collectReachableShapes(shape.MemberRef.Shape, reached)
+	case "map":
+		collectReachableShapes(shape.ValueRef.Shape, reached)
+	}
+}
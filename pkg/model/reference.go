@@ -0,0 +1,265 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package model
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/gertd/go-pluralize"
+
+	ackgenconfig "github.com/aws-controllers-k8s/code-generator/pkg/generate/config"
+	"github.com/aws-controllers-k8s/code-generator/pkg/names"
+)
+
+// referenceGoType is the Go type of the fields referencing a single Kubernetes
+// object, declared in the API package when a resource has references
+const referenceGoType = "*KubernetesObjectReference"
+
+// ReferenceField describes a Spec field whose value may be read from other
+// Kubernetes objects, and the Spec field referencing them
+type ReferenceField struct {
+	// Field is the Spec field set from the referenced objects
+	Field *Field
+	// RefField is the Spec field naming the referenced objects
+	RefField *Field
+	// IsList is true if Field is a list of strings, whose elements are each
+	// read from one of the referenced objects
+	IsList bool
+	cfg    *ackgenconfig.ReferencesConfig
+}
+
+// APIVersion returns the API group and version of the referenced objects,
+// e.g. "iam.services.k8s.aws/v1alpha1"
+func (rf *ReferenceField) APIVersion() string {
+	return rf.cfg.APIVersion
+}
+
+// Group returns the API group of the referenced objects, empty for the core
+// API group
+func (rf *ReferenceField) Group() string {
+	if i := strings.LastIndex(rf.cfg.APIVersion, "/"); i >= 0 {
+		return rf.cfg.APIVersion[:i]
+	}
+	return ""
+}
+
+// Version returns the API version of the referenced objects
+func (rf *ReferenceField) Version() string {
+	if i := strings.LastIndex(rf.cfg.APIVersion, "/"); i >= 0 {
+		return rf.cfg.APIVersion[i+1:]
+	}
+	return rf.cfg.APIVersion
+}
+
+// Kind returns the kind of the referenced objects
+func (rf *ReferenceField) Kind() string {
+	return rf.cfg.Kind
+}
+
+// Resource returns the plural resource name of the referenced objects
+func (rf *ReferenceField) Resource() string {
+	if rf.cfg.Resource != "" {
+		return rf.cfg.Resource
+	}
+	return strings.ToLower(pluralize.NewClient().Plural(rf.cfg.Kind))
+}
+
+// ConditionType returns the type of the resource condition telling whether
+// the objects RefField names were resolved, e.g. "RoleRefResolved"
+func (rf *ReferenceField) ConditionType() string {
+	return rf.RefField.Names.Camel + "Resolved"
+}
+
+// JSONPath returns the JSONPath template of the value in the referenced
+// objects, e.g. "{.status.ackResourceMetadata.arn}"
+func (rf *ReferenceField) JSONPath() string {
+	path := strings.TrimSpace(rf.cfg.Path)
+	if strings.HasPrefix(path, "{") {
+		return path
+	}
+	return "{" + path + "}"
+}
+
+// IsOwner returns true if the referenced objects are the owners of the
+// resource
+func (rf *ReferenceField) IsOwner() bool {
+	return rf.cfg.Owner
+}
+
+// OwnerLabel returns the key of the label naming the owner of the resource,
+// e.g. "apigatewayv2.services.k8s.aws/api", or "configmap" for objects of the
+// core API group
+func (rf *ReferenceField) OwnerLabel() string {
+	kind := strings.ToLower(rf.cfg.Kind)
+	if group := rf.Group(); group != "" {
+		return group + "/" + kind
+	}
+	return kind
+}
+
+// ReferenceFields returns the Spec fields whose value may be read from other
+// Kubernetes objects, sorted by name
+func (r *CRD) ReferenceFields() []*ReferenceField {
+	fieldNames := []string{}
+	for fieldName, field := range r.SpecFields {
+		if field.FieldConfig != nil && field.FieldConfig.References != nil {
+			fieldNames = append(fieldNames, fieldName)
+		}
+	}
+	sort.Strings(fieldNames)
+	refFields := []*ReferenceField{}
+	for _, fieldName := range fieldNames {
+		field := r.SpecFields[fieldName]
+		isList := field.GoType == "[]*string"
+		refField, found := r.SpecFields[referenceFieldName(fieldName, isList)]
+		if !found {
+			continue
+		}
+		refFields = append(refFields, &ReferenceField{
+			Field:    field,
+			RefField: refField,
+			IsList:   isList,
+			cfg:      field.FieldConfig.References,
+		})
+	}
+	return refFields
+}
+
+// referenceFieldName returns the name of the Spec field naming the objects
+// the supplied field's value is read from
+func referenceFieldName(fieldName string, isList bool) string {
+	if isList {
+		return fieldName + "Refs"
+	}
+	return fieldName + "Ref"
+}
+
+// addReferenceFields adds to the Spec the fields naming the objects the
+// values of the Spec fields configured with references are read from. Only
+// string and list of strings fields may reference other objects.
+func (r *CRD) addReferenceFields() error {
+	fieldNames := []string{}
+	for fieldName := range r.SpecFields {
+		fieldNames = append(fieldNames, fieldName)
+	}
+	sort.Strings(fieldNames)
+	for _, fieldName := range fieldNames {
+		field := r.SpecFields[fieldName]
+		if field.FieldConfig == nil || field.FieldConfig.References == nil {
+			continue
+		}
+		refs := field.FieldConfig.References
+		goType := referenceGoType
+		isList := false
+		switch field.GoType {
+		case "*string":
+		case "[]*string":
+			goType = "[]" + referenceGoType
+			isList = true
+		default:
+			return fmt.Errorf(
+				"%s.%s: references are only supported on string and list of strings fields, not %s",
+				r.Names.Original, fieldName, field.GoType,
+			)
+		}
+		refFieldName := referenceFieldName(fieldName, isList)
+		if _, found := r.SpecFields[refFieldName]; found {
+			return fmt.Errorf(
+				"%s.%s: reference field %s is already a Spec field",
+				r.Names.Original, fieldName, refFieldName,
+			)
+		}
+		refNames := names.New(refFieldName)
+		refField := NewField(r, refNames.Camel, refNames, nil, &ackgenconfig.FieldConfig{
+			CustomField: &ackgenconfig.CustomFieldConfig{GoType: goType},
+			Documentation: &ackgenconfig.DocumentationConfig{
+				Replace: fmt.Sprintf(
+					"%s names the %s %s objects %s is read from.",
+					refNames.Camel, refs.APIVersion, refs.Kind, field.Names.Camel,
+				),
+			},
+		})
+		r.SpecFields[refFieldName] = refField
+		r.Fields[refField.Path] = refField
+	}
+	return nil
+}
+
+// HasOwnerReferenceFields returns true if the objects referenced by any of
+// the resource's Spec fields are the owners of the resource
+func (r *CRD) HasOwnerReferenceFields() bool {
+	for _, refField := range r.ReferenceFields() {
+		if refField.IsOwner() {
+			return true
+		}
+	}
+	return false
+}
+
+// DeletionPrerequisites returns the Spec fields referencing the objects that
+// must be deleted before the resource, sorted by name
+func (r *CRD) DeletionPrerequisites() []*ReferenceField {
+	refFields := []*ReferenceField{}
+	for _, refField := range r.ReferenceFields() {
+		if refField.cfg.DeleteOrder == ackgenconfig.DeleteOrderParentFirst {
+			refFields = append(refFields, refField)
+		}
+	}
+	return refFields
+}
+
+// DeletionDependents returns the Spec fields of the API's other resources
+// referencing the resources of this CRD, when the referencing resources must
+// be deleted first
+func (r *CRD) DeletionDependents() []*ReferenceField {
+	return r.deletionDependents
+}
+
+// HasDeletionOrder returns true if the resource's deletion waits for the
+// deletion of other objects
+func (r *CRD) HasDeletionOrder() bool {
+	return len(r.DeletionPrerequisites()) > 0 || len(r.DeletionDependents()) > 0
+}
+
+// linkDeletionDependents records in the supplied CRDs the Spec fields of the
+// other CRDs referencing them, when the referencing resources must be deleted
+// first
+func (m *Model) linkDeletionDependents(crds []*CRD) error {
+	for _, crd := range crds {
+		for _, refField := range crd.ReferenceFields() {
+			if refField.cfg.DeleteOrder != ackgenconfig.DeleteOrderChildrenFirst {
+				continue
+			}
+			var parent *CRD
+			for _, other := range crds {
+				if refField.Group() == other.APIGroup() && refField.Kind() == other.Kind {
+					parent = other
+					break
+				}
+			}
+			if parent == nil {
+				return fmt.Errorf(
+					"%s.%s: references delete_order %q requires %s %s to be a resource of the API",
+					crd.Names.Original, refField.Field.Names.Original,
+					ackgenconfig.DeleteOrderChildrenFirst,
+					refField.APIVersion(), refField.Kind(),
+				)
+			}
+			parent.deletionDependents = append(parent.deletionDependents, refField)
+		}
+	}
+	return nil
+}
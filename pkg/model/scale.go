@@ -0,0 +1,158 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package model
+
+import (
+	"fmt"
+
+	ackgenconfig "github.com/aws-controllers-k8s/code-generator/pkg/generate/config"
+	"github.com/aws-controllers-k8s/code-generator/pkg/names"
+)
+
+// ScaleReplicasFieldName is the name of the Status field added to mirror the
+// observed number of replicas of the resources exposing the scale
+// subresource, unless configured to be read from another Status field
+const ScaleReplicasFieldName = "Replicas"
+
+// scaleReplicasGoType is the Go type of the fields holding the number of
+// replicas of the scale subresource
+const scaleReplicasGoType = "*int64"
+
+// Scale returns the instructions to expose the scale subresource of the CRD,
+// if any
+func (r *CRD) Scale() *ackgenconfig.ScaleConfig {
+	return r.cfg.ResourceScale(r.Names.Original)
+}
+
+// ScaleSpecReplicasField returns the Spec field holding the desired number
+// of replicas of the scale subresource, or nil if the CRD has no scale
+// subresource
+func (r *CRD) ScaleSpecReplicasField() *Field {
+	scale := r.Scale()
+	if scale == nil {
+		return nil
+	}
+	return topLevelField(r.SpecFields, scale.SpecReplicasPath)
+}
+
+// ScaleStatusReplicasField returns the Status field holding the observed
+// number of replicas of the scale subresource, or nil if the CRD has no scale
+// subresource
+func (r *CRD) ScaleStatusReplicasField() *Field {
+	scale := r.Scale()
+	if scale == nil {
+		return nil
+	}
+	if scale.StatusReplicasPath == "" {
+		return r.StatusFields[ScaleReplicasFieldName]
+	}
+	return topLevelField(r.StatusFields, scale.StatusReplicasPath)
+}
+
+// MirrorsScaleReplicas returns true if the Status field holding the observed
+// number of replicas of the scale subresource is set to the value of the
+// Spec field last observed in the AWS resource
+func (r *CRD) MirrorsScaleReplicas() bool {
+	scale := r.Scale()
+	return scale != nil && scale.StatusReplicasPath == ""
+}
+
+// ScaleMarkerArgs returns the arguments of the CRD's
+// `+kubebuilder:subresource:scale` marker, e.g.
+// "specpath=.spec.desiredCount,statuspath=.status.replicas", or an empty
+// string if the CRD has no scale subresource
+func (r *CRD) ScaleMarkerArgs() string {
+	specField := r.ScaleSpecReplicasField()
+	statusField := r.ScaleStatusReplicasField()
+	if specField == nil || statusField == nil {
+		return ""
+	}
+	return fmt.Sprintf(
+		"specpath=.spec.%s,statuspath=.status.%s",
+		specField.Names.CamelLower, statusField.Names.CamelLower,
+	)
+}
+
+// addScaleFields checks the fields holding the number of replicas of the
+// scale subresource are integers, adding to the Status the field mirroring
+// the observed number of replicas unless it is read from another field
+func (r *CRD) addScaleFields() error {
+	scale := r.Scale()
+	if scale == nil {
+		return nil
+	}
+	specField := r.ScaleSpecReplicasField()
+	if specField == nil {
+		return fmt.Errorf(
+			"%s: scale spec_replicas_path %s is not a Spec field",
+			r.Names.Original, scale.SpecReplicasPath,
+		)
+	}
+	if specField.GoType != scaleReplicasGoType {
+		return fmt.Errorf(
+			"%s: scale spec_replicas_path %s must be an integer field, not %s",
+			r.Names.Original, scale.SpecReplicasPath, specField.GoType,
+		)
+	}
+	if scale.StatusReplicasPath != "" {
+		statusField := r.ScaleStatusReplicasField()
+		if statusField == nil {
+			return fmt.Errorf(
+				"%s: scale status_replicas_path %s is not a Status field",
+				r.Names.Original, scale.StatusReplicasPath,
+			)
+		}
+		if statusField.GoType != scaleReplicasGoType {
+			return fmt.Errorf(
+				"%s: scale status_replicas_path %s must be an integer field, not %s",
+				r.Names.Original, scale.StatusReplicasPath, statusField.GoType,
+			)
+		}
+		return nil
+	}
+	if _, found := r.StatusFields[ScaleReplicasFieldName]; found {
+		return fmt.Errorf(
+			"%s: scale replicas field %s is already a Status field",
+			r.Names.Original, ScaleReplicasFieldName,
+		)
+	}
+	fieldNames := names.New(ScaleReplicasFieldName)
+	field := NewField(r, fieldNames.Camel, fieldNames, nil, &ackgenconfig.FieldConfig{
+		CustomField: &ackgenconfig.CustomFieldConfig{GoType: scaleReplicasGoType},
+		Documentation: &ackgenconfig.DocumentationConfig{
+			Replace: fmt.Sprintf(
+				"%s is the number of replicas last observed in the AWS resource, mirroring its %s Spec field.",
+				fieldNames.Camel, specField.Names.Camel,
+			),
+		},
+	})
+	r.StatusFields[ScaleReplicasFieldName] = field
+	r.Fields[field.Path] = field
+	return nil
+}
+
+// topLevelField returns the field of the supplied Spec or Status fields
+// having the supplied name, or nil if there is none
+func topLevelField(fields map[string]*Field, fieldName string) *Field {
+	if field, found := fields[fieldName]; found {
+		return field
+	}
+	camel := names.New(fieldName).Camel
+	for _, field := range fields {
+		if field.Names.Camel == camel {
+			return field
+		}
+	}
+	return nil
+}
@@ -14,6 +14,8 @@
 package model
 
 import (
+	"fmt"
+	"sort"
 	"strings"
 
 	awssdkmodel "github.com/aws/aws-sdk-go/private/model/api"
@@ -35,12 +37,29 @@ type SDKAPI struct {
 	API            *awssdkmodel.API
 	APIGroupSuffix string
 	CustomShapes   []*CustomShape
+	// ShapeConstraints contains, keyed by shape name, the value constraints
+	// the API model places on its shapes
+	ShapeConstraints map[string]*ShapeConstraints
+	// APIVersion is the version of the API model, the name of its directory
+	// in the SDK, e.g. "2015-09-21"
+	APIVersion string
+	// CloudControlResources contains, keyed by resource name, the AWS Cloud
+	// Control resource types the resources are generated from, for the API
+	// models converted from resource schemas
+	CloudControlResources map[string]*CloudControlResource
+	// ResourceAPIs contains, keyed by resource name, the API models of the
+	// other versions of the service API the resources are configured to be
+	// generated from
+	ResourceAPIs map[string]*SDKAPI
 	// A map of operation type and resource name to
 	// aws-sdk-go/private/model/api.Operation structs
 	opMap *OperationMap
 	// Map, keyed by original Shape GoTypeElem(), with the values being a
 	// renamed type name (due to conflicting names)
 	typeRenames map[string]string
+	// Map, keyed by shape name, of the set of names of the shapes reachable
+	// from that shape
+	shapeReach map[string]map[string]bool
 	// Default is "services.k8s.aws"
 }
 
@@ -61,9 +80,18 @@ func (a *SDKAPI) GetOperationMap(cfg *ackgenconfig.Config) *OperationMap {
 	if a.opMap != nil {
 		return a.opMap
 	}
-	// create an index of Operations by operation types and resource name
+	// create an index of Operations by operation types and resource name.
+	// Operation IDs are visited in sorted order so that when more than one
+	// operation maps to the same operation type and resource, the same one
+	// wins on every run.
+	opIDs := make([]string, 0, len(a.API.Operations))
+	for opID := range a.API.Operations {
+		opIDs = append(opIDs, opID)
+	}
+	sort.Strings(opIDs)
 	opMap := OperationMap{}
-	for opID, op := range a.API.Operations {
+	for _, opID := range opIDs {
+		op := a.API.Operations[opID]
 		opTypeArray, resName := getOpTypeAndResourceName(opID, cfg)
 		for _, opType := range opTypeArray {
 			if _, found := opMap[opType]; !found {
@@ -72,10 +100,76 @@ func (a *SDKAPI) GetOperationMap(cfg *ackgenconfig.Config) *OperationMap {
 			opMap[opType][resName] = op
 		}
 	}
+	a.pinResourceOperations(opMap, cfg)
 	a.opMap = &opMap
 	return &opMap
 }
 
+// pinResourceOperations replaces the operations inferred from operation
+// names with the operations configured for each resource in generator
+// config. An operation pinned to a resource no longer serves any other
+// resource or operation type it was inferred for.
+func (a *SDKAPI) pinResourceOperations(
+	opMap OperationMap,
+	cfg *ackgenconfig.Config,
+) {
+	if cfg == nil {
+		return
+	}
+	resNames := []string{}
+	pinnedOpIDs := []string{}
+	for resName, resConfig := range cfg.Resources {
+		resOps := pinnedOperations(resConfig)
+		if len(resOps) == 0 {
+			continue
+		}
+		resNames = append(resNames, resName)
+		for _, opID := range resOps {
+			if _, found := a.API.Operations[opID]; !found {
+				// This is a compile-time failure, just bomb out...
+				msg := fmt.Sprintf(
+					"unknown operation %s configured for resource %s",
+					opID, resName,
+				)
+				panic(msg)
+			}
+			pinnedOpIDs = append(pinnedOpIDs, opID)
+		}
+	}
+	for _, ops := range opMap {
+		for resName, op := range ops {
+			if util.InStrings(op.Name, pinnedOpIDs) {
+				delete(ops, resName)
+			}
+		}
+	}
+	sort.Strings(resNames)
+	for _, resName := range resNames {
+		for opTypeString, opID := range pinnedOperations(cfg.Resources[resName]) {
+			opType := OpTypeFromString(opTypeString)
+			if _, found := opMap[opType]; !found {
+				opMap[opType] = map[string]*awssdkmodel.Operation{}
+			}
+			opMap[opType][resName] = a.API.Operations[opID]
+			log.V(1).Info(
+				"pinned resource operation", "resource", resName,
+				"operation", opID, "operation_type", opTypeString,
+			)
+		}
+	}
+}
+
+// pinnedOperations returns a map, keyed by operation type name, of the
+// operation IDs configured for the supplied resource, including its
+// GetAttributes and SetAttributes operations
+func pinnedOperations(resConfig ackgenconfig.ResourceConfig) map[string]string {
+	res := resConfig.Operations.ByOperationType()
+	for opType, opID := range resConfig.UnpackAttributesMapConfig.ByOperationType() {
+		res[opType] = opID
+	}
+	return res
+}
+
 // GetCustomShapeRef finds a ShapeRef for a custom shape using either its member
 // or its value shape name.
 func (a *SDKAPI) GetCustomShapeRef(shapeName string) *awssdkmodel.ShapeRef {
@@ -144,6 +238,15 @@ func (a *SDKAPI) CRDNames(cfg *ackgenconfig.Config) []names.Names {
 		}
 		crdNames = append(crdNames, names.New(crdName))
 	}
+	for crdName := range a.ResourceAPIs {
+		if _, found := createOps[crdName]; found || cfg.IsIgnoredResource(crdName) {
+			continue
+		}
+		crdNames = append(crdNames, names.New(crdName))
+	}
+	sort.Slice(crdNames, func(i, j int) bool {
+		return crdNames[i].Camel < crdNames[j].Camel
+	})
 	return crdNames
 }
 
@@ -260,6 +363,12 @@ func getOpTypeAndResourceName(opID string, cfg *ackgenconfig.Config) ([]OpType,
 			opType = OpTypeFromString(operationType)
 			opTypes = append(opTypes, opType)
 		}
+		if operationConfig.ResourceName != "" || len(operationConfig.OperationType) > 0 {
+			log.V(1).Info(
+				"applied operation config", "operation", opID,
+				"resource", resName, "operation_types", operationConfig.OperationType,
+			)
+		}
 	}
 	return opTypes, resName
 }
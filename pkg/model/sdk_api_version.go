@@ -0,0 +1,76 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package model
+
+import (
+	"sort"
+
+	ackgenconfig "github.com/aws-controllers-k8s/code-generator/pkg/generate/config"
+)
+
+// ResourceSDKAPI returns the API model the supplied resource is generated
+// from: the API model of the version of the service API the resource is
+// configured to be generated from, if any, or else this API model
+func (a *SDKAPI) ResourceSDKAPI(resName string) *SDKAPI {
+	if resAPI, found := a.ResourceAPIs[resName]; found {
+		return resAPI
+	}
+	return a
+}
+
+// ResourceOperationMap returns the OperationMap of the API model the
+// supplied resource is generated from
+func (a *SDKAPI) ResourceOperationMap(
+	resName string,
+	cfg *ackgenconfig.Config,
+) *OperationMap {
+	resAPI, found := a.ResourceAPIs[resName]
+	if !found {
+		return a.GetOperationMap(cfg)
+	}
+	if cfg == nil {
+		return resAPI.GetOperationMap(nil)
+	}
+	// The API model of another version doesn't necessarily contain the
+	// operations configured for the resources generated from other
+	// versions, so only the configuration of the resources generated from
+	// that version applies to it
+	versionCfg := *cfg
+	versionCfg.Resources = map[string]ackgenconfig.ResourceConfig{}
+	for name, api := range a.ResourceAPIs {
+		if rConfig, found := cfg.Resources[name]; found && api == resAPI {
+			versionCfg.Resources[name] = rConfig
+		}
+	}
+	return resAPI.GetOperationMap(&versionCfg)
+}
+
+// sdkAPIs returns this API model followed by the API models of the other
+// versions of the service API resources are generated from, sorted by
+// version
+func (a *SDKAPI) sdkAPIs() []*SDKAPI {
+	apis := []*SDKAPI{}
+	seen := map[*SDKAPI]bool{}
+	for _, resAPI := range a.ResourceAPIs {
+		if resAPI == a || seen[resAPI] {
+			continue
+		}
+		seen[resAPI] = true
+		apis = append(apis, resAPI)
+	}
+	sort.Slice(apis, func(i, j int) bool {
+		return apis[i].APIVersion < apis[j].APIVersion
+	})
+	return append([]*SDKAPI{a}, apis...)
+}
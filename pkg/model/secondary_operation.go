@@ -0,0 +1,86 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package model
+
+import (
+	"fmt"
+
+	awssdkmodel "github.com/aws/aws-sdk-go/private/model/api"
+
+	"github.com/aws-controllers-k8s/code-generator/pkg/names"
+)
+
+// SecondaryOp is an operation called after a resource is created, and when
+// it is updated, to configure part of the resource its CRUD operations don't
+type SecondaryOp struct {
+	*awssdkmodel.Operation
+	// Fields are the Spec fields added for the members of the operation's
+	// Input shape. A change to any of them causes the operation to be
+	// called when the resource is updated.
+	Fields []*Field
+}
+
+// addSecondaryOps adds the secondary operations configured for the supplied
+// CRD, along with Spec fields for the members of their Input shapes that
+// aren't already fields of the CRD
+func (m *Model) addSecondaryOps(crd *CRD) error {
+	resConfig, found := m.cfg.Resources[crd.Names.Original]
+	if !found {
+		return nil
+	}
+	for _, opConfig := range resConfig.SecondaryOperations {
+		op, found := crd.sdkAPI.API.Operations[opConfig.Operation]
+		if !found {
+			return fmt.Errorf(
+				"unknown secondary operation %s for resource %s",
+				opConfig.Operation, crd.Names.Original,
+			)
+		}
+		if op.InputRef.Shape == nil {
+			return ErrNilShapePointer
+		}
+		secondaryOp := &SecondaryOp{Operation: op}
+		inputShape := op.InputRef.Shape
+		for _, memberName := range inputShape.MemberNames() {
+			if crd.IsPrimaryARNField(memberName) {
+				continue
+			}
+			if inSpec, inStatus := crd.HasMember(memberName, op.Name); inSpec || inStatus {
+				// Identifiers of the resource, typically
+				continue
+			}
+			fieldName, _ := m.cfg.ResourceFieldRename(
+				crd.Names.Original, op.Name, memberName,
+			)
+			memberNames := names.New(fieldName)
+			memberNames.ModelOriginal = memberName
+			crd.AddSpecField(memberNames, inputShape.MemberRefs[memberName])
+			secondaryOp.Fields = append(
+				secondaryOp.Fields, crd.SpecFields[memberNames.Original],
+			)
+		}
+		if len(secondaryOp.Fields) == 0 {
+			return fmt.Errorf(
+				"secondary operation %s adds no fields to resource %s",
+				op.Name, crd.Names.Original,
+			)
+		}
+		log.V(1).Info(
+			"added secondary operation", "resource", crd.Names.Original,
+			"operation", op.Name, "fields", len(secondaryOp.Fields),
+		)
+		crd.SecondaryOps = append(crd.SecondaryOps, secondaryOp)
+	}
+	return nil
+}
@@ -0,0 +1,124 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package model
+
+import (
+	"fmt"
+
+	ackgenconfig "github.com/aws-controllers-k8s/code-generator/pkg/generate/config"
+	"github.com/aws-controllers-k8s/code-generator/pkg/names"
+)
+
+// splitCRD returns the CRDs the supplied CRD is split into. Each of them
+// has the Spec fields listed in its split config, the Spec fields no split
+// lists and all the Status fields of the supplied CRD.
+func (m *Model) splitCRD(
+	crd *CRD,
+	splits []*ackgenconfig.SplitConfig,
+) ([]*CRD, error) {
+	if crd.UnpacksAttributesMap() {
+		return nil, fmt.Errorf(
+			"resource %s unpacks an attributes map and can't be split",
+			crd.Names.Original,
+		)
+	}
+	owners := map[string]string{}
+	for _, split := range splits {
+		for _, fieldName := range split.Fields {
+			if _, found := crd.SpecFields[fieldName]; !found {
+				return nil, fmt.Errorf(
+					"unknown field %s in split %s of resource %s",
+					fieldName, split.Name, crd.Names.Original,
+				)
+			}
+			if owner, found := owners[fieldName]; found {
+				return nil, fmt.Errorf(
+					"field %s of resource %s is in both splits %s and %s",
+					fieldName, crd.Names.Original, owner, split.Name,
+				)
+			}
+			owners[fieldName] = split.Name
+		}
+	}
+
+	res := []*CRD{}
+	for _, split := range splits {
+		splitCRD := NewCRD(m.SDKAPI, m.cfg, names.New(split.Name), crd.Ops)
+		splitCRD.SplitFrom = crd
+		for _, fieldName := range crd.SpecFieldNames() {
+			if owner, found := owners[fieldName]; found && owner != split.Name {
+				continue
+			}
+			field := crd.SpecFields[fieldName]
+			splitCRD.AddSpecField(field.Names, field.ShapeRef)
+		}
+		for _, field := range crd.StatusFields {
+			splitCRD.AddStatusField(field.Names, field.ShapeRef)
+		}
+		for _, secondaryOp := range crd.SecondaryOps {
+			owner, err := secondaryOpOwner(crd, secondaryOp, owners)
+			if err != nil {
+				return nil, err
+			}
+			if owner != "" && owner != split.Name {
+				continue
+			}
+			splitOp := &SecondaryOp{Operation: secondaryOp.Operation}
+			for _, field := range secondaryOp.Fields {
+				splitOp.Fields = append(
+					splitOp.Fields, splitCRD.SpecFields[field.Names.Original],
+				)
+			}
+			splitCRD.SecondaryOps = append(splitCRD.SecondaryOps, splitOp)
+		}
+		for _, updateOp := range crd.UpdateOps {
+			// Update operations update the fields the split has
+			splitOp := &UpdateOp{Operation: updateOp.Operation}
+			for _, field := range updateOp.Fields {
+				if splitField, found := splitCRD.SpecFields[field.Names.Original]; found {
+					splitOp.Fields = append(splitOp.Fields, splitField)
+				}
+			}
+			if len(splitOp.Fields) > 0 {
+				splitCRD.UpdateOps = append(splitCRD.UpdateOps, splitOp)
+			}
+		}
+		log.V(1).Info(
+			"split resource", "resource", crd.Names.Original,
+			"split", split.Name, "fields", len(splitCRD.SpecFields),
+		)
+		res = append(res, splitCRD)
+	}
+	return res, nil
+}
+
+// secondaryOpOwner returns the name of the split owning the fields of the
+// supplied secondary operation, which is empty when the fields are kept by
+// every split
+func secondaryOpOwner(
+	crd *CRD,
+	secondaryOp *SecondaryOp,
+	owners map[string]string,
+) (string, error) {
+	owner := owners[secondaryOp.Fields[0].Names.Original]
+	for _, field := range secondaryOp.Fields[1:] {
+		if owners[field.Names.Original] != owner {
+			return "", fmt.Errorf(
+				"fields of secondary operation %s of resource %s are in different splits",
+				secondaryOp.Name, crd.Names.Original,
+			)
+		}
+	}
+	return owner, nil
+}
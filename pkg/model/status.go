@@ -0,0 +1,68 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package model
+
+import (
+	"fmt"
+
+	"github.com/aws-controllers-k8s/code-generator/pkg/names"
+)
+
+// HasStatusSubresource returns true if the CRD has the status subresource
+func (r *CRD) HasStatusSubresource() bool {
+	return r.cfg.ResourceStatus(r.Names.Original).HasSubresource()
+}
+
+// IsPrunedStatusField returns true if the Status field having the supplied
+// name is removed from the CRD schema, while remaining in the Go types
+func (r *CRD) IsPrunedStatusField(fieldName string) bool {
+	status := r.cfg.ResourceStatus(r.Names.Original)
+	if status == nil {
+		return false
+	}
+	camel := names.New(fieldName).Camel
+	for _, pruned := range status.PruneFields {
+		if pruned == fieldName || names.New(pruned).Camel == camel {
+			return true
+		}
+	}
+	return false
+}
+
+// checkPrunedStatusFields returns an error if a pruned Status field isn't a
+// Status field of the resource, or holds the replicas of its scale
+// subresource
+func (r *CRD) checkPrunedStatusFields() error {
+	status := r.cfg.ResourceStatus(r.Names.Original)
+	if status == nil {
+		return nil
+	}
+	replicasField := r.ScaleStatusReplicasField()
+	for _, fieldName := range status.PruneFields {
+		field := topLevelField(r.StatusFields, fieldName)
+		if field == nil {
+			return fmt.Errorf(
+				"%s: pruned Status field %s is not a Status field",
+				r.Names.Original, fieldName,
+			)
+		}
+		if field == replicasField {
+			return fmt.Errorf(
+				"%s: pruned Status field %s holds the replicas of the scale subresource",
+				r.Names.Original, fieldName,
+			)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,156 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package model
+
+import (
+	"fmt"
+	"strings"
+
+	awssdkmodel "github.com/aws/aws-sdk-go/private/model/api"
+
+	ackgenconfig "github.com/aws-controllers-k8s/code-generator/pkg/generate/config"
+	"github.com/aws-controllers-k8s/code-generator/pkg/names"
+)
+
+const (
+	// TagFieldName is the original name of the top-level fields holding the
+	// tags of a resource
+	TagFieldName = "Tags"
+	// TagTypeName is the name of the struct type of the tags when tags are
+	// represented as a list
+	TagTypeName = "Tag"
+)
+
+// TagFormat returns the format the tags held by the field are converted to,
+// or the empty string if the field isn't a top-level Tags field or its tags
+// are already represented in the configured format.
+func (f *Field) TagFormat() string {
+	if f.ShapeRef == nil || !isTopLevelTagField(f.Path, f.Names) {
+		return ""
+	}
+	return tagFormat(f.CRD.cfg, f.ShapeRef.Shape)
+}
+
+// isTopLevelTagField returns true if the field with the supplied path and
+// names is the top-level field holding the tags of a resource
+func isTopLevelTagField(path string, fieldNames names.Names) bool {
+	return fieldNames.Original == TagFieldName && !strings.Contains(path, ".")
+}
+
+// tagFormat returns the tag format configured in the generator config if
+// the supplied shape holds tags in the other format, or the empty string
+// otherwise
+func tagFormat(cfg *ackgenconfig.Config, shape *awssdkmodel.Shape) string {
+	if cfg == nil {
+		return ""
+	}
+	switch cfg.TagFormat {
+	case ackgenconfig.TagFormatMap:
+		if isTagListShape(shape) {
+			return cfg.TagFormat
+		}
+	case ackgenconfig.TagFormatList:
+		if isTagMapShape(shape) {
+			return cfg.TagFormat
+		}
+	}
+	return ""
+}
+
+// tagGoType returns the Go type, and the Go type of the elements, of tags
+// represented in the supplied format
+func tagGoType(format string) (string, string) {
+	if format == ackgenconfig.TagFormatList {
+		return TagTypeName, "[]*" + TagTypeName
+	}
+	return "string", "map[string]*string"
+}
+
+// isTagMapShape returns true if the supplied shape is a map of strings keyed
+// by strings
+func isTagMapShape(shape *awssdkmodel.Shape) bool {
+	return shape != nil && shape.Type == "map" &&
+		shape.KeyRef.Shape != nil && shape.KeyRef.Shape.Type == "string" &&
+		shape.ValueRef.Shape != nil && shape.ValueRef.Shape.Type == "string"
+}
+
+// isTagListShape returns true if the supplied shape is a list of structs
+// having exactly two string members, Key and Value
+func isTagListShape(shape *awssdkmodel.Shape) bool {
+	if shape == nil || shape.Type != "list" || shape.MemberRef.Shape == nil {
+		return false
+	}
+	elemShape := shape.MemberRef.Shape
+	if elemShape.Type != "structure" || len(elemShape.MemberRefs) != 2 {
+		return false
+	}
+	for _, memberName := range []string{"Key", "Value"} {
+		memberRef, found := elemShape.MemberRefs[memberName]
+		if !found || memberRef.Shape == nil || memberRef.Shape.Type != "string" {
+			return false
+		}
+	}
+	return true
+}
+
+// tagTypeDef returns the type definition of the Tag struct when tags are
+// represented as a list but the AWS API model doesn't define a suitable Tag
+// structure itself, or nil if no type definition is needed
+func (m *Model) tagTypeDef(tdefs []*TypeDef) (*TypeDef, error) {
+	if m.cfg == nil || m.cfg.TagFormat != ackgenconfig.TagFormatList {
+		return nil, nil
+	}
+	crds, _ := m.GetCRDs()
+	needed := false
+	for _, crd := range crds {
+		for _, f := range crd.Fields {
+			if f.TagFormat() != "" {
+				needed = true
+			}
+		}
+	}
+	if !needed {
+		return nil, nil
+	}
+	for _, tdef := range tdefs {
+		if tdef.Names.Camel != TagTypeName {
+			continue
+		}
+		if tdef.Attrs["Key"] == nil || tdef.Attrs["Value"] == nil {
+			return nil, fmt.Errorf(
+				"cannot represent tags as a list: the %s type of the API "+
+					"doesn't have a Key and a Value", TagTypeName,
+			)
+		}
+		return nil, nil
+	}
+	stringShape := &awssdkmodel.Shape{ShapeName: "String", Type: "string"}
+	shape := &awssdkmodel.Shape{
+		ShapeName:     TagTypeName,
+		Type:          "structure",
+		Documentation: "// " + TagTypeName + " is a key-value pair attached to an AWS resource",
+		MemberRefs: map[string]*awssdkmodel.ShapeRef{
+			"Key":   {ShapeName: stringShape.ShapeName, Shape: stringShape},
+			"Value": {ShapeName: stringShape.ShapeName, Shape: stringShape},
+		},
+	}
+	return &TypeDef{
+		Names: names.New(TagTypeName),
+		Shape: shape,
+		Attrs: map[string]*Attr{
+			"Key":   NewAttr(names.New("Key"), "*string", stringShape),
+			"Value": NewAttr(names.New("Value"), "*string", stringShape),
+		},
+	}, nil
+}
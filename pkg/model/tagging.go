@@ -0,0 +1,162 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package model
+
+import (
+	"strings"
+
+	awssdkmodel "github.com/aws/aws-sdk-go/private/model/api"
+)
+
+var (
+	// tagOpNames are the names an AWS API may give the operation that adds
+	// tags to a resource
+	tagOpNames = []string{"TagResource"}
+	// untagOpNames are the names an AWS API may give the operation that
+	// removes tags from a resource
+	untagOpNames = []string{"UntagResource"}
+	// listTagsOpNames are the names an AWS API may give the operation that
+	// lists the tags of a resource
+	listTagsOpNames = []string{"ListTagsForResource", "ListTags"}
+)
+
+// TaggingOps contains the operations an AWS API offers to manage the tags of
+// any of its resources, identified by their ARN
+type TaggingOps struct {
+	// Tag adds tags to a resource, replacing the value of existing tags
+	Tag *TaggingOp
+	// Untag removes tags from a resource
+	Untag *TaggingOp
+	// List lists the tags of a resource
+	List *TaggingOp
+}
+
+// TaggingOp is one of the tagging operations of an AWS API
+type TaggingOp struct {
+	*awssdkmodel.Operation
+	// ARNMemberName is the name of the input shape's member holding the ARN
+	// of the resource
+	ARNMemberName string
+	// TagsMemberName is the name of the member holding the tags. For the
+	// Untag operation, it is the input shape's member holding the keys of
+	// the tags to remove. For the List operation, it is a member of the
+	// output shape.
+	TagsMemberName string
+	// TagsShapeRef is the ShapeRef of the member holding the tags
+	TagsShapeRef *awssdkmodel.ShapeRef
+}
+
+// GetTaggingOps returns the tagging operations of the AWS API, or nil if the
+// API doesn't have all of the operations needed to manage tags or they don't
+// have the expected shapes
+func (a *SDKAPI) GetTaggingOps() *TaggingOps {
+	tag := a.findTaggingOp(tagOpNames, false, isTagShape)
+	untag := a.findTaggingOp(untagOpNames, false, isStringListShape)
+	list := a.findTaggingOp(listTagsOpNames, true, isTagShape)
+	if tag == nil || untag == nil || list == nil {
+		return nil
+	}
+	return &TaggingOps{
+		Tag:   tag,
+		Untag: untag,
+		List:  list,
+	}
+}
+
+// findTaggingOp returns the first operation with one of the supplied names
+// whose input shape has an ARN member and whose input, or output if
+// tagsInOutput is true, shape has a member holding the tags
+func (a *SDKAPI) findTaggingOp(
+	opNames []string,
+	tagsInOutput bool,
+	isTagsShape func(*awssdkmodel.Shape) bool,
+) *TaggingOp {
+	for _, opName := range opNames {
+		op, found := a.API.Operations[opName]
+		if !found || op.InputRef.Shape == nil {
+			continue
+		}
+		arnMemberName := ""
+		for _, memberName := range op.InputRef.Shape.MemberNames() {
+			memberRef := op.InputRef.Shape.MemberRefs[memberName]
+			if isARNMember(memberName, memberRef) {
+				arnMemberName = memberName
+				break
+			}
+		}
+		tagsShape := op.InputRef.Shape
+		if tagsInOutput {
+			tagsShape = op.OutputRef.Shape
+		}
+		if arnMemberName == "" || tagsShape == nil {
+			continue
+		}
+		for _, memberName := range tagsShape.MemberNames() {
+			memberRef := tagsShape.MemberRefs[memberName]
+			if memberRef.Shape != nil && isTagsShape(memberRef.Shape) {
+				return &TaggingOp{
+					Operation:      op,
+					ARNMemberName:  arnMemberName,
+					TagsMemberName: memberName,
+					TagsShapeRef:   memberRef,
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// isARNMember returns true if the supplied member holds an ARN, which it does
+// when it is a string whose member or shape name ends in "Arn"
+func isARNMember(memberName string, memberRef *awssdkmodel.ShapeRef) bool {
+	if memberRef.Shape == nil || memberRef.Shape.Type != "string" {
+		return false
+	}
+	return strings.HasSuffix(strings.ToLower(memberName), "arn") ||
+		strings.HasSuffix(strings.ToLower(memberRef.Shape.ShapeName), "arn")
+}
+
+// isTagShape returns true if the supplied shape holds tags, either as a map
+// or as a list of Key/Value structs
+func isTagShape(shape *awssdkmodel.Shape) bool {
+	return isTagMapShape(shape) || isTagListShape(shape)
+}
+
+// isStringListShape returns true if the supplied shape is a list of strings
+func isStringListShape(shape *awssdkmodel.Shape) bool {
+	return shape.Type == "list" && shape.MemberRef.Shape != nil &&
+		shape.MemberRef.Shape.Type == "string"
+}
+
+// TaggingOps returns the tagging operations used to keep the tags of the
+// resource in sync with its Spec.Tags field, or nil if the tags of the
+// resource aren't synced. Tags are synced when the generator config enables
+// it, the resource has a top-level Tags field in its Spec and the AWS API has
+// all the tagging operations.
+func (r *CRD) TaggingOps() *TaggingOps {
+	if r.cfg == nil || !r.cfg.SyncTags {
+		return nil
+	}
+	if _, found := r.SpecFields[TagFieldName]; !found {
+		return nil
+	}
+	return r.sdkAPI.GetTaggingOps()
+}
+
+// TagsAreMap returns true if the Spec.Tags field of the resource holds its
+// tags in a map, false if it holds them in a list of Tag structs
+func (r *CRD) TagsAreMap() bool {
+	f, found := r.SpecFields[TagFieldName]
+	return found && strings.HasPrefix(f.GoType, "map[")
+}
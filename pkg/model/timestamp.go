@@ -0,0 +1,51 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package model
+
+import (
+	ackgenconfig "github.com/aws-controllers-k8s/code-generator/pkg/generate/config"
+)
+
+// TimestampFormat returns how the value of the field is represented if the
+// field is a timestamp. The format configured for the field takes precedence
+// over the format configured for the whole API.
+func (f *Field) TimestampFormat() string {
+	return timestampFormat(f.CRD.cfg, f.FieldConfig)
+}
+
+// timestampFormat returns the format of timestamp values given the generator
+// config and the config of the field holding the value, which may be nil
+func timestampFormat(
+	cfg *ackgenconfig.Config,
+	fieldCfg *ackgenconfig.FieldConfig,
+) string {
+	if fieldCfg != nil && fieldCfg.TimestampFormat != "" {
+		return fieldCfg.TimestampFormat
+	}
+	return cfg.GetTimestampFormat()
+}
+
+// timestampGoType returns the Go type, without the pointer, of timestamp
+// values in the supplied format
+func timestampGoType(format string) string {
+	switch format {
+	case ackgenconfig.TimestampFormatRFC3339:
+		return "string"
+	case ackgenconfig.TimestampFormatEpoch:
+		return "int64"
+	}
+	// time.Time needs to be converted to apimachinery/metav1.Time otherwise
+	// there is no DeepCopy support
+	return "metav1.Time"
+}
@@ -39,7 +39,10 @@ func CleanGoType(
 	gte := shape.GoTypeElem()
 	gtwp := shape.GoTypeWithPkgName()
 	// Normalize the type names for structs and list elements
-	if shape.Type == "structure" {
+	if api.IsDocument(shape) {
+		// Documents are free-form JSON, stored as is in the CR
+		return "RawExtension", RawExtensionGoType, RawExtensionGoType
+	} else if shape.Type == "structure" {
 		cleanNames := names.New(gte)
 		gte = cleanNames.Camel
 		if api.HasConflictingTypeName(gte, cfg) {
@@ -59,16 +62,42 @@ func CleanGoType(
 		gt = "[]" + mgt
 		gtwp = "[]" + mgtwp
 	} else if shape.Type == "timestamp" {
-		// time.Time needs to be converted to apimachinery/metav1.Time
-		// otherwise there is no DeepCopy support
-		gtwp = "*metav1.Time"
-		gte = "metav1.Time"
-		gt = "*metav1.Time"
+		gte = timestampGoType(timestampFormat(cfg, fieldCfg))
+		gt = "*" + gte
+		gtwp = gt
+	} else if nf := numberFormat(shape, fieldCfg); nf != "" {
+		gte = numberGoType(nf)
+		gt = "*" + gte
+		return gte, gt, gt
+	} else if fieldCfg != nil && fieldCfg.IsSecret && shape.Type == "map" {
+		// Maps of secrets reference a Secret per key
+		gte = "SecretKeyReference"
+		gt = "map[string]*ackv1alpha1.SecretKeyReference"
+		return gte, gt, gt
 	} else if fieldCfg != nil && fieldCfg.IsSecret {
 		gt = "*ackv1alpha1.SecretKeyReference"
 		gte = "SecretKeyReference"
 		gtwp = "*ackv1alpha1.SecretKeyReference"
 		return gte, gt, gtwp
+	} else if IsTypedEnum(cfg, shape) {
+		gte = api.GetEnumTypeName(shape.ShapeName, cfg)
+		gt = "*" + gte
+		gtwp = gt
+	} else if shape.Type == "map" && api.IsDocument(shape.ValueRef.Shape) {
+		gte = "RawExtension"
+		gt = "map[string]" + RawExtensionGoType
+		gtwp = gt
+	} else if shape.Type == "map" && IsTypedEnum(cfg, shape.ValueRef.Shape) {
+		vgte, vgt, _ := CleanGoType(api, cfg, shape.ValueRef.Shape, fieldCfg)
+		gte = vgte
+		gt = "map[string]" + vgt
+		gtwp = gt
+	} else if shape.Type == "map" && shape.ValueRef.Shape != nil &&
+		(shape.ValueRef.Shape.Type == "list" || shape.ValueRef.Shape.Type == "map") {
+		// Maps of lists or maps, e.g. `map[string][]*Rule`, need the cleaned
+		// up names of their nested element types
+		_, vgt, vgtwp := CleanGoType(api, cfg, shape.ValueRef.Shape, fieldCfg)
+		return gte, "map[string]" + vgt, "map[string]" + vgtwp
 	}
 
 	// Replace the type part of the full type-with-package-name with the
@@ -0,0 +1,81 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package model
+
+import (
+	"fmt"
+
+	awssdkmodel "github.com/aws/aws-sdk-go/private/model/api"
+)
+
+// UpdateOp is one of the operations updating a group of a resource's fields
+type UpdateOp struct {
+	*awssdkmodel.Operation
+	// Fields are the Spec fields the operation updates. The operation is
+	// called when any of them differs between the desired and latest states
+	// of the resource.
+	Fields []*Field
+}
+
+// addUpdateOps adds the operations configured to update groups of the
+// supplied CRD's Spec fields
+func (m *Model) addUpdateOps(crd *CRD) error {
+	resConfig, found := m.cfg.Resources[crd.Names.Original]
+	if !found || resConfig.UpdateOperation == nil {
+		return nil
+	}
+	for _, opConfig := range resConfig.UpdateOperation.Operations {
+		op, found := crd.sdkAPI.API.Operations[opConfig.Operation]
+		if !found {
+			return fmt.Errorf(
+				"unknown update operation %s for resource %s",
+				opConfig.Operation, crd.Names.Original,
+			)
+		}
+		if op.InputRef.Shape == nil {
+			return ErrNilShapePointer
+		}
+		for _, secondaryOp := range crd.SecondaryOps {
+			if secondaryOp.Name == op.Name {
+				return fmt.Errorf(
+					"operation %s is both an update and a secondary operation of resource %s",
+					op.Name, crd.Names.Original,
+				)
+			}
+		}
+		if len(opConfig.Fields) == 0 {
+			return fmt.Errorf(
+				"update operation %s of resource %s has no fields",
+				op.Name, crd.Names.Original,
+			)
+		}
+		updateOp := &UpdateOp{Operation: op}
+		for _, fieldName := range opConfig.Fields {
+			field, found := crd.SpecFields[fieldName]
+			if !found {
+				return fmt.Errorf(
+					"unknown field %s for update operation %s of resource %s",
+					fieldName, op.Name, crd.Names.Original,
+				)
+			}
+			updateOp.Fields = append(updateOp.Fields, field)
+		}
+		log.V(1).Info(
+			"added update operation", "resource", crd.Names.Original,
+			"operation", op.Name, "fields", opConfig.Fields,
+		)
+		crd.UpdateOps = append(crd.UpdateOps, updateOp)
+	}
+	return nil
+}
@@ -0,0 +1,363 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package model
+
+import (
+	"fmt"
+	"math"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	awssdkmodel "github.com/aws/aws-sdk-go/private/model/api"
+
+	"github.com/aws-controllers-k8s/code-generator/pkg/names"
+)
+
+// maxExactFloat is the largest integer that a float64 represents exactly.
+// Constraint values beyond it (e.g. the maximum of a long) are not emitted
+// since they would be rounded.
+const maxExactFloat = 1 << 53
+
+// enumValueRegex matches enum values that can be used unquoted in a
+// kubebuilder marker
+var enumValueRegex = regexp.MustCompile(`^[A-Za-z0-9_.\-]+$`)
+
+// ShapeConstraints contains the value constraints and default value an API
+// model places on a shape, and whether a structure shape is a union or a
+// document. The aws-sdk-go model loader only keeps the minimum
+// of a shape, so these are read from the API model file.
+type ShapeConstraints struct {
+	// Min is the minimum length of a string, number of items of a list or
+	// map, or value of a number
+	Min *float64 `json:"min,omitempty"`
+	// Max is the maximum length of a string, number of items of a list or
+	// map, or value of a number
+	Max *float64 `json:"max,omitempty"`
+	// Pattern is the regular expression string values must match
+	Pattern string `json:"pattern,omitempty"`
+	// Default is the value the service uses when none is supplied
+	Default interface{} `json:"default,omitempty"`
+	// Union is true for structure shapes of which exactly one member must be
+	// set, also known as tagged unions
+	Union bool `json:"union,omitempty"`
+	// Document is true for shapes holding free-form JSON documents
+	Document bool `json:"document,omitempty"`
+}
+
+// GetShapeConstraints returns the constraints the API model places on the
+// supplied shape, or nil if there are none
+func (a *SDKAPI) GetShapeConstraints(shape *awssdkmodel.Shape) *ShapeConstraints {
+	if shape == nil {
+		return nil
+	}
+	if c, found := a.ShapeConstraints[shape.ShapeName]; found {
+		return c
+	}
+	if shape.Min > 0 {
+		// Models loaded without reading the model file still have the
+		// minimum
+		min := shape.Min
+		return &ShapeConstraints{Min: &min}
+	}
+	return nil
+}
+
+// ValidationMarkers returns the kubebuilder validation markers, without the
+// leading `// `, that enforce the supplied shape's constraints in the CRD
+// schema. For example, for a string shape with a length between 1 and 64
+// characters:
+//
+//	+kubebuilder:validation:MinLength=1
+//	+kubebuilder:validation:MaxLength=64
+func (a *SDKAPI) ValidationMarkers(shape *awssdkmodel.Shape) []string {
+	if shape == nil {
+		return nil
+	}
+	markers := []string{}
+	c := a.GetShapeConstraints(shape)
+	var minMarker, maxMarker string
+	switch shape.Type {
+	case "string":
+		minMarker, maxMarker = "MinLength", "MaxLength"
+	case "list":
+		minMarker, maxMarker = "MinItems", "MaxItems"
+	case "map":
+		minMarker, maxMarker = "MinProperties", "MaxProperties"
+	case "integer", "long", "float", "double":
+		minMarker, maxMarker = "Minimum", "Maximum"
+	}
+	if c != nil && minMarker != "" {
+		if value, ok := constraintValue(c.Min, shape.Type); ok {
+			markers = append(markers, fmt.Sprintf("+kubebuilder:validation:%s=%s", minMarker, value))
+		}
+		if value, ok := constraintValue(c.Max, shape.Type); ok {
+			markers = append(markers, fmt.Sprintf("+kubebuilder:validation:%s=%s", maxMarker, value))
+		}
+	}
+	if shape.Type == "structure" && c != nil && c.Document {
+		markers = append(markers, preserveUnknownFieldsMarker)
+	}
+	if shape.Type == "structure" && c != nil && c.Union {
+		markers = append(markers, unionMarker(shape))
+	}
+	if shape.Type != "string" {
+		return markers
+	}
+	if shape.IsEnum() {
+		values := make([]string, 0, len(shape.Enum))
+		for _, value := range shape.Enum {
+			if !enumValueRegex.MatchString(value) {
+				value = strconv.Quote(value)
+			}
+			values = append(values, value)
+		}
+		markers = append(markers, "+kubebuilder:validation:Enum="+strings.Join(values, ";"))
+	} else if c != nil && validPattern(c.Pattern) {
+		markers = append(markers, fmt.Sprintf("+kubebuilder:validation:Pattern=`%s`", c.Pattern))
+	}
+	return markers
+}
+
+// IsUnion returns true if the supplied shape is a structure shape of which
+// exactly one member must be set
+func (a *SDKAPI) IsUnion(shape *awssdkmodel.Shape) bool {
+	if shape == nil || shape.Type != "structure" {
+		return false
+	}
+	c := a.GetShapeConstraints(shape)
+	return c != nil && c.Union
+}
+
+// unionMarker returns the CEL validation rule marker that requires exactly one
+// member of the supplied union shape to be set. For a union with the members
+// `S3Source` and `HTTPSource`:
+//
+//	+kubebuilder:validation:XValidation:rule="[has(self.httpSource), has(self.s3Source)].exists_one(x, x)",message="exactly one of httpSource, s3Source must be set"
+func unionMarker(shape *awssdkmodel.Shape) string {
+	jsonNames := make([]string, 0, len(shape.MemberRefs))
+	for memberName := range shape.MemberRefs {
+		jsonNames = append(jsonNames, names.New(memberName).CamelLower)
+	}
+	sort.Strings(jsonNames)
+	tests := make([]string, 0, len(jsonNames))
+	for _, jsonName := range jsonNames {
+		tests = append(tests, "has(self."+jsonName+")")
+	}
+	return fmt.Sprintf(
+		"+kubebuilder:validation:XValidation:rule=%q,message=%q",
+		"["+strings.Join(tests, ", ")+"].exists_one(x, x)",
+		"exactly one of "+strings.Join(jsonNames, ", ")+" must be set",
+	)
+}
+
+// constraintValue returns the string representation of a min or max
+// constraint and whether it should be emitted at all. Length and size
+// constraints can't be negative and are always integers.
+func constraintValue(value *float64, shapeType string) (string, bool) {
+	if value == nil || math.Abs(*value) > maxExactFloat {
+		return "", false
+	}
+	switch shapeType {
+	case "float", "double":
+		return strconv.FormatFloat(*value, 'f', -1, 64), true
+	case "integer", "long":
+		return strconv.FormatInt(int64(*value), 10), true
+	}
+	if *value < 0 {
+		return "", false
+	}
+	return strconv.FormatInt(int64(*value), 10), true
+}
+
+// validPattern returns true if the supplied API model pattern can be used in
+// a CRD schema. Many API model patterns are written for Java's regular
+// expression engine and use constructs that the Kubernetes API server can't
+// compile, and a pattern containing a backtick can't be quoted in a marker.
+func validPattern(pattern string) bool {
+	if pattern == "" || strings.Contains(pattern, "`") {
+		return false
+	}
+	_, err := regexp.Compile(pattern)
+	return err == nil
+}
+
+// ValidationMarkers returns the kubebuilder validation markers for the
+// field's shape constraints. Fields whose Go type is overridden by the
+// generator config, such as secrets, have no shape constraint markers. The
+// immutability of fields is validated on the Spec, see
+// CRD.SpecValidationMarkers.
+func (f *Field) ValidationMarkers() []string {
+	markers := []string{}
+	// The constraints of the shape of converted tags don't apply to the
+	// field's type
+	if f.ShapeRef != nil && (f.FieldConfig == nil || !f.FieldConfig.IsSecret) &&
+		f.TagFormat() == "" {
+		markers = append(markers, f.CRD.sdkAPI.ValidationMarkers(f.ShapeRef.Shape)...)
+	}
+	return markers
+}
+
+// SpecValidationMarkers returns the CEL validation rule markers of the
+// resource's Spec that prevent the fields, top-level or nested, configured as
+// immutable from being changed once set. The rules are transition rules,
+// which the Kubernetes API server evaluates on updates from version 1.25
+// onwards.
+//
+// A field is set when every field along its path is present. An unset field
+// may be set, whereas a set field may be neither changed nor unset, including
+// by removing any of its parents. The rules are on the Spec rather than on
+// the top-level fields because the API server skips the transition rules of
+// a field missing from either the old or the new object, which would allow
+// unsetting a field by removing its top-level parent.
+//
+// For the immutable field path `Config.Engine`, the Spec gets the marker:
+//
+//	+kubebuilder:validation:XValidation:rule="!has(oldSelf.config) || !has(oldSelf.config.engine) || (has(self.config) && has(self.config.engine) && self.config.engine == oldSelf.config.engine)",message="config.engine is immutable once set"
+func (r *CRD) SpecValidationMarkers() []string {
+	markers := []string{}
+	for _, immutablePath := range r.GetImmutableFieldPaths() {
+		parts := strings.Split(immutablePath, ".")
+		var field *Field
+		for _, specField := range r.SpecFields {
+			if names.New(parts[0]).Camel == specField.Names.Camel {
+				field = specField
+				break
+			}
+		}
+		if field == nil {
+			continue
+		}
+		jsonPath := []string{field.Names.CamelLower}
+		for _, part := range parts[1:] {
+			jsonPath = append(jsonPath, names.New(part).CamelLower)
+		}
+		fieldPath := strings.Join(jsonPath, ".")
+
+		// The field is unset in the old object, or else set to the same
+		// value in the new object
+		unset := []string{}
+		for _, test := range celHasPath("oldSelf", jsonPath) {
+			unset = append(unset, "!"+test)
+		}
+		unchanged := append(
+			celHasPath("self", jsonPath),
+			fmt.Sprintf("self.%s == oldSelf.%s", fieldPath, fieldPath),
+		)
+		rule := fmt.Sprintf(
+			"%s || (%s)",
+			strings.Join(unset, " || "), strings.Join(unchanged, " && "),
+		)
+		markers = append(markers, fmt.Sprintf(
+			"+kubebuilder:validation:XValidation:rule=%q,message=%q",
+			rule, fieldPath+" is immutable once set",
+		))
+	}
+	return markers
+}
+
+// celHasPath returns the CEL expressions testing the presence of every field
+// along the supplied path in the supplied variable. has() fails on a missing
+// parent field, so each parent is tested before its children.
+func celHasPath(varName string, jsonPath []string) []string {
+	tests := []string{}
+	for x := range jsonPath {
+		tests = append(tests, fmt.Sprintf(
+			"has(%s.%s)", varName, strings.Join(jsonPath[:x+1], "."),
+		))
+	}
+	return tests
+}
+
+// GetDefaultValue returns the default value of the field, or nil if it has
+// none. A default value in the field's config takes precedence over the
+// default value of the field's shape.
+func (f *Field) GetDefaultValue() interface{} {
+	if f.FieldConfig != nil && f.FieldConfig.Default != nil {
+		return f.FieldConfig.Default
+	}
+	if f.ShapeRef == nil || (f.FieldConfig != nil && f.FieldConfig.IsSecret) {
+		return nil
+	}
+	if c := f.CRD.sdkAPI.GetShapeConstraints(f.ShapeRef.Shape); c != nil {
+		return c.Default
+	}
+	return nil
+}
+
+// DefaultMarker returns the kubebuilder marker, without the leading `// `,
+// that sets the default value of the field in the CRD schema, or the empty
+// string if the field has no default value.
+//
+//	+kubebuilder:default="MUTABLE"
+func (f *Field) DefaultMarker() string {
+	value := f.GetDefaultValue()
+	if value == nil {
+		return ""
+	}
+	literal, err := defaultLiteral(value)
+	if err != nil {
+		msg := fmt.Sprintf(
+			"GENERATION FAILURE! Unable to generate a default value for the field %s: %v",
+			f.Path, err,
+		)
+		panic(msg)
+	}
+	return "+kubebuilder:default=" + literal
+}
+
+// defaultLiteral returns the representation of a default value in a
+// kubebuilder marker. Lists are written as `{a,b}` and maps as `{a: b}`.
+func defaultLiteral(value interface{}) (string, error) {
+	switch v := value.(type) {
+	case string:
+		return strconv.Quote(v), nil
+	case bool:
+		return strconv.FormatBool(v), nil
+	case float64:
+		if v == math.Trunc(v) && math.Abs(v) <= maxExactFloat {
+			return strconv.FormatInt(int64(v), 10), nil
+		}
+		return strconv.FormatFloat(v, 'f', -1, 64), nil
+	case int:
+		return strconv.Itoa(v), nil
+	case []interface{}:
+		items := make([]string, 0, len(v))
+		for _, item := range v {
+			literal, err := defaultLiteral(item)
+			if err != nil {
+				return "", err
+			}
+			items = append(items, literal)
+		}
+		return "{" + strings.Join(items, ",") + "}", nil
+	case map[string]interface{}:
+		keys := make([]string, 0, len(v))
+		for key := range v {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		items := make([]string, 0, len(v))
+		for _, key := range keys {
+			literal, err := defaultLiteral(v[key])
+			if err != nil {
+				return "", err
+			}
+			items = append(items, strconv.Quote(key)+": "+literal)
+		}
+		return "{" + strings.Join(items, ",") + "}", nil
+	}
+	return "", fmt.Errorf("unsupported default value type %T", value)
+}
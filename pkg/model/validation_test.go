@@ -0,0 +1,175 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package model_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/aws-controllers-k8s/code-generator/pkg/model"
+	"github.com/aws-controllers-k8s/code-generator/pkg/testutil"
+)
+
+func TestValidationMarkers_ECRRepository(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	g := testutil.NewModelForService(t, "ecr")
+
+	crds, err := g.GetCRDs()
+	require.Nil(err)
+
+	crd := getCRDByName("Repository", crds)
+	require.NotNil(crd)
+
+	assert.Equal(
+		[]string{
+			"+kubebuilder:validation:MinLength=2",
+			"+kubebuilder:validation:MaxLength=256",
+			"+kubebuilder:validation:Pattern=`(?:[a-z0-9]+(?:[._-][a-z0-9]+)*/)*[a-z0-9]+(?:[._-][a-z0-9]+)*`",
+		},
+		crd.SpecFields["RepositoryName"].ValidationMarkers(),
+	)
+	assert.Equal(
+		[]string{"+kubebuilder:validation:Enum=MUTABLE;IMMUTABLE"},
+		crd.SpecFields["ImageTagMutability"].ValidationMarkers(),
+	)
+	assert.Empty(crd.SpecFields["ImageScanningConfiguration"].ValidationMarkers())
+
+	maxResults := g.SDKAPI.API.Shapes["MaxResults"]
+	require.NotNil(maxResults)
+	assert.Equal(
+		[]string{
+			"+kubebuilder:validation:Minimum=1",
+			"+kubebuilder:validation:Maximum=1000",
+		},
+		g.SDKAPI.ValidationMarkers(maxResults),
+	)
+}
+
+func TestValidationMarkers_Union(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	g := testutil.NewModelForService(t, "ecr")
+
+	shape := g.SDKAPI.API.Shapes["ImageIdentifier"]
+	require.NotNil(shape)
+	assert.False(g.SDKAPI.IsUnion(shape))
+
+	g.SDKAPI.ShapeConstraints["ImageIdentifier"] = &model.ShapeConstraints{
+		Union: true,
+	}
+	assert.True(g.SDKAPI.IsUnion(shape))
+	assert.Equal(
+		[]string{
+			`+kubebuilder:validation:XValidation:rule="[has(self.imageDigest), has(self.imageTag)].exists_one(x, x)",message="exactly one of imageDigest, imageTag must be set"`,
+		},
+		g.SDKAPI.ValidationMarkers(shape),
+	)
+}
+
+func TestSpecValidationMarkers_ECRRepository_ImmutableFields(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	g := testutil.NewModelForServiceWithOptions(t, "ecr", &testutil.TestingModelOptions{
+		GeneratorConfigFile: "generator-with-immutable-fields.yaml",
+	})
+
+	crds, err := g.GetCRDs()
+	require.Nil(err)
+
+	crd := getCRDByName("Repository", crds)
+	require.NotNil(crd)
+
+	assert.Equal(
+		[]string{
+			`+kubebuilder:validation:XValidation:rule="!has(oldSelf.imageScanningConfiguration) || !has(oldSelf.imageScanningConfiguration.scanOnPush) || (has(self.imageScanningConfiguration) && has(self.imageScanningConfiguration.scanOnPush) && self.imageScanningConfiguration.scanOnPush == oldSelf.imageScanningConfiguration.scanOnPush)",message="imageScanningConfiguration.scanOnPush is immutable once set"`,
+			`+kubebuilder:validation:XValidation:rule="!has(oldSelf.repositoryName) || (has(self.repositoryName) && self.repositoryName == oldSelf.repositoryName)",message="repositoryName is immutable once set"`,
+		},
+		crd.SpecValidationMarkers(),
+	)
+	// The rules are on the Spec rather than on the fields
+	for _, fieldName := range []string{"RepositoryName", "ImageScanningConfiguration"} {
+		for _, marker := range crd.SpecFields[fieldName].ValidationMarkers() {
+			assert.NotContains(marker, "oldSelf")
+		}
+	}
+	assert.Equal(
+		[]string{"+kubebuilder:validation:Enum=MUTABLE;IMMUTABLE"},
+		crd.SpecFields["ImageTagMutability"].ValidationMarkers(),
+	)
+
+	// Resources without immutable fields don't get a rule
+	g = testutil.NewModelForService(t, "ecr")
+	crds, err = g.GetCRDs()
+	require.Nil(err)
+	crd = getCRDByName("Repository", crds)
+	require.NotNil(crd)
+	assert.Empty(crd.SpecValidationMarkers())
+}
+
+func TestSpecValidationMarkers_SageMakerDataQualityJobDefinition_NestedImmutableFields(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	g := testutil.NewModelForServiceWithOptions(t, "sagemaker", &testutil.TestingModelOptions{
+		GeneratorConfigFile: "generator-with-immutable-fields.yaml",
+	})
+
+	crds, err := g.GetCRDs()
+	require.Nil(err)
+
+	crd := getCRDByName("DataQualityJobDefinition", crds)
+	require.NotNil(crd)
+
+	// Every optional parent is tested in both the old and the new object:
+	// setting the field, or any of its parents, is allowed, while changing
+	// it, unsetting it or removing any of its parents is not
+	assert.Equal(
+		[]string{
+			`+kubebuilder:validation:XValidation:rule="!has(oldSelf.dataQualityBaselineConfig) || !has(oldSelf.dataQualityBaselineConfig.constraintsResource) || !has(oldSelf.dataQualityBaselineConfig.constraintsResource.s3URI) || (has(self.dataQualityBaselineConfig) && has(self.dataQualityBaselineConfig.constraintsResource) && has(self.dataQualityBaselineConfig.constraintsResource.s3URI) && self.dataQualityBaselineConfig.constraintsResource.s3URI == oldSelf.dataQualityBaselineConfig.constraintsResource.s3URI)",message="dataQualityBaselineConfig.constraintsResource.s3URI is immutable once set"`,
+			`+kubebuilder:validation:XValidation:rule="!has(oldSelf.dataQualityJobInput) || !has(oldSelf.dataQualityJobInput.endpointInput) || !has(oldSelf.dataQualityJobInput.endpointInput.endpointName) || (has(self.dataQualityJobInput) && has(self.dataQualityJobInput.endpointInput) && has(self.dataQualityJobInput.endpointInput.endpointName) && self.dataQualityJobInput.endpointInput.endpointName == oldSelf.dataQualityJobInput.endpointInput.endpointName)",message="dataQualityJobInput.endpointInput.endpointName is immutable once set"`,
+		},
+		crd.SpecValidationMarkers(),
+	)
+}
+
+func TestDefaultMarker_ECRRepository(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	g := testutil.NewModelForServiceWithOptions(t, "ecr", &testutil.TestingModelOptions{
+		GeneratorConfigFile: "generator-with-field-defaults.yaml",
+	})
+
+	crds, err := g.GetCRDs()
+	require.Nil(err)
+
+	crd := getCRDByName("Repository", crds)
+	require.NotNil(crd)
+
+	assert.Equal(
+		`+kubebuilder:default="MUTABLE"`,
+		crd.SpecFields["ImageTagMutability"].DefaultMarker(),
+	)
+	assert.Equal(
+		`+kubebuilder:default={"scanOnPush": true}`,
+		crd.SpecFields["ImageScanningConfiguration"].DefaultMarker(),
+	)
+	assert.Equal("", crd.SpecFields["RepositoryName"].DefaultMarker())
+}
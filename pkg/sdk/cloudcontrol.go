@@ -0,0 +1,556 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package sdk
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/aws-controllers-k8s/code-generator/pkg/model"
+)
+
+const (
+	// ModelFormatCloudControl is the AWS CloudFormation registry resource
+	// schema format of the resource types of the AWS Cloud Control API. The
+	// schemas of a service's resource types are the JSON files of the
+	// `schemas/<service>` directory, e.g. `schemas/logs/aws-logs-loggroup.json`.
+	ModelFormatCloudControl = "cloudcontrol"
+
+	cloudControlDefinitionRefPrefix = "#/definitions/"
+	cloudControlPropertyPtrPrefix   = "/properties/"
+)
+
+var (
+	ErrCloudControlSchemaNotFound = errors.New(
+		"no resource schema found",
+	)
+)
+
+// cloudControlSchema is the subset of a CloudFormation registry resource
+// schema the code generator reads
+type cloudControlSchema struct {
+	TypeName             string                           `json:"typeName"`
+	Description          string                           `json:"description"`
+	Properties           map[string]*cloudControlProperty `json:"properties"`
+	Definitions          map[string]*cloudControlProperty `json:"definitions"`
+	Required             []string                         `json:"required"`
+	ReadOnlyProperties   []string                         `json:"readOnlyProperties"`
+	CreateOnlyProperties []string                         `json:"createOnlyProperties"`
+	PrimaryIdentifier    []string                         `json:"primaryIdentifier"`
+	Handlers             map[string]*cloudControlHandler  `json:"handlers"`
+	// resourceName is the last part of the type name, e.g. "LogGroup"
+	resourceName string
+}
+
+type cloudControlHandler struct {
+	Permissions []string `json:"permissions"`
+}
+
+// cloudControlProperty is a JSON schema describing a property of a resource
+// or of one of its definitions
+type cloudControlProperty struct {
+	Ref                  string                           `json:"$ref"`
+	Type                 json.RawMessage                  `json:"type"`
+	Description          string                           `json:"description"`
+	Enum                 []interface{}                    `json:"enum"`
+	Items                *cloudControlProperty            `json:"items"`
+	Properties           map[string]*cloudControlProperty `json:"properties"`
+	PatternProperties    map[string]*cloudControlProperty `json:"patternProperties"`
+	AdditionalProperties json.RawMessage                  `json:"additionalProperties"`
+	Required             []string                         `json:"required"`
+	MinLength            *float64                         `json:"minLength"`
+	MaxLength            *float64                         `json:"maxLength"`
+	Minimum              *float64                         `json:"minimum"`
+	Maximum              *float64                         `json:"maximum"`
+	Pattern              string                           `json:"pattern"`
+	OneOf                []json.RawMessage                `json:"oneOf"`
+	AnyOf                []json.RawMessage                `json:"anyOf"`
+}
+
+// jsonType returns the JSON type of the property, or an empty string if it
+// may have several types
+func (p *cloudControlProperty) jsonType() string {
+	if len(p.Type) == 0 {
+		if len(p.Properties) > 0 {
+			return "object"
+		}
+		return ""
+	}
+	var t string
+	if err := json.Unmarshal(p.Type, &t); err != nil {
+		// e.g. ["string", "object"]
+		return ""
+	}
+	return t
+}
+
+// ConvertCloudControlSchemas converts the supplied CloudFormation registry
+// schemas of the resource types of a service into an aws-sdk-go `api-2.json`
+// document, and returns it along with, keyed by resource name, the resource
+// types. Each resource type gets the Create, Get, Update and Delete
+// operations of the handlers it has, named after the resource, e.g.
+// CreateLogGroup, whose input and output shapes are made of the properties
+// of the schema. The output of the Create operation holds all the
+// properties, so that the read-only ones end up in the Status of the CRD.
+func ConvertCloudControlSchemas(
+	schemas [][]byte,
+) ([]byte, map[string]*model.CloudControlResource, error) {
+	if len(schemas) == 0 {
+		return nil, nil, ErrCloudControlSchemaNotFound
+	}
+	c := &cloudControlConverter{
+		doc: &api2Document{
+			Version:    "2.0",
+			Metadata:   map[string]interface{}{},
+			Operations: map[string]*api2Operation{},
+			Shapes:     map[string]*api2Shape{},
+		},
+		definitionNames: map[string]map[string]string{},
+	}
+	for _, b := range schemas {
+		schema := &cloudControlSchema{}
+		if err := json.Unmarshal(b, schema); err != nil {
+			return nil, nil, fmt.Errorf("cannot parse resource schema: %v", err)
+		}
+		parts := strings.Split(schema.TypeName, "::")
+		if len(parts) != 3 {
+			return nil, nil, fmt.Errorf(
+				"invalid resource type name %q, expected Organization::Service::Resource",
+				schema.TypeName,
+			)
+		}
+		schema.resourceName = parts[2]
+		c.schemas = append(c.schemas, schema)
+	}
+	// Convert the resource types in a stable order so that any conflicting
+	// shape names are resolved deterministically
+	sort.Slice(c.schemas, func(i, j int) bool {
+		return c.schemas[i].TypeName < c.schemas[j].TypeName
+	})
+	if err := c.convert(); err != nil {
+		return nil, nil, err
+	}
+	b, err := json.MarshalIndent(c.doc, "", "  ")
+	if err != nil {
+		return nil, nil, err
+	}
+	return b, c.resources(), nil
+}
+
+type cloudControlConverter struct {
+	schemas []*cloudControlSchema
+	doc     *api2Document
+	// definitionNames contains, keyed by resource name and definition name,
+	// the names of the shapes of the definitions of the resource schemas
+	definitionNames map[string]map[string]string
+}
+
+func (c *cloudControlConverter) convert() error {
+	namespace := ""
+	for _, schema := range c.schemas {
+		ns := schema.TypeName[:strings.LastIndex(schema.TypeName, "::")]
+		if namespace != "" && ns != namespace {
+			return fmt.Errorf(
+				"resource types %s and %s belong to different services",
+				c.schemas[0].TypeName, schema.TypeName,
+			)
+		}
+		namespace = ns
+	}
+	c.convertMetadata(namespace)
+	c.nameDefinitions()
+	for _, schema := range c.schemas {
+		if err := c.convertResource(schema); err != nil {
+			return fmt.Errorf("%s: %v", schema.TypeName, err)
+		}
+	}
+	return nil
+}
+
+// convertMetadata sets the metadata of the AWS Cloud Control API, which the
+// controller calls
+func (c *cloudControlConverter) convertMetadata(namespace string) {
+	md := c.doc.Metadata
+	md["apiVersion"] = "2021-09-30"
+	md["endpointPrefix"] = "cloudcontrolapi"
+	md["jsonVersion"] = "1.0"
+	md["protocol"] = "json"
+	md["serviceAbbreviation"] = "CloudControlApi"
+	md["serviceFullName"] = "AWS Cloud Control API (" + namespace + ")"
+	md["serviceId"] = "CloudControl"
+	md["signatureVersion"] = "v4"
+	md["signingName"] = "cloudcontrolapi"
+	md["targetPrefix"] = "CloudApiService"
+	md["uid"] = "cloudcontrol-2021-09-30"
+}
+
+// nameDefinitions names the shapes of the definitions of the resource
+// schemas after the definitions, unless several schemas have different
+// definitions of the same name, in which case the shapes are prefixed with
+// the name of the resource
+func (c *cloudControlConverter) nameDefinitions() {
+	byName := map[string][]*cloudControlProperty{}
+	for _, schema := range c.schemas {
+		for defName, def := range schema.Definitions {
+			byName[defName] = append(byName[defName], def)
+		}
+	}
+	for _, schema := range c.schemas {
+		names := map[string]string{}
+		for defName := range schema.Definitions {
+			names[defName] = defName
+			for _, other := range byName[defName] {
+				if !reflect.DeepEqual(other, byName[defName][0]) {
+					names[defName] = schema.resourceName + defName
+					break
+				}
+			}
+		}
+		c.definitionNames[schema.resourceName] = names
+	}
+}
+
+// convertResource adds the operations and shapes of the supplied resource
+// type
+func (c *cloudControlConverter) convertResource(schema *cloudControlSchema) error {
+	resName := schema.resourceName
+	readOnly := propertyNames(schema.ReadOnlyProperties)
+	createOnly := propertyNames(schema.CreateOnlyProperties)
+	propNames := []string{}
+	for propName := range schema.Properties {
+		propNames = append(propNames, propName)
+	}
+	sort.Strings(propNames)
+
+	members := map[string]*api2ShapeRef{}
+	for _, propName := range propNames {
+		ref, err := c.convertProperty(schema, resName, propName, schema.Properties[propName])
+		if err != nil {
+			return err
+		}
+		if createOnly[propName] {
+			ref.Documentation = strings.TrimSpace(
+				ref.Documentation + " This property can't be updated.",
+			)
+		}
+		members[propName] = ref
+	}
+	writable := map[string]*api2ShapeRef{}
+	for propName, ref := range members {
+		if !readOnly[propName] {
+			writable[propName] = ref
+		}
+	}
+	required := []string{}
+	for _, propName := range schema.Required {
+		if !readOnly[propName] {
+			required = append(required, propName)
+		}
+	}
+	identifiers := map[string]*api2ShapeRef{}
+	primaryIdentifier := propertyNames(schema.PrimaryIdentifier)
+	for propName := range primaryIdentifier {
+		ref, found := members[propName]
+		if !found {
+			return fmt.Errorf("unknown primary identifier property %s", propName)
+		}
+		identifiers[propName] = ref
+	}
+	identifierNames := []string{}
+	for _, ptr := range schema.PrimaryIdentifier {
+		identifierNames = append(identifierNames, strings.TrimPrefix(ptr, cloudControlPropertyPtrPrefix))
+	}
+
+	c.addOperation(
+		"Create"+resName,
+		strings.TrimSpace("Creates a "+schema.TypeName+" resource. "+schema.Description),
+		&api2Shape{Type: "structure", Members: writable, Required: required},
+		&api2Shape{Type: "structure", Members: members},
+	)
+	if schema.Handlers["read"] != nil {
+		c.addOperation(
+			"Get"+resName, "Returns the properties of a "+schema.TypeName+" resource.",
+			&api2Shape{Type: "structure", Members: identifiers, Required: identifierNames},
+			&api2Shape{Type: "structure", Members: members},
+		)
+	}
+	if schema.Handlers["update"] != nil {
+		updatable := map[string]*api2ShapeRef{}
+		for propName, ref := range writable {
+			if !createOnly[propName] || primaryIdentifier[propName] {
+				updatable[propName] = ref
+			}
+		}
+		c.addOperation(
+			"Update"+resName, "Updates a "+schema.TypeName+" resource.",
+			&api2Shape{Type: "structure", Members: updatable, Required: identifierNames},
+			&api2Shape{Type: "structure", Members: members},
+		)
+	}
+	if schema.Handlers["delete"] != nil {
+		c.addOperation(
+			"Delete"+resName, "Deletes a "+schema.TypeName+" resource.",
+			&api2Shape{Type: "structure", Members: identifiers, Required: identifierNames},
+			&api2Shape{Type: "structure", Members: map[string]*api2ShapeRef{}},
+		)
+	}
+	return nil
+}
+
+// addOperation adds an operation with the supplied input and output shapes,
+// named after the operation
+func (c *cloudControlConverter) addOperation(
+	opName string,
+	doc string,
+	input *api2Shape,
+	output *api2Shape,
+) {
+	c.doc.Shapes[opName+"Input"] = input
+	c.doc.Shapes[opName+"Output"] = output
+	c.doc.Operations[opName] = &api2Operation{
+		Name:          opName,
+		HTTP:          api2HTTP{Method: "POST", RequestURI: "/"},
+		Input:         &api2ShapeRef{Shape: opName + "Input"},
+		Output:        &api2ShapeRef{Shape: opName + "Output"},
+		Documentation: doc,
+	}
+}
+
+// convertProperty adds the shape of the supplied property, whose shape is
+// named after the supplied owner shape and property names unless the
+// property refers to a definition or has a primitive type, and returns a
+// reference to it
+func (c *cloudControlConverter) convertProperty(
+	schema *cloudControlSchema,
+	ownerName string,
+	propName string,
+	prop *cloudControlProperty,
+) (*api2ShapeRef, error) {
+	ref := &api2ShapeRef{Documentation: prop.Description}
+	if prop.Ref != "" {
+		defName := strings.TrimPrefix(prop.Ref, cloudControlDefinitionRefPrefix)
+		def, found := schema.Definitions[defName]
+		if !found {
+			return nil, fmt.Errorf("unknown definition %s", prop.Ref)
+		}
+		shapeName := c.definitionNames[schema.resourceName][defName]
+		if _, converted := c.doc.Shapes[shapeName]; !converted {
+			// Reserve the name first, for the recursive definitions
+			c.doc.Shapes[shapeName] = &api2Shape{Type: "structure"}
+			defRef, err := c.convertProperty(schema, shapeName, "", def)
+			if err != nil {
+				return nil, err
+			}
+			if defRef.Shape != shapeName {
+				// The definition is an alias of another shape
+				c.doc.Shapes[shapeName] = c.doc.Shapes[defRef.Shape]
+			}
+		}
+		ref.Shape = shapeName
+		return ref, nil
+	}
+	shapeName := ownerName + propName
+	switch prop.jsonType() {
+	case "string":
+		if len(prop.Enum) > 0 {
+			values := []string{}
+			for _, v := range prop.Enum {
+				values = append(values, fmt.Sprint(v))
+			}
+			c.doc.Shapes[shapeName] = &api2Shape{Type: "string", Enum: values}
+			ref.Shape = shapeName
+			return ref, nil
+		}
+		if prop.MinLength == nil && prop.MaxLength == nil && prop.Pattern == "" {
+			ref.Shape = c.primitiveShape("String", "string")
+			return ref, nil
+		}
+		c.doc.Shapes[shapeName] = &api2Shape{
+			Type:    "string",
+			Min:     prop.MinLength,
+			Max:     prop.MaxLength,
+			Pattern: prop.Pattern,
+		}
+	case "integer", "number":
+		shapeType := map[string]string{"integer": "long", "number": "double"}[prop.jsonType()]
+		if prop.Minimum == nil && prop.Maximum == nil {
+			ref.Shape = c.primitiveShape(strings.Title(shapeType), shapeType)
+			return ref, nil
+		}
+		c.doc.Shapes[shapeName] = &api2Shape{
+			Type: shapeType,
+			Min:  prop.Minimum,
+			Max:  prop.Maximum,
+		}
+	case "boolean":
+		ref.Shape = c.primitiveShape("Boolean", "boolean")
+		return ref, nil
+	case "array":
+		if prop.Items == nil {
+			ref.Shape = c.documentShape()
+			return ref, nil
+		}
+		itemRef, err := c.convertProperty(schema, shapeName, "Item", prop.Items)
+		if err != nil {
+			return nil, err
+		}
+		listName := itemRef.Shape + "List"
+		if _, found := c.doc.Shapes[listName]; !found {
+			c.doc.Shapes[listName] = &api2Shape{
+				Type:   "list",
+				Member: &api2ShapeRef{Shape: itemRef.Shape},
+			}
+		}
+		ref.Shape = listName
+		return ref, nil
+	case "object":
+		if len(prop.Properties) > 0 {
+			return c.convertObject(schema, shapeName, prop, ref)
+		}
+		if valueProp := prop.mapValue(); valueProp != nil {
+			valueRef, err := c.convertProperty(schema, shapeName, "Value", valueProp)
+			if err != nil {
+				return nil, err
+			}
+			c.doc.Shapes[shapeName] = &api2Shape{
+				Type:  "map",
+				Key:   &api2ShapeRef{Shape: c.primitiveShape("String", "string")},
+				Value: &api2ShapeRef{Shape: valueRef.Shape},
+			}
+			break
+		}
+		ref.Shape = c.documentShape()
+		return ref, nil
+	default:
+		// Properties of several types are documents
+		ref.Shape = c.documentShape()
+		return ref, nil
+	}
+	ref.Shape = shapeName
+	return ref, nil
+}
+
+// convertObject adds the structure shape of the supplied object property
+func (c *cloudControlConverter) convertObject(
+	schema *cloudControlSchema,
+	shapeName string,
+	prop *cloudControlProperty,
+	ref *api2ShapeRef,
+) (*api2ShapeRef, error) {
+	shape := &api2Shape{
+		Type:     "structure",
+		Members:  map[string]*api2ShapeRef{},
+		Required: prop.Required,
+	}
+	// A definition's name is reserved before it is converted
+	if existing, found := c.doc.Shapes[shapeName]; found && existing.Type == "structure" {
+		shape = existing
+		shape.Members = map[string]*api2ShapeRef{}
+		shape.Required = prop.Required
+	} else {
+		c.doc.Shapes[shapeName] = shape
+	}
+	memberNames := []string{}
+	for memberName := range prop.Properties {
+		memberNames = append(memberNames, memberName)
+	}
+	sort.Strings(memberNames)
+	for _, memberName := range memberNames {
+		memberRef, err := c.convertProperty(schema, shapeName, memberName, prop.Properties[memberName])
+		if err != nil {
+			return nil, err
+		}
+		shape.Members[memberName] = memberRef
+	}
+	ref.Shape = shapeName
+	return ref, nil
+}
+
+// mapValue returns the schema of the values of an object property whose
+// members aren't known in advance, or nil if the property isn't a map
+func (p *cloudControlProperty) mapValue() *cloudControlProperty {
+	for _, valueProp := range p.PatternProperties {
+		if len(p.PatternProperties) == 1 {
+			return valueProp
+		}
+	}
+	if len(p.AdditionalProperties) > 0 && p.AdditionalProperties[0] == '{' {
+		valueProp := &cloudControlProperty{}
+		if err := json.Unmarshal(p.AdditionalProperties, valueProp); err == nil {
+			return valueProp
+		}
+	}
+	return nil
+}
+
+// primitiveShape adds, if needed, the shape of the supplied name and type,
+// and returns its name
+func (c *cloudControlConverter) primitiveShape(shapeName string, shapeType string) string {
+	if _, found := c.doc.Shapes[shapeName]; !found {
+		c.doc.Shapes[shapeName] = &api2Shape{Type: shapeType}
+	}
+	return shapeName
+}
+
+// documentShape adds, if needed, the shape of the properties whose values
+// are arbitrary JSON documents, and returns its name
+func (c *cloudControlConverter) documentShape() string {
+	if _, found := c.doc.Shapes["Document"]; !found {
+		c.doc.Shapes["Document"] = &api2Shape{Type: "structure", Document: true}
+	}
+	return "Document"
+}
+
+// resources returns, keyed by resource name, the converted resource types
+func (c *cloudControlConverter) resources() map[string]*model.CloudControlResource {
+	resources := map[string]*model.CloudControlResource{}
+	for _, schema := range c.schemas {
+		identifier := []string{}
+		for _, ptr := range schema.PrimaryIdentifier {
+			identifier = append(identifier, strings.TrimPrefix(ptr, cloudControlPropertyPtrPrefix))
+		}
+		permissions := map[string][]string{}
+		for handler, h := range schema.Handlers {
+			if h != nil {
+				permissions[handler] = h.Permissions
+			}
+		}
+		resources[schema.resourceName] = &model.CloudControlResource{
+			TypeName:           schema.TypeName,
+			PrimaryIdentifier:  identifier,
+			HandlerPermissions: permissions,
+		}
+	}
+	return resources
+}
+
+// propertyNames returns the set of the names of the top-level properties the
+// supplied JSON pointers, e.g. "/properties/Arn", point to
+func propertyNames(ptrs []string) map[string]bool {
+	names := map[string]bool{}
+	for _, ptr := range ptrs {
+		if !strings.HasPrefix(ptr, cloudControlPropertyPtrPrefix) {
+			continue
+		}
+		name := strings.TrimPrefix(ptr, cloudControlPropertyPtrPrefix)
+		if !strings.Contains(name, "/") {
+			names[name] = true
+		}
+	}
+	return names
+}
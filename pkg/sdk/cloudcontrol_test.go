@@ -0,0 +1,142 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//	 http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package sdk_test
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/aws-controllers-k8s/code-generator/pkg/sdk"
+)
+
+const cloudControlTestSchema = `{
+  "typeName": "AWS::Logs::LogGroup",
+  "description": "Resource schema for AWS::Logs::LogGroup",
+  "definitions": {
+    "Tag": {
+      "type": "object",
+      "properties": {
+        "Key": {"type": "string", "minLength": 1, "maxLength": 128},
+        "Value": {"type": "string"}
+      },
+      "required": ["Key", "Value"]
+    }
+  },
+  "properties": {
+    "LogGroupName": {"type": "string", "minLength": 1, "maxLength": 512},
+    "KmsKeyId": {"type": "string"},
+    "RetentionInDays": {"type": "integer", "enum": [1, 3, 5]},
+    "LogGroupClass": {"type": "string", "enum": ["STANDARD", "INFREQUENT_ACCESS"]},
+    "Tags": {"type": "array", "items": {"$ref": "#/definitions/Tag"}},
+    "Arn": {"type": "string"}
+  },
+  "readOnlyProperties": ["/properties/Arn"],
+  "createOnlyProperties": ["/properties/LogGroupName"],
+  "primaryIdentifier": ["/properties/LogGroupName"],
+  "handlers": {
+    "create": {"permissions": ["logs:CreateLogGroup"]},
+    "read": {"permissions": ["logs:DescribeLogGroups"]},
+    "update": {"permissions": ["logs:PutRetentionPolicy"]},
+    "delete": {"permissions": ["logs:DeleteLogGroup"]}
+  }
+}`
+
+func TestConvertCloudControlSchemas(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	b, resources, err := sdk.ConvertCloudControlSchemas([][]byte{[]byte(cloudControlTestSchema)})
+	require.Nil(err)
+
+	doc := struct {
+		Metadata   map[string]string `json:"metadata"`
+		Operations map[string]struct {
+			Input  struct{ Shape string } `json:"input"`
+			Output struct{ Shape string } `json:"output"`
+		} `json:"operations"`
+		Shapes map[string]struct {
+			Type     string              `json:"type"`
+			Required []string            `json:"required"`
+			Enum     []string            `json:"enum"`
+			Min      float64             `json:"min"`
+			Members  map[string]struct{} `json:"members"`
+			Member   struct {
+				Shape string `json:"shape"`
+			} `json:"member"`
+		} `json:"shapes"`
+	}{}
+	require.Nil(json.Unmarshal(b, &doc))
+
+	assert.Equal("cloudcontrolapi", doc.Metadata["endpointPrefix"])
+	assert.Equal("CloudControl", doc.Metadata["serviceId"])
+
+	for _, opName := range []string{"CreateLogGroup", "GetLogGroup", "UpdateLogGroup", "DeleteLogGroup"} {
+		require.Contains(doc.Operations, opName)
+		assert.Equal(opName+"Input", doc.Operations[opName].Input.Shape)
+	}
+	// Read-only properties are only returned
+	assert.NotContains(doc.Shapes["CreateLogGroupInput"].Members, "Arn")
+	assert.Contains(doc.Shapes["CreateLogGroupOutput"].Members, "Arn")
+	assert.Equal([]string{"LogGroupName"}, doc.Shapes["GetLogGroupInput"].Required)
+	assert.Len(doc.Shapes["GetLogGroupInput"].Members, 1)
+
+	assert.Equal([]string{"STANDARD", "INFREQUENT_ACCESS"}, doc.Shapes["LogGroupLogGroupClass"].Enum)
+	assert.Equal(float64(1), doc.Shapes["LogGroupLogGroupName"].Min)
+	assert.Equal("list", doc.Shapes["TagList"].Type)
+	assert.Equal("Tag", doc.Shapes["TagList"].Member.Shape)
+	assert.Equal([]string{"Key", "Value"}, doc.Shapes["Tag"].Required)
+
+	require.Contains(resources, "LogGroup")
+	assert.Equal("AWS::Logs::LogGroup", resources["LogGroup"].TypeName)
+	assert.Equal([]string{"LogGroupName"}, resources["LogGroup"].PrimaryIdentifier)
+	assert.Equal([]string{"logs:CreateLogGroup"}, resources["LogGroup"].HandlerPermissions["create"])
+}
+
+func TestConvertCloudControlSchemas_Invalid(t *testing.T) {
+	_, _, err := sdk.ConvertCloudControlSchemas(nil)
+	assert.Equal(t, sdk.ErrCloudControlSchemaNotFound, err)
+
+	_, _, err = sdk.ConvertCloudControlSchemas([][]byte{[]byte(`{"typeName": "LogGroup"}`)})
+	require.NotNil(t, err)
+	assert.Contains(t, err.Error(), "invalid resource type name")
+}
+
+func TestAPI_CloudControl(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	tmpDir, err := ioutil.TempDir("", "sdk-cloudcontrol")
+	require.Nil(err)
+	defer os.RemoveAll(tmpDir)
+
+	sdkHelper := sdk.NewHelper(tmpDir, emptyConfig())
+	require.Nil(sdkHelper.WithModelFormat(sdk.ModelFormatCloudControl))
+	require.Nil(sdkHelper.WriteModelFiles("logs", map[string][]byte{
+		"aws-logs-loggroup.json": []byte(cloudControlTestSchema),
+	}))
+	_, err = os.Stat(filepath.Join(tmpDir, "schemas", "logs", "aws-logs-loggroup.json"))
+	require.Nil(err)
+
+	api, err := sdkHelper.API("logs")
+	require.Nil(err)
+	assert.Contains(api.API.Operations, "CreateLogGroup")
+	require.Contains(api.CloudControlResources, "LogGroup")
+	assert.Equal("AWS::Logs::LogGroup", api.CloudControlResources["LogGroup"].TypeName)
+}
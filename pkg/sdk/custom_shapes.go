@@ -16,6 +16,7 @@ package sdk
 import (
 	"errors"
 	"fmt"
+	"strings"
 
 	awssdkmodel "github.com/aws/aws-sdk-go/private/model/api"
 
@@ -30,6 +31,11 @@ const (
 	ShapeNameTemplateList = "%sList"
 	ShapeNameTemplateMap  = "%sMap"
 	ShapeNameTemplateKey  = "%sKey"
+
+	// customShapeSuffixList and customShapeSuffixMap are the suffixes
+	// ShapeNameTemplateList and ShapeNameTemplateMap append to shape names
+	customShapeSuffixList = "List"
+	customShapeSuffixMap  = "Map"
 )
 
 type customShapeInjector struct {
@@ -40,6 +46,10 @@ type customShapeInjector struct {
 // fields that contain CustomFieldConfig values. It will append these values
 // into the list of shapes in the API and update the list of custom shapes in
 // the SDKAPI object.
+//
+// The member and value shapes of custom fields may themselves be custom
+// shapes, referred to either by a Go-like type expression, e.g.
+// "map[string][]Rule", or by the name of the custom shape, e.g. "RuleList".
 func (h *Helper) InjectCustomShapes(sdkapi *ackmodel.SDKAPI) error {
 	injector := customShapeInjector{sdkapi}
 
@@ -48,9 +58,7 @@ func (h *Helper) InjectCustomShapes(sdkapi *ackmodel.SDKAPI) error {
 		if err != nil {
 			return err
 		}
-
-		sdkapi.API.Shapes[customShape.Shape.ShapeName] = customShape.Shape
-		sdkapi.CustomShapes = append(sdkapi.CustomShapes, customShape)
+		injector.register(customShape)
 	}
 
 	for _, memberShape := range h.cfg.GetCustomListFieldMembers() {
@@ -58,14 +66,48 @@ func (h *Helper) InjectCustomShapes(sdkapi *ackmodel.SDKAPI) error {
 		if err != nil {
 			return err
 		}
-
-		sdkapi.API.Shapes[customShape.Shape.ShapeName] = customShape.Shape
-		sdkapi.CustomShapes = append(sdkapi.CustomShapes, customShape)
+		injector.register(customShape)
 	}
 
 	return nil
 }
 
+// register adds the supplied custom shape to the shapes of the API and to the
+// list of custom shapes of the SDKAPI object
+func (i *customShapeInjector) register(customShape *ackmodel.CustomShape) {
+	i.sdkAPI.API.Shapes[customShape.Shape.ShapeName] = customShape.Shape
+	i.sdkAPI.CustomShapes = append(i.sdkAPI.CustomShapes, customShape)
+}
+
+// resolveShape returns the shape with the supplied name. If there is no such
+// shape in the API, the name may be a type expression such as "[]Rule" or
+// "map[string]Rule", or the name of a custom shape such as "RuleList" or
+// "RuleMap", in which case the custom shapes it refers to are created.
+func (i *customShapeInjector) resolveShape(name string) (*awssdkmodel.Shape, error) {
+	if shape, exists := i.sdkAPI.API.Shapes[name]; exists {
+		return shape, nil
+	}
+	var customShape *ackmodel.CustomShape
+	var err error
+	switch {
+	case strings.HasPrefix(name, "[]"):
+		customShape, err = i.newList(strings.TrimPrefix(name, "[]"))
+	case strings.HasPrefix(name, "map[string]"):
+		customShape, err = i.newMap(strings.TrimPrefix(name, "map[string]"))
+	case strings.HasSuffix(name, customShapeSuffixList) && name != customShapeSuffixList:
+		customShape, err = i.newList(strings.TrimSuffix(name, customShapeSuffixList))
+	case strings.HasSuffix(name, customShapeSuffixMap) && name != customShapeSuffixMap:
+		customShape, err = i.newMap(strings.TrimSuffix(name, customShapeSuffixMap))
+	default:
+		return nil, fmt.Errorf("%w: %s", ErrMemberShapeNotFound, name)
+	}
+	if err != nil {
+		return nil, err
+	}
+	i.register(customShape)
+	return customShape.Shape, nil
+}
+
 // createShapeRefForMember creates a minimal ShapeRef type to encapsulate a
 // shape.
 func (i *customShapeInjector) createShapeRefForMember(shape *awssdkmodel.Shape) *awssdkmodel.ShapeRef {
@@ -90,9 +132,9 @@ func (i *customShapeInjector) createKeyShape(shapeName string) *awssdkmodel.Shap
 // newMap loads a shape given its name and creates a custom shape that is a
 // map with strings as keys and that shape as the value.
 func (i *customShapeInjector) newMap(valueShapeName string) (*ackmodel.CustomShape, error) {
-	valueShape, exists := i.sdkAPI.API.Shapes[valueShapeName]
-	if !exists {
-		return nil, ErrMemberShapeNotFound
+	valueShape, err := i.resolveShape(valueShapeName)
+	if err != nil {
+		return nil, err
 	}
 	valueShapeRef := i.createShapeRefForMember(valueShape)
 
@@ -125,9 +167,9 @@ func (i *customShapeInjector) newMap(valueShapeName string) (*ackmodel.CustomSha
 // newList loads a shape given its name and creates a custom shape that is a
 // list of that shape.
 func (i *customShapeInjector) newList(memberShapeName string) (*ackmodel.CustomShape, error) {
-	memberShape, exists := i.sdkAPI.API.Shapes[memberShapeName]
-	if !exists {
-		return nil, ErrMemberShapeNotFound
+	memberShape, err := i.resolveShape(memberShapeName)
+	if err != nil {
+		return nil, err
 	}
 	memberShapeRef := i.createShapeRefForMember(memberShape)
 
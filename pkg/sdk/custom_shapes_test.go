@@ -131,3 +131,67 @@ func TestCustomMapField(t *testing.T) {
 	_, exists = api.API.Shapes[shapeRef.ShapeName]
 	assert.True(exists)
 }
+
+func TestCustomNestedFields(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	cfg := config.Config{
+		Resources: map[string]config.ResourceConfig{
+			"Bucket": {
+				Fields: map[string]*config.FieldConfig{
+					"TagSets": {
+						CustomField: &config.CustomFieldConfig{
+							MapOf: "[]Tag",
+						},
+					},
+					"TagMaps": {
+						CustomField: &config.CustomFieldConfig{
+							ListOf: "map[string]Tag",
+						},
+					},
+					// Refers to the custom shape created for TagSets
+					"TagSetLists": {
+						CustomField: &config.CustomFieldConfig{
+							ListOf: "TagListMap",
+						},
+					},
+				},
+			},
+		},
+	}
+	api := s3SDKAPI(t, cfg)
+
+	tagShape, exists := api.API.Shapes["Tag"]
+	require.True(exists)
+
+	// map[string][]Tag
+	shapeRef := api.GetCustomShapeRef("[]Tag")
+	require.NotNil(shapeRef)
+	assert.Equal("TagListMap", shapeRef.ShapeName)
+	assert.Equal("map", shapeRef.Shape.Type)
+	assert.Equal("list", shapeRef.Shape.ValueRef.Shape.Type)
+	assert.Equal(tagShape, shapeRef.Shape.ValueRef.Shape.MemberRef.Shape)
+	_, _, gtwp := model.CleanGoType(api, &cfg, shapeRef.Shape, nil)
+	assert.Equal("map[string][]*s3.Tag", gtwp)
+
+	// []map[string]Tag
+	shapeRef = api.GetCustomShapeRef("map[string]Tag")
+	require.NotNil(shapeRef)
+	assert.Equal("TagMapList", shapeRef.ShapeName)
+	assert.Equal("list", shapeRef.Shape.Type)
+	assert.Equal("map", shapeRef.Shape.MemberRef.Shape.Type)
+	assert.Equal(tagShape, shapeRef.Shape.MemberRef.Shape.ValueRef.Shape)
+
+	// [](map[string][]Tag)
+	shapeRef = api.GetCustomShapeRef("TagListMap")
+	require.NotNil(shapeRef)
+	assert.Equal("TagListMapList", shapeRef.ShapeName)
+	assert.Equal("TagListMap", shapeRef.Shape.MemberRef.Shape.ShapeName)
+
+	// The nested custom shapes were registered into API shapes
+	_, exists = api.API.Shapes["TagList"]
+	assert.True(exists)
+	_, exists = api.API.Shapes["TagMap"]
+	assert.True(exists)
+}
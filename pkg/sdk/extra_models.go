@@ -0,0 +1,104 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package sdk
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+)
+
+// WithExtraModelsPath sets the path to a directory of additional `api-2`
+// service API models, laid out like the aws-sdk-go `models/apis` directory
+// (e.g. `ecr/2015-09-21/api-2.json`). Their files are overlaid on top of the
+// SDK's: a file of an API version replaces the SDK's file of the same name,
+// and the API versions, or services, missing from the SDK are added to it.
+// This allows generating controllers from service API models that aren't
+// released in the SDK yet.
+func (h *Helper) WithExtraModelsPath(extraModelsPath string) {
+	h.extraModelsPath = extraModelsPath
+}
+
+// extraModelsDir returns the directory of the extra models of the supplied
+// service API version, or an empty string if there are none
+func (h *Helper) extraModelsDir(serviceModelName string, apiVersion string) string {
+	if h.extraModelsPath == "" || h.modelFormat != ModelFormatAPI2 {
+		return ""
+	}
+	dir := filepath.Join(h.extraModelsPath, serviceModelName, apiVersion)
+	if fi, err := os.Stat(dir); err != nil || !fi.IsDir() {
+		return ""
+	}
+	return dir
+}
+
+// apiModelFilePaths returns a map, keyed by file name, of the paths to the
+// JSON files making up the supplied service API version, the files of the
+// extra models replacing the SDK's
+func (h *Helper) apiModelFilePaths(
+	serviceModelName string,
+	apiVersion string,
+) (map[string]string, error) {
+	dirs := []string{
+		filepath.Join(h.basePath, "models", "apis", serviceModelName, apiVersion),
+	}
+	if extraDir := h.extraModelsDir(serviceModelName, apiVersion); extraDir != "" {
+		dirs = append(dirs, extraDir)
+	}
+	paths := map[string]string{}
+	for _, dir := range dirs {
+		dirPaths, err := filepath.Glob(filepath.Join(dir, "*.json"))
+		if err != nil {
+			return nil, err
+		}
+		for _, path := range dirPaths {
+			paths[filepath.Base(path)] = path
+		}
+	}
+	return paths, nil
+}
+
+// overlayExtraModels writes the files of the supplied service API version,
+// with the files of its extra models overlaid on the SDK's, into a new
+// temporary directory and returns the path to its `api-2.json` file, or an
+// empty string if the API version has no extra models. Callers are
+// responsible for removing the directory.
+func (h *Helper) overlayExtraModels(
+	serviceModelName string,
+	apiVersion string,
+) (string, error) {
+	if h.extraModelsDir(serviceModelName, apiVersion) == "" {
+		return "", nil
+	}
+	paths, err := h.apiModelFilePaths(serviceModelName, apiVersion)
+	if err != nil {
+		return "", err
+	}
+	tmpDir, err := ioutil.TempDir("", "ack-extra-models-")
+	if err != nil {
+		return "", err
+	}
+	for fileName, path := range paths {
+		b, err := ioutil.ReadFile(path)
+		if err != nil {
+			os.RemoveAll(tmpDir)
+			return "", err
+		}
+		if err = ioutil.WriteFile(filepath.Join(tmpDir, fileName), b, 0644); err != nil {
+			os.RemoveAll(tmpDir)
+			return "", err
+		}
+	}
+	return filepath.Join(tmpDir, "api-2.json"), nil
+}
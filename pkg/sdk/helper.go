@@ -14,12 +14,14 @@
 package sdk
 
 import (
+	"encoding/json"
 	"errors"
 	"fmt"
 	"io/ioutil"
 	"os"
 	"path/filepath"
 	"sort"
+	"strings"
 
 	"gopkg.in/src-d/go-git.v4"
 
@@ -56,13 +58,19 @@ type Helper struct {
 	loader         *awssdkmodel.Loader
 	// Default is set by `FirstAPIVersion`
 	apiVersion string
+	// Default is `ModelFormatAPI2`
+	modelFormat string
+	// extraModelsPath is the directory of the extra service API models
+	// overlaid on the SDK's. See `WithExtraModelsPath`.
+	extraModelsPath string
 }
 
 // NewHelper returns a new SDKHelper object
 func NewHelper(basePath string, cfg ackgenconfig.Config) *Helper {
 	return &Helper{
-		cfg:      cfg,
-		basePath: basePath,
+		cfg:         cfg,
+		basePath:    basePath,
+		modelFormat: ModelFormatAPI2,
 		loader: &awssdkmodel.Loader{
 			BaseImport:            basePath,
 			IgnoreUnsupportedAPIs: true,
@@ -93,12 +101,77 @@ func (h *Helper) WithAPIVersion(apiVersion string) {
 	h.apiVersion = apiVersion
 }
 
+// WithModelFormat sets the format of the service model files that will be
+// loaded. When the format is `ModelFormatSmithy`, h.basePath should point to
+// an aws-sdk-go-v2 repository. When the format is `ModelFormatCloudControl`,
+// h.basePath should point to a directory containing the resource schemas of
+// the service in its `schemas/<service>` directory.
+func (h *Helper) WithModelFormat(modelFormat string) error {
+	switch modelFormat {
+	case "", ModelFormatAPI2:
+		h.modelFormat = ModelFormatAPI2
+	case ModelFormatSmithy:
+		h.modelFormat = ModelFormatSmithy
+	case ModelFormatCloudControl:
+		h.modelFormat = ModelFormatCloudControl
+	default:
+		return fmt.Errorf("%s: %v", modelFormat, ErrUnknownModelFormat)
+	}
+	return nil
+}
+
 // API returns the aws-sdk-go API model for a supplied service model name.
 func (h *Helper) API(serviceModelName string) (*model.SDKAPI, error) {
 	modelPath, _, err := h.ModelAndDocsPath(serviceModelName)
 	if err != nil {
 		return nil, err
 	}
+	if h.modelFormat == ModelFormatSmithy {
+		modelPath, err = h.convertSmithyModelFile(modelPath)
+		if err != nil {
+			return nil, err
+		}
+		defer os.RemoveAll(filepath.Dir(modelPath))
+	}
+	overlaidPath, err := h.overlayExtraModels(serviceModelName, h.apiVersion)
+	if err != nil {
+		return nil, err
+	}
+	if overlaidPath != "" {
+		defer os.RemoveAll(filepath.Dir(overlaidPath))
+		modelPath = overlaidPath
+	}
+	var ccResources map[string]*model.CloudControlResource
+	if h.modelFormat == ModelFormatCloudControl {
+		modelPath, ccResources, err = h.convertCloudControlSchemas(modelPath)
+		if err != nil {
+			return nil, err
+		}
+		defer os.RemoveAll(filepath.Dir(modelPath))
+	}
+	sdkapi, err := h.loadAPI(modelPath)
+	if err != nil {
+		return nil, err
+	}
+	sdkapi.APIVersion = h.apiVersion
+	sdkapi.CloudControlResources = ccResources
+	if err = h.loadResourceAPIs(serviceModelName, sdkapi); err != nil {
+		return nil, err
+	}
+	return sdkapi, nil
+}
+
+// loadAPI returns the API model in the supplied `api-2.json` file, with the
+// model overrides of the generator config applied
+func (h *Helper) loadAPI(modelPath string) (*model.SDKAPI, error) {
+	if len(h.cfg.ModelOverrides) > 0 {
+		overriddenPath, err := h.overrideModelFile(modelPath)
+		if err != nil {
+			return nil, err
+		}
+		defer os.RemoveAll(filepath.Dir(overriddenPath))
+		modelPath = overriddenPath
+	}
 	apis, err := h.loader.Load([]string{modelPath})
 	if err != nil {
 		return nil, err
@@ -114,19 +187,145 @@ func (h *Helper) API(serviceModelName string) (*model.SDKAPI, error) {
 		// unexported map variable...
 		_ = api.ServicePackageDoc()
 		sdkapi := model.NewSDKAPI(api, h.APIGroupSuffix)
+		sdkapi.ShapeConstraints, err = loadShapeConstraints(modelPath)
+		if err != nil {
+			return nil, err
+		}
 
-		h.InjectCustomShapes(sdkapi)
+		if err = h.InjectCustomShapes(sdkapi); err != nil {
+			return nil, err
+		}
 
 		return sdkapi, nil
 	}
 	return nil, ErrServiceNotFound
 }
 
+// loadResourceAPIs loads the API models of the other versions of the service
+// API the resources are configured to be generated from into the supplied
+// API model's ResourceAPIs, returning an error if a version doesn't exist or
+// doesn't contain the Create operation of the resource
+func (h *Helper) loadResourceAPIs(
+	serviceModelName string,
+	sdkapi *model.SDKAPI,
+) error {
+	resVersions := h.cfg.ResourceSDKAPIVersions()
+	resNames := []string{}
+	for resName, version := range resVersions {
+		if version != h.apiVersion {
+			resNames = append(resNames, resName)
+		}
+	}
+	if len(resNames) == 0 {
+		return nil
+	}
+	sort.Strings(resNames)
+	if h.modelFormat != ModelFormatAPI2 {
+		return fmt.Errorf(
+			"resource %s: sdk_api_version is not supported with %s models",
+			resNames[0], h.modelFormat,
+		)
+	}
+	versions, err := h.GetAPIVersions(serviceModelName)
+	if err != nil {
+		return err
+	}
+	versionAPIs := map[string]*model.SDKAPI{}
+	sdkapi.ResourceAPIs = map[string]*model.SDKAPI{}
+	for _, resName := range resNames {
+		version := resVersions[resName]
+		if !util.InStrings(version, versions) {
+			return fmt.Errorf(
+				"resource %s: sdk_api_version %s: %v, found %s",
+				resName, version, ErrAPIVersionNotFound,
+				strings.Join(versions, ", "),
+			)
+		}
+		versionAPI, found := versionAPIs[version]
+		if !found {
+			modelPath := filepath.Join(
+				h.basePath, "models", "apis", serviceModelName, version,
+				"api-2.json",
+			)
+			overlaidPath, err := h.overlayExtraModels(serviceModelName, version)
+			if err != nil {
+				return err
+			}
+			if overlaidPath != "" {
+				defer os.RemoveAll(filepath.Dir(overlaidPath))
+				modelPath = overlaidPath
+			}
+			versionAPI, err = h.loadAPI(modelPath)
+			if err != nil {
+				return fmt.Errorf("cannot load API version %s: %v", version, err)
+			}
+			versionAPI.APIVersion = version
+			versionAPIs[version] = versionAPI
+		}
+		sdkapi.ResourceAPIs[resName] = versionAPI
+	}
+	for _, resName := range resNames {
+		opMap := *sdkapi.ResourceOperationMap(resName, &h.cfg)
+		if _, found := opMap[model.OpTypeCreate][resName]; !found {
+			return fmt.Errorf(
+				"resource %s: API version %s has no create operation for the resource",
+				resName, resVersions[resName],
+			)
+		}
+	}
+	return nil
+}
+
+// loadShapeConstraints returns, keyed by shape name, the min, max and pattern
+// constraints, default values and union and document flags of the shapes in
+// the supplied `api-2.json` file
+func loadShapeConstraints(
+	modelPath string,
+) (map[string]*model.ShapeConstraints, error) {
+	b, err := ioutil.ReadFile(modelPath)
+	if err != nil {
+		return nil, err
+	}
+	doc := struct {
+		Shapes map[string]*model.ShapeConstraints `json:"shapes"`
+	}{}
+	if err = json.Unmarshal(b, &doc); err != nil {
+		return nil, fmt.Errorf("cannot read shape constraints from %s: %v", modelPath, err)
+	}
+	constraints := map[string]*model.ShapeConstraints{}
+	for shapeName, c := range doc.Shapes {
+		if c.Min != nil || c.Max != nil || c.Pattern != "" || c.Default != nil || c.Union || c.Document {
+			constraints[shapeName] = c
+		}
+	}
+	return constraints, nil
+}
+
 // ModelAndDocsPath returns two string paths to the supplied service's API and
-// doc JSON files
+// doc JSON files. For `ModelFormatCloudControl` both are the path to the
+// directory of the service's resource schemas.
 func (h *Helper) ModelAndDocsPath(
 	serviceModelName string,
 ) (string, string, error) {
+	if h.modelFormat == ModelFormatCloudControl {
+		// Resource schemas embed the documentation in the schema files
+		schemasPath := filepath.Join(h.basePath, "schemas", serviceModelName)
+		if fi, err := os.Stat(schemasPath); err != nil || !fi.IsDir() {
+			return "", "", fmt.Errorf("%s: %v", serviceModelName, ErrServiceNotFound)
+		}
+		return schemasPath, schemasPath, nil
+	}
+	if h.modelFormat == ModelFormatSmithy {
+		// Smithy models embed the documentation in the model file itself
+		modelPath := filepath.Join(
+			h.basePath, "codegen", "sdk-codegen", "aws-models",
+			serviceModelName+".json",
+		)
+		if _, err := os.Stat(modelPath); err != nil {
+			return "", "", fmt.Errorf("%s: %v", serviceModelName, ErrServiceNotFound)
+		}
+		return modelPath, modelPath, nil
+	}
 	if h.apiVersion == "" {
 		apiVersion, err := h.FirstAPIVersion(serviceModelName)
 		if err != nil {
@@ -139,9 +338,78 @@ func (h *Helper) ModelAndDocsPath(
 	)
 	modelPath := filepath.Join(versionPath, "api-2.json")
 	docsPath := filepath.Join(versionPath, "docs-2.json")
+	// The files of the extra models replace the SDK's
+	if extraDir := h.extraModelsDir(serviceModelName, h.apiVersion); extraDir != "" {
+		if path := filepath.Join(extraDir, "api-2.json"); util.FileExists(path) {
+			modelPath = path
+		}
+		if path := filepath.Join(extraDir, "docs-2.json"); util.FileExists(path) {
+			docsPath = path
+		}
+	}
 	return modelPath, docsPath, nil
 }
 
+// convertSmithyModelFile converts the Smithy JSON model at the supplied path
+// into an `api-2.json` file in a new temporary directory and returns the path
+// to the converted file. Callers are responsible for removing the directory.
+func (h *Helper) convertSmithyModelFile(smithyPath string) (string, error) {
+	b, err := ioutil.ReadFile(smithyPath)
+	if err != nil {
+		return "", err
+	}
+	converted, err := ConvertSmithyModel(b)
+	if err != nil {
+		return "", fmt.Errorf("cannot convert %s: %v", smithyPath, err)
+	}
+	tmpDir, err := ioutil.TempDir("", "ack-smithy-")
+	if err != nil {
+		return "", err
+	}
+	modelPath := filepath.Join(tmpDir, "api-2.json")
+	if err = ioutil.WriteFile(modelPath, converted, 0644); err != nil {
+		os.RemoveAll(tmpDir)
+		return "", err
+	}
+	return modelPath, nil
+}
+
+// convertCloudControlSchemas converts the resource schemas in the supplied
+// directory into an `api-2.json` file in a new temporary directory and
+// returns the path to the converted file along with the converted resource
+// types. Callers are responsible for removing the directory.
+func (h *Helper) convertCloudControlSchemas(
+	schemasPath string,
+) (string, map[string]*model.CloudControlResource, error) {
+	paths, err := filepath.Glob(filepath.Join(schemasPath, "*.json"))
+	if err != nil {
+		return "", nil, err
+	}
+	sort.Strings(paths)
+	schemas := [][]byte{}
+	for _, path := range paths {
+		b, err := ioutil.ReadFile(path)
+		if err != nil {
+			return "", nil, err
+		}
+		schemas = append(schemas, b)
+	}
+	converted, resources, err := ConvertCloudControlSchemas(schemas)
+	if err != nil {
+		return "", nil, fmt.Errorf("cannot convert %s: %v", schemasPath, err)
+	}
+	tmpDir, err := ioutil.TempDir("", "ack-cloudcontrol-")
+	if err != nil {
+		return "", nil, err
+	}
+	modelPath := filepath.Join(tmpDir, "api-2.json")
+	if err = ioutil.WriteFile(modelPath, converted, 0644); err != nil {
+		os.RemoveAll(tmpDir)
+		return "", nil, err
+	}
+	return modelPath, resources, nil
+}
+
 // FirstAPIVersion returns the first found API version for a service API.
 // (e.h. "2012-10-03")
 func (h *Helper) FirstAPIVersion(serviceModelName string) (string, error) {
@@ -153,9 +421,43 @@ func (h *Helper) FirstAPIVersion(serviceModelName string) (string, error) {
 	return versions[0], nil
 }
 
-// GetAPIVersions returns the list of API Versions found in a service directory.
+// GetAPIVersions returns the list of API Versions found in a service directory,
+// including the API versions of the extra models.
 func (h *Helper) GetAPIVersions(serviceModelName string) ([]string, error) {
 	apiPath := filepath.Join(h.basePath, "models", "apis", serviceModelName)
+	versions, err := apiVersionDirs(apiPath)
+	if h.extraModelsPath != "" && h.modelFormat == ModelFormatAPI2 {
+		extraVersions, extraErr := apiVersionDirs(
+			filepath.Join(h.extraModelsPath, serviceModelName),
+		)
+		switch {
+		case extraErr == nil:
+			// The service may be missing from the SDK altogether
+			if os.IsNotExist(err) {
+				err = nil
+			}
+			for _, version := range extraVersions {
+				if !util.InStrings(version, versions) {
+					versions = append(versions, version)
+				}
+			}
+			sort.Strings(versions)
+		case !os.IsNotExist(extraErr):
+			return nil, extraErr
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+	if len(versions) == 0 {
+		return nil, ErrNoValidVersionDirectory
+	}
+	return versions, nil
+}
+
+// apiVersionDirs returns the names of the API version directories in the
+// supplied service directory
+func apiVersionDirs(apiPath string) ([]string, error) {
 	versionDirs, err := ioutil.ReadDir(apiPath)
 	if err != nil {
 		return nil, err
@@ -173,8 +475,86 @@ func (h *Helper) GetAPIVersions(serviceModelName string) ([]string, error) {
 		}
 		versions = append(versions, version)
 	}
-	if len(versions) == 0 {
-		return nil, ErrNoValidVersionDirectory
-	}
 	return versions, nil
 }
+
+// ModelFiles returns a map, keyed by file name, of the contents of the files
+// making up a service's API model, as listed by ModelFilePaths.
+func (h *Helper) ModelFiles(serviceModelName string) (map[string][]byte, error) {
+	paths, err := h.ModelFilePaths(serviceModelName)
+	if err != nil {
+		return nil, err
+	}
+	files := map[string][]byte{}
+	for fileName, path := range paths {
+		b, err := ioutil.ReadFile(path)
+		if err != nil {
+			return nil, err
+		}
+		files[fileName] = b
+	}
+	return files, nil
+}
+
+// ModelFilePaths returns a map, keyed by file name, of the paths to the files
+// making up a service's API model. For `api-2` models these are all the JSON
+// files in the model's version directory (API, docs, paginators, waiters...),
+// with the files of the extra models replacing the SDK's; Smithy models
+// consist of a single file and Cloud Control models of the resource schemas
+// of the service.
+func (h *Helper) ModelFilePaths(serviceModelName string) (map[string]string, error) {
+	modelPath, _, err := h.ModelAndDocsPath(serviceModelName)
+	if err != nil {
+		return nil, err
+	}
+	switch h.modelFormat {
+	case ModelFormatAPI2:
+		return h.apiModelFilePaths(serviceModelName, h.apiVersion)
+	case ModelFormatCloudControl:
+		schemaPaths, err := filepath.Glob(filepath.Join(modelPath, "*.json"))
+		if err != nil {
+			return nil, err
+		}
+		paths := map[string]string{}
+		for _, path := range schemaPaths {
+			paths[filepath.Base(path)] = path
+		}
+		return paths, nil
+	}
+	return map[string]string{filepath.Base(modelPath): modelPath}, nil
+}
+
+// WriteModelFiles writes the supplied service API model files, as returned
+// by ModelFiles, into the helper's base path using the same directory layout
+// as the SDK, so that they can subsequently be loaded with API.
+func (h *Helper) WriteModelFiles(
+	serviceModelName string,
+	files map[string][]byte,
+) error {
+	modelDir := filepath.Join(h.basePath, "codegen", "sdk-codegen", "aws-models")
+	switch h.modelFormat {
+	case ModelFormatAPI2:
+		apiVersion := h.apiVersion
+		if apiVersion == "" {
+			apiVersion = "0000-00-00"
+		}
+		modelDir = filepath.Join(h.basePath, "models", "apis", serviceModelName, apiVersion)
+	case ModelFormatCloudControl:
+		modelDir = filepath.Join(h.basePath, "schemas", serviceModelName)
+	}
+	if err := os.MkdirAll(modelDir, os.ModePerm); err != nil {
+		return err
+	}
+	for fileName, b := range files {
+		if fileName != filepath.Base(fileName) {
+			return fmt.Errorf("invalid model file name %q", fileName)
+		}
+		if h.modelFormat == ModelFormatSmithy {
+			fileName = serviceModelName + ".json"
+		}
+		if err := ioutil.WriteFile(filepath.Join(modelDir, fileName), b, 0644); err != nil {
+			return err
+		}
+	}
+	return nil
+}
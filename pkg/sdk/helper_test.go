@@ -14,6 +14,8 @@
 package sdk_test
 
 import (
+	"io/ioutil"
+	"os"
 	"path/filepath"
 	"testing"
 
@@ -179,3 +181,99 @@ func TestGetOutputShapeRef(t *testing.T) {
 		}
 	}
 }
+
+func TestModelFiles_RoundTrip(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	sdkHelper := sdk.NewHelper(filepath.Clean("../testdata"), emptyConfig())
+	files, err := sdkHelper.ModelFiles("ecr")
+	require.Nil(err)
+	assert.Contains(files, "api-2.json")
+	assert.Contains(files, "docs-2.json")
+
+	tmpDir, err := ioutil.TempDir("", "sdk-helper")
+	require.Nil(err)
+	defer os.RemoveAll(tmpDir)
+
+	tmpHelper := sdk.NewHelper(tmpDir, emptyConfig())
+	require.Nil(tmpHelper.WriteModelFiles("ecr", files))
+
+	api, err := tmpHelper.API("ecr")
+	require.Nil(err)
+	assert.Contains(api.API.Shapes, "Repository")
+}
+
+func TestAPI_ResourceSDKAPIVersion(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	cfg := config.Config{
+		Resources: map[string]config.ResourceConfig{
+			"Repository": {SDKAPIVersion: "0000-00-01"},
+		},
+	}
+	sdkHelper := sdk.NewHelper(filepath.Clean("../testdata"), cfg)
+	api, err := sdkHelper.API("ecr")
+	require.Nil(err)
+	assert.Equal("0000-00-00", api.APIVersion)
+	assert.NotContains(api.API.Shapes, "EncryptionConfiguration")
+
+	repoAPI := api.ResourceSDKAPI("Repository")
+	assert.Equal("0000-00-01", repoAPI.APIVersion)
+	assert.Contains(repoAPI.API.Shapes, "EncryptionConfiguration")
+	// Resources that aren't configured are generated from the API model
+	// loaded for the service
+	assert.Equal(api, api.ResourceSDKAPI("Image"))
+
+	cfg.Resources["Repository"] = config.ResourceConfig{SDKAPIVersion: "2015-09-21"}
+	sdkHelper = sdk.NewHelper(filepath.Clean("../testdata"), cfg)
+	_, err = sdkHelper.API("ecr")
+	require.NotNil(err)
+	assert.Contains(err.Error(), "resource Repository: sdk_api_version 2015-09-21")
+	assert.Contains(err.Error(), sdk.ErrAPIVersionNotFound.Error())
+}
+
+func TestAPI_ExtraModels(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	// The extra models add the encryptionConfiguration field of the
+	// 0000-00-01 API version to 0000-00-00, and a new 0000-00-02 version
+	apiModel, err := ioutil.ReadFile(
+		filepath.Join("..", "testdata", "models", "apis", "ecr", "0000-00-01", "api-2.json"),
+	)
+	require.Nil(err)
+	extraDir, err := ioutil.TempDir("", "sdk-extra-models")
+	require.Nil(err)
+	defer os.RemoveAll(extraDir)
+	for _, version := range []string{"0000-00-00", "0000-00-02"} {
+		versionDir := filepath.Join(extraDir, "ecr", version)
+		require.Nil(os.MkdirAll(versionDir, os.ModePerm))
+		require.Nil(ioutil.WriteFile(filepath.Join(versionDir, "api-2.json"), apiModel, 0644))
+	}
+
+	sdkHelper := sdk.NewHelper(filepath.Clean("../testdata"), emptyConfig())
+	sdkHelper.WithExtraModelsPath(extraDir)
+	versions, err := sdkHelper.GetAPIVersions("ecr")
+	require.Nil(err)
+	assert.Equal([]string{"0000-00-00", "0000-00-01", "0000-00-02"}, versions)
+
+	api, err := sdkHelper.API("ecr")
+	require.Nil(err)
+	assert.Equal("0000-00-00", api.APIVersion)
+	assert.Contains(api.API.Shapes, "EncryptionConfiguration")
+
+	// The SDK's files that the extra models don't replace are kept
+	files, err := sdkHelper.ModelFiles("ecr")
+	require.Nil(err)
+	assert.Equal(apiModel, files["api-2.json"])
+	assert.Contains(files, "docs-2.json")
+
+	sdkHelper = sdk.NewHelper(filepath.Clean("../testdata"), emptyConfig())
+	sdkHelper.WithExtraModelsPath(extraDir)
+	sdkHelper.WithAPIVersion("0000-00-02")
+	api, err = sdkHelper.API("ecr")
+	require.Nil(err)
+	assert.Contains(api.API.Shapes, "EncryptionConfiguration")
+}
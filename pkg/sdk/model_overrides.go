@@ -0,0 +1,186 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package sdk
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	ackgenconfig "github.com/aws-controllers-k8s/code-generator/pkg/generate/config"
+)
+
+var (
+	ErrModelOverridePathNotFound = errors.New(
+		"no such path in the api model",
+	)
+)
+
+// overrideModelFile applies the model overrides of the generator config to
+// the `api-2.json` file at the supplied path, writing the result into a new
+// temporary directory along with the other JSON files of the model (docs,
+// paginators...), and returns the path to the overridden file. Callers are
+// responsible for removing the directory.
+func (h *Helper) overrideModelFile(modelPath string) (string, error) {
+	b, err := ioutil.ReadFile(modelPath)
+	if err != nil {
+		return "", err
+	}
+	overridden, err := ApplyModelOverrides(b, h.cfg.ModelOverrides)
+	if err != nil {
+		return "", fmt.Errorf("cannot override %s: %v", modelPath, err)
+	}
+	tmpDir, err := ioutil.TempDir("", "ack-model-overrides-")
+	if err != nil {
+		return "", err
+	}
+	siblingPaths, err := filepath.Glob(filepath.Join(filepath.Dir(modelPath), "*.json"))
+	if err != nil {
+		os.RemoveAll(tmpDir)
+		return "", err
+	}
+	files := map[string][]byte{filepath.Base(modelPath): overridden}
+	for _, path := range siblingPaths {
+		if path == modelPath {
+			continue
+		}
+		if files[filepath.Base(path)], err = ioutil.ReadFile(path); err != nil {
+			os.RemoveAll(tmpDir)
+			return "", err
+		}
+	}
+	for fileName, b := range files {
+		if err = ioutil.WriteFile(filepath.Join(tmpDir, fileName), b, 0644); err != nil {
+			os.RemoveAll(tmpDir)
+			return "", err
+		}
+	}
+	return filepath.Join(tmpDir, filepath.Base(modelPath)), nil
+}
+
+// ApplyModelOverrides returns the supplied `api-2.json` document with the
+// supplied model overrides applied in order
+func ApplyModelOverrides(
+	doc []byte,
+	overrides []ackgenconfig.ModelOverrideConfig,
+) ([]byte, error) {
+	decoder := json.NewDecoder(bytes.NewReader(doc))
+	// Keep the numbers, e.g. the min and max constraints, as they are
+	decoder.UseNumber()
+	var root interface{}
+	if err := decoder.Decode(&root); err != nil {
+		return nil, err
+	}
+	for i, override := range overrides {
+		tokens, err := pointerTokens(override.Path)
+		if err != nil {
+			return nil, fmt.Errorf("model_overrides[%d]: %v", i, err)
+		}
+		root, err = applyModelOverride(root, tokens, override)
+		if err != nil {
+			return nil, fmt.Errorf(
+				"model_overrides[%d]: %s %s: %v",
+				i, override.Op, override.Path, err,
+			)
+		}
+	}
+	return json.MarshalIndent(root, "", "  ")
+}
+
+// pointerTokens returns the unescaped reference tokens of the supplied JSON
+// Pointer
+func pointerTokens(pointer string) ([]string, error) {
+	if !strings.HasPrefix(pointer, "/") {
+		return nil, fmt.Errorf("path %q must start with a /", pointer)
+	}
+	tokens := strings.Split(pointer[1:], "/")
+	for i, token := range tokens {
+		token = strings.ReplaceAll(token, "~1", "/")
+		tokens[i] = strings.ReplaceAll(token, "~0", "~")
+	}
+	return tokens, nil
+}
+
+// applyModelOverride applies the supplied override to the value at the path
+// made of the supplied tokens within the supplied node, and returns the
+// overridden node
+func applyModelOverride(
+	node interface{},
+	tokens []string,
+	override ackgenconfig.ModelOverrideConfig,
+) (interface{}, error) {
+	token := tokens[0]
+	last := len(tokens) == 1
+	switch n := node.(type) {
+	case map[string]interface{}:
+		child, found := n[token]
+		if !found && (!last || override.Op != ackgenconfig.ModelOverrideAdd) {
+			return nil, fmt.Errorf("%q: %v", token, ErrModelOverridePathNotFound)
+		}
+		if !last {
+			child, err := applyModelOverride(child, tokens[1:], override)
+			if err != nil {
+				return nil, err
+			}
+			n[token] = child
+			return n, nil
+		}
+		if override.Op == ackgenconfig.ModelOverrideRemove {
+			delete(n, token)
+		} else {
+			n[token] = override.Value
+		}
+		return n, nil
+	case []interface{}:
+		if last && override.Op == ackgenconfig.ModelOverrideAdd && token == "-" {
+			return append(n, override.Value), nil
+		}
+		i, err := strconv.Atoi(token)
+		size := len(n)
+		if last && override.Op == ackgenconfig.ModelOverrideAdd {
+			// Values can be inserted after the last element
+			size++
+		}
+		if err != nil || i < 0 || i >= size {
+			return nil, fmt.Errorf("%q: %v", token, ErrModelOverridePathNotFound)
+		}
+		if !last {
+			child, err := applyModelOverride(n[i], tokens[1:], override)
+			if err != nil {
+				return nil, err
+			}
+			n[i] = child
+			return n, nil
+		}
+		switch override.Op {
+		case ackgenconfig.ModelOverrideAdd:
+			n = append(n, nil)
+			copy(n[i+1:], n[i:])
+			n[i] = override.Value
+		case ackgenconfig.ModelOverrideReplace:
+			n[i] = override.Value
+		default:
+			n = append(n[:i], n[i+1:]...)
+		}
+		return n, nil
+	default:
+		return nil, fmt.Errorf("%q: %v", token, ErrModelOverridePathNotFound)
+	}
+}
@@ -0,0 +1,166 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//	 http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package sdk_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	config "github.com/aws-controllers-k8s/code-generator/pkg/generate/config"
+	"github.com/aws-controllers-k8s/code-generator/pkg/sdk"
+)
+
+const modelOverridesTestModel = `{
+  "shapes": {
+    "Broken": {"type": "structure", "members": {"a": {"shape": "Missing"}}},
+    "Mutability": {"type": "string", "enum": ["MUTABLE", "IMMUTABLE"]},
+    "Repository": {
+      "type": "structure",
+      "members": {"name": {"shape": "String"}},
+      "max": 256
+    }
+  }
+}`
+
+func TestApplyModelOverrides(t *testing.T) {
+	tests := []struct {
+		name      string
+		overrides []config.ModelOverrideConfig
+		wantPath  []string
+		want      interface{}
+		wantErr   string
+	}{
+		{
+			name: "add member",
+			overrides: []config.ModelOverrideConfig{{
+				Op:    "add",
+				Path:  "/shapes/Repository/members/description",
+				Value: map[string]interface{}{"shape": "String"},
+			}},
+			wantPath: []string{"shapes", "Repository", "members", "description", "shape"},
+			want:     "String",
+		},
+		{
+			name: "append enum value",
+			overrides: []config.ModelOverrideConfig{{
+				Op: "add", Path: "/shapes/Mutability/enum/-", Value: "FROZEN",
+			}},
+			wantPath: []string{"shapes", "Mutability", "enum"},
+			want:     []interface{}{"MUTABLE", "IMMUTABLE", "FROZEN"},
+		},
+		{
+			name: "insert enum value",
+			overrides: []config.ModelOverrideConfig{{
+				Op: "add", Path: "/shapes/Mutability/enum/0", Value: "FROZEN",
+			}},
+			wantPath: []string{"shapes", "Mutability", "enum"},
+			want:     []interface{}{"FROZEN", "MUTABLE", "IMMUTABLE"},
+		},
+		{
+			name: "replace enum values",
+			overrides: []config.ModelOverrideConfig{{
+				Op: "replace", Path: "/shapes/Mutability/enum/1", Value: "LOCKED",
+			}},
+			wantPath: []string{"shapes", "Mutability", "enum"},
+			want:     []interface{}{"MUTABLE", "LOCKED"},
+		},
+		{
+			name: "remove shape",
+			overrides: []config.ModelOverrideConfig{{
+				Op: "remove", Path: "/shapes/Broken",
+			}},
+			wantPath: []string{"shapes", "Broken"},
+			want:     nil,
+		},
+		{
+			name: "numbers are kept as is",
+			overrides: []config.ModelOverrideConfig{{
+				Op: "remove", Path: "/shapes/Broken",
+			}},
+			wantPath: []string{"shapes", "Repository", "max"},
+			want:     json.Number("256"),
+		},
+		{
+			name: "replace missing member",
+			overrides: []config.ModelOverrideConfig{{
+				Op: "replace", Path: "/shapes/Repository/members/arn", Value: "x",
+			}},
+			wantErr: `model_overrides[0]: replace /shapes/Repository/members/arn: "arn": no such path in the api model`,
+		},
+		{
+			name: "remove out of range enum value",
+			overrides: []config.ModelOverrideConfig{{
+				Op: "remove", Path: "/shapes/Mutability/enum/2",
+			}},
+			wantErr: `"2": no such path in the api model`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert := assert.New(t)
+			require := require.New(t)
+
+			b, err := sdk.ApplyModelOverrides([]byte(modelOverridesTestModel), tt.overrides)
+			if tt.wantErr != "" {
+				require.NotNil(err)
+				assert.Contains(err.Error(), tt.wantErr)
+				return
+			}
+			require.Nil(err)
+
+			decoder := json.NewDecoder(bytes.NewReader(b))
+			decoder.UseNumber()
+			var got interface{}
+			require.Nil(decoder.Decode(&got))
+			for _, token := range tt.wantPath {
+				got = got.(map[string]interface{})[token]
+			}
+			assert.Equal(tt.want, got)
+		})
+	}
+}
+
+func TestAPI_ModelOverrides(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	cfg := config.Config{
+		ModelOverrides: []config.ModelOverrideConfig{
+			{
+				Op:    "add",
+				Path:  "/shapes/ImageTagMutability/enum/-",
+				Value: "FROZEN",
+			},
+			{
+				Op:   "remove",
+				Path: "/shapes/Repository/members/createdAt",
+			},
+		},
+	}
+	sdkHelper := sdk.NewHelper(filepath.Clean("../testdata"), cfg)
+	api, err := sdkHelper.API("ecr")
+	require.Nil(err)
+	assert.Equal(
+		[]string{"MUTABLE", "IMMUTABLE", "FROZEN"},
+		api.API.Shapes["ImageTagMutability"].Enum,
+	)
+	assert.NotContains(api.API.Shapes["Repository"].MemberRefs, "createdAt")
+	// The documentation is still loaded alongside the overridden model
+	assert.NotEmpty(api.API.Operations["CreateRepository"].Documentation)
+}
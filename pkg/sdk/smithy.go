@@ -0,0 +1,635 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package sdk
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+const (
+	// ModelFormatAPI2 is the aws-sdk-go (v1) `api-2.json` model format
+	ModelFormatAPI2 = "api-2"
+	// ModelFormatSmithy is the Smithy JSON AST model format used by
+	// aws-sdk-go-v2
+	ModelFormatSmithy = "smithy"
+
+	smithyPreludeNamespace = "smithy.api"
+	smithyUnitShape        = "smithy.api#Unit"
+)
+
+var (
+	ErrUnknownModelFormat = errors.New(
+		"unknown model format",
+	)
+	ErrSmithyServiceNotFound = errors.New(
+		"no service shape found in Smithy model",
+	)
+)
+
+// smithyModel is the top-level document of a Smithy JSON AST model file
+type smithyModel struct {
+	Smithy string                  `json:"smithy"`
+	Shapes map[string]*smithyShape `json:"shapes"`
+}
+
+// smithyTarget is a reference to another shape in a Smithy model, optionally
+// decorated with traits (for structure members)
+type smithyTarget struct {
+	Target string                     `json:"target"`
+	Traits map[string]json.RawMessage `json:"traits,omitempty"`
+}
+
+type smithyShape struct {
+	Type       string                   `json:"type"`
+	Version    string                   `json:"version,omitempty"`
+	Operations []smithyTarget           `json:"operations,omitempty"`
+	Resources  []smithyTarget           `json:"resources,omitempty"`
+	Input      *smithyTarget            `json:"input,omitempty"`
+	Output     *smithyTarget            `json:"output,omitempty"`
+	Errors     []smithyTarget           `json:"errors,omitempty"`
+	Members    map[string]*smithyTarget `json:"members,omitempty"`
+	Member     *smithyTarget            `json:"member,omitempty"`
+	Key        *smithyTarget            `json:"key,omitempty"`
+	Value      *smithyTarget            `json:"value,omitempty"`
+	// Resource shapes bind lifecycle operations individually
+	Create    *smithyTarget              `json:"create,omitempty"`
+	Put       *smithyTarget              `json:"put,omitempty"`
+	Read      *smithyTarget              `json:"read,omitempty"`
+	Update    *smithyTarget              `json:"update,omitempty"`
+	Delete    *smithyTarget              `json:"delete,omitempty"`
+	List      *smithyTarget              `json:"list,omitempty"`
+	Traits    map[string]json.RawMessage `json:"traits,omitempty"`
+	localName string
+}
+
+// api2Document mirrors the subset of the aws-sdk-go `api-2.json` document
+// that the aws-sdk-go model loader consumes.
+type api2Document struct {
+	Version    string                    `json:"version"`
+	Metadata   map[string]interface{}    `json:"metadata"`
+	Operations map[string]*api2Operation `json:"operations"`
+	Shapes     map[string]*api2Shape     `json:"shapes"`
+}
+
+type api2HTTP struct {
+	Method       string `json:"method"`
+	RequestURI   string `json:"requestUri"`
+	ResponseCode int    `json:"responseCode,omitempty"`
+}
+
+type api2Operation struct {
+	Name          string          `json:"name"`
+	HTTP          api2HTTP        `json:"http"`
+	Input         *api2ShapeRef   `json:"input,omitempty"`
+	Output        *api2ShapeRef   `json:"output,omitempty"`
+	Errors        []*api2ShapeRef `json:"errors,omitempty"`
+	Documentation string          `json:"documentation,omitempty"`
+	Deprecated    bool            `json:"deprecated,omitempty"`
+}
+
+type api2ShapeRef struct {
+	Shape            string `json:"shape"`
+	Documentation    string `json:"documentation,omitempty"`
+	Location         string `json:"location,omitempty"`
+	LocationName     string `json:"locationName,omitempty"`
+	IdempotencyToken bool   `json:"idempotencyToken,omitempty"`
+	TimestampFormat  string `json:"timestampFormat,omitempty"`
+	Deprecated       bool   `json:"deprecated,omitempty"`
+}
+
+type api2Error struct {
+	Code           string `json:"code,omitempty"`
+	HTTPStatusCode int    `json:"httpStatusCode,omitempty"`
+	SenderFault    bool   `json:"senderFault,omitempty"`
+}
+
+type api2Shape struct {
+	Type          string                   `json:"type"`
+	Members       map[string]*api2ShapeRef `json:"members,omitempty"`
+	Member        *api2ShapeRef            `json:"member,omitempty"`
+	Key           *api2ShapeRef            `json:"key,omitempty"`
+	Value         *api2ShapeRef            `json:"value,omitempty"`
+	Required      []string                 `json:"required,omitempty"`
+	Enum          []string                 `json:"enum,omitempty"`
+	Min           *float64                 `json:"min,omitempty"`
+	Max           *float64                 `json:"max,omitempty"`
+	Pattern       string                   `json:"pattern,omitempty"`
+	Default       json.RawMessage          `json:"default,omitempty"`
+	Sensitive     bool                     `json:"sensitive,omitempty"`
+	Exception     bool                     `json:"exception,omitempty"`
+	Error         *api2Error               `json:"error,omitempty"`
+	Document      bool                     `json:"document,omitempty"`
+	Union         bool                     `json:"union,omitempty"`
+	Deprecated    bool                     `json:"deprecated,omitempty"`
+	Documentation string                   `json:"documentation,omitempty"`
+}
+
+// smithySimpleTypes maps Smithy simple shape types to their `api-2.json`
+// equivalent
+var smithySimpleTypes = map[string]string{
+	"blob":       "blob",
+	"boolean":    "boolean",
+	"string":     "string",
+	"byte":       "integer",
+	"short":      "integer",
+	"integer":    "integer",
+	"intEnum":    "integer",
+	"long":       "long",
+	"float":      "float",
+	"double":     "double",
+	"bigInteger": "long",
+	"bigDecimal": "double",
+	"timestamp":  "timestamp",
+	"enum":       "string",
+}
+
+// smithyProtocols maps Smithy AWS protocol traits to the `api-2.json`
+// protocol and JSON version metadata values
+var smithyProtocols = map[string][2]string{
+	"aws.protocols#awsJson1_0": {"json", "1.0"},
+	"aws.protocols#awsJson1_1": {"json", "1.1"},
+	"aws.protocols#restJson1":  {"rest-json", ""},
+	"aws.protocols#restXml":    {"rest-xml", ""},
+	"aws.protocols#awsQuery":   {"query", ""},
+	"aws.protocols#ec2Query":   {"ec2", ""},
+}
+
+// ConvertSmithyModel converts the contents of a Smithy JSON AST model file
+// (the format aws-sdk-go-v2 uses in `codegen/sdk-codegen/aws-models`) into
+// an equivalent aws-sdk-go `api-2.json` document that can be consumed by the
+// aws-sdk-go model loader.
+func ConvertSmithyModel(data []byte) ([]byte, error) {
+	sm := &smithyModel{}
+	if err := json.Unmarshal(data, sm); err != nil {
+		return nil, fmt.Errorf("cannot parse Smithy model: %v", err)
+	}
+	c := &smithyConverter{
+		model: sm,
+		doc: &api2Document{
+			Version:    "2.0",
+			Metadata:   map[string]interface{}{},
+			Operations: map[string]*api2Operation{},
+			Shapes:     map[string]*api2Shape{},
+		},
+	}
+	if err := c.convert(); err != nil {
+		return nil, err
+	}
+	return json.MarshalIndent(c.doc, "", "  ")
+}
+
+type smithyConverter struct {
+	model *smithyModel
+	doc   *api2Document
+}
+
+func (c *smithyConverter) convert() error {
+	var serviceID string
+	var service *smithyShape
+	// Iterate in a stable order so that any conflicting local names are
+	// resolved deterministically
+	shapeIDs := make([]string, 0, len(c.model.Shapes))
+	for shapeID, shape := range c.model.Shapes {
+		shape.localName = smithyLocalName(shapeID)
+		shapeIDs = append(shapeIDs, shapeID)
+	}
+	sort.Strings(shapeIDs)
+	for _, shapeID := range shapeIDs {
+		if c.model.Shapes[shapeID].Type == "service" {
+			serviceID = shapeID
+			service = c.model.Shapes[shapeID]
+			break
+		}
+	}
+	if service == nil {
+		return ErrSmithyServiceNotFound
+	}
+	c.convertMetadata(serviceID, service)
+
+	for _, opID := range c.serviceOperationIDs(service) {
+		if err := c.convertOperation(opID); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// serviceOperationIDs returns the sorted, de-duplicated set of operation
+// shape IDs bound to the service, either directly or via resources
+func (c *smithyConverter) serviceOperationIDs(service *smithyShape) []string {
+	seen := map[string]bool{}
+	var walkResource func(resID string)
+	addTarget := func(t *smithyTarget) {
+		if t != nil {
+			seen[t.Target] = true
+		}
+	}
+	walkResource = func(resID string) {
+		res, ok := c.model.Shapes[resID]
+		if !ok {
+			return
+		}
+		for _, t := range []*smithyTarget{
+			res.Create, res.Put, res.Read, res.Update, res.Delete, res.List,
+		} {
+			addTarget(t)
+		}
+		for _, op := range res.Operations {
+			seen[op.Target] = true
+		}
+		for _, sub := range res.Resources {
+			walkResource(sub.Target)
+		}
+	}
+	for _, op := range service.Operations {
+		seen[op.Target] = true
+	}
+	for _, res := range service.Resources {
+		walkResource(res.Target)
+	}
+	opIDs := make([]string, 0, len(seen))
+	for opID := range seen {
+		opIDs = append(opIDs, opID)
+	}
+	sort.Strings(opIDs)
+	return opIDs
+}
+
+func (c *smithyConverter) convertMetadata(serviceID string, service *smithyShape) {
+	md := c.doc.Metadata
+	md["apiVersion"] = service.Version
+	md["signatureVersion"] = "v4"
+	md["targetPrefix"] = smithyLocalName(serviceID)
+
+	awsService := struct {
+		SDKID          string `json:"sdkId"`
+		ArnNamespace   string `json:"arnNamespace"`
+		EndpointPrefix string `json:"endpointPrefix"`
+	}{}
+	if raw, ok := service.Traits["aws.api#service"]; ok {
+		json.Unmarshal(raw, &awsService)
+	}
+	endpointPrefix := awsService.EndpointPrefix
+	if endpointPrefix == "" {
+		endpointPrefix = awsService.ArnNamespace
+	}
+	md["endpointPrefix"] = endpointPrefix
+	md["serviceId"] = awsService.SDKID
+	md["uid"] = fmt.Sprintf("%s-%s", endpointPrefix, service.Version)
+
+	sigv4 := struct {
+		Name string `json:"name"`
+	}{}
+	if raw, ok := service.Traits["aws.auth#sigv4"]; ok {
+		json.Unmarshal(raw, &sigv4)
+		md["signingName"] = sigv4.Name
+	}
+	if title := c.stringTrait(service.Traits, "smithy.api#title"); title != "" {
+		md["serviceFullName"] = title
+	}
+	for trait, protocol := range smithyProtocols {
+		if _, ok := service.Traits[trait]; ok {
+			md["protocol"] = protocol[0]
+			if protocol[1] != "" {
+				md["jsonVersion"] = protocol[1]
+			}
+			break
+		}
+	}
+	if doc := c.stringTrait(service.Traits, "smithy.api#documentation"); doc != "" {
+		md["documentation"] = doc
+	}
+}
+
+func (c *smithyConverter) convertOperation(opID string) error {
+	op, ok := c.model.Shapes[opID]
+	if !ok || op.Type != "operation" {
+		return fmt.Errorf("operation %s referenced by service but not found in Smithy model", opID)
+	}
+	api2Op := &api2Operation{
+		Name: op.localName,
+		HTTP: api2HTTP{
+			Method:     "POST",
+			RequestURI: "/",
+		},
+		Documentation: c.stringTrait(op.Traits, "smithy.api#documentation"),
+		Deprecated:    c.hasTrait(op.Traits, "smithy.api#deprecated"),
+	}
+	if raw, ok := op.Traits["smithy.api#http"]; ok {
+		http := struct {
+			Method string `json:"method"`
+			URI    string `json:"uri"`
+			Code   int    `json:"code"`
+		}{}
+		if err := json.Unmarshal(raw, &http); err != nil {
+			return fmt.Errorf("cannot parse http trait of %s: %v", opID, err)
+		}
+		api2Op.HTTP = api2HTTP{
+			Method:       http.Method,
+			RequestURI:   http.URI,
+			ResponseCode: http.Code,
+		}
+	}
+	var err error
+	if api2Op.Input, err = c.convertOperationRef(op.Input); err != nil {
+		return err
+	}
+	if api2Op.Output, err = c.convertOperationRef(op.Output); err != nil {
+		return err
+	}
+	for _, errTarget := range op.Errors {
+		ref, err := c.convertOperationRef(&errTarget)
+		if err != nil {
+			return err
+		}
+		api2Op.Errors = append(api2Op.Errors, ref)
+	}
+	c.doc.Operations[op.localName] = api2Op
+	return nil
+}
+
+// convertOperationRef returns the shape reference for an operation's input,
+// output or error, converting the referenced shape if necessary. Operations
+// with no input or output are modeled in Smithy as targeting `smithy.api#Unit`
+func (c *smithyConverter) convertOperationRef(t *smithyTarget) (*api2ShapeRef, error) {
+	if t == nil || t.Target == smithyUnitShape {
+		return nil, nil
+	}
+	name, err := c.convertShape(t.Target)
+	if err != nil {
+		return nil, err
+	}
+	return &api2ShapeRef{Shape: name}, nil
+}
+
+// convertMember returns the shape reference for a structure member, list
+// member or map key/value, converting the targeted shape if necessary.
+func (c *smithyConverter) convertMember(t *smithyTarget) (*api2ShapeRef, error) {
+	name, err := c.convertShape(t.Target)
+	if err != nil {
+		return nil, err
+	}
+	ref := &api2ShapeRef{
+		Shape:            name,
+		Documentation:    c.stringTrait(t.Traits, "smithy.api#documentation"),
+		IdempotencyToken: c.hasTrait(t.Traits, "smithy.api#idempotencyToken"),
+		TimestampFormat:  c.stringTrait(t.Traits, "smithy.api#timestampFormat"),
+		Deprecated:       c.hasTrait(t.Traits, "smithy.api#deprecated"),
+	}
+	switch {
+	case c.hasTrait(t.Traits, "smithy.api#httpLabel"):
+		ref.Location = "uri"
+	case c.hasTrait(t.Traits, "smithy.api#httpQuery"):
+		ref.Location = "querystring"
+		ref.LocationName = c.stringTrait(t.Traits, "smithy.api#httpQuery")
+	case c.hasTrait(t.Traits, "smithy.api#httpHeader"):
+		ref.Location = "header"
+		ref.LocationName = c.stringTrait(t.Traits, "smithy.api#httpHeader")
+	case c.hasTrait(t.Traits, "smithy.api#jsonName"):
+		ref.LocationName = c.stringTrait(t.Traits, "smithy.api#jsonName")
+	}
+	return ref, nil
+}
+
+// convertShape converts the Smithy shape with the supplied absolute shape ID
+// and returns the name of the resulting `api-2.json` shape.
+func (c *smithyConverter) convertShape(shapeID string) (string, error) {
+	shape, ok := c.model.Shapes[shapeID]
+	if !ok {
+		if strings.HasPrefix(shapeID, smithyPreludeNamespace+"#") {
+			return c.convertPreludeShape(shapeID)
+		}
+		return "", fmt.Errorf("shape %s not found in Smithy model", shapeID)
+	}
+	name := shape.localName
+	if _, converted := c.doc.Shapes[name]; converted {
+		return name, nil
+	}
+	api2 := &api2Shape{
+		Documentation: c.stringTrait(shape.Traits, "smithy.api#documentation"),
+		Sensitive:     c.hasTrait(shape.Traits, "smithy.api#sensitive"),
+		Deprecated:    c.hasTrait(shape.Traits, "smithy.api#deprecated"),
+		Pattern:       c.stringTrait(shape.Traits, "smithy.api#pattern"),
+		Default:       shape.Traits["smithy.api#default"],
+	}
+	// Register the shape before converting members so that recursive
+	// shapes terminate
+	c.doc.Shapes[name] = api2
+	c.applyConstraintTraits(api2, shape.Traits)
+
+	var err error
+	switch shape.Type {
+	case "structure", "union":
+		api2.Type = "structure"
+		api2.Union = shape.Type == "union"
+		api2.Members = map[string]*api2ShapeRef{}
+		memberNames := make([]string, 0, len(shape.Members))
+		for memberName := range shape.Members {
+			memberNames = append(memberNames, memberName)
+		}
+		sort.Strings(memberNames)
+		for _, memberName := range memberNames {
+			member := shape.Members[memberName]
+			if api2.Members[memberName], err = c.convertMember(member); err != nil {
+				return "", err
+			}
+			if c.hasTrait(member.Traits, "smithy.api#required") {
+				api2.Required = append(api2.Required, memberName)
+			}
+		}
+		if c.hasTrait(shape.Traits, "smithy.api#error") {
+			c.applyErrorTraits(api2, name, shape.Traits)
+		}
+	case "list", "set":
+		api2.Type = "list"
+		if shape.Member == nil {
+			return "", fmt.Errorf("list shape %s has no member", shapeID)
+		}
+		if api2.Member, err = c.convertMember(shape.Member); err != nil {
+			return "", err
+		}
+	case "map":
+		api2.Type = "map"
+		if shape.Key == nil || shape.Value == nil {
+			return "", fmt.Errorf("map shape %s has no key or value", shapeID)
+		}
+		if api2.Key, err = c.convertMember(shape.Key); err != nil {
+			return "", err
+		}
+		if api2.Value, err = c.convertMember(shape.Value); err != nil {
+			return "", err
+		}
+	case "document":
+		api2.Type = "structure"
+		api2.Document = true
+	default:
+		simpleType, ok := smithySimpleTypes[shape.Type]
+		if !ok {
+			return "", fmt.Errorf(
+				"shape %s has unsupported Smithy type %s", shapeID, shape.Type,
+			)
+		}
+		api2.Type = simpleType
+		api2.Enum = c.enumValues(shape)
+	}
+	return name, nil
+}
+
+// convertPreludeShape converts a reference to one of the Smithy prelude
+// shapes (e.g. `smithy.api#String`) into an equivalent simple shape
+func (c *smithyConverter) convertPreludeShape(shapeID string) (string, error) {
+	name := smithyLocalName(shapeID)
+	if _, converted := c.doc.Shapes[name]; converted {
+		return name, nil
+	}
+	smithyType := strings.ToLower(name[:1]) + name[1:]
+	// Prelude shapes like `PrimitiveInteger` are the non-nullable variants
+	smithyType = strings.TrimPrefix(smithyType, "primitive")
+	smithyType = strings.ToLower(smithyType[:1]) + smithyType[1:]
+	if smithyType == "document" {
+		c.doc.Shapes[name] = &api2Shape{Type: "structure", Document: true}
+		return name, nil
+	}
+	simpleType, ok := smithySimpleTypes[smithyType]
+	if !ok {
+		return "", fmt.Errorf("unsupported Smithy prelude shape %s", shapeID)
+	}
+	c.doc.Shapes[name] = &api2Shape{Type: simpleType}
+	return name, nil
+}
+
+// enumValues returns the enumerated values of a Smithy 1.0 `@enum` string
+// shape or a Smithy 2.0 `enum` shape, or nil if the shape is not an enum
+func (c *smithyConverter) enumValues(shape *smithyShape) []string {
+	values := []string{}
+	if raw, ok := shape.Traits["smithy.api#enum"]; ok {
+		defs := []struct {
+			Value string `json:"value"`
+		}{}
+		json.Unmarshal(raw, &defs)
+		for _, def := range defs {
+			values = append(values, def.Value)
+		}
+	}
+	if shape.Type == "enum" {
+		memberNames := make([]string, 0, len(shape.Members))
+		for memberName := range shape.Members {
+			memberNames = append(memberNames, memberName)
+		}
+		sort.Strings(memberNames)
+		for _, memberName := range memberNames {
+			value := c.stringTrait(shape.Members[memberName].Traits, "smithy.api#enumValue")
+			if value == "" {
+				value = memberName
+			}
+			values = append(values, value)
+		}
+	}
+	if len(values) == 0 {
+		return nil
+	}
+	return values
+}
+
+// applyConstraintTraits translates the Smithy `@length` and `@range` traits
+// into the `api-2.json` min and max attributes
+func (c *smithyConverter) applyConstraintTraits(
+	api2 *api2Shape,
+	traits map[string]json.RawMessage,
+) {
+	for _, trait := range []string{"smithy.api#length", "smithy.api#range"} {
+		raw, ok := traits[trait]
+		if !ok {
+			continue
+		}
+		bounds := struct {
+			Min *float64 `json:"min"`
+			Max *float64 `json:"max"`
+		}{}
+		if err := json.Unmarshal(raw, &bounds); err == nil {
+			api2.Min = bounds.Min
+			api2.Max = bounds.Max
+		}
+	}
+}
+
+// applyErrorTraits marks the shape as an exception and translates the
+// Smithy `@error`, `@httpError` and `aws.protocols#awsQueryError` traits
+func (c *smithyConverter) applyErrorTraits(
+	api2 *api2Shape,
+	name string,
+	traits map[string]json.RawMessage,
+) {
+	api2.Exception = true
+	api2.Error = &api2Error{
+		Code:        name,
+		SenderFault: c.stringTrait(traits, "smithy.api#error") == "client",
+	}
+	if raw, ok := traits["smithy.api#httpError"]; ok {
+		json.Unmarshal(raw, &api2.Error.HTTPStatusCode)
+	}
+	if raw, ok := traits["aws.protocols#awsQueryError"]; ok {
+		queryError := struct {
+			Code           string `json:"code"`
+			HTTPStatusCode int    `json:"httpResponseCode"`
+		}{}
+		if err := json.Unmarshal(raw, &queryError); err == nil {
+			api2.Error.Code = queryError.Code
+			api2.Error.HTTPStatusCode = queryError.HTTPStatusCode
+		}
+	}
+}
+
+// hasTrait returns true if the trait is present in the supplied trait map
+func (c *smithyConverter) hasTrait(
+	traits map[string]json.RawMessage,
+	trait string,
+) bool {
+	_, ok := traits[trait]
+	return ok
+}
+
+// stringTrait returns the value of a string-valued trait, or the empty
+// string if the trait is absent or isn't a string
+func (c *smithyConverter) stringTrait(
+	traits map[string]json.RawMessage,
+	trait string,
+) string {
+	raw, ok := traits[trait]
+	if !ok {
+		return ""
+	}
+	var s string
+	if err := json.Unmarshal(raw, &s); err != nil {
+		return ""
+	}
+	return s
+}
+
+// smithyLocalName returns the name portion of an absolute Smithy shape ID.
+// e.g. "com.amazonaws.ecr#Repository" -> "Repository"
+func smithyLocalName(shapeID string) string {
+	if idx := strings.Index(shapeID, "#"); idx >= 0 {
+		shapeID = shapeID[idx+1:]
+	}
+	// Strip any member component. e.g. "Repository$repositoryName"
+	if idx := strings.Index(shapeID, "$"); idx >= 0 {
+		shapeID = shapeID[:idx]
+	}
+	return shapeID
+}
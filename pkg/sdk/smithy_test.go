@@ -0,0 +1,147 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//	 http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package sdk_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/aws-controllers-k8s/code-generator/pkg/sdk"
+)
+
+const smithyTestModel = `{
+  "smithy": "1.0",
+  "shapes": {
+    "com.amazonaws.ecr#AmazonEC2ContainerRegistry_V20150921": {
+      "type": "service",
+      "version": "2015-09-21",
+      "operations": [
+        {"target": "com.amazonaws.ecr#CreateRepository"}
+      ],
+      "traits": {
+        "aws.api#service": {"sdkId": "ECR", "arnNamespace": "ecr", "endpointPrefix": "api.ecr"},
+        "aws.auth#sigv4": {"name": "ecr"},
+        "aws.protocols#awsJson1_1": {},
+        "smithy.api#title": "Amazon EC2 Container Registry"
+      }
+    },
+    "com.amazonaws.ecr#CreateRepository": {
+      "type": "operation",
+      "input": {"target": "com.amazonaws.ecr#CreateRepositoryRequest"},
+      "output": {"target": "com.amazonaws.ecr#CreateRepositoryResponse"},
+      "errors": [{"target": "com.amazonaws.ecr#RepositoryAlreadyExistsException"}],
+      "traits": {"smithy.api#documentation": "<p>Creates a repository.</p>"}
+    },
+    "com.amazonaws.ecr#CreateRepositoryRequest": {
+      "type": "structure",
+      "members": {
+        "repositoryName": {
+          "target": "com.amazonaws.ecr#RepositoryName",
+          "traits": {"smithy.api#required": {}}
+        },
+        "imageTagMutability": {"target": "com.amazonaws.ecr#ImageTagMutability"},
+        "tags": {"target": "com.amazonaws.ecr#TagList"}
+      }
+    },
+    "com.amazonaws.ecr#CreateRepositoryResponse": {
+      "type": "structure",
+      "members": {
+        "createdAt": {"target": "smithy.api#Timestamp"}
+      }
+    },
+    "com.amazonaws.ecr#RepositoryName": {
+      "type": "string",
+      "traits": {"smithy.api#length": {"min": 2, "max": 256}}
+    },
+    "com.amazonaws.ecr#ImageTagMutability": {
+      "type": "string",
+      "traits": {"smithy.api#enum": [{"value": "MUTABLE"}, {"value": "IMMUTABLE"}]}
+    },
+    "com.amazonaws.ecr#TagList": {
+      "type": "list",
+      "member": {"target": "com.amazonaws.ecr#Tag"}
+    },
+    "com.amazonaws.ecr#Tag": {
+      "type": "structure",
+      "members": {
+        "Key": {"target": "smithy.api#String"},
+        "Value": {"target": "smithy.api#String"}
+      }
+    },
+    "com.amazonaws.ecr#RepositoryAlreadyExistsException": {
+      "type": "structure",
+      "members": {},
+      "traits": {"smithy.api#error": "client", "smithy.api#httpError": 400}
+    }
+  }
+}`
+
+func TestConvertSmithyModel(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	b, err := sdk.ConvertSmithyModel([]byte(smithyTestModel))
+	require.Nil(err)
+
+	doc := struct {
+		Metadata   map[string]string `json:"metadata"`
+		Operations map[string]struct {
+			Input  struct{ Shape string }   `json:"input"`
+			Output struct{ Shape string }   `json:"output"`
+			Errors []struct{ Shape string } `json:"errors"`
+		} `json:"operations"`
+		Shapes map[string]struct {
+			Type      string   `json:"type"`
+			Required  []string `json:"required"`
+			Enum      []string `json:"enum"`
+			Min       float64  `json:"min"`
+			Exception bool     `json:"exception"`
+			Member    struct {
+				Shape string `json:"shape"`
+			} `json:"member"`
+		} `json:"shapes"`
+	}{}
+	require.Nil(json.Unmarshal(b, &doc))
+
+	assert.Equal("2015-09-21", doc.Metadata["apiVersion"])
+	assert.Equal("api.ecr", doc.Metadata["endpointPrefix"])
+	assert.Equal("ECR", doc.Metadata["serviceId"])
+	assert.Equal("json", doc.Metadata["protocol"])
+	assert.Equal("1.1", doc.Metadata["jsonVersion"])
+	assert.Equal("AmazonEC2ContainerRegistry_V20150921", doc.Metadata["targetPrefix"])
+
+	require.Contains(doc.Operations, "CreateRepository")
+	op := doc.Operations["CreateRepository"]
+	assert.Equal("CreateRepositoryRequest", op.Input.Shape)
+	assert.Equal("CreateRepositoryResponse", op.Output.Shape)
+	require.Len(op.Errors, 1)
+	assert.Equal("RepositoryAlreadyExistsException", op.Errors[0].Shape)
+
+	assert.Equal([]string{"repositoryName"}, doc.Shapes["CreateRepositoryRequest"].Required)
+	assert.Equal([]string{"MUTABLE", "IMMUTABLE"}, doc.Shapes["ImageTagMutability"].Enum)
+	assert.Equal(float64(2), doc.Shapes["RepositoryName"].Min)
+	assert.Equal("list", doc.Shapes["TagList"].Type)
+	assert.Equal("Tag", doc.Shapes["TagList"].Member.Shape)
+	assert.Equal("timestamp", doc.Shapes["Timestamp"].Type)
+	assert.Equal("string", doc.Shapes["String"].Type)
+	assert.True(doc.Shapes["RepositoryAlreadyExistsException"].Exception)
+}
+
+func TestConvertSmithyModel_NoService(t *testing.T) {
+	_, err := sdk.ConvertSmithyModel([]byte(`{"smithy": "1.0", "shapes": {}}`))
+	assert.Equal(t, sdk.ErrSmithyServiceNotFound, err)
+}
@@ -34,6 +34,8 @@ type TestingModelOptions struct {
 	GeneratorConfigFile string
 	// The AWS Service's API version. Defaults to 00-00-0000
 	ServiceAPIVersion string
+	// The format of the service model files. Defaults to api-2
+	ModelFormat string
 }
 
 // SetDefaults sets the empty fields to a default value.
@@ -84,6 +86,9 @@ func NewModelForServiceWithOptions(t *testing.T, servicePackageName string, opti
 	}
 	sdkHelper := acksdk.NewHelper(path, cfg)
 	sdkHelper.WithAPIVersion(options.ServiceAPIVersion)
+	if err := sdkHelper.WithModelFormat(options.ModelFormat); err != nil {
+		t.Fatal(err)
+	}
 	sdkAPI, err := sdkHelper.API(servicePackageName)
 	if err != nil {
 		t.Fatal(err)
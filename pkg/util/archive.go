@@ -0,0 +1,166 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package util
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+)
+
+// DownloadTarball downloads the gzipped tarball at the supplied URL, sending
+// the supplied request headers, and extracts it into destPath with
+// ExtractTarball.
+//
+// Calling this function is equivalent to executing
+// `curl -L $tarballURL | tar -xz --strip-components 1 -C $destPath $includePaths`
+// If progress is not nil, it is called with the number of bytes downloaded.
+func DownloadTarball(
+	ctx context.Context,
+	tarballURL string,
+	header http.Header,
+	destPath string,
+	includePaths []string,
+	progress ProgressFunc,
+) error {
+	req, err := http.NewRequest(http.MethodGet, tarballURL, nil)
+	if err != nil {
+		return err
+	}
+	req = req.WithContext(ctx)
+	for key, values := range header {
+		req.Header[key] = values
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("cannot download %s: %s", tarballURL, resp.Status)
+	}
+	var body io.Reader = resp.Body
+	if progress != nil {
+		body = &progressReader{r: resp.Body, fn: progress}
+	}
+	return ExtractTarball(body, destPath, includePaths)
+}
+
+// ExtractTarball extracts the regular files and directories of the supplied
+// gzipped tarball into destPath, stripping the top-level directory of the
+// archive's paths, like GitHub's source code archives have. When
+// includePaths is not empty, only the files under the supplied paths,
+// relative to the top-level directory, are extracted.
+func ExtractTarball(r io.Reader, destPath string, includePaths []string) error {
+	gz, err := gzip.NewReader(r)
+	if err != nil {
+		return err
+	}
+	defer gz.Close()
+	tr := tar.NewReader(gz)
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		name := path.Clean(strings.TrimPrefix(hdr.Name, "./"))
+		// Reject the paths escaping the archive before stripping the
+		// top-level directory, which could be one of their ".." elements
+		if path.IsAbs(name) || name == ".." || strings.HasPrefix(name, "../") {
+			return fmt.Errorf("invalid path %s in archive", hdr.Name)
+		}
+		// Strip the top-level directory
+		parts := strings.SplitN(name, "/", 2)
+		if len(parts) != 2 {
+			continue
+		}
+		name = parts[1]
+		if !pathIncluded(name, includePaths) {
+			continue
+		}
+		target := filepath.Join(destPath, filepath.FromSlash(name))
+		switch hdr.Typeflag {
+		case tar.TypeDir:
+			if err = os.MkdirAll(target, os.ModePerm); err != nil {
+				return err
+			}
+		case tar.TypeReg:
+			if err = os.MkdirAll(filepath.Dir(target), os.ModePerm); err != nil {
+				return err
+			}
+			if err = extractTarFile(tr, target); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// extractTarFile writes the current file of the supplied tar reader at the
+// supplied path
+func extractTarFile(tr *tar.Reader, target string) error {
+	f, err := os.OpenFile(target, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, 0644)
+	if err != nil {
+		return err
+	}
+	if _, err = io.Copy(f, tr); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+// pathIncluded returns true if the supplied slash-separated path is, or is
+// under, one of the supplied paths, or if no paths are supplied
+func pathIncluded(name string, includePaths []string) bool {
+	if len(includePaths) == 0 {
+		return true
+	}
+	for _, includePath := range includePaths {
+		includePath = path.Clean(filepath.ToSlash(includePath))
+		if name == includePath || strings.HasPrefix(name, includePath+"/") ||
+			strings.HasPrefix(includePath, name+"/") {
+			return true
+		}
+	}
+	return false
+}
+
+// progressReader calls a ProgressFunc with the number of bytes read so far
+// every time another megabyte is read
+type progressReader struct {
+	r        io.Reader
+	fn       ProgressFunc
+	read     int64
+	reported int64
+}
+
+func (pr *progressReader) Read(p []byte) (int, error) {
+	n, err := pr.r.Read(p)
+	pr.read += int64(n)
+	if pr.read-pr.reported >= 1<<20 || (err == io.EOF && pr.read > pr.reported) {
+		pr.reported = pr.read
+		pr.fn(fmt.Sprintf("%.1f MiB", float64(pr.read)/(1<<20)))
+	}
+	return n, err
+}
@@ -0,0 +1,256 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package util_test
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sort"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/aws-controllers-k8s/code-generator/pkg/util"
+)
+
+// tarEntry is an entry of a tarball built by the tests
+type tarEntry struct {
+	name     string
+	typeflag byte
+	content  string
+}
+
+// newTarball returns a gzipped tarball of the supplied entries
+func newTarball(t *testing.T, entries []tarEntry) []byte {
+	buf := &bytes.Buffer{}
+	gz := gzip.NewWriter(buf)
+	tw := tar.NewWriter(gz)
+	for _, entry := range entries {
+		hdr := &tar.Header{
+			Name:     entry.name,
+			Typeflag: entry.typeflag,
+			Mode:     0644,
+			Size:     int64(len(entry.content)),
+		}
+		if entry.typeflag == tar.TypeDir {
+			hdr.Mode = 0755
+			hdr.Size = 0
+		}
+		if entry.typeflag == tar.TypeSymlink {
+			hdr.Linkname = entry.content
+			hdr.Size = 0
+		}
+		if entry.typeflag == tar.TypeXGlobalHeader {
+			hdr = &tar.Header{
+				Name:       entry.name,
+				Typeflag:   entry.typeflag,
+				PAXRecords: map[string]string{"comment": entry.content},
+			}
+		}
+		require.Nil(t, tw.WriteHeader(hdr))
+		if hdr.Size > 0 {
+			_, err := tw.Write([]byte(entry.content))
+			require.Nil(t, err)
+		}
+	}
+	require.Nil(t, tw.Close())
+	require.Nil(t, gz.Close())
+	return buf.Bytes()
+}
+
+// listFiles returns the slash-separated paths of the regular files under the
+// supplied directory, relative to it, mapped to their content. A missing
+// directory has no files.
+func listFiles(t *testing.T, dir string) map[string]string {
+	files := map[string]string{}
+	if _, err := os.Stat(dir); os.IsNotExist(err) {
+		return files
+	}
+	err := filepath.Walk(dir, func(path string, info os.FileInfo, err error) error {
+		if err != nil || info.IsDir() {
+			return err
+		}
+		relPath, err := filepath.Rel(dir, path)
+		if err != nil {
+			return err
+		}
+		content, err := ioutil.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		files[filepath.ToSlash(relPath)] = string(content)
+		return nil
+	})
+	require.Nil(t, err)
+	return files
+}
+
+// sdkTarballEntries are the entries of a tarball laid out like GitHub's
+// source code archives
+var sdkTarballEntries = []tarEntry{
+	// GitHub's archives start with a global header entry at the top level
+	{"pax_global_header", tar.TypeXGlobalHeader, "0d0e2ef3c8b7b8e0c5c1a6e3d4f3f0b5a1e9c2d7"},
+	{"aws-sdk-go-1.37.10/", tar.TypeDir, ""},
+	{"aws-sdk-go-1.37.10/go.mod", tar.TypeReg, "module github.com/aws/aws-sdk-go\n"},
+	{"aws-sdk-go-1.37.10/models/", tar.TypeDir, ""},
+	{"aws-sdk-go-1.37.10/models/apis/", tar.TypeDir, ""},
+	{"aws-sdk-go-1.37.10/models/apis/ecr/2015-09-21/api-2.json", tar.TypeReg, "{}"},
+	{"aws-sdk-go-1.37.10/models/endpoints/endpoints.json", tar.TypeReg, "[]"},
+	{"aws-sdk-go-1.37.10/service/ecr/api.go", tar.TypeReg, "package ecr\n"},
+	{"aws-sdk-go-1.37.10/service/ecr/link.go", tar.TypeSymlink, "api.go"},
+}
+
+func TestExtractTarball(t *testing.T) {
+	testCases := []struct {
+		name         string
+		entries      []tarEntry
+		includePaths []string
+		wantFiles    map[string]string
+		wantErr      bool
+	}{
+		{
+			name:    "top-level directory stripped",
+			entries: sdkTarballEntries,
+			wantFiles: map[string]string{
+				"go.mod":                                "module github.com/aws/aws-sdk-go\n",
+				"models/apis/ecr/2015-09-21/api-2.json": "{}",
+				"models/endpoints/endpoints.json":       "[]",
+				"service/ecr/api.go":                    "package ecr\n",
+			},
+		},
+		{
+			name:         "include paths",
+			entries:      sdkTarballEntries,
+			includePaths: []string{"models/apis", "go.mod"},
+			wantFiles: map[string]string{
+				"go.mod":                                "module github.com/aws/aws-sdk-go\n",
+				"models/apis/ecr/2015-09-21/api-2.json": "{}",
+			},
+		},
+		{
+			name: "entries at the top level only",
+			entries: []tarEntry{
+				{"README.md", tar.TypeReg, "# aws-sdk-go\n"},
+				{"./LICENSE.txt", tar.TypeReg, "Apache License\n"},
+			},
+			wantFiles: map[string]string{},
+		},
+		{
+			name: "entry outside the destination",
+			entries: []tarEntry{
+				{"aws-sdk-go-1.37.10/go.mod", tar.TypeReg, "module github.com/aws/aws-sdk-go\n"},
+				{"aws-sdk-go-1.37.10/../../etc/passwd", tar.TypeReg, "root:x:0:0\n"},
+			},
+			wantErr: true,
+		},
+		{
+			name: "absolute path",
+			entries: []tarEntry{
+				{"/etc/passwd", tar.TypeReg, "root:x:0:0\n"},
+			},
+			wantErr: true,
+		},
+		{
+			name: "parent directory after the top-level directory",
+			entries: []tarEntry{
+				{"aws-sdk-go-1.37.10/../evil.sh", tar.TypeReg, "#!/bin/sh\n"},
+			},
+			// The path is cleaned into a top-level entry
+			wantFiles: map[string]string{},
+		},
+	}
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			assert := assert.New(t)
+			require := require.New(t)
+
+			dir, err := ioutil.TempDir("", "tarball")
+			require.Nil(err)
+			defer os.RemoveAll(dir)
+			destPath := filepath.Join(dir, "dest")
+
+			err = util.ExtractTarball(
+				bytes.NewReader(newTarball(t, tt.entries)), destPath, tt.includePaths,
+			)
+			if tt.wantErr {
+				assert.NotNil(err)
+				// Nothing is written outside the destination
+				for path := range listFiles(t, dir) {
+					assert.Equal("dest/go.mod", path)
+				}
+				return
+			}
+			require.Nil(err)
+			assert.Equal(tt.wantFiles, listFiles(t, destPath))
+		})
+	}
+}
+
+func TestExtractTarball_NotGzipped(t *testing.T) {
+	err := util.ExtractTarball(
+		bytes.NewReader([]byte("<html>Not Found</html>")), os.TempDir(), nil,
+	)
+	assert.NotNil(t, err)
+}
+
+func TestDownloadTarball(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	tarball := newTarball(t, sdkTarballEntries)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer t0k3n" {
+			http.Error(w, "<html>Not Found</html>", http.StatusNotFound)
+			return
+		}
+		w.Write(tarball)
+	}))
+	defer srv.Close()
+
+	dir, err := ioutil.TempDir("", "tarball")
+	require.Nil(err)
+	defer os.RemoveAll(dir)
+
+	messages := []string{}
+	err = util.DownloadTarball(
+		context.TODO(), srv.URL, http.Header{"Authorization": {"Bearer t0k3n"}},
+		filepath.Join(dir, "sdk"), []string{"go.mod"},
+		func(message string) { messages = append(messages, message) },
+	)
+	require.Nil(err)
+	files := []string{}
+	for path := range listFiles(t, filepath.Join(dir, "sdk")) {
+		files = append(files, path)
+	}
+	sort.Strings(files)
+	assert.Equal([]string{"go.mod"}, files)
+	assert.NotEmpty(messages)
+
+	// An error page is reported as such instead of as an invalid archive
+	err = util.DownloadTarball(
+		context.TODO(), srv.URL, nil, filepath.Join(dir, "missing"), nil, nil,
+	)
+	require.NotNil(err)
+	assert.Contains(err.Error(), "404 Not Found")
+	_, err = os.Stat(filepath.Join(dir, "missing"))
+	assert.True(os.IsNotExist(err))
+}
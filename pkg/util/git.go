@@ -18,11 +18,62 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"os"
 
 	"gopkg.in/src-d/go-git.v4"
+	"gopkg.in/src-d/go-git.v4/config"
 	"gopkg.in/src-d/go-git.v4/plumbing"
+	"gopkg.in/src-d/go-git.v4/plumbing/storer"
+	"gopkg.in/src-d/go-git.v4/plumbing/transport"
+	"gopkg.in/src-d/go-git.v4/plumbing/transport/client"
+	"gopkg.in/src-d/go-git.v4/plumbing/transport/server"
 )
 
+func init() {
+	// go-git runs the git-upload-pack binary to clone and fetch from local
+	// repositories (file:// URLs and paths). Serve them in-process instead,
+	// so that git doesn't need to be installed.
+	client.InstallProtocol("file", server.NewServer(localRepositoryLoader{}))
+}
+
+// localRepositoryLoader loads the local repositories served by the in-process
+// file transport. Unlike go-git's default loader, which only loads the bare
+// repositories having a config file, it loads any repository git.PlainOpen
+// can open: the repositories having a working tree, their .git directories
+// and the bare repositories, including the ones initialized by go-git, which
+// don't have a config file until their configuration is set.
+type localRepositoryLoader struct{}
+
+// Load returns the storer of the repository at the endpoint's path
+func (localRepositoryLoader) Load(ep *transport.Endpoint) (storer.Storer, error) {
+	repo, err := git.PlainOpen(ep.Path)
+	if err == git.ErrRepositoryNotExists {
+		return nil, transport.ErrRepositoryNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return repo.Storer, nil
+}
+
+// isLocalRepository returns true if the supplied URL is the path or file://
+// URL of a local repository
+func isLocalRepository(repositoryURL string) bool {
+	ep, err := transport.NewEndpoint(repositoryURL)
+	return err == nil && ep.Protocol == "file"
+}
+
+// shallowDepth returns the depth of the shallow clones and fetches of the
+// repository at the supplied URL: 1, or 0 (i.e. a full clone or fetch of the
+// requested references) for local repositories, because the in-process file
+// transport doesn't support shallow clones. Local clones are cheap anyway.
+func shallowDepth(repositoryURL string) int {
+	if isLocalRepository(repositoryURL) {
+		return 0
+	}
+	return 1
+}
+
 // LoadRepository loads a repository from the local file system.
 // TODO(a-hilaly): load repository into a memory filesystem (needs go1.16
 // migration or use somethign like https://github.com/spf13/afero
@@ -33,20 +84,175 @@ func LoadRepository(path string) (*git.Repository, error) {
 // CloneRepository clones a git repository into a given directory.
 //
 // Calling his function is equivalent to executing `git clone $repositoryURL $path`
-func CloneRepository(ctx context.Context, path, repositoryURL string) error {
-	_, err := git.PlainCloneContext(ctx, path, false, &git.CloneOptions{
+// If progress is not nil, it is called with the remote's progress messages.
+// If auth is not nil, it is used to authenticate with the remote.
+func CloneRepository(
+	ctx context.Context,
+	path, repositoryURL string,
+	auth transport.AuthMethod,
+	progress ProgressFunc,
+) error {
+	_, err := cloneRepository(ctx, path, &git.CloneOptions{
 		URL:      repositoryURL,
-		Progress: nil,
+		Auth:     auth,
+		Progress: progressWriterFor(progress),
 		// Clone and fetch all tags
 		Tags: git.AllTags,
 	})
 	return err
 }
 
+// CloneRepositoryTag does a shallow clone of a single tag of a git repository
+// into a given directory. Only the commit the tag points to is downloaded,
+// except from local repositories, from which the tag's history is cloned.
+//
+// Calling this function is equivalent to executing
+// `git clone --depth 1 --branch $tag --no-tags $repositoryURL $path`
+// If progress is not nil, it is called with the remote's progress messages.
+// If auth is not nil, it is used to authenticate with the remote.
+func CloneRepositoryTag(
+	ctx context.Context,
+	path, repositoryURL, tag string,
+	auth transport.AuthMethod,
+	progress ProgressFunc,
+) error {
+	tagRefName := plumbing.NewTagReferenceName(tag)
+	repo, err := cloneRepository(ctx, path, &git.CloneOptions{
+		URL:           repositoryURL,
+		Auth:          auth,
+		Progress:      progressWriterFor(progress),
+		ReferenceName: tagRefName,
+		SingleBranch:  true,
+		Depth:         shallowDepth(repositoryURL),
+		Tags:          git.NoTags,
+	})
+	if err != nil {
+		return err
+	}
+	if err = ensureTagReference(repo, tag); err != nil {
+		os.RemoveAll(path)
+		return err
+	}
+	return nil
+}
+
+// cloneRepository clones a git repository into a given directory, which must
+// not exist, with the supplied options. If the clone fails, the partially
+// cloned directory is removed so that it isn't mistaken for a clone later.
+func cloneRepository(
+	ctx context.Context,
+	path string,
+	opts *git.CloneOptions,
+) (*git.Repository, error) {
+	if _, err := os.Stat(path); err == nil {
+		return nil, fmt.Errorf("cannot clone into %s: %v", path, os.ErrExist)
+	} else if !os.IsNotExist(err) {
+		return nil, err
+	}
+	if err := opts.Validate(); err != nil {
+		return nil, err
+	}
+	repo, err := git.PlainCloneContext(ctx, path, false, opts)
+	if err != nil {
+		os.RemoveAll(path)
+		return nil, err
+	}
+	return repo, nil
+}
+
+// FetchRepositoryTag does a shallow fetch of a single remote tag, or a full
+// fetch of it from local repositories. It returns an error if the remote
+// doesn't have the tag.
+//
+// Calling this function is equivalent to executing
+// `git -C $path fetch --depth 1 origin tag $tag`
+// If progress is not nil, it is called with the remote's progress messages.
+// If auth is not nil, it is used to authenticate with the remote.
+func FetchRepositoryTag(
+	ctx context.Context,
+	path, tag string,
+	auth transport.AuthMethod,
+	progress ProgressFunc,
+) error {
+	repo, err := git.PlainOpen(path)
+	if err != nil {
+		return err
+	}
+	remote, err := repo.Remote(git.DefaultRemoteName)
+	if err != nil {
+		return err
+	}
+	tagRefName := plumbing.NewTagReferenceName(tag)
+	err = repo.FetchContext(ctx, &git.FetchOptions{
+		RefSpecs: []config.RefSpec{
+			config.RefSpec(fmt.Sprintf("+%s:%s", tagRefName, tagRefName)),
+		},
+		Depth:    shallowDepth(remote.Config().URLs[0]),
+		Auth:     auth,
+		Progress: progressWriterFor(progress),
+		Tags:     git.NoTags,
+	})
+	if err == git.NoErrAlreadyUpToDate {
+		return nil
+	}
+	return err
+}
+
+// SetRepositoryRemoteURL points the origin remote of a repository to the
+// supplied URL, e.g. to fetch from a mirror of the repository it was cloned
+// from.
+//
+// Calling this function is equivalent to executing
+// `git -C $path remote set-url origin $repositoryURL`
+func SetRepositoryRemoteURL(repo *git.Repository, repositoryURL string) error {
+	cfg, err := repo.Config()
+	if err != nil {
+		return err
+	}
+	remote, found := cfg.Remotes[git.DefaultRemoteName]
+	if !found {
+		return fmt.Errorf("remote %s not found", git.DefaultRemoteName)
+	}
+	if len(remote.URLs) == 1 && remote.URLs[0] == repositoryURL {
+		return nil
+	}
+	remote.URLs = []string{repositoryURL}
+	return repo.Storer.SetConfig(cfg)
+}
+
+// HasRepositoryTag returns true if the tag exists in the local repository.
+func HasRepositoryTag(repo *git.Repository, tag string) bool {
+	_, err := getRepositoryTagRef(repo, tag)
+	return err == nil
+}
+
+// ensureTagReference makes sure that the local repository has a reference for
+// the given tag, pointing to HEAD. A shallow clone of a tag leaves HEAD
+// detached at the tagged commit without always storing the tag reference.
+func ensureTagReference(repo *git.Repository, tag string) error {
+	if HasRepositoryTag(repo, tag) {
+		return nil
+	}
+	head, err := repo.Head()
+	if err != nil {
+		return err
+	}
+	return repo.Storer.SetReference(
+		plumbing.NewHashReference(plumbing.NewTagReferenceName(tag), head.Hash()),
+	)
+}
+
 // FetchRepositoryTags fetches a repository remote tags.
 //
 // Calling this function is equivalent to executing `git -C $path fetch --all --tags`
-func FetchRepositoryTags(ctx context.Context, path string) error {
+// If progress is not nil, it is called with the remote's progress messages.
+// If auth is not nil, it is used to authenticate with the remote.
+func FetchRepositoryTags(
+	ctx context.Context,
+	path string,
+	auth transport.AuthMethod,
+	progress ProgressFunc,
+) error {
 	// PlainOpen will make the git commands run against the local
 	// repository and directly make changes to it. So no need to
 	// save/rewrite the refs
@@ -56,7 +262,8 @@ func FetchRepositoryTags(ctx context.Context, path string) error {
 	}
 
 	err = repo.FetchContext(ctx, &git.FetchOptions{
-		Progress: nil,
+		Auth:     auth,
+		Progress: progressWriterFor(progress),
 		Tags:     git.AllTags,
 	})
 	// weirdly go-git returns a error "Already up to date" when all tags
@@ -0,0 +1,232 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package util_test
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/src-d/go-git.v4"
+	"gopkg.in/src-d/go-git.v4/plumbing"
+	"gopkg.in/src-d/go-git.v4/plumbing/object"
+
+	"github.com/aws-controllers-k8s/code-generator/pkg/util"
+)
+
+// testRepositories contains the paths of the local repositories cloned and
+// fetched from by the tests, and the commits their tags point to
+type testRepositories struct {
+	// workTree is a repository having a working tree, tagged v1.0.0, v2.0.0
+	// and v3.0.0
+	workTree string
+	// bare is a bare clone of workTree, made before it was tagged v3.0.0
+	bare string
+	// tags are the commits the tags point to, keyed by tag
+	tags map[string]plumbing.Hash
+}
+
+// newTestRepositories creates the local repositories cloned and fetched from
+// by the tests in a new temporary directory, which the caller removes, using
+// go-git only
+func newTestRepositories(t *testing.T) (string, *testRepositories) {
+	require := require.New(t)
+
+	dir, err := ioutil.TempDir("", "git")
+	require.Nil(err)
+	repos := &testRepositories{
+		workTree: filepath.Join(dir, "work-tree"),
+		bare:     filepath.Join(dir, "bare.git"),
+		tags:     map[string]plumbing.Hash{},
+	}
+
+	repo, err := git.PlainInit(repos.workTree, false)
+	require.Nil(err)
+	wt, err := repo.Worktree()
+	require.Nil(err)
+	for _, tag := range []string{"v1.0.0", "v2.0.0", "v3.0.0"} {
+		if tag == "v3.0.0" {
+			_, err = git.PlainClone(repos.bare, true, &git.CloneOptions{
+				URL:  repos.workTree,
+				Tags: git.AllTags,
+			})
+			require.Nil(err)
+		}
+		err = ioutil.WriteFile(
+			filepath.Join(repos.workTree, "VERSION"), []byte(tag), 0644,
+		)
+		require.Nil(err)
+		_, err = wt.Add("VERSION")
+		require.Nil(err)
+		hash, err := wt.Commit("Release "+tag, &git.CommitOptions{
+			Author: &object.Signature{
+				Name:  "ACK",
+				Email: "ack@example.com",
+				When:  time.Now(),
+			},
+		})
+		require.Nil(err)
+		_, err = repo.CreateTag(tag, hash, nil)
+		require.Nil(err)
+		repos.tags[tag] = hash
+	}
+	return dir, repos
+}
+
+func TestCloneRepositoryTag(t *testing.T) {
+	dir, repos := newTestRepositories(t)
+	defer os.RemoveAll(dir)
+
+	testCases := []struct {
+		name          string
+		repositoryURL string
+	}{
+		{"bare repository path", repos.bare},
+		{"bare repository file URL", "file://" + repos.bare},
+		{"repository with a working tree", repos.workTree},
+		{"repository with a working tree file URL", "file://" + repos.workTree},
+		{"git directory of a repository with a working tree", filepath.Join(repos.workTree, git.GitDirName)},
+	}
+	for x, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			assert := assert.New(t)
+			require := require.New(t)
+
+			path := filepath.Join(dir, fmt.Sprintf("clone-%d", x))
+			err := util.CloneRepositoryTag(
+				context.TODO(), path, tt.repositoryURL, "v1.0.0", nil, nil,
+			)
+			require.Nil(err)
+
+			repo, err := util.LoadRepository(path)
+			require.Nil(err)
+			assert.True(util.HasRepositoryTag(repo, "v1.0.0"))
+			assert.False(util.HasRepositoryTag(repo, "v2.0.0"))
+			head, err := repo.Head()
+			require.Nil(err)
+			assert.Equal(repos.tags["v1.0.0"], head.Hash())
+			version, err := ioutil.ReadFile(filepath.Join(path, "VERSION"))
+			require.Nil(err)
+			assert.Equal("v1.0.0", string(version))
+
+			// An existing directory is never cloned into, nor removed
+			err = util.CloneRepositoryTag(
+				context.TODO(), path, tt.repositoryURL, "v2.0.0", nil, nil,
+			)
+			assert.NotNil(err)
+			assert.True(util.HasRepositoryTag(repo, "v1.0.0"))
+		})
+	}
+}
+
+func TestCloneRepositoryTag_NotFound(t *testing.T) {
+	assert := assert.New(t)
+
+	dir, repos := newTestRepositories(t)
+	defer os.RemoveAll(dir)
+
+	// The partial clone is removed
+	path := filepath.Join(dir, "clone")
+	err := util.CloneRepositoryTag(
+		context.TODO(), path, repos.bare, "v9.9.9", nil, nil,
+	)
+	assert.NotNil(err)
+	_, err = os.Stat(path)
+	assert.True(os.IsNotExist(err))
+
+	err = util.CloneRepositoryTag(
+		context.TODO(), path, filepath.Join(dir, "missing.git"), "v1.0.0", nil, nil,
+	)
+	assert.NotNil(err)
+	_, err = os.Stat(path)
+	assert.True(os.IsNotExist(err))
+}
+
+func TestFetchRepositoryTag(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	dir, repos := newTestRepositories(t)
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, "clone")
+	require.Nil(util.CloneRepositoryTag(
+		context.TODO(), path, repos.bare, "v1.0.0", nil, nil,
+	))
+	require.Nil(util.FetchRepositoryTag(
+		context.TODO(), path, "v2.0.0", nil, nil,
+	))
+	// Fetching a tag again is a no-op
+	require.Nil(util.FetchRepositoryTag(
+		context.TODO(), path, "v2.0.0", nil, nil,
+	))
+	// The bare repository was cloned before the v3.0.0 tag was created
+	assert.NotNil(util.FetchRepositoryTag(
+		context.TODO(), path, "v3.0.0", nil, nil,
+	))
+
+	repo, err := util.LoadRepository(path)
+	require.Nil(err)
+	assert.True(util.HasRepositoryTag(repo, "v1.0.0"))
+	assert.True(util.HasRepositoryTag(repo, "v2.0.0"))
+	assert.False(util.HasRepositoryTag(repo, "v3.0.0"))
+
+	require.Nil(util.CheckoutRepositoryTag(repo, "v2.0.0"))
+	head, err := repo.Head()
+	require.Nil(err)
+	assert.Equal(repos.tags["v2.0.0"], head.Hash())
+	version, err := ioutil.ReadFile(filepath.Join(path, "VERSION"))
+	require.Nil(err)
+	assert.Equal("v2.0.0", string(version))
+}
+
+func TestSetRepositoryRemoteURL(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	dir, repos := newTestRepositories(t)
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, "clone")
+	require.Nil(util.CloneRepositoryTag(
+		context.TODO(), path, repos.bare, "v1.0.0", nil, nil,
+	))
+	repo, err := util.LoadRepository(path)
+	require.Nil(err)
+
+	// Only the repository with a working tree has the v3.0.0 tag
+	require.Nil(util.SetRepositoryRemoteURL(repo, repos.workTree))
+	require.Nil(util.SetRepositoryRemoteURL(repo, repos.workTree))
+	remote, err := repo.Remote(git.DefaultRemoteName)
+	require.Nil(err)
+	assert.Equal([]string{repos.workTree}, remote.Config().URLs)
+
+	require.Nil(util.FetchRepositoryTag(
+		context.TODO(), path, "v3.0.0", nil, nil,
+	))
+	repo, err = util.LoadRepository(path)
+	require.Nil(err)
+	assert.True(util.HasRepositoryTag(repo, "v3.0.0"))
+
+	// The origin remote must exist
+	workTree, err := util.LoadRepository(repos.workTree)
+	require.Nil(err)
+	assert.NotNil(util.SetRepositoryRemoteURL(workTree, repos.bare))
+}
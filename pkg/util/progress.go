@@ -0,0 +1,56 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package util
+
+import (
+	"bytes"
+	"io"
+	"strings"
+)
+
+// ProgressFunc is called with each progress message emitted by a long-running
+// operation, such as "Receiving objects:  42% (1234/2938)"
+type ProgressFunc func(message string)
+
+// progressWriter adapts a ProgressFunc to the io.Writer that go-git writes
+// remote progress messages to. Messages are terminated by either a carriage
+// return or a new line.
+type progressWriter struct {
+	fn  ProgressFunc
+	buf []byte
+}
+
+func (w *progressWriter) Write(p []byte) (int, error) {
+	w.buf = append(w.buf, p...)
+	for {
+		end := bytes.IndexAny(w.buf, "\r\n")
+		if end < 0 {
+			break
+		}
+		if message := strings.TrimSpace(string(w.buf[:end])); message != "" {
+			w.fn(message)
+		}
+		w.buf = w.buf[end+1:]
+	}
+	return len(p), nil
+}
+
+// progressWriterFor returns an io.Writer that calls the supplied ProgressFunc
+// for every progress message written to it, or nil if the ProgressFunc is nil
+func progressWriterFor(fn ProgressFunc) io.Writer {
+	if fn == nil {
+		return nil
+	}
+	return &progressWriter{fn: fn}
+}
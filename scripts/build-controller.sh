@@ -27,6 +27,7 @@ ACK_GENERATE_API_VERSION=${ACK_GENERATE_API_VERSION:-"v1alpha1"}
 ACK_GENERATE_CONFIG_PATH=${ACK_GENERATE_CONFIG_PATH:-""}
 ACK_METADATA_CONFIG_PATH=${ACK_METADATA_CONFIG_PATH:-""}
 AWS_SDK_GO_VERSION=${AWS_SDK_GO_VERSION:-""}
+AWS_SDK_GO_PATH=${AWS_SDK_GO_PATH:-""}
 DEFAULT_RUNTIME_CRD_DIR="$ROOT_DIR/../../aws-controllers-k8s/runtime/config"
 RUNTIME_CRD_DIR=${RUNTIME_CRD_DIR:-$DEFAULT_RUNTIME_CRD_DIR}
 
@@ -58,6 +59,10 @@ Environment variables:
   AWS_SDK_GO_VERSION:       Overrides the version of github.com/aws/aws-sdk-go used
                             by 'ack-generate' to fetch the service API Specifications.
                             Default: Version of aws/aws-sdk-go in service go.mod
+  AWS_SDK_GO_PATH:          Path to an existing local copy of github.com/aws/aws-sdk-go
+                            (a git checkout or Go module cache directory). When set,
+                            'ack-generate' does not clone or fetch the SDK repository.
+                            Default: ""
   TEMPLATES_DIR:            Overrides the directory containg ack-generate templates
                             Default: $TEMPLATES_DIR
   K8S_RBAC_ROLE_NAME:       Name of the Kubernetes Role to use when generating
@@ -129,7 +134,7 @@ echo "Copying common custom resource definitions into $SERVICE"
 mkdir -p $config_output_dir/crd/common
 cp -r $RUNTIME_CRD_DIR/crd/* $config_output_dir/crd/common/
 
-if [ -z "$AWS_SDK_GO_VERSION" ]; then
+if [ -z "$AWS_SDK_GO_VERSION" ] && [ -z "$AWS_SDK_GO_PATH" ]; then
     AWS_SDK_GO_VERSION=$(go list -m -f '{{ .Version }}' -modfile $SERVICE_CONTROLLER_SOURCE_PATH/go.mod github.com/aws/aws-sdk-go)
 fi
 
@@ -174,6 +179,11 @@ if [ -n "$AWS_SDK_GO_VERSION" ]; then
     apis_args="$apis_args --aws-sdk-go-version $AWS_SDK_GO_VERSION"
 fi
 
+if [ -n "$AWS_SDK_GO_PATH" ]; then
+    ag_args="$ag_args --aws-sdk-go-path $AWS_SDK_GO_PATH"
+    apis_args="$apis_args --aws-sdk-go-path $AWS_SDK_GO_PATH"
+fi
+
 echo "Building Kubernetes API objects for $SERVICE"
 $ACK_GENERATE_BIN_PATH $apis_args
 if [ $? -ne 0 ]; then
@@ -1,6 +1,9 @@
 #!/usr/bin/env bash
 
-CONTROLLER_TOOLS_VERSION="v0.7.0"
+# controller-gen v0.9.0 or later is required by the CRD-level
+# +kubebuilder:metadata marker stamping configured labels and annotations onto
+# the CRDs
+CONTROLLER_TOOLS_VERSION="v0.9.2"
 
 # setting the -x option if debugging is true
 if [[ "${DEBUG:-"false"}" = "true" ]]; then
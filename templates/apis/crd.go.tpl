@@ -14,10 +14,19 @@ import (
 )
 
 {{ .CRD.Documentation }}
+{{- range $marker := .CRD.SpecValidationMarkers }}
+// {{ $marker }}
+{{- end }}
 type {{ .CRD.Kind }}Spec struct {
 	{{- range $fieldName, $field := .CRD.SpecFields }}
-	{{- if $field.ShapeRef }}
-	{{ $field.ShapeRef.Documentation }}
+	{{- if $field.Documentation }}
+	{{ $field.Documentation }}
+	{{- end }}
+	{{- range $marker := $field.ValidationMarkers }}
+	// {{ $marker }}
+	{{- end }}
+	{{- if $field.DefaultMarker }}
+	// {{ $field.DefaultMarker }}
 	{{- end }}
 	{{ if $field.IsRequired }} // +kubebuilder:validation:Required
 	{{ $field.Names.Camel }} {{ $field.GoType }} `json:"{{ $field.Names.CamelLower }}"`
@@ -39,25 +48,55 @@ type {{ .CRD.Kind }}Status struct {
 	// +kubebuilder:validation:Optional
 	Conditions []*ackv1alpha1.Condition `json:"conditions"`
 	{{- range $fieldName, $field := .CRD.StatusFields }}
-	{{- if $field.ShapeRef }}
-	{{ $field.ShapeRef.Documentation }}
+	{{- if $field.Documentation }}
+	{{ $field.Documentation }}
 	{{- end }}
+	{{- if $.CRD.IsPrunedStatusField $fieldName }}
+	//
+	// {{ $field.Names.Camel }} is pruned from the CRD schema: it is only set
+	// while the resource is reconciled, and never stored.
+	{{ $field.Names.Camel }} {{ $field.GoType }} `json:"-"`
+	{{- else }}
 	// +kubebuilder:validation:Optional
 	{{ $field.Names.Camel }} {{ $field.GoType }} `json:"{{ $field.Names.CamelLower }},omitempty"`
+	{{- end }}
 {{- end }}
 }
 
 // {{ .CRD.Kind }} is the Schema for the {{ .CRD.Plural }} API
 // +kubebuilder:object:root=true
+{{- if .CRD.HasStatusSubresource }}
 // +kubebuilder:subresource:status
+{{- end }}
+{{- if .CRD.ScaleMarkerArgs }}
+// +kubebuilder:subresource:scale:{{ .CRD.ScaleMarkerArgs }}
+{{- end }}
+{{- if .IsStorageVersion }}
+// +kubebuilder:storageversion
+{{- end }}
+{{- if .Version.IsDeprecated }}
+// +kubebuilder:deprecatedversion{{ if .Version.DeprecationWarning }}:warning={{ printf "%q" .Version.DeprecationWarning }}{{ end }}
+{{- end }}
+{{- if not .Version.IsServed }}
+// +kubebuilder:unservedversion
+{{- end }}
 {{- range $column := .CRD.AdditionalPrinterColumns }}
 // +kubebuilder:printcolumn:name="{{$column.Name}}",type={{$column.Type}},priority={{$column.Priority}},JSONPath=`{{$column.JSONPath}}`
 {{- end }}
+{{- if .CRD.IsARNPrimaryKey }}
+// +kubebuilder:printcolumn:name="ARN",type="string",priority=1,JSONPath=".status.ackResourceMetadata.arn"
+{{- end }}
+{{- range $column := .CRD.DefaultPrinterColumns }}
+// +kubebuilder:printcolumn:name="{{$column.Name}}",type={{$column.Type}},priority={{$column.Priority}},JSONPath=`{{$column.JSONPath}}`
+{{- end }}
 {{- if .CRD.PrintAgeColumn }}
 // +kubebuilder:printcolumn:name="Age",type="date",priority=0,JSONPath=".metadata.creationTimestamp"
 {{- end }}
-{{- if .CRD.ShortNames }}
-// +kubebuilder:resource:shortName={{ Join .CRD.ShortNames ";" }}
+{{- if .CRD.MetadataMarkerArgs }}
+// +kubebuilder:metadata:{{ .CRD.MetadataMarkerArgs }}
+{{- end }}
+{{- if .CRD.ResourceMarkerArgs }}
+// +kubebuilder:resource:{{ .CRD.ResourceMarkerArgs }}
 {{- end }}
 type {{ .CRD.Kind }} struct {
 	metav1.TypeMeta   `json:",inline"`
@@ -0,0 +1,16 @@
+{{- template "boilerplate" }}
+
+package {{ .APIVersion }}
+
+// KubernetesObjectReference names a Kubernetes object a resource's field
+// value is read from. The object is looked for in the namespace of the
+// resource, unless Namespace is set and the controller allows cross-namespace
+// references.
+type KubernetesObjectReference struct {
+	// Name is the name of the referenced object
+	// +kubebuilder:validation:Required
+	Name *string `json:"name"`
+	// Namespace is the namespace of the referenced object, if not the
+	// namespace of the resource
+	Namespace *string `json:"namespace,omitempty"`
+}
@@ -4,8 +4,11 @@
 {{- end }}
 type {{ .Names.Camel }} struct {
 {{- range $attrName, $attr := .Attrs }}
-	{{- if $attr.Shape.Documentation }}
-	{{ $attr.Shape.Documentation }}
+	{{- if $attr.Documentation }}
+	{{ $attr.Documentation }}
+	{{- end }}
+	{{- range $marker := $attr.ValidationMarkers }}
+	// {{ $marker }}
 	{{- end }}
 	{{ $attr.Names.Camel }} {{ $attr.GoType }} `json:"{{ $attr.Names.CamelLower }},omitempty"`
 {{- end }}
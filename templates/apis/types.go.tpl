@@ -6,6 +6,7 @@ import (
 	ackv1alpha1 "github.com/aws-controllers-k8s/runtime/apis/core/v1alpha1"
 	"github.com/aws/aws-sdk-go/aws"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
 )
 
 // Hack to avoid import errors during build...
@@ -13,6 +14,7 @@ var (
 	_ = &metav1.Time{}
 	_ = &aws.JSONValue{}
 	_ = ackv1alpha1.AWSAccountID("")
+	_ = &runtime.RawExtension{}
 )
 {{- range $typeDef := .TypeDefs }}
 
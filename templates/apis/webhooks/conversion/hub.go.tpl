@@ -0,0 +1,6 @@
+{{- template "boilerplate" }}
+
+package {{ .APIVersion }}
+
+// Hub marks this type as a conversion hub.
+func (*{{ .CRD.Kind }}) Hub() {}
@@ -0,0 +1,43 @@
+{{- template "boilerplate" }}
+
+package {{ .APIVersion }}
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"sigs.k8s.io/controller-runtime/pkg/conversion"
+
+	{{ .HubVersion }} "github.com/aws-controllers-k8s/{{ .ServicePackageName }}-controller/{{ .HubPackagePath }}"
+)
+
+var _ conversion.Convertible = &{{ .CRD.Kind }}{}
+
+// Hack to avoid import errors during build...
+var _ = json.Marshal
+
+// ConvertTo converts this {{ .CRD.Kind }} to the Hub version ({{ .HubVersion }}).
+func (src *{{ .CRD.Kind }}) ConvertTo(dstRaw conversion.Hub) error {
+	dst, ok := dstRaw.(*{{ .HubVersion }}.{{ .CRD.Kind }})
+	if !ok {
+		return fmt.Errorf("cannot convert to %T", dstRaw)
+	}
+{{ GoCodeConvert .Delta true .HubVersion "src" "dst" 1 }}
+	dst.Status.ACKResourceMetadata = src.Status.ACKResourceMetadata
+	dst.Status.Conditions = src.Status.Conditions
+	dst.ObjectMeta = src.ObjectMeta
+	return nil
+}
+
+// ConvertFrom converts from the Hub version ({{ .HubVersion }}) to this version.
+func (dst *{{ .CRD.Kind }}) ConvertFrom(srcRaw conversion.Hub) error {
+	src, ok := srcRaw.(*{{ .HubVersion }}.{{ .CRD.Kind }})
+	if !ok {
+		return fmt.Errorf("cannot convert from %T", srcRaw)
+	}
+{{ GoCodeConvert .Delta false .HubVersion "src" "dst" 1 }}
+	dst.Status.ACKResourceMetadata = src.Status.ACKResourceMetadata
+	dst.Status.Conditions = src.Status.Conditions
+	dst.ObjectMeta = src.ObjectMeta
+	return nil
+}
@@ -0,0 +1,18 @@
+{{- template "boilerplate" }}
+
+package {{ .APIVersion }}
+
+import (
+	ctrl "sigs.k8s.io/controller-runtime"
+)
+
+// SetupWebhooks registers the webhooks of every resource in this API version
+// with the manager's webhook server
+func SetupWebhooks(mgr ctrl.Manager) error {
+{{- range $crd := .CRDs }}
+	if err := (&{{ $crd.Kind }}{}).SetupWebhookWithManager(mgr); err != nil {
+		return err
+	}
+{{- end }}
+	return nil
+}
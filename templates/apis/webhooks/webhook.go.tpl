@@ -0,0 +1,145 @@
+{{- template "boilerplate" }}
+
+package {{ .APIVersion }}
+
+import (
+{{- if .CRD.HasDeprecatedFields }}
+	"context"
+	"net/http"
+{{- end }}
+{{- if .CRD.HasImmutableFieldChanges }}
+	"reflect"
+{{- end }}
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+{{- if .CRD.HasDeprecatedFields }}
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+{{- end }}
+)
+
+// SetupWebhookWithManager registers the {{ .CRD.Kind }} webhooks with the
+// manager's webhook server
+func (r *{{ .CRD.Kind }}) SetupWebhookWithManager(mgr ctrl.Manager) error {
+{{- if .CRD.HasDeprecatedFields }}
+	mgr.GetWebhookServer().Register(
+		"/warn-{{ .APIGroup | Dashed }}-{{ .APIVersion }}-{{ .CRD.Kind | ToLower }}",
+		&webhook.Admission{Handler: &{{ .CRD.Kind }}DeprecationWarner{}},
+	)
+{{- end }}
+	return ctrl.NewWebhookManagedBy(mgr).
+		For(r).
+		Complete()
+}
+
+// +kubebuilder:webhook:path=/mutate-{{ .APIGroup | Dashed }}-{{ .APIVersion }}-{{ .CRD.Kind | ToLower }},mutating=true,failurePolicy=fail,sideEffects=None,groups={{ .APIGroup }},resources={{ .CRD.Plural | ToLower }},verbs=create;update,versions={{ .APIVersion }},name=m{{ .CRD.Kind | ToLower }}.{{ .APIGroup }},admissionReviewVersions={v1,v1beta1}
+
+var _ webhook.Defaulter = &{{ .CRD.Kind }}{}
+
+// Default implements webhook.Defaulter so a webhook will be registered for
+// the {{ .CRD.Kind }} type
+func (r *{{ .CRD.Kind }}) Default() {
+{{- if $hookCode := Hook .CRD "webhook_default" }}
+{{ $hookCode }}
+{{- end }}
+}
+
+// +kubebuilder:webhook:path=/validate-{{ .APIGroup | Dashed }}-{{ .APIVersion }}-{{ .CRD.Kind | ToLower }},mutating=false,failurePolicy=fail,sideEffects=None,groups={{ .APIGroup }},resources={{ .CRD.Plural | ToLower }},verbs=create;update,versions={{ .APIVersion }},name=v{{ .CRD.Kind | ToLower }}.{{ .APIGroup }},admissionReviewVersions={v1,v1beta1}
+
+var _ webhook.Validator = &{{ .CRD.Kind }}{}
+
+// ValidateCreate implements webhook.Validator so a webhook will be registered
+// for the {{ .CRD.Kind }} type
+func (r *{{ .CRD.Kind }}) ValidateCreate() error {
+	var allErrs field.ErrorList
+{{- if $hookCode := Hook .CRD "webhook_validate_create" }}
+{{ $hookCode }}
+{{- end }}
+	return r.toAggregateError(allErrs)
+}
+
+// ValidateUpdate implements webhook.Validator so a webhook will be registered
+// for the {{ .CRD.Kind }} type
+func (r *{{ .CRD.Kind }}) ValidateUpdate(oldRaw runtime.Object) error {
+	var allErrs field.ErrorList
+{{- if or .CRD.HasImmutableFieldChanges (Hook .CRD "webhook_validate_update") }}
+	old, ok := oldRaw.(*{{ .CRD.Kind }})
+	if !ok {
+		return apierrors.NewBadRequest("expected a {{ .CRD.Kind }} object")
+	}
+{{- end }}
+{{- if .CRD.HasImmutableFieldChanges }}
+{{ GoCodeValidateImmutableFields .CRD "old" "r" "allErrs" 1 }}
+{{- end }}
+{{- if $hookCode := Hook .CRD "webhook_validate_update" }}
+{{ $hookCode }}
+{{- end }}
+	return r.toAggregateError(allErrs)
+}
+
+// ValidateDelete implements webhook.Validator so a webhook will be registered
+// for the {{ .CRD.Kind }} type
+func (r *{{ .CRD.Kind }}) ValidateDelete() error {
+	var allErrs field.ErrorList
+{{- if $hookCode := Hook .CRD "webhook_validate_delete" }}
+{{ $hookCode }}
+{{- end }}
+	return r.toAggregateError(allErrs)
+}
+
+// toAggregateError returns an Invalid API error containing the supplied field
+// errors, or nil if there are none
+func (r *{{ .CRD.Kind }}) toAggregateError(allErrs field.ErrorList) error {
+	if len(allErrs) == 0 {
+		return nil
+	}
+	return apierrors.NewInvalid(
+		GroupVersion.WithKind("{{ .CRD.Kind }}").GroupKind(),
+		r.Name,
+		allErrs,
+	)
+}
+{{- if .CRD.HasDeprecatedFields }}
+
+// +kubebuilder:webhook:path=/warn-{{ .APIGroup | Dashed }}-{{ .APIVersion }}-{{ .CRD.Kind | ToLower }},mutating=false,failurePolicy=ignore,sideEffects=None,groups={{ .APIGroup }},resources={{ .CRD.Plural | ToLower }},verbs=create;update,versions={{ .APIVersion }},name=w{{ .CRD.Kind | ToLower }}.{{ .APIGroup }},admissionReviewVersions={v1,v1beta1}
+
+// {{ .CRD.Kind }}DeprecationWarner admits every {{ .CRD.Kind }}, warning the
+// users creating or updating them about the deprecated fields they set
+type {{ .CRD.Kind }}DeprecationWarner struct {
+	decoder *admission.Decoder
+}
+
+var _ admission.DecoderInjector = &{{ .CRD.Kind }}DeprecationWarner{}
+
+// InjectDecoder implements admission.DecoderInjector so the webhook server
+// supplies the decoder of the requests' objects
+func (w *{{ .CRD.Kind }}DeprecationWarner) InjectDecoder(d *admission.Decoder) error {
+	w.decoder = d
+	return nil
+}
+
+// Handle implements admission.Handler, allowing the request with a warning
+// for every deprecated field set in the {{ .CRD.Kind }}
+func (w *{{ .CRD.Kind }}DeprecationWarner) Handle(
+	ctx context.Context,
+	req admission.Request,
+) admission.Response {
+	r := &{{ .CRD.Kind }}{}
+	if err := w.decoder.Decode(req, r); err != nil {
+		return admission.Errored(http.StatusBadRequest, err)
+	}
+	resp := admission.Allowed("")
+	resp.Warnings = r.deprecationWarnings()
+	return resp
+}
+
+// deprecationWarnings returns a warning for every deprecated field set in
+// the {{ .CRD.Kind }}
+func (r *{{ .CRD.Kind }}) deprecationWarnings() []string {
+	var warnings []string
+{{ GoCodeDeprecationWarnings .CRD "r" "warnings" 1 -}}
+	return warnings
+}
+{{- end }}
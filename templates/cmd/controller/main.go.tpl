@@ -14,11 +14,14 @@ import (
 	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
 	ctrlrt "sigs.k8s.io/controller-runtime"
 	ctrlrtmetrics "sigs.k8s.io/controller-runtime/pkg/metrics"
-	svcsdk "github.com/aws/aws-sdk-go/service/{{ .ServicePackageName }}"
+	svcsdk "github.com/aws/aws-sdk-go/service/{{ .SDKPackageName }}"
 
 	ackv1alpha1 "github.com/aws-controllers-k8s/runtime/apis/core/v1alpha1"
 	svcresource "github.com/aws-controllers-k8s/{{ .ServicePackageName }}-controller/pkg/resource"
-	svctypes "github.com/aws-controllers-k8s/{{ .ServicePackageName }}-controller/apis/{{ .APIVersion }}"
+	svctypes "github.com/aws-controllers-k8s/{{ .ServicePackageName }}-controller/{{ .APIPackagePath }}"
+{{- range .AdditionalAPIGroups }}
+	{{ .PackageAlias }} "github.com/aws-controllers-k8s/{{ $.ServicePackageName }}-controller/{{ .PackagePath }}"
+{{- end }}
 	{{/* TODO(a-hilaly): import apis/* packages to register webhooks */}}
 	{{ $serviceAlias := .ServicePackageName }} {{range $crdName := .SnakeCasedCRDNames }}_ "github.com/aws-controllers-k8s/{{ $serviceAlias }}-controller/pkg/resource/{{ $crdName }}"
 	{{end}}
@@ -36,12 +39,32 @@ func init() {
 	_ = clientgoscheme.AddToScheme(scheme)
 	{{/* TODO(a-hilaly): register all the apis/* schemes */}}
 	_ = svctypes.AddToScheme(scheme)
+{{- range .AdditionalAPIGroups }}
+	_ = {{ .PackageAlias }}.AddToScheme(scheme)
+{{- end }}
 	_ = ackv1alpha1.AddToScheme(scheme)
 }
 
 func main() {
 	var ackCfg ackcfg.Config
 	ackCfg.BindFlags()
+	flag.BoolVar(
+		&svcresource.UseFIPSEndpoint,
+		"use-fips-endpoint", svcresource.UseFIPSEndpoint,
+		"Call the FIPS endpoints of the AWS service API",
+	)
+	flag.BoolVar(
+		&svcresource.UseDualStackEndpoint,
+		"use-dual-stack-endpoint", svcresource.UseDualStackEndpoint,
+		"Call the dual-stack endpoints of the AWS service API, reachable over IPv4 and IPv6",
+	)
+{{- if .GeneratorConfig.ResourceContainsReferences }}
+	flag.BoolVar(
+		&svcresource.AllowCrossNamespaceReferences,
+		"allow-cross-namespace-references", false,
+		"Allow the resources to reference objects in other namespaces than their own",
+	)
+{{- end }}
 	flag.Parse()
 	ackCfg.SetupLogger()
 
@@ -116,6 +139,16 @@ func main() {
 		)
 		os.Exit(1)
 	}
+{{- if .GeneratorConfig.ResourceContainsReferences }}
+
+	if err = svcresource.SetupReferenceWatches(mgr); err != nil {
+		setupLog.Error(
+			err, "unable to watch the objects referenced by the resources",
+			"aws.service", awsServiceAlias,
+		)
+		os.Exit(1)
+	}
+{{- end }}
 
 	setupLog.Info(
 		"starting manager",
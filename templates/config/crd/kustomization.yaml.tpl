@@ -3,6 +3,6 @@ kind: Kustomization
 bases:
   - common
 resources:
-{{- range .CRDNames }}
-  - bases/{{ $.APIGroup }}_{{ . }}.yaml 
+{{- range .CRDBases }}
+  - bases/{{ . }}
 {{- end }}
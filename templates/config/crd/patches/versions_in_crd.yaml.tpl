@@ -0,0 +1,26 @@
+# Sets which API versions of the {{ .CRD.Kind }} custom resource definition are
+# served and deprecated. This JSON patch lists the versions in the order
+# controller-gen writes them, and is applied with:
+#
+#   patchesJson6902:
+#   - target:
+#       group: apiextensions.k8s.io
+#       version: v1
+#       kind: CustomResourceDefinition
+#       name: {{ .CRD.Plural | ToLower }}.{{ .APIGroup }}
+#     path: patches/versions_in_{{ .CRD.Plural | ToLower }}.yaml
+{{- range $index, $version := .Versions }}
+- op: add
+  path: /spec/versions/{{ $index }}/served
+  value: {{ $version.IsServed }}
+{{- if $version.IsDeprecated }}
+- op: add
+  path: /spec/versions/{{ $index }}/deprecated
+  value: true
+{{- if $version.DeprecationWarning }}
+- op: add
+  path: /spec/versions/{{ $index }}/deprecationWarning
+  value: {{ printf "%q" $version.DeprecationWarning }}
+{{- end }}
+{{- end }}
+{{- end }}
@@ -0,0 +1,18 @@
+# Enables the conversion webhook for the {{ .CRD.Kind }} custom resource
+# definition
+apiVersion: apiextensions.k8s.io/v1
+kind: CustomResourceDefinition
+metadata:
+  name: {{ .CRD.Plural | ToLower }}.{{ .APIGroup }}
+spec:
+  conversion:
+    strategy: Webhook
+    webhook:
+      clientConfig:
+        service:
+          namespace: ack-system
+          name: ack-{{ .ServicePackageName }}-webhook-service
+          path: /convert
+      conversionReviewVersions:
+      - v1
+      - v1beta1
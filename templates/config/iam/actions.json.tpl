@@ -0,0 +1 @@
+{{ .ActionsJSON }}
@@ -0,0 +1,15 @@
+# IAM actions of the {{ .CRD.Kind }} resources
+
+The IAM actions the {{ .ServicePackageName }} controller performs for the
+{{ .CRD.Kind }} resources, by the path of the reconciliation performing them.
+
+| Path | IAM actions |
+| ---- | ----------- |
+| Create | {{ range $i, $action := .Actions.Create }}{{ if $i }}, {{ end }}`{{ $action }}`{{ else }}-{{ end }} |
+| Read | {{ range $i, $action := .Actions.Read }}{{ if $i }}, {{ end }}`{{ $action }}`{{ else }}-{{ end }} |
+| Update | {{ range $i, $action := .Actions.Update }}{{ if $i }}, {{ end }}`{{ $action }}`{{ else }}-{{ end }} |
+| Delete | {{ range $i, $action := .Actions.Delete }}{{ if $i }}, {{ end }}`{{ $action }}`{{ else }}-{{ end }} |
+| Tag | {{ range $i, $action := .Actions.Tag }}{{ if $i }}, {{ end }}`{{ $action }}`{{ else }}-{{ end }} |
+{{- if .Actions.Extra }}
+| Extra | {{ range $i, $action := .Actions.Extra }}{{ if $i }}, {{ end }}`{{ $action }}`{{ end }} |
+{{- end }}
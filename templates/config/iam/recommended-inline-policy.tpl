@@ -0,0 +1 @@
+{{ .PolicyJSON }}
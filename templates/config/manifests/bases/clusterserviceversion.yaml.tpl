@@ -20,7 +20,7 @@ spec:
     owned: 
     {{- range .CRDs}}
     - kind: {{ .Kind}}
-      name: {{ ToLower .Plural }}.{{$.APIGroup}}
+      name: {{ ToLower .Plural }}.{{ .APIGroup }}
       version: {{$.APIVersion}}
       displayName: {{.Kind}}
       description: {{.Kind}} represents the state of an AWS {{$.ServicePackageName}} {{.Kind}} resource.
@@ -6,13 +6,40 @@ metadata:
   name: ack-{{ .ServicePackageName }}-reader
   namespace: default
 rules:
+{{- if .NamespacedCRDNames }}
 - apiGroups:
   - {{ .APIGroup }}
+{{- range .AdditionalAPIGroups }}
+  - {{ .Group }}
+{{- end }}
+  resources:
+{{- range $crdName := .NamespacedCRDNames }}
+  - {{ $crdName }}
+{{- end }}
+  verbs:
+  - get
+  - list
+  - watch
+{{- end }}
+{{- if .ClusterScopedCRDNames }}
+---
+apiVersion: rbac.authorization.k8s.io/v1
+kind: ClusterRole
+metadata:
+  creationTimestamp: null
+  name: ack-{{ .ServicePackageName }}-cluster-reader
+rules:
+- apiGroups:
+  - {{ .APIGroup }}
+{{- range .AdditionalAPIGroups }}
+  - {{ .Group }}
+{{- end }}
   resources:
-{{- range $crdName := .CRDNames }}
+{{- range $crdName := .ClusterScopedCRDNames }}
   - {{ $crdName }}
 {{- end }}
   verbs:
   - get
   - list
   - watch
+{{- end }}
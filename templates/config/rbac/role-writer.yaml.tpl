@@ -6,10 +6,14 @@ metadata:
   name: ack-{{ .ServicePackageName }}-writer
   namespace: default
 rules:
+{{- if .NamespacedCRDNames }}
 - apiGroups:
   - {{ .APIGroup }}
+{{- range .AdditionalAPIGroups }}
+  - {{ .Group }}
+{{- end }}
   resources:
-{{- range $crdName := .CRDNames }}
+{{- range $crdName := .NamespacedCRDNames }}
   - {{ $crdName }}
 {{- end }}
   verbs:
@@ -22,11 +26,54 @@ rules:
   - watch
 - apiGroups:
   - {{ .APIGroup }}
+{{- range .AdditionalAPIGroups }}
+  - {{ .Group }}
+{{- end }}
   resources:
-{{- range $crdName := .CRDNames }}
+{{- range $crdName := .NamespacedCRDNames }}
   - {{ $crdName }}
 {{- end }}
   verbs:
   - get
   - patch
   - update
+{{- end }}
+{{- if .ClusterScopedCRDNames }}
+---
+apiVersion: rbac.authorization.k8s.io/v1
+kind: ClusterRole
+metadata:
+  creationTimestamp: null
+  name: ack-{{ .ServicePackageName }}-cluster-writer
+rules:
+- apiGroups:
+  - {{ .APIGroup }}
+{{- range .AdditionalAPIGroups }}
+  - {{ .Group }}
+{{- end }}
+  resources:
+{{- range $crdName := .ClusterScopedCRDNames }}
+  - {{ $crdName }}
+{{- end }}
+  verbs:
+  - create
+  - delete
+  - get
+  - list
+  - patch
+  - update
+  - watch
+- apiGroups:
+  - {{ .APIGroup }}
+{{- range .AdditionalAPIGroups }}
+  - {{ .Group }}
+{{- end }}
+  resources:
+{{- range $crdName := .ClusterScopedCRDNames }}
+  - {{ $crdName }}
+{{- end }}
+  verbs:
+  - get
+  - patch
+  - update
+{{- end }}
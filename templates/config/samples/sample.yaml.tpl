@@ -1,6 +1,6 @@
 {{- range .Samples -}}
 ---
-apiVersion: {{$.APIGroup}}/{{$.APIVersion}}
+apiVersion: {{ .APIGroup }}/{{$.APIVersion}}
 kind: {{.Kind}}
 metadata:
   name: example
@@ -0,0 +1,12 @@
+apiVersion: v1
+kind: Service
+metadata:
+  name: ack-{{ .ServicePackageName }}-webhook-service
+  namespace: ack-system
+spec:
+  selector:
+    control-plane: controller
+  ports:
+    - port: 443
+      targetPort: 9443
+      protocol: TCP
@@ -0,0 +1,12 @@
+# {{ .ServiceID }} API Reference
+
+API reference for the `{{ .APIGroup }}/{{ .APIVersion }}` custom resources.
+
+## Resources
+{{ range $crd := .CRDs }}
+* [{{ $crd.Kind }}]({{ $crd.Kind | ToSnake }}.md)
+{{- end }}
+
+## Types
+
+* [Types](types.md)
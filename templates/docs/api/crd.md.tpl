@@ -0,0 +1,27 @@
+# {{ .CRD.Kind }}
+
+**API Version:** `{{ .APIGroup }}/{{ .APIVersion }}`
+
+**Kind:** `{{ .CRD.Kind }}`
+
+**Plural:** `{{ .CRD.Plural | ToLower }}`
+
+{{ DocString .CRD.Documentation }}
+
+## Spec
+
+| Field | Type | Required | Description |
+| ----- | ---- | -------- | ----------- |
+{{- range $fieldName, $field := .CRD.SpecFields }}
+| `{{ $field.Names.CamelLower }}` | `{{ $field.GoType }}` | {{ if $field.IsRequired }}Yes{{ else }}No{{ end }} | {{ DocTableCell $field.Documentation }} |
+{{- end }}
+
+## Status
+
+| Field | Type | Description |
+| ----- | ---- | ----------- |
+| `ackResourceMetadata` | `*ackv1alpha1.ResourceMetadata` | Resource sync state, account ownership and constructed ARN for the resource. |
+| `conditions` | `[]*ackv1alpha1.Condition` | Conditions describing the various terminal states of the resource and its backend AWS service API resource. |
+{{- range $fieldName, $field := .CRD.StatusFields }}
+| `{{ $field.Names.CamelLower }}` | `{{ $field.GoType }}` | {{ DocTableCell $field.Documentation }} |
+{{- end }}
@@ -0,0 +1,17 @@
+# {{ .ServiceID }} Types
+
+Types referenced by the fields of the `{{ .APIGroup }}/{{ .APIVersion }}` resources.
+{{- range $typeDef := .TypeDefs }}
+
+## {{ $typeDef.Names.Camel }}
+{{- if $typeDef.Shape }}{{ if $typeDef.Shape.Documentation }}
+
+{{ DocString $typeDef.Shape.Documentation }}
+{{- end }}{{ end }}
+
+| Field | Type | Description |
+| ----- | ---- | ----------- |
+{{- range $attrName, $attr := $typeDef.Attrs }}
+| `{{ $attr.Names.CamelLower }}` | `{{ $attr.GoType }}` | {{ DocTableCell $attr.Documentation }} |
+{{- end }}
+{{- end }}
@@ -4,7 +4,10 @@ package {{ .CRD.Names.Snake }}
 
 import (
 	"bytes"
+	"encoding/json"
+	"math"
 	"reflect"
+	"strings"
 
 	ackcompare "github.com/aws-controllers-k8s/runtime/pkg/compare"
 )
@@ -13,6 +16,9 @@ import (
 var (
 	_ = &bytes.Buffer{}
 	_ = &reflect.Method{}
+	_ = json.Marshal
+	_ = math.Abs
+	_ = strings.EqualFold
 )
 
 // newResourceDelta returns a new `ackcompare.Delta` used to compare two
@@ -37,3 +43,51 @@ func newResourceDelta(
 {{- end }}
 	return delta
 }
+{{- if .CRD.GetSensitiveFieldPaths }}
+
+// sensitiveFieldPaths are the paths of the fields whose values are redacted
+// from the resource's deltas before they are logged
+var sensitiveFieldPaths = []string{
+{{- range $path := .CRD.GetSensitiveFieldPaths }}
+	"{{ $path }}",
+{{- end }}
+}
+
+// redactedValue replaces the redacted values in the resource's deltas
+const redactedValue = "<redacted>"
+
+// redactedDelta returns a copy of the supplied delta, to be logged, whose
+// differences at, or containing, a sensitive field have their values
+// redacted. The supplied delta, which updates are made with, keeps the
+// actual values.
+func redactedDelta(delta *ackcompare.Delta) *ackcompare.Delta {
+	redacted := ackcompare.NewDelta()
+	for _, diff := range delta.Differences {
+		redactedDiff := *diff
+		diffPath := diff.Path.String()
+		for _, path := range sensitiveFieldPaths {
+			if diffPath == "" || diffPath == path ||
+				strings.HasPrefix(diffPath, path+".") ||
+				strings.HasPrefix(path, diffPath+".") {
+				redactedDiff.A = redactedValue
+				redactedDiff.B = redactedValue
+				break
+			}
+		}
+		redacted.Differences = append(redacted.Differences, &redactedDiff)
+	}
+	return redacted
+}
+{{- end }}
+
+// equalJSONStrings returns true if the supplied strings are the same JSON
+// document, regardless of their formatting and of the order of their object
+// keys. Strings that aren't JSON documents are compared as is.
+func equalJSONStrings(a, b string) bool {
+	var aDoc, bDoc interface{}
+	if json.Unmarshal([]byte(a), &aDoc) != nil ||
+		json.Unmarshal([]byte(b), &bDoc) != nil {
+		return a == b
+	}
+	return reflect.DeepEqual(aDoc, bDoc)
+}
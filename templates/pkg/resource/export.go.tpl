@@ -0,0 +1,148 @@
+{{ template "boilerplate" }}
+
+package {{ .CRD.Names.Snake }}
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	ctrlrtconfig "sigs.k8s.io/controller-runtime/pkg/client/config"
+
+	svcapitypes "github.com/aws-controllers-k8s/{{ .ServicePackageName }}-controller/{{ .APIPackagePath }}"
+)
+{{- $kind := .CRD.Export.GetKind }}
+
+// +kubebuilder:rbac:groups="",resources={{ if eq $kind "ConfigMap" }}configmaps{{ else }}secrets{{ end }},verbs=get;create;update
+
+var (
+	// exportClient writes the {{ $kind }}s the resources' field values are
+	// exported to
+	exportClient     kubernetes.Interface
+	exportClientErr  error
+	exportClientOnce sync.Once
+)
+
+// getExportClient returns the client writing the {{ $kind }}s the resources'
+// field values are exported to, creating it on first use
+func getExportClient() (kubernetes.Interface, error) {
+	exportClientOnce.Do(func() {
+		restConfig, err := ctrlrtconfig.GetConfig()
+		if err != nil {
+			exportClientErr = err
+			return
+		}
+		exportClient, exportClientErr = kubernetes.NewForConfig(restConfig)
+	})
+	return exportClient, exportClientErr
+}
+
+// exportData returns the values of the supplied resource's exported fields,
+// keyed by the {{ $kind }} keys they are written to
+func exportData(ko *svcapitypes.{{ .CRD.Names.Camel }}) map[string]string {
+	data := map[string]string{}
+{{ GoCodeExportData .CRD "ko" "data" 1 -}}
+	return data
+}
+
+// exportOwnerReference returns the reference making the supplied resource
+// the owner of the {{ $kind }} its field values are exported to
+func exportOwnerReference(ko *svcapitypes.{{ .CRD.Names.Camel }}) metav1.OwnerReference {
+	return metav1.OwnerReference{
+		APIVersion: svcapitypes.GroupVersion.String(),
+		Kind:       "{{ .CRD.Names.Camel }}",
+		Name:       ko.Name,
+		UID:        ko.UID,
+	}
+}
+
+// exportFields writes the values of the supplied resource's exported fields
+// to the {{ $kind }} named by its Spec.ExportTo, creating the {{ $kind }},
+// owned by the resource, if it doesn't exist yet
+func (rm *resourceManager) exportFields(
+	ctx context.Context,
+	r *resource,
+) error {
+	ko := r.ko
+	if ko.Spec.ExportTo == nil || *ko.Spec.ExportTo == "" {
+		return nil
+	}
+	name := *ko.Spec.ExportTo
+	data := exportData(ko)
+	kc, err := getExportClient()
+	if err != nil {
+		return err
+	}
+{{- if eq $kind "ConfigMap" }}
+	client := kc.CoreV1().ConfigMaps(ko.Namespace)
+	obj, err := client.Get(ctx, name, metav1.GetOptions{})
+	if apierrors.IsNotFound(err) {
+		obj = &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:            name,
+				Namespace:       ko.Namespace,
+				OwnerReferences: []metav1.OwnerReference{exportOwnerReference(ko)},
+			},
+			Data: data,
+		}
+		if _, err = client.Create(ctx, obj, metav1.CreateOptions{}); err != nil {
+			return fmt.Errorf("creating exported ConfigMap %s/%s: %v", ko.Namespace, name, err)
+		}
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("reading exported ConfigMap %s/%s: %v", ko.Namespace, name, err)
+	}
+	changed := false
+	if obj.Data == nil {
+		obj.Data = map[string]string{}
+	}
+	for key, value := range data {
+		if current, found := obj.Data[key]; !found || current != value {
+			obj.Data[key] = value
+			changed = true
+		}
+	}
+{{- else }}
+	client := kc.CoreV1().Secrets(ko.Namespace)
+	obj, err := client.Get(ctx, name, metav1.GetOptions{})
+	if apierrors.IsNotFound(err) {
+		obj = &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:            name,
+				Namespace:       ko.Namespace,
+				OwnerReferences: []metav1.OwnerReference{exportOwnerReference(ko)},
+			},
+			StringData: data,
+		}
+		if _, err = client.Create(ctx, obj, metav1.CreateOptions{}); err != nil {
+			return fmt.Errorf("creating exported Secret %s/%s: %v", ko.Namespace, name, err)
+		}
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("reading exported Secret %s/%s: %v", ko.Namespace, name, err)
+	}
+	changed := false
+	if obj.Data == nil {
+		obj.Data = map[string][]byte{}
+	}
+	for key, value := range data {
+		if current, found := obj.Data[key]; !found || string(current) != value {
+			obj.Data[key] = []byte(value)
+			changed = true
+		}
+	}
+{{- end }}
+	if !changed {
+		return nil
+	}
+	if _, err = client.Update(ctx, obj, metav1.UpdateOptions{}); err != nil {
+		return fmt.Errorf("updating exported {{ $kind }} %s/%s: %v", ko.Namespace, name, err)
+	}
+	return nil
+}
@@ -5,6 +5,12 @@ package {{ .CRD.Names.Snake }}
 import (
 	"context"
 	"fmt"
+{{- if .CRD.RegionFrom }}
+	"regexp"
+{{- end }}
+{{- if .CRD.ErrorRequeueCodes }}
+	"sync"
+{{- end }}
 	"time"
 
 	ackv1alpha1 "github.com/aws-controllers-k8s/runtime/apis/core/v1alpha1"
@@ -16,12 +22,17 @@ import (
 	ackrequeue "github.com/aws-controllers-k8s/runtime/pkg/requeue"
 	ackrtlog "github.com/aws-controllers-k8s/runtime/pkg/runtime/log"
 	acktypes "github.com/aws-controllers-k8s/runtime/pkg/types"
+{{- if .CRD.RegionFrom }}
+	"github.com/aws/aws-sdk-go/aws"
+{{- end }}
 	"github.com/aws/aws-sdk-go/aws/session"
 	"github.com/go-logr/logr"
 	corev1 "k8s.io/api/core/v1"
 
-	svcsdk "github.com/aws/aws-sdk-go/service/{{ .ServicePackageName }}"
-	svcsdkapi "github.com/aws/aws-sdk-go/service/{{ .ServicePackageName }}/{{ .ServicePackageName }}iface"
+	svcsdk "github.com/aws/aws-sdk-go/service/{{ .SDKPackageName }}"
+	svcsdkapi "github.com/aws/aws-sdk-go/service/{{ .SDKPackageName }}/{{ .SDKPackageName }}iface"
+
+	svcresource "github.com/aws-controllers-k8s/{{ .ServicePackageName }}-controller/pkg/resource"
 )
 
 // +kubebuilder:rbac:groups={{ .APIGroup }},resources={{ ToLower .CRD.Plural }},verbs=get;list;watch;create;update;patch;delete
@@ -78,6 +89,16 @@ func (rm *resourceManager) ReadOne(
 		// Should never happen... if it does, it's buggy code.
 		panic("resource manager's ReadOne() method received resource with nil CR object")
 	}
+{{- if .CRD.RegionFrom }}
+	if regionalRM, err := rm.regionalManager(r); err != nil {
+		return rm.onError(r, err)
+	} else if regionalRM != rm {
+		return regionalRM.ReadOne(ctx, res)
+	}
+{{- end }}
+{{- if .CRD.EndpointURLFrom }}
+	ctx = svcresource.WithEndpointURL(ctx, endpointURL(r))
+{{- end }}
 	observed, err := rm.sdkFind(ctx, r)
 	if err != nil {
 		if observed != nil {
@@ -85,6 +106,11 @@ func (rm *resourceManager) ReadOne(
 		}
 		return rm.onError(r, err)
 	}
+{{- if .CRD.Export }}
+	if err := rm.exportFields(ctx, observed); err != nil {
+		return rm.onError(observed, err)
+	}
+{{- end }}
 	return rm.onSuccess(observed)
 }
 
@@ -100,12 +126,57 @@ func (rm *resourceManager) Create(
 		// Should never happen... if it does, it's buggy code.
 		panic("resource manager's Create() method received resource with nil CR object")
 	}
+{{- if .CRD.RegionFrom }}
+	if regionalRM, err := rm.regionalManager(r); err != nil {
+		return rm.onError(r, err)
+	} else if regionalRM != rm {
+		return regionalRM.Create(ctx, res)
+	}
+{{- end }}
+{{- if .CRD.EndpointURLFrom }}
+	ctx = svcresource.WithEndpointURL(ctx, endpointURL(r))
+{{- end }}
+{{- if .CRD.ReferenceFields }}
+	resolved, err := rm.resolveReferences(ctx, r)
+	if err != nil {
+		return rm.onError(resolved, err)
+	}
+	r = resolved
+{{- end }}
 	created, err := rm.sdkCreate(ctx, r)
 	if err != nil {
 		return rm.onError(r, err)
 	}
+{{- if .CRD.ReadBackOnCreate }}
+	created = rm.readBackCreated(ctx, created)
+{{- end }}
+{{- if .CRD.GetRecreateFieldPaths }}
+	setRecreatingCondition(created.ko, corev1.ConditionFalse)
+{{- end }}
 	return rm.onSuccess(created)
 }
+{{- if .CRD.ReadBackOnCreate }}
+
+// readBackCreated reads the supplied newly-created resource back from the
+// backend AWS service API and sets the fields of its Spec that weren't set to
+// the defaults the service assigned to them, so that the first reconciliation
+// doesn't see them as differences. The resource is returned as is if it can't
+// be read yet; the next reconciliations observe its defaults.
+func (rm *resourceManager) readBackCreated(
+	ctx context.Context,
+	created *resource,
+) *resource {
+	rlog := ackrtlog.FromContext(ctx)
+	observed, err := rm.sdkFind(ctx, created)
+	if err != nil {
+		rlog.Debug("unable to read back created resource", "error", err)
+		return created
+	}
+	ko := created.ko.DeepCopy()
+{{ GoCodeReadBackSpecDefaults .CRD "observed.ko" "ko" 1 }}
+	return &resource{ko}
+}
+{{- end }}
 
 // Update attempts to mutate the supplied desired AWSResource in the backend AWS
 // service API, returning an AWSResource representing the newly-mutated
@@ -127,6 +198,39 @@ func (rm *resourceManager) Update(
 		// Should never happen... if it does, it's buggy code.
 		panic("resource manager's Update() method received resource with nil CR object")
 	}
+{{- if .CRD.RegionFrom }}
+	if regionalRM, err := rm.regionalManager(desired); err != nil {
+		return rm.onError(latest, err)
+	} else if regionalRM != rm {
+		return regionalRM.Update(ctx, resDesired, resLatest, delta)
+	}
+{{- end }}
+{{- if .CRD.EndpointURLFrom }}
+	ctx = svcresource.WithEndpointURL(ctx, endpointURL(desired))
+{{- end }}
+{{- if .CRD.ReferenceFields }}
+	resolved, err := rm.resolveReferences(ctx, desired)
+	if err != nil {
+		return rm.onError(withReferenceConditions(latest, resolved), err)
+	}
+	desired = resolved
+{{- end }}
+{{- if .CRD.GetRecreateFieldPaths }}
+	if isRecreating(latest) || rm.recreateRequested(desired, delta) {
+		// The desired resource is created once the latest resource is
+		// reported as not found
+		recreating, err := rm.recreate(ctx, latest)
+		if err != nil {
+			return rm.onError(latest, err)
+		}
+		return recreating, ackrequeue.NeededAfter(nil, recreateRequeueDelay)
+	}
+{{- end }}
+{{- if .CRD.GetSensitiveFieldPaths }}
+	// Only the logged delta has the values of the sensitive fields redacted
+	rlog := ackrtlog.FromContext(ctx)
+	rlog.Debug("updating resource", "diff", redactedDelta(delta).Differences)
+{{- end }}
 	updated, err := rm.sdkUpdate(ctx, desired, latest, delta)
 	if err != nil {
 		return rm.onError(latest, err)
@@ -146,6 +250,21 @@ func (rm *resourceManager) Delete(
 		// Should never happen... if it does, it's buggy code.
 		panic("resource manager's Update() method received resource with nil CR object")
 	}
+{{- if .CRD.RegionFrom }}
+	if regionalRM, err := rm.regionalManager(r); err != nil {
+		return rm.onError(r, err)
+	} else if regionalRM != rm {
+		return regionalRM.Delete(ctx, res)
+	}
+{{- end }}
+{{- if .CRD.EndpointURLFrom }}
+	ctx = svcresource.WithEndpointURL(ctx, endpointURL(r))
+{{- end }}
+{{- if .CRD.HasDeletionOrder }}
+	if err := rm.checkDeletionOrder(ctx, r); err != nil {
+		return rm.onError(r, err)
+	}
+{{- end }}
 	observed, err := rm.sdkDelete(ctx, r)
 	if err != nil {
 		if observed != nil {
@@ -183,7 +302,11 @@ func (rm *resourceManager) LateInitialize(
 	// If there are no fields to late initialize, do nothing
 	if len(lateInitializeFieldNames) == 0 {
 		rlog.Debug("no late initialization required.")
+{{- if .CRD.HasResync }}
+		return latest, resyncRequeue(latest)
+{{- else }}
 		return latest, nil
+{{- end }}
 	}
 	latestCopy := latest.DeepCopy()
 	lateInitConditionReason := ""
@@ -214,8 +337,34 @@ func (rm *resourceManager) LateInitialize(
 	lateInitConditionMessage = "Late initialization successful"
 	lateInitConditionReason = "Late initialization successful"
 	ackcondition.SetLateInitialized(lateInitializedRes, corev1.ConditionTrue, &lateInitConditionMessage, &lateInitConditionReason)
+{{- if .CRD.HasResync }}
+	return lateInitializedRes, resyncRequeue(lateInitializedRes)
+{{- else }}
 	return lateInitializedRes, nil
+{{- end }}
+}
+{{- if .CRD.HasResync }}
+
+// resyncPeriod returns the duration after which the supplied resource is
+// requeued once it is reconciled, depending on its current state. The
+// resource isn't requeued if it is 0.
+func resyncPeriod(
+	r *resource,
+) time.Duration {
+{{ GoCodeResyncPeriod .CRD "r.ko" 1 -}}
+}
+
+// resyncRequeue returns the error requeuing the supplied resource after its
+// resync period, or nil if it isn't requeued
+func resyncRequeue(
+	res acktypes.AWSResource,
+) error {
+	if period := resyncPeriod(res.(*resource)); period > 0 {
+		return ackrequeue.NeededAfter(nil, period)
+	}
+	return nil
 }
+{{- end }}
 
 // incompleteLateInitialization return true if there are fields which were supposed to be
 // late initialized but are not. If all the fields are late initialized, false is returned
@@ -233,6 +382,81 @@ func (rm *resourceManager) lateInitializeFromReadOneOutput(
 ) acktypes.AWSResource {
 {{ GoCodeLateInitializeFromReadOne .CRD "observed" "latest" 1 }}
 }
+{{- if .CRD.EndpointURLFrom }}
+
+// endpointURL returns the URL overriding the AWS API endpoint called for the
+// supplied resource, empty if it isn't overridden
+func endpointURL(r *resource) string {
+{{- if eq .CRD.EndpointURLFrom "annotation" }}
+	return r.ko.GetAnnotations()[svcresource.EndpointURLAnnotation]
+{{- else }}
+	if r.ko.Spec.EndpointURL == nil {
+		return ""
+	}
+	return *r.ko.Spec.EndpointURL
+{{- end }}
+}
+{{- end }}
+
+{{- if .CRD.RegionFrom }}
+
+// regionPattern matches the names of the AWS regions, e.g. "us-west-2"
+var regionPattern = regexp.MustCompile(`^[a-z]{2}(-[a-z]+)+-[0-9]+$`)
+
+// invalidRegionError is returned when the AWS region selected for a resource
+// isn't the name of an AWS region
+type invalidRegionError struct {
+	region string
+}
+
+func (e *invalidRegionError) Error() string {
+	return fmt.Sprintf("invalid AWS region %q", e.region)
+}
+
+// isInvalidRegion returns true if the supplied error is returned when the AWS
+// region selected for a resource isn't the name of an AWS region
+func isInvalidRegion(err error) bool {
+	_, ok := err.(*invalidRegionError)
+	return ok
+}
+
+// resourceRegion returns the AWS region selected for the supplied resource,
+// empty if it is managed in the region of its namespace
+func resourceRegion(r *resource) string {
+{{- if eq .CRD.RegionFrom "annotation" }}
+	return r.ko.GetAnnotations()[svcresource.RegionAnnotation]
+{{- else }}
+	if r.ko.Spec.Region == nil {
+		return ""
+	}
+	return *r.ko.Spec.Region
+{{- end }}
+}
+
+// regionalManager returns the resource manager managing the supplied
+// resource in the AWS region selected for it, which is rm itself unless the
+// resource selects another region than rm's
+func (rm *resourceManager) regionalManager(
+	r *resource,
+) (*resourceManager, error) {
+	region := resourceRegion(r)
+	if region == "" || ackv1alpha1.AWSRegion(region) == rm.awsRegion {
+		return rm, nil
+	}
+	if !regionPattern.MatchString(region) {
+		return nil, &invalidRegionError{region: region}
+	}
+	regionalRM, err := rmFactory.ManagerFor(
+		rm.cfg, rm.log, rm.metrics, rm.rr,
+		rm.sess.Copy(aws.NewConfig().WithRegion(region)),
+		rm.awsAccountID, ackv1alpha1.AWSRegion(region),
+	)
+	if err != nil {
+		return nil, err
+	}
+	return regionalRM.(*resourceManager), nil
+}
+{{- end }}
 
 // newResourceManager returns a new struct implementing
 // acktypes.AWSResourceManager
@@ -245,6 +469,20 @@ func newResourceManager(
 	id ackv1alpha1.AWSAccountID,
 	region ackv1alpha1.AWSRegion,
 ) (*resourceManager, error) {
+	sdkapi := svcsdk.New(sess, svcresource.EndpointConfig(
+		{{ if .CRD.Endpoint.OverridesFIPS }}{{ .CRD.Endpoint.GetFIPS }}{{ else }}svcresource.UseFIPSEndpoint{{ end }},
+		{{ if .CRD.Endpoint.OverridesDualStack }}{{ .CRD.Endpoint.GetDualStack }}{{ else }}svcresource.UseDualStackEndpoint{{ end }},
+	))
+	svcresource.AddUserAgentHandler(&sdkapi.Handlers, "{{ .CRD.Names.Camel }}")
+{{- if .CRD.EndpointURLFrom }}
+	svcresource.AddEndpointURLHandler(&sdkapi.Handlers)
+{{- end }}
+{{- if .CRD.Config.HasRateLimits }}
+	svcresource.AddRateLimitHandler(&sdkapi.Handlers)
+{{- end }}
+{{- if .CRD.Config.HasRetryConfig }}
+	svcresource.ConfigureRetries(sdkapi.Client)
+{{- end }}
 	return &resourceManager{
 		cfg: cfg,
 		log: log,
@@ -253,7 +491,7 @@ func newResourceManager(
 		awsAccountID: id,
 		awsRegion: region,
 		sess:		 sess,
-		sdkapi:	   svcsdk.New(sess),
+		sdkapi:	   sdkapi,
 	}, nil
 }
 
@@ -266,6 +504,9 @@ func (rm *resourceManager) onError(
 	if r == nil {
 		return nil, err
 	}
+{{- if .CRD.ErrorRequeueCodes }}
+	err = applyErrorRequeuePolicy(r, err)
+{{- end }}
 	r1, updated := rm.updateConditions(r, false, err)
 	if !updated {
 		return r, err
@@ -289,9 +530,117 @@ func (rm *resourceManager) onSuccess(
 	if r == nil  {
 		return nil, nil
 	}
+{{- if .CRD.ErrorRequeueCodes }}
+	resetErrorRetries(r)
+{{- end }}
 	r1, updated := rm.updateConditions(r, true, nil)
 	if !updated {
 		return r, nil
 	}
 	return r1, nil
 }
+{{- if .CRD.ErrorRequeueCodes }}
+
+// errorRequeuePolicy describes how the resource is requeued when an AWS error
+// is returned
+type errorRequeuePolicy struct {
+	// delay is the duration after which the resource is requeued, the
+	// runtime's exponential backoff is used if it is 0
+	delay time.Duration
+	// maxRetries is the number of times the resource is retried for the same
+	// generation, indefinitely if it is 0
+	maxRetries int
+}
+
+// errorRequeuePolicies are the requeue policies of the AWS errors, keyed by
+// their code
+var errorRequeuePolicies = map[string]errorRequeuePolicy{
+{{- range $code := .CRD.ErrorRequeueCodes }}
+{{- $policy := index $.CRD.ErrorRequeues $code }}
+	"{{ $code }}": {
+		delay:      {{ $policy.DelaySeconds }} * time.Second,
+		maxRetries: {{ $policy.MaxRetries }},
+	},
+{{- end }}
+}
+
+// errorRetryKey identifies the retries of a generation of a resource after an
+// AWS error
+type errorRetryKey struct {
+	namespace  string
+	name       string
+	generation int64
+	code       string
+}
+
+var (
+	// errorRetries counts the retries of the resources after AWS errors
+	errorRetries   = map[errorRetryKey]int{}
+	errorRetriesMu sync.Mutex
+)
+
+// retriesExhaustedError is returned in place of an AWS error once the
+// resource was retried as many times as its requeue policy allows
+type retriesExhaustedError struct {
+	err     error
+	retries int
+}
+
+func (e *retriesExhaustedError) Error() string {
+	return fmt.Sprintf("giving up after %d retries: %s", e.retries, e.err.Error())
+}
+
+// isRetriesExhausted returns true if the supplied error is returned once the
+// resource was retried as many times as its requeue policy allows
+func isRetriesExhausted(err error) bool {
+	_, ok := err.(*retriesExhaustedError)
+	return ok
+}
+
+// applyErrorRequeuePolicy returns the supplied error wrapped to requeue the
+// resource after the delay of the error's requeue policy, or a
+// retriesExhaustedError once the policy's retries are exhausted. Errors
+// without a requeue policy are returned as is.
+func applyErrorRequeuePolicy(
+	r *resource,
+	err error,
+) error {
+	awsErr, ok := ackerr.AWSError(err)
+	if !ok {
+		return err
+	}
+	policy, found := errorRequeuePolicies[awsErr.Code()]
+	if !found {
+		return err
+	}
+	key := errorRetryKey{
+		namespace:  r.ko.Namespace,
+		name:       r.ko.Name,
+		generation: r.ko.Generation,
+		code:       awsErr.Code(),
+	}
+	errorRetriesMu.Lock()
+	errorRetries[key]++
+	retries := errorRetries[key]
+	errorRetriesMu.Unlock()
+	if policy.maxRetries > 0 && retries > policy.maxRetries {
+		return &retriesExhaustedError{err: err, retries: policy.maxRetries}
+	}
+	if policy.delay > 0 {
+		return ackrequeue.NeededAfter(err, policy.delay)
+	}
+	return err
+}
+
+// resetErrorRetries forgets the retries of the supplied resource after AWS
+// errors, once it is reconciled successfully
+func resetErrorRetries(r *resource) {
+	errorRetriesMu.Lock()
+	defer errorRetriesMu.Unlock()
+	for key := range errorRetries {
+		if key.namespace == r.ko.Namespace && key.name == r.ko.Name {
+			delete(errorRetries, key)
+		}
+	}
+}
+{{- end }}
@@ -57,6 +57,9 @@ func (f *resourceManagerFactory) ManagerFor(
 	if err != nil {
 		return nil, err
 	}
+{{- if .CRD.ReferenceFields }}
+	setReferencesReconciler(rr)
+{{- end }}
 	f.rmCache[rmId] = rm
 	return rm, nil
 }
@@ -82,6 +85,10 @@ func newResourceManagerFactory() *resourceManagerFactory {
 	}
 }
 
+// rmFactory produces the resource managers of the resources, for each AWS
+// account and region
+var rmFactory = newResourceManagerFactory()
+
 func init() {
-	svcresource.RegisterManagerFactory(newResourceManagerFactory())
+	svcresource.RegisterManagerFactory(rmFactory)
 }
@@ -0,0 +1,670 @@
+{{ template "boilerplate" }}
+
+package {{ .CRD.Names.Snake }}
+
+import (
+	"context"
+{{- if or .CRD.ReferenceFields .CRD.HasDeletionOrder }}
+	"fmt"
+	"sync"
+	"time"
+
+	ackrequeue "github.com/aws-controllers-k8s/runtime/pkg/requeue"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/dynamic"
+	ctrlrtconfig "sigs.k8s.io/controller-runtime/pkg/client/config"
+{{- end }}
+{{- if or .CRD.ReferenceFields .CRD.DeletionDependents }}
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+{{- end }}
+{{- if .CRD.ReferenceFields }}
+	"errors"
+
+	ackv1alpha1 "github.com/aws-controllers-k8s/runtime/apis/core/v1alpha1"
+	acktypes "github.com/aws-controllers-k8s/runtime/pkg/types"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	k8stypes "k8s.io/apimachinery/pkg/types"
+{{- if .CRD.HasOwnerReferenceFields }}
+	"k8s.io/apimachinery/pkg/util/validation"
+{{- end }}
+	"k8s.io/client-go/util/jsonpath"
+	ctrlrt "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+
+	svcapitypes "github.com/aws-controllers-k8s/{{ .ServicePackageName }}-controller/{{ .APIPackagePath }}"
+	svcresource "github.com/aws-controllers-k8s/{{ .ServicePackageName }}-controller/pkg/resource"
+{{- end }}
+{{- if .CRD.DeletionPrerequisites }}
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+{{- end }}
+)
+{{- if or .CRD.ReferenceFields .CRD.HasDeletionOrder }}
+
+var (
+	// dynamicClient reads the objects related to the resources
+	dynamicClient     dynamic.Interface
+	dynamicClientErr  error
+	dynamicClientOnce sync.Once
+)
+
+// getDynamicClient returns the client reading the objects related to the
+// resources, creating it on first use
+func getDynamicClient() (dynamic.Interface, error) {
+	dynamicClientOnce.Do(func() {
+		restConfig, err := ctrlrtconfig.GetConfig()
+		if err != nil {
+			dynamicClientErr = err
+			return
+		}
+		dynamicClient, dynamicClientErr = dynamic.NewForConfig(restConfig)
+	})
+	return dynamicClient, dynamicClientErr
+}
+{{- end }}
+{{- if .CRD.ReferenceFields }}
+{{ range $ref := .CRD.ReferenceFields }}
+// +kubebuilder:rbac:groups={{ if $ref.Group }}{{ $ref.Group }}{{ else }}""{{ end }},resources={{ $ref.Resource }},verbs=get;list;watch
+{{- end }}
+
+// errCrossNamespaceReference is returned when a resource references an object
+// in another namespace while cross-namespace references aren't allowed
+var errCrossNamespaceReference = errors.New(
+	"cross-namespace references are not allowed, see the --allow-cross-namespace-references flag",
+)
+
+const (
+	// referencesIndexField is the name of the index of the resources by the
+	// objects they reference
+	referencesIndexField = "spec.references"
+	// referenceRequeueAfter is how long to wait before resolving again the
+	// references to objects that don't exist or don't have the value yet.
+	// The reference watches re-reconcile the resources as soon as the objects
+	// change, so this is only a fallback.
+	referenceRequeueAfter = 5 * time.Minute
+)
+
+var (
+	// referencesReconciler is the reconciler of the resources, used to
+	// re-reconcile them when the objects they reference change
+	referencesReconciler   reconcile.Reconciler
+	referencesReconcilerMu sync.RWMutex
+)
+{{- end }}
+
+// resolveReferences returns a copy of the supplied resource whose Spec fields
+// referencing other Kubernetes objects are set from the referenced objects,
+// or the supplied resource if it has no such fields. The copy has a condition
+// per reference field telling whether its referenced objects were resolved,
+// and is returned along with the error of the first unresolved reference.
+func (rm *resourceManager) resolveReferences(
+	ctx context.Context,
+	r *resource,
+) (*resource, error) {
+{{- if not .CRD.ReferenceFields }}
+	return r, nil
+}
+{{- else }}
+	ko := r.ko.DeepCopy()
+{{- range $ref := .CRD.ReferenceFields }}
+{{- if $ref.IsList }}
+	if ko.Spec.{{ $ref.RefField.Names.Camel }} != nil {
+		values := []*string{}
+{{- if $ref.IsOwner }}
+		owners := []*unstructured.Unstructured{}
+{{- end }}
+		for _, ref := range ko.Spec.{{ $ref.RefField.Names.Camel }} {
+			if ref == nil {
+				continue
+			}
+			value, {{ if $ref.IsOwner }}obj{{ else }}_{{ end }}, err := resolveReference(
+				ctx, ko.Namespace, ref,
+				schema.GroupVersionResource{Group: "{{ $ref.Group }}", Version: "{{ $ref.Version }}", Resource: "{{ $ref.Resource }}"},
+				"{{ $ref.JSONPath }}",
+			)
+			if err != nil {
+				setReferenceCondition(
+					ko, "{{ $ref.ConditionType }}",
+					referenceTarget("{{ $ref.APIVersion }}", "{{ $ref.Kind }}", ko.Namespace, ref), err,
+				)
+				return &resource{ko}, err
+			}
+			values = append(values, &value)
+{{- if $ref.IsOwner }}
+			owners = append(owners, obj)
+{{- end }}
+		}
+		setReferenceCondition(
+			ko, "{{ $ref.ConditionType }}",
+			fmt.Sprintf("%d {{ $ref.APIVersion }} {{ $ref.Kind }} objects", len(values)), nil,
+		)
+		ko.Spec.{{ $ref.Field.Names.Camel }} = values
+{{- if $ref.IsOwner }}
+		setReferenceOwners(ko, "{{ $ref.APIVersion }}", "{{ $ref.Kind }}", "{{ $ref.OwnerLabel }}", owners)
+{{- end }}
+	} else {
+		removeReferenceCondition(ko, "{{ $ref.ConditionType }}")
+{{- if $ref.IsOwner }}
+		setReferenceOwners(ko, "{{ $ref.APIVersion }}", "{{ $ref.Kind }}", "{{ $ref.OwnerLabel }}", nil)
+{{- end }}
+	}
+{{- else }}
+	if ref := ko.Spec.{{ $ref.RefField.Names.Camel }}; ref != nil {
+		value, {{ if $ref.IsOwner }}obj{{ else }}_{{ end }}, err := resolveReference(
+			ctx, ko.Namespace, ref,
+			schema.GroupVersionResource{Group: "{{ $ref.Group }}", Version: "{{ $ref.Version }}", Resource: "{{ $ref.Resource }}"},
+			"{{ $ref.JSONPath }}",
+		)
+		setReferenceCondition(
+			ko, "{{ $ref.ConditionType }}",
+			referenceTarget("{{ $ref.APIVersion }}", "{{ $ref.Kind }}", ko.Namespace, ref), err,
+		)
+		if err != nil {
+			return &resource{ko}, err
+		}
+		ko.Spec.{{ $ref.Field.Names.Camel }} = &value
+{{- if $ref.IsOwner }}
+		setReferenceOwners(
+			ko, "{{ $ref.APIVersion }}", "{{ $ref.Kind }}", "{{ $ref.OwnerLabel }}",
+			[]*unstructured.Unstructured{obj},
+		)
+{{- end }}
+	} else {
+		removeReferenceCondition(ko, "{{ $ref.ConditionType }}")
+{{- if $ref.IsOwner }}
+		setReferenceOwners(ko, "{{ $ref.APIVersion }}", "{{ $ref.Kind }}", "{{ $ref.OwnerLabel }}", nil)
+{{- end }}
+	}
+{{- end }}
+{{- end }}
+	return &resource{ko}, nil
+}
+
+// resolveReference returns the value at the supplied JSONPath of the object
+// of the supplied resource type the supplied reference names, and the object. The object is
+// read from the namespace of the reference if set, or else from the supplied
+// namespace of the referencing resource. The reconciliation is requeued while
+// the object doesn't exist or doesn't have the value yet, unless the object
+// changes sooner.
+func resolveReference(
+	ctx context.Context,
+	namespace string,
+	ref *svcapitypes.KubernetesObjectReference,
+	gvr schema.GroupVersionResource,
+	path string,
+) (string, *unstructured.Unstructured, error) {
+	if ref.Name == nil {
+		return "", nil, fmt.Errorf("reference to %s has no name", gvr.Resource)
+	}
+	name := *ref.Name
+	if ref.Namespace != nil && *ref.Namespace != "" && *ref.Namespace != namespace {
+{{- if not .CRD.IsClusterScoped }}
+		if !svcresource.AllowCrossNamespaceReferences {
+			return "", nil, errCrossNamespaceReference
+		}
+{{- end }}
+		namespace = *ref.Namespace
+	}
+	dc, err := getDynamicClient()
+	if err != nil {
+		return "", nil, err
+	}
+	obj, err := dc.Resource(gvr).Namespace(namespace).Get(
+		ctx, name, metav1.GetOptions{},
+	)
+	if err != nil {
+		return "", nil, ackrequeue.NeededAfter(
+			fmt.Errorf("reading referenced %s %s/%s: %v", gvr.Resource, namespace, name, err),
+			referenceRequeueAfter,
+		)
+	}
+	jp := jsonpath.New(gvr.Resource)
+	if err := jp.Parse(path); err != nil {
+		return "", nil, err
+	}
+	results, err := jp.FindResults(obj.UnstructuredContent())
+	if err != nil || len(results) == 0 || len(results[0]) == 0 {
+		return "", nil, ackrequeue.NeededAfter(
+			fmt.Errorf("referenced %s %s/%s has no value at %s", gvr.Resource, namespace, name, path),
+			referenceRequeueAfter,
+		)
+	}
+	return fmt.Sprint(results[0][0].Interface()), obj, nil
+}
+
+// referencesIndexKey returns the key of the object of the supplied resource
+// type, namespace and name in the index of the resources by the objects they
+// reference
+func referencesIndexKey(
+	gvr schema.GroupVersionResource,
+	namespace string,
+	name string,
+) string {
+	return fmt.Sprintf("%s/%s/%s/%s", gvr.Group, gvr.Resource, namespace, name)
+}
+
+// referenceNamespace returns the namespace of the object the supplied
+// reference of a resource in the supplied namespace names
+func referenceNamespace(
+	namespace string,
+	ref *svcapitypes.KubernetesObjectReference,
+) string {
+	if ref.Namespace != nil && *ref.Namespace != "" {
+		return *ref.Namespace
+	}
+	return namespace
+}
+
+// referencesIndexKeys returns the keys of the objects the supplied resource
+// references in the index of the resources by the objects they reference
+func referencesIndexKeys(obj runtime.Object) []string {
+	ko, ok := obj.(*svcapitypes.{{ .CRD.Names.Camel }})
+	if !ok {
+		return nil
+	}
+	keys := []string{}
+{{- range $ref := .CRD.ReferenceFields }}
+	{{ $ref.Field.Names.CamelLower }}GVR := schema.GroupVersionResource{Group: "{{ $ref.Group }}", Version: "{{ $ref.Version }}", Resource: "{{ $ref.Resource }}"}
+{{- if $ref.IsList }}
+	for _, ref := range ko.Spec.{{ $ref.RefField.Names.Camel }} {
+		if ref == nil || ref.Name == nil {
+			continue
+		}
+		keys = append(keys, referencesIndexKey(
+			{{ $ref.Field.Names.CamelLower }}GVR, referenceNamespace(ko.Namespace, ref), *ref.Name,
+		))
+	}
+{{- else }}
+	if ref := ko.Spec.{{ $ref.RefField.Names.Camel }}; ref != nil && ref.Name != nil {
+		keys = append(keys, referencesIndexKey(
+			{{ $ref.Field.Names.CamelLower }}GVR, referenceNamespace(ko.Namespace, ref), *ref.Name,
+		))
+	}
+{{- end }}
+{{- end }}
+	return keys
+}
+
+// setReferencesReconciler sets the reconciler used to re-reconcile the
+// resources when the objects they reference change
+func setReferencesReconciler(rr acktypes.Reconciler) {
+	r, ok := rr.(reconcile.Reconciler)
+	if !ok {
+		return
+	}
+	referencesReconcilerMu.Lock()
+	defer referencesReconcilerMu.Unlock()
+	referencesReconciler = r
+}
+
+// referencesReconcilerFunc re-reconciles the resources whose referenced
+// objects changed
+type referencesReconcilerFunc func(reconcile.Request) (reconcile.Result, error)
+
+// Reconcile implements reconcile.Reconciler
+func (f referencesReconcilerFunc) Reconcile(
+	req reconcile.Request,
+) (reconcile.Result, error) {
+	return f(req)
+}
+
+// reconcileReferencing reconciles the resource the supplied request names
+// with the reconciler of the resources, once the resource manager factory has
+// been handed it
+func reconcileReferencing(req reconcile.Request) (reconcile.Result, error) {
+	referencesReconcilerMu.RLock()
+	r := referencesReconciler
+	referencesReconcilerMu.RUnlock()
+	if r == nil {
+		return reconcile.Result{RequeueAfter: 10 * time.Second}, nil
+	}
+	return r.Reconcile(req)
+}
+
+// referencingRequests returns the reconcile requests of the resources
+// referencing the object of the supplied resource type, namespace and name
+func referencingRequests(
+	kc client.Client,
+	gvr schema.GroupVersionResource,
+	namespace string,
+	name string,
+) []reconcile.Request {
+	list := &svcapitypes.{{ .CRD.Names.Camel }}List{}
+	if err := kc.List(
+		context.Background(), list,
+		client.MatchingFields{referencesIndexField: referencesIndexKey(gvr, namespace, name)},
+	); err != nil {
+		return nil
+	}
+	reqs := []reconcile.Request{}
+	for _, ko := range list.Items {
+		reqs = append(reqs, reconcile.Request{NamespacedName: k8stypes.NamespacedName{
+			Namespace: ko.Namespace,
+			Name:      ko.Name,
+		}})
+	}
+	return reqs
+}
+
+// setupReferenceWatches indexes the resources by the objects they reference
+// and watches the referenced objects, re-reconciling the resources
+// referencing them when they change
+func setupReferenceWatches(mgr ctrlrt.Manager) error {
+	if err := mgr.GetFieldIndexer().IndexField(
+		context.Background(), &svcapitypes.{{ .CRD.Names.Camel }}{},
+		referencesIndexField, referencesIndexKeys,
+	); err != nil {
+		return err
+	}
+	c, err := controller.New("{{ .CRD.Names.Lower }}-references", mgr, controller.Options{
+		Reconciler: referencesReconcilerFunc(reconcileReferencing),
+	})
+	if err != nil {
+		return err
+	}
+	kc := mgr.GetClient()
+{{- range $ref := .CRD.ReferenceFields }}
+	{{ $ref.Field.Names.CamelLower }}Obj := &unstructured.Unstructured{}
+	{{ $ref.Field.Names.CamelLower }}Obj.SetGroupVersionKind(schema.GroupVersionKind{Group: "{{ $ref.Group }}", Version: "{{ $ref.Version }}", Kind: "{{ $ref.Kind }}"})
+	{{ $ref.Field.Names.CamelLower }}GVR := schema.GroupVersionResource{Group: "{{ $ref.Group }}", Version: "{{ $ref.Version }}", Resource: "{{ $ref.Resource }}"}
+	if err := c.Watch(
+		&source.Kind{Type: {{ $ref.Field.Names.CamelLower }}Obj},
+		&handler.EnqueueRequestsFromMapFunc{
+			ToRequests: handler.ToRequestsFunc(func(obj handler.MapObject) []reconcile.Request {
+				return referencingRequests(
+					kc, {{ $ref.Field.Names.CamelLower }}GVR, obj.Meta.GetNamespace(), obj.Meta.GetName(),
+				)
+			}),
+		},
+	); err != nil {
+		return err
+	}
+{{- end }}
+	return nil
+}
+
+func init() {
+	svcresource.RegisterReferenceWatchSetup(setupReferenceWatches)
+}
+
+// referenceTarget returns the identity of the object the supplied reference
+// of a resource in the supplied namespace names, for the reference conditions
+func referenceTarget(
+	apiVersion string,
+	kind string,
+	namespace string,
+	ref *svcapitypes.KubernetesObjectReference,
+) string {
+	name := ""
+	if ref.Name != nil {
+		name = *ref.Name
+	}
+	return fmt.Sprintf(
+		"%s %s %s/%s", apiVersion, kind, referenceNamespace(namespace, ref), name,
+	)
+}
+
+// setReferenceCondition sets the condition of the supplied type telling
+// whether the reference to the supplied target was resolved, given the error
+// resolving it
+func setReferenceCondition(
+	ko *svcapitypes.{{ .CRD.Names.Camel }},
+	condType ackv1alpha1.ConditionType,
+	target string,
+	err error,
+) {
+	var condition *ackv1alpha1.Condition
+	for _, c := range ko.Status.Conditions {
+		if c.Type == condType {
+			condition = c
+			break
+		}
+	}
+	if condition == nil {
+		condition = &ackv1alpha1.Condition{Type: condType}
+		ko.Status.Conditions = append(ko.Status.Conditions, condition)
+	}
+	status := corev1.ConditionTrue
+	reason := "Resolved"
+	message := "resolved " + target
+	if err != nil {
+		status = corev1.ConditionFalse
+		reason = "Unresolved"
+		if err == errCrossNamespaceReference {
+			reason = "CrossNamespaceReference"
+		}
+		message = fmt.Sprintf("unable to resolve %s: %v", target, err)
+	}
+	if condition.Status != status {
+		now := metav1.Now()
+		condition.LastTransitionTime = &now
+	}
+	condition.Status = status
+	condition.Reason = &reason
+	condition.Message = &message
+}
+
+// removeReferenceCondition removes the condition of the supplied type of a
+// reference field that isn't set anymore
+func removeReferenceCondition(
+	ko *svcapitypes.{{ .CRD.Names.Camel }},
+	condType ackv1alpha1.ConditionType,
+) {
+	conditions := []*ackv1alpha1.Condition{}
+	for _, c := range ko.Status.Conditions {
+		if c.Type != condType {
+			conditions = append(conditions, c)
+		}
+	}
+	ko.Status.Conditions = conditions
+}
+
+// withReferenceConditions returns a copy of the supplied resource having the
+// reference conditions of the supplied resolved resource
+func withReferenceConditions(r *resource, resolved *resource) *resource {
+	ko := r.ko.DeepCopy()
+	for _, c := range resolved.ko.Status.Conditions {
+		switch c.Type {
+{{- range $ref := .CRD.ReferenceFields }}
+		case "{{ $ref.ConditionType }}":
+{{- end }}
+		default:
+			continue
+		}
+		removeReferenceCondition(ko, c.Type)
+		ko.Status.Conditions = append(ko.Status.Conditions, c)
+	}
+	return &resource{ko}
+}
+
+{{- if .CRD.HasOwnerReferenceFields }}
+
+// setReferenceOwners makes the supplied referenced objects of the supplied
+// API version and kind the owners of the supplied resource, replacing the
+// owners of that kind it had, so that the resource is garbage collected along
+// with them. Objects in other namespaces than the resource's can't own it and
+// are skipped. The resource is also labeled with the name of its owner, when
+// it has exactly one of that kind.
+func setReferenceOwners(
+	ko *svcapitypes.{{ .CRD.Names.Camel }},
+	apiVersion string,
+	kind string,
+	label string,
+	objs []*unstructured.Unstructured,
+) {
+	owners := []metav1.OwnerReference{}
+	for _, owner := range ko.OwnerReferences {
+		if owner.APIVersion != apiVersion || owner.Kind != kind {
+			owners = append(owners, owner)
+		}
+	}
+	names := []string{}
+	for _, obj := range objs {
+		if obj.GetNamespace() != "" && obj.GetNamespace() != ko.Namespace {
+			continue
+		}
+		owners = append(owners, metav1.OwnerReference{
+			APIVersion: apiVersion,
+			Kind:       kind,
+			Name:       obj.GetName(),
+			UID:        obj.GetUID(),
+		})
+		names = append(names, obj.GetName())
+	}
+	if len(owners) == 0 {
+		owners = nil
+	}
+	ko.OwnerReferences = owners
+	if len(names) == 1 && len(validation.IsValidLabelValue(names[0])) == 0 {
+		if ko.Labels == nil {
+			ko.Labels = map[string]string{}
+		}
+		ko.Labels[label] = names[0]
+	} else {
+		delete(ko.Labels, label)
+	}
+}
+{{- end }}
+{{- end }}
+{{- if .CRD.HasDeletionOrder }}
+
+// deletionRequeueAfter is how long to wait before checking again whether the
+// objects a resource's deletion waits for are gone
+const deletionRequeueAfter = 15 * time.Second
+{{ range $ref := .CRD.DeletionDependents }}
+// +kubebuilder:rbac:groups={{ $ref.Field.CRD.APIGroup }},resources={{ ToLower $ref.Field.CRD.Plural }},verbs=get;list
+{{- end }}
+
+// checkDeletionOrder returns an error requeueing the deletion of the supplied
+// resource while the objects it must be deleted after still exist
+func (rm *resourceManager) checkDeletionOrder(
+	ctx context.Context,
+	r *resource,
+) error {
+	dc, err := getDynamicClient()
+	if err != nil {
+		return err
+	}
+{{- range $ref := .CRD.DeletionPrerequisites }}
+	// The {{ $ref.RefField.Names.Camel }} objects are deleted first
+	{{ $ref.Field.Names.CamelLower }}GVR := schema.GroupVersionResource{Group: "{{ $ref.Group }}", Version: "{{ $ref.Version }}", Resource: "{{ $ref.Resource }}"}
+{{- if $ref.IsList }}
+	for _, ref := range r.ko.Spec.{{ $ref.RefField.Names.Camel }} {
+		if ref == nil || ref.Name == nil {
+			continue
+		}
+		if err := waitForDeletion(
+			ctx, dc, {{ $ref.Field.Names.CamelLower }}GVR,
+			referenceNamespace(r.ko.Namespace, ref), *ref.Name,
+		); err != nil {
+			return err
+		}
+	}
+{{- else }}
+	if ref := r.ko.Spec.{{ $ref.RefField.Names.Camel }}; ref != nil && ref.Name != nil {
+		if err := waitForDeletion(
+			ctx, dc, {{ $ref.Field.Names.CamelLower }}GVR,
+			referenceNamespace(r.ko.Namespace, ref), *ref.Name,
+		); err != nil {
+			return err
+		}
+	}
+{{- end }}
+{{- end }}
+{{- range $ref := .CRD.DeletionDependents }}
+	// The {{ $ref.Field.CRD.Kind }} resources referencing the resource in
+	// their {{ $ref.RefField.Names.Camel }} field are deleted first
+	if err := waitForReferencingDeletion(
+		ctx, dc,
+		schema.GroupVersionResource{Group: "{{ $ref.Field.CRD.APIGroup }}", Version: "{{ $.APIVersion }}", Resource: "{{ ToLower $ref.Field.CRD.Plural }}"},
+		"{{ $ref.RefField.Names.CamelLower }}", {{ $ref.IsList }},
+		r.ko.Namespace, r.ko.Name,
+	); err != nil {
+		return err
+	}
+{{- end }}
+	return nil
+}
+{{- if .CRD.DeletionPrerequisites }}
+
+// waitForDeletion returns an error requeueing the reconciliation while the
+// object of the supplied resource type, namespace and name exists
+func waitForDeletion(
+	ctx context.Context,
+	dc dynamic.Interface,
+	gvr schema.GroupVersionResource,
+	namespace string,
+	name string,
+) error {
+	_, err := dc.Resource(gvr).Namespace(namespace).Get(
+		ctx, name, metav1.GetOptions{},
+	)
+	if apierrors.IsNotFound(err) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	return ackrequeue.NeededAfter(
+		fmt.Errorf("waiting for the deletion of %s %s/%s", gvr.Resource, namespace, name),
+		deletionRequeueAfter,
+	)
+}
+{{- end }}
+{{- if .CRD.DeletionDependents }}
+
+// waitForReferencingDeletion returns an error requeueing the reconciliation
+// while objects of the supplied resource type reference the resource of the
+// supplied namespace and name in the supplied Spec field
+func waitForReferencingDeletion(
+	ctx context.Context,
+	dc dynamic.Interface,
+	gvr schema.GroupVersionResource,
+	refField string,
+	isList bool,
+	namespace string,
+	name string,
+) error {
+	list, err := dc.Resource(gvr).Namespace(metav1.NamespaceAll).List(
+		ctx, metav1.ListOptions{},
+	)
+	if err != nil {
+		return err
+	}
+	for _, obj := range list.Items {
+		refs := []interface{}{}
+		if isList {
+			refs, _, _ = unstructured.NestedSlice(obj.Object, "spec", refField)
+		} else if ref, found, _ := unstructured.NestedMap(obj.Object, "spec", refField); found {
+			refs = append(refs, ref)
+		}
+		for _, ref := range refs {
+			ref, ok := ref.(map[string]interface{})
+			if !ok {
+				continue
+			}
+			refNamespace, _, _ := unstructured.NestedString(ref, "namespace")
+			if refNamespace == "" {
+				refNamespace = obj.GetNamespace()
+			}
+			refName, _, _ := unstructured.NestedString(ref, "name")
+			if refNamespace == namespace && refName == name {
+				return ackrequeue.NeededAfter(
+					fmt.Errorf(
+						"waiting for the deletion of %s %s/%s referencing the resource",
+						gvr.Resource, obj.GetNamespace(), obj.GetName(),
+					),
+					deletionRequeueAfter,
+				)
+			}
+		}
+	}
+	return nil
+}
+{{- end }}
+{{- end }}
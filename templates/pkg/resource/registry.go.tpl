@@ -3,8 +3,35 @@
 package resource
 
 import (
+{{- if .GeneratorConfig.HasEndpointURLOverrides }}
+	"context"
+	"fmt"
+	"net/url"
+	"strings"
+{{- end }}
+{{- if .GeneratorConfig.IsAdaptiveRetryMode }}
+	"sync"
+{{- end }}
+{{- if .GeneratorConfig.HasRetryConfig }}
+	"time"
+{{- end }}
+
 	ackrt "github.com/aws-controllers-k8s/runtime/pkg/runtime"
 	acktypes "github.com/aws-controllers-k8s/runtime/pkg/types"
+	"github.com/aws/aws-sdk-go/aws"
+{{- if .GeneratorConfig.HasRetryConfig }}
+	"github.com/aws/aws-sdk-go/aws/client"
+{{- end }}
+	"github.com/aws/aws-sdk-go/aws/endpoints"
+	"github.com/aws/aws-sdk-go/aws/request"
+{{- if or .GeneratorConfig.HasRateLimits .GeneratorConfig.IsAdaptiveRetryMode }}
+	"golang.org/x/time/rate"
+{{- end }}
+{{- if .GeneratorConfig.ResourceContainsReferences }}
+	ctrlrt "sigs.k8s.io/controller-runtime"
+{{- end }}
+
+	"github.com/aws-controllers-k8s/{{ .ServicePackageName }}-controller/pkg/version"
 )
 
 // +kubebuilder:rbac:groups=services.k8s.aws,resources=adoptedresources,verbs=get;list;watch;create;update;patch;delete
@@ -17,6 +44,23 @@ import (
 
 var (
 	reg = ackrt.NewRegistry()
+	// UseFIPSEndpoint determines whether the FIPS endpoints of the AWS
+	// service API are called, unless overridden for a kind of resources. It
+	// is set by the --use-fips-endpoint flag.
+	UseFIPSEndpoint = {{ .GeneratorConfig.Endpoint.GetFIPS }}
+	// UseDualStackEndpoint determines whether the dual-stack endpoints of the
+	// AWS service API are called, unless overridden for a kind of resources.
+	// It is set by the --use-dual-stack-endpoint flag.
+	UseDualStackEndpoint = {{ .GeneratorConfig.Endpoint.GetDualStack }}
+{{- if .GeneratorConfig.ResourceContainsReferences }}
+	// AllowCrossNamespaceReferences determines whether the resources may
+	// reference objects in other namespaces than their own. It is set by
+	// the --allow-cross-namespace-references flag.
+	AllowCrossNamespaceReferences = false
+	// referenceWatchSetups set up the watches of the objects referenced by
+	// the resources
+	referenceWatchSetups = []func(ctrlrt.Manager) error{}
+{{- end }}
 )
 
 // GetManagerFactories returns a slice of resource manager factories that are
@@ -30,3 +74,278 @@ func GetManagerFactories() []acktypes.AWSResourceManagerFactory {
 func RegisterManagerFactory(f acktypes.AWSResourceManagerFactory) {
 	reg.RegisterResourceManagerFactory(f)
 }
+
+// EndpointConfig returns the config of the AWS SDK clients calling the FIPS
+// or dual-stack endpoints of the AWS service API, if instructed to
+func EndpointConfig(useFIPS bool, useDualStack bool) *aws.Config {
+	cfg := aws.NewConfig().WithUseDualStack(useDualStack)
+	if useFIPS {
+		cfg.EndpointResolver = endpoints.ResolverFunc(resolveFIPSEndpoint)
+	}
+	return cfg
+}
+
+// resolveFIPSEndpoint resolves the FIPS endpoint of the supplied service in
+// the supplied region, which the AWS SDK names after FIPS pseudo regions,
+// e.g. "fips-us-gov-west-1". Returns an error if the service has no FIPS
+// endpoint in the region, so the calls never silently fall back to the
+// standard endpoint.
+func resolveFIPSEndpoint(
+	service string,
+	region string,
+	opts ...func(*endpoints.Options),
+) (endpoints.ResolvedEndpoint, error) {
+	opts = append(opts, endpoints.StrictMatchingOption)
+	resolver := endpoints.DefaultResolver()
+	resolved, err := resolver.EndpointFor(service, "fips-"+region, opts...)
+	if err == nil {
+		return resolved, nil
+	}
+	if resolved, err := resolver.EndpointFor(service, region+"-fips", opts...); err == nil {
+		return resolved, nil
+	}
+	return resolved, err
+}
+
+// userAgentExtra is the metadata added to the User-Agent of the calls to the
+// AWS API, after the kind of the resource the calls are made for
+var userAgentExtra = []string{
+{{- range $extra := .GeneratorConfig.UserAgentExtra }}
+	"{{ $extra }}",
+{{- end }}
+}
+
+// AddUserAgentHandler adds to the supplied AWS SDK client handlers the
+// handler appending to the User-Agent of the requests the name and version
+// of the controller, the supplied kind of the resources the requests are
+// made for and the metadata of the generator config, so the calls to the
+// AWS API can be attributed to the controller
+func AddUserAgentHandler(handlers *request.Handlers, kind string) {
+	controllerVersion := version.GitVersion
+	if controllerVersion == "" {
+		controllerVersion = "unknown"
+	}
+	extra := append([]string{"kind/" + kind}, userAgentExtra...)
+	handlers.Build.PushBack(request.MakeAddToUserAgentHandler(
+		"{{ .ServicePackageName }}-controller", controllerVersion, extra...,
+	))
+}
+{{- if .GeneratorConfig.HasRegionOverrides }}
+
+// RegionAnnotation is the annotation of the resources selecting the AWS
+// region they are managed in, instead of the region of their namespace
+const RegionAnnotation = "{{ .APIGroup }}/region"
+{{- end }}
+{{- if .GeneratorConfig.HasEndpointURLOverrides }}
+
+// EndpointURLAnnotation is the annotation of the resources overriding the URL
+// of the AWS API endpoint called for them
+const EndpointURLAnnotation = "{{ .APIGroup }}/endpoint-url"
+
+// endpointURLKey is the key of the context value overriding the URL of the
+// AWS API endpoint
+type endpointURLKey struct{}
+
+// WithEndpointURL returns a copy of the supplied context sending the calls
+// made with it to the supplied AWS API endpoint URL, unless the URL is empty
+func WithEndpointURL(ctx context.Context, endpointURL string) context.Context {
+	if endpointURL == "" {
+		return ctx
+	}
+	return context.WithValue(ctx, endpointURLKey{}, endpointURL)
+}
+
+// AddEndpointURLHandler adds to the supplied AWS SDK client handlers the
+// handler sending the requests to the endpoint URL of their context, if any
+func AddEndpointURLHandler(handlers *request.Handlers) {
+	handlers.Build.PushBack(func(r *request.Request) {
+		endpointURL, ok := r.Context().Value(endpointURLKey{}).(string)
+		if !ok {
+			return
+		}
+		u, err := url.Parse(endpointURL)
+		if err != nil || u.Scheme == "" || u.Host == "" {
+			r.Error = fmt.Errorf("invalid endpoint URL %q", endpointURL)
+			return
+		}
+		r.HTTPRequest.URL.Scheme = u.Scheme
+		r.HTTPRequest.URL.Host = u.Host
+		if prefix := strings.TrimSuffix(u.Path, "/"); prefix != "" {
+			r.HTTPRequest.URL.Path = prefix + r.HTTPRequest.URL.Path
+			if r.HTTPRequest.URL.RawPath != "" {
+				r.HTTPRequest.URL.RawPath = prefix + r.HTTPRequest.URL.RawPath
+			}
+		}
+		r.HTTPRequest.Host = ""
+		request.SanitizeHostForHeader(r.HTTPRequest)
+	})
+}
+{{- end }}
+{{- if .GeneratorConfig.ResourceContainsReferences }}
+
+// RegisterReferenceWatchSetup registers a function setting up the watches of
+// the objects referenced by a kind of resources
+func RegisterReferenceWatchSetup(setup func(ctrlrt.Manager) error) {
+	referenceWatchSetups = append(referenceWatchSetups, setup)
+}
+
+// SetupReferenceWatches sets up with the supplied controller manager the
+// watches re-reconciling the resources when the objects they reference change
+func SetupReferenceWatches(mgr ctrlrt.Manager) error {
+	for _, setup := range referenceWatchSetups {
+		if err := setup(mgr); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+{{- end }}
+{{- if .GeneratorConfig.HasRateLimits }}
+
+var (
+{{- if .GeneratorConfig.RateLimit }}
+	// serviceRateLimiter limits the rate of all the calls to the AWS API
+	serviceRateLimiter = rate.NewLimiter({{ .GeneratorConfig.RateLimit.QPS }}, {{ .GeneratorConfig.RateLimit.GetBurst }})
+{{- end }}
+	// operationRateLimiters limit the rate of the calls to particular
+	// operations of the AWS API, keyed by the name of the operation
+	operationRateLimiters = map[string]*rate.Limiter{
+{{- range $opName := .GeneratorConfig.OperationRateLimitNames }}
+{{- $rateLimit := $.GeneratorConfig.OperationRateLimit $opName }}
+		"{{ $opName }}": rate.NewLimiter({{ $rateLimit.QPS }}, {{ $rateLimit.GetBurst }}),
+{{- end }}
+	}
+)
+
+// AddRateLimitHandler adds to the supplied AWS SDK client handlers the
+// handler waiting, before every attempt to call an operation, until the rate
+// limits of the service API and of the operation allow the call
+func AddRateLimitHandler(handlers *request.Handlers) {
+	handlers.Sign.PushFront(func(r *request.Request) {
+{{- if .GeneratorConfig.RateLimit }}
+		if err := serviceRateLimiter.Wait(r.Context()); err != nil {
+			r.Error = err
+			return
+		}
+{{- end }}
+		if limiter, found := operationRateLimiters[r.Operation.Name]; found {
+			if err := limiter.Wait(r.Context()); err != nil {
+				r.Error = err
+			}
+		}
+	})
+}
+{{- end }}
+{{- if .GeneratorConfig.HasRetryConfig }}
+
+// newRetryer returns a retryer making up to the supplied number of attempts
+// to call an operation, waiting between the supplied delays before retrying.
+// The AWS SDK's defaults are used for the zero values.
+func newRetryer(
+	maxAttempts int,
+	minDelay time.Duration,
+	maxDelay time.Duration,
+) request.Retryer {
+	retryer := client.DefaultRetryer{
+		NumMaxRetries: client.DefaultRetryerMaxNumRetries,
+		MinRetryDelay: minDelay,
+		MaxRetryDelay: maxDelay,
+	}
+	if maxAttempts > 0 {
+		retryer.NumMaxRetries = maxAttempts - 1
+	}
+	return retryer
+}
+
+var (
+{{- with .GeneratorConfig.Retry }}
+	// serviceRetryer retries the failed calls to the AWS API
+	serviceRetryer = newRetryer({{ .MaxAttempts }}, {{ .MinDelayMilliseconds }}*time.Millisecond, {{ .MaxDelayMilliseconds }}*time.Millisecond)
+{{- end }}
+	// operationRetryers retry the failed calls to particular operations of
+	// the AWS API, keyed by the name of the operation
+	operationRetryers = map[string]request.Retryer{
+{{- range $opName := .GeneratorConfig.OperationRetryNames }}
+{{- with $.GeneratorConfig.OperationRetry $opName }}
+		"{{ $opName }}": newRetryer({{ .MaxAttempts }}, {{ .MinDelayMilliseconds }}*time.Millisecond, {{ .MaxDelayMilliseconds }}*time.Millisecond),
+{{- end }}
+{{- end }}
+	}
+)
+{{- if .GeneratorConfig.IsAdaptiveRetryMode }}
+
+const (
+	// adaptiveMinQPS is the lowest rate of the calls to the AWS API while it
+	// throttles them, and the rate they are sped up by on every success
+	adaptiveMinQPS = 0.5
+	// adaptiveMaxQPS is the rate above which the calls to the AWS API are no
+	// longer slowed down
+	adaptiveMaxQPS = 100
+)
+
+// adaptiveRateLimiter slows down the calls to the AWS API while it throttles
+// them, halving their rate on every throttling error, and speeds them up
+// again as they succeed
+type adaptiveRateLimiter struct {
+	sync.Mutex
+	limiter *rate.Limiter
+}
+
+// adaptiveLimiter slows down the calls to the AWS API, they aren't slowed
+// down until it throttles them
+var adaptiveLimiter = &adaptiveRateLimiter{
+	limiter: rate.NewLimiter(rate.Inf, 1),
+}
+
+// wait waits until the rate of the calls allows the supplied request to be
+// sent
+func (l *adaptiveRateLimiter) wait(r *request.Request) {
+	if err := l.limiter.Wait(r.Context()); err != nil {
+		r.Error = err
+	}
+}
+
+// update slows down the calls if the supplied request was throttled, or
+// speeds them up if it succeeded
+func (l *adaptiveRateLimiter) update(r *request.Request) {
+	l.Lock()
+	defer l.Unlock()
+	limit := l.limiter.Limit()
+	switch {
+	case request.IsErrorThrottle(r.Error):
+		if limit == rate.Inf {
+			limit = adaptiveMaxQPS
+		}
+		limit /= 2
+		if limit < adaptiveMinQPS {
+			limit = adaptiveMinQPS
+		}
+	case r.Error == nil && limit != rate.Inf:
+		limit += adaptiveMinQPS
+		if limit > adaptiveMaxQPS {
+			limit = rate.Inf
+		}
+	default:
+		return
+	}
+	l.limiter.SetLimit(limit)
+}
+{{- end }}
+
+// ConfigureRetries configures the supplied AWS SDK client to retry the failed
+// calls to the AWS API as instructed by the generator config
+func ConfigureRetries(c *client.Client) {
+{{- if .GeneratorConfig.Retry }}
+	c.Retryer = serviceRetryer
+{{- end }}
+	c.Handlers.Validate.PushBack(func(r *request.Request) {
+		if retryer, found := operationRetryers[r.Operation.Name]; found {
+			r.Retryer = retryer
+		}
+	})
+{{- if .GeneratorConfig.IsAdaptiveRetryMode }}
+	c.Handlers.Sign.PushFront(adaptiveLimiter.wait)
+	c.Handlers.CompleteAttempt.PushBack(adaptiveLimiter.update)
+{{- end }}
+}
+{{- end }}
@@ -3,18 +3,21 @@
 package {{ .CRD.Names.Snake }}
 
 import (
+	"fmt"
+
 	ackv1alpha1 "github.com/aws-controllers-k8s/runtime/apis/core/v1alpha1"
 	acktypes "github.com/aws-controllers-k8s/runtime/pkg/types"
 	ackerrors "github.com/aws-controllers-k8s/runtime/pkg/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	k8srt "k8s.io/apimachinery/pkg/runtime"
 
-	svcapitypes "github.com/aws-controllers-k8s/{{ .ServicePackageName }}-controller/apis/{{ .APIVersion}}"
+	svcapitypes "github.com/aws-controllers-k8s/{{ .ServicePackageName }}-controller/{{ .APIPackagePath }}"
 )
 
 // Hack to avoid import errors during build...
 var (
 	_ = &ackerrors.MissingNameIdentifier
+	_ = fmt.Errorf
 )
 
 // resource implements the `aws-controller-k8s/runtime/pkg/types.AWSResource`
@@ -4,8 +4,16 @@ package {{ .CRD.Names.Snake }}
 
 import (
 	"context"
+	"encoding/json"
+	"fmt"
 	"reflect"
+{{- if .CRD.TerminalExceptionMessages }}
+	"regexp"
+{{- end }}
+	"sort"
+	"strconv"
 	"strings"
+	"time"
 
 	ackv1alpha1 "github.com/aws-controllers-k8s/runtime/apis/core/v1alpha1"
 	ackcondition "github.com/aws-controllers-k8s/runtime/pkg/condition"
@@ -13,11 +21,16 @@ import (
 	ackerr "github.com/aws-controllers-k8s/runtime/pkg/errors"
 	ackrtlog "github.com/aws-controllers-k8s/runtime/pkg/runtime/log"
 	"github.com/aws/aws-sdk-go/aws"
-	svcsdk "github.com/aws/aws-sdk-go/service/{{ .ServicePackageName }}"
+{{- if .CRD.CloudControlResource }}
+	"github.com/aws/aws-sdk-go/aws/awserr"
+{{- end }}
+	svcsdk "github.com/aws/aws-sdk-go/service/{{ .SDKPackageName }}"
 	corev1 "k8s.io/api/core/v1"
+	k8sresource "k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
 
-	svcapitypes "github.com/aws-controllers-k8s/{{.ServicePackageName }}-controller/apis/{{ .APIVersion }}"
+	svcapitypes "github.com/aws-controllers-k8s/{{.ServicePackageName }}-controller/{{ .APIPackagePath }}"
 )
 
 // Hack to avoid import errors during build...
@@ -31,8 +44,18 @@ var (
 	_ = &ackerr.NotFound
 	_ = &ackcondition.NotManagedMessage
 	_ = &reflect.Value{}
+	_ = json.Marshal
+	_ = &runtime.RawExtension{}
+	_ = time.Unix
+	_ = strconv.ParseFloat
+	_ = k8sresource.ParseQuantity
+	_ = sort.Strings
+	_ = fmt.Sprintf
 )
 
+{{ if .CRD.CloudControlResource -}}
+{{ template "sdk_cloud_control" . }}
+{{- else -}}
 // sdkFind returns SDK-specific information about a supplied resource
 {{ if .CRD.Ops.ReadOne }}
 	{{- template "sdk_find_read_one" . }}
@@ -44,6 +67,9 @@ var (
 	{{- template "sdk_find_not_implemented" . }}
 {{- end }}
 
+{{ if .CRD.IsObserveOnly -}}
+{{ template "sdk_observe_only" . }}
+{{- else -}}
 // sdkCreate creates the supplied resource in the backend AWS service API and
 // returns a copy of the resource with resource fields (in both Spec and
 // Status) filled in with values from the CREATE API operation's Output shape.
@@ -81,6 +107,11 @@ func (rm *resourceManager) sdkCreate(
 	if err != nil {
 		return nil, err
 	}
+{{- if $errorsMember := .CRD.BatchErrorsMember .CRD.Ops.Create }}
+	if len(resp.{{ $errorsMember }}) > 0 {
+		return nil, fmt.Errorf("{{ .CRD.Ops.Create.ExportedName }} failed: %v", resp.{{ $errorsMember }}[0])
+	}
+{{- end }}
 	// Merge in the information we read from the API call above to the copy of
 	// the original Kubernetes object we passed to the function
 	ko := desired.ko.DeepCopy()
@@ -96,6 +127,9 @@ func (rm *resourceManager) sdkCreate(
 		return nil, err
 	}
 {{- end }}
+{{- if .CRD.SecondaryOps }}
+{{- template "sdk_create_secondary_operations" . }}
+{{- end }}
 {{- if $hookCode := Hook .CRD "sdk_create_post_set_output" }}
 {{ $hookCode }}
 {{- end }}
@@ -117,6 +151,10 @@ func (rm *resourceManager) newCreateRequestPayload(
 // returns a new resource with updated fields.
 {{ if .CRD.CustomUpdateMethodName }}
 	{{- template "sdk_update_custom" . }}
+{{- else if .CRD.UpdateOps }}
+	{{- template "sdk_update_operations" . }}
+{{- else if .CRD.IsUpsert }}
+	{{- template "sdk_update_upsert" . }}
 {{- else if .CRD.Ops.Update }}
 	{{- template "sdk_update" . }}
 {{- else if .CRD.Ops.SetAttributes }}
@@ -153,6 +191,11 @@ func (rm *resourceManager) sdkDelete(
 	var resp {{ .CRD.GetOutputShapeGoType .CRD.Ops.Delete }}; _ = resp;
 	resp, err = rm.sdkapi.{{ .CRD.Ops.Delete.Name }}WithContext(ctx, input)
 	rm.metrics.RecordAPICall("DELETE", "{{ .CRD.Ops.Delete.Name }}", err)
+{{- if $errorsMember := .CRD.BatchErrorsMember .CRD.Ops.Delete }}
+	if err == nil && len(resp.{{ $errorsMember }}) > 0 {
+		err = fmt.Errorf("{{ .CRD.Ops.Delete.Name }} failed: %v", resp.{{ $errorsMember }}[0])
+	}
+{{- end }}
 {{- if $hookCode := Hook .CRD "sdk_delete_post_request" }}
 {{ $hookCode }}
 {{- end }}
@@ -175,6 +218,20 @@ func (rm *resourceManager) newDeleteRequestPayload(
 }
 {{- end }}
 
+{{ if .CRD.TaggingOps -}}
+{{ template "sdk_sync_tags" . }}
+{{- end }}
+
+{{ if .CRD.SecondaryOps -}}
+{{ template "sdk_secondary_operations" . }}
+{{- end }}
+
+{{ if and .CRD.SplitFrom .CRD.Ops.Update .CRD.Ops.ReadOne -}}
+{{ template "sdk_merge_split_update" . }}
+{{- end }}
+{{- end }}
+{{- end }}
+
 // setStatusDefaults sets default properties into supplied custom resource
 func (rm *resourceManager) setStatusDefaults (
 	ko *svcapitypes.{{ .CRD.Names.Camel }},
@@ -185,6 +242,19 @@ func (rm *resourceManager) setStatusDefaults (
 	if ko.Status.ACKResourceMetadata.OwnerAccountID == nil {
 		ko.Status.ACKResourceMetadata.OwnerAccountID = &rm.awsAccountID
 	}
+{{- if .CRD.RegionFrom }}
+	region := string(rm.awsRegion)
+	ko.Status.Region = &region
+{{- end }}
+{{- if .CRD.MirrorsScaleReplicas }}
+{{- $specField := .CRD.ScaleSpecReplicasField }}
+	if ko.Spec.{{ $specField.Names.Camel }} != nil {
+		replicas := *ko.Spec.{{ $specField.Names.Camel }}
+		ko.Status.{{ .CRD.ScaleStatusReplicasField.Names.Camel }} = &replicas
+	} else {
+		ko.Status.{{ .CRD.ScaleStatusReplicasField.Names.Camel }} = nil
+	}
+{{- end }}
 	if ko.Status.Conditions == nil {
 		ko.Status.Conditions = []*ackv1alpha1.Condition{}
 	}
@@ -216,7 +286,7 @@ func (rm *resourceManager) updateConditions (
 		}
 	}
 
-	if rm.terminalAWSError(err) || err ==  ackerr.SecretTypeNotSupported || err == ackerr.SecretNotFound {
+	if rm.terminalAWSError(err) || err ==  ackerr.SecretTypeNotSupported || err == ackerr.SecretNotFound{{ if .CRD.IsObserveOnly }} || err == errObserveOnly{{ end }}{{ if .CRD.ReferenceFields }} || err == errCrossNamespaceReference{{ end }}{{ if .CRD.ErrorRequeueCodes }} || isRetriesExhausted(err){{ end }}{{ if .CRD.RegionFrom }} || isInvalidRegion(err){{ end }} {
 		if terminalCondition == nil {
 			terminalCondition = &ackv1alpha1.Condition{
 				Type:   ackv1alpha1.ConditionTypeTerminal,
@@ -224,7 +294,7 @@ func (rm *resourceManager) updateConditions (
 			ko.Status.Conditions = append(ko.Status.Conditions, terminalCondition)
 		}
 		var errorMessage = ""
-		if err == ackerr.SecretTypeNotSupported || err == ackerr.SecretNotFound {
+		if err == ackerr.SecretTypeNotSupported || err == ackerr.SecretNotFound{{ if .CRD.IsObserveOnly }} || err == errObserveOnly{{ end }}{{ if .CRD.ReferenceFields }} || err == errCrossNamespaceReference{{ end }}{{ if .CRD.ErrorRequeueCodes }} || isRetriesExhausted(err){{ end }}{{ if .CRD.RegionFrom }} || isInvalidRegion(err){{ end }} {
 			errorMessage = err.Error()
 		} else {
 			awsErr, _ := ackerr.AWSError(err)
@@ -291,7 +361,7 @@ func (rm *resourceManager) updateConditions (
 // and if the exception indicates that it is a Terminal exception
 // 'Terminal' exception are specified in generator configuration
 func (rm *resourceManager) terminalAWSError(err error) bool {
-{{- if .CRD.TerminalExceptionCodes }}
+{{- if or .CRD.TerminalExceptionCodes .CRD.TerminalExceptionMessages }}
 	if err == nil {
 		return false
 	}
@@ -299,19 +369,42 @@ func (rm *resourceManager) terminalAWSError(err error) bool {
 	if !ok {
 		return false
 	}
+{{- if .CRD.TerminalExceptionCodes }}
 	switch awsErr.Code() {
 	case {{ range $x, $terminalCode := .CRD.TerminalExceptionCodes -}}{{ if ne ($x) (0) }},
 		{{ end }} "{{ $terminalCode }}"{{ end }}:
 		return true
-	default:
-		return false
 	}
+{{- end }}
+{{- if .CRD.TerminalExceptionMessages }}
+	for _, tm := range terminalMessages {
+		if (tm.code == "" || tm.code == awsErr.Code()) &&
+			tm.pattern.MatchString(awsErr.Message()) {
+			return true
+		}
+	}
+{{- end }}
+	return false
 {{- else }}
 	// No terminal_errors specified for this resource in generator config
 	return false
 {{- end }}
 }
 
+{{- if .CRD.TerminalExceptionMessages }}
+
+// terminalMessages are the exceptions that are terminal when their message
+// matches the pattern, whatever their code if it is empty
+var terminalMessages = []struct {
+	code    string
+	pattern *regexp.Regexp
+}{
+{{- range $tm := .CRD.TerminalExceptionMessages }}
+	{"{{ $tm.Code }}", regexp.MustCompile({{ printf "%q" $tm.Pattern }})},
+{{- end }}
+}
+{{- end }}
+
 {{- if .CRD.HasImmutableFieldChanges }}
 // getImmutableFieldChanges returns list of immutable fields from the
 func (rm *resourceManager) getImmutableFieldChanges(
@@ -324,6 +417,11 @@ func (rm *resourceManager) getImmutableFieldChanges(
 			fields = append(fields,"{{$immutableField}}")
 		}
 	{{- end }}
+	{{- if .CRD.GetRecreateFieldPaths }}
+	// The fields replacing the resource are immutable unless the resource
+	// opts in to being replaced
+	fields = append(fields, rm.getRecreateFieldChanges(delta)...)
+	{{- end }}
 
 	return fields
 }
@@ -371,6 +469,115 @@ func (rm *resourceManager) handleImmutableFieldsChangedCondition(
 	return &resource{ko}
 }
 {{- end }}
+{{- if .CRD.GetRecreateFieldPaths }}
+
+// recreateOnChangeAnnotation is the annotation opting a resource in, when set
+// to "true", to being deleted and created again when the Spec fields that
+// can't be updated in place are modified
+const recreateOnChangeAnnotation = "services.k8s.aws/recreate-on-change"
+
+// getRecreateFieldChanges returns the list of modified Spec fields that can
+// only be changed by replacing the resource
+func (rm *resourceManager) getRecreateFieldChanges(
+	delta *ackcompare.Delta,
+) []string {
+	var fields []string
+	{{- range $recreateField := .CRD.GetRecreateFieldPaths }}
+	if delta.DifferentAt("{{ $recreateField }}") {
+		fields = append(fields, "{{ $recreateField }}")
+	}
+	{{- end }}
+	return fields
+}
+
+// recreateRequested returns true if Spec fields that can only be changed by
+// replacing the resource were modified and the resource opts in to being
+// replaced
+func (rm *resourceManager) recreateRequested(
+	r *resource,
+	delta *ackcompare.Delta,
+) bool {
+	if r.ko.GetAnnotations()[recreateOnChangeAnnotation] != "true" {
+		return false
+	}
+	return len(rm.getRecreateFieldChanges(delta)) > 0
+}
+
+// recreatingConditionType is the type of the condition set on a resource
+// being replaced, from the deletion of the latest resource until the desired
+// resource is created
+const recreatingConditionType ackv1alpha1.ConditionType = "Recreating"
+
+// recreateRequeueDelay is the delay after which a resource being replaced is
+// requeued, until the deletion of the latest resource completes
+const recreateRequeueDelay = 15 * time.Second
+
+// isRecreating returns true if the supplied resource is being replaced
+func isRecreating(r *resource) bool {
+	for _, condition := range r.ko.Status.Conditions {
+		if condition.Type == recreatingConditionType {
+			return condition.Status == corev1.ConditionTrue
+		}
+	}
+	return false
+}
+
+// recreate starts replacing the latest resource in the backend AWS service
+// API, which may delete resources asynchronously: it deletes the latest
+// resource, unless it is already being deleted, and returns it marked as
+// being recreated. The desired resource is created by Create once ReadOne
+// reports the latest resource as not found, which removes the mark.
+func (rm *resourceManager) recreate(
+	ctx context.Context,
+	latest *resource,
+) (recreating *resource, err error) {
+	rlog := ackrtlog.FromContext(ctx)
+	exit := rlog.Trace("rm.recreate")
+	defer exit(err)
+
+	if !isRecreating(latest) {
+		if _, err = rm.sdkDelete(ctx, latest); err != nil {
+			return nil, err
+		}
+	}
+	ko := latest.ko.DeepCopy()
+	setRecreatingCondition(ko, corev1.ConditionTrue)
+	return &resource{ko}, nil
+}
+
+// setRecreatingCondition sets the status of the condition telling whether
+// the resource is being replaced
+func setRecreatingCondition(
+	ko *svcapitypes.{{ .CRD.Names.Camel }},
+	status corev1.ConditionStatus,
+) {
+	var condition *ackv1alpha1.Condition
+	for _, c := range ko.Status.Conditions {
+		if c.Type == recreatingConditionType {
+			condition = c
+			break
+		}
+	}
+	if condition == nil {
+		if status != corev1.ConditionTrue {
+			return
+		}
+		condition = &ackv1alpha1.Condition{Type: recreatingConditionType}
+		ko.Status.Conditions = append(ko.Status.Conditions, condition)
+	}
+	if condition.Status == status {
+		return
+	}
+	now := metav1.Now()
+	condition.LastTransitionTime = &now
+	condition.Status = status
+	message := "deleted to be created again with the modified Spec fields"
+	if status != corev1.ConditionTrue {
+		message = "created again with the modified Spec fields"
+	}
+	condition.Message = &message
+}
+{{- end }}
 {{- if $hookCode := Hook .CRD "sdk_file_end" }}
 {{ $hookCode }}
 {{- end }}
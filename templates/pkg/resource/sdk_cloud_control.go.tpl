@@ -0,0 +1,371 @@
+{{- define "sdk_cloud_control" -}}
+// cloudControlTypeName is the AWS Cloud Control type name of the resource
+const cloudControlTypeName = "{{ .CRD.CloudControlResource.TypeName }}"
+
+// cloudControlPropertyNames maps the JSON keys of the resource's fields to the
+// names of the resource type's properties, when they differ
+var cloudControlPropertyNames = map[string]string{
+{{- range $key, $property := .CRD.CloudControlPropertyNames }}
+	"{{ $key }}": "{{ $property }}",
+{{- end }}
+}
+
+// sdkFind returns SDK-specific information about a supplied resource
+func (rm *resourceManager) sdkFind(
+	ctx context.Context,
+	r *resource,
+) (latest *resource, err error) {
+	rlog := ackrtlog.FromContext(ctx)
+	exit := rlog.Trace("rm.sdkFind")
+	defer exit(err)
+
+{{- if $hookCode := Hook .CRD "sdk_read_one_pre_build_request" }}
+{{ $hookCode }}
+{{- end }}
+	// If any of the properties identifying the resource are missing, the AWS
+	// resource is not created yet
+	identifier, found := rm.cloudControlIdentifier(r)
+	if !found {
+		return nil, ackerr.NotFound
+	}
+	ko := r.ko.DeepCopy()
+	if err = rm.getCloudControlResource(ctx, identifier, ko); err != nil {
+		return nil, err
+	}
+	rm.setStatusDefaults(ko)
+{{- if $hookCode := Hook .CRD "sdk_read_one_post_set_output" }}
+{{ $hookCode }}
+{{- end }}
+	return &resource{ko}, nil
+}
+
+// cloudControlIdentifier returns the identifier of the supplied resource in
+// the AWS Cloud Control API, made of the values of the properties of the
+// resource type's primary identifier joined by "|", and false if any of them
+// is missing
+func (rm *resourceManager) cloudControlIdentifier(
+	r *resource,
+) (string, bool) {
+	values := []string{}
+{{- range $id := .CRD.CloudControlIdentifiers }}
+{{- if $id.IsARN }}
+	if r.ko.Status.ACKResourceMetadata == nil || r.ko.{{ $id.Path }} == nil {
+		return "", false
+	}
+	values = append(values, string(*r.ko.{{ $id.Path }}))
+{{- else }}
+	if r.ko.{{ $id.Path }} == nil {
+		return "", false
+	}
+{{- if $id.IsString }}
+	values = append(values, *r.ko.{{ $id.Path }})
+{{- else }}
+	values = append(values, fmt.Sprint(*r.ko.{{ $id.Path }}))
+{{- end }}
+{{- end }}
+{{- end }}
+	if len(values) == 0 {
+		return "", false
+	}
+	return strings.Join(values, "|"), true
+}
+
+// getCloudControlResource reads the properties of the resource with the
+// supplied identifier into the Spec and Status of the supplied object
+func (rm *resourceManager) getCloudControlResource(
+	ctx context.Context,
+	identifier string,
+	ko *svcapitypes.{{ .CRD.Names.Camel }},
+) error {
+	resp, err := rm.sdkapi.GetResourceWithContext(ctx, &svcsdk.GetResourceInput{
+		TypeName:   aws.String(cloudControlTypeName),
+		Identifier: aws.String(identifier),
+	})
+	rm.metrics.RecordAPICall("READ_ONE", "GetResource", err)
+	if err != nil {
+		if awsErr, ok := ackerr.AWSError(err); ok && awsErr.Code() == svcsdk.ErrCodeResourceNotFoundException {
+			return ackerr.NotFound
+		}
+		return err
+	}
+	if resp.ResourceDescription == nil || resp.ResourceDescription.Properties == nil {
+		return ackerr.NotFound
+	}
+	properties := map[string]interface{}{}
+	if err = json.Unmarshal([]byte(*resp.ResourceDescription.Properties), &properties); err != nil {
+		return err
+	}
+	b, err := json.Marshal(renameCloudControlKeys(properties, cloudControlFieldKeys()))
+	if err != nil {
+		return err
+	}
+	if err = json.Unmarshal(b, &ko.Spec); err != nil {
+		return err
+	}
+	if err = json.Unmarshal(b, &ko.Status); err != nil {
+		return err
+	}
+{{- if $arnProperty := .CRD.CloudControlARNProperty }}
+	if arn, ok := properties["{{ $arnProperty }}"].(string); ok {
+		if ko.Status.ACKResourceMetadata == nil {
+			ko.Status.ACKResourceMetadata = &ackv1alpha1.ResourceMetadata{}
+		}
+		resourceARN := ackv1alpha1.AWSResourceName(arn)
+		ko.Status.ACKResourceMetadata.ARN = &resourceARN
+	}
+{{- end }}
+	return nil
+}
+
+// sdkCreate creates the supplied resource in the backend AWS service API and
+// returns a copy of the resource with resource fields (in both Spec and
+// Status) filled in with the properties of the created resource.
+func (rm *resourceManager) sdkCreate(
+	ctx context.Context,
+	desired *resource,
+) (created *resource, err error) {
+	rlog := ackrtlog.FromContext(ctx)
+	exit := rlog.Trace("rm.sdkCreate")
+	defer exit(err)
+
+{{- if $hookCode := Hook .CRD "sdk_create_pre_build_request" }}
+{{ $hookCode }}
+{{- end }}
+	desiredState, err := rm.cloudControlProperties(desired)
+	if err != nil {
+		return nil, err
+	}
+	b, err := json.Marshal(desiredState)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := rm.sdkapi.CreateResourceWithContext(ctx, &svcsdk.CreateResourceInput{
+		TypeName:     aws.String(cloudControlTypeName),
+		DesiredState: aws.String(string(b)),
+	})
+	rm.metrics.RecordAPICall("CREATE", "CreateResource", err)
+	if err != nil {
+		return nil, err
+	}
+	event, err := rm.waitForCloudControlRequest(ctx, resp.ProgressEvent)
+	if err != nil {
+		return nil, err
+	}
+	if event.Identifier == nil {
+		return nil, fmt.Errorf("CreateResource returned no identifier for %s", cloudControlTypeName)
+	}
+	ko := desired.ko.DeepCopy()
+	if err = rm.getCloudControlResource(ctx, *event.Identifier, ko); err != nil {
+		return nil, err
+	}
+	rm.setStatusDefaults(ko)
+{{- if $hookCode := Hook .CRD "sdk_create_post_set_output" }}
+{{ $hookCode }}
+{{- end }}
+	return &resource{ko}, nil
+}
+
+// sdkUpdate patches the supplied resource in the backend AWS service API and
+// returns a new resource with updated fields.
+func (rm *resourceManager) sdkUpdate(
+	ctx context.Context,
+	desired *resource,
+	latest *resource,
+	delta *ackcompare.Delta,
+) (updated *resource, err error) {
+	rlog := ackrtlog.FromContext(ctx)
+	exit := rlog.Trace("rm.sdkUpdate")
+	defer exit(err)
+
+{{- if $hookCode := Hook .CRD "sdk_update_pre_build_request" }}
+{{ $hookCode }}
+{{- end }}
+	identifier, found := rm.cloudControlIdentifier(latest)
+	if !found {
+		return nil, ackerr.NotFound
+	}
+	desiredState, err := rm.cloudControlProperties(desired)
+	if err != nil {
+		return nil, err
+	}
+	latestState, err := rm.cloudControlProperties(latest)
+	if err != nil {
+		return nil, err
+	}
+	// Patch the top-level properties that differ, in a stable order
+	keys := []string{}
+	for key := range desiredState {
+		keys = append(keys, key)
+	}
+	for key := range latestState {
+		if _, found := desiredState[key]; !found {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+	patch := []map[string]interface{}{}
+	for _, key := range keys {
+		value, found := desiredState[key]
+		if !found {
+			patch = append(patch, map[string]interface{}{
+				"op": "remove", "path": "/" + key,
+			})
+		} else if !reflect.DeepEqual(value, latestState[key]) {
+			patch = append(patch, map[string]interface{}{
+				"op": "add", "path": "/" + key, "value": value,
+			})
+		}
+	}
+	ko := desired.ko.DeepCopy()
+	if len(patch) > 0 {
+		b, err := json.Marshal(patch)
+		if err != nil {
+			return nil, err
+		}
+		resp, err := rm.sdkapi.UpdateResourceWithContext(ctx, &svcsdk.UpdateResourceInput{
+			TypeName:      aws.String(cloudControlTypeName),
+			Identifier:    aws.String(identifier),
+			PatchDocument: aws.String(string(b)),
+		})
+		rm.metrics.RecordAPICall("UPDATE", "UpdateResource", err)
+		if err != nil {
+			return nil, err
+		}
+		if _, err = rm.waitForCloudControlRequest(ctx, resp.ProgressEvent); err != nil {
+			return nil, err
+		}
+	}
+	if err = rm.getCloudControlResource(ctx, identifier, ko); err != nil {
+		return nil, err
+	}
+	rm.setStatusDefaults(ko)
+{{- if $hookCode := Hook .CRD "sdk_update_post_set_output" }}
+{{ $hookCode }}
+{{- end }}
+	return &resource{ko}, nil
+}
+
+// sdkDelete deletes the supplied resource in the backend AWS service API
+func (rm *resourceManager) sdkDelete(
+	ctx context.Context,
+	r *resource,
+) (latest *resource, err error) {
+	rlog := ackrtlog.FromContext(ctx)
+	exit := rlog.Trace("rm.sdkDelete")
+	defer exit(err)
+
+{{- if $hookCode := Hook .CRD "sdk_delete_pre_build_request" }}
+{{ $hookCode }}
+{{- end }}
+	identifier, found := rm.cloudControlIdentifier(r)
+	if !found {
+		return nil, nil
+	}
+	resp, err := rm.sdkapi.DeleteResourceWithContext(ctx, &svcsdk.DeleteResourceInput{
+		TypeName:   aws.String(cloudControlTypeName),
+		Identifier: aws.String(identifier),
+	})
+	rm.metrics.RecordAPICall("DELETE", "DeleteResource", err)
+	if err != nil {
+		if awsErr, ok := ackerr.AWSError(err); ok && awsErr.Code() == svcsdk.ErrCodeResourceNotFoundException {
+			return nil, nil
+		}
+		return nil, err
+	}
+	_, err = rm.waitForCloudControlRequest(ctx, resp.ProgressEvent)
+	return nil, err
+}
+
+// waitForCloudControlRequest waits for the resource operation request with
+// the supplied progress to complete and returns its final progress, or an
+// error if it failed
+func (rm *resourceManager) waitForCloudControlRequest(
+	ctx context.Context,
+	event *svcsdk.ProgressEvent,
+) (*svcsdk.ProgressEvent, error) {
+	if event == nil || event.RequestToken == nil {
+		return nil, fmt.Errorf("no resource operation request returned for %s", cloudControlTypeName)
+	}
+	input := &svcsdk.GetResourceRequestStatusInput{
+		RequestToken: event.RequestToken,
+	}
+	// A failed request is reported by the status below
+	_ = rm.sdkapi.WaitUntilResourceRequestSuccessWithContext(ctx, input)
+	resp, err := rm.sdkapi.GetResourceRequestStatusWithContext(ctx, input)
+	rm.metrics.RecordAPICall("READ_ONE", "GetResourceRequestStatus", err)
+	if err != nil {
+		return nil, err
+	}
+	event = resp.ProgressEvent
+	if event == nil || event.OperationStatus == nil {
+		return nil, fmt.Errorf("no progress returned for %s request", cloudControlTypeName)
+	}
+	if *event.OperationStatus != svcsdk.OperationStatusSuccess {
+		return nil, awserr.New(
+			aws.StringValue(event.ErrorCode),
+			fmt.Sprintf(
+				"%s %s request %s: %s",
+				cloudControlTypeName,
+				aws.StringValue(event.Operation),
+				strings.ToLower(*event.OperationStatus),
+				aws.StringValue(event.StatusMessage),
+			),
+			nil,
+		)
+	}
+	return event, nil
+}
+
+// cloudControlProperties returns the properties of the resource type held
+// in the Spec of the supplied resource
+func (rm *resourceManager) cloudControlProperties(
+	r *resource,
+) (map[string]interface{}, error) {
+	b, err := json.Marshal(r.ko.Spec)
+	if err != nil {
+		return nil, err
+	}
+	spec := map[string]interface{}{}
+	if err = json.Unmarshal(b, &spec); err != nil {
+		return nil, err
+	}
+	properties, _ := renameCloudControlKeys(spec, cloudControlPropertyNames).(map[string]interface{})
+	return properties, nil
+}
+
+// cloudControlFieldKeys returns the JSON keys of the resource's fields keyed
+// by the names of the properties they hold, when they differ
+func cloudControlFieldKeys() map[string]string {
+	keys := map[string]string{}
+	for key, property := range cloudControlPropertyNames {
+		keys[property] = key
+	}
+	return keys
+}
+
+// renameCloudControlKeys returns a copy of the supplied JSON value with the
+// keys of its objects renamed with the supplied names
+func renameCloudControlKeys(
+	value interface{},
+	names map[string]string,
+) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		renamed := map[string]interface{}{}
+		for key, elem := range v {
+			if name, found := names[key]; found {
+				key = name
+			}
+			renamed[key] = renameCloudControlKeys(elem, names)
+		}
+		return renamed
+	case []interface{}:
+		renamed := make([]interface{}, len(v))
+		for i, elem := range v {
+			renamed[i] = renameCloudControlKeys(elem, names)
+		}
+		return renamed
+	}
+	return value
+}
+{{- end -}}
@@ -25,11 +25,35 @@ func (rm *resourceManager) sdkFind(
 {{ $hookCode }}
 {{- end }}
 	var resp {{ .CRD.GetOutputShapeGoType .CRD.Ops.ReadMany }}
+{{- if $pagination := .CRD.ReadManyPagination }}
+	// Read the pages of results, up to {{ $pagination.MaxPages }}, and gather their elements
+	for page := 0; page < {{ $pagination.MaxPages }}; page++ {
+		var pageResp {{ .CRD.GetOutputShapeGoType .CRD.Ops.ReadMany }}
+		pageResp, err = rm.sdkapi.{{ .CRD.Ops.ReadMany.ExportedName }}WithContext(ctx, input)
+		rm.metrics.RecordAPICall("READ_MANY", "{{ .CRD.Ops.ReadMany.ExportedName }}", err)
+		if err != nil {
+			break
+		}
+		if resp == nil {
+			resp = pageResp
+		} else {
+			resp.{{ $pagination.ResultMember }} = append(resp.{{ $pagination.ResultMember }}, pageResp.{{ $pagination.ResultMember }}...)
+		}
+		if pageResp.{{ $pagination.OutputToken }} == nil || *pageResp.{{ $pagination.OutputToken }} == "" {
+			break
+		}
+		input.{{ $pagination.InputToken }} = pageResp.{{ $pagination.OutputToken }}
+	}
+{{- if $hookCode := Hook .CRD "sdk_read_many_post_request" }}
+{{ $hookCode }}
+{{- end }}
+{{- else }}
 	resp, err = rm.sdkapi.{{ .CRD.Ops.ReadMany.ExportedName }}WithContext(ctx, input)
 {{- if $hookCode := Hook .CRD "sdk_read_many_post_request" }}
 {{ $hookCode }}
 {{- end }}
 	rm.metrics.RecordAPICall("READ_MANY", "{{ .CRD.Ops.ReadMany.ExportedName }}", err)
+{{- end }}
 	if err != nil {
 		if awsErr, ok := ackerr.AWSError(err); ok && awsErr.Code() == "{{ ResourceExceptionCode .CRD 404 }}" {{ GoCodeSetExceptionMessageCheck .CRD 404 }}{
 			return nil, ackerr.NotFound
@@ -0,0 +1,37 @@
+{{- define "sdk_observe_only" -}}
+// errObserveOnly is returned when the resource would need to be created,
+// updated or deleted in the backend AWS service API, since the controller
+// only observes it
+var errObserveOnly = fmt.Errorf(
+	"{{ .CRD.Names.Camel }} resources are observe-only and can't be created, updated or deleted",
+)
+
+// sdkCreate returns an error since the resource is observe-only and must be
+// created outside of the controller
+func (rm *resourceManager) sdkCreate(
+	ctx context.Context,
+	desired *resource,
+) (*resource, error) {
+	return nil, errObserveOnly
+}
+
+// sdkUpdate returns an error since the resource is observe-only and its
+// Spec can't differ from its state in the backend AWS service API
+func (rm *resourceManager) sdkUpdate(
+	ctx context.Context,
+	desired *resource,
+	latest *resource,
+	delta *ackcompare.Delta,
+) (*resource, error) {
+	return nil, errObserveOnly
+}
+
+// sdkDelete leaves the resource in the backend AWS service API since the
+// resource is observe-only, so only the CR is deleted
+func (rm *resourceManager) sdkDelete(
+	ctx context.Context,
+	r *resource,
+) (latest *resource, err error) {
+	return nil, nil
+}
+{{- end -}}
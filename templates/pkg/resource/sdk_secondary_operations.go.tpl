@@ -0,0 +1,54 @@
+{{- define "sdk_create_secondary_operations" -}}
+{{- range $op := .CRD.SecondaryOps }}
+	if {{ range $i, $field := $op.Fields }}{{ if $i }} || {{ end }}ko.Spec.{{ $field.Names.Camel }} != nil{{ end }} {
+		if err := rm.sync{{ $op.ExportedName }}(ctx, &resource{ko}); err != nil {
+			return nil, err
+		}
+	}
+{{- end }}
+{{- end -}}
+
+{{- define "sdk_update_secondary_operations" -}}
+{{- range $op := .CRD.SecondaryOps }}
+	if {{ range $i, $field := $op.Fields }}{{ if $i }} || {{ end }}delta.DifferentAt("Spec.{{ $field.Names.Camel }}"){{ end }} {
+		if err := rm.sync{{ $op.ExportedName }}(ctx, desired); err != nil {
+			return nil, err
+		}
+	}
+{{- end }}
+{{- end -}}
+
+{{- define "sdk_secondary_operations" -}}
+{{- $CRD := .CRD }}
+{{- range $op := .CRD.SecondaryOps }}
+// sync{{ $op.ExportedName }} calls the {{ $op.ExportedName }} API with the
+// fields of the supplied resource it configures
+func (rm *resourceManager) sync{{ $op.ExportedName }}(
+	ctx context.Context,
+	r *resource,
+) (err error) {
+	rlog := ackrtlog.FromContext(ctx)
+	exit := rlog.Trace("rm.sync{{ $op.ExportedName }}")
+	defer exit(err)
+
+	input, err := rm.new{{ $op.ExportedName }}RequestPayload(r)
+	if err != nil {
+		return err
+	}
+	_, err = rm.sdkapi.{{ $op.ExportedName }}WithContext(ctx, input)
+	rm.metrics.RecordAPICall("UPDATE", "{{ $op.ExportedName }}", err)
+	return err
+}
+
+// new{{ $op.ExportedName }}RequestPayload returns an SDK-specific struct for
+// the HTTP request payload of the {{ $op.ExportedName }} API call for the
+// resource
+func (rm *resourceManager) new{{ $op.ExportedName }}RequestPayload(
+	r *resource,
+) (*svcsdk.{{ $op.InputRef.Shape.ShapeName }}, error) {
+	res := &svcsdk.{{ $op.InputRef.Shape.ShapeName }}{}
+{{ GoCodeSetSecondaryOperationInput $CRD $op "r.ko" "res" 1 }}
+	return res, nil
+}
+{{ end }}
+{{- end -}}
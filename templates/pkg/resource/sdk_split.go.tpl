@@ -0,0 +1,30 @@
+{{- define "sdk_merge_split_update" -}}
+{{- if $mergeCode := GoCodeMergeSplitUpdateInput .CRD "resp" "input" 1 -}}
+// mergeSplitUpdatePayload sets the members of the supplied Update API call
+// payload owned by the other resources split from {{ .CRD.SplitFrom.Names.Original }}
+// to their current values in the backend AWS service API, so that the call
+// doesn't reset them
+func (rm *resourceManager) mergeSplitUpdatePayload(
+	ctx context.Context,
+	r *resource,
+	input *svcsdk.{{ .CRD.Ops.Update.InputRef.Shape.ShapeName }},
+) (err error) {
+	rlog := ackrtlog.FromContext(ctx)
+	exit := rlog.Trace("rm.mergeSplitUpdatePayload")
+	defer exit(err)
+
+	readInput, err := rm.newDescribeRequestPayload(r)
+	if err != nil {
+		return err
+	}
+	var resp {{ .CRD.GetOutputShapeGoType .CRD.Ops.ReadOne }}
+	resp, err = rm.sdkapi.{{ .CRD.Ops.ReadOne.ExportedName }}WithContext(ctx, readInput)
+	rm.metrics.RecordAPICall("READ_ONE", "{{ .CRD.Ops.ReadOne.ExportedName }}", err)
+	if err != nil {
+		return err
+	}
+{{ $mergeCode }}
+	return nil
+}
+{{- end }}
+{{- end -}}
@@ -0,0 +1,74 @@
+{{- define "sdk_update_tags" -}}
+	if delta.DifferentAt("Spec.Tags") {
+		if err := rm.syncTags(ctx, desired, latest); err != nil {
+			return nil, err
+		}
+	}
+{{- end -}}
+
+{{- define "sdk_sync_tags" -}}
+// syncTags adds the tags of the desired resource that are missing or have a
+// different value in the backend AWS service API and removes the tags that
+// aren't desired anymore
+func (rm *resourceManager) syncTags(
+	ctx context.Context,
+	desired *resource,
+	latest *resource,
+) (err error) {
+	rlog := ackrtlog.FromContext(ctx)
+	exit := rlog.Trace("rm.syncTags")
+	defer exit(err)
+
+	if latest.ko.Status.ACKResourceMetadata == nil || latest.ko.Status.ACKResourceMetadata.ARN == nil {
+		return ackerr.NotFound
+	}
+	arn := string(*latest.ko.Status.ACKResourceMetadata.ARN)
+
+	listInput := &svcsdk.{{ .CRD.TaggingOps.List.InputRef.Shape.ShapeName }}{}
+	listInput.Set{{ .CRD.TaggingOps.List.ARNMemberName }}(arn)
+	listResp, err := rm.sdkapi.{{ .CRD.TaggingOps.List.ExportedName }}WithContext(ctx, listInput)
+	rm.metrics.RecordAPICall("READ_MANY", "{{ .CRD.TaggingOps.List.ExportedName }}", err)
+	if err != nil {
+		return err
+	}
+	latestTags := map[string]*string{}
+{{ GoCodeListTagsOutputToMap .CRD "listResp" "latestTags" 1 -}}
+	desiredTags := map[string]*string{}
+{{ GoCodeCRTagsToMap .CRD "desired.ko.Spec.Tags" "desiredTags" 1 -}}
+
+	toRemove := []*string{}
+	for key := range latestTags {
+		if _, ok := desiredTags[key]; !ok {
+			toRemove = append(toRemove, aws.String(key))
+		}
+	}
+	toAdd := map[string]*string{}
+	for key, val := range desiredTags {
+		if latestVal, ok := latestTags[key]; !ok || aws.StringValue(latestVal) != aws.StringValue(val) {
+			toAdd[key] = val
+		}
+	}
+
+	if len(toRemove) > 0 {
+		untagInput := &svcsdk.{{ .CRD.TaggingOps.Untag.InputRef.Shape.ShapeName }}{}
+		untagInput.Set{{ .CRD.TaggingOps.Untag.ARNMemberName }}(arn)
+		untagInput.Set{{ .CRD.TaggingOps.Untag.TagsMemberName }}(toRemove)
+		_, err = rm.sdkapi.{{ .CRD.TaggingOps.Untag.ExportedName }}WithContext(ctx, untagInput)
+		rm.metrics.RecordAPICall("UPDATE", "{{ .CRD.TaggingOps.Untag.ExportedName }}", err)
+		if err != nil {
+			return err
+		}
+	}
+	if len(toAdd) > 0 {
+		tagInput := &svcsdk.{{ .CRD.TaggingOps.Tag.InputRef.Shape.ShapeName }}{}
+		tagInput.Set{{ .CRD.TaggingOps.Tag.ARNMemberName }}(arn)
+{{ GoCodeSetTagResourceInputTags .CRD "toAdd" "tagInput" 2 -}}
+		_, err = rm.sdkapi.{{ .CRD.TaggingOps.Tag.ExportedName }}WithContext(ctx, tagInput)
+		rm.metrics.RecordAPICall("UPDATE", "{{ .CRD.TaggingOps.Tag.ExportedName }}", err)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+{{- end -}}
@@ -12,6 +12,12 @@ func (rm *resourceManager) sdkUpdate(
 {{- if $hookCode := Hook .CRD "sdk_update_pre_build_request" }}
 {{ $hookCode }}
 {{- end }}
+{{- if .CRD.TaggingOps }}
+{{ template "sdk_update_tags" . }}
+{{- end }}
+{{- if .CRD.SecondaryOps }}
+{{- template "sdk_update_secondary_operations" . }}
+{{- end }}
 {{- if $customMethod := .CRD.GetCustomImplementation .CRD.Ops.Update }}
 	updated, err = rm.{{ $customMethod }}(ctx, desired, latest, delta)
 	if updated != nil || err != nil {
@@ -22,6 +28,11 @@ func (rm *resourceManager) sdkUpdate(
 	if err != nil {
 		return nil, err
 	}
+{{- if and .CRD.SplitFrom .CRD.Ops.ReadOne (GoCodeMergeSplitUpdateInput .CRD "resp" "input" 1) }}
+	if err = rm.mergeSplitUpdatePayload(ctx, desired, input); err != nil {
+		return nil, err
+	}
+{{- end }}
 {{- if $hookCode := Hook .CRD "sdk_update_post_build_request" }}
 {{ $hookCode }} 
 {{- end }}
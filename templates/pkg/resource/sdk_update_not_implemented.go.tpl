@@ -5,6 +5,30 @@ func (rm *resourceManager) sdkUpdate(
 	latest *resource,
 	delta *ackcompare.Delta,
 ) (*resource, error) {
+{{- if .CRD.SecondaryOps }}
+	// Only the tags of the resource and the fields configured by its
+	// secondary operations can be updated
+{{- if .CRD.TaggingOps }}
+{{ template "sdk_update_tags" . }}
+{{- end }}
+{{- template "sdk_update_secondary_operations" . }}
+	updatable := true
+	for _, diff := range delta.Differences {
+		if {{ if .CRD.TaggingOps }}!diff.Path.Contains("Spec.Tags") && {{ end }}
+{{- range $i, $op := .CRD.SecondaryOps }}{{ range $j, $field := $op.Fields }}{{ if or $i $j }} && {{ end }}!diff.Path.Contains("Spec.{{ $field.Names.Camel }}"){{ end }}{{ end }} {
+			updatable = false
+		}
+	}
+	if updatable {
+		return desired, nil
+	}
+{{- else if .CRD.TaggingOps }}
+	// Only the tags of the resource can be updated
+{{ template "sdk_update_tags" . }}
+	if delta.DifferentAt("Spec.Tags") && len(delta.Differences) == 1 {
+		return desired, nil
+	}
+{{- end }}
 	// TODO(jaypipes): Figure this out...
 	return nil, ackerr.NotImplemented
 }
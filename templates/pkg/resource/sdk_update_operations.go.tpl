@@ -0,0 +1,75 @@
+{{- define "sdk_update_operations" -}}
+func (rm *resourceManager) sdkUpdate(
+	ctx context.Context,
+	desired *resource,
+	latest *resource,
+	delta *ackcompare.Delta,
+) (updated *resource, err error) {
+	rlog := ackrtlog.FromContext(ctx)
+	exit := rlog.Trace("rm.sdkUpdate")
+	defer exit(err)
+
+{{- if $hookCode := Hook .CRD "sdk_update_pre_build_request" }}
+{{ $hookCode }}
+{{- end }}
+{{- if .CRD.TaggingOps }}
+{{ template "sdk_update_tags" . }}
+{{- end }}
+	// Only call the operations updating the fields that changed, in order
+{{- range $op := .CRD.UpdateOps }}
+	if {{ range $i, $field := $op.Fields }}{{ if $i }} || {{ end }}delta.DifferentAt("Spec.{{ $field.Names.Camel }}"){{ end }} {
+		if err = rm.update{{ $op.ExportedName }}(ctx, desired); err != nil {
+			return nil, err
+		}
+	}
+{{- end }}
+{{- if .CRD.SecondaryOps }}
+{{- template "sdk_update_secondary_operations" . }}
+{{- end }}
+{{- if .CRD.HasImmutableFieldChanges }}
+	desired = rm.handleImmutableFieldsChangedCondition(desired, delta)
+{{- end }}
+
+	// The update operations' responses aren't merged into the resource. The
+	// latest state of the resource is read on the next reconciliation.
+	ko := desired.ko.DeepCopy()
+	rm.setStatusDefaults(ko)
+{{- if $hookCode := Hook .CRD "sdk_update_post_set_output" }}
+{{ $hookCode }}
+{{- end }}
+	return &resource{ko}, nil
+}
+{{- $CRD := .CRD }}
+{{- range $op := .CRD.UpdateOps }}
+
+// update{{ $op.ExportedName }} calls the {{ $op.ExportedName }} API to
+// update the fields of the supplied resource it manages
+func (rm *resourceManager) update{{ $op.ExportedName }}(
+	ctx context.Context,
+	r *resource,
+) (err error) {
+	rlog := ackrtlog.FromContext(ctx)
+	exit := rlog.Trace("rm.update{{ $op.ExportedName }}")
+	defer exit(err)
+
+	input, err := rm.new{{ $op.ExportedName }}RequestPayload(r)
+	if err != nil {
+		return err
+	}
+	_, err = rm.sdkapi.{{ $op.ExportedName }}WithContext(ctx, input)
+	rm.metrics.RecordAPICall("UPDATE", "{{ $op.ExportedName }}", err)
+	return err
+}
+
+// new{{ $op.ExportedName }}RequestPayload returns an SDK-specific struct for
+// the HTTP request payload of the {{ $op.ExportedName }} API call for the
+// resource
+func (rm *resourceManager) new{{ $op.ExportedName }}RequestPayload(
+	r *resource,
+) (*svcsdk.{{ $op.InputRef.Shape.ShapeName }}, error) {
+	res := &svcsdk.{{ $op.InputRef.Shape.ShapeName }}{}
+{{ GoCodeSetUpdateOperationInput $CRD $op "r.ko" "res" 1 }}
+	return res, nil
+}
+{{- end }}
+{{- end -}}
@@ -11,6 +11,12 @@ func (rm *resourceManager) sdkUpdate(
 	if rm.requiredFieldsMissingFromSetAttributesInput(desired) {
 		panic("Required field in SetAttributes input shape missing!")
 	}
+{{- if .CRD.TaggingOps }}
+{{ template "sdk_update_tags" . }}
+{{- end }}
+{{- if .CRD.SecondaryOps }}
+{{- template "sdk_update_secondary_operations" . }}
+{{- end }}
 
 	input, err := rm.newSetAttributesRequestPayload(desired)
 	if err != nil {
@@ -0,0 +1,34 @@
+{{- define "sdk_update_upsert" -}}
+func (rm *resourceManager) sdkUpdate(
+	ctx context.Context,
+	desired *resource,
+	latest *resource,
+	delta *ackcompare.Delta,
+) (updated *resource, err error) {
+	rlog := ackrtlog.FromContext(ctx)
+	exit := rlog.Trace("rm.sdkUpdate")
+	defer exit(err)
+
+{{- if $hookCode := Hook .CRD "sdk_update_pre_build_request" }}
+{{ $hookCode }}
+{{- end }}
+{{- if .CRD.TaggingOps }}
+{{ template "sdk_update_tags" . }}
+{{- end }}
+{{- if .CRD.HasImmutableFieldChanges }}
+	desired = rm.handleImmutableFieldsChangedCondition(desired, delta)
+{{- end }}
+	// The {{ .CRD.Ops.Create.ExportedName }} API creates the resource or
+	// replaces the existing one, so the resource is updated by putting its
+	// desired state again
+	updated, err = rm.sdkCreate(ctx, desired)
+	if err != nil {
+		return nil, err
+	}
+	ko := updated.ko
+{{- if $hookCode := Hook .CRD "sdk_update_post_set_output" }}
+{{ $hookCode }}
+{{- end }}
+	return &resource{ko}, nil
+}
+{{- end -}}